@@ -0,0 +1,97 @@
+// Command migrate shows, applies and reverts the database migrations. The bot applies
+// pending migrations itself at startup; this is for checking a database and rolling back.
+//
+//	go run ./cmd/migrate status
+//	go run ./cmd/migrate -db dev.db down -steps 1
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/database/migrations"
+)
+
+func main() {
+	path := flag.String("db", "", "SQLite file to migrate (default: the bot's database from DB_TYPE)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: migrate [-db file] status | up | down [-steps n]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("⚠️ .env file not found, reading from environment variables")
+	}
+
+	conn, driver, err := database.Connect(*path)
+	if err != nil {
+		log.Fatalf("Database connection failed: %v", err)
+	}
+	defer conn.Close()
+
+	migrator, err := migrations.New(conn, driver)
+	if err != nil {
+		log.Fatalf("Loading migrations failed: %v", err)
+	}
+
+	ctx := context.Background()
+	switch flag.Arg(0) {
+	case "status", "":
+		err = status(ctx, migrator)
+	case "up":
+		var applied []migrations.Migration
+		applied, err = migrator.Up(ctx)
+		report("Applied", applied)
+	case "down":
+		downFlags := flag.NewFlagSet("down", flag.ExitOnError)
+		steps := downFlags.Int("steps", 1, "number of migrations to revert")
+		downFlags.Parse(flag.Args()[1:])
+
+		var reverted []migrations.Migration
+		reverted, err = migrator.Down(ctx, *steps)
+		report("Reverted", reverted)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+}
+
+// status prints the schema version and the migrations not applied yet
+func status(ctx context.Context, migrator *migrations.Migrator) error {
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🗄️ Schema version %d, %d pending", version, len(pending))
+	for _, migration := range pending {
+		log.Printf("   ⏳ %04d_%s", migration.Version, migration.Name)
+	}
+	return nil
+}
+
+// report logs the migrations a command applied or reverted
+func report(action string, done []migrations.Migration) {
+	if len(done) == 0 {
+		log.Printf("Nothing to do")
+	}
+	for _, migration := range done {
+		log.Printf("✅ %s %04d_%s", action, migration.Version, migration.Name)
+	}
+}
@@ -0,0 +1,63 @@
+// Command seed fills a development database with fixture teams, members, projects, tasks,
+// activity and analyses. The same -seed always produces the same data.
+//
+//	go run ./cmd/seed -db dev.db -seed 42
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/fixtures"
+)
+
+func main() {
+	path := flag.String("db", "", "SQLite file to fill (default: the bot's database from DB_TYPE)")
+	seed := flag.Int64("seed", 1, "random seed, the same seed produces the same data")
+	teams := flag.Int("teams", 3, "number of teams")
+	projects := flag.Int("projects", 2, "projects per team")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("⚠️ .env file not found, reading from environment variables")
+	}
+
+	db, err := openDatabase(*path)
+	if err != nil {
+		log.Fatalf("Database initialization failed: %v", err)
+	}
+	defer db.Close()
+
+	// Dates count from the start of today so repeated runs on the same day match
+	now := time.Now().Truncate(24 * time.Hour)
+	summary, err := fixtures.Seed(context.Background(), db, fixtures.Options{
+		Seed:            *seed,
+		Teams:           *teams,
+		ProjectsPerTeam: *projects,
+		Now:             now,
+	})
+	if err != nil {
+		log.Fatalf("Seeding failed, nothing was written: %v", err)
+	}
+
+	log.Printf("🌱 Seeded %d teams, %d members, %d projects, %d tasks, %d activities and %d analyses (seed %d)",
+		summary.Teams, summary.Members, summary.Projects, summary.Tasks, summary.Activities, summary.Analyses, *seed)
+	log.Printf("💬 Team chat IDs: %d down to %d", fixtures.ChatID(0), fixtures.ChatID(*teams-1))
+}
+
+// openDatabase opens the SQLite file at path, or the bot's own database when path is empty
+func openDatabase(path string) (*database.DB, error) {
+	if path != "" {
+		return database.NewSQLiteDB(path)
+	}
+	if os.Getenv("DB_TYPE") == "postgres" {
+		return database.NewPostgresDB()
+	}
+	return database.NewDB()
+}
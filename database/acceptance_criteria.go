@@ -0,0 +1,61 @@
+package database
+
+import (
+    "fmt"
+    "strings"
+)
+
+// SetTaskAcceptanceCriteria replaces the acceptance criteria of a task
+func (db *DB) SetTaskAcceptanceCriteria(taskID string, criteria []string) error {
+    placeholders := db.getPlaceholders(3)
+    deleteQuery := fmt.Sprintf("DELETE FROM task_acceptance_criteria WHERE task_id = %s", placeholders[0])
+    if _, err := db.conn.Exec(deleteQuery, taskID); err != nil {
+        return fmt.Errorf("qabul mezonlarini o'chirishda xatolik: %w", err)
+    }
+
+    insertQuery := fmt.Sprintf(`
+    INSERT INTO task_acceptance_criteria (task_id, position, criterion)
+    VALUES (%s, %s, %s)`, placeholders[0], placeholders[1], placeholders[2])
+
+    position := 0
+    for _, criterion := range criteria {
+        criterion = strings.TrimSpace(criterion)
+        if criterion == "" {
+            continue
+        }
+        if _, err := db.conn.Exec(insertQuery, taskID, position, criterion); err != nil {
+            return fmt.Errorf("qabul mezonini saqlashda xatolik: %w", err)
+        }
+        position++
+    }
+
+    return nil
+}
+
+// GetProjectAcceptanceCriteria returns the acceptance criteria of a project's tasks by task ID
+func (db *DB) GetProjectAcceptanceCriteria(projectID string) (map[string][]string, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT c.task_id, c.criterion
+    FROM task_acceptance_criteria c
+    JOIN tasks t ON t.id = c.task_id
+    WHERE t.project_id = %s
+    ORDER BY c.task_id, c.position`, placeholders[0])
+
+    rows, err := db.conn.Query(query, projectID)
+    if err != nil {
+        return nil, fmt.Errorf("qabul mezonlarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    criteria := make(map[string][]string)
+    for rows.Next() {
+        var taskID, criterion string
+        if err := rows.Scan(&taskID, &criterion); err != nil {
+            return nil, fmt.Errorf("qabul mezonini o'qishda xatolik: %w", err)
+        }
+        criteria[taskID] = append(criteria[taskID], criterion)
+    }
+
+    return criteria, nil
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+)
+
+// GetAIUsage returns how many AI analyses a team has run in a period (e.g. "2026-10")
+func (db *DB) GetAIUsage(teamID, period string) (int, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf("SELECT analyses FROM ai_usage WHERE team_id = %s AND period = %s",
+        placeholders[0], placeholders[1])
+
+    var analyses int
+    err := db.conn.QueryRow(query, teamID, period).Scan(&analyses)
+    if err == sql.ErrNoRows {
+        return 0, nil
+    }
+    if err != nil {
+        return 0, fmt.Errorf("AI foydalanishini olishda xatolik: %w", err)
+    }
+
+    return analyses, nil
+}
+
+// IncrementAIUsage records one AI analysis for a team in a period
+func (db *DB) IncrementAIUsage(teamID, period string) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    INSERT INTO ai_usage (team_id, period, analyses)
+    VALUES (%s, %s, 1)
+    ON CONFLICT(team_id, period) DO UPDATE SET
+        analyses = ai_usage.analyses + 1,
+        updated_at = CURRENT_TIMESTAMP`,
+        placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, teamID, period); err != nil {
+        return fmt.Errorf("AI foydalanishini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+    "fmt"
+    "time"
+)
+
+// AnalysisRun is a recorded /analyze run with the breakdown it produced
+type AnalysisRun struct {
+    ID              int64     `json:"id"`
+    ChatID          int64     `json:"chat_id"`
+    UserID          int64     `json:"user_id"`
+    RequirementHash string    `json:"requirement_hash"`
+    Requirement     string    `json:"requirement"`
+    Provider        string    `json:"provider"`
+    Model           string    `json:"model"`
+    TokensUsed      int       `json:"tokens_used"`
+    TotalEstimate   float64   `json:"total_estimate"`
+    TaskCount       int       `json:"task_count"`
+    Result          string    `json:"result"` // JSON encoded breakdown
+    Saved           bool      `json:"saved"`
+    ProjectID       string    `json:"project_id"`
+    CreatedAt       time.Time `json:"created_at"`
+}
+
+// SaveAnalysisRun records an analysis run and sets its ID
+func (db *DB) SaveAnalysisRun(run *AnalysisRun) error {
+    if run.CreatedAt.IsZero() {
+        run.CreatedAt = time.Now()
+    }
+
+    placeholders := db.getPlaceholders(11)
+    query := fmt.Sprintf(`
+    INSERT INTO analysis_runs (chat_id, user_id, requirement_hash, requirement, provider, model, tokens_used,
+        total_estimate, task_count, result, created_at)
+    VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+    RETURNING id`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3], placeholders[4],
+        placeholders[5], placeholders[6], placeholders[7], placeholders[8], placeholders[9], placeholders[10])
+
+    err := db.conn.QueryRow(query, run.ChatID, run.UserID, run.RequirementHash, run.Requirement, run.Provider,
+        run.Model, run.TokensUsed, run.TotalEstimate, run.TaskCount, run.Result,
+        run.CreatedAt.UTC().Truncate(time.Second)).Scan(&run.ID)
+    if err != nil {
+        return fmt.Errorf("tahlilni saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// GetAnalysisRuns returns the most recent analysis runs of a chat
+func (db *DB) GetAnalysisRuns(chatID int64, limit int) ([]AnalysisRun, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT id, chat_id, user_id, requirement_hash, requirement, provider, model, tokens_used,
+        total_estimate, task_count, result, saved, project_id, created_at
+    FROM analysis_runs
+    WHERE chat_id = %s
+    ORDER BY created_at DESC, id DESC
+    LIMIT %s`, placeholders[0], placeholders[1])
+
+    rows, err := db.conn.Query(query, chatID, limit)
+    if err != nil {
+        return nil, fmt.Errorf("tahlillarni olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var runs []AnalysisRun
+    for rows.Next() {
+        var run AnalysisRun
+        if err := rows.Scan(&run.ID, &run.ChatID, &run.UserID, &run.RequirementHash, &run.Requirement,
+            &run.Provider, &run.Model, &run.TokensUsed, &run.TotalEstimate, &run.TaskCount, &run.Result, &run.Saved,
+            &run.ProjectID, &run.CreatedAt); err != nil {
+            return nil, fmt.Errorf("tahlilni o'qishda xatolik: %w", err)
+        }
+        runs = append(runs, run)
+    }
+
+    return runs, nil
+}
+
+// GetAnalysisRun returns an analysis run of a chat by ID
+func (db *DB) GetAnalysisRun(chatID, id int64) (*AnalysisRun, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT id, chat_id, user_id, requirement_hash, requirement, provider, model, tokens_used,
+        total_estimate, task_count, result, saved, project_id, created_at
+    FROM analysis_runs
+    WHERE chat_id = %s AND id = %s`, placeholders[0], placeholders[1])
+
+    var run AnalysisRun
+    err := db.conn.QueryRow(query, chatID, id).Scan(&run.ID, &run.ChatID, &run.UserID, &run.RequirementHash,
+        &run.Requirement, &run.Provider, &run.Model, &run.TokensUsed, &run.TotalEstimate, &run.TaskCount, &run.Result,
+        &run.Saved, &run.ProjectID, &run.CreatedAt)
+    if err != nil {
+        return nil, fmt.Errorf("tahlilni olishda xatolik: %w", err)
+    }
+
+    return &run, nil
+}
+
+// MarkAnalysisRunSaved records that the tasks of an analysis run were saved to a project
+func (db *DB) MarkAnalysisRunSaved(id int64, projectID string) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`UPDATE analysis_runs SET saved = TRUE, project_id = %s WHERE id = %s`,
+        placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, projectID, id); err != nil {
+        return fmt.Errorf("tahlil holatini yangilashda xatolik: %w", err)
+    }
+
+    return nil
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+    "fmt"
+    "time"
+)
+
+// Audit actions recorded by task escalation
+const (
+    AuditEscalationReminder = "escalation_reminder"
+    AuditEscalationLead     = "escalation_lead"
+    AuditEscalationRule     = "escalation_rule"
+)
+
+// AuditTaskDependencyOverride records a status change that skipped the dependency check
+const AuditTaskDependencyOverride = "task_dependency_override"
+
+// AuditTaskCommitStart records a task moved to in progress by its first linked commit
+const AuditTaskCommitStart = "task_commit_start"
+
+// AuditDeployment records a deployment and the tasks it released
+const AuditDeployment = "deployment"
+
+// Audit actions recorded by project share links
+const (
+    AuditProjectShareCreated = "project_share_created"
+    AuditProjectShareRevoked = "project_share_revoked"
+)
+
+// AuditEntry is a recorded team action such as a task escalation
+type AuditEntry struct {
+    ID        int64     `json:"id"`
+    ChatID    int64     `json:"chat_id"`
+    Actor     string    `json:"actor"`
+    Action    string    `json:"action"`
+    Subject   string    `json:"subject"`
+    Details   string    `json:"details"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// LogAudit appends an entry to the audit trail
+func (db *DB) LogAudit(entry *AuditEntry) error {
+    if entry.CreatedAt.IsZero() {
+        entry.CreatedAt = time.Now()
+    }
+
+    placeholders := db.getPlaceholders(6)
+    query := fmt.Sprintf(`
+    INSERT INTO audit_log (chat_id, actor, action, subject, details, created_at)
+    VALUES (%s, %s, %s, %s, %s, %s)
+    RETURNING id`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3], placeholders[4], placeholders[5])
+
+    err := db.conn.QueryRow(query, entry.ChatID, entry.Actor, entry.Action, entry.Subject, entry.Details,
+        entry.CreatedAt.UTC().Truncate(time.Second)).Scan(&entry.ID)
+    if err != nil {
+        return fmt.Errorf("audit yozuvini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// GetAuditLog returns the most recent audit entries of a chat, optionally filtered by action prefix
+func (db *DB) GetAuditLog(chatID int64, actionPrefix string, limit int) ([]AuditEntry, error) {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf(`
+    SELECT id, chat_id, actor, action, subject, details, created_at
+    FROM audit_log
+    WHERE chat_id = %s AND action LIKE %s
+    ORDER BY created_at DESC, id DESC
+    LIMIT %s`, placeholders[0], placeholders[1], placeholders[2])
+
+    rows, err := db.conn.Query(query, chatID, actionPrefix+"%", limit)
+    if err != nil {
+        return nil, fmt.Errorf("audit jurnalini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var entries []AuditEntry
+    for rows.Next() {
+        var entry AuditEntry
+        if err := rows.Scan(&entry.ID, &entry.ChatID, &entry.Actor, &entry.Action, &entry.Subject,
+            &entry.Details, &entry.CreatedAt); err != nil {
+            return nil, fmt.Errorf("audit yozuvini o'qishda xatolik: %w", err)
+        }
+        entries = append(entries, entry)
+    }
+
+    return entries, nil
+}
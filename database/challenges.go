@@ -0,0 +1,49 @@
+package database
+
+import (
+    "fmt"
+)
+
+// RecordChallengeCompletion records that a user solved the challenge of a day. It reports
+// false when they had already solved that day's challenge.
+func (db *DB) RecordChallengeCompletion(telegramID int64, day, challengeID string) (bool, error) {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf(`
+    INSERT INTO challenge_completions (telegram_id, day, challenge_id)
+    VALUES (%s, %s, %s)
+    ON CONFLICT(telegram_id, day) DO NOTHING`,
+        placeholders[0], placeholders[1], placeholders[2])
+
+    result, err := db.conn.Exec(query, telegramID, day, challengeID)
+    if err != nil {
+        return false, fmt.Errorf("kunlik masala yechimini saqlashda xatolik: %w", err)
+    }
+    if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+        return false, nil
+    }
+
+    return true, nil
+}
+
+// GetChallengeDays returns the days on which a user solved the daily challenge, newest first
+func (db *DB) GetChallengeDays(telegramID int64) ([]string, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("SELECT day FROM challenge_completions WHERE telegram_id = %s ORDER BY day DESC", placeholders[0])
+
+    rows, err := db.conn.Query(query, telegramID)
+    if err != nil {
+        return nil, fmt.Errorf("kunlik masala yechimlarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var days []string
+    for rows.Next() {
+        var day string
+        if err := rows.Scan(&day); err != nil {
+            return nil, fmt.Errorf("kunlik masala yechimini o'qishda xatolik: %w", err)
+        }
+        days = append(days, day)
+    }
+
+    return days, nil
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+    "fmt"
+)
+
+// GetChatSettings returns all stored settings for a chat as key/value pairs
+func (db *DB) GetChatSettings(chatID int64) (map[string]string, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("SELECT key, value FROM chat_settings WHERE chat_id = %s", placeholders[0])
+
+    rows, err := db.conn.Query(query, chatID)
+    if err != nil {
+        return nil, fmt.Errorf("chat sozlamalarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    settings := make(map[string]string)
+    for rows.Next() {
+        var key, value string
+        if err := rows.Scan(&key, &value); err != nil {
+            return nil, fmt.Errorf("chat sozlamasini o'qishda xatolik: %w", err)
+        }
+        settings[key] = value
+    }
+
+    return settings, nil
+}
+
+// SetChatSetting creates or updates a single chat setting
+func (db *DB) SetChatSetting(chatID int64, key, value string) error {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf(`
+    INSERT INTO chat_settings (chat_id, key, value)
+    VALUES (%s, %s, %s)
+    ON CONFLICT(chat_id, key) DO UPDATE SET
+        value = EXCLUDED.value,
+        updated_at = CURRENT_TIMESTAMP`,
+        placeholders[0], placeholders[1], placeholders[2])
+
+    _, err := db.conn.Exec(query, chatID, key, value)
+    if err != nil {
+        return fmt.Errorf("chat sozlamasini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// DeleteChatSetting removes a single chat setting
+func (db *DB) DeleteChatSetting(chatID int64, key string) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf("DELETE FROM chat_settings WHERE chat_id = %s AND key = %s", placeholders[0], placeholders[1])
+
+    _, err := db.conn.Exec(query, chatID, key)
+    if err != nil {
+        return fmt.Errorf("chat sozlamasini o'chirishda xatolik: %w", err)
+    }
+
+    return nil
+}
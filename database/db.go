@@ -1,6 +1,7 @@
 package database
 
 import (
+    "context"
     "database/sql"
     "fmt"
     "log"
@@ -46,6 +47,13 @@ type Task struct {
     CreatedAt     time.Time  `json:"created_at"`
     UpdatedAt     time.Time  `json:"updated_at"`
     CompletedAt   *time.Time `json:"completed_at"`
+    // Optimistic and pessimistic bounds around EstimateHours, stored in task_estimate_ranges
+    OptimisticHours  float64 `json:"optimistic_hours,omitempty"`
+    PessimisticHours float64 `json:"pessimistic_hours,omitempty"`
+    // Given/When/Then criteria, stored in task_acceptance_criteria
+    AcceptanceCriteria []string `json:"acceptance_criteria,omitempty"`
+    // Date the task should be finished by, stored in task_due_dates
+    DueDate *time.Time `json:"due_date,omitempty"`
 }
 
 // TeamMember represents a team member in the database
@@ -72,19 +80,39 @@ type ProjectStats struct {
 }
 
 type DB struct {
-    conn *sql.DB
+    // conn runs the queries: the pool, or a transaction of it inside WithTx
+    conn   querier
+    pool   *sql.DB
+    tx     *sql.Tx
+    driver string
+    // replica serves Analytics queries when DATABASE_REPLICA_URL is set
+    replica *DB
 }
 
+// defaultSQLitePath is the bot's SQLite database when DB_TYPE is not postgres
+const defaultSQLitePath = "./yordamchi_bot.db"
+
 func NewDB() (*DB, error) {
-    conn, err := sql.Open("sqlite3", "./yordamchi_bot.db")
+    return openSQLite(defaultSQLitePath)
+}
+
+// NewSQLiteDB opens the SQLite database at path instead of the bot's default file,
+// e.g. a scratch database filled by cmd/seed
+func NewSQLiteDB(path string) (*DB, error) {
+    return openSQLite(path)
+}
+
+// openSQLite opens the SQLite database at path, migrating it to the current schema
+func openSQLite(path string) (*DB, error) {
+    conn, err := sql.Open("sqlite3", path)
     if err != nil {
         return nil, fmt.Errorf("ma'lumotlar bazasiga ulanishda xatolik: %w", err)
     }
 
-    db := &DB{conn: conn}
+    db := &DB{conn: conn, pool: conn, driver: "sqlite3"}
     
-    if err := db.createTables(); err != nil {
-        return nil, fmt.Errorf("jadvallar yaratishda xatolik: %w", err)
+    if err := db.migrate(); err != nil {
+        return nil, fmt.Errorf("migratsiyalarda xatolik: %w", err)
     }
 
     log.Println("✅ Ma'lumotlar bazasi muvaffaqiyatli sozlandi")
@@ -113,84 +141,6 @@ func (db *DB) getPlaceholders(count int) []string {
     return placeholders
 }
 
-func (db *DB) createTables() error {
-    query := `
-    CREATE TABLE IF NOT EXISTS users (
-        id INTEGER PRIMARY KEY AUTOINCREMENT,
-        telegram_id INTEGER UNIQUE NOT NULL,
-        username TEXT,
-        first_name TEXT,
-        last_name TEXT,
-        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-        updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
-    );
-
-    CREATE TABLE IF NOT EXISTS user_activity (
-        id INTEGER PRIMARY KEY AUTOINCREMENT,
-        user_id INTEGER,
-        command TEXT,
-        timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
-        FOREIGN KEY (user_id) REFERENCES users (id)
-    );
-
-    CREATE TABLE IF NOT EXISTS teams (
-        id TEXT PRIMARY KEY,
-        name TEXT NOT NULL,
-        chat_id INTEGER UNIQUE NOT NULL,
-        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-        updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
-    );
-
-    CREATE TABLE IF NOT EXISTS team_members (
-        id TEXT PRIMARY KEY,
-        team_id TEXT NOT NULL,
-        user_id INTEGER NOT NULL,
-        username TEXT NOT NULL,
-        role TEXT DEFAULT 'developer',
-        skills TEXT,
-        capacity REAL DEFAULT 40.0,
-        current_workload REAL DEFAULT 0.0,
-        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-        updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-        FOREIGN KEY (team_id) REFERENCES teams (id),
-        FOREIGN KEY (user_id) REFERENCES users (telegram_id)
-    );
-
-    CREATE TABLE IF NOT EXISTS projects (
-        id TEXT PRIMARY KEY,
-        name TEXT NOT NULL,
-        description TEXT,
-        team_id TEXT NOT NULL,
-        status TEXT DEFAULT 'active',
-        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-        updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-        FOREIGN KEY (team_id) REFERENCES teams (id)
-    );
-
-    CREATE TABLE IF NOT EXISTS tasks (
-        id TEXT PRIMARY KEY,
-        project_id TEXT NOT NULL,
-        title TEXT NOT NULL,
-        description TEXT,
-        category TEXT NOT NULL,
-        estimate_hours REAL DEFAULT 0.0,
-        actual_hours REAL DEFAULT 0.0,
-        status TEXT DEFAULT 'todo',
-        priority INTEGER DEFAULT 3,
-        assigned_to TEXT,
-        dependencies TEXT,
-        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-        updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-        completed_at DATETIME,
-        FOREIGN KEY (project_id) REFERENCES projects (id),
-        FOREIGN KEY (assigned_to) REFERENCES team_members (id)
-    );
-    `
-
-    _, err := db.conn.Exec(query)
-    return err
-}
-
 func (db *DB) CreateOrUpdateUser(telegramID int64, username, firstName, lastName string) error {
     query := `
     INSERT INTO users (telegram_id, username, first_name, last_name)
@@ -211,21 +161,37 @@ func (db *DB) CreateOrUpdateUser(telegramID int64, username, firstName, lastName
     return nil
 }
 
+// LogUserActivity records a command of a user. Activity can be logged before the user's
+// registration is stored, so a missing user row is inserted in the same transaction;
+// CreateOrUpdateUser fills in the names later.
 func (db *DB) LogUserActivity(telegramID int64, command string) error {
-    userIDQuery := "SELECT id FROM users WHERE telegram_id = $1"
-    var userID int
-    err := db.conn.QueryRow(userIDQuery, telegramID).Scan(&userID)
-    if err != nil {
-        return fmt.Errorf("foydalanuvchi ID topilmadi: %w", err)
-    }
+    return db.WithTx(context.Background(), func(tx *DB) error {
+        userID, err := tx.ensureUserID(telegramID)
+        if err != nil {
+            return err
+        }
 
-    activityQuery := "INSERT INTO user_activity (user_id, command) VALUES ($1, $2)"
-    _, err = db.conn.Exec(activityQuery, userID, command)
-    if err != nil {
-        return fmt.Errorf("faollik yozishda xatolik: %w", err)
+        activityQuery := "INSERT INTO user_activity (user_id, command) VALUES ($1, $2)"
+        if _, err := tx.conn.Exec(activityQuery, userID, command); err != nil {
+            return fmt.Errorf("faollik yozishda xatolik: %w", err)
+        }
+        return nil
+    })
+}
+
+// ensureUserID returns the row ID of a user, inserting the user when it is missing
+func (db *DB) ensureUserID(telegramID int64) (int, error) {
+    ensureUserQuery := "INSERT INTO users (telegram_id) VALUES ($1) ON CONFLICT(telegram_id) DO NOTHING"
+    if _, err := db.conn.Exec(ensureUserQuery, telegramID); err != nil {
+        return 0, fmt.Errorf("foydalanuvchini yaratishda xatolik: %w", err)
     }
 
-    return nil
+    userIDQuery := "SELECT id FROM users WHERE telegram_id = $1"
+    var userID int
+    if err := db.conn.QueryRow(userIDQuery, telegramID).Scan(&userID); err != nil {
+        return 0, fmt.Errorf("foydalanuvchi ID topilmadi: %w", err)
+    }
+    return userID, nil
 }
 
 // UserActivity represents user activity data
@@ -343,31 +309,9 @@ func (db *DB) GetDailyStats() (map[string]int, error) {
 
 // Project methods
 func (db *DB) CreateProject(project *Project) error {
-    // First ensure the team exists (extract chat ID from team_id format "team_12345")
-    if strings.HasPrefix(project.TeamID, "team_") {
-        chatIDStr := strings.TrimPrefix(project.TeamID, "team_")
-        if chatIDStr != "" {
-            // Try to parse chat ID
-            chatID := int64(0)
-            if _, err := fmt.Sscanf(chatIDStr, "%d", &chatID); err == nil {
-                // Check if team exists
-                teamPlaceholders := db.getPlaceholders(1)
-                teamQuery := fmt.Sprintf("SELECT id FROM teams WHERE chat_id = %s", teamPlaceholders[0])
-                var existingTeamID string
-                err := db.conn.QueryRow(teamQuery, chatID).Scan(&existingTeamID)
-                if err != nil {
-                    // Team doesn't exist, create it
-                    createPlaceholders := db.getPlaceholders(3)
-                    createTeamQuery := fmt.Sprintf("INSERT INTO teams (id, name, chat_id) VALUES (%s, %s, %s)", 
-                        createPlaceholders[0], createPlaceholders[1], createPlaceholders[2])
-                    _, err = db.conn.Exec(createTeamQuery, project.TeamID, fmt.Sprintf("Chat %d Team", chatID), chatID)
-                    if err != nil {
-                        return fmt.Errorf("jamoa yaratishda xatolik: %w", err)
-                    }
-                    log.Printf("👥 Jamoa yaratildi: %s (Chat ID: %d)", project.TeamID, chatID)
-                }
-            }
-        }
+    // First ensure the team exists
+    if err := db.ensureTeamOfID(project.TeamID); err != nil {
+        return err
     }
     
     // Now create the project
@@ -460,6 +404,21 @@ func (db *DB) CreateTask(task *Task) error {
     if err != nil {
         return fmt.Errorf("vazifa yaratishda xatolik: %w", err)
     }
+
+    if task.OptimisticHours > 0 || task.PessimisticHours > 0 {
+        if err := db.SetTaskEstimateBounds(task.ID, task.OptimisticHours, task.PessimisticHours); err != nil {
+            return err
+        }
+    }
+
+    // Number the task for keys like TASK-12; tasks without one are numbered when first shown
+    if _, err := db.EnsureTaskKey(task.ID); err != nil {
+        log.Printf("⚠️ %v", err)
+    }
+
+    if len(task.AcceptanceCriteria) > 0 {
+        return db.SetTaskAcceptanceCriteria(task.ID, task.AcceptanceCriteria)
+    }
     
     return nil
 }
@@ -520,12 +479,39 @@ func (db *DB) GetTasksByProjectID(projectID string) ([]Task, error) {
         
         tasks = append(tasks, task)
     }
+    rows.Close()
+
+    criteria, err := db.GetProjectAcceptanceCriteria(projectID)
+    if err != nil {
+        return nil, err
+    }
+    bounds, err := db.GetProjectEstimateBounds(projectID)
+    if err != nil {
+        return nil, err
+    }
+    dueDates, err := db.GetProjectDueDates(projectID)
+    if err != nil {
+        return nil, err
+    }
+    for i := range tasks {
+        tasks[i].AcceptanceCriteria = criteria[tasks[i].ID]
+        tasks[i].OptimisticHours = bounds[tasks[i].ID].OptimisticHours
+        tasks[i].PessimisticHours = bounds[tasks[i].ID].PessimisticHours
+        if due, ok := dueDates[tasks[i].ID]; ok {
+            tasks[i].DueDate = &due
+        }
+    }
     
     return tasks, nil
 }
 
 // Team Member methods
 func (db *DB) CreateTeamMember(member *TeamMember) error {
+    // Members reference their team, so make sure the chat's team row exists
+    if err := db.ensureTeamOfID(member.TeamID); err != nil {
+        return err
+    }
+
     skillsJSON := strings.Join(member.Skills, ",")
     
     // Detect database type by attempting to use PostgreSQL syntax first
@@ -677,5 +663,8 @@ func (db *DB) GetProjectStats(projectID string) (*ProjectStats, error) {
 }
 
 func (db *DB) Close() error {
-    return db.conn.Close()
+    if err := db.closeReplica(); err != nil {
+        log.Println(err)
+    }
+    return db.pool.Close()
 }
\ No newline at end of file
@@ -0,0 +1,105 @@
+package database
+
+import (
+    "context"
+    "fmt"
+    "time"
+)
+
+// SetTaskTimeline backdates when a task was created and completed, e.g. for sample projects
+func (db *DB) SetTaskTimeline(taskID string, createdAt time.Time, completedAt *time.Time, actualHours float64) error {
+    var completed interface{}
+    if completedAt != nil {
+        completed = completedAt.UTC().Truncate(time.Second)
+    }
+
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    UPDATE tasks
+    SET created_at = %s, completed_at = %s, actual_hours = %s
+    WHERE id = %s`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    if _, err := db.conn.Exec(query, createdAt.UTC().Truncate(time.Second), completed, actualHours, taskID); err != nil {
+        return fmt.Errorf("vazifa tarixini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// SetProjectCreatedAt backdates when a project was started, e.g. for sample projects
+func (db *DB) SetProjectCreatedAt(projectID string, createdAt time.Time) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf("UPDATE projects SET created_at = %s WHERE id = %s", placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, createdAt.UTC().Truncate(time.Second), projectID); err != nil {
+        return fmt.Errorf("loyiha sanasini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// LogUserActivityAt records a command of a user at a given time, e.g. for sample activity history
+func (db *DB) LogUserActivityAt(telegramID int64, command string, at time.Time) error {
+    return db.WithTx(context.Background(), func(tx *DB) error {
+        userID, err := tx.ensureUserID(telegramID)
+        if err != nil {
+            return err
+        }
+
+        placeholders := tx.getPlaceholders(3)
+        query := fmt.Sprintf("INSERT INTO user_activity (user_id, command, timestamp) VALUES (%s, %s, %s)",
+            placeholders[0], placeholders[1], placeholders[2])
+        if _, err := tx.conn.Exec(query, userID, command, at.UTC().Truncate(time.Second)); err != nil {
+            return fmt.Errorf("faollik yozishda xatolik: %w", err)
+        }
+        return nil
+    })
+}
+
+// DeleteProject removes a project together with its tasks and their comments, health record,
+// share links, webhook and deployments
+func (db *DB) DeleteProject(projectID string) error {
+    placeholders := db.getPlaceholders(1)
+    queries := []string{
+        fmt.Sprintf("DELETE FROM task_escalations WHERE task_id IN (SELECT id FROM tasks WHERE project_id = %s)", placeholders[0]),
+        fmt.Sprintf("DELETE FROM task_acceptance_criteria WHERE task_id IN (SELECT id FROM tasks WHERE project_id = %s)", placeholders[0]),
+        fmt.Sprintf("DELETE FROM task_estimate_ranges WHERE task_id IN (SELECT id FROM tasks WHERE project_id = %s)", placeholders[0]),
+        fmt.Sprintf("DELETE FROM task_releases WHERE task_id IN (SELECT id FROM tasks WHERE project_id = %s)", placeholders[0]),
+        fmt.Sprintf("DELETE FROM task_attachments WHERE task_id IN (SELECT id FROM tasks WHERE project_id = %s)", placeholders[0]),
+        fmt.Sprintf("DELETE FROM task_comments WHERE task_id IN (SELECT id FROM tasks WHERE project_id = %s)", placeholders[0]),
+        fmt.Sprintf("DELETE FROM tasks WHERE project_id = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM deployments WHERE project_id = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM project_health WHERE project_id = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM project_shares WHERE project_id = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM project_webhooks WHERE project_id = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM projects WHERE id = %s", placeholders[0]),
+    }
+
+    return db.WithTx(context.Background(), func(tx *DB) error {
+        for _, query := range queries {
+            if _, err := tx.conn.Exec(query, projectID); err != nil {
+                return fmt.Errorf("loyihani o'chirishda xatolik: %w", err)
+            }
+        }
+        return nil
+    })
+}
+
+// DeleteTeamMember removes a team member, unassigning their tasks
+func (db *DB) DeleteTeamMember(memberID string) error {
+    placeholders := db.getPlaceholders(1)
+    queries := []string{
+        fmt.Sprintf("UPDATE tasks SET assigned_to = NULL WHERE assigned_to = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM team_members WHERE id = %s", placeholders[0]),
+    }
+
+    return db.WithTx(context.Background(), func(tx *DB) error {
+        for _, query := range queries {
+            if _, err := tx.conn.Exec(query, memberID); err != nil {
+                return fmt.Errorf("jamoa a'zosini o'chirishda xatolik: %w", err)
+            }
+        }
+        return nil
+    })
+}
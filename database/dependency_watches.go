@@ -0,0 +1,115 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+// DependencyWatch is a GitHub repository whose Go modules a chat gets weekly update reports for
+type DependencyWatch struct {
+    ID         int64     `json:"id"`
+    ChatID     int64     `json:"chat_id"`
+    Repository string    `json:"repository"`
+    CreatedBy  int64     `json:"created_by"`
+    ReportedAt time.Time `json:"reported_at"`
+    CreatedAt  time.Time `json:"created_at"`
+}
+
+// AddDependencyWatch starts watching a repository for a chat and sets the watch's ID. It
+// reports false when the chat already watches the repository.
+func (db *DB) AddDependencyWatch(watch *DependencyWatch) (bool, error) {
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    INSERT INTO dependency_watches (chat_id, repository, created_by, reported_at)
+    VALUES (%s, %s, %s, %s)
+    ON CONFLICT(chat_id, repository) DO NOTHING
+    RETURNING id`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    reportedAt := watch.ReportedAt.UTC().Truncate(time.Second)
+    err := db.conn.QueryRow(query, watch.ChatID, watch.Repository, watch.CreatedBy, reportedAt).Scan(&watch.ID)
+    if err == sql.ErrNoRows {
+        return false, nil
+    }
+    if err != nil {
+        return false, fmt.Errorf("kuzatiladigan repozitoriyni saqlashda xatolik: %w", err)
+    }
+
+    return true, nil
+}
+
+// GetDependencyWatches returns the repositories a chat watches in the order they were added
+func (db *DB) GetDependencyWatches(chatID int64) ([]DependencyWatch, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT id, chat_id, repository, created_by, reported_at, created_at
+    FROM dependency_watches
+    WHERE chat_id = %s
+    ORDER BY id ASC`, placeholders[0])
+
+    return db.queryDependencyWatches(query, chatID)
+}
+
+// GetDueDependencyWatches returns watches never reported or last reported before the given
+// time, least recently reported first
+func (db *DB) GetDueDependencyWatches(before time.Time, limit int) ([]DependencyWatch, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT id, chat_id, repository, created_by, reported_at, created_at
+    FROM dependency_watches
+    WHERE reported_at IS NULL OR reported_at < %s
+    ORDER BY reported_at ASC
+    LIMIT %s`, placeholders[0], placeholders[1])
+
+    return db.queryDependencyWatches(query, before.UTC().Truncate(time.Second), limit)
+}
+
+// MarkDependencyReported records when the update report of a watch was posted
+func (db *DB) MarkDependencyReported(id int64, reportedAt time.Time) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf("UPDATE dependency_watches SET reported_at = %s WHERE id = %s", placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, reportedAt.UTC().Truncate(time.Second), id); err != nil {
+        return fmt.Errorf("hisobot vaqtini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// DeleteDependencyWatch stops watching a repository and reports whether the chat watched it
+func (db *DB) DeleteDependencyWatch(chatID int64, repository string) (bool, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf("DELETE FROM dependency_watches WHERE chat_id = %s AND repository = %s", placeholders[0], placeholders[1])
+
+    removed, err := db.execCount(query, chatID, repository)
+    if err != nil {
+        return false, fmt.Errorf("kuzatiladigan repozitoriyni o'chirishda xatolik: %w", err)
+    }
+
+    return removed > 0, nil
+}
+
+// queryDependencyWatches runs a dependency watch query and scans the rows
+func (db *DB) queryDependencyWatches(query string, args ...interface{}) ([]DependencyWatch, error) {
+    rows, err := db.conn.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("kuzatiladigan repozitoriylarni olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var watches []DependencyWatch
+    for rows.Next() {
+        var watch DependencyWatch
+        var reportedAt sql.NullTime
+        if err := rows.Scan(&watch.ID, &watch.ChatID, &watch.Repository, &watch.CreatedBy, &reportedAt, &watch.CreatedAt); err != nil {
+            return nil, fmt.Errorf("kuzatiladigan repozitoriyni o'qishda xatolik: %w", err)
+        }
+        if reportedAt.Valid {
+            watch.ReportedAt = reportedAt.Time
+        }
+        watches = append(watches, watch)
+    }
+
+    return watches, nil
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+// Deployment is a version of a project shipped to production
+type Deployment struct {
+    ID         int64     `json:"id"`
+    ProjectID  string    `json:"project_id"`
+    Version    string    `json:"version"`
+    DeployedBy string    `json:"deployed_by"`
+    DeployedAt time.Time `json:"deployed_at"`
+}
+
+// ReleasedTask is a completed task shipped with a deployment. Number is its task key
+// number, 0 when the task has none.
+type ReleasedTask struct {
+    TaskID     string `json:"task_id"`
+    Title      string `json:"title"`
+    Category   string `json:"category"`
+    AssignedTo string `json:"assigned_to"`
+    Number     int    `json:"number"`
+}
+
+// CreateDeployment records a deployment and sets its ID. It reports false, leaving the
+// record untouched, when the project's version was already recorded.
+func (db *DB) CreateDeployment(deployment *Deployment) (bool, error) {
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    INSERT INTO deployments (project_id, version, deployed_by, deployed_at)
+    VALUES (%s, %s, %s, %s)
+    ON CONFLICT(project_id, version) DO NOTHING
+    RETURNING id`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    deployedAt := deployment.DeployedAt.UTC().Truncate(time.Second)
+    err := db.conn.QueryRow(query, deployment.ProjectID, deployment.Version, deployment.DeployedBy, deployedAt).Scan(&deployment.ID)
+    if err == sql.ErrNoRows {
+        return false, nil
+    }
+    if err != nil {
+        return false, fmt.Errorf("deployni saqlashda xatolik: %w", err)
+    }
+
+    deployment.DeployedAt = deployedAt
+    return true, nil
+}
+
+// GetLatestDeployment returns the project's most recent deployment before the given one,
+// or nil when there is none
+func (db *DB) GetLatestDeployment(projectID string, beforeID int64) (*Deployment, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT id, project_id, version, deployed_by, deployed_at
+    FROM deployments
+    WHERE project_id = %s AND id < %s
+    ORDER BY id DESC
+    LIMIT 1`, placeholders[0], placeholders[1])
+
+    var deployment Deployment
+    err := db.conn.QueryRow(query, projectID, beforeID).Scan(&deployment.ID, &deployment.ProjectID,
+        &deployment.Version, &deployment.DeployedBy, &deployment.DeployedAt)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("oxirgi deployni olishda xatolik: %w", err)
+    }
+    return &deployment, nil
+}
+
+// ReleaseCompletedTasks marks the project's completed tasks not shipped by an earlier
+// deployment as released by this one and returns them
+func (db *DB) ReleaseCompletedTasks(projectID string, deploymentID int64) ([]ReleasedTask, error) {
+    placeholders := db.getPlaceholders(3)
+    insertQuery := fmt.Sprintf(`
+    INSERT INTO task_releases (task_id, deployment_id)
+    SELECT t.id, %s
+    FROM tasks t
+    WHERE t.project_id = %s AND t.status = %s
+    AND NOT EXISTS (SELECT 1 FROM task_releases r WHERE r.task_id = t.id)`,
+        placeholders[0], placeholders[1], placeholders[2])
+
+    if _, err := db.conn.Exec(insertQuery, deploymentID, projectID, "completed"); err != nil {
+        return nil, fmt.Errorf("vazifalarni reliz qilishda xatolik: %w", err)
+    }
+
+    selectQuery := fmt.Sprintf(`
+    SELECT t.id, t.title, COALESCE(t.category, ''), COALESCE(t.assigned_to, ''), COALESCE(k.number, 0)
+    FROM task_releases r
+    JOIN tasks t ON t.id = r.task_id
+    LEFT JOIN task_keys k ON k.task_id = t.id
+    WHERE r.deployment_id = %s
+    ORDER BY t.category, t.priority, t.title`, placeholders[0])
+
+    rows, err := db.conn.Query(selectQuery, deploymentID)
+    if err != nil {
+        return nil, fmt.Errorf("reliz vazifalarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var tasks []ReleasedTask
+    for rows.Next() {
+        var task ReleasedTask
+        if err := rows.Scan(&task.TaskID, &task.Title, &task.Category, &task.AssignedTo, &task.Number); err != nil {
+            return nil, fmt.Errorf("reliz vazifasini o'qishda xatolik: %w", err)
+        }
+        tasks = append(tasks, task)
+    }
+    return tasks, nil
+}
+
+// GetProjectChatID returns the chat of the project's team, or 0 when the project does not exist
+func (db *DB) GetProjectChatID(projectID string) (int64, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT tm.chat_id
+    FROM projects p
+    JOIN teams tm ON tm.id = p.team_id
+    WHERE p.id = %s`, placeholders[0])
+
+    var chatID int64
+    err := db.conn.QueryRow(query, projectID).Scan(&chatID)
+    if err == sql.ErrNoRows {
+        return 0, nil
+    }
+    if err != nil {
+        return 0, fmt.Errorf("loyiha chatini olishda xatolik: %w", err)
+    }
+    return chatID, nil
+}
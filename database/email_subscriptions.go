@@ -0,0 +1,150 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+// EmailSubscription is a user's opt-in to receive digests and alerts by email
+type EmailSubscription struct {
+    UserID       int64     `json:"user_id"`
+    Email        string    `json:"email"`
+    Digest       bool      `json:"digest"`
+    Alerts       bool      `json:"alerts"`
+    Token        string    `json:"token"`
+    LastDigestAt time.Time `json:"last_digest_at"`
+    LastAlertAt  time.Time `json:"last_alert_at"`
+}
+
+// SaveEmailSubscription creates or updates a user's subscription. The token is kept on updates.
+func (db *DB) SaveEmailSubscription(sub *EmailSubscription) error {
+    placeholders := db.getPlaceholders(5)
+    query := fmt.Sprintf(`
+    INSERT INTO email_subscriptions (user_id, email, digest, alerts, token)
+    VALUES (%s, %s, %s, %s, %s)
+    ON CONFLICT(user_id) DO UPDATE SET
+        email = EXCLUDED.email,
+        digest = EXCLUDED.digest,
+        alerts = EXCLUDED.alerts,
+        updated_at = CURRENT_TIMESTAMP`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3], placeholders[4])
+
+    if _, err := db.conn.Exec(query, sub.UserID, sub.Email, sub.Digest, sub.Alerts, sub.Token); err != nil {
+        return fmt.Errorf("email obunasini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// GetEmailSubscription returns a user's subscription, or nil if there is none
+func (db *DB) GetEmailSubscription(userID int64) (*EmailSubscription, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT user_id, email, digest, alerts, token, last_digest_at, last_alert_at
+    FROM email_subscriptions
+    WHERE user_id = %s`, placeholders[0])
+
+    sub, err := scanEmailSubscription(db.conn.QueryRow(query, userID))
+    if err == sql.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("email obunasini olishda xatolik: %w", err)
+    }
+
+    return sub, nil
+}
+
+// GetEmailSubscriptions returns subscriptions with the digest or alerts enabled
+func (db *DB) GetEmailSubscriptions() ([]EmailSubscription, error) {
+    query := `
+    SELECT user_id, email, digest, alerts, token, last_digest_at, last_alert_at
+    FROM email_subscriptions
+    WHERE digest = TRUE OR alerts = TRUE`
+
+    rows, err := db.conn.Query(query)
+    if err != nil {
+        return nil, fmt.Errorf("email obunalarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var subs []EmailSubscription
+    for rows.Next() {
+        sub, err := scanEmailSubscription(rows)
+        if err != nil {
+            return nil, fmt.Errorf("email obunasini o'qishda xatolik: %w", err)
+        }
+        subs = append(subs, *sub)
+    }
+
+    return subs, nil
+}
+
+// DeleteEmailSubscription removes a user's subscription and reports whether it existed
+func (db *DB) DeleteEmailSubscription(userID int64) (bool, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("DELETE FROM email_subscriptions WHERE user_id = %s", placeholders[0])
+
+    result, err := db.conn.Exec(query, userID)
+    if err != nil {
+        return false, fmt.Errorf("email obunasini o'chirishda xatolik: %w", err)
+    }
+
+    affected, err := result.RowsAffected()
+    return affected > 0, err
+}
+
+// UnsubscribeEmail removes the subscription with the given unsubscribe token and reports whether it existed
+func (db *DB) UnsubscribeEmail(token string) (bool, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("DELETE FROM email_subscriptions WHERE token = %s", placeholders[0])
+
+    result, err := db.conn.Exec(query, token)
+    if err != nil {
+        return false, fmt.Errorf("email obunasini bekor qilishda xatolik: %w", err)
+    }
+
+    affected, err := result.RowsAffected()
+    return affected > 0, err
+}
+
+// MarkEmailDigestSent records when the weekly digest was last sent to a user
+func (db *DB) MarkEmailDigestSent(userID int64, sentAt time.Time) error {
+    return db.markEmailSent("last_digest_at", userID, sentAt)
+}
+
+// MarkEmailAlertSent records when an overdue alert was last sent to a user
+func (db *DB) MarkEmailAlertSent(userID int64, sentAt time.Time) error {
+    return db.markEmailSent("last_alert_at", userID, sentAt)
+}
+
+// markEmailSent updates one of the delivery timestamps of a subscription
+func (db *DB) markEmailSent(column string, userID int64, sentAt time.Time) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf("UPDATE email_subscriptions SET %s = %s WHERE user_id = %s",
+        column, placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, sentAt.UTC().Truncate(time.Second), userID); err != nil {
+        return fmt.Errorf("email yuborilgan vaqtini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+    Scan(dest ...interface{}) error
+}
+
+// scanEmailSubscription reads a subscription row
+func scanEmailSubscription(row rowScanner) (*EmailSubscription, error) {
+    var sub EmailSubscription
+    var lastDigest, lastAlert sql.NullTime
+    if err := row.Scan(&sub.UserID, &sub.Email, &sub.Digest, &sub.Alerts, &sub.Token, &lastDigest, &lastAlert); err != nil {
+        return nil, err
+    }
+    sub.LastDigestAt = lastDigest.Time
+    sub.LastAlertAt = lastAlert.Time
+    return &sub, nil
+}
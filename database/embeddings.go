@@ -0,0 +1,213 @@
+package database
+
+import (
+    "encoding/binary"
+    "fmt"
+    "math"
+)
+
+// Kinds of documents indexed for semantic search. Snippets are the team's knowledge base.
+const (
+    EmbeddingKindTask     = "task"
+    EmbeddingKindAnalysis = "analysis"
+    EmbeddingKindSnippet  = "snippet"
+)
+
+// Embedding is the vector of an indexed document. Vectors are compared only with vectors
+// of the same model.
+type Embedding struct {
+    Kind        string    `json:"kind"`
+    ChatID      int64     `json:"chat_id"`
+    RefID       string    `json:"ref_id"`
+    Title       string    `json:"title"`
+    Model       string    `json:"model"`
+    ContentHash string    `json:"content_hash"`
+    Vector      []float32 `json:"-"`
+}
+
+// EmbeddingSource is a document to index: new, changed since it was indexed, or indexed
+// with another model. ContentHash is the hash it was last indexed with by the model, "" when never.
+type EmbeddingSource struct {
+    Kind        string
+    ChatID      int64
+    RefID       string
+    Title       string
+    Body        string
+    ContentHash string
+}
+
+// GetEmbeddingSources returns up to limit documents of every kind that need indexing with the model
+func (db *DB) GetEmbeddingSources(model string, limit int) ([]EmbeddingSource, error) {
+    placeholders := db.getPlaceholders(3)
+    queries := []string{
+        fmt.Sprintf(`
+        SELECT 'task', tm.chat_id, t.id, t.title, COALESCE(t.description, ''), CASE WHEN e.model = %s THEN e.content_hash ELSE '' END
+        FROM tasks t
+        JOIN projects p ON p.id = t.project_id
+        JOIN teams tm ON tm.id = p.team_id
+        LEFT JOIN embeddings e ON e.kind = 'task' AND e.chat_id = tm.chat_id AND e.ref_id = t.id
+        WHERE e.ref_id IS NULL OR e.model <> %s OR t.updated_at >= e.indexed_at
+        LIMIT %s`, placeholders[0], placeholders[1], placeholders[2]),
+        fmt.Sprintf(`
+        SELECT 'analysis', r.chat_id, CAST(r.id AS TEXT), r.requirement, '', CASE WHEN e.model = %s THEN e.content_hash ELSE '' END
+        FROM analysis_runs r
+        LEFT JOIN embeddings e ON e.kind = 'analysis' AND e.chat_id = r.chat_id AND e.ref_id = CAST(r.id AS TEXT)
+        WHERE e.ref_id IS NULL OR e.model <> %s
+        LIMIT %s`, placeholders[0], placeholders[1], placeholders[2]),
+        fmt.Sprintf(`
+        SELECT 'snippet', s.chat_id, s.name, s.name, s.content, CASE WHEN e.model = %s THEN e.content_hash ELSE '' END
+        FROM snippets s
+        LEFT JOIN embeddings e ON e.kind = 'snippet' AND e.chat_id = s.chat_id AND e.ref_id = s.name
+        WHERE e.ref_id IS NULL OR e.model <> %s OR s.updated_at >= e.indexed_at
+        LIMIT %s`, placeholders[0], placeholders[1], placeholders[2]),
+    }
+
+    var sources []EmbeddingSource
+    for _, query := range queries {
+        if len(sources) >= limit {
+            break
+        }
+        rows, err := db.conn.Query(query, model, model, limit-len(sources))
+        if err != nil {
+            return nil, fmt.Errorf("indekslanadigan hujjatlarni olishda xatolik: %w", err)
+        }
+        for rows.Next() {
+            var source EmbeddingSource
+            if err := rows.Scan(&source.Kind, &source.ChatID, &source.RefID, &source.Title, &source.Body, &source.ContentHash); err != nil {
+                rows.Close()
+                return nil, fmt.Errorf("indekslanadigan hujjatni o'qishda xatolik: %w", err)
+            }
+            sources = append(sources, source)
+        }
+        rows.Close()
+    }
+    return sources, nil
+}
+
+// SaveEmbedding stores a document's vector, replacing an earlier one
+func (db *DB) SaveEmbedding(embedding Embedding) error {
+    placeholders := db.getPlaceholders(7)
+    query := fmt.Sprintf(`
+    INSERT INTO embeddings (kind, chat_id, ref_id, title, model, content_hash, vector)
+    VALUES (%s, %s, %s, %s, %s, %s, %s)
+    ON CONFLICT(kind, chat_id, ref_id) DO UPDATE SET
+        title = EXCLUDED.title,
+        model = EXCLUDED.model,
+        content_hash = EXCLUDED.content_hash,
+        vector = EXCLUDED.vector,
+        indexed_at = CURRENT_TIMESTAMP`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3],
+        placeholders[4], placeholders[5], placeholders[6])
+
+    _, err := db.conn.Exec(query, embedding.Kind, embedding.ChatID, embedding.RefID, embedding.Title,
+        embedding.Model, embedding.ContentHash, encodeVector(embedding.Vector))
+    if err != nil {
+        return fmt.Errorf("embeddingni saqlashda xatolik: %w", err)
+    }
+    return nil
+}
+
+// TouchEmbedding marks a document whose content did not change as indexed now
+func (db *DB) TouchEmbedding(kind string, chatID int64, refID string) error {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf(`
+    UPDATE embeddings SET indexed_at = CURRENT_TIMESTAMP
+    WHERE kind = %s AND chat_id = %s AND ref_id = %s`, placeholders[0], placeholders[1], placeholders[2])
+
+    if _, err := db.conn.Exec(query, kind, chatID, refID); err != nil {
+        return fmt.Errorf("embeddingni yangilashda xatolik: %w", err)
+    }
+    return nil
+}
+
+// GetEmbedding returns a document's vector made with the model, or nil when it is not indexed
+func (db *DB) GetEmbedding(kind string, chatID int64, refID, model string) (*Embedding, error) {
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    SELECT kind, chat_id, ref_id, title, model, content_hash, vector
+    FROM embeddings
+    WHERE kind = %s AND chat_id = %s AND ref_id = %s AND model = %s`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    embeddings, err := db.queryEmbeddings(query, kind, chatID, refID, model)
+    if err != nil || len(embeddings) == 0 {
+        return nil, err
+    }
+    return &embeddings[0], nil
+}
+
+// GetChatEmbeddings returns the vectors of a chat's documents made with the model. Semantic
+// search compares them all with the query vector: an exact scan, which stays fast at the
+// size of one team's tasks, analyses and snippets.
+func (db *DB) GetChatEmbeddings(chatID int64, model string) ([]Embedding, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT kind, chat_id, ref_id, title, model, content_hash, vector
+    FROM embeddings
+    WHERE chat_id = %s AND model = %s`, placeholders[0], placeholders[1])
+
+    return db.queryEmbeddings(query, chatID, model)
+}
+
+// PruneEmbeddings deletes the vectors of documents that no longer exist
+func (db *DB) PruneEmbeddings() (int64, error) {
+    queries := []string{
+        "DELETE FROM embeddings WHERE kind = 'task' AND ref_id NOT IN (SELECT id FROM tasks)",
+        "DELETE FROM embeddings WHERE kind = 'analysis' AND ref_id NOT IN (SELECT CAST(id AS TEXT) FROM analysis_runs)",
+        `DELETE FROM embeddings WHERE kind = 'snippet' AND NOT EXISTS (
+            SELECT 1 FROM snippets s WHERE s.chat_id = embeddings.chat_id AND s.name = embeddings.ref_id)`,
+    }
+
+    var total int64
+    for _, query := range queries {
+        removed, err := db.execCount(query)
+        if err != nil {
+            return total, fmt.Errorf("eskirgan embeddinglarni o'chirishda xatolik: %w", err)
+        }
+        total += removed
+    }
+    return total, nil
+}
+
+// queryEmbeddings runs a query selecting embedding rows
+func (db *DB) queryEmbeddings(query string, args ...interface{}) ([]Embedding, error) {
+    rows, err := db.conn.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("embeddinglarni olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var embeddings []Embedding
+    for rows.Next() {
+        var embedding Embedding
+        var vector []byte
+        if err := rows.Scan(&embedding.Kind, &embedding.ChatID, &embedding.RefID, &embedding.Title,
+            &embedding.Model, &embedding.ContentHash, &vector); err != nil {
+            return nil, fmt.Errorf("embeddingni o'qishda xatolik: %w", err)
+        }
+        embedding.Vector = decodeVector(vector)
+        embeddings = append(embeddings, embedding)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("embeddinglarni olishda xatolik: %w", err)
+    }
+    return embeddings, nil
+}
+
+// encodeVector packs a vector as little-endian float32 values
+func encodeVector(vector []float32) []byte {
+    data := make([]byte, 4*len(vector))
+    for i, value := range vector {
+        binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(value))
+    }
+    return data
+}
+
+// decodeVector unpacks a vector packed by encodeVector
+func decodeVector(data []byte) []float32 {
+    vector := make([]float32, len(data)/4)
+    for i := range vector {
+        vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+    }
+    return vector
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+// EscalationCandidate is an assigned todo task with its escalation state
+type EscalationCandidate struct {
+    TaskID      string    `json:"task_id"`
+    Title       string    `json:"title"`
+    ProjectName string    `json:"project_name"`
+    TeamID      string    `json:"team_id"`
+    ChatID      int64     `json:"chat_id"`
+    Priority    int       `json:"priority"`
+    Assignee    string    `json:"assignee"`
+    TodoSince   time.Time `json:"todo_since"`
+    Level       int       `json:"level"`
+    NotifiedAt  time.Time `json:"notified_at"`
+}
+
+// GetEscalationCandidates returns assigned tasks in todo with priority up to maxPriority.
+// The escalation level resets when the task was updated after its last notification.
+func (db *DB) GetEscalationCandidates(maxPriority int) ([]EscalationCandidate, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT t.id, t.title, p.name, p.team_id, tm.chat_id, t.priority, m.username, t.updated_at,
+           e.level, e.task_updated_at, e.notified_at
+    FROM tasks t
+    JOIN projects p ON p.id = t.project_id
+    JOIN teams tm ON tm.id = p.team_id
+    JOIN team_members m ON m.id = t.assigned_to
+    LEFT JOIN task_escalations e ON e.task_id = t.id
+    WHERE t.status = 'todo' AND t.priority <= %s
+    ORDER BY t.priority ASC, t.updated_at ASC`, placeholders[0])
+
+    rows, err := db.conn.Query(query, maxPriority)
+    if err != nil {
+        return nil, fmt.Errorf("eskalatsiya vazifalarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var candidates []EscalationCandidate
+    for rows.Next() {
+        var candidate EscalationCandidate
+        var level sql.NullInt64
+        var taskUpdatedAt, notifiedAt sql.NullTime
+        if err := rows.Scan(&candidate.TaskID, &candidate.Title, &candidate.ProjectName, &candidate.TeamID,
+            &candidate.ChatID, &candidate.Priority, &candidate.Assignee, &candidate.TodoSince,
+            &level, &taskUpdatedAt, &notifiedAt); err != nil {
+            return nil, fmt.Errorf("eskalatsiya vazifasini o'qishda xatolik: %w", err)
+        }
+
+        if level.Valid && taskUpdatedAt.Valid && taskUpdatedAt.Time.Equal(candidate.TodoSince.UTC().Truncate(time.Second)) {
+            candidate.Level = int(level.Int64)
+            candidate.NotifiedAt = notifiedAt.Time
+        }
+        candidates = append(candidates, candidate)
+    }
+
+    return candidates, nil
+}
+
+// SetTaskEscalation records the escalation level reached for a task
+func (db *DB) SetTaskEscalation(taskID string, level int, taskUpdatedAt, notifiedAt time.Time) error {
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    INSERT INTO task_escalations (task_id, level, task_updated_at, notified_at)
+    VALUES (%s, %s, %s, %s)
+    ON CONFLICT(task_id) DO UPDATE SET
+        level = EXCLUDED.level,
+        task_updated_at = EXCLUDED.task_updated_at,
+        notified_at = EXCLUDED.notified_at`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    _, err := db.conn.Exec(query, taskID, level,
+        taskUpdatedAt.UTC().Truncate(time.Second), notifiedAt.UTC().Truncate(time.Second))
+    if err != nil {
+        return fmt.Errorf("eskalatsiya holatini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
@@ -0,0 +1,55 @@
+package database
+
+import "fmt"
+
+// EstimateBounds holds the optimistic and pessimistic hours of a task
+type EstimateBounds struct {
+    OptimisticHours  float64
+    PessimisticHours float64
+}
+
+// SetTaskEstimateBounds replaces the optimistic and pessimistic estimate of a task
+func (db *DB) SetTaskEstimateBounds(taskID string, optimistic, pessimistic float64) error {
+    placeholders := db.getPlaceholders(3)
+    deleteQuery := fmt.Sprintf("DELETE FROM task_estimate_ranges WHERE task_id = %s", placeholders[0])
+    if _, err := db.conn.Exec(deleteQuery, taskID); err != nil {
+        return fmt.Errorf("baholash oralig'ini o'chirishda xatolik: %w", err)
+    }
+
+    insertQuery := fmt.Sprintf(`
+    INSERT INTO task_estimate_ranges (task_id, optimistic_hours, pessimistic_hours)
+    VALUES (%s, %s, %s)`, placeholders[0], placeholders[1], placeholders[2])
+    if _, err := db.conn.Exec(insertQuery, taskID, optimistic, pessimistic); err != nil {
+        return fmt.Errorf("baholash oralig'ini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// GetProjectEstimateBounds returns the estimate bounds of a project's tasks by task ID
+func (db *DB) GetProjectEstimateBounds(projectID string) (map[string]EstimateBounds, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT r.task_id, r.optimistic_hours, r.pessimistic_hours
+    FROM task_estimate_ranges r
+    JOIN tasks t ON t.id = r.task_id
+    WHERE t.project_id = %s`, placeholders[0])
+
+    rows, err := db.conn.Query(query, projectID)
+    if err != nil {
+        return nil, fmt.Errorf("baholash oraliqlarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    bounds := make(map[string]EstimateBounds)
+    for rows.Next() {
+        var taskID string
+        var b EstimateBounds
+        if err := rows.Scan(&taskID, &b.OptimisticHours, &b.PessimisticHours); err != nil {
+            return nil, fmt.Errorf("baholash oralig'ini o'qishda xatolik: %w", err)
+        }
+        bounds[taskID] = b
+    }
+
+    return bounds, nil
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "strings"
+    "time"
+)
+
+// RecordFeatureUse counts one use of a command by a user and returns how often they used it
+func (db *DB) RecordFeatureUse(telegramID int64, command string) (int, error) {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf(`
+    INSERT INTO feature_usage (telegram_id, command, uses, last_used_at)
+    VALUES (%s, %s, 1, %s)
+    ON CONFLICT(telegram_id, command) DO UPDATE SET
+        uses = feature_usage.uses + 1,
+        last_used_at = EXCLUDED.last_used_at`,
+        placeholders[0], placeholders[1], placeholders[2])
+
+    if _, err := db.conn.Exec(query, telegramID, command, time.Now().Unix()); err != nil {
+        return 0, fmt.Errorf("buyruq ishlatilishini saqlashda xatolik: %w", err)
+    }
+
+    var uses int
+    selectQuery := fmt.Sprintf("SELECT uses FROM feature_usage WHERE telegram_id = %s AND command = %s", placeholders[0], placeholders[1])
+    if err := db.conn.QueryRow(selectQuery, telegramID, command).Scan(&uses); err != nil {
+        return 0, fmt.Errorf("buyruq ishlatilishini olishda xatolik: %w", err)
+    }
+
+    return uses, nil
+}
+
+// GetFeatureUsage returns how often a user used each command
+func (db *DB) GetFeatureUsage(telegramID int64) (map[string]int, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("SELECT command, uses FROM feature_usage WHERE telegram_id = %s", placeholders[0])
+
+    rows, err := db.conn.Query(query, telegramID)
+    if err != nil {
+        return nil, fmt.Errorf("foydalanuvchi profilini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    usage := make(map[string]int)
+    for rows.Next() {
+        var command string
+        var uses int
+        if err := rows.Scan(&command, &uses); err != nil {
+            return nil, fmt.Errorf("foydalanuvchi profilini o'qishda xatolik: %w", err)
+        }
+        usage[command] = uses
+    }
+
+    return usage, nil
+}
+
+// GetHintState returns whether a user turned hints off, when they were last shown one and
+// the commands they were already hinted at
+func (db *DB) GetHintState(telegramID int64) (bool, time.Time, []string, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("SELECT disabled, last_hint_at, shown FROM user_hints WHERE telegram_id = %s", placeholders[0])
+
+    var disabled bool
+    var lastHintAt int64
+    var shown string
+    err := db.conn.QueryRow(query, telegramID).Scan(&disabled, &lastHintAt, &shown)
+    if err == sql.ErrNoRows {
+        return false, time.Time{}, nil, nil
+    }
+    if err != nil {
+        return false, time.Time{}, nil, fmt.Errorf("maslahat holatini olishda xatolik: %w", err)
+    }
+
+    var hinted []string
+    if shown != "" {
+        hinted = strings.Split(shown, ",")
+    }
+    var last time.Time
+    if lastHintAt > 0 {
+        last = time.Unix(lastHintAt, 0)
+    }
+    return disabled, last, hinted, nil
+}
+
+// SaveHintShown records that a user was hinted at a command just now
+func (db *DB) SaveHintShown(telegramID int64, command string) error {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf(`
+    INSERT INTO user_hints (telegram_id, last_hint_at, shown)
+    VALUES (%s, %s, %s)
+    ON CONFLICT(telegram_id) DO UPDATE SET
+        last_hint_at = EXCLUDED.last_hint_at,
+        shown = CASE WHEN user_hints.shown = '' THEN EXCLUDED.shown
+            ELSE user_hints.shown || ',' || EXCLUDED.shown END`,
+        placeholders[0], placeholders[1], placeholders[2])
+
+    if _, err := db.conn.Exec(query, telegramID, time.Now().Unix(), command); err != nil {
+        return fmt.Errorf("maslahatni saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// SetHintsDisabled turns the "did you know" hints of a user off or back on
+func (db *DB) SetHintsDisabled(telegramID int64, disabled bool) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    INSERT INTO user_hints (telegram_id, disabled)
+    VALUES (%s, %s)
+    ON CONFLICT(telegram_id) DO UPDATE SET
+        disabled = EXCLUDED.disabled`,
+        placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, telegramID, disabled); err != nil {
+        return fmt.Errorf("maslahat sozlamasini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
@@ -0,0 +1,157 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+// FeedSubscription is an RSS or Atom feed a chat follows. ETag and LastModified hold the
+// validators of the last response so unchanged feeds are not downloaded again.
+type FeedSubscription struct {
+    ID           int64     `json:"id"`
+    ChatID       int64     `json:"chat_id"`
+    URL          string    `json:"url"`
+    Title        string    `json:"title"`
+    ETag         string    `json:"etag"`
+    LastModified string    `json:"last_modified"`
+    CheckedAt    time.Time `json:"checked_at"`
+    CreatedBy    int64     `json:"created_by"`
+    CreatedAt    time.Time `json:"created_at"`
+}
+
+// AddFeedSubscription subscribes a chat to a feed and sets the subscription's ID. It reports
+// false when the chat already follows the feed.
+func (db *DB) AddFeedSubscription(subscription *FeedSubscription) (bool, error) {
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    INSERT INTO feed_subscriptions (chat_id, url, title, created_by)
+    VALUES (%s, %s, %s, %s)
+    ON CONFLICT(chat_id, url) DO NOTHING
+    RETURNING id`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    err := db.conn.QueryRow(query, subscription.ChatID, subscription.URL, subscription.Title, subscription.CreatedBy).Scan(&subscription.ID)
+    if err == sql.ErrNoRows {
+        return false, nil
+    }
+    if err != nil {
+        return false, fmt.Errorf("lenta obunasini saqlashda xatolik: %w", err)
+    }
+
+    return true, nil
+}
+
+// GetFeedSubscriptions returns the feeds a chat follows in the order they were added
+func (db *DB) GetFeedSubscriptions(chatID int64) ([]FeedSubscription, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT id, chat_id, url, title, etag, last_modified, checked_at, created_by, created_at
+    FROM feed_subscriptions
+    WHERE chat_id = %s
+    ORDER BY id ASC`, placeholders[0])
+
+    return db.queryFeedSubscriptions(query, chatID)
+}
+
+// GetDueFeedSubscriptions returns subscriptions never checked or last checked before the
+// given time, least recently checked first
+func (db *DB) GetDueFeedSubscriptions(before time.Time, limit int) ([]FeedSubscription, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT id, chat_id, url, title, etag, last_modified, checked_at, created_by, created_at
+    FROM feed_subscriptions
+    WHERE checked_at IS NULL OR checked_at < %s
+    ORDER BY checked_at ASC
+    LIMIT %s`, placeholders[0], placeholders[1])
+
+    return db.queryFeedSubscriptions(query, before.UTC().Truncate(time.Second), limit)
+}
+
+// UpdateFeedSubscription stores the feed title, the validators of the last response and
+// when the feed was checked
+func (db *DB) UpdateFeedSubscription(subscription *FeedSubscription) error {
+    placeholders := db.getPlaceholders(5)
+    query := fmt.Sprintf(`
+    UPDATE feed_subscriptions SET title = %s, etag = %s, last_modified = %s, checked_at = %s
+    WHERE id = %s`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3], placeholders[4])
+
+    checkedAt := subscription.CheckedAt.UTC().Truncate(time.Second)
+    if _, err := db.conn.Exec(query, subscription.Title, subscription.ETag, subscription.LastModified, checkedAt, subscription.ID); err != nil {
+        return fmt.Errorf("lenta obunasini yangilashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// DeleteFeedSubscription unsubscribes a chat from a feed and forgets its seen entries. It
+// reports whether the subscription was found.
+func (db *DB) DeleteFeedSubscription(chatID, id int64) (bool, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf("DELETE FROM feed_subscriptions WHERE id = %s AND chat_id = %s", placeholders[0], placeholders[1])
+
+    removed, err := db.execCount(query, id, chatID)
+    if err != nil {
+        return false, fmt.Errorf("lenta obunasini o'chirishda xatolik: %w", err)
+    }
+    if removed == 0 {
+        return false, nil
+    }
+
+    query = fmt.Sprintf("DELETE FROM feed_entries_seen WHERE subscription_id = %s", placeholders[0])
+    if _, err := db.conn.Exec(query, id); err != nil {
+        return true, fmt.Errorf("lenta yozuvlarini o'chirishda xatolik: %w", err)
+    }
+
+    return true, nil
+}
+
+// MarkFeedEntriesSeen records the entries of a subscription as posted and returns the IDs
+// that had not been seen before, in the given order
+func (db *DB) MarkFeedEntriesSeen(subscriptionID int64, entryIDs []string) ([]string, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    INSERT INTO feed_entries_seen (subscription_id, entry_id)
+    VALUES (%s, %s)
+    ON CONFLICT(subscription_id, entry_id) DO NOTHING`,
+        placeholders[0], placeholders[1])
+
+    var fresh []string
+    for _, entryID := range entryIDs {
+        added, err := db.execCount(query, subscriptionID, entryID)
+        if err != nil {
+            return fresh, fmt.Errorf("lenta yozuvini saqlashda xatolik: %w", err)
+        }
+        if added > 0 {
+            fresh = append(fresh, entryID)
+        }
+    }
+
+    return fresh, nil
+}
+
+// queryFeedSubscriptions runs a feed subscription query and scans the rows
+func (db *DB) queryFeedSubscriptions(query string, args ...interface{}) ([]FeedSubscription, error) {
+    rows, err := db.conn.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("lenta obunalarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var subscriptions []FeedSubscription
+    for rows.Next() {
+        var subscription FeedSubscription
+        var checkedAt sql.NullTime
+        if err := rows.Scan(&subscription.ID, &subscription.ChatID, &subscription.URL, &subscription.Title,
+            &subscription.ETag, &subscription.LastModified, &checkedAt, &subscription.CreatedBy, &subscription.CreatedAt); err != nil {
+            return nil, fmt.Errorf("lenta obunasini o'qishda xatolik: %w", err)
+        }
+        if checkedAt.Valid {
+            subscription.CheckedAt = checkedAt.Time
+        }
+        subscriptions = append(subscriptions, subscription)
+    }
+
+    return subscriptions, nil
+}
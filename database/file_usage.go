@@ -0,0 +1,44 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+)
+
+// GetFileUsage returns how many files and bytes a team has had processed in a period (e.g. "2026-10")
+func (db *DB) GetFileUsage(teamID, period string) (int, int64, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf("SELECT files, bytes FROM file_usage WHERE team_id = %s AND period = %s",
+        placeholders[0], placeholders[1])
+
+    var files int
+    var bytes int64
+    err := db.conn.QueryRow(query, teamID, period).Scan(&files, &bytes)
+    if err == sql.ErrNoRows {
+        return 0, 0, nil
+    }
+    if err != nil {
+        return 0, 0, fmt.Errorf("fayl foydalanishini olishda xatolik: %w", err)
+    }
+
+    return files, bytes, nil
+}
+
+// AddFileUsage records processed files and their total size for a team in a period
+func (db *DB) AddFileUsage(teamID, period string, files int, bytes int64) error {
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    INSERT INTO file_usage (team_id, period, files, bytes)
+    VALUES (%s, %s, %s, %s)
+    ON CONFLICT(team_id, period) DO UPDATE SET
+        files = file_usage.files + excluded.files,
+        bytes = file_usage.bytes + excluded.bytes,
+        updated_at = CURRENT_TIMESTAMP`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    if _, err := db.conn.Exec(query, teamID, period, files, bytes); err != nil {
+        return fmt.Errorf("fayl foydalanishini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+    "log"
+    "os"
+
+    "yordamchi-dev-bot/database/migrations"
+)
+
+// migrate brings the schema up to date with the embedded migrations
+func (db *DB) migrate() error {
+    migrator, err := migrations.New(db.pool, db.driver)
+    if err != nil {
+        return err
+    }
+
+    applied, err := migrator.Up(context.Background())
+    for _, migration := range applied {
+        log.Printf("🗄️ Migratsiya qo'llandi: %04d_%s", migration.Version, migration.Name)
+    }
+    return err
+}
+
+// Connect opens the bot's database without migrating it, for tools such as cmd/migrate:
+// the SQLite file at path, PostgreSQL when path is empty and DB_TYPE is postgres, or the
+// default SQLite file. It also returns the driver name.
+func Connect(path string) (*sql.DB, string, error) {
+    driver, source := "sqlite3", path
+    if path == "" {
+        source = defaultSQLitePath
+        if os.Getenv("DB_TYPE") == "postgres" {
+            driver, source = "postgres", postgresURL()
+        }
+    }
+
+    conn, err := sql.Open(driver, source)
+    if err == nil {
+        err = conn.Ping()
+    }
+    if err != nil {
+        if conn != nil {
+            conn.Close()
+        }
+        return nil, "", fmt.Errorf("ma'lumotlar bazasiga ulanishda xatolik: %w", err)
+    }
+    return conn, driver, nil
+}
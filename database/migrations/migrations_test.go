@@ -0,0 +1,144 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openSQLite opens an empty SQLite database in a temporary directory
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "migrations.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// tableExists reports whether db has a table named name
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&count); err != nil {
+		t.Fatalf("reading sqlite_master: %v", err)
+	}
+	return count > 0
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	sqlite, err := New(nil, "sqlite3")
+	if err != nil {
+		t.Fatalf("New(sqlite3) error = %v", err)
+	}
+	postgres, err := New(nil, "postgres")
+	if err != nil {
+		t.Fatalf("New(postgres) error = %v", err)
+	}
+
+	// Both dialects have to move through the same versions
+	if len(sqlite.Migrations()) != len(postgres.Migrations()) {
+		t.Fatalf("sqlite has %d migrations, postgres %d", len(sqlite.Migrations()), len(postgres.Migrations()))
+	}
+	for i, migration := range sqlite.Migrations() {
+		other := postgres.Migrations()[i]
+		if migration.Version != i+1 || other.Version != migration.Version || other.Name != migration.Name {
+			t.Errorf("migration %d is %d_%s in sqlite and %d_%s in postgres, want matching versions from 1",
+				i, migration.Version, migration.Name, other.Version, other.Name)
+		}
+	}
+
+	if _, err := New(nil, "mysql"); err == nil {
+		t.Error("expected an unknown driver to fail")
+	}
+}
+
+func TestUpAndDown(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+
+	// A database from before migrations keeps its rows
+	if _, err := db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY AUTOINCREMENT, telegram_id INTEGER UNIQUE NOT NULL, username TEXT, first_name TEXT, last_name TEXT, created_at DATETIME, updated_at DATETIME)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("INSERT INTO users (telegram_id) VALUES (42)"); err != nil {
+		t.Fatal(err)
+	}
+
+	migrator, err := New(db, "sqlite3")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if len(applied) != len(migrator.Migrations()) {
+		t.Errorf("Up() applied %d migrations, want all %d", len(applied), len(migrator.Migrations()))
+	}
+	var users int
+	db.QueryRow("SELECT COUNT(*) FROM users").Scan(&users)
+	if users != 1 || !tableExists(t, db, "project_webhooks") {
+		t.Errorf("after Up() users = %d, want the existing user and the whole schema", users)
+	}
+
+	if again, err := migrator.Up(ctx); err != nil || len(again) != 0 {
+		t.Errorf("second Up() = %d migrations, %v, want nothing to do", len(again), err)
+	}
+	if version, _ := migrator.Version(ctx); version != len(migrator.Migrations()) {
+		t.Errorf("Version() = %d, want %d", version, len(migrator.Migrations()))
+	}
+
+	reverted, err := migrator.Down(ctx, len(migrator.Migrations()))
+	if err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+	if len(reverted) != len(migrator.Migrations()) || tableExists(t, db, "users") {
+		t.Errorf("Down() reverted %d migrations, want all and no tables left", len(reverted))
+	}
+	if pending, _ := migrator.Pending(ctx); len(pending) != len(migrator.Migrations()) {
+		t.Errorf("Pending() after Down() = %d, want all", len(pending))
+	}
+}
+
+func TestFailedMigrationIsRolledBack(t *testing.T) {
+	files := fstest.MapFS{
+		"test/0001_notes.up.sql":      {Data: []byte("CREATE TABLE notes (id INTEGER PRIMARY KEY, text TEXT);")},
+		"test/0001_notes.down.sql":    {Data: []byte("DROP TABLE notes;")},
+		"test/0002_labels.up.sql":     {Data: []byte("CREATE TABLE labels (id INTEGER PRIMARY KEY); ALTER TABLE missing ADD COLUMN label TEXT;")},
+		"test/0002_labels.down.sql":   {Data: []byte("DROP TABLE labels;")},
+		"test/0003_archived.up.sql":   {Data: []byte("ALTER TABLE notes ADD COLUMN archived INTEGER;")},
+		"test/0003_archived.down.sql": {Data: []byte("ALTER TABLE notes DROP COLUMN archived;")},
+	}
+	db := openSQLite(t)
+	migrator, err := newMigrator(db, false, files, "test")
+	if err != nil {
+		t.Fatalf("newMigrator() error = %v", err)
+	}
+
+	applied, err := migrator.Up(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "0002") {
+		t.Fatalf("Up() error = %v, want migration 2 to fail", err)
+	}
+	if len(applied) != 1 || applied[0].Name != "notes" {
+		t.Errorf("Up() applied %v, want only the notes migration", applied)
+	}
+	if tableExists(t, db, "labels") {
+		t.Error("expected the failed migration's table to be rolled back")
+	}
+	if version, _ := migrator.Version(context.Background()); version != 1 {
+		t.Errorf("Version() = %d, want 1", version)
+	}
+
+	// A version without a down file is rejected before anything runs
+	delete(files, "test/0003_archived.down.sql")
+	if _, err := newMigrator(db, false, files, "test"); err == nil {
+		t.Error("expected a migration without a down file to be rejected")
+	}
+}
@@ -0,0 +1,3 @@
+-- Scheduled messages that failed for a passing reason are sent again; attempts counts
+-- the failed sends so far.
+ALTER TABLE scheduled_messages ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,38 @@
+DROP TABLE IF EXISTS project_webhooks;
+DROP TABLE IF EXISTS task_comments;
+DROP TABLE IF EXISTS dependency_watches;
+DROP TABLE IF EXISTS feed_entries_seen;
+DROP TABLE IF EXISTS feed_subscriptions;
+DROP TABLE IF EXISTS challenge_completions;
+DROP TABLE IF EXISTS user_hints;
+DROP TABLE IF EXISTS feature_usage;
+DROP TABLE IF EXISTS utilization_snapshots;
+DROP TABLE IF EXISTS mood_respondents;
+DROP TABLE IF EXISTS mood_results;
+DROP TABLE IF EXISTS embeddings;
+DROP TABLE IF EXISTS task_releases;
+DROP TABLE IF EXISTS deployments;
+DROP TABLE IF EXISTS task_attachments;
+DROP TABLE IF EXISTS task_commits;
+DROP TABLE IF EXISTS task_keys;
+DROP TABLE IF EXISTS task_due_dates;
+DROP TABLE IF EXISTS task_estimate_ranges;
+DROP TABLE IF EXISTS task_acceptance_criteria;
+DROP TABLE IF EXISTS analysis_runs;
+DROP TABLE IF EXISTS email_subscriptions;
+DROP TABLE IF EXISTS project_shares;
+DROP TABLE IF EXISTS scheduled_messages;
+DROP TABLE IF EXISTS snippets;
+DROP TABLE IF EXISTS chat_settings;
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS task_escalations;
+DROP TABLE IF EXISTS project_health;
+DROP TABLE IF EXISTS file_usage;
+DROP TABLE IF EXISTS ai_usage;
+DROP TABLE IF EXISTS team_settings;
+DROP TABLE IF EXISTS tasks;
+DROP TABLE IF EXISTS projects;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
+DROP TABLE IF EXISTS user_activity;
+DROP TABLE IF EXISTS users;
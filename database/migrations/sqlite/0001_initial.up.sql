@@ -0,0 +1,370 @@
+-- The schema the bot created at startup before it had migrations. Every statement is
+-- IF NOT EXISTS, so databases from that time keep their data and only get version 1
+-- recorded.
+
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    telegram_id INTEGER UNIQUE NOT NULL,
+    username TEXT,
+    first_name TEXT,
+    last_name TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS user_activity (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER,
+    command TEXT,
+    timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+CREATE TABLE IF NOT EXISTS teams (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    chat_id INTEGER UNIQUE NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS team_members (
+    id TEXT PRIMARY KEY,
+    team_id TEXT NOT NULL,
+    user_id INTEGER NOT NULL,
+    username TEXT NOT NULL,
+    role TEXT DEFAULT 'developer',
+    skills TEXT,
+    capacity REAL DEFAULT 40.0,
+    current_workload REAL DEFAULT 0.0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (team_id) REFERENCES teams (id),
+    FOREIGN KEY (user_id) REFERENCES users (telegram_id)
+);
+
+CREATE TABLE IF NOT EXISTS projects (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    team_id TEXT NOT NULL,
+    status TEXT DEFAULT 'active',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (team_id) REFERENCES teams (id)
+);
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT,
+    category TEXT NOT NULL,
+    estimate_hours REAL DEFAULT 0.0,
+    actual_hours REAL DEFAULT 0.0,
+    status TEXT DEFAULT 'todo',
+    priority INTEGER DEFAULT 3,
+    assigned_to TEXT,
+    dependencies TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    completed_at DATETIME,
+    FOREIGN KEY (project_id) REFERENCES projects (id),
+    FOREIGN KEY (assigned_to) REFERENCES team_members (id)
+);
+
+CREATE TABLE IF NOT EXISTS team_settings (
+    team_id TEXT NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_id, key)
+);
+
+CREATE TABLE IF NOT EXISTS ai_usage (
+    team_id TEXT NOT NULL,
+    period TEXT NOT NULL,
+    analyses INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_id, period)
+);
+
+CREATE TABLE IF NOT EXISTS file_usage (
+    team_id TEXT NOT NULL,
+    period TEXT NOT NULL,
+    files INTEGER NOT NULL DEFAULT 0,
+    bytes BIGINT NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_id, period)
+);
+
+CREATE TABLE IF NOT EXISTS project_health (
+    project_id TEXT PRIMARY KEY,
+    deadline DATETIME,
+    score INTEGER NOT NULL DEFAULT 100,
+    level TEXT NOT NULL DEFAULT '',
+    computed_at DATETIME,
+    FOREIGN KEY (project_id) REFERENCES projects (id)
+);
+
+CREATE TABLE IF NOT EXISTS task_escalations (
+    task_id TEXT PRIMARY KEY,
+    level INTEGER NOT NULL DEFAULT 0,
+    task_updated_at DATETIME NOT NULL,
+    notified_at DATETIME NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks (id)
+);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chat_id INTEGER NOT NULL,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    subject TEXT,
+    details TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_chat ON audit_log (chat_id, created_at);
+
+CREATE TABLE IF NOT EXISTS chat_settings (
+    chat_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chat_id, key)
+);
+
+CREATE TABLE IF NOT EXISTS snippets (
+    chat_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    content TEXT NOT NULL,
+    usage_count INTEGER DEFAULT 0,
+    created_by INTEGER DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chat_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS scheduled_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chat_id INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    send_at DATETIME NOT NULL,
+    created_by INTEGER DEFAULT 0,
+    status TEXT DEFAULT 'pending',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_messages_due ON scheduled_messages(status, send_at);
+
+CREATE TABLE IF NOT EXISTS project_shares (
+    token TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL,
+    chat_id INTEGER NOT NULL,
+    created_by INTEGER DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    revoked_at DATETIME,
+    FOREIGN KEY (project_id) REFERENCES projects (id)
+);
+
+CREATE TABLE IF NOT EXISTS email_subscriptions (
+    user_id INTEGER PRIMARY KEY,
+    email TEXT NOT NULL,
+    digest BOOLEAN NOT NULL DEFAULT TRUE,
+    alerts BOOLEAN NOT NULL DEFAULT TRUE,
+    token TEXT NOT NULL UNIQUE,
+    last_digest_at DATETIME,
+    last_alert_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS analysis_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chat_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    requirement_hash TEXT NOT NULL,
+    requirement TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL DEFAULT '',
+    tokens_used INTEGER NOT NULL DEFAULT 0,
+    total_estimate REAL NOT NULL DEFAULT 0,
+    task_count INTEGER NOT NULL DEFAULT 0,
+    result TEXT NOT NULL,
+    saved BOOLEAN NOT NULL DEFAULT FALSE,
+    project_id TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_analysis_runs_chat ON analysis_runs (chat_id, created_at);
+
+CREATE TABLE IF NOT EXISTS task_acceptance_criteria (
+    task_id TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    criterion TEXT NOT NULL,
+    PRIMARY KEY (task_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS task_estimate_ranges (
+    task_id TEXT PRIMARY KEY,
+    optimistic_hours REAL NOT NULL DEFAULT 0,
+    pessimistic_hours REAL NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS task_due_dates (
+    task_id TEXT PRIMARY KEY,
+    due_date TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS task_keys (
+    task_id TEXT PRIMARY KEY,
+    team_id TEXT NOT NULL,
+    number INTEGER NOT NULL,
+    UNIQUE (team_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS task_commits (
+    task_id TEXT NOT NULL,
+    sha TEXT NOT NULL,
+    repository TEXT NOT NULL,
+    message TEXT NOT NULL,
+    author TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    committed_at DATETIME,
+    PRIMARY KEY (task_id, sha)
+);
+
+CREATE TABLE IF NOT EXISTS task_attachments (
+    task_id TEXT NOT NULL,
+    file_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, file_id)
+);
+
+CREATE TABLE IF NOT EXISTS deployments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id TEXT NOT NULL,
+    version TEXT NOT NULL,
+    deployed_by TEXT NOT NULL DEFAULT '',
+    deployed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (project_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS task_releases (
+    task_id TEXT PRIMARY KEY,
+    deployment_id INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS embeddings (
+    kind TEXT NOT NULL,
+    chat_id BIGINT NOT NULL,
+    ref_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    model TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    vector BLOB NOT NULL,
+    indexed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (kind, chat_id, ref_id)
+);
+CREATE INDEX IF NOT EXISTS idx_embeddings_chat ON embeddings (chat_id, model);
+
+CREATE TABLE IF NOT EXISTS mood_results (
+    team_id TEXT NOT NULL,
+    week TEXT NOT NULL,
+    score INTEGER NOT NULL,
+    responses INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (team_id, week, score)
+);
+
+CREATE TABLE IF NOT EXISTS mood_respondents (
+    team_id TEXT NOT NULL,
+    week TEXT NOT NULL,
+    respondent TEXT NOT NULL,
+    PRIMARY KEY (team_id, week, respondent)
+);
+
+CREATE TABLE IF NOT EXISTS utilization_snapshots (
+    member_id TEXT NOT NULL,
+    day TEXT NOT NULL,
+    chat_id BIGINT NOT NULL,
+    capacity REAL NOT NULL DEFAULT 0,
+    allocated REAL NOT NULL DEFAULT 0,
+    PRIMARY KEY (member_id, day)
+);
+
+CREATE TABLE IF NOT EXISTS feature_usage (
+    telegram_id BIGINT NOT NULL,
+    command TEXT NOT NULL,
+    uses INTEGER NOT NULL DEFAULT 0,
+    last_used_at INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (telegram_id, command)
+);
+
+CREATE TABLE IF NOT EXISTS user_hints (
+    telegram_id BIGINT PRIMARY KEY,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_hint_at INTEGER NOT NULL DEFAULT 0,
+    shown TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS challenge_completions (
+    telegram_id BIGINT NOT NULL,
+    day TEXT NOT NULL,
+    challenge_id TEXT NOT NULL,
+    completed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (telegram_id, day)
+);
+
+CREATE TABLE IF NOT EXISTS feed_subscriptions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chat_id INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    etag TEXT NOT NULL DEFAULT '',
+    last_modified TEXT NOT NULL DEFAULT '',
+    checked_at DATETIME,
+    created_by INTEGER DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (chat_id, url)
+);
+
+CREATE TABLE IF NOT EXISTS feed_entries_seen (
+    subscription_id INTEGER NOT NULL,
+    entry_id TEXT NOT NULL,
+    seen_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (subscription_id, entry_id)
+);
+
+CREATE TABLE IF NOT EXISTS dependency_watches (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chat_id INTEGER NOT NULL,
+    repository TEXT NOT NULL,
+    created_by INTEGER DEFAULT 0,
+    reported_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (chat_id, repository)
+);
+
+CREATE TABLE IF NOT EXISTS task_comments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id TEXT NOT NULL,
+    chat_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    author TEXT NOT NULL DEFAULT '',
+    text TEXT NOT NULL,
+    message_id INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comments_task ON task_comments (task_id, created_at);
+
+CREATE TABLE IF NOT EXISTS project_webhooks (
+    project_id TEXT PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (project_id) REFERENCES projects (id)
+);
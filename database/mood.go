@@ -0,0 +1,73 @@
+package database
+
+import (
+    "fmt"
+)
+
+// MoodResult is how many members of a team picked each check-in score in a week. Answers
+// are stored only as these counts, so they cannot be traced back to a member.
+type MoodResult struct {
+    Week   string
+    Counts map[int]int
+}
+
+// RecordMoodAnswer counts a check-in answer once per respondent and week. The respondent is
+// an opaque key kept apart from the counts; it reports false when they already answered.
+func (db *DB) RecordMoodAnswer(teamID, week, respondent string, score int) (bool, error) {
+    placeholders := db.getPlaceholders(4)
+    respondentQuery := fmt.Sprintf(`
+    INSERT INTO mood_respondents (team_id, week, respondent)
+    VALUES (%s, %s, %s)
+    ON CONFLICT(team_id, week, respondent) DO NOTHING`,
+        placeholders[0], placeholders[1], placeholders[2])
+
+    result, err := db.conn.Exec(respondentQuery, teamID, week, respondent)
+    if err != nil {
+        return false, fmt.Errorf("kayfiyat javobini saqlashda xatolik: %w", err)
+    }
+    if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+        return false, nil
+    }
+
+    resultQuery := fmt.Sprintf(`
+    INSERT INTO mood_results (team_id, week, score, responses)
+    VALUES (%s, %s, %s, 1)
+    ON CONFLICT(team_id, week, score) DO UPDATE SET
+        responses = mood_results.responses + 1`,
+        placeholders[0], placeholders[1], placeholders[2])
+    if _, err := db.conn.Exec(resultQuery, teamID, week, score); err != nil {
+        return false, fmt.Errorf("kayfiyat natijasini saqlashda xatolik: %w", err)
+    }
+
+    return true, nil
+}
+
+// GetMoodResults returns a team's check-in results of the given weeks, in the same order.
+// Weeks without answers have empty counts.
+func (db *DB) GetMoodResults(teamID string, weeks []string) ([]MoodResult, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT score, responses FROM mood_results
+    WHERE team_id = %s AND week = %s`, placeholders[0], placeholders[1])
+
+    results := make([]MoodResult, len(weeks))
+    for i, week := range weeks {
+        results[i] = MoodResult{Week: week, Counts: make(map[int]int)}
+
+        rows, err := db.conn.Query(query, teamID, week)
+        if err != nil {
+            return nil, fmt.Errorf("kayfiyat natijalarini olishda xatolik: %w", err)
+        }
+        for rows.Next() {
+            var score, responses int
+            if err := rows.Scan(&score, &responses); err != nil {
+                rows.Close()
+                return nil, fmt.Errorf("kayfiyat natijasini o'qishda xatolik: %w", err)
+            }
+            results[i].Counts[score] = responses
+        }
+        rows.Close()
+    }
+
+    return results, nil
+}
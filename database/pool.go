@@ -0,0 +1,55 @@
+package database
+
+import (
+    "database/sql"
+    "os"
+    "strconv"
+    "time"
+)
+
+// PoolConfig sizes the PostgreSQL connection pool
+type PoolConfig struct {
+    MaxOpenConns    int
+    MaxIdleConns    int
+    ConnMaxLifetime time.Duration
+    ConnMaxIdleTime time.Duration
+}
+
+// LoadPoolConfig reads DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS, DB_CONN_MAX_LIFETIME and
+// DB_CONN_MAX_IDLE_TIME. Durations use Go syntax, e.g. 30m.
+func LoadPoolConfig() PoolConfig {
+    return PoolConfig{
+        MaxOpenConns:    poolEnvInt("DB_MAX_OPEN_CONNS", 20),
+        MaxIdleConns:    poolEnvInt("DB_MAX_IDLE_CONNS", 5),
+        ConnMaxLifetime: poolEnvDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+        ConnMaxIdleTime: poolEnvDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+    }
+}
+
+// apply configures the pool of conn
+func (c PoolConfig) apply(conn *sql.DB) {
+    conn.SetMaxOpenConns(c.MaxOpenConns)
+    conn.SetMaxIdleConns(c.MaxIdleConns)
+    conn.SetConnMaxLifetime(c.ConnMaxLifetime)
+    conn.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+}
+
+// PoolStats returns the connection pool statistics: connections in use and idle, and how
+// often and how long queries waited for a free connection
+func (db *DB) PoolStats() sql.DBStats {
+    return db.pool.Stats()
+}
+
+func poolEnvInt(name string, fallback int) int {
+    if value, err := strconv.Atoi(os.Getenv(name)); err == nil && value > 0 {
+        return value
+    }
+    return fallback
+}
+
+func poolEnvDuration(name string, fallback time.Duration) time.Duration {
+    if value, err := time.ParseDuration(os.Getenv(name)); err == nil && value > 0 {
+        return value
+    }
+    return fallback
+}
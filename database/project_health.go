@@ -0,0 +1,111 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+// ProjectHealthRecord is the stored health score and deadline of a project
+type ProjectHealthRecord struct {
+    ProjectID  string    `json:"project_id"`
+    Deadline   time.Time `json:"deadline"`
+    Score      int       `json:"score"`
+    Level      string    `json:"level"`
+    ComputedAt time.Time `json:"computed_at"`
+}
+
+// ActiveProject is an active project together with the chat of its team
+type ActiveProject struct {
+    Project
+    ChatID int64 `json:"chat_id"`
+}
+
+// GetProjectHealth returns the stored health of a project, or nil if none was recorded
+func (db *DB) GetProjectHealth(projectID string) (*ProjectHealthRecord, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT project_id, deadline, score, level, computed_at
+    FROM project_health
+    WHERE project_id = %s`, placeholders[0])
+
+    var record ProjectHealthRecord
+    var deadline, computedAt sql.NullTime
+    err := db.conn.QueryRow(query, projectID).Scan(&record.ProjectID, &deadline, &record.Score, &record.Level, &computedAt)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("loyiha holatini olishda xatolik: %w", err)
+    }
+
+    record.Deadline = deadline.Time
+    record.ComputedAt = computedAt.Time
+    return &record, nil
+}
+
+// SetProjectDeadline stores the target completion date of a project
+func (db *DB) SetProjectDeadline(projectID string, deadline time.Time) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    INSERT INTO project_health (project_id, deadline)
+    VALUES (%s, %s)
+    ON CONFLICT(project_id) DO UPDATE SET
+        deadline = EXCLUDED.deadline`,
+        placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, projectID, deadline.UTC().Truncate(time.Second)); err != nil {
+        return fmt.Errorf("loyiha muddatini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// SaveProjectHealth stores a computed health score, keeping the deadline
+func (db *DB) SaveProjectHealth(projectID string, score int, level string, computedAt time.Time) error {
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    INSERT INTO project_health (project_id, score, level, computed_at)
+    VALUES (%s, %s, %s, %s)
+    ON CONFLICT(project_id) DO UPDATE SET
+        score = EXCLUDED.score,
+        level = EXCLUDED.level,
+        computed_at = EXCLUDED.computed_at`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    if _, err := db.conn.Exec(query, projectID, score, level, computedAt.UTC().Truncate(time.Second)); err != nil {
+        return fmt.Errorf("loyiha holatini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// GetActiveProjects returns all active projects with the chat of their team
+func (db *DB) GetActiveProjects() ([]ActiveProject, error) {
+    query := `
+    SELECT p.id, p.name, p.description, p.team_id, p.status, p.created_at, p.updated_at, t.chat_id
+    FROM projects p
+    JOIN teams t ON t.id = p.team_id
+    WHERE p.status = 'active'
+    ORDER BY p.created_at ASC`
+
+    rows, err := db.conn.Query(query)
+    if err != nil {
+        return nil, fmt.Errorf("faol loyihalarni olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var projects []ActiveProject
+    for rows.Next() {
+        var project ActiveProject
+        var description sql.NullString
+        if err := rows.Scan(&project.ID, &project.Name, &description, &project.TeamID, &project.Status,
+            &project.CreatedAt, &project.UpdatedAt, &project.ChatID); err != nil {
+            return nil, fmt.Errorf("loyiha ma'lumotlarini o'qishda xatolik: %w", err)
+        }
+        project.Description = description.String
+        projects = append(projects, project)
+    }
+
+    return projects, nil
+}
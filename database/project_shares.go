@@ -0,0 +1,100 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+// ProjectShare is a read-only access token to a project's status page
+type ProjectShare struct {
+    Token     string    `json:"token"`
+    ProjectID string    `json:"project_id"`
+    ChatID    int64     `json:"chat_id"`
+    CreatedBy int64     `json:"created_by"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateProjectShare stores a new share token
+func (db *DB) CreateProjectShare(share *ProjectShare) error {
+    if share.CreatedAt.IsZero() {
+        share.CreatedAt = time.Now()
+    }
+
+    placeholders := db.getPlaceholders(5)
+    query := fmt.Sprintf(`
+    INSERT INTO project_shares (token, project_id, chat_id, created_by, created_at)
+    VALUES (%s, %s, %s, %s, %s)`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3], placeholders[4])
+
+    _, err := db.conn.Exec(query, share.Token, share.ProjectID, share.ChatID, share.CreatedBy,
+        share.CreatedAt.UTC().Truncate(time.Second))
+    if err != nil {
+        return fmt.Errorf("ulashish havolasini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// GetProjectShare returns an active share by token, or nil if it does not exist or was revoked
+func (db *DB) GetProjectShare(token string) (*ProjectShare, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT token, project_id, chat_id, created_by, created_at
+    FROM project_shares
+    WHERE token = %s AND revoked_at IS NULL`, placeholders[0])
+
+    var share ProjectShare
+    err := db.conn.QueryRow(query, token).Scan(&share.Token, &share.ProjectID, &share.ChatID,
+        &share.CreatedBy, &share.CreatedAt)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("ulashish havolasini olishda xatolik: %w", err)
+    }
+
+    return &share, nil
+}
+
+// GetActiveProjectShares returns the active shares of a chat's projects
+func (db *DB) GetActiveProjectShares(chatID int64) ([]ProjectShare, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT token, project_id, chat_id, created_by, created_at
+    FROM project_shares
+    WHERE chat_id = %s AND revoked_at IS NULL
+    ORDER BY created_at ASC`, placeholders[0])
+
+    rows, err := db.conn.Query(query, chatID)
+    if err != nil {
+        return nil, fmt.Errorf("ulashish havolalarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var shares []ProjectShare
+    for rows.Next() {
+        var share ProjectShare
+        if err := rows.Scan(&share.Token, &share.ProjectID, &share.ChatID, &share.CreatedBy, &share.CreatedAt); err != nil {
+            return nil, fmt.Errorf("ulashish havolasini o'qishda xatolik: %w", err)
+        }
+        shares = append(shares, share)
+    }
+
+    return shares, nil
+}
+
+// RevokeProjectShares revokes all active shares of a project and returns how many were revoked
+func (db *DB) RevokeProjectShares(projectID string) (int64, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    UPDATE project_shares SET revoked_at = %s
+    WHERE project_id = %s AND revoked_at IS NULL`, placeholders[0], placeholders[1])
+
+    result, err := db.conn.Exec(query, time.Now().UTC().Truncate(time.Second), projectID)
+    if err != nil {
+        return 0, fmt.Errorf("ulashish havolalarini bekor qilishda xatolik: %w", err)
+    }
+
+    return result.RowsAffected()
+}
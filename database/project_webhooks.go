@@ -0,0 +1,55 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+)
+
+// GetProjectWebhook returns a project's outbound webhook URL, signing secret and
+// comma-separated events; the URL is empty when none is configured
+func (db *DB) GetProjectWebhook(projectID string) (string, string, string, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("SELECT url, secret, events FROM project_webhooks WHERE project_id = %s", placeholders[0])
+
+    var url, secret, events string
+    err := db.conn.QueryRow(query, projectID).Scan(&url, &secret, &events)
+    if err == sql.ErrNoRows {
+        return "", "", "", nil
+    }
+    if err != nil {
+        return "", "", "", fmt.Errorf("loyiha webhookini olishda xatolik: %w", err)
+    }
+
+    return url, secret, events, nil
+}
+
+// SetProjectWebhook creates or replaces a project's outbound webhook
+func (db *DB) SetProjectWebhook(projectID, url, secret, events string) error {
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    INSERT INTO project_webhooks (project_id, url, secret, events)
+    VALUES (%s, %s, %s, %s)
+    ON CONFLICT(project_id) DO UPDATE SET
+        url = EXCLUDED.url,
+        secret = EXCLUDED.secret,
+        events = EXCLUDED.events`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    if _, err := db.conn.Exec(query, projectID, url, secret, events); err != nil {
+        return fmt.Errorf("loyiha webhookini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// DeleteProjectWebhook removes a project's outbound webhook
+func (db *DB) DeleteProjectWebhook(projectID string) error {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("DELETE FROM project_webhooks WHERE project_id = %s", placeholders[0])
+
+    if _, err := db.conn.Exec(query, projectID); err != nil {
+        return fmt.Errorf("loyiha webhookini o'chirishda xatolik: %w", err)
+    }
+
+    return nil
+}
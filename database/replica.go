@@ -0,0 +1,60 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "log"
+    "os"
+)
+
+// attachReplica opens the optional read replica of DATABASE_REPLICA_URL. Without it, or
+// when the replica is unreachable at startup, analytics queries use the primary.
+func (db *DB) attachReplica(pool PoolConfig) {
+    replicaURL := os.Getenv("DATABASE_REPLICA_URL")
+    if replicaURL == "" {
+        return
+    }
+
+    conn, err := sql.Open("postgres", replicaURL)
+    if err == nil {
+        err = conn.Ping()
+    }
+    if err != nil {
+        log.Printf("⚠️ Read replica ishlatilmaydi, asosiy baza ishlatiladi: %v", err)
+        if conn != nil {
+            conn.Close()
+        }
+        return
+    }
+
+    pool.apply(conn)
+    db.replica = &DB{conn: conn, pool: conn, driver: db.driver}
+    log.Println("✅ Read replica ulandi")
+}
+
+// Analytics returns the database to run heavy read-only queries on: digests, statistics
+// and dashboards. It is the read replica when one is configured and the primary otherwise.
+// Replicas lag slightly behind, so reads that must see a write just made, and all writes,
+// stay on the primary.
+func (db *DB) Analytics() *DB {
+    if db.replica != nil {
+        return db.replica
+    }
+    return db
+}
+
+// HasReplica reports whether analytics queries go to a read replica
+func (db *DB) HasReplica() bool {
+    return db.replica != nil
+}
+
+// closeReplica closes the read replica connection when there is one
+func (db *DB) closeReplica() error {
+    if db.replica == nil {
+        return nil
+    }
+    if err := db.replica.pool.Close(); err != nil {
+        return fmt.Errorf("read replica'ni yopishda xatolik: %w", err)
+    }
+    return nil
+}
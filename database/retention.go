@@ -0,0 +1,71 @@
+package database
+
+import (
+    "fmt"
+    "time"
+)
+
+// PurgeUserActivity deletes logged commands older than before and returns how many were removed
+func (db *DB) PurgeUserActivity(before time.Time) (int64, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("DELETE FROM user_activity WHERE timestamp < %s", placeholders[0])
+
+    removed, err := db.execCount(query, before.UTC().Truncate(time.Second))
+    if err != nil {
+        return 0, fmt.Errorf("eski faoliyatni o'chirishda xatolik: %w", err)
+    }
+    return removed, nil
+}
+
+// PurgeAnalysisRuns deletes analysis history older than before. Tasks saved from a run stay
+// in their project.
+func (db *DB) PurgeAnalysisRuns(before time.Time) (int64, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("DELETE FROM analysis_runs WHERE created_at < %s", placeholders[0])
+
+    removed, err := db.execCount(query, before.UTC().Truncate(time.Second))
+    if err != nil {
+        return 0, fmt.Errorf("eski tahlillarni o'chirishda xatolik: %w", err)
+    }
+    return removed, nil
+}
+
+// PurgeTemporaryData deletes bookkeeping rows that are no longer needed: delivered or
+// cancelled scheduled messages, revoked share links and utilization snapshots older than
+// before, and mood check-in respondents of weeks before beforeWeek
+func (db *DB) PurgeTemporaryData(before time.Time, beforeWeek string) (int64, error) {
+    placeholders := db.getPlaceholders(2)
+    cutoff := before.UTC().Truncate(time.Second)
+    statements := []struct {
+        query string
+        args  []interface{}
+    }{
+        {fmt.Sprintf("DELETE FROM scheduled_messages WHERE status <> %s AND send_at < %s", placeholders[0], placeholders[1]),
+            []interface{}{ScheduledStatusPending, cutoff}},
+        {fmt.Sprintf("DELETE FROM project_shares WHERE revoked_at IS NOT NULL AND revoked_at < %s", placeholders[0]),
+            []interface{}{cutoff}},
+        {fmt.Sprintf("DELETE FROM utilization_snapshots WHERE day < %s", placeholders[0]),
+            []interface{}{before.Format(utilizationDayLayout)}},
+        {fmt.Sprintf("DELETE FROM mood_respondents WHERE week < %s", placeholders[0]),
+            []interface{}{beforeWeek}},
+    }
+
+    var total int64
+    for _, statement := range statements {
+        removed, err := db.execCount(statement.query, statement.args...)
+        if err != nil {
+            return total, fmt.Errorf("vaqtinchalik ma'lumotlarni o'chirishda xatolik: %w", err)
+        }
+        total += removed
+    }
+    return total, nil
+}
+
+// execCount runs a statement and returns the number of affected rows
+func (db *DB) execCount(query string, args ...interface{}) (int64, error) {
+    result, err := db.conn.Exec(query, args...)
+    if err != nil {
+        return 0, err
+    }
+    return result.RowsAffected()
+}
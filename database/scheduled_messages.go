@@ -0,0 +1,156 @@
+package database
+
+import (
+    "fmt"
+    "time"
+)
+
+// Scheduled message statuses
+const (
+    ScheduledStatusPending   = "pending"
+    ScheduledStatusSending   = "sending"
+    ScheduledStatusSent      = "sent"
+    ScheduledStatusFailed    = "failed"
+    ScheduledStatusCancelled = "cancelled"
+)
+
+// ScheduledMessage represents a message queued for delivery to a chat
+type ScheduledMessage struct {
+    ID        int64     `json:"id"`
+    ChatID    int64     `json:"chat_id"`
+    Text      string    `json:"text"`
+    SendAt    time.Time `json:"send_at"`
+    CreatedBy int64     `json:"created_by"`
+    Status    string    `json:"status"`
+    Attempts  int       `json:"attempts"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateScheduledMessage stores a pending message and sets its ID
+func (db *DB) CreateScheduledMessage(message *ScheduledMessage) error {
+    placeholders := db.getPlaceholders(5)
+    query := fmt.Sprintf(`
+    INSERT INTO scheduled_messages (chat_id, text, send_at, created_by, status)
+    VALUES (%s, %s, %s, %s, %s)
+    RETURNING id`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3], placeholders[4])
+
+    // Times are stored in UTC with second precision so they compare consistently
+    sendAt := message.SendAt.UTC().Truncate(time.Second)
+    err := db.conn.QueryRow(query, message.ChatID, message.Text, sendAt, message.CreatedBy, ScheduledStatusPending).Scan(&message.ID)
+    if err != nil {
+        return fmt.Errorf("rejalashtirilgan xabarni saqlashda xatolik: %w", err)
+    }
+
+    message.Status = ScheduledStatusPending
+    return nil
+}
+
+// GetPendingScheduledMessages returns pending messages of a chat ordered by delivery time
+func (db *DB) GetPendingScheduledMessages(chatID int64) ([]ScheduledMessage, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT id, chat_id, text, send_at, created_by, status, attempts, created_at
+    FROM scheduled_messages
+    WHERE chat_id = %s AND status = %s
+    ORDER BY send_at ASC`, placeholders[0], placeholders[1])
+
+    return db.queryScheduledMessages(query, chatID, ScheduledStatusPending)
+}
+
+// GetDueScheduledMessages returns pending messages whose delivery time has passed
+func (db *DB) GetDueScheduledMessages(now time.Time, limit int) ([]ScheduledMessage, error) {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf(`
+    SELECT id, chat_id, text, send_at, created_by, status, attempts, created_at
+    FROM scheduled_messages
+    WHERE status = %s AND send_at <= %s
+    ORDER BY send_at ASC
+    LIMIT %s`, placeholders[0], placeholders[1], placeholders[2])
+
+    return db.queryScheduledMessages(query, ScheduledStatusPending, now.UTC().Truncate(time.Second), limit)
+}
+
+// ClaimScheduledMessage marks a pending message as being sent and reports whether it was
+// still pending. A message cancelled or claimed in the meantime is not sent.
+func (db *DB) ClaimScheduledMessage(id int64) (bool, error) {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf("UPDATE scheduled_messages SET status = %s WHERE id = %s AND status = %s",
+        placeholders[0], placeholders[1], placeholders[2])
+
+    claimed, err := db.execCount(query, ScheduledStatusSending, id, ScheduledStatusPending)
+    if err != nil {
+        return false, fmt.Errorf("rejalashtirilgan xabarni band qilishda xatolik: %w", err)
+    }
+    return claimed > 0, nil
+}
+
+// FinishScheduledMessage sets the final status, sent or failed, of a claimed message. A
+// message whose status cannot be saved stays claimed and is never sent twice.
+func (db *DB) FinishScheduledMessage(id int64, status string) error {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf("UPDATE scheduled_messages SET status = %s WHERE id = %s AND status = %s",
+        placeholders[0], placeholders[1], placeholders[2])
+
+    if _, err := db.conn.Exec(query, status, id, ScheduledStatusSending); err != nil {
+        return fmt.Errorf("rejalashtirilgan xabar holatini yangilashda xatolik: %w", err)
+    }
+    return nil
+}
+
+// RetryScheduledMessage puts a claimed message back to pending for another attempt at
+// sendAt and counts the failed one
+func (db *DB) RetryScheduledMessage(id int64, sendAt time.Time) error {
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    UPDATE scheduled_messages SET status = %s, send_at = %s, attempts = attempts + 1
+    WHERE id = %s AND status = %s`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    if _, err := db.conn.Exec(query, ScheduledStatusPending, sendAt.UTC().Truncate(time.Second), id, ScheduledStatusSending); err != nil {
+        return fmt.Errorf("rejalashtirilgan xabarni qayta rejalashtirishda xatolik: %w", err)
+    }
+    return nil
+}
+
+// CancelScheduledMessage cancels a pending message of a chat and reports whether it was found
+func (db *DB) CancelScheduledMessage(chatID, id int64) (bool, error) {
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    UPDATE scheduled_messages SET status = %s
+    WHERE id = %s AND chat_id = %s AND status = %s`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    result, err := db.conn.Exec(query, ScheduledStatusCancelled, id, chatID, ScheduledStatusPending)
+    if err != nil {
+        return false, fmt.Errorf("rejalashtirilgan xabarni bekor qilishda xatolik: %w", err)
+    }
+
+    affected, err := result.RowsAffected()
+    if err != nil {
+        return false, fmt.Errorf("rejalashtirilgan xabarni bekor qilishda xatolik: %w", err)
+    }
+
+    return affected > 0, nil
+}
+
+// queryScheduledMessages runs a scheduled message query and scans the rows
+func (db *DB) queryScheduledMessages(query string, args ...interface{}) ([]ScheduledMessage, error) {
+    rows, err := db.conn.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("rejalashtirilgan xabarlarni olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var messages []ScheduledMessage
+    for rows.Next() {
+        var message ScheduledMessage
+        if err := rows.Scan(&message.ID, &message.ChatID, &message.Text, &message.SendAt,
+            &message.CreatedBy, &message.Status, &message.Attempts, &message.CreatedAt); err != nil {
+            return nil, fmt.Errorf("rejalashtirilgan xabarni o'qishda xatolik: %w", err)
+        }
+        messages = append(messages, message)
+    }
+
+    return messages, nil
+}
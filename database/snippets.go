@@ -0,0 +1,116 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+// Snippet represents a reusable canned response saved in a chat
+type Snippet struct {
+    ChatID     int64     `json:"chat_id"`
+    Name       string    `json:"name"`
+    Content    string    `json:"content"`
+    UsageCount int       `json:"usage_count"`
+    CreatedBy  int64     `json:"created_by"`
+    CreatedAt  time.Time `json:"created_at"`
+    UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// SaveSnippet creates or replaces a snippet, keeping its usage count
+func (db *DB) SaveSnippet(snippet *Snippet) error {
+    placeholders := db.getPlaceholders(4)
+    query := fmt.Sprintf(`
+    INSERT INTO snippets (chat_id, name, content, created_by)
+    VALUES (%s, %s, %s, %s)
+    ON CONFLICT(chat_id, name) DO UPDATE SET
+        content = EXCLUDED.content,
+        updated_at = CURRENT_TIMESTAMP`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3])
+
+    _, err := db.conn.Exec(query, snippet.ChatID, snippet.Name, snippet.Content, snippet.CreatedBy)
+    if err != nil {
+        return fmt.Errorf("snippetni saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// GetSnippet returns a snippet by name, or nil if it does not exist
+func (db *DB) GetSnippet(chatID int64, name string) (*Snippet, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT chat_id, name, content, usage_count, created_by, created_at, updated_at
+    FROM snippets WHERE chat_id = %s AND name = %s`, placeholders[0], placeholders[1])
+
+    var snippet Snippet
+    err := db.conn.QueryRow(query, chatID, name).Scan(
+        &snippet.ChatID, &snippet.Name, &snippet.Content, &snippet.UsageCount,
+        &snippet.CreatedBy, &snippet.CreatedAt, &snippet.UpdatedAt)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("snippetni olishda xatolik: %w", err)
+    }
+
+    return &snippet, nil
+}
+
+// GetSnippetsByChatID returns all snippets of a chat, most used first
+func (db *DB) GetSnippetsByChatID(chatID int64) ([]Snippet, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT chat_id, name, content, usage_count, created_by, created_at, updated_at
+    FROM snippets WHERE chat_id = %s
+    ORDER BY usage_count DESC, name ASC`, placeholders[0])
+
+    rows, err := db.conn.Query(query, chatID)
+    if err != nil {
+        return nil, fmt.Errorf("snippetlarni olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var snippets []Snippet
+    for rows.Next() {
+        var snippet Snippet
+        if err := rows.Scan(&snippet.ChatID, &snippet.Name, &snippet.Content, &snippet.UsageCount,
+            &snippet.CreatedBy, &snippet.CreatedAt, &snippet.UpdatedAt); err != nil {
+            return nil, fmt.Errorf("snippetni o'qishda xatolik: %w", err)
+        }
+        snippets = append(snippets, snippet)
+    }
+
+    return snippets, nil
+}
+
+// IncrementSnippetUsage bumps the usage counter of a snippet
+func (db *DB) IncrementSnippetUsage(chatID int64, name string) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf("UPDATE snippets SET usage_count = usage_count + 1 WHERE chat_id = %s AND name = %s",
+        placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, chatID, name); err != nil {
+        return fmt.Errorf("snippet statistikasini yangilashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// DeleteSnippet removes a snippet and reports whether it existed
+func (db *DB) DeleteSnippet(chatID int64, name string) (bool, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf("DELETE FROM snippets WHERE chat_id = %s AND name = %s", placeholders[0], placeholders[1])
+
+    result, err := db.conn.Exec(query, chatID, name)
+    if err != nil {
+        return false, fmt.Errorf("snippetni o'chirishda xatolik: %w", err)
+    }
+
+    affected, err := result.RowsAffected()
+    if err != nil {
+        return false, fmt.Errorf("snippetni o'chirishda xatolik: %w", err)
+    }
+
+    return affected > 0, nil
+}
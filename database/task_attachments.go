@@ -0,0 +1,37 @@
+package database
+
+import (
+    "fmt"
+)
+
+// Kinds of task attachments
+const (
+    AttachmentScreenshot = "screenshot"
+)
+
+// AddTaskAttachment attaches a Telegram file, such as a bug screenshot, to a task
+func (db *DB) AddTaskAttachment(taskID, fileID, kind string) error {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf(`
+    INSERT INTO task_attachments (task_id, file_id, kind)
+    VALUES (%s, %s, %s)
+    ON CONFLICT(task_id, file_id) DO NOTHING`,
+        placeholders[0], placeholders[1], placeholders[2])
+
+    if _, err := db.conn.Exec(query, taskID, fileID, kind); err != nil {
+        return fmt.Errorf("vazifaga fayl biriktirishda xatolik: %w", err)
+    }
+    return nil
+}
+
+// CountTaskAttachments returns how many files are attached to a task
+func (db *DB) CountTaskAttachments(taskID string) (int, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("SELECT COUNT(*) FROM task_attachments WHERE task_id = %s", placeholders[0])
+
+    var count int
+    if err := db.conn.QueryRow(query, taskID).Scan(&count); err != nil {
+        return 0, fmt.Errorf("vazifa fayllarini sanashda xatolik: %w", err)
+    }
+    return count, nil
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+    "fmt"
+    "time"
+)
+
+// TaskComment is a chat message linked to a task, e.g. a reply to the task's card
+type TaskComment struct {
+    ID        int64     `json:"id"`
+    TaskID    string    `json:"task_id"`
+    ChatID    int64     `json:"chat_id"`
+    UserID    int64     `json:"user_id"`
+    Author    string    `json:"author"`
+    Text      string    `json:"text"`
+    MessageID int       `json:"message_id"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// AddTaskComment stores a comment on a task and sets its ID
+func (db *DB) AddTaskComment(comment *TaskComment) error {
+    placeholders := db.getPlaceholders(6)
+    query := fmt.Sprintf(`
+    INSERT INTO task_comments (task_id, chat_id, user_id, author, text, message_id)
+    VALUES (%s, %s, %s, %s, %s, %s)
+    RETURNING id`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3], placeholders[4], placeholders[5])
+
+    err := db.conn.QueryRow(query, comment.TaskID, comment.ChatID, comment.UserID, comment.Author, comment.Text, comment.MessageID).Scan(&comment.ID)
+    if err != nil {
+        return fmt.Errorf("vazifa izohini saqlashda xatolik: %w", err)
+    }
+    return nil
+}
+
+// GetTaskComments returns the latest comments of a task, oldest of them first
+func (db *DB) GetTaskComments(taskID string, limit int) ([]TaskComment, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT id, task_id, chat_id, user_id, author, text, message_id, created_at
+    FROM task_comments
+    WHERE task_id = %s
+    ORDER BY created_at DESC, id DESC
+    LIMIT %s`, placeholders[0], placeholders[1])
+
+    rows, err := db.conn.Query(query, taskID, limit)
+    if err != nil {
+        return nil, fmt.Errorf("vazifa izohlarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var comments []TaskComment
+    for rows.Next() {
+        var comment TaskComment
+        if err := rows.Scan(&comment.ID, &comment.TaskID, &comment.ChatID, &comment.UserID,
+            &comment.Author, &comment.Text, &comment.MessageID, &comment.CreatedAt); err != nil {
+            return nil, fmt.Errorf("vazifa izohini o'qishda xatolik: %w", err)
+        }
+        comments = append(comments, comment)
+    }
+
+    // Newest were selected first; show them in the order they were written
+    for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+        comments[i], comments[j] = comments[j], comments[i]
+    }
+    return comments, nil
+}
+
+// CountTaskComments returns how many comments a task has
+func (db *DB) CountTaskComments(taskID string) (int, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("SELECT COUNT(*) FROM task_comments WHERE task_id = %s", placeholders[0])
+
+    var count int
+    if err := db.conn.QueryRow(query, taskID).Scan(&count); err != nil {
+        return 0, fmt.Errorf("vazifa izohlarini sanashda xatolik: %w", err)
+    }
+    return count, nil
+}
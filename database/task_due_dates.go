@@ -0,0 +1,64 @@
+package database
+
+import (
+    "fmt"
+    "time"
+)
+
+// dueDateLayout is how task due dates are stored, without a time or timezone
+const dueDateLayout = "2006-01-02"
+
+// SetTaskDueDate stores the date a task should be finished by
+func (db *DB) SetTaskDueDate(taskID string, due time.Time) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    INSERT INTO task_due_dates (task_id, due_date)
+    VALUES (%s, %s)
+    ON CONFLICT(task_id) DO UPDATE SET
+        due_date = excluded.due_date`,
+        placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, taskID, due.Format(dueDateLayout)); err != nil {
+        return fmt.Errorf("vazifa muddatini saqlashda xatolik: %w", err)
+    }
+    return nil
+}
+
+// ClearTaskDueDate removes the due date of a task
+func (db *DB) ClearTaskDueDate(taskID string) error {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("DELETE FROM task_due_dates WHERE task_id = %s", placeholders[0])
+    if _, err := db.conn.Exec(query, taskID); err != nil {
+        return fmt.Errorf("vazifa muddatini o'chirishda xatolik: %w", err)
+    }
+    return nil
+}
+
+// GetProjectDueDates returns the due dates of a project's tasks by task ID, as dates in UTC
+func (db *DB) GetProjectDueDates(projectID string) (map[string]time.Time, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT d.task_id, d.due_date
+    FROM task_due_dates d
+    JOIN tasks t ON t.id = d.task_id
+    WHERE t.project_id = %s`, placeholders[0])
+
+    rows, err := db.conn.Query(query, projectID)
+    if err != nil {
+        return nil, fmt.Errorf("vazifa muddatlarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    dueDates := make(map[string]time.Time)
+    for rows.Next() {
+        var taskID, value string
+        if err := rows.Scan(&taskID, &value); err != nil {
+            return nil, fmt.Errorf("vazifa muddatini o'qishda xatolik: %w", err)
+        }
+        if due, err := time.Parse(dueDateLayout, value); err == nil {
+            dueDates[taskID] = due
+        }
+    }
+
+    return dueDates, nil
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+// TaskCommit is a commit linked to a task by a task key in its message
+type TaskCommit struct {
+    TaskID      string    `json:"task_id"`
+    SHA         string    `json:"sha"`
+    Repository  string    `json:"repository"`
+    Message     string    `json:"message"`
+    Author      string    `json:"author"`
+    URL         string    `json:"url"`
+    CommittedAt time.Time `json:"committed_at"`
+}
+
+// EnsureTaskKey returns the task's number within its team, the N of a key like TASK-N,
+// assigning the team's next number when the task has none yet
+func (db *DB) EnsureTaskKey(taskID string) (int, error) {
+    placeholders := db.getPlaceholders(1)
+    selectQuery := fmt.Sprintf("SELECT number FROM task_keys WHERE task_id = %s", placeholders[0])
+
+    var number int
+    err := db.conn.QueryRow(selectQuery, taskID).Scan(&number)
+    if err == nil {
+        return number, nil
+    }
+    if err != sql.ErrNoRows {
+        return 0, fmt.Errorf("vazifa kalitini olishda xatolik: %w", err)
+    }
+
+    insertQuery := fmt.Sprintf(`
+    INSERT INTO task_keys (task_id, team_id, number)
+    SELECT t.id, p.team_id, COALESCE((SELECT MAX(k.number) FROM task_keys k WHERE k.team_id = p.team_id), 0) + 1
+    FROM tasks t
+    JOIN projects p ON p.id = t.project_id
+    WHERE t.id = %s
+    ON CONFLICT(task_id) DO NOTHING`, placeholders[0])
+
+    // Two tasks of a team numbered at once collide on (team_id, number); the retry takes the next one
+    for attempt := 0; attempt < 2; attempt++ {
+        if _, err = db.conn.Exec(insertQuery, taskID); err == nil {
+            break
+        }
+    }
+    if err != nil {
+        return 0, fmt.Errorf("vazifa kalitini saqlashda xatolik: %w", err)
+    }
+
+    if err := db.conn.QueryRow(selectQuery, taskID).Scan(&number); err != nil {
+        return 0, fmt.Errorf("vazifa kalitini olishda xatolik: %w", err)
+    }
+    return number, nil
+}
+
+// FindTaskByKey returns the ID of the team's task with the number, or "" when there is none
+func (db *DB) FindTaskByKey(teamID string, number int) (string, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf("SELECT task_id FROM task_keys WHERE team_id = %s AND number = %s", placeholders[0], placeholders[1])
+
+    var taskID string
+    err := db.conn.QueryRow(query, teamID, number).Scan(&taskID)
+    if err == sql.ErrNoRows {
+        return "", nil
+    }
+    if err != nil {
+        return "", fmt.Errorf("vazifani kalit bo'yicha olishda xatolik: %w", err)
+    }
+    return taskID, nil
+}
+
+// LinkTaskCommit links a commit to a task and reports whether the link is new
+func (db *DB) LinkTaskCommit(commit TaskCommit) (bool, error) {
+    placeholders := db.getPlaceholders(7)
+    query := fmt.Sprintf(`
+    INSERT INTO task_commits (task_id, sha, repository, message, author, url, committed_at)
+    VALUES (%s, %s, %s, %s, %s, %s, %s)
+    ON CONFLICT(task_id, sha) DO NOTHING`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3],
+        placeholders[4], placeholders[5], placeholders[6])
+
+    result, err := db.conn.Exec(query, commit.TaskID, commit.SHA, commit.Repository, commit.Message,
+        commit.Author, commit.URL, commit.CommittedAt.UTC().Truncate(time.Second))
+    if err != nil {
+        return false, fmt.Errorf("commitni vazifaga bog'lashda xatolik: %w", err)
+    }
+    affected, err := result.RowsAffected()
+    if err != nil {
+        return false, fmt.Errorf("commitni vazifaga bog'lashda xatolik: %w", err)
+    }
+    return affected > 0, nil
+}
+
+// GetTaskCommits returns the latest commits linked to a task, newest first
+func (db *DB) GetTaskCommits(taskID string, limit int) ([]TaskCommit, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT task_id, sha, repository, message, author, url, committed_at
+    FROM task_commits
+    WHERE task_id = %s
+    ORDER BY committed_at DESC
+    LIMIT %s`, placeholders[0], placeholders[1])
+
+    rows, err := db.conn.Query(query, taskID, limit)
+    if err != nil {
+        return nil, fmt.Errorf("vazifa commitlarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var commits []TaskCommit
+    for rows.Next() {
+        var commit TaskCommit
+        if err := rows.Scan(&commit.TaskID, &commit.SHA, &commit.Repository, &commit.Message,
+            &commit.Author, &commit.URL, &commit.CommittedAt); err != nil {
+            return nil, fmt.Errorf("vazifa commitini o'qishda xatolik: %w", err)
+        }
+        commits = append(commits, commit)
+    }
+    return commits, nil
+}
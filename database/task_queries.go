@@ -0,0 +1,235 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+// CompletedTask is a finished task with its estimate, used for velocity tracking
+type CompletedTask struct {
+    ID            string    `json:"id"`
+    Title         string    `json:"title"`
+    EstimateHours float64   `json:"estimate_hours"`
+    ActualHours   float64   `json:"actual_hours"`
+    CompletedAt   time.Time `json:"completed_at"`
+}
+
+// GetCompletedTasksByChatID returns tasks of the chat's team completed since the given time
+func (db *DB) GetCompletedTasksByChatID(chatID int64, since time.Time) ([]CompletedTask, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT t.id, t.title, t.estimate_hours, t.actual_hours, t.completed_at
+    FROM tasks t
+    JOIN projects p ON p.id = t.project_id
+    WHERE p.team_id = %s AND t.completed_at IS NOT NULL AND t.completed_at >= %s
+    ORDER BY t.completed_at ASC`, placeholders[0], placeholders[1])
+
+    rows, err := db.conn.Query(query, fmt.Sprintf("team_%d", chatID), since.UTC())
+    if err != nil {
+        return nil, fmt.Errorf("bajarilgan vazifalarni olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var tasks []CompletedTask
+    for rows.Next() {
+        var task CompletedTask
+        var completedAt sql.NullTime
+        if err := rows.Scan(&task.ID, &task.Title, &task.EstimateHours, &task.ActualHours, &completedAt); err != nil {
+            return nil, fmt.Errorf("bajarilgan vazifani o'qishda xatolik: %w", err)
+        }
+        if completedAt.Valid {
+            task.CompletedAt = completedAt.Time
+        }
+        tasks = append(tasks, task)
+    }
+
+    return tasks, nil
+}
+
+// TaskLocation identifies where a task lives and who it is assigned to
+type TaskLocation struct {
+    ProjectID      string `json:"project_id"`
+    ChatID         int64  `json:"chat_id"`
+    AssigneeUserID int64  `json:"assignee_user_id"`
+}
+
+// GetTaskLocation returns the project and team chat of a task, or nil if the task does not exist
+func (db *DB) GetTaskLocation(taskID string) (*TaskLocation, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT t.project_id, tm.chat_id, COALESCE(m.user_id, 0)
+    FROM tasks t
+    JOIN projects p ON p.id = t.project_id
+    JOIN teams tm ON tm.id = p.team_id
+    LEFT JOIN team_members m ON m.id = t.assigned_to
+    WHERE t.id = %s`, placeholders[0])
+
+    var location TaskLocation
+    err := db.conn.QueryRow(query, taskID).Scan(&location.ProjectID, &location.ChatID, &location.AssigneeUserID)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("vazifani olishda xatolik: %w", err)
+    }
+
+    return &location, nil
+}
+
+// UpdateTaskStatus changes a task's status, setting completed_at when it is completed
+func (db *DB) UpdateTaskStatus(taskID, status string) error {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf(`
+    UPDATE tasks SET
+        status = %s,
+        updated_at = CURRENT_TIMESTAMP,
+        completed_at = CASE WHEN %s = 'completed' THEN CURRENT_TIMESTAMP ELSE NULL END
+    WHERE id = %s`, placeholders[0], placeholders[1], placeholders[2])
+
+    if _, err := db.conn.Exec(query, status, status, taskID); err != nil {
+        return fmt.Errorf("vazifa holatini yangilashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// AddTaskActualHours logs time spent on a task
+func (db *DB) AddTaskActualHours(taskID string, hours float64) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    UPDATE tasks SET
+        actual_hours = actual_hours + %s,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = %s`, placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, hours, taskID); err != nil {
+        return fmt.Errorf("vazifa vaqtini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// UpdateTaskEstimate sets a task's most likely estimate and drops its stored range bounds
+func (db *DB) UpdateTaskEstimate(taskID string, hours float64) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    UPDATE tasks SET
+        estimate_hours = %s,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = %s`, placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, hours, taskID); err != nil {
+        return fmt.Errorf("vazifa bahosini yangilashda xatolik: %w", err)
+    }
+
+    deleteQuery := fmt.Sprintf("DELETE FROM task_estimate_ranges WHERE task_id = %s", placeholders[0])
+    if _, err := db.conn.Exec(deleteQuery, taskID); err != nil {
+        return fmt.Errorf("baholash oralig'ini o'chirishda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// AssignTask sets the team member a task is assigned to
+func (db *DB) AssignTask(taskID, memberID string) error {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    UPDATE tasks SET
+        assigned_to = %s,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = %s`, placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, memberID, taskID); err != nil {
+        return fmt.Errorf("vazifani tayinlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// DeleteTask removes a task together with its comments, criteria, estimate range and
+// attachments, e.g. when a re-analysis drops it from the requirements
+func (db *DB) DeleteTask(taskID string) error {
+    placeholders := db.getPlaceholders(1)
+    queries := []string{
+        fmt.Sprintf("DELETE FROM task_escalations WHERE task_id = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM task_acceptance_criteria WHERE task_id = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM task_estimate_ranges WHERE task_id = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM task_releases WHERE task_id = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM task_attachments WHERE task_id = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM task_comments WHERE task_id = %s", placeholders[0]),
+        fmt.Sprintf("DELETE FROM tasks WHERE id = %s", placeholders[0]),
+    }
+
+    for _, query := range queries {
+        if _, err := db.conn.Exec(query, taskID); err != nil {
+            return fmt.Errorf("vazifani o'chirishda xatolik: %w", err)
+        }
+    }
+
+    return nil
+}
+
+// AssignedTask is an open task assigned to a user, with its project and team
+type AssignedTask struct {
+    Task
+    ProjectName string    `json:"project_name"`
+    TeamName    string    `json:"team_name"`
+    ChatID      int64     `json:"chat_id"`
+    Deadline    time.Time `json:"deadline"`
+}
+
+// LinkTeamMemberships attaches a Telegram user ID to team member records added by username
+func (db *DB) LinkTeamMemberships(telegramID int64, username string) error {
+    if username == "" {
+        return nil
+    }
+
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    UPDATE team_members SET user_id = %s, updated_at = CURRENT_TIMESTAMP
+    WHERE user_id = 0 AND LOWER(username) = LOWER(%s)`, placeholders[0], placeholders[1])
+
+    if _, err := db.conn.Exec(query, telegramID, username); err != nil {
+        return fmt.Errorf("jamoa a'zoligini bog'lashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// GetTasksAssignedToUser returns open tasks assigned to the user across all teams
+func (db *DB) GetTasksAssignedToUser(telegramID int64) ([]AssignedTask, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT t.id, t.project_id, t.title, t.category, t.estimate_hours, t.actual_hours, t.status,
+           t.priority, t.assigned_to, t.created_at, t.updated_at,
+           p.name, tm.name, tm.chat_id, h.deadline
+    FROM tasks t
+    JOIN team_members m ON m.id = t.assigned_to
+    JOIN projects p ON p.id = t.project_id
+    JOIN teams tm ON tm.id = p.team_id
+    LEFT JOIN project_health h ON h.project_id = p.id
+    WHERE m.user_id = %s AND t.status <> 'completed'
+    ORDER BY t.priority ASC, t.created_at ASC`, placeholders[0])
+
+    rows, err := db.conn.Query(query, telegramID)
+    if err != nil {
+        return nil, fmt.Errorf("foydalanuvchi vazifalarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var tasks []AssignedTask
+    for rows.Next() {
+        var task AssignedTask
+        var deadline sql.NullTime
+        if err := rows.Scan(&task.ID, &task.ProjectID, &task.Title, &task.Category, &task.EstimateHours,
+            &task.ActualHours, &task.Status, &task.Priority, &task.AssignedTo, &task.CreatedAt, &task.UpdatedAt,
+            &task.ProjectName, &task.TeamName, &task.ChatID, &deadline); err != nil {
+            return nil, fmt.Errorf("foydalanuvchi vazifasini o'qishda xatolik: %w", err)
+        }
+        task.Deadline = deadline.Time
+        tasks = append(tasks, task)
+    }
+
+    return tasks, nil
+}
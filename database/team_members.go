@@ -0,0 +1,136 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+    "log"
+    "strings"
+)
+
+// EnsureTeam returns the team of a chat, creating it with name when the chat has none.
+// An empty name falls back to "Chat <id> Team".
+func (db *DB) EnsureTeam(chatID int64, name string) (string, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("SELECT id FROM teams WHERE chat_id = %s", placeholders[0])
+
+    var teamID string
+    err := db.conn.QueryRow(query, chatID).Scan(&teamID)
+    if err == nil {
+        return teamID, nil
+    }
+    if err != sql.ErrNoRows {
+        return "", fmt.Errorf("jamoani olishda xatolik: %w", err)
+    }
+
+    teamID = fmt.Sprintf("team_%d", chatID)
+    if name == "" {
+        name = fmt.Sprintf("Chat %d Team", chatID)
+    }
+    createPlaceholders := db.getPlaceholders(3)
+    createQuery := fmt.Sprintf("INSERT INTO teams (id, name, chat_id) VALUES (%s, %s, %s) ON CONFLICT DO NOTHING",
+        createPlaceholders[0], createPlaceholders[1], createPlaceholders[2])
+    if _, err := db.conn.Exec(createQuery, teamID, name, chatID); err != nil {
+        return "", fmt.Errorf("jamoa yaratishda xatolik: %w", err)
+    }
+
+    log.Printf("👥 Jamoa yaratildi: %s (Chat ID: %d)", teamID, chatID)
+    return teamID, nil
+}
+
+// ensureTeamOfID creates the team of a "team_<chat ID>" team ID when it is missing. Other
+// team IDs are left alone.
+func (db *DB) ensureTeamOfID(teamID string) error {
+    var chatID int64
+    if _, err := fmt.Sscanf(teamID, "team_%d", &chatID); err != nil || teamID != fmt.Sprintf("team_%d", chatID) {
+        return nil
+    }
+    _, err := db.EnsureTeam(chatID, "")
+    return err
+}
+
+// GetTeamMemberByUsername returns the member of a team with a username, ignoring case, or
+// nil if the team has none
+func (db *DB) GetTeamMemberByUsername(teamID, username string) (*TeamMember, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT id, team_id, user_id, username, COALESCE(role, ''), COALESCE(skills, ''),
+           COALESCE(capacity, 0), COALESCE(current_workload, 0)
+    FROM team_members
+    WHERE team_id = %s AND LOWER(username) = LOWER(%s)`, placeholders[0], placeholders[1])
+
+    var member TeamMember
+    var skills string
+    err := db.conn.QueryRow(query, teamID, username).Scan(&member.ID, &member.TeamID, &member.UserID,
+        &member.Username, &member.Role, &skills, &member.Capacity, &member.Current)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("jamoa a'zosini olishda xatolik: %w", err)
+    }
+    if skills != "" {
+        member.Skills = strings.Split(skills, ",")
+    }
+
+    return &member, nil
+}
+
+// UpdateTeamMemberProfile sets a team member's skills and weekly capacity in hours
+func (db *DB) UpdateTeamMemberProfile(memberID string, skills []string, capacity float64) error {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf(`
+    UPDATE team_members SET skills = %s, capacity = %s, updated_at = CURRENT_TIMESTAMP
+    WHERE id = %s`, placeholders[0], placeholders[1], placeholders[2])
+
+    result, err := db.conn.Exec(query, strings.Join(skills, ","), capacity, memberID)
+    if err != nil {
+        return fmt.Errorf("jamoa a'zosi profilini yangilashda xatolik: %w", err)
+    }
+    if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+        return fmt.Errorf("jamoa a'zosi topilmadi: %s", memberID)
+    }
+
+    return nil
+}
+
+// TeamMembership is a user's member record in one team, with the team's name and chat
+type TeamMembership struct {
+    TeamMember
+    TeamName string `json:"team_name"`
+    ChatID   int64  `json:"chat_id"`
+}
+
+// GetTeamMembershipsByUser returns the member records of a user across all teams
+func (db *DB) GetTeamMembershipsByUser(telegramID int64) ([]TeamMembership, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT m.id, m.team_id, m.user_id, m.username, COALESCE(m.role, ''), COALESCE(m.skills, ''),
+           COALESCE(m.capacity, 0), COALESCE(m.current_workload, 0), t.name, t.chat_id
+    FROM team_members m
+    JOIN teams t ON t.id = m.team_id
+    WHERE m.user_id = %s
+    ORDER BY t.name ASC`, placeholders[0])
+
+    rows, err := db.conn.Query(query, telegramID)
+    if err != nil {
+        return nil, fmt.Errorf("jamoa a'zoliklarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var memberships []TeamMembership
+    for rows.Next() {
+        var membership TeamMembership
+        var skills string
+        if err := rows.Scan(&membership.ID, &membership.TeamID, &membership.UserID, &membership.Username,
+            &membership.Role, &skills, &membership.Capacity, &membership.Current,
+            &membership.TeamName, &membership.ChatID); err != nil {
+            return nil, fmt.Errorf("jamoa a'zoligini o'qishda xatolik: %w", err)
+        }
+        if skills != "" {
+            membership.Skills = strings.Split(skills, ",")
+        }
+        memberships = append(memberships, membership)
+    }
+
+    return memberships, nil
+}
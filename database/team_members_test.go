@@ -0,0 +1,32 @@
+package database
+
+import "testing"
+
+func TestCreateTeamMemberCreatesTeam(t *testing.T) {
+    db := openTestDB(t)
+
+    member := &TeamMember{ID: "m1", TeamID: "team_-100", Username: "Alice", Role: "developer", Skills: []string{"go"}, Capacity: 40}
+    if err := db.CreateTeamMember(member); err != nil {
+        t.Fatalf("CreateTeamMember() error = %v", err)
+    }
+    memberships, err := db.GetTeamMembershipsByUser(0)
+    if err != nil || len(memberships) != 1 || memberships[0].ChatID != -100 || memberships[0].TeamName != "Chat -100 Team" {
+        t.Fatalf("memberships = %+v, %v, want the member in a new team of chat -100", memberships, err)
+    }
+
+    // An existing team keeps its name
+    if teamID, err := db.EnsureTeam(-100, "Backend"); err != nil || teamID != "team_-100" {
+        t.Errorf("EnsureTeam() = %q, %v, want the existing team", teamID, err)
+    }
+    if teamID, err := db.EnsureTeam(-200, "Backend"); err != nil || teamID != "team_-200" {
+        t.Errorf("EnsureTeam() of a new chat = %q, %v", teamID, err)
+    }
+
+    found, err := db.GetTeamMemberByUsername("team_-100", "alice")
+    if err != nil || found == nil || found.ID != "m1" || len(found.Skills) != 1 {
+        t.Errorf("GetTeamMemberByUsername() = %+v, %v, want m1 regardless of case", found, err)
+    }
+    if found, err := db.GetTeamMemberByUsername("team_-200", "alice"); err != nil || found != nil {
+        t.Errorf("GetTeamMemberByUsername() in another team = %+v, %v, want nil", found, err)
+    }
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+    "fmt"
+)
+
+// GetTeamSettings returns all stored settings for a team as key/value pairs
+func (db *DB) GetTeamSettings(teamID string) (map[string]string, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf("SELECT key, value FROM team_settings WHERE team_id = %s", placeholders[0])
+
+    rows, err := db.conn.Query(query, teamID)
+    if err != nil {
+        return nil, fmt.Errorf("jamoa sozlamalarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    settings := make(map[string]string)
+    for rows.Next() {
+        var key, value string
+        if err := rows.Scan(&key, &value); err != nil {
+            return nil, fmt.Errorf("jamoa sozlamasini o'qishda xatolik: %w", err)
+        }
+        settings[key] = value
+    }
+
+    return settings, nil
+}
+
+// SetTeamSetting creates or updates a single team setting
+func (db *DB) SetTeamSetting(teamID, key, value string) error {
+    placeholders := db.getPlaceholders(3)
+    query := fmt.Sprintf(`
+    INSERT INTO team_settings (team_id, key, value)
+    VALUES (%s, %s, %s)
+    ON CONFLICT(team_id, key) DO UPDATE SET
+        value = EXCLUDED.value,
+        updated_at = CURRENT_TIMESTAMP`,
+        placeholders[0], placeholders[1], placeholders[2])
+
+    _, err := db.conn.Exec(query, teamID, key, value)
+    if err != nil {
+        return fmt.Errorf("jamoa sozlamasini saqlashda xatolik: %w", err)
+    }
+
+    return nil
+}
+
+// TeamChat pairs a team with its Telegram chat
+type TeamChat struct {
+    TeamID string `json:"team_id"`
+    ChatID int64  `json:"chat_id"`
+}
+
+// GetTeamChatsWithSettingPrefix returns teams that have a non-empty setting starting with the prefix
+func (db *DB) GetTeamChatsWithSettingPrefix(prefix string) ([]TeamChat, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT DISTINCT s.team_id, t.chat_id
+    FROM team_settings s
+    JOIN teams t ON t.id = s.team_id
+    WHERE s.key LIKE %s AND s.value <> ''
+    ORDER BY s.team_id`, placeholders[0])
+
+    rows, err := db.conn.Query(query, prefix+"%")
+    if err != nil {
+        return nil, fmt.Errorf("jamoalarni sozlama bo'yicha olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var teams []TeamChat
+    for rows.Next() {
+        var team TeamChat
+        if err := rows.Scan(&team.TeamID, &team.ChatID); err != nil {
+            return nil, fmt.Errorf("jamoa ma'lumotini o'qishda xatolik: %w", err)
+        }
+        teams = append(teams, team)
+    }
+
+    return teams, nil
+}
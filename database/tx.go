@@ -0,0 +1,49 @@
+package database
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+)
+
+// querier runs queries on the connection pool or on a transaction
+type querier interface {
+    Exec(query string, args ...interface{}) (sql.Result, error)
+    Query(query string, args ...interface{}) (*sql.Rows, error)
+    QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// WithTx runs fn in a transaction, so multi-step writes such as saving an analysis are
+// applied completely or not at all. fn receives a DB whose methods all run on the
+// transaction; it is committed when fn returns nil and rolled back when fn fails or panics.
+// Calls inside a transaction join it instead of starting another one.
+func (db *DB) WithTx(ctx context.Context, fn func(tx *DB) error) (err error) {
+    if db.tx != nil {
+        return fn(db)
+    }
+
+    sqlTx, err := db.pool.BeginTx(ctx, nil)
+    if err != nil {
+        return fmt.Errorf("tranzaksiyani boshlashda xatolik: %w", err)
+    }
+
+    defer func() {
+        if recovered := recover(); recovered != nil {
+            sqlTx.Rollback()
+            panic(recovered)
+        }
+        if err != nil {
+            sqlTx.Rollback()
+        }
+    }()
+
+    // Reads inside the transaction must see its writes, so there is no replica
+    if err = fn(&DB{conn: sqlTx, pool: db.pool, tx: sqlTx, driver: db.driver}); err != nil {
+        return err
+    }
+
+    if err = sqlTx.Commit(); err != nil {
+        return fmt.Errorf("tranzaksiyani yakunlashda xatolik: %w", err)
+    }
+    return nil
+}
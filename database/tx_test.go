@@ -0,0 +1,177 @@
+package database
+
+import (
+    "context"
+    "errors"
+    "path/filepath"
+    "testing"
+)
+
+// openTestDB opens an empty SQLite database in a temporary directory
+func openTestDB(t *testing.T) *DB {
+    t.Helper()
+    db, err := openSQLite(filepath.Join(t.TempDir(), "test.db"))
+    if err != nil {
+        t.Fatalf("openSQLite() error = %v", err)
+    }
+    t.Cleanup(func() { db.Close() })
+    return db
+}
+
+// saveAnalysis creates a project with two tasks, the second depending on the first
+func saveAnalysis(tx *DB) error {
+    if err := tx.CreateProject(&Project{ID: "proj_1", Name: "Shop", TeamID: "team_-100", Status: "active"}); err != nil {
+        return err
+    }
+    if err := tx.CreateTask(&Task{ID: "task_1", ProjectID: "proj_1", Title: "Login", Category: "backend", Status: "todo", AcceptanceCriteria: []string{"Wrong password is rejected"}}); err != nil {
+        return err
+    }
+    return tx.CreateTask(&Task{ID: "task_2", ProjectID: "proj_1", Title: "Cart", Category: "frontend", Status: "todo", Dependencies: []string{"task_1"}})
+}
+
+func TestWithTxCommitsAndRollsBack(t *testing.T) {
+    db := openTestDB(t)
+    ctx := context.Background()
+
+    failure := errors.New("AI result rejected")
+    err := db.WithTx(ctx, func(tx *DB) error {
+        if err := saveAnalysis(tx); err != nil {
+            return err
+        }
+        return failure
+    })
+    if !errors.Is(err, failure) {
+        t.Fatalf("WithTx() error = %v, want the error of fn", err)
+    }
+    if projects, _ := db.GetProjectsByChatID(-100); len(projects) != 0 {
+        t.Fatalf("projects after rollback = %+v, want none", projects)
+    }
+    if tasks, _ := db.GetTasksByProjectID("proj_1"); len(tasks) != 0 {
+        t.Fatalf("tasks after rollback = %+v, want none", tasks)
+    }
+
+    if err := db.WithTx(ctx, saveAnalysis); err != nil {
+        t.Fatalf("WithTx() error = %v", err)
+    }
+    tasks, err := db.GetTasksByProjectID("proj_1")
+    if err != nil || len(tasks) != 2 {
+        t.Fatalf("tasks after commit = %+v, %v, want 2", tasks, err)
+    }
+    criteria, _ := db.GetProjectAcceptanceCriteria("proj_1")
+    if len(criteria["task_1"]) != 1 {
+        t.Errorf("acceptance criteria = %v, want the one saved with task_1", criteria)
+    }
+}
+
+func TestWithTxRollsBackFailedStepsAndPanics(t *testing.T) {
+    db := openTestDB(t)
+    ctx := context.Background()
+    if err := db.WithTx(ctx, saveAnalysis); err != nil {
+        t.Fatalf("WithTx() error = %v", err)
+    }
+
+    // Rebalancing: the first assignment succeeds, the duplicate task ID fails the second step
+    err := db.WithTx(ctx, func(tx *DB) error {
+        if err := tx.AssignTask("task_1", "member_1"); err != nil {
+            return err
+        }
+        return tx.CreateTask(&Task{ID: "task_2", ProjectID: "proj_1", Title: "Duplicate", Category: "qa", Status: "todo"})
+    })
+    if err == nil {
+        t.Fatal("expected the duplicate task to fail the transaction")
+    }
+    tasks, _ := db.GetTasksByProjectID("proj_1")
+    for _, task := range tasks {
+        if task.AssignedTo != "" {
+            t.Errorf("task %s assigned to %q after rollback, want unassigned", task.ID, task.AssignedTo)
+        }
+    }
+
+    func() {
+        defer func() {
+            if recover() == nil {
+                t.Error("expected the panic to propagate")
+            }
+        }()
+        db.WithTx(ctx, func(tx *DB) error {
+            tx.AssignTask("task_2", "member_2")
+            panic("handler bug")
+        })
+    }()
+    tasks, _ = db.GetTasksByProjectID("proj_1")
+    for _, task := range tasks {
+        if task.AssignedTo != "" {
+            t.Errorf("task %s assigned to %q after a panic, want unassigned", task.ID, task.AssignedTo)
+        }
+    }
+}
+
+func TestWithTxJoinsOuterTransaction(t *testing.T) {
+    db := openTestDB(t)
+    ctx := context.Background()
+    if err := db.WithTx(ctx, saveAnalysis); err != nil {
+        t.Fatalf("WithTx() error = %v", err)
+    }
+
+    // DeleteProject runs its own transaction, which joins the outer one and rolls back with it
+    failure := errors.New("cancelled")
+    err := db.WithTx(ctx, func(tx *DB) error {
+        if err := tx.DeleteProject("proj_1"); err != nil {
+            return err
+        }
+        return failure
+    })
+    if !errors.Is(err, failure) {
+        t.Fatalf("WithTx() error = %v, want the error of fn", err)
+    }
+    if tasks, _ := db.GetTasksByProjectID("proj_1"); len(tasks) != 2 {
+        t.Errorf("tasks = %+v, want both kept after the rollback", tasks)
+    }
+}
+
+func TestLogUserActivityInsertsMissingUser(t *testing.T) {
+    db := openTestDB(t)
+
+    if err := db.LogUserActivity(42, "/hazil"); err != nil {
+        t.Fatalf("LogUserActivity() error = %v", err)
+    }
+    if err := db.CreateOrUpdateUser(42, "dev", "Dev", ""); err != nil {
+        t.Fatalf("CreateOrUpdateUser() error = %v", err)
+    }
+    if err := db.LogUserActivity(42, "/help"); err != nil {
+        t.Fatalf("LogUserActivity() error = %v", err)
+    }
+
+    if count, _ := db.GetUserStats(); count != 1 {
+        t.Errorf("users = %d, want 1", count)
+    }
+    if activities, _ := db.GetUserActivities(42, 10); len(activities) != 2 {
+        t.Errorf("activities = %+v, want 2", activities)
+    }
+}
+
+func TestLogUserActivityRollsBackTheUserOnFailure(t *testing.T) {
+    db := openTestDB(t)
+
+    // The user row is written first; it must not outlive a failed activity insert
+    if _, err := db.conn.Exec("DROP TABLE user_activity"); err != nil {
+        t.Fatal(err)
+    }
+    if err := db.LogUserActivity(42, "/hazil"); err == nil {
+        t.Fatal("expected LogUserActivity() to fail without the user_activity table")
+    }
+    if count, _ := db.GetUserStats(); count != 0 {
+        t.Errorf("users = %d, want the inserted user rolled back", count)
+    }
+
+    // A retry inside an outer transaction joins it instead of nesting
+    err := db.WithTx(context.Background(), func(tx *DB) error {
+        return tx.LogUserActivity(42, "/help")
+    })
+    if err == nil {
+        t.Fatal("expected the joined LogUserActivity() to fail too")
+    }
+    if count, _ := db.GetUserStats(); count != 0 {
+        t.Errorf("users = %d after the outer transaction failed, want 0", count)
+    }
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+    "database/sql"
+    "fmt"
+)
+
+// GetUser returns a user by Telegram ID, or nil if the user was never stored
+func (db *DB) GetUser(telegramID int64) (*User, error) {
+    placeholders := db.getPlaceholders(1)
+    query := fmt.Sprintf(`
+    SELECT id, telegram_id, COALESCE(username, ''), COALESCE(first_name, ''), COALESCE(last_name, ''),
+           created_at, updated_at
+    FROM users
+    WHERE telegram_id = %s`, placeholders[0])
+
+    var user User
+    err := db.conn.QueryRow(query, telegramID).Scan(&user.ID, &user.TelegramID, &user.Username,
+        &user.FirstName, &user.LastName, &user.CreatedAt, &user.UpdatedAt)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("foydalanuvchini olishda xatolik: %w", err)
+    }
+
+    return &user, nil
+}
@@ -0,0 +1,47 @@
+package database
+
+import "testing"
+
+func TestUserProfileQueries(t *testing.T) {
+    db := openTestDB(t)
+
+    if user, err := db.GetUser(42); err != nil || user != nil {
+        t.Fatalf("GetUser() of an unknown user = %v, %v, want nil", user, err)
+    }
+    if err := db.CreateOrUpdateUser(42, "alice", "Alice", ""); err != nil {
+        t.Fatal(err)
+    }
+    user, err := db.GetUser(42)
+    if err != nil || user == nil || user.Username != "alice" || user.CreatedAt.IsZero() {
+        t.Fatalf("GetUser() = %+v, %v, want alice", user, err)
+    }
+
+    // Teams are created for their chats on first use
+    for _, chatID := range []int64{-100, -200} {
+        if _, err := db.GetTeamMembersByChatID(chatID); err != nil {
+            t.Fatal(err)
+        }
+    }
+    members := []*TeamMember{
+        {ID: "m1", TeamID: "team_-100", UserID: 42, Username: "alice", Role: "lead", Skills: []string{"go", "sql"}, Capacity: 30},
+        {ID: "m2", TeamID: "team_-200", UserID: 42, Username: "alice", Role: "developer", Capacity: 10},
+        {ID: "m3", TeamID: "team_-200", UserID: 7, Username: "bob", Role: "developer", Capacity: 40},
+    }
+    for _, member := range members {
+        if err := db.CreateTeamMember(member); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    memberships, err := db.GetTeamMembershipsByUser(42)
+    if err != nil {
+        t.Fatalf("GetTeamMembershipsByUser() error = %v", err)
+    }
+    if len(memberships) != 2 {
+        t.Fatalf("memberships = %+v, want alice's two", memberships)
+    }
+    first := memberships[0]
+    if first.ChatID != -100 || first.Role != "lead" || len(first.Skills) != 2 || first.Capacity != 30 {
+        t.Errorf("first membership = %+v, want the lead role in chat -100", first)
+    }
+}
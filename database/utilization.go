@@ -0,0 +1,83 @@
+package database
+
+import (
+    "fmt"
+    "time"
+)
+
+// utilizationDayLayout is how snapshot days are stored
+const utilizationDayLayout = "2006-01-02"
+
+// UtilizationSnapshot is a member's capacity and allocated open hours on a day
+type UtilizationSnapshot struct {
+    MemberID  string    `json:"member_id"`
+    Day       time.Time `json:"day"`
+    ChatID    int64     `json:"chat_id"`
+    Capacity  float64   `json:"capacity"`
+    Allocated float64   `json:"allocated"`
+}
+
+// GetTeamChatIDs returns the chats that have a team
+func (db *DB) GetTeamChatIDs() ([]int64, error) {
+    rows, err := db.conn.Query("SELECT DISTINCT chat_id FROM teams")
+    if err != nil {
+        return nil, fmt.Errorf("jamoa chatlarini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var chatIDs []int64
+    for rows.Next() {
+        var chatID int64
+        if err := rows.Scan(&chatID); err != nil {
+            return nil, fmt.Errorf("jamoa chatini o'qishda xatolik: %w", err)
+        }
+        chatIDs = append(chatIDs, chatID)
+    }
+    return chatIDs, nil
+}
+
+// SaveUtilizationSnapshot stores a member's snapshot for its day; the first snapshot of a day is kept
+func (db *DB) SaveUtilizationSnapshot(snapshot UtilizationSnapshot) error {
+    placeholders := db.getPlaceholders(5)
+    query := fmt.Sprintf(`
+    INSERT INTO utilization_snapshots (member_id, day, chat_id, capacity, allocated)
+    VALUES (%s, %s, %s, %s, %s)
+    ON CONFLICT(member_id, day) DO NOTHING`,
+        placeholders[0], placeholders[1], placeholders[2], placeholders[3], placeholders[4])
+
+    if _, err := db.conn.Exec(query, snapshot.MemberID, snapshot.Day.Format(utilizationDayLayout),
+        snapshot.ChatID, snapshot.Capacity, snapshot.Allocated); err != nil {
+        return fmt.Errorf("bandlik ma'lumotini saqlashda xatolik: %w", err)
+    }
+    return nil
+}
+
+// GetUtilizationHistory returns a member's snapshots from the since day on, oldest first
+func (db *DB) GetUtilizationHistory(memberID string, since time.Time) ([]UtilizationSnapshot, error) {
+    placeholders := db.getPlaceholders(2)
+    query := fmt.Sprintf(`
+    SELECT member_id, day, chat_id, capacity, allocated
+    FROM utilization_snapshots
+    WHERE member_id = %s AND day >= %s
+    ORDER BY day ASC`, placeholders[0], placeholders[1])
+
+    rows, err := db.conn.Query(query, memberID, since.Format(utilizationDayLayout))
+    if err != nil {
+        return nil, fmt.Errorf("bandlik tarixini olishda xatolik: %w", err)
+    }
+    defer rows.Close()
+
+    var snapshots []UtilizationSnapshot
+    for rows.Next() {
+        var snapshot UtilizationSnapshot
+        var day string
+        if err := rows.Scan(&snapshot.MemberID, &day, &snapshot.ChatID, &snapshot.Capacity, &snapshot.Allocated); err != nil {
+            return nil, fmt.Errorf("bandlik ma'lumotini o'qishda xatolik: %w", err)
+        }
+        if snapshot.Day, err = time.Parse(utilizationDayLayout, day); err != nil {
+            continue
+        }
+        snapshots = append(snapshots, snapshot)
+    }
+    return snapshots, nil
+}
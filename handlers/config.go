@@ -4,9 +4,7 @@ import (
     "encoding/json"
     "fmt"
     "io"
-    "math/rand"
     "os"
-    "time"
 )
 
 type Config struct {
@@ -14,6 +12,11 @@ type Config struct {
     Messages MessageConfig  `json:"messages"`
     Jokes    []string       `json:"jokes"`
     Quotes   []string       `json:"quotes"`
+    // Commands holds translated command menu descriptions by language code and command
+    Commands map[string]map[string]string `json:"commands"`
+    // Cooldowns limits how often a chat may run a command, optionally with its first
+    // argument, e.g. {"/status_report email": "1h"}
+    Cooldowns map[string]string `json:"cooldowns"`
 }
 
 type BotConfig struct {
@@ -46,22 +49,5 @@ func LoadConfig() (*Config, error) {
         return nil, fmt.Errorf("config.json parse qilishda xatolik: %w", err)
     }
 
-    rand.Seed(time.Now().UnixNano())
-    
     return &config, nil
 }
-
-// Fixed functions to accept config parameter
-func GetRandomJoke(config *Config) string {
-    if len(config.Jokes) == 0 {
-        return "😅 Hazillar yuklanmagan!"
-    }
-    return config.Jokes[rand.Intn(len(config.Jokes))]
-}
-
-func GetRandomQuote(config *Config) string {
-    if len(config.Quotes) == 0 {
-        return "💭 Iqtiboslar yuklanmagan!"
-    }
-    return config.Quotes[rand.Intn(len(config.Quotes))]
-}
\ No newline at end of file
@@ -1,29 +1,80 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
 )
 
 // TelegramBot represents the main bot application
 type TelegramBot struct {
 	token        string
-	url          string
 	dependencies *Dependencies
+	// mux holds the bot's HTTP routes, relative to basePath
+	mux         *http.ServeMux
+	basePath    string
+	middlewares []HTTPMiddleware
+	// mediaGroups collects the documents of an album into one analysis
+	mediaGroups *mediaGroupBuffer
+	// lastUpdate and lastPoll are the Unix nanoseconds of the latest update and successful
+	// getUpdates, watched by the watchdog
+	lastUpdate atomic.Int64
+	lastPoll   atomic.Int64
+	watchdog   *Watchdog
+	// sendQueue retries the messages Telegram rate limited
+	sendQueue *sendQueue
+	// rerunEdits runs a command again when its message is edited, see EDITED_MESSAGES
+	rerunEdits bool
+	// telegram calls the Bot API through the shared connection pool, which logs and
+	// measures every call
+	telegram *services.TelegramClient
+	// inFlight tracks the updates and pushes being processed; closing turns new webhooks
+	// away once Shutdown started
+	inFlight sync.WaitGroup
+	closing  atomic.Bool
+	// lifecycle guards the server and the cancel of the background jobs Start launched
+	lifecycle sync.Mutex
+	server    *http.Server
+	cancel    context.CancelFunc
 }
 
 // TelegramUpdate represents Telegram webhook update
 type TelegramUpdate struct {
 	UpdateID int             `json:"update_id"`
 	Message  *TelegramMessage `json:"message"`
+	// CallbackQuery is sent when a user presses an inline keyboard button
+	CallbackQuery *TelegramCallbackQuery `json:"callback_query,omitempty"`
+	// EditedMessage and EditedChannelPost carry the new version of an edited message
+	EditedMessage     *TelegramMessage `json:"edited_message,omitempty"`
+	EditedChannelPost *TelegramMessage `json:"edited_channel_post,omitempty"`
+	// ChannelPost is a message posted in a channel the bot administers
+	ChannelPost *TelegramMessage `json:"channel_post,omitempty"`
+}
+
+// TelegramCallbackQuery represents an inline keyboard button press
+type TelegramCallbackQuery struct {
+	ID      string           `json:"id"`
+	From    *TelegramUser    `json:"from"`
+	Message *TelegramMessage `json:"message"`
+	Data    string           `json:"data"`
 }
 
 // TelegramMessage represents Telegram message
@@ -31,11 +82,21 @@ type TelegramMessage struct {
 	MessageID int           `json:"message_id"`
 	From      *TelegramUser `json:"from"`
 	Chat      *TelegramChat `json:"chat"`
+	// SenderChat is the channel a channel post was sent on behalf of
+	SenderChat *TelegramChat `json:"sender_chat,omitempty"`
 	Text      string        `json:"text"`
 	Date      int64         `json:"date"`
+	Caption   string        `json:"caption,omitempty"`
+	// ReplyToMessage is the message this one replies to
+	ReplyToMessage *TelegramMessage `json:"reply_to_message,omitempty"`
 	// File attachments
 	Document *domain.TelegramDocument `json:"document,omitempty"`
 	Photo    []domain.TelegramPhoto   `json:"photo,omitempty"`
+	// MediaGroupID is shared by the messages of an album
+	MediaGroupID string `json:"media_group_id,omitempty"`
+	// Voice is a recorded voice message, Audio an uploaded audio file
+	Voice *domain.TelegramVoice `json:"voice,omitempty"`
+	Audio *domain.TelegramVoice `json:"audio,omitempty"`
 }
 
 // TelegramUser represents Telegram user
@@ -55,22 +116,130 @@ type TelegramChat struct {
 	Username string `json:"username"`
 }
 
-// NewTelegramBot creates a new Telegram bot instance
+// NewTelegramBot creates a new Telegram bot instance with its own HTTP routes. Routes are
+// served under HTTP_BASE_PATH (e.g. "/bot1") so several bots can share one host.
 func NewTelegramBot(token string, dependencies *Dependencies) *TelegramBot {
-	return &TelegramBot{
+	b := &TelegramBot{
 		token:        token,
-		url:          fmt.Sprintf("https://api.telegram.org/bot%s", token),
 		dependencies: dependencies,
+		mux:          http.NewServeMux(),
+		basePath:     normalizeBasePath(os.Getenv("HTTP_BASE_PATH")),
+		rerunEdits:   rerunEditedCommands(),
 	}
+	b.mediaGroups = newMediaGroupBuffer(mediaGroupWindow, func(messages []*TelegramMessage) {
+		b.goTracked(func() { b.processMediaGroup(messages) })
+	})
+	b.sendQueue = newSendQueue(b)
+
+	b.telegram = dependencies.TelegramAPI
+	if b.telegram == nil {
+		transport := services.NewTelegramTransport(nil, services.TelegramRedactionFromEnv(token), dependencies.Logger, dependencies.Metrics)
+		b.telegram = services.NewTelegramClient(token, transport)
+	}
+
+	// Long-running commands show "typing" through this bot
+	if dependencies.ChatActions != nil {
+		dependencies.ChatActions.SetSender(b)
+	}
+	// Handlers with progressive responses post and edit their placeholders through this bot
+	if dependencies.Router != nil {
+		for _, handler := range dependencies.Router.GetHandlers() {
+			if aware, ok := handler.(domain.ResponderAware); ok {
+				aware.SetResponder(b)
+			}
+		}
+	}
+
+	b.Use(requestLoggingMiddleware(dependencies.Logger))
+	b.Use(recoveryMiddleware(dependencies.Logger))
+	b.Use(bodyLimitMiddleware(maxRequestBody()))
+
+	b.HandleFunc("/webhook", b.handleWebhook)
+	b.HandleFunc("/health", b.handleHealth)
+	b.HandleFunc("/metrics", b.handleMetrics)
+	b.HandleFunc("/github/webhook", b.handleGitHubWebhook)
+	b.HandleFunc("/deploy", b.handleDeploy)
+	b.HandleFunc("/share/", b.handleSharePage)
+	b.HandleFunc("/unsubscribe/", b.handleUnsubscribe)
+	b.HandleFunc("/reports/", b.handleReport)
+	b.HandleFunc("/webapp", b.handleWebAppPage)
+	b.HandleFunc("/webapp/api/", b.handleWebAppAPI)
+	return b
+}
+
+// HandleFunc registers a route relative to the bot's base path
+func (b *TelegramBot) HandleFunc(pattern string, handler http.HandlerFunc) {
+	b.mux.HandleFunc(pattern, handler)
+}
+
+// Use adds an HTTP middleware; middlewares run in the order they were added
+func (b *TelegramBot) Use(middleware HTTPMiddleware) {
+	b.middlewares = append(b.middlewares, middleware)
 }
 
-// Start starts the bot HTTP server
+// Handler returns the bot's routes under its base path wrapped in its middlewares
+func (b *TelegramBot) Handler() http.Handler {
+	var handler http.Handler = b.mux
+	if b.basePath != "" {
+		handler = http.StripPrefix(b.basePath, handler)
+	}
+	for i := len(b.middlewares) - 1; i >= 0; i-- {
+		handler = b.middlewares[i](handler)
+	}
+	return handler
+}
+
+// Start starts the bot HTTP server. With BOT_MODE=polling updates are fetched with
+// getUpdates as well, while the other routes keep being served.
 func (b *TelegramBot) Start(port string) error {
-	http.HandleFunc("/webhook", b.handleWebhook)
-	http.HandleFunc("/health", b.handleHealth)
-	
-	b.dependencies.Logger.Info("Bot server starting", "port", port)
-	return http.ListenAndServe(":"+port, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	server := &http.Server{
+		Addr:              ":" + port,
+		Handler:           b.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	b.lifecycle.Lock()
+	if b.closing.Load() {
+		b.lifecycle.Unlock()
+		cancel()
+		return nil
+	}
+	b.server, b.cancel = server, cancel
+	b.lifecycle.Unlock()
+
+	// Groups may address several bots, so learn which /command@name is ours
+	b.loadBotUsername(ctx)
+
+	// Start background jobs that deliver messages through this bot
+	b.dependencies.Scheduler.Start(ctx, b)
+
+	// Show the commands with descriptions in the Telegram menu
+	if CommandMenuEnabled() {
+		go func() {
+			ctx, cancel := context.WithTimeout(ctx, commandMenuTimeout)
+			defer cancel()
+			if err := b.RegisterCommands(ctx); err != nil {
+				b.dependencies.Logger.Warn("Command menu not fully registered", "error", err)
+			}
+		}()
+	}
+
+	if PollingMode() {
+		go b.Poll(ctx)
+	}
+
+	// Alert outside Telegram when the bot stops reaching it or receiving updates
+	if WatchdogEnabled() {
+		b.watchdog = NewWatchdog(b, b.dependencies.Mailer)
+		go b.watchdog.Run(ctx, watchdogInterval)
+	}
+
+	b.dependencies.Logger.Info("Bot server starting", "port", port, "base_path", b.basePath, "polling", PollingMode())
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		cancel()
+		return err
+	}
+	return nil
 }
 
 // handleWebhook processes incoming Telegram webhooks
@@ -79,6 +248,11 @@ func (b *TelegramBot) handleWebhook(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	// Telegram delivers the update again later, to the next instance
+	if b.closing.Load() {
+		http.Error(w, "Shutting down", http.StatusServiceUnavailable)
+		return
+	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -95,7 +269,7 @@ func (b *TelegramBot) handleWebhook(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Process the update asynchronously
-	go b.processUpdate(&update)
+	b.goTracked(func() { b.processUpdate(&update) })
 
 	// Respond to Telegram immediately
 	w.WriteHeader(http.StatusOK)
@@ -111,53 +285,365 @@ func (b *TelegramBot) handleHealth(w http.ResponseWriter, r *http.Request) {
 		"uptime":  uptime.String(),
 		"version": "1.0.0",
 	}
+	if b.watchdog != nil {
+		if problem := b.watchdog.Status(); problem != "" {
+			health["status"] = "degraded"
+			health["telegram"] = problem
+		}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(health)
 }
 
+// LastUpdate returns when the bot last received an update, zero before the first
+func (b *TelegramBot) LastUpdate() time.Time {
+	return unixNanoTime(b.lastUpdate.Load())
+}
+
+// LastPoll returns when getUpdates last succeeded, zero when the bot does not poll
+func (b *TelegramBot) LastPoll() time.Time {
+	return unixNanoTime(b.lastPoll.Load())
+}
+
+// unixNanoTime converts stored Unix nanoseconds back to a time, keeping 0 as zero
+func unixNanoTime(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
 // processUpdate processes a single Telegram update
 func (b *TelegramBot) processUpdate(update *TelegramUpdate) {
+	b.lastUpdate.Store(time.Now().UnixNano())
+
+	if update.CallbackQuery != nil {
+		b.processCallback(update.CallbackQuery)
+		return
+	}
+
+	if edited := update.EditedMessage; edited != nil || update.EditedChannelPost != nil {
+		if edited == nil {
+			edited = update.EditedChannelPost
+		}
+		b.processEdit(update.UpdateID, edited)
+		return
+	}
+
+	if update.ChannelPost != nil {
+		b.processChannelPost(update.ChannelPost)
+		return
+	}
+
 	if update.Message == nil {
 		return
 	}
 	
 	// Allow messages with files even if they don't have text
-	if update.Message.Text == "" && update.Message.Document == nil && len(update.Message.Photo) == 0 {
+	if update.Message.Text == "" && update.Message.Document == nil && len(update.Message.Photo) == 0 && messageVoice(update.Message) == nil {
+		return
+	}
+
+	// Documents and photos sent as an album arrive one per update, analyze them together.
+	// Screenshots sent during a /bug dialog still answer it one by one.
+	if update.Message.MediaGroupID != "" && (update.Message.Document != nil || (len(update.Message.Photo) > 0 && !b.inBugDialog(update.Message))) {
+		b.mediaGroups.Add(update.Message)
 		return
 	}
 
 	// Convert Telegram structures to domain structures
 	domainCmd := b.convertToDomainCommand(update.Message)
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	// Voice messages in groups are conversation, unless captioned with a command
+	if domainCmd.Text == "" {
+		return
+	}
+
+	// Quick action menu buttons arrive as plain text, map them back to their command
+	command, isButton := b.dependencies.Menu.ResolveButton(domainCmd.Chat.ID, domainCmd.Text)
+	if isButton {
+		domainCmd.Text = command
+	}
+
+	// Other messages of a user in an open /bug dialog answer its questions, screenshots included
+	if !isButton && b.dependencies.BugDialogs != nil && b.dependencies.BugDialogs.Active(domainCmd.Chat.ID, domainCmd.User.TelegramID) {
+		if answer := messageText(update.Message); !strings.HasPrefix(answer, "/") {
+			domainCmd.Text = strings.TrimSpace("/bug " + answer)
+		}
+	}
+
+	// Files sent without a command are only analyzed in chats that keep auto-analysis on
+	if domainCmd.Text == "/analyze" && messageText(update.Message) == "" && !b.dependencies.ChatSettings.AutoAnalyzes(domainCmd.Chat.ID) {
+		return
+	}
+
+	// In the private chat, plain messages of members added by /import_admins answer the
+	// question about their skills and capacity
+	if !isButton && update.Message.Chat.Type == "private" && update.Message.Text != "" && !strings.HasPrefix(domainCmd.Text, "/") &&
+		b.dependencies.MemberProfiles != nil && b.dependencies.MemberProfiles.Pending(domainCmd.User.TelegramID) != nil {
+		domainCmd.Text = "/member_profile " + strings.TrimSpace(update.Message.Text)
+	}
+
+	// In the private chat, plain messages during an /interview answer its question
+	if !isButton && update.Message.Chat.Type == "private" && update.Message.Text != "" && !strings.HasPrefix(domainCmd.Text, "/") &&
+		b.dependencies.Interviews != nil && b.dependencies.Interviews.Active(domainCmd.User.TelegramID) {
+		domainCmd.Text = "/interview " + strings.TrimSpace(update.Message.Text)
+	}
+
+	// Plain messages feed the opt-in chat history; in groups they are not commands, unless
+	// they share a link the chat wants summarized automatically
+	if !strings.HasPrefix(domainCmd.Text, "/") && update.Message.Text != "" {
+		b.recordHistory(update.Message)
+		if link := b.autoSummaryLink(domainCmd.Chat.ID, update.Message.Text); link != "" {
+			domainCmd.Text = "/summarize_link " + link
+		} else if taskID := b.repliedTaskID(update.Message); taskID != "" {
+			// A reply to a task card is a comment on that task
+			domainCmd.Text = "/task " + taskID + " comment " + strings.TrimSpace(update.Message.Text)
+		} else if isGroupChat(update.Message.Chat.Type) {
+			return
+		}
+	}
 
-	// Route command through the application
-	response, err := b.dependencies.Router.Route(ctx, domainCmd)
+	b.routeCommand(domainCmd)
+}
+
+// rerunEditedCommands reads EDITED_MESSAGES: "rerun" runs a command again when its message
+// is edited, e.g. to fix a typo in the arguments; "ignore", the default, drops edits
+func rerunEditedCommands() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv("EDITED_MESSAGES")), "rerun")
+}
+
+// processEdit handles the new version of an edited message. Only commands are run again,
+// so edits of conversation, answers to dialogs and album captions are ignored.
+func (b *TelegramBot) processEdit(updateID int, msg *TelegramMessage) {
+	if !b.rerunEdits || msg.Chat == nil || msg.MediaGroupID != "" || !strings.HasPrefix(messageText(msg), "/") {
+		return
+	}
+
+	b.dependencies.Logger.Info("Running edited command again", "chat_id", msg.Chat.ID, "message_id", msg.MessageID)
+	if msg.Chat.Type == "channel" {
+		b.processChannelPost(msg)
+		return
+	}
+	b.processUpdate(&TelegramUpdate{UpdateID: updateID, Message: msg})
+}
+
+// processChannelPost routes the commands posted in a channel, such as a /status_report for
+// an announcement channel. Other posts are the channel's content and are left alone.
+func (b *TelegramBot) processChannelPost(msg *TelegramMessage) {
+	if msg.Chat == nil || !strings.HasPrefix(messageText(msg), "/") {
+		return
+	}
+
+	// Channel posts have no author unless signed, the channel itself stands in for the user
+	if msg.From == nil {
+		sender := msg.SenderChat
+		if sender == nil {
+			sender = msg.Chat
+		}
+		msg.From = &TelegramUser{ID: sender.ID, FirstName: sender.Title, Username: sender.Username}
+	}
+
+	b.routeCommand(b.convertToDomainCommand(msg))
+}
+
+// processMediaGroup routes the documents and photos of an album as one command. The
+// caption of any message in the album, e.g. "/analyze --brief", applies to all of them.
+func (b *TelegramBot) processMediaGroup(messages []*TelegramMessage) {
+	base := messages[0]
+	for _, msg := range messages {
+		if strings.TrimSpace(msg.Caption) != "" {
+			base = msg
+			break
+		}
+	}
+
+	domainCmd := b.convertToDomainCommand(base)
+	if messageText(base) == "" && !b.dependencies.ChatSettings.AutoAnalyzes(domainCmd.Chat.ID) {
+		return
+	}
+	photos := 0
+	for _, msg := range messages {
+		if msg.Document != nil {
+			domainCmd.Documents = append(domainCmd.Documents, *msg.Document)
+		} else if len(msg.Photo) > 0 {
+			photos++
+			domainCmd.Documents = append(domainCmd.Documents, domain.PhotoDocument(msg.Photo, fmt.Sprintf("photo_%d.jpg", photos)))
+		}
+	}
+	if domainCmd.Document == nil {
+		domainCmd.Document = &domainCmd.Documents[0]
+	}
+
+	b.dependencies.Logger.Info("Media group received", "chat_id", domainCmd.Chat.ID, "files", len(domainCmd.Documents))
+	b.routeCommand(domainCmd)
+}
+
+// inBugDialog reports whether the sender of a message is answering an open /bug dialog
+func (b *TelegramBot) inBugDialog(msg *TelegramMessage) bool {
+	return msg.From != nil && b.dependencies.BugDialogs != nil && b.dependencies.BugDialogs.Active(msg.Chat.ID, msg.From.ID)
+}
+
+// processCallback routes the command carried by an inline keyboard button
+func (b *TelegramBot) processCallback(query *TelegramCallbackQuery) {
+	if err := b.answerCallbackQuery(query.ID); err != nil {
+		b.dependencies.Logger.Warn("Failed to answer callback query", "error", err)
+	}
+
+	if query.Message == nil || query.From == nil || query.Data == "" {
+		return
+	}
+
+	// Treat the button press as if the user sent its data in the same chat: commands are
+	// routed as such, other data goes to the callback handler claiming it
+	msg := &TelegramMessage{
+		MessageID: query.Message.MessageID,
+		From:      query.From,
+		Chat:      query.Message.Chat,
+		Text:      query.Data,
+		Date:      time.Now().Unix(),
+	}
+	domainCmd := b.convertToDomainCommand(msg)
+	domainCmd.Callback = &domain.CallbackQuery{ID: query.ID, Data: query.Data, MessageID: query.Message.MessageID}
+	b.routeCommand(domainCmd)
+}
+
+// routeCommand routes a command through the application and sends the response
+func (b *TelegramBot) routeCommand(domainCmd *domain.Command) {
+	// Route command through the application, which applies the command's time limit
+	response, err := b.dependencies.Router.Route(context.Background(), domainCmd)
 	if err != nil {
 		b.dependencies.Logger.Error("Command routing failed", 
-			"command", domainCmd.Text, 
+			"command", domain.LogText(domainCmd.Text), 
 			"user_id", domainCmd.User.TelegramID,
 			"error", err)
 		
 		// Send error response
-		b.sendTelegramMessage(update.Message.Chat.ID, "❌ Xatolik yuz berdi. Keyinroq urinib ko'ring.")
+		b.sendTelegramMessage(domainCmd.Chat.ID, "❌ Xatolik yuz berdi. Keyinroq urinib ko'ring.")
 		return
 	}
 
+	// Post a shared result to its chat before replying to the command
+	if response != nil && response.Shared != nil {
+		if err := b.sendMessage(response.Shared.ChatID, response.Shared.Text, response.Shared.ParseMode, nil); err != nil {
+			b.dependencies.Logger.Error("Failed to send shared message",
+				"chat_id", response.Shared.ChatID,
+				"error", err)
+			b.sendTelegramMessage(domainCmd.Chat.ID, "❌ Could not post to the team chat. Is the bot still a member?")
+			return
+		}
+	}
+
+	// In busy groups the answer replies to the command that asked for it
+	replyTo := 0
+	if response != nil {
+		replyTo = response.ReplyToMessageID
+		if replyTo == 0 && domainCmd.Callback == nil && isGroupChat(domainCmd.Chat.Type) {
+			replyTo = domainCmd.MessageID
+		}
+	}
+
+	// Upload a document response with its text as the caption
+	if response != nil && response.Document != nil {
+		err = b.sendDocument(context.Background(), domainCmd.Chat.ID, replyTo, response.Document, response.Text, response.ParseMode, response.ReplyMarkup)
+		if err == nil {
+			return
+		}
+		b.dependencies.Logger.Error("Failed to send Telegram document",
+			"chat_id", domainCmd.Chat.ID,
+			"file_name", response.Document.FileName,
+			"error", err)
+		// Without the file the user still gets the text of the response, unless a caption
+		// too long for the file was already sent as a message
+		text := "❌ Could not upload `" + response.Document.FileName + "`. Please try again."
+		if messageLength(response.Text) <= maxCaptionLength {
+			text = strings.TrimSpace(response.Text + "\n\n" + text)
+		}
+		response = &domain.Response{Text: text, ParseMode: response.ParseMode}
+	}
+
+	// Replace the handler's placeholder with the result, or send it as a new message when
+	// the placeholder is gone
+	if response != nil && response.Text != "" && response.EditMessageID != 0 {
+		err = b.EditMessage(context.Background(), domainCmd.Chat.ID, response.EditMessageID, response.Text, response.ParseMode, response.ReplyMarkup)
+		if err == nil {
+			return
+		}
+		b.dependencies.Logger.Warn("Failed to edit placeholder, sending a new message",
+			"chat_id", domainCmd.Chat.ID,
+			"message_id", response.EditMessageID,
+			"error", err)
+	}
+
 	// Send response back to Telegram
 	if response != nil && response.Text != "" {
-		err = b.sendTelegramMessageWithParseMode(update.Message.Chat.ID, response.Text, response.ParseMode)
+		err = b.sendReply(domainCmd.Chat.ID, replyTo, response.Text, response.ParseMode, response.ReplyMarkup)
 		if err != nil {
 			b.dependencies.Logger.Error("Failed to send Telegram message", 
-				"chat_id", update.Message.Chat.ID,
+				"chat_id", domainCmd.Chat.ID,
 				"error", err)
 		}
 	}
 }
 
+// autoSummaryLink returns the link of a plain message to summarize without a command
+func (b *TelegramBot) autoSummaryLink(chatID int64, text string) string {
+	if b.dependencies.LinkSummaries == nil || !strings.Contains(text, "http") {
+		return ""
+	}
+	return b.dependencies.LinkSummaries.AutoLink(chatID, text)
+}
+
+// repliedTaskID returns the task of the bot's task card a message replies to, or ""
+func (b *TelegramBot) repliedTaskID(msg *TelegramMessage) string {
+	reply := msg.ReplyToMessage
+	if reply == nil || reply.From == nil || !reply.From.IsBot || reply.From.ID != b.botID() {
+		return ""
+	}
+	return domain.FindTaskID(messageText(reply))
+}
+
+// botID returns the bot's user ID, which is the part of the token before the colon
+func (b *TelegramBot) botID() int64 {
+	id, _, _ := strings.Cut(b.token, ":")
+	botID, _ := strconv.ParseInt(id, 10, 64)
+	return botID
+}
+
+// isGroupChat reports whether a chat type is a group or supergroup
+func isGroupChat(chatType string) bool {
+	return chatType == "group" || chatType == "supergroup"
+}
+
+// recordHistory stores a plain chat message for chats that opted into history
+func (b *TelegramBot) recordHistory(msg *TelegramMessage) {
+	if b.dependencies.ChatHistory == nil || msg.From == nil || msg.From.IsBot {
+		return
+	}
+
+	author := msg.From.FirstName
+	if msg.From.Username != "" {
+		author = "@" + msg.From.Username
+	}
+
+	b.dependencies.ChatHistory.Record(services.BufferedMessage{
+		ChatID:    msg.Chat.ID,
+		UserID:    msg.From.ID,
+		Author:    author,
+		Text:      msg.Text,
+		Timestamp: time.Unix(msg.Date, 0),
+	})
+}
+
+// messageText returns the text of a message, or the caption of a file or photo
+func messageText(msg *TelegramMessage) string {
+	if text := strings.TrimSpace(msg.Text); text != "" {
+		return text
+	}
+	return strings.TrimSpace(msg.Caption)
+}
+
 // convertToDomainCommand converts Telegram message to domain command
 func (b *TelegramBot) convertToDomainCommand(msg *TelegramMessage) *domain.Command {
 	cmd := &domain.Command{
@@ -178,67 +664,362 @@ func (b *TelegramBot) convertToDomainCommand(msg *TelegramMessage) *domain.Comma
 			Username: msg.Chat.Username,
 		},
 		Timestamp: time.Unix(msg.Date, 0),
+		MessageID: msg.MessageID,
 		// Include file attachments
 		Document:  msg.Document,
 		Photo:     msg.Photo,
+		Voice:     messageVoice(msg),
 	}
 	
-	// If there's no text but there's a file, set the text to /analyze for automatic processing
-	if cmd.Text == "" && (msg.Document != nil || len(msg.Photo) > 0) {
+	// Keep the replied-to text so commands like /translate can work on it
+	if msg.ReplyToMessage != nil {
+		cmd.ReplyToText = msg.ReplyToMessage.Text
+		if cmd.ReplyToText == "" {
+			cmd.ReplyToText = msg.ReplyToMessage.Caption
+		}
+		cmd.ReplyToDocument = msg.ReplyToMessage.Document
+		cmd.ReplyToVoice = messageVoice(msg.ReplyToMessage)
+	}
+
+	// A file captioned with a command, e.g. "/import_json", is handled by that command
+	if cmd.Text == "" && strings.HasPrefix(strings.TrimSpace(msg.Caption), "/") {
+		cmd.Text = strings.TrimSpace(msg.Caption)
+	}
+
+	// If there's no text but there's a file, set the text to /analyze for automatic processing.
+	// Voice messages are only analyzed unasked in the private chat.
+	if cmd.Text == "" && (msg.Document != nil || len(msg.Photo) > 0 || (cmd.Voice != nil && msg.Chat.Type == "private")) {
 		cmd.Text = "/analyze"
 	}
 	
 	return cmd
 }
 
+// messageVoice returns the voice message or audio file of a message, if any
+func messageVoice(msg *TelegramMessage) *domain.TelegramVoice {
+	if msg.Voice != nil {
+		return msg.Voice
+	}
+	return msg.Audio
+}
+
 // sendTelegramMessage sends a message to Telegram with HTML parse mode (for backwards compatibility)
 func (b *TelegramBot) sendTelegramMessage(chatID int64, text string) error {
 	return b.sendTelegramMessageWithParseMode(chatID, text, "HTML")
 }
 
+// SendMessage implements domain.MessageSender for background jobs
+func (b *TelegramBot) SendMessage(ctx context.Context, chatID int64, text, parseMode string) error {
+	return b.sendTelegramMessageWithParseMode(chatID, text, parseMode)
+}
+
+// SendMessageWithKeyboard implements domain.KeyboardSender for background jobs
+func (b *TelegramBot) SendMessageWithKeyboard(ctx context.Context, chatID int64, text, parseMode string, keyboard *domain.InlineKeyboardMarkup) error {
+	return b.sendMessage(chatID, text, parseMode, keyboard)
+}
+
 // sendTelegramMessageWithParseMode sends a message to Telegram with specified parse mode
 func (b *TelegramBot) sendTelegramMessageWithParseMode(chatID int64, text string, parseMode string) error {
+	return b.sendMessage(chatID, text, parseMode, nil)
+}
+
+// sendMessage sends a message with an optional reply markup such as an inline keyboard
+func (b *TelegramBot) sendMessage(chatID int64, text, parseMode string, replyMarkup interface{}) error {
+	return b.sendReply(chatID, 0, text, parseMode, replyMarkup)
+}
+
+// sendReply sends a message as a reply to replyTo, or as a plain message when it is 0. The
+// message is still sent when the one it replies to was deleted.
+func (b *TelegramBot) sendReply(chatID int64, replyTo int, text, parseMode string, replyMarkup interface{}) error {
+	// Telegram rejects long texts, so they go out as several messages with the first replying
+	// and the keyboard on the last
+	if chunks := splitMessage(text, maxMessageLength); len(chunks) > 1 {
+		for i, chunk := range chunks {
+			var markup interface{}
+			if i == len(chunks)-1 {
+				markup = replyMarkup
+			}
+			if err := b.sendReply(chatID, replyTo, chunk, parseMode, markup); err != nil {
+				return fmt.Errorf("failed to send part %d of %d: %w", i+1, len(chunks), err)
+			}
+			replyTo = 0
+		}
+		return nil
+	}
+
+	message := &outgoingMessage{chatID: chatID, replyTo: replyTo, text: text, parseMode: parseMode, replyMarkup: replyMarkup}
+
+	// Messages to a chat with rate limited ones wait behind them to keep their order
+	if b.sendQueue.holds(chatID) {
+		return b.sendQueue.add(message)
+	}
+
+	err := b.postMessage(message)
+	if limited := rateLimited(err); limited != nil && limited.RetryAfter <= maxRetryAfter {
+		b.dependencies.Logger.Warn("Telegram rate limit reached, message queued",
+			"chat_id", chatID,
+			"retry_after", limited.RetryAfter)
+		message.attempts, message.retryAfter = 1, limited.RetryAfter
+		if queueErr := b.sendQueue.add(message); queueErr != nil {
+			b.dependencies.Metrics.RecordTelegramRetry(false)
+			return fmt.Errorf("%w: %w", queueErr, err)
+		}
+		return nil
+	}
+	return err
+}
+
+// postMessage calls sendMessage once. A 429 answer is returned as a rate limited
+// services.TelegramAPIError.
+func (b *TelegramBot) postMessage(message *outgoingMessage) error {
 	// Default to HTML if parseMode is empty
+	parseMode := message.parseMode
 	if parseMode == "" {
 		parseMode = "HTML"
 	}
 
 	payload := map[string]interface{}{
-		"chat_id":    chatID,
-		"text":       text,
+		"chat_id":    message.chatID,
+		"text":       message.text,
 		"parse_mode": parseMode,
 	}
+	// A keyboard builder without buttons yields a nil keyboard, which is no markup
+	replyMarkup := message.replyMarkup
+	if keyboard, ok := replyMarkup.(*domain.InlineKeyboardMarkup); ok && keyboard == nil {
+		replyMarkup = nil
+	}
+	if replyMarkup != nil {
+		payload["reply_markup"] = replyMarkup
+	}
+	if message.replyTo != 0 {
+		payload["reply_parameters"] = replyParameters(message.replyTo)
+	}
 
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
+	// /simulate telegram_429 answers in Telegram's place, so the message takes the same
+	// queue and retries as a real rate limit
+	err := b.countRateLimit(b.dependencies.Simulator.TelegramError())
+	if err == nil {
+		err = b.callAPI(context.Background(), "sendMessage", payload, nil)
+	}
+
+	// If it's a Markdown parsing error and we're using Markdown, fallback to plain text
+	if err != nil && parseMode == "Markdown" && strings.Contains(err.Error(), "can't parse entities") {
+		b.dependencies.Logger.Warn("Markdown parsing failed, falling back to plain text",
+			"chat_id", message.chatID,
+			"error", err)
+
+		// Strip Markdown formatting and retry with no parse mode
+		plain := *message
+		plain.text, plain.parseMode = stripMarkdown(message.text), ""
+		return b.postMessage(&plain)
+	}
+	return err
+}
+
+// SendDocument implements domain.DocumentSender for jobs that deliver files
+func (b *TelegramBot) SendDocument(ctx context.Context, chatID int64, document *domain.OutgoingDocument, caption, parseMode string) error {
+	return b.sendDocument(ctx, chatID, 0, document, caption, parseMode, nil)
+}
+
+// sendDocument uploads a file, or an inline photo, to the chat with an optional caption and
+// reply markup, replying to replyTo when it is set. A caption too long for Telegram is sent
+// as a message before the file.
+func (b *TelegramBot) sendDocument(ctx context.Context, chatID int64, replyTo int, document *domain.OutgoingDocument, caption, parseMode string, replyMarkup interface{}) error {
+	if document.Report {
+		caption = b.withReportLink(ctx, document, caption, parseMode)
+	}
+	if messageLength(caption) > maxCaptionLength {
+		if err := b.sendReply(chatID, replyTo, caption, parseMode, nil); err != nil {
+			return fmt.Errorf("failed to send caption: %w", err)
+		}
+		caption, replyTo = "", 0
 	}
 
-	url := fmt.Sprintf("%s/sendMessage", b.url)
-	resp, err := http.Post(url, "application/json", strings.NewReader(string(jsonPayload)))
+	err := b.uploadDocument(ctx, chatID, replyTo, document, caption, parseMode, replyMarkup)
+	if err != nil && parseMode == "Markdown" && strings.Contains(err.Error(), "can't parse entities") {
+		b.dependencies.Logger.Warn("Markdown parsing failed, falling back to plain text",
+			"chat_id", chatID,
+			"error", err)
+		err = b.uploadDocument(ctx, chatID, replyTo, document, stripMarkdown(caption), "", replyMarkup)
+	}
+	return err
+}
+
+// uploadDocument posts a file with sendDocument, or sendPhoto for inline photos
+func (b *TelegramBot) uploadDocument(ctx context.Context, chatID int64, replyTo int, document *domain.OutgoingDocument, caption, parseMode string, replyMarkup interface{}) error {
+	method, field := "sendDocument", "document"
+	if document.Photo {
+		method, field = "sendPhoto", "photo"
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	writer.WriteField("chat_id", strconv.FormatInt(chatID, 10))
+	if caption != "" {
+		writer.WriteField("caption", caption)
+		if parseMode != "" {
+			writer.WriteField("parse_mode", parseMode)
+		}
+	}
+	if keyboard, ok := replyMarkup.(*domain.InlineKeyboardMarkup); ok && keyboard == nil {
+		replyMarkup = nil
+	}
+	if replyMarkup != nil {
+		markup, err := json.Marshal(replyMarkup)
+		if err != nil {
+			return fmt.Errorf("failed to marshal reply markup: %w", err)
+		}
+		writer.WriteField("reply_markup", string(markup))
+	}
+	if replyTo != 0 {
+		parameters, err := json.Marshal(replyParameters(replyTo))
+		if err != nil {
+			return fmt.Errorf("failed to marshal reply parameters: %w", err)
+		}
+		writer.WriteField("reply_parameters", string(parameters))
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, strings.ReplaceAll(document.FileName, `"`, "'")))
+	header.Set("Content-Type", documentContentType(document))
+	part, err := writer.CreatePart(header)
 	if err != nil {
-		return fmt.Errorf("failed to send HTTP request: %w", err)
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := part.Write(document.Content); err != nil {
+		return fmt.Errorf("failed to write document: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close form: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		
-		// If it's a Markdown parsing error and we're using Markdown, fallback to plain text
-		if parseMode == "Markdown" && strings.Contains(string(body), "can't parse entities") {
-			b.dependencies.Logger.Warn("Markdown parsing failed, falling back to plain text", 
-				"chat_id", chatID, 
-				"error", string(body))
-			
-			// Strip Markdown formatting and retry with no parse mode
-			plainText := stripMarkdown(text)
-			return b.sendTelegramMessageWithParseMode(chatID, plainText, "")
+	return b.callUpload(ctx, method, writer.FormDataContentType(), &body)
+}
+
+// replyParameters threads a message as a reply, sending it even when the replied-to message
+// was deleted in the meantime
+func replyParameters(messageID int) map[string]interface{} {
+	return map[string]interface{}{
+		"message_id":                  messageID,
+		"allow_sending_without_reply": true,
+	}
+}
+
+// documentTypes covers report formats missing from Go's built-in MIME table on hosts
+// without a mime.types file
+var documentTypes = map[string]string{
+	".csv":  "text/csv",
+	".md":   "text/markdown",
+	".txt":  "text/plain",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// documentContentType returns the MIME type of an upload, from its file name unless set
+func documentContentType(document *domain.OutgoingDocument) string {
+	if document.ContentType != "" {
+		return document.ContentType
+	}
+	if contentType, ok := documentTypes[strings.ToLower(filepath.Ext(document.FileName))]; ok {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(filepath.Ext(document.FileName)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// SendChatAction implements domain.ChatActionSender for long-running commands
+func (b *TelegramBot) SendChatAction(ctx context.Context, chatID int64, action string) error {
+	return b.callAPI(ctx, "sendChatAction", map[string]interface{}{"chat_id": chatID, "action": action}, nil)
+}
+
+// answerCallbackQuery acknowledges a button press so the client stops showing a spinner
+func (b *TelegramBot) answerCallbackQuery(queryID string) error {
+	return b.callAPI(context.Background(), "answerCallbackQuery", map[string]interface{}{"callback_query_id": queryID}, nil)
+}
+
+// SendPlaceholder implements domain.Responder: it posts a plain text message, such as
+// "⏳ Analyzing...", and returns its ID so the result can replace it
+func (b *TelegramBot) SendPlaceholder(ctx context.Context, chatID int64, text string) (int, error) {
+	var result struct {
+		MessageID int `json:"message_id"`
+	}
+	if err := b.callAPI(ctx, "sendMessage", map[string]interface{}{"chat_id": chatID, "text": text}, &result); err != nil {
+		return 0, err
+	}
+	return result.MessageID, nil
+}
+
+// EditMessage implements domain.Responder by replacing the text and keyboard of a message
+// the bot sent. Like sendMessage, it retries as plain text when Telegram rejects the Markdown,
+// and a text too long for one message continues in new messages after the edited one.
+func (b *TelegramBot) EditMessage(ctx context.Context, chatID int64, messageID int, text, parseMode string, replyMarkup interface{}) error {
+	if chunks := splitMessage(text, maxMessageLength); len(chunks) > 1 {
+		if err := b.EditMessage(ctx, chatID, messageID, chunks[0], parseMode, nil); err != nil {
+			return err
 		}
-		
-		return fmt.Errorf("telegram API error: %d, response: %s", resp.StatusCode, string(body))
+		for i, chunk := range chunks[1:] {
+			var markup interface{}
+			if i == len(chunks)-2 {
+				markup = replyMarkup
+			}
+			if err := b.sendMessage(chatID, chunk, parseMode, markup); err != nil {
+				return fmt.Errorf("failed to send part %d of %d: %w", i+2, len(chunks), err)
+			}
+		}
+		return nil
 	}
 
-	return nil
+	if parseMode == "" {
+		parseMode = "HTML"
+	}
+
+	payload := map[string]interface{}{
+		"chat_id":    chatID,
+		"message_id": messageID,
+		"text":       text,
+		"parse_mode": parseMode,
+	}
+	if keyboard, ok := replyMarkup.(*domain.InlineKeyboardMarkup); ok && keyboard == nil {
+		replyMarkup = nil
+	}
+	if replyMarkup != nil {
+		payload["reply_markup"] = replyMarkup
+	}
+
+	err := b.callAPI(ctx, "editMessageText", payload, nil)
+	if err == nil || strings.Contains(err.Error(), "message is not modified") {
+		return nil
+	}
+	if parseMode == "Markdown" && strings.Contains(err.Error(), "can't parse entities") {
+		b.dependencies.Logger.Warn("Markdown parsing failed, falling back to plain text",
+			"chat_id", chatID,
+			"error", err)
+		payload["text"] = stripMarkdown(text)
+		delete(payload, "parse_mode")
+		err = b.callAPI(ctx, "editMessageText", payload, nil)
+	}
+	return err
+}
+
+// callAPI calls a Bot API method with a JSON payload, decoding its result into result when
+// it is not nil, and counts the calls Telegram rate limited
+func (b *TelegramBot) callAPI(ctx context.Context, method string, payload interface{}, result interface{}) error {
+	return b.countRateLimit(b.telegram.Call(ctx, method, payload, result))
+}
+
+// callUpload calls a Bot API method with a multipart body like callAPI
+func (b *TelegramBot) callUpload(ctx context.Context, method, contentType string, body io.Reader) error {
+	return b.countRateLimit(b.telegram.Upload(ctx, method, contentType, body, nil))
+}
+
+// countRateLimit records a 429 answer in the metrics and returns err unchanged
+func (b *TelegramBot) countRateLimit(err error) error {
+	if rateLimited(err) != nil {
+		b.dependencies.Metrics.RecordTelegramRateLimit()
+	}
+	return err
 }
 
 // stripMarkdown removes Markdown formatting from text to create plain text fallback
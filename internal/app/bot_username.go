@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+)
+
+// botUsernameTimeout bounds the getMe call looking up the bot's username at startup
+const botUsernameTimeout = 10 * time.Second
+
+// usernameAware is a router that answers only commands addressed to this bot
+type usernameAware interface {
+	SetBotUsername(username string)
+}
+
+// loadBotUsername tells the router the bot's username, from BOT_USERNAME or else getMe.
+// Without it the router answers /command@name whatever the name is.
+func (b *TelegramBot) loadBotUsername(ctx context.Context) {
+	router, ok := b.dependencies.Router.(usernameAware)
+	if !ok {
+		return
+	}
+
+	username := strings.TrimPrefix(strings.TrimSpace(os.Getenv("BOT_USERNAME")), "@")
+	if username == "" {
+		ctx, cancel := context.WithTimeout(ctx, botUsernameTimeout)
+		defer cancel()
+		var me struct {
+			Username string `json:"username"`
+		}
+		if err := b.callAPI(ctx, "getMe", map[string]interface{}{}, &me); err != nil {
+			b.dependencies.Logger.Warn("Bot username unknown, answering commands addressed to any bot", "error", err)
+			return
+		}
+		username = me.Username
+	}
+
+	router.SetBotUsername(username)
+	b.dependencies.Logger.Info("Bot username loaded", "username", username)
+}
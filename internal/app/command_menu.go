@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Telegram limits on the command menu
+const (
+	maxMenuCommands          = 100
+	maxMenuDescriptionLength = 256
+	commandMenuTimeout       = 30 * time.Second
+)
+
+// menuCommandPattern finds commands in usage strings; Telegram only accepts lowercase
+// names of up to 32 letters, digits and underscores
+var (
+	menuCommandPattern  = regexp.MustCompile(`(?:^|[\s;|(])/([a-z0-9_]{1,32})\b`)
+	languageCodePattern = regexp.MustCompile(`^[a-z]{2}$`)
+)
+
+// MenuCommand is one entry of the Telegram command menu
+type MenuCommand struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+	// scope is the handler's MenuScope, empty for commands that work everywhere
+	scope string
+}
+
+// CommandMenuEnabled reports whether the command menu is registered on startup; set
+// REGISTER_COMMANDS=false to manage it with BotFather instead
+func CommandMenuEnabled() bool {
+	return os.Getenv("REGISTER_COMMANDS") != "false"
+}
+
+// menuCommands collects the commands of the registered handlers for the menu, in the order
+// of the help text. Descriptions come from the help text, then from the handler.
+func (b *TelegramBot) menuCommands() []MenuCommand {
+	if b.dependencies.Router == nil {
+		return nil
+	}
+	help := ""
+	if b.dependencies.Config != nil {
+		help = b.dependencies.Config.Messages.Help
+	}
+	helpDescriptions, helpOrder := parseHelpCommands(help)
+
+	var commands []MenuCommand
+	seen := make(map[string]bool)
+	for _, handler := range b.dependencies.Router.GetHandlers() {
+		scope := ""
+		if scoped, ok := handler.(domain.MenuScoped); ok {
+			scope = scoped.MenuScope()
+		}
+		if scope == domain.MenuScopeHidden {
+			continue
+		}
+		// A usage string also mentions arguments such as "/alias add /short /command", so
+		// only the commands the handler itself answers are kept
+		for _, match := range menuCommandPattern.FindAllStringSubmatch(handler.Usage(), -1) {
+			name := match[1]
+			if seen[name] || !handler.CanHandle("/"+name) {
+				continue
+			}
+			seen[name] = true
+			description := helpDescriptions[name]
+			if description == "" {
+				description = strings.TrimSpace(handler.Description())
+			}
+			commands = append(commands, MenuCommand{Command: name, Description: menuDescription(description, name), scope: scope})
+		}
+	}
+
+	sort.SliceStable(commands, func(i, j int) bool {
+		return helpPosition(helpOrder, commands[i].Command) < helpPosition(helpOrder, commands[j].Command)
+	})
+	return commands
+}
+
+// parseHelpCommands reads "/command args - description" lines of the help text and returns
+// the description and position of each command
+func parseHelpCommands(help string) (map[string]string, map[string]int) {
+	descriptions := make(map[string]string)
+	order := make(map[string]int)
+	for _, line := range strings.Split(help, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "/") {
+			continue
+		}
+		name := strings.ToLower(strings.TrimPrefix(strings.Fields(line)[0], "/"))
+		if _, ok := order[name]; ok {
+			continue
+		}
+		order[name] = len(order)
+		if _, description, ok := strings.Cut(line, " - "); ok {
+			descriptions[name] = strings.TrimSpace(description)
+		}
+	}
+	return descriptions, order
+}
+
+// helpPosition returns where a command is listed in the help text; commands missing from
+// it go last
+func helpPosition(order map[string]int, name string) int {
+	if position, ok := order[name]; ok {
+		return position
+	}
+	return len(order)
+}
+
+// menuDescription fits a description into the menu's 1-256 characters
+func menuDescription(description, name string) string {
+	if description == "" {
+		description = "/" + name
+	}
+	if runes := []rune(description); len(runes) > maxMenuDescriptionLength {
+		description = string(runes[:maxMenuDescriptionLength-1]) + "…"
+	}
+	return description
+}
+
+// RegisterCommands sets the command menu shown in Telegram: all commands by default, and
+// separate lists for private and group chats without the commands that do not work there.
+// Each language in the config's "commands" section gets its own lists, with translated
+// descriptions where the config has them.
+func (b *TelegramBot) RegisterCommands(ctx context.Context) error {
+	commands := b.menuCommands()
+	if len(commands) == 0 {
+		return nil
+	}
+
+	languages := []string{""}
+	translations := map[string]map[string]string{}
+	if b.dependencies.Config != nil {
+		translations = b.dependencies.Config.Commands
+	}
+	for language := range translations {
+		if !languageCodePattern.MatchString(language) {
+			b.dependencies.Logger.Warn("Skipping command translations with an invalid language code", "language", language)
+			continue
+		}
+		languages = append(languages, language)
+	}
+	sort.Strings(languages[1:])
+
+	scopes := []struct {
+		scope   string
+		exclude string
+	}{
+		{"default", ""},
+		{"all_private_chats", domain.MenuScopeGroup},
+		{"all_group_chats", domain.MenuScopePrivate},
+	}
+
+	var failed []string
+	var lastErr error
+	for _, scope := range scopes {
+		for _, language := range languages {
+			list := scopedMenu(commands, scope.exclude, translations[language])
+			payload := map[string]interface{}{
+				"commands": list,
+				"scope":    map[string]string{"type": scope.scope},
+			}
+			if language != "" {
+				payload["language_code"] = language
+			}
+			if err := b.callAPI(ctx, "setMyCommands", payload, nil); err != nil {
+				failed = append(failed, strings.TrimSpace(scope.scope+" "+language))
+				lastErr = err
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to register commands for %s: %w", strings.Join(failed, ", "), lastErr)
+	}
+	b.dependencies.Logger.Info("Command menu registered", "commands", len(commands), "languages", len(languages)-1)
+	return nil
+}
+
+// scopedMenu returns the commands for one scope and language, at most Telegram's limit
+func scopedMenu(commands []MenuCommand, exclude string, translations map[string]string) []MenuCommand {
+	var list []MenuCommand
+	for _, command := range commands {
+		if exclude != "" && command.scope == exclude {
+			continue
+		}
+		if translated := strings.TrimSpace(translations[command.Command]); translated != "" {
+			command.Description = menuDescription(translated, command.Command)
+		}
+		list = append(list, command)
+		if len(list) == maxMenuCommands {
+			break
+		}
+	}
+	return list
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"yordamchi-dev-bot/handlers"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/handlers/commands"
+)
+
+// scopedCommand answers one command that only works in some chats
+type scopedCommand struct {
+	name, usage, scope string
+}
+
+func (c *scopedCommand) CanHandle(command string) bool { return command == "/"+c.name }
+func (c *scopedCommand) Description() string           { return "Handler description of " + c.name }
+func (c *scopedCommand) Usage() string                 { return c.usage }
+func (c *scopedCommand) MenuScope() string             { return c.scope }
+func (c *scopedCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	return &domain.Response{}, nil
+}
+
+func TestRegisterCommandsPerScopeAndLanguage(t *testing.T) {
+	type call struct {
+		Commands []MenuCommand     `json:"commands"`
+		Scope    map[string]string `json:"scope"`
+		Language string            `json:"language_code"`
+	}
+	var calls []call
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload call
+		json.NewDecoder(r.Body).Decode(&payload)
+		calls = append(calls, payload)
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer server.Close()
+
+	logger := NewStructuredLogger()
+	router := NewCommandRouter(logger)
+	router.RegisterHandler(&scopedCommand{name: "email", usage: "/email [address | off] - Email notifications", scope: domain.MenuScopePrivate})
+	router.RegisterHandler(commands.NewHazilCommand([]string{"joke"}, logger))
+	router.RegisterHandler(&scopedCommand{name: "import_admins", usage: "/import_admins - Add admins", scope: domain.MenuScopeGroup})
+	router.RegisterHandler(&scopedCommand{name: "alias", usage: "/alias [add /short /command] - Shortcuts"})
+	router.RegisterHandler(&scopedCommand{name: "simulate", usage: "/simulate - Faults", scope: domain.MenuScopeHidden})
+
+	config := &handlers.Config{
+		Messages: handlers.MessageConfig{Help: "📚 Commands:\n/hazil - Random programming joke\n/email - Task digest by email\n/import_admins - Add the chat admins to the team"},
+		Commands: map[string]map[string]string{"ru": {"hazil": "Случайная шутка"}, "russian": {"hazil": "ignored"}},
+	}
+	bot := NewTelegramBot("token", &Dependencies{Logger: logger, Router: router, Config: config})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+
+	if err := bot.RegisterCommands(context.Background()); err != nil {
+		t.Fatalf("RegisterCommands() error = %v", err)
+	}
+	// Three scopes, each without and with the one valid language
+	if len(calls) != 6 {
+		t.Fatalf("expected 6 setMyCommands calls, got %d: %+v", len(calls), calls)
+	}
+
+	names := func(list []MenuCommand) []string {
+		var result []string
+		for _, command := range list {
+			result = append(result, command.Command)
+		}
+		return result
+	}
+	want := map[string][]string{
+		"default":           {"hazil", "email", "import_admins", "alias"},
+		"all_private_chats": {"hazil", "email", "alias"},
+		"all_group_chats":   {"hazil", "import_admins", "alias"},
+	}
+	for _, c := range calls {
+		got, expected := strings.Join(names(c.Commands), " "), strings.Join(want[c.Scope["type"]], " ")
+		if got != expected {
+			t.Errorf("scope %s %q: got commands %q, want %q", c.Scope["type"], c.Language, got, expected)
+		}
+
+		description := c.Commands[0].Description
+		if c.Language == "ru" && description != "Случайная шутка" {
+			t.Errorf("expected the Russian description, got %q", description)
+		}
+		if c.Language == "" && description != "Random programming joke" {
+			t.Errorf("expected the help text description, got %q", description)
+		}
+		if c.Commands[len(c.Commands)-1].Description != "Handler description of alias" {
+			t.Errorf("expected a command missing from the help to use the handler description, got %q", c.Commands[len(c.Commands)-1].Description)
+		}
+	}
+}
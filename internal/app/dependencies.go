@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -29,8 +30,25 @@ type Dependencies struct {
 	UserService    domain.UserService
 	
 	// DevTaskMaster Services
-	TaskAnalyzer   *services.TaskAnalyzer
-	TeamManager    *services.TeamManager
+	TaskAnalyzer    *services.TaskAnalyzer
+	TeamManager     *services.TeamManager
+	CalendarService *services.CalendarService
+	Scheduler       *services.Scheduler
+	ChatHistory     *services.ChatHistoryService
+	Menu            *services.MenuService
+	ChatSettings    *services.ChatSettingsService
+	BugDialogs      *services.BugDialogService
+	MemberProfiles  *services.MemberProfileService
+	Interviews      *services.InterviewService
+	LinkSummaries   *services.LinkSummaryService
+	Simulator       *services.Simulator
+	ChatActions     *middleware.ChatActionMiddleware
+	Metrics         *MetricsProvider
+	Mailer          *services.Mailer
+	ReportLinks     *services.ReportLinkService
+	JobQueue        *services.JobQueue
+	ProjectWebhooks *services.ProjectWebhooks
+	TelegramAPI     *services.TelegramClient
 
 	// Bot
 	StartTime time.Time
@@ -51,40 +69,146 @@ func NewDependencies(config *handlers.Config, db *database.DB) (*Dependencies, e
 	weatherService := services.NewWeatherService(serviceLogger)
 	userService := NewUserService(db, logger)
 	
+	// Every Bot API call is logged with the token and user chats redacted, and measured
+	metricsMiddleware := middleware.NewMetricsMiddleware(logger)
+	telegramTransport := services.NewTelegramTransport(nil, services.TelegramRedactionFromEnv(os.Getenv("BOT_TOKEN")), logger, metricsMiddleware)
+	// The bot and the services that call Telegram share one client and its connection pool
+	telegramClient := services.NewTelegramClient(os.Getenv("BOT_TOKEN"), telegramTransport)
+
 	// Create file processing services
 	fileExtractor := services.NewFileExtractor(logger)
-	telegramFileService := services.NewTelegramFileService(os.Getenv("BOT_TOKEN"), logger)
+	telegramFileService := services.NewTelegramFileService(telegramClient, logger)
+	telegramChatService := services.NewTelegramChatService(telegramClient, logger)
 	
 	// Create DevTaskMaster services
 	taskAnalyzer := services.NewTaskAnalyzer(serviceLogger)
 	teamManager := services.NewTeamManager()
+	holidayService := services.NewHolidayService(serviceLogger)
+	calendarService := services.NewCalendarService(db, holidayService, logger)
+	estimationService := services.NewEstimationService(db, logger)
+	aiUsageService := services.NewAIUsageService(db, services.BotLocation(), logger)
+	fileUsageService := services.NewFileUsageService(db, services.BotLocation(), logger)
+	escalationService := services.NewEscalationService(db, logger)
+	notificationBridge := services.NewNotificationBridge(db, logger)
+	projectWebhooks := services.NewProjectWebhooks(db, logger)
+	mailer := services.NewMailer(logger)
+	reportLinks := services.NewReportLinkService(
+		services.NewLocalReportStorage(os.Getenv("REPORT_STORAGE_DIR")),
+		services.ReportLinkSecret(os.Getenv("REPORT_LINK_SECRET"), os.Getenv("BOT_TOKEN")),
+		os.Getenv("PUBLIC_URL"), logger)
+	aliasService := services.NewAliasService(db, logger)
+	menuService := services.NewMenuService(db, logger)
+	chatSettings := services.NewChatSettingsService(db, logger)
+	cooldowns := services.NewCooldownService(config.Cooldowns, db, logger)
+	bugDialogs := services.NewBugDialogService()
+	memberProfiles := services.NewMemberProfileService()
+	chatHistory := services.NewChatHistoryService(services.NewMessageBuffer(), db, logger)
+	retentionService := services.NewRetentionService(db, logger)
+	aiChain := services.NewAIChain(logger)
+	translationService := services.NewTranslationService(services.NewAITranslator(aiChain), logger)
+	summaryService := services.NewSummaryService(aiChain, logger)
+	testPlanService := services.NewTestPlanService(aiChain, logger)
+	statusReportService := services.NewStatusReportService(aiChain, logger)
+	diagramRenderer := services.NewKrokiRenderer(logger)
+	chatService := services.NewChatService(aiChain, logger)
+	interviewService := services.NewInterviewService(aiChain, logger)
+	linkSummaryService := services.NewLinkSummaryService(services.NewPublicHTTPClient(20*time.Second, serviceLogger), aiChain, db, logger)
+	feedReader := services.NewFeedReader(services.NewPublicHTTPClient(20*time.Second, serviceLogger))
+	dependencyChecker := services.NewDependencyChecker(serviceLogger)
+	playgroundService := services.NewPlaygroundService(serviceLogger)
+	goLinter := services.NewGoLinter(logger)
+	telemetry := services.NewTelemetry(db, logger)
+	taskAnalyzer.SetTelemetry(telemetry)
+	aiChain.SetTelemetry(telemetry)
+	simulator := services.NewSimulator()
+	taskAnalyzer.SetSimulator(simulator)
+	aiChain.SetSimulator(simulator)
+	aiStats := services.NewAIStats()
+	taskAnalyzer.SetAIStats(aiStats)
+	aiChain.SetAIStats(aiStats)
+	botAdmins := services.NewBotAdmins()
+
+	// Report misconfigured AI keys at startup instead of on the first /analyze
+	if os.Getenv("AI_STARTUP_CHECK") != "false" {
+		go aiChain.LogCheckReport(context.Background())
+	}
+
+	// Voice requirements are transcribed with the OpenAI or Groq Whisper API
+	transcriptionService := services.NewTranscriptionService(logger)
+
+	// Embeddings for semantic search use the OpenAI or Gemini key
+	embeddingService := services.NewEmbeddingService(logger)
+
+	// Per-user usage profile for "did you know" hints about untried commands
+	hintService := services.NewHintService(db, logger)
+
+	// Create scheduler for background jobs
+	scheduler := services.NewScheduler(logger)
+	scheduler.AddJob("scheduled_messages", 30*time.Second, newScheduledMessageJob(db, logger))
+	scheduler.AddJob("embedding_index", 10*time.Minute, newEmbeddingIndexJob(db, embeddingService, logger))
+	scheduler.AddJob("history_retention", 10*time.Minute, newHistoryRetentionJob(chatHistory, retentionService, logger))
+	scheduler.AddJob("data_retention", time.Hour, newDataRetentionJob(retentionService, services.BotLocation(), logger))
+	scheduler.AddJob("task_escalation", 5*time.Minute, newTaskEscalationJob(db, escalationService, calendarService, logger))
+	scheduler.AddJob("project_health", time.Hour, newProjectHealthJob(db, calendarService, notificationBridge, projectWebhooks, services.BotLocation(), logger))
+	scheduler.AddJob("mood_checkins", time.Hour, newMoodCheckinJob(db, services.BotLocation(), logger))
+	scheduler.AddJob("daily_challenge", time.Hour, newDailyChallengeJob(db, services.BotLocation(), logger))
+	scheduler.AddJob("feed_poll", 5*time.Minute, newFeedPollJob(db, feedReader, feedPollInterval(), logger))
+	scheduler.AddJob("dependency_reports", time.Hour, newDependencyReportJob(db, dependencyChecker, logger))
+	scheduler.AddJob("utilization_snapshots", time.Hour, newUtilizationSnapshotJob(db, services.BotLocation(), logger))
+	scheduler.AddJob("sprint_summary", time.Hour, newSprintSummaryJob(db, estimationService, notificationBridge, services.BotLocation(), logger))
+	scheduler.AddJob("status_report", time.Hour, newStatusReportJob(db, calendarService, statusReportService, notificationBridge, services.BotLocation(), logger))
+	if mailer.Enabled() {
+		scheduler.AddJob("email_notifications", time.Hour, newEmailNotificationJob(db, calendarService, mailer, os.Getenv("PUBLIC_URL"), services.BotLocation(), logger))
+	}
+	if telemetry.Enabled() {
+		scheduler.AddJob("telemetry", 24*time.Hour, newTelemetryJob(telemetry))
+	}
 
 	// Create router
 	router := NewCommandRouter(logger)
+	router.SetAliasResolver(aliasService)
+	router.SetChatPolicy(chatSettings)
+	router.SetUnknownCommandText(config.Messages.UnknownCommand)
 
 	// Create and register middlewares
 	loggingMiddleware := middleware.NewLoggingMiddleware(logger)
 	validationMiddleware := middleware.NewValidationMiddleware(logger)
 	cachingMiddleware := middleware.NewCachingMiddleware(logger)
-	metricsMiddleware := middleware.NewMetricsMiddleware(logger)
+	telemetryMiddleware := middleware.NewTelemetryMiddleware(telemetry)
 	authMiddleware := middleware.NewAuthMiddleware(userService, logger)
 	activityMiddleware := middleware.NewActivityMiddleware(db, logger)
+	hintMiddleware := middleware.NewHintMiddleware(hintService, logger)
 	rateLimitMiddleware := middleware.NewRateLimitMiddleware(10, time.Minute, logger) // 10 requests per minute
+	cooldownMiddleware := middleware.NewCooldownMiddleware(cooldowns, logger)
+	chatActionMiddleware := middleware.NewChatActionMiddleware(logger)
+
+	// Alert the operators when commands get slow
+	if threshold := latencyAlertThreshold(); threshold > 0 && len(botAdmins.AlertChats()) > 0 {
+		scheduler.AddJob("latency_alert", 5*time.Minute, newLatencyAlertJob(metricsMiddleware, botAdmins, threshold, logger))
+	}
 
 	// Register middleware in optimal order
 	router.RegisterMiddleware(loggingMiddleware)     // Log first
 	router.RegisterMiddleware(metricsMiddleware)     // Metrics collection
+	router.RegisterMiddleware(telemetryMiddleware)   // Anonymized usage statistics (opt-in)
 	router.RegisterMiddleware(validationMiddleware)  // Validate input early
 	router.RegisterMiddleware(cachingMiddleware)     // Cache before expensive operations
 	router.RegisterMiddleware(authMiddleware)        // Authentication
 	router.RegisterMiddleware(activityMiddleware)    // Log activity after auth
-	router.RegisterMiddleware(rateLimitMiddleware)   // Rate limiting last
+	router.RegisterMiddleware(rateLimitMiddleware)   // Rate limiting
+	router.RegisterMiddleware(cooldownMiddleware)    // Per-chat cooldowns from config.json
+	router.RegisterMiddleware(hintMiddleware)        // Usage profile and occasional tips
+	router.RegisterMiddleware(chatActionMiddleware)  // "typing" while admitted commands run
+	if simulator.Enabled() {
+		router.RegisterMiddleware(middleware.NewSimulationMiddleware(simulator, logger)) // Staging fault injection
+		logger.Warn("Fault simulation enabled, /simulate is available")
+	}
 
 	// Create and register command handlers
 	startCommand := commands.NewStartCommand(config.Messages.Welcome, logger)
 	helpCommand := commands.NewHelpCommand(router, config.Messages.Help, logger)
 	pingCommand := commands.NewPingCommand(logger, startTime)
-	githubCommand := commands.NewGitHubCommand(githubService, logger)
+	githubCommand := commands.NewGitHubCommand(db, githubService, logger)
 	hazilCommand := commands.NewHazilCommand(config.Jokes, logger)
 	iqtibosCommand := commands.NewIqtibosCommand(config.Quotes, logger)
 	haqidaCommand := commands.NewHaqidaCommand(config, logger)
@@ -94,16 +218,66 @@ func NewDependencies(config *handlers.Config, db *database.DB) (*Dependencies, e
 	weatherCommand := commands.NewWeatherCommand(weatherService, logger)
 	
 	// Create metrics provider and metrics command
-	metricsProvider := NewMetricsProvider(metricsMiddleware, cachingMiddleware)
+	metricsProvider := NewMetricsProvider(metricsMiddleware, cachingMiddleware, taskAnalyzer, aiStats, retentionService, db)
 	metricsCommand := commands.NewMetricsCommand(metricsProvider, logger)
 	
 	// Create DevTaskMaster command handlers
-	analyzeCommand := commands.NewAnalyzeCommand(taskAnalyzer, logger, fileExtractor, telegramFileService)
-	projectCommand := commands.NewProjectCommand(db, logger)
+	jobQueue := services.NewJobQueue(logger)
+	analyzeCommand := commands.NewAnalyzeCommand(db, taskAnalyzer, teamManager, logger, fileExtractor, telegramFileService, calendarService, estimationService, aiUsageService, fileUsageService, transcriptionService, jobQueue, projectWebhooks)
+	projectCommand := commands.NewProjectCommand(db, notificationBridge, projectWebhooks, logger)
 	teamCommand := commands.NewTeamCommand(db, teamManager, logger)
-	workloadCommand := commands.NewWorkloadCommand(db, teamManager, logger)
-	listProjectsCommand := commands.NewListProjectsCommand(db, logger)
+	workloadCommand := commands.NewWorkloadCommand(db, teamManager, calendarService, estimationService, logger)
+	listProjectsCommand := commands.NewListProjectsCommand(db, calendarService, logger)
 	listTeamCommand := commands.NewListTeamCommand(db, logger)
+	importAdminsCommand := commands.NewImportAdminsCommand(db, telegramChatService, memberProfiles, logger)
+	memberProfileCommand := commands.NewMemberProfileCommand(db, memberProfiles, logger)
+	challengeCommand := commands.NewChallengeCommand(db, logger)
+	calendarCommand := commands.NewCalendarCommand(calendarService, logger)
+	holidaysCommand := commands.NewHolidaysCommand(holidayService, calendarService, logger)
+	aliasCommand := commands.NewAliasCommand(aliasService, router, logger)
+	snippetCommand := commands.NewSnippetCommand(db, logger)
+	highlightCommand := commands.NewHighlightCommand(logger)
+	shareExampleCommand := commands.NewShareExampleCommand(playgroundService, telegramFileService, logger)
+	lintCommand := commands.NewLintCommand(goLinter, telegramFileService, logger)
+	scheduleMessageCommand := commands.NewScheduleMessageCommand(db, services.BotLocation(), logger)
+	translateCommand := commands.NewTranslateCommand(translationService, logger)
+	summarizeCommand := commands.NewSummarizeCommand(chatHistory, summaryService, db, projectWebhooks, logger)
+	privacyCommand := commands.NewPrivacyCommand(db, chatHistory, telemetry, logger)
+	chatCommand := commands.NewChatCommand(chatService, logger)
+	interviewCommand := commands.NewInterviewCommand(interviewService, logger)
+	summarizeLinkCommand := commands.NewSummarizeLinkCommand(linkSummaryService, logger)
+	subscribeFeedCommand := commands.NewSubscribeFeedCommand(db, feedReader, logger)
+	watchDepsCommand := commands.NewWatchDepsCommand(db, dependencyChecker, logger)
+	pointsCommand := commands.NewPointsCommand(estimationService, logger)
+	velocityCommand := commands.NewVelocityCommand(db, estimationService, logger)
+	utilizationCommand := commands.NewUtilizationCommand(db, logger)
+	checkinCommand := commands.NewCheckinCommand(db, logger)
+	quotaCommand := commands.NewQuotaCommand(rateLimitMiddleware, cooldowns, chatService, aiUsageService, fileUsageService, fileExtractor, logger)
+	escalationCommand := commands.NewEscalationCommand(db, escalationService, logger)
+	healthCommand := commands.NewHealthCommand(db, calendarService, logger)
+	deployCommand := commands.NewDeployCommand(db, logger)
+	bugCommand := commands.NewBugCommand(db, bugDialogs, githubService, telegramFileService, projectWebhooks, logger)
+	similarCommand := commands.NewSimilarCommand(db, embeddingService, logger)
+	stackCommand := commands.NewStackCommand(db, logger)
+	settingsCommand := commands.NewSettingsCommand(db, chatHistory, menuService, chatSettings, logger)
+	chatSettingsCommand := commands.NewChatSettingsCommand(chatSettings, telegramChatService, router, logger)
+	hintsCommand := commands.NewHintsCommand(hintService, logger)
+	taskCommand := commands.NewTaskCommand(db, projectWebhooks, logger)
+	myTasksCommand := commands.NewMyTasksCommand(db, calendarService, logger)
+	whoAmICommand := commands.NewWhoAmICommand(db, chatSettings, logger)
+	shareCommand := commands.NewShareCommand(db, os.Getenv("PUBLIC_URL"), logger)
+	bridgeCommand := commands.NewBridgeCommand(notificationBridge, logger)
+	projectWebhookCommand := commands.NewProjectWebhookCommand(db, projectWebhooks, telegramChatService, logger)
+	emailCommand := commands.NewEmailCommand(db, mailer, logger)
+	webAppCommand := commands.NewWebAppCommand(os.Getenv("PUBLIC_URL"), logger)
+	menuCommand := commands.NewMenuCommand(menuService, router, logger)
+	demoCommand := commands.NewDemoCommand(db, logger)
+	exportJSONCommand := commands.NewExportJSONCommand(db, logger)
+	importJSONCommand := commands.NewImportJSONCommand(db, telegramFileService, logger)
+	testPlanCommand := commands.NewTestPlanCommand(db, testPlanService, projectWebhooks, logger)
+	dependenciesCommand := commands.NewDependenciesCommand(db, diagramRenderer, logger)
+	statusReportCommand := commands.NewStatusReportCommand(db, calendarService, statusReportService, notificationBridge, mailer, logger)
+	aiCheckCommand := commands.NewAITestCommand(aiChain, botAdmins, logger)
 
 	// Register original commands
 	router.RegisterHandler(startCommand)
@@ -125,7 +299,60 @@ func NewDependencies(config *handlers.Config, db *database.DB) (*Dependencies, e
 	router.RegisterHandler(teamCommand)
 	router.RegisterHandler(workloadCommand)
 	router.RegisterHandler(listProjectsCommand)
+	router.RegisterCallbackHandler(listProjectsCommand)
 	router.RegisterHandler(listTeamCommand)
+	router.RegisterHandler(importAdminsCommand)
+	router.RegisterHandler(memberProfileCommand)
+	router.RegisterHandler(challengeCommand)
+	router.RegisterHandler(calendarCommand)
+	router.RegisterHandler(holidaysCommand)
+	router.RegisterHandler(aliasCommand)
+	router.RegisterHandler(snippetCommand)
+	router.RegisterHandler(highlightCommand)
+	router.RegisterHandler(shareExampleCommand)
+	router.RegisterHandler(lintCommand)
+	router.RegisterHandler(scheduleMessageCommand)
+	router.RegisterHandler(translateCommand)
+	router.RegisterHandler(summarizeCommand)
+	router.RegisterHandler(privacyCommand)
+	router.RegisterHandler(chatCommand)
+	router.RegisterHandler(interviewCommand)
+	router.RegisterHandler(summarizeLinkCommand)
+	router.RegisterHandler(subscribeFeedCommand)
+	router.RegisterHandler(watchDepsCommand)
+	router.RegisterHandler(pointsCommand)
+	router.RegisterHandler(velocityCommand)
+	router.RegisterHandler(utilizationCommand)
+	router.RegisterHandler(checkinCommand)
+	router.RegisterHandler(quotaCommand)
+	router.RegisterHandler(escalationCommand)
+	router.RegisterHandler(healthCommand)
+	router.RegisterHandler(deployCommand)
+	router.RegisterHandler(bugCommand)
+	router.RegisterHandler(similarCommand)
+	router.RegisterHandler(stackCommand)
+	router.RegisterHandler(settingsCommand)
+	router.RegisterHandler(chatSettingsCommand)
+	router.RegisterHandler(hintsCommand)
+	router.RegisterHandler(taskCommand)
+	router.RegisterHandler(myTasksCommand)
+	router.RegisterHandler(whoAmICommand)
+	router.RegisterHandler(shareCommand)
+	router.RegisterHandler(bridgeCommand)
+	router.RegisterHandler(projectWebhookCommand)
+	router.RegisterHandler(emailCommand)
+	router.RegisterHandler(webAppCommand)
+	router.RegisterHandler(menuCommand)
+	router.RegisterHandler(demoCommand)
+	router.RegisterHandler(exportJSONCommand)
+	router.RegisterHandler(importJSONCommand)
+	router.RegisterHandler(testPlanCommand)
+	router.RegisterHandler(dependenciesCommand)
+	router.RegisterHandler(statusReportCommand)
+	router.RegisterHandler(aiCheckCommand)
+	if simulator.Enabled() {
+		router.RegisterHandler(commands.NewSimulateCommand(simulator, logger))
+	}
 
 	// Start background tasks
 	go func() {
@@ -134,6 +361,18 @@ func NewDependencies(config *handlers.Config, db *database.DB) (*Dependencies, e
 		
 		for range ticker.C {
 			rateLimitMiddleware.Cleanup()
+			chatService.UserLimiter().Cleanup()
+			chatService.ChatBudget().Cleanup()
+			interviewService.Limiter().Cleanup()
+			linkSummaryService.Limiter().Cleanup()
+			goLinter.Limiter().Cleanup()
+			if reportLinks.Enabled() {
+				if removed, err := reportLinks.Prune(context.Background(), time.Now()); err != nil {
+					logger.Error("Failed to prune shared reports", "error", err)
+				} else if removed > 0 {
+					logger.Info("Expired shared reports removed", "files", removed)
+				}
+			}
 		}
 	}()
 
@@ -145,8 +384,25 @@ func NewDependencies(config *handlers.Config, db *database.DB) (*Dependencies, e
 		GitHubService:  githubService,
 		WeatherService: weatherService,
 		UserService:    userService,
-		TaskAnalyzer:   taskAnalyzer,
-		TeamManager:    teamManager,
+		TaskAnalyzer:    taskAnalyzer,
+		TeamManager:     teamManager,
+		CalendarService: calendarService,
+		Scheduler:       scheduler,
+		ChatHistory:     chatHistory,
+		Menu:            menuService,
+		ChatSettings:    chatSettings,
+		BugDialogs:      bugDialogs,
+		MemberProfiles:  memberProfiles,
+		Interviews:      interviewService,
+		LinkSummaries:   linkSummaryService,
+		Simulator:       simulator,
+		ChatActions:     chatActionMiddleware,
+		Metrics:         metricsProvider,
+		Mailer:          mailer,
+		ReportLinks:     reportLinks,
+		JobQueue:        jobQueue,
+		ProjectWebhooks: projectWebhooks,
+		TelegramAPI:     telegramClient,
 		StartTime:      startTime,
 	}, nil
 }
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/handlers/commands"
+)
+
+// deployRequest is the body CI posts to /deploy after shipping a version
+type deployRequest struct {
+	ProjectID  string `json:"project_id"`
+	Version    string `json:"version"`
+	DeployedBy string `json:"deployed_by"`
+}
+
+// handleDeploy records a deployment reported by CI, like /deployed does from chat, and posts
+// the release notes to the project's team chat. It is off unless DEPLOY_TOKEN is set, and CI
+// authenticates with "Authorization: Bearer <token>".
+func (b *TelegramBot) handleDeploy(w http.ResponseWriter, r *http.Request) {
+	token := os.Getenv("DEPLOY_TOKEN")
+	if token == "" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var request deployRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&request); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+
+	db := b.dependencies.DB
+	logger := b.dependencies.Logger
+	chatID, err := db.GetProjectChatID(request.ProjectID)
+	if err != nil {
+		logger.Error("Failed to load project chat", "project_id", request.ProjectID, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "Failed to load project")
+		return
+	}
+	var project *database.Project
+	if chatID != 0 {
+		projects, err := db.GetProjectsByChatID(chatID)
+		if err != nil {
+			logger.Error("Failed to load project", "project_id", request.ProjectID, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to load project")
+			return
+		}
+		for i := range projects {
+			if projects[i].ID == request.ProjectID {
+				project = &projects[i]
+			}
+		}
+	}
+	if project == nil {
+		writeJSONError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	actor := "CI"
+	if request.DeployedBy != "" {
+		actor = "CI (" + request.DeployedBy + ")"
+	}
+	release, err := commands.RecordDeployment(db, logger, *project, chatID, request.Version, actor, 0)
+	switch {
+	case errors.Is(err, commands.ErrInvalidVersion):
+		writeJSONError(w, http.StatusBadRequest, "Invalid version")
+		return
+	case errors.Is(err, commands.ErrDeploymentRecorded):
+		writeJSONError(w, http.StatusConflict, "Version already recorded")
+		return
+	case err != nil:
+		logger.Error("Failed to record deployment", "project_id", request.ProjectID, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "Failed to record deployment")
+		return
+	}
+
+	if err := b.sendMessage(chatID, release.Notes, "Markdown", nil); err != nil {
+		logger.Error("Failed to post release notes", "chat_id", chatID, "error", err)
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"deployment_id":  release.Deployment.ID,
+		"released_tasks": len(release.Tasks),
+	})
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+func TestSendDocumentLongCaption(t *testing.T) {
+	var calls []string
+	var caption, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		if r.URL.Path == "/sendDocument" {
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Errorf("ParseMultipartForm() error = %v", err)
+			}
+			caption = r.FormValue("caption")
+			if _, header, err := r.FormFile("document"); err == nil {
+				contentType = header.Header.Get("Content-Type")
+			}
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer server.Close()
+
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger()})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+
+	document := &domain.OutgoingDocument{FileName: "tasks.csv", Content: []byte("id,title\n1,Login\n")}
+	if err := bot.SendDocument(context.Background(), 7, document, strings.Repeat("a", maxCaptionLength+1), ""); err != nil {
+		t.Fatalf("SendDocument() error = %v", err)
+	}
+
+	if len(calls) != 2 || calls[0] != "/sendMessage" || calls[1] != "/sendDocument" {
+		t.Fatalf("calls = %v, want the caption as a message before the file", calls)
+	}
+	if caption != "" {
+		t.Errorf("caption = %q, want none on the file", caption)
+	}
+	if !strings.HasPrefix(contentType, "text/csv") {
+		t.Errorf("content type = %q, want text/csv from the file name", contentType)
+	}
+}
+
+func TestSendDocumentMarkdownFallback(t *testing.T) {
+	var captions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		captions = append(captions, r.FormValue("caption"))
+		if r.FormValue("parse_mode") == "Markdown" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"ok":false,"description":"Bad Request: can't parse entities"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer server.Close()
+
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger()})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+
+	document := &domain.OutgoingDocument{FileName: "report.pdf", Content: []byte("%PDF-1.4"), ContentType: "application/pdf"}
+	if err := bot.SendDocument(context.Background(), 7, document, "**Report** for my_project", "Markdown"); err != nil {
+		t.Fatalf("SendDocument() error = %v", err)
+	}
+	if len(captions) != 2 || captions[1] != "Report for my_project" {
+		t.Errorf("captions = %q, want a plain text retry", captions)
+	}
+}
+
+func TestReportDocumentIsLinkedAndServed(t *testing.T) {
+	var caption string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		caption = r.FormValue("caption")
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer server.Close()
+
+	links := services.NewReportLinkService(services.NewLocalReportStorage(t.TempDir()), "secret", "https://bot.example.com", NewStructuredLogger())
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger(), ReportLinks: links})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+
+	document := &domain.OutgoingDocument{FileName: "testplan_proj_1.md", Content: []byte("# Test plan"), Report: true}
+	if err := bot.SendDocument(context.Background(), 7, document, "📋 Test plan", ""); err != nil {
+		t.Fatalf("SendDocument() error = %v", err)
+	}
+	start := strings.Index(caption, "https://bot.example.com/reports/")
+	if !strings.HasPrefix(caption, "📋 Test plan\n\n🔗") || start < 0 {
+		t.Fatalf("caption = %q, want the report link", caption)
+	}
+	path := strings.TrimPrefix(caption[start:], "https://bot.example.com")
+
+	recorder := httptest.NewRecorder()
+	bot.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "# Test plan" {
+		t.Fatalf("GET %s = %d %q", path, recorder.Code, recorder.Body.String())
+	}
+	if disposition := recorder.Header().Get("Content-Disposition"); disposition != `attachment; filename=testplan_proj_1.md` {
+		t.Errorf("Content-Disposition = %q", disposition)
+	}
+
+	recorder = httptest.NewRecorder()
+	bot.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path+"0", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("tampered link = %d, want 404", recorder.Code)
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"yordamchi-dev-bot/internal/handlers/commands"
+)
+
+func TestEditedMessagesAndChannelPosts(t *testing.T) {
+	var mutex sync.Mutex
+	var sent []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		mutex.Lock()
+		sent = append(sent, payload)
+		mutex.Unlock()
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer server.Close()
+
+	logger := NewStructuredLogger()
+	router := NewCommandRouter(logger)
+	router.RegisterHandler(commands.NewHazilCommand([]string{"joke"}, logger))
+	bot := NewTelegramBot("token", &Dependencies{Logger: logger, Router: router})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+
+	private := &TelegramChat{ID: 7, Type: "private"}
+	edit := &TelegramUpdate{UpdateID: 1, EditedMessage: &TelegramMessage{
+		MessageID: 10, From: &TelegramUser{ID: 7}, Chat: private, Text: "/hazil",
+	}}
+	sentCount := func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(sent)
+	}
+
+	// Edits are dropped by default
+	bot.processUpdate(edit)
+	if count := sentCount(); count != 0 {
+		t.Fatalf("sent %d messages for an ignored edit, want 0", count)
+	}
+
+	bot.rerunEdits = true
+	bot.processUpdate(edit)
+	bot.processUpdate(&TelegramUpdate{UpdateID: 2, EditedMessage: &TelegramMessage{
+		MessageID: 11, From: &TelegramUser{ID: 7}, Chat: private, Text: "no longer a command",
+	}})
+	if count := sentCount(); count != 1 {
+		t.Fatalf("sent %d messages, want 1 for the edited command", count)
+	}
+
+	// Channel posts run commands on behalf of the channel and leave other posts alone
+	channel := &TelegramChat{ID: -1001, Type: "channel", Title: "Announcements"}
+	bot.processUpdate(&TelegramUpdate{UpdateID: 3, ChannelPost: &TelegramMessage{MessageID: 12, SenderChat: channel, Chat: channel, Text: "Release 1.2 is out"}})
+	post := &TelegramMessage{MessageID: 13, SenderChat: channel, Chat: channel, Text: "/hazil"}
+	bot.processUpdate(&TelegramUpdate{UpdateID: 4, ChannelPost: post})
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(sent) != 2 || sent[1]["chat_id"] != float64(-1001) || sent[1]["text"] != "joke" {
+		t.Fatalf("sent %v, want the joke posted to the channel", sent)
+	}
+	if post.From == nil || post.From.ID != -1001 {
+		t.Errorf("channel post author = %+v, want the channel", post.From)
+	}
+}
@@ -0,0 +1,202 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/handlers/commands"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// Local times after which the weekly digest (Mondays) and the daily overdue alert are sent
+const (
+	emailDigestHour = 8
+	emailAlertHour  = 9
+)
+
+// taskEmailTemplate renders the weekly digest and overdue alert emails
+var taskEmailTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: -apple-system, 'Segoe UI', Roboto, sans-serif; color: #222; max-width: 640px;">
+<h2>{{.Title}}</h2>
+<p>{{.Intro}}</p>
+{{range .Groups}}
+<h3>{{.Title}} ({{len .Tasks}})</h3>
+<table style="width: 100%; border-collapse: collapse;">
+{{range .Tasks}}<tr>
+<td style="padding: 4px 8px; border-bottom: 1px solid #eee;"><b>{{.Title}}</b><br><span style="color: #777;">{{.ProjectName}} · {{.TeamName}}{{if not .Deadline.IsZero}} · due {{.Deadline.Format "Jan 2"}}{{end}}</span></td>
+<td style="padding: 4px 8px; border-bottom: 1px solid #eee; white-space: nowrap;">{{.Status}}</td>
+<td style="padding: 4px 8px; border-bottom: 1px solid #eee; white-space: nowrap;"><code>/task {{.ID}}</code></td>
+</tr>
+{{end}}</table>
+{{end}}
+<p style="color: #777; font-size: 12px; margin-top: 24px;">You receive this because you opted in with /email in Telegram.
+{{if .UnsubscribeURL}}<a href="{{.UnsubscribeURL}}">Unsubscribe</a>{{else}}Send /email off to the bot to unsubscribe.{{end}}</p>
+</body>
+</html>
+`))
+
+// unsubscribePageTemplate confirms and completes an email unsubscribe
+var unsubscribePageTemplate = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><meta name="robots" content="noindex"><title>Unsubscribe</title></head>
+<body style="font-family: -apple-system, 'Segoe UI', Roboto, sans-serif; max-width: 480px; margin: 3rem auto; padding: 0 1rem;">
+{{if .Done}}<p>✅ You are unsubscribed and will no longer receive emails.</p>
+{{else if .Unknown}}<p>This link is no longer valid. You may already be unsubscribed.</p>
+{{else}}<p>Stop receiving task digests and overdue alerts by email?</p>
+<form method="post"><button type="submit">Unsubscribe</button></form>{{end}}
+</body>
+</html>
+`))
+
+// taskEmail is the view model of a task email
+type taskEmail struct {
+	Title          string
+	Intro          string
+	Groups         []commands.AssignedTaskGroup
+	UnsubscribeURL string
+}
+
+// renderTaskEmail renders a task email with the same grouping as /my_tasks
+func renderTaskEmail(data taskEmail) (string, error) {
+	var html bytes.Buffer
+	if err := taskEmailTemplate.Execute(&html, data); err != nil {
+		return "", err
+	}
+	return html.String(), nil
+}
+
+// unsubscribeURL returns the unsubscribe link of a subscription, or "" without a public URL
+func unsubscribeURL(baseURL, token string) string {
+	if baseURL == "" {
+		return ""
+	}
+	return strings.TrimRight(baseURL, "/") + "/unsubscribe/" + token
+}
+
+// handleUnsubscribe shows a confirmation page on GET and unsubscribes on POST, which also
+// serves one-click List-Unsubscribe requests from mail clients
+func (b *TelegramBot) handleUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.URL.Path, "/unsubscribe/")
+	if token == "" || strings.Contains(token, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	page := struct{ Done, Unknown bool }{}
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		removed, err := b.dependencies.DB.UnsubscribeEmail(token)
+		if err != nil {
+			b.dependencies.Logger.Error("Failed to unsubscribe email", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		page.Done = removed
+		page.Unknown = !removed
+		if removed {
+			b.dependencies.Logger.Info("Email unsubscribed via link")
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := unsubscribePageTemplate.Execute(w, page); err != nil {
+		b.dependencies.Logger.Error("Failed to render unsubscribe page", "error", err)
+	}
+}
+
+// newEmailNotificationJob sends the weekly task digest and the daily overdue alert to users
+// who opted in with /email. It runs hourly and keeps track of what was sent per user.
+func newEmailNotificationJob(db *database.DB, calendars *services.CalendarService, mailer *services.Mailer, baseURL string, location *time.Location, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		subs, err := db.GetEmailSubscriptions()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().In(location)
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+		digestDue := today.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7)).Add(emailDigestHour * time.Hour)
+		alertDue := today.Add(emailAlertHour * time.Hour)
+
+		for _, sub := range subs {
+			sendDigest := sub.Digest && !now.Before(digestDue) && sub.LastDigestAt.Before(digestDue)
+			sendAlert := sub.Alerts && !now.Before(alertDue) && sub.LastAlertAt.Before(alertDue)
+			if !sendDigest && !sendAlert {
+				continue
+			}
+
+			tasks, err := db.Analytics().GetTasksAssignedToUser(sub.UserID)
+			if err != nil {
+				logger.Error("Failed to load tasks for email", "user_id", sub.UserID, "error", err)
+				continue
+			}
+			groups := commands.GroupAssignedTasks(ctx, tasks, calendars, now)
+			link := unsubscribeURL(baseURL, sub.Token)
+
+			if sendDigest {
+				email := taskEmail{
+					Title:          "🗂️ Your weekly task digest",
+					Intro:          "Your open tasks across all teams, by due date and priority.",
+					Groups:         groups,
+					UnsubscribeURL: link,
+				}
+				if len(groups) == 0 {
+					email.Intro = "🎉 You have no open tasks this week."
+				}
+				if sendTaskEmail(mailer, sub.Email, "Weekly task digest", email, logger) {
+					if err := db.MarkEmailDigestSent(sub.UserID, now); err != nil {
+						logger.Error("Failed to record email digest", "user_id", sub.UserID, "error", err)
+					}
+				}
+			}
+
+			if sendAlert {
+				// Only the overdue group is alerted, and only when it is not empty
+				if len(groups) == 0 || !groups[0].Overdue {
+					continue
+				}
+				email := taskEmail{
+					Title:          "🚨 Overdue tasks",
+					Intro:          "These tasks are past their project deadline or well beyond their estimate.",
+					Groups:         groups[:1],
+					UnsubscribeURL: link,
+				}
+				if sendTaskEmail(mailer, sub.Email, "You have overdue tasks", email, logger) {
+					if err := db.MarkEmailAlertSent(sub.UserID, now); err != nil {
+						logger.Error("Failed to record email alert", "user_id", sub.UserID, "error", err)
+					}
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// sendTaskEmail renders and sends a task email, reporting whether it was delivered
+func sendTaskEmail(mailer *services.Mailer, to, subject string, data taskEmail, logger domain.Logger) bool {
+	html, err := renderTaskEmail(data)
+	if err != nil {
+		logger.Error("Failed to render email", "subject", subject, "error", err)
+		return false
+	}
+
+	if err := mailer.Send(services.Email{To: to, Subject: subject, HTML: html, UnsubscribeURL: data.UnsubscribeURL}); err != nil {
+		logger.Error("Failed to send email", "subject", subject, "error", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/handlers/commands"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// handleGitHubWebhook links the commits of GitHub push events to tasks by the task keys in
+// their messages. It is off unless GITHUB_WEBHOOK_SECRET is set, and deliveries must be
+// signed with that secret.
+func (b *TelegramBot) handleGitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	secret := os.Getenv("GITHUB_WEBHOOK_SECRET")
+	if secret == "" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+	if !services.VerifyGitHubSignature(secret, body, r.Header.Get("X-Hub-Signature-256")) {
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	// Ping and other events are acknowledged without processing
+	if r.Header.Get("X-GitHub-Event") != "push" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	push, err := services.ParseGitHubPush(body)
+	if err != nil {
+		b.dependencies.Logger.Warn("Failed to parse GitHub push", "error", err)
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	b.goTracked(func() { b.linkPushCommits(push) })
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// linkPushCommits links the commits of a push to the tasks of every team linked to its
+// repository and posts the new links to the team chat
+func (b *TelegramBot) linkPushCommits(push *services.GitHubPush) {
+	db := b.dependencies.DB
+	logger := b.dependencies.Logger
+
+	teams, err := db.GetTeamChatsWithSettingPrefix(commands.GitHubRepoKey)
+	if err != nil {
+		logger.Error("Failed to load teams with a linked repository", "error", err)
+		return
+	}
+
+	for _, team := range teams {
+		settings, err := db.GetTeamSettings(team.TeamID)
+		if err != nil || !strings.EqualFold(settings[commands.GitHubRepoKey], push.Repository.FullName) {
+			continue
+		}
+
+		lines := linkTeamCommits(db, team, settings, push, logger)
+		if len(lines) == 0 {
+			continue
+		}
+		text := fmt.Sprintf("🔗 **Commits linked** · `%s`\n\n%s", push.Repository.FullName, strings.Join(lines, "\n"))
+		if err := b.sendMessage(team.ChatID, text, "Markdown", nil); err != nil {
+			logger.Error("Failed to post linked commits", "chat_id", team.ChatID, "error", err)
+		}
+	}
+}
+
+// linkTeamCommits links each commit to the team's tasks it mentions, moving to-do tasks to
+// in progress on their first commit unless the team turned that off. It returns a line per new link.
+func linkTeamCommits(db *database.DB, team database.TeamChat, settings map[string]string, push *services.GitHubPush, logger domain.Logger) []string {
+	prefix := commands.TaskKeyPrefix(settings)
+	var lines []string
+	for _, commit := range push.Commits {
+		for _, number := range domain.FindTaskKeys(commit.Message, prefix) {
+			taskID, err := db.FindTaskByKey(team.TeamID, number)
+			if err != nil || taskID == "" {
+				continue
+			}
+
+			linked, err := db.LinkTaskCommit(database.TaskCommit{
+				TaskID:      taskID,
+				SHA:         commit.ID,
+				Repository:  push.Repository.FullName,
+				Message:     commit.Message,
+				Author:      commit.AuthorName(),
+				URL:         commit.URL,
+				CommittedAt: commit.Timestamp,
+			})
+			if err != nil {
+				logger.Error("Failed to link commit", "task_id", taskID, "sha", commit.ID, "error", err)
+				continue
+			}
+			if !linked {
+				continue
+			}
+
+			key := domain.FormatTaskKey(prefix, number)
+			line := fmt.Sprintf("• `%s` %s → **%s**", commands.ShortSHA(commit.ID), commit.Title(), key)
+			if commands.GitHubAutoStart(settings) && startTaskOnCommit(db, team, taskID, key, commit, logger) {
+				line += " ▶️ started"
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// startTaskOnCommit moves a to-do task to in progress and reports whether it did
+func startTaskOnCommit(db *database.DB, team database.TeamChat, taskID, key string, commit services.GitHubCommit, logger domain.Logger) bool {
+	location, err := db.GetTaskLocation(taskID)
+	if err != nil || location == nil {
+		return false
+	}
+	tasks, err := db.GetTasksByProjectID(location.ProjectID)
+	if err != nil {
+		return false
+	}
+	for _, task := range tasks {
+		if task.ID != taskID || task.Status != domain.TaskStatusTodo {
+			continue
+		}
+		if err := db.UpdateTaskStatus(taskID, domain.TaskStatusInProgress); err != nil {
+			logger.Error("Failed to start task on commit", "task_id", taskID, "error", err)
+			return false
+		}
+		if err := db.LogAudit(&database.AuditEntry{
+			ChatID:  team.ChatID,
+			Actor:   "github:" + commit.AuthorName(),
+			Action:  database.AuditTaskCommitStart,
+			Subject: taskID,
+			Details: fmt.Sprintf("%s started by commit %s", key, commands.ShortSHA(commit.ID)),
+		}); err != nil {
+			logger.Error("Failed to write audit entry", "task_id", taskID, "error", err)
+		}
+		return true
+	}
+	return false
+}
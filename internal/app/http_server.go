@@ -0,0 +1,93 @@
+package app
+
+import (
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// defaultMaxRequestBody limits request bodies unless HTTP_MAX_BODY_BYTES is set.
+// Telegram updates and Mini App requests are far smaller.
+const defaultMaxRequestBody = 1 << 20
+
+// HTTPMiddleware wraps an HTTP handler
+type HTTPMiddleware func(http.Handler) http.Handler
+
+// normalizeBasePath turns "bot1", "/bot1/" or "" into "/bot1" or ""
+func normalizeBasePath(path string) string {
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	if path == "" {
+		return ""
+	}
+	return "/" + path
+}
+
+// maxRequestBody returns HTTP_MAX_BODY_BYTES, or the default when unset or invalid
+func maxRequestBody() int64 {
+	if value, err := strconv.ParseInt(os.Getenv("HTTP_MAX_BODY_BYTES"), 10, 64); err == nil && value > 0 {
+		return value
+	}
+	return defaultMaxRequestBody
+}
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// requestLoggingMiddleware logs method, path, status and duration of each request
+func requestLoggingMiddleware(logger domain.Logger) HTTPMiddleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			logger.Debug("HTTP request", "method", r.Method, "path", r.URL.Path,
+				"status", recorder.status, "duration", time.Since(start))
+		})
+	}
+}
+
+// recoveryMiddleware turns a handler panic into a 500 response instead of dropping the connection
+func recoveryMiddleware(logger domain.Logger) HTTPMiddleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if err := recover(); err != nil {
+					if err == http.ErrAbortHandler {
+						panic(err)
+					}
+					logger.Error("HTTP handler panicked", "path", r.URL.Path, "error", err, "stack", string(debug.Stack()))
+					http.Error(w, "Internal server error", http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bodyLimitMiddleware rejects request bodies larger than maxBytes
+func bodyLimitMiddleware(maxBytes int64) HTTPMiddleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
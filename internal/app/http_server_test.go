@@ -0,0 +1,57 @@
+package app
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTelegramBotRoutesUnderBasePath(t *testing.T) {
+	t.Setenv("HTTP_BASE_PATH", "bot1/")
+	t.Setenv("HTTP_MAX_BODY_BYTES", "16")
+
+	first := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger()})
+	first.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.Write(body)
+	})
+	first.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	t.Setenv("HTTP_BASE_PATH", "")
+	second := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger()})
+
+	tests := []struct {
+		name   string
+		bot    *TelegramBot
+		path   string
+		body   string
+		status int
+	}{
+		{"route under base path", first, "/bot1/echo", "hello", http.StatusOK},
+		{"route outside base path", first, "/echo", "hello", http.StatusNotFound},
+		{"routes are per bot", second, "/echo", "hello", http.StatusNotFound},
+		{"body limit", first, "/bot1/echo", strings.Repeat("x", 32), http.StatusRequestEntityTooLarge},
+		{"panic recovery", first, "/bot1/panic", "", http.StatusInternalServerError},
+		{"webhook rejects GET", second, "/webhook", "", http.StatusMethodNotAllowed},
+	}
+
+	for _, test := range tests {
+		method := http.MethodPost
+		if test.body == "" {
+			method = http.MethodGet
+		}
+		recorder := httptest.NewRecorder()
+		test.bot.Handler().ServeHTTP(recorder, httptest.NewRequest(method, test.path, strings.NewReader(test.body)))
+		if recorder.Code != test.status {
+			t.Errorf("%s: expected status %d, got %d", test.name, test.status, recorder.Code)
+		}
+	}
+}
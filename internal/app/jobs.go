@@ -0,0 +1,775 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/handlers/commands"
+	"yordamchi-dev-bot/internal/middleware"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// scheduledMessageBatchSize limits how many due messages are delivered per tick
+const scheduledMessageBatchSize = 50
+
+const (
+	// maxScheduledMessageAttempts is how often a scheduled message is sent before it fails
+	maxScheduledMessageAttempts = 5
+	// scheduledMessageRetryDelay is the wait after the first failed send; it doubles with
+	// every attempt
+	scheduledMessageRetryDelay = time.Minute
+)
+
+// newScheduledMessageJob delivers scheduled messages whose time has come. Each message is
+// claimed before it is sent, so a cancellation in between wins and a message is never sent
+// twice. Sends that fail for a passing reason are retried later.
+func newScheduledMessageJob(db *database.DB, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		messages, err := db.GetDueScheduledMessages(time.Now(), scheduledMessageBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			claimed, err := db.ClaimScheduledMessage(message.ID)
+			if err != nil {
+				logger.Error("Failed to claim scheduled message", "id", message.ID, "error", err)
+				continue
+			}
+			if !claimed {
+				continue
+			}
+
+			err = sender.SendMessage(ctx, message.ChatID, message.Text, "Markdown")
+			switch {
+			case err == nil:
+				logger.Info("Scheduled message delivered", "id", message.ID, "chat_id", message.ChatID)
+				err = db.FinishScheduledMessage(message.ID, database.ScheduledStatusSent)
+			case retryableSendError(err) && message.Attempts+1 < maxScheduledMessageAttempts:
+				retryAt := time.Now().Add(scheduledMessageRetryDelay << message.Attempts)
+				logger.Warn("Failed to deliver scheduled message, retrying later",
+					"id", message.ID, "chat_id", message.ChatID, "attempts", message.Attempts+1, "retry_at", retryAt, "error", err)
+				err = db.RetryScheduledMessage(message.ID, retryAt)
+			default:
+				logger.Error("Failed to deliver scheduled message", "id", message.ID, "chat_id", message.ChatID, "error", err)
+				err = db.FinishScheduledMessage(message.ID, database.ScheduledStatusFailed)
+			}
+			if err != nil {
+				logger.Error("Failed to update scheduled message status", "id", message.ID, "error", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// retryableSendError reports whether a failed send may succeed later: network errors, a
+// full send queue, rate limits and Telegram's server errors. Other Bot API answers, such
+// as a chat the bot was removed from, fail the same way again.
+func retryableSendError(err error) bool {
+	var apiErr *services.TelegramAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RateLimited() || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// embeddingIndexBatch limits how many documents are indexed per run
+const embeddingIndexBatch = 200
+
+// newEmbeddingIndexJob embeds new and changed tasks, analyses and snippets for /similar
+// and drops the vectors of deleted ones
+func newEmbeddingIndexJob(db *database.DB, embeddings *services.EmbeddingService, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		if !embeddings.Enabled() {
+			return nil
+		}
+
+		model := embeddings.Model()
+		sources, err := db.GetEmbeddingSources(model, embeddingIndexBatch)
+		if err != nil {
+			return err
+		}
+		indexed, err := commands.IndexEmbeddingSources(ctx, db, embeddings, model, sources)
+		if err != nil {
+			return err
+		}
+		removed, err := db.PruneEmbeddings()
+		if err != nil {
+			return err
+		}
+
+		if indexed > 0 || removed > 0 {
+			logger.Info("Embeddings indexed", "model", model, "indexed", indexed, "removed", removed)
+		}
+		return nil
+	}
+}
+
+// newHistoryRetentionJob drops buffered chat messages past their retention window
+func newHistoryRetentionJob(history *services.ChatHistoryService, retention *services.RetentionService, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		if removed := history.PruneExpired(); removed > 0 {
+			retention.RecordPurged(services.RetentionMessageBuffer, int64(removed))
+			logger.Info("Expired chat history pruned", "removed", removed)
+		}
+		return nil
+	}
+}
+
+// retentionHour is the local hour of the nightly retention purge
+const retentionHour = 3
+
+// newDataRetentionJob purges data past its retention period once a night. It runs hourly
+// so a restart does not skip a night.
+func newDataRetentionJob(retention *services.RetentionService, location *time.Location, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		now := time.Now().In(location)
+		lastRun := retention.Stats().LastRun.In(location)
+		if now.Hour() < retentionHour || lastRun.Format("2006-01-02") == now.Format("2006-01-02") {
+			return nil
+		}
+
+		purged, err := retention.Purge(now)
+		logger.Info("Data retention purge finished",
+			"user_activity", purged[services.RetentionUserActivity],
+			"analysis_history", purged[services.RetentionAnalyses],
+			"temporary_data", purged[services.RetentionTemporary])
+		return err
+	}
+}
+
+// newTaskEscalationJob reminds assignees of stale high-priority tasks and escalates to the team lead.
+// Nothing is sent to a team on its weekends and holidays; the next working day catches up.
+func newTaskEscalationJob(db *database.DB, escalations *services.EscalationService, calendars *services.CalendarService, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		candidates, err := db.GetEscalationCandidates(3)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		rules := make(map[string]domain.EscalationRule)
+		workingDay := make(map[string]bool)
+		for _, task := range candidates {
+			working, ok := workingDay[task.TeamID]
+			if !ok {
+				working = calendars.GetCalendar(ctx, task.TeamID).IsWorkingDay(now)
+				workingDay[task.TeamID] = working
+			}
+			if !working {
+				continue
+			}
+
+			rule, ok := rules[task.TeamID]
+			if !ok {
+				rule = escalations.GetRule(task.TeamID)
+				rules[task.TeamID] = rule
+			}
+			if !rule.Covers(task.Priority) {
+				continue
+			}
+
+			level := rule.NextLevel(task.Level, task.TodoSince, task.NotifiedAt, now)
+			if level == task.Level {
+				continue
+			}
+
+			var text, action string
+			if level == domain.EscalationReminded {
+				action = database.AuditEscalationReminder
+				text = fmt.Sprintf("⏰ **Task Reminder**\n\n@%s, **%s** (%s) has been in todo for %s.\n\n"+
+					"Please start it or update its status. The team lead is notified in %s.",
+					task.Assignee, task.Title, task.ProjectName, formatStaleFor(now.Sub(task.TodoSince)), formatStaleFor(rule.EscalateAfter))
+			} else {
+				action = database.AuditEscalationLead
+				leads := escalationLeads(db, rule, task.ChatID, logger)
+				text = fmt.Sprintf("🚨 **Task Escalated**\n\n%s: **%s** (%s), assigned to @%s, is still in todo after %s and a reminder.",
+					leads, task.Title, task.ProjectName, task.Assignee, formatStaleFor(now.Sub(task.TodoSince)))
+			}
+
+			if err := sender.SendMessage(ctx, task.ChatID, text, "Markdown"); err != nil {
+				logger.Error("Failed to send task escalation", "task_id", task.TaskID, "chat_id", task.ChatID, "error", err)
+				continue
+			}
+
+			if err := db.SetTaskEscalation(task.TaskID, level, task.TodoSince, now); err != nil {
+				logger.Error("Failed to save task escalation", "task_id", task.TaskID, "error", err)
+			}
+			if err := db.LogAudit(&database.AuditEntry{
+				ChatID:  task.ChatID,
+				Actor:   "system",
+				Action:  action,
+				Subject: task.TaskID,
+				Details: fmt.Sprintf("%s (assignee @%s)", task.Title, task.Assignee),
+			}); err != nil {
+				logger.Error("Failed to write audit entry", "task_id", task.TaskID, "error", err)
+			}
+
+			logger.Info("Task escalation sent", "task_id", task.TaskID, "chat_id", task.ChatID, "level", level)
+		}
+
+		return nil
+	}
+}
+
+// escalationLeads returns the mentions of the leads notified on escalation
+func escalationLeads(db *database.DB, rule domain.EscalationRule, chatID int64, logger domain.Logger) string {
+	if rule.Lead != "" {
+		return "@" + rule.Lead
+	}
+
+	members, err := db.GetTeamMembersByChatID(chatID)
+	if err != nil {
+		logger.Warn("Failed to load team leads", "chat_id", chatID, "error", err)
+	}
+
+	var mentions []string
+	for _, member := range members {
+		if strings.EqualFold(member.Role, "lead") {
+			mentions = append(mentions, "@"+member.Username)
+		}
+	}
+	if len(mentions) == 0 {
+		return "Team lead (set one with `/escalation lead @username`)"
+	}
+	return strings.Join(mentions, " ")
+}
+
+// formatStaleFor formats how long a task has been waiting, in hours or days
+func formatStaleFor(d time.Duration) string {
+	if d >= 48*time.Hour {
+		return fmt.Sprintf("%d days", int(d.Hours()/24))
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
+
+// projectHealthHour is the local hour after which the nightly health scores are refreshed
+const projectHealthHour = 2
+
+// newProjectHealthJob refreshes project health scores once a night, alerts chats when a
+// project turns red and tells project webhooks about every level change. It runs hourly
+// so a missed night is caught up after a restart.
+func newProjectHealthJob(db *database.DB, calendars *services.CalendarService, bridge *services.NotificationBridge, webhooks *services.ProjectWebhooks, location *time.Location, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		now := time.Now().In(location)
+		lastRun := time.Date(now.Year(), now.Month(), now.Day(), projectHealthHour, 0, 0, 0, location)
+		if now.Before(lastRun) {
+			lastRun = lastRun.AddDate(0, 0, -1)
+		}
+
+		projects, err := db.GetActiveProjects()
+		if err != nil {
+			return err
+		}
+
+		for _, project := range projects {
+			health, record, err := commands.EvaluateProjectHealth(ctx, db, calendars, project.Project, now)
+			if err != nil {
+				logger.Error("Failed to evaluate project health", "project_id", project.ID, "error", err)
+				continue
+			}
+			if record != nil && !record.ComputedAt.Before(lastRun) {
+				continue
+			}
+
+			if err := db.SaveProjectHealth(project.ID, health.Score, health.Level, now); err != nil {
+				logger.Error("Failed to save project health", "project_id", project.ID, "error", err)
+				continue
+			}
+			logger.Info("Project health updated", "project_id", project.ID, "score", health.Score, "level", health.Level)
+
+			previous := ""
+			if record != nil {
+				previous = record.Level
+			}
+			if previous != "" && previous != health.Level {
+				webhooks.StatusChanged(project.ID, services.ProjectStatusChange{
+					Name:           project.Name,
+					Status:         health.Level,
+					PreviousStatus: previous,
+					Score:          health.Score,
+					Reasons:        health.Reasons,
+				})
+			}
+			if health.Level != domain.HealthRed || previous == domain.HealthRed {
+				continue
+			}
+
+			text := fmt.Sprintf("🔴 **Project Health Alert**\n\n**%s** turned red (score %d/100).\n\n",
+				project.Name, health.Score)
+			for _, reason := range health.Reasons {
+				text += fmt.Sprintf("• %s\n", reason)
+			}
+			text += fmt.Sprintf("\nSee `/health %s` for details.", project.ID)
+
+			if err := sender.SendMessage(ctx, project.ChatID, text, "Markdown"); err != nil {
+				logger.Error("Failed to send project health alert", "project_id", project.ID, "chat_id", project.ChatID, "error", err)
+			}
+			bridge.Notify(ctx, project.TeamID, services.BridgeEventIncident, text)
+		}
+
+		return nil
+	}
+}
+
+// newUtilizationSnapshotJob records each team member's utilization once a day for
+// /utilization. It runs hourly; only the first snapshot of a day is stored.
+func newUtilizationSnapshotJob(db *database.DB, location *time.Location, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		chatIDs, err := db.GetTeamChatIDs()
+		if err != nil {
+			return err
+		}
+
+		today := time.Now().In(location)
+		for _, chatID := range chatIDs {
+			members, _, err := commands.LoadTeamWorkload(db, chatID, logger)
+			if err != nil {
+				logger.Error("Failed to load team workload", "chat_id", chatID, "error", err)
+				continue
+			}
+			for _, member := range members {
+				if err := db.SaveUtilizationSnapshot(database.UtilizationSnapshot{
+					MemberID:  member.ID,
+					Day:       today,
+					ChatID:    chatID,
+					Capacity:  member.Capacity,
+					Allocated: member.Current,
+				}); err != nil {
+					logger.Error("Failed to save utilization snapshot", "member_id", member.ID, "error", err)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// Mood check-ins are sent on Fridays after moodCheckinHour; the team pulse of the
+// previous week is posted on Mondays after statusReportHour
+const (
+	moodCheckinDay  = time.Friday
+	moodCheckinHour = 15
+)
+
+// newMoodCheckinJob sends the anonymous weekly check-in to the members of teams that
+// enabled it and posts last week's result with its trend to the team chat. It runs hourly
+// and does each once per week.
+func newMoodCheckinJob(db *database.DB, location *time.Location, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		now := time.Now().In(location)
+		sendCheckin := now.Weekday() == moodCheckinDay && now.Hour() >= moodCheckinHour
+		sendPulse := now.Weekday() == time.Monday && now.Hour() >= statusReportHour
+		if !sendCheckin && !sendPulse {
+			return nil
+		}
+
+		teams, err := db.GetTeamChatsWithSettingPrefix(commands.MoodCheckinKey)
+		if err != nil {
+			return err
+		}
+
+		for _, team := range teams {
+			settings, err := db.GetTeamSettings(team.TeamID)
+			if err != nil {
+				logger.Error("Failed to load team settings", "team_id", team.TeamID, "error", err)
+				continue
+			}
+			title := settings[commands.MoodCheckinKey]
+			if title == "" {
+				continue
+			}
+
+			if week := domain.MoodWeekKey(now); sendCheckin && settings[commands.MoodCheckinSentKey] != week {
+				sendMoodCheckins(ctx, db, sender, team, title, week, logger)
+				if err := db.SetTeamSetting(team.TeamID, commands.MoodCheckinSentKey, week); err != nil {
+					logger.Error("Failed to save mood check-in state", "team_id", team.TeamID, "error", err)
+				}
+			}
+
+			if week := domain.MoodWeekKey(now.AddDate(0, 0, -7)); sendPulse && settings[commands.MoodCheckinReportedKey] != week {
+				weeks, err := commands.LoadMoodWeeks(db, team.TeamID, now, 4)
+				if err != nil {
+					logger.Error("Failed to load mood results", "team_id", team.TeamID, "error", err)
+					continue
+				}
+				text := "🧭 **Weekly Team Pulse**\n\n" + commands.FormatMoodTrend(weeks)
+				if err := sender.SendMessage(ctx, team.ChatID, text, "Markdown"); err != nil {
+					logger.Error("Failed to send team pulse", "team_id", team.TeamID, "error", err)
+				}
+				if err := db.SetTeamSetting(team.TeamID, commands.MoodCheckinReportedKey, week); err != nil {
+					logger.Error("Failed to save team pulse state", "team_id", team.TeamID, "error", err)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// newDailyChallengeJob posts the day's Go challenge to the chats that enabled it, once a
+// day after commands.ChallengeHour
+func newDailyChallengeJob(db *database.DB, location *time.Location, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		now := time.Now().In(location)
+		if now.Hour() < commands.ChallengeHour {
+			return nil
+		}
+
+		teams, err := db.GetTeamChatsWithSettingPrefix(commands.ChallengeKey)
+		if err != nil {
+			return err
+		}
+
+		day := domain.ChallengeDay(now)
+		text := commands.FormatChallenge(domain.DailyChallenge(domain.ChallengeBank, now))
+		keyboardSender, withKeyboard := sender.(domain.KeyboardSender)
+		for _, team := range teams {
+			settings, err := db.GetTeamSettings(team.TeamID)
+			if err != nil {
+				logger.Error("Failed to load team settings", "team_id", team.TeamID, "error", err)
+				continue
+			}
+			if settings[commands.ChallengeKey] == "" || settings[commands.ChallengeSentKey] == day {
+				continue
+			}
+
+			if withKeyboard {
+				err = keyboardSender.SendMessageWithKeyboard(ctx, team.ChatID, text, "Markdown", commands.ChallengeKeyboard())
+			} else {
+				err = sender.SendMessage(ctx, team.ChatID, text, "Markdown")
+			}
+			if err != nil {
+				logger.Error("Failed to post daily challenge", "team_id", team.TeamID, "error", err)
+				continue
+			}
+			if err := db.SetTeamSetting(team.TeamID, commands.ChallengeSentKey, day); err != nil {
+				logger.Error("Failed to save daily challenge state", "team_id", team.TeamID, "error", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// sendMoodCheckins sends the check-in question privately to every member with a Telegram account
+func sendMoodCheckins(ctx context.Context, db *database.DB, sender domain.MessageSender, team database.TeamChat, title, week string, logger domain.Logger) {
+	keyboardSender, ok := sender.(domain.KeyboardSender)
+	if !ok {
+		logger.Warn("Mood check-ins need a sender with inline keyboards", "team_id", team.TeamID)
+		return
+	}
+
+	members, err := db.GetTeamMembersByChatID(team.ChatID)
+	if err != nil {
+		logger.Error("Failed to load team members", "team_id", team.TeamID, "error", err)
+		return
+	}
+
+	text, keyboard := commands.MoodCheckinMessage(team.ChatID, title, week)
+	sent := 0
+	for _, member := range members {
+		if member.UserID == 0 {
+			continue
+		}
+		// Fails for members who never started a private chat with the bot
+		if err := keyboardSender.SendMessageWithKeyboard(ctx, member.UserID, text, "Markdown", keyboard); err != nil {
+			logger.Warn("Failed to send mood check-in", "team_id", team.TeamID, "member_id", member.ID, "error", err)
+			continue
+		}
+		sent++
+	}
+	logger.Info("Mood check-ins sent", "team_id", team.TeamID, "week", week, "sent", sent, "members", len(members))
+}
+
+// newSprintSummaryJob mirrors a summary of each finished sprint to the Slack or Discord
+// channels of teams that enabled the bridge
+func newSprintSummaryJob(db *database.DB, estimation *services.EstimationService, bridge *services.NotificationBridge, location *time.Location, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		teams, err := db.GetTeamChatsWithSettingPrefix(services.BridgeSettingPrefix)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().In(location)
+		for _, team := range teams {
+			if !bridge.GetConfig(team.TeamID).Enabled(services.BridgeEventSprintSummary) {
+				continue
+			}
+
+			config := estimation.GetSprintConfig(team.TeamID)
+			sprints := domain.RecentSprints(config.Start, config.LengthDays, now, 2)
+			if len(sprints) < 2 {
+				continue
+			}
+			finished := sprints[0]
+			if bridge.LastSprintSummary(team.TeamID) >= finished.Number {
+				continue
+			}
+
+			tasks, err := db.Analytics().GetCompletedTasksByChatID(team.ChatID, finished.Start)
+			if err != nil {
+				logger.Error("Failed to load completed tasks", "team_id", team.TeamID, "error", err)
+				continue
+			}
+			work := make([]domain.CompletedWork, len(tasks))
+			for i, task := range tasks {
+				hours := task.ActualHours
+				if hours == 0 {
+					hours = task.EstimateHours
+				}
+				work[i] = domain.CompletedWork{CompletedAt: task.CompletedAt, Hours: hours}
+			}
+			velocity := estimation.GetSettings(team.TeamID).Scale.Velocity([]domain.Sprint{finished}, work)[0]
+
+			text := fmt.Sprintf("🏁 **Sprint %d finished** (%s → %s)\n✅ %d tasks | 🎲 %d pts | ⏱️ %.1fh",
+				finished.Number, finished.Start.Format("Jan 2"), finished.End.AddDate(0, 0, -1).Format("Jan 2"),
+				velocity.Tasks, velocity.Points, velocity.Hours)
+			bridge.Notify(ctx, team.TeamID, services.BridgeEventSprintSummary, text)
+
+			if err := bridge.SetLastSprintSummary(team.TeamID, finished.Number); err != nil {
+				logger.Error("Failed to save sprint summary state", "team_id", team.TeamID, "error", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// statusReportHour is the local hour on Mondays after which weekly status reports are sent
+const statusReportHour = 9
+
+// newStatusReportJob sends the weekly status report of each active project to teams that enabled
+// it, mirroring it to Slack or Discord. It runs hourly and sends once per ISO week.
+func newStatusReportJob(db *database.DB, calendars *services.CalendarService, reports *services.StatusReportService, bridge *services.NotificationBridge, location *time.Location, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		now := time.Now().In(location)
+		if now.Weekday() != time.Monday || now.Hour() < statusReportHour {
+			return nil
+		}
+		year, week := now.ISOWeek()
+		current := fmt.Sprintf("%d-W%02d", year, week)
+
+		teams, err := db.GetTeamChatsWithSettingPrefix(commands.StatusReportWeeklyKey)
+		if err != nil {
+			return err
+		}
+
+		for _, team := range teams {
+			settings, err := db.GetTeamSettings(team.TeamID)
+			if err != nil {
+				logger.Error("Failed to load team settings", "team_id", team.TeamID, "error", err)
+				continue
+			}
+			language := settings[commands.StatusReportWeeklyKey]
+			if language == "" || settings[commands.StatusReportLastKey] == current {
+				continue
+			}
+
+			projects, err := db.GetProjectsByChatID(team.ChatID)
+			if err != nil {
+				logger.Error("Failed to load projects", "team_id", team.TeamID, "error", err)
+				continue
+			}
+			for _, project := range projects {
+				if project.Status != "active" {
+					continue
+				}
+
+				facts, err := commands.BuildStatusFacts(ctx, db, calendars, project, now)
+				if err != nil {
+					logger.Error("Failed to collect project metrics", "project_id", project.ID, "error", err)
+					continue
+				}
+				if facts.TotalTasks == 0 {
+					continue
+				}
+				report, err := reports.Compose(ctx, facts, language)
+				if err != nil {
+					logger.Error("Failed to compose status report", "project_id", project.ID, "error", err)
+					continue
+				}
+
+				text := commands.FormatStatusReport(project.Name, report)
+				if err := sender.SendMessage(ctx, team.ChatID, text, "Markdown"); err != nil {
+					logger.Error("Failed to send status report", "project_id", project.ID, "chat_id", team.ChatID, "error", err)
+				}
+				bridge.Notify(ctx, team.TeamID, services.BridgeEventStatusReport, text)
+			}
+
+			if err := db.SetTeamSetting(team.TeamID, commands.StatusReportLastKey, current); err != nil {
+				logger.Error("Failed to save status report state", "team_id", team.TeamID, "error", err)
+			}
+			logger.Info("Weekly status reports sent", "team_id", team.TeamID, "week", current)
+		}
+
+		return nil
+	}
+}
+
+// newTelemetryJob reports the anonymized usage statistics collected since the last run
+func newTelemetryJob(telemetry *services.Telemetry) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		return telemetry.Flush(ctx)
+	}
+}
+
+const (
+	// defaultLatencyAlertP95 is the 95th percentile response time above which admins are alerted
+	defaultLatencyAlertP95 = 5 * time.Second
+	// latencyAlertMinRequests ignores commands used too rarely for a meaningful percentile
+	latencyAlertMinRequests = 10
+	// latencyAlertCooldown keeps a slow command from alerting on every run
+	latencyAlertCooldown = time.Hour
+)
+
+// latencyAlertThreshold returns the LATENCY_ALERT_P95_MS threshold, 0 when alerts are off
+func latencyAlertThreshold() time.Duration {
+	value := strings.TrimSpace(os.Getenv("LATENCY_ALERT_P95_MS"))
+	if value == "" {
+		return defaultLatencyAlertP95
+	}
+	ms, err := strconv.Atoi(value)
+	if err != nil || ms < 0 {
+		return defaultLatencyAlertP95
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// newLatencyAlertJob alerts the admins when the 95th percentile response time of a command
+// since the previous run exceeds the threshold
+func newLatencyAlertJob(metrics *middleware.MetricsMiddleware, admins *services.BotAdmins, threshold time.Duration, logger domain.Logger) services.JobFunc {
+	previous := make(map[string]middleware.LatencyHistogram)
+	alerted := make(map[string]time.Time)
+
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		current := metrics.LatencyHistograms()
+		commandNames := make([]string, 0, len(current))
+		for command := range current {
+			commandNames = append(commandNames, command)
+		}
+		sort.Strings(commandNames)
+
+		now := time.Now()
+		var slow []string
+		for _, command := range commandNames {
+			recent := current[command].Since(previous[command])
+			p95 := recent.Percentile(0.95)
+			if recent.Count < latencyAlertMinRequests || p95 <= threshold || now.Sub(alerted[command]) < latencyAlertCooldown {
+				continue
+			}
+			alerted[command] = now
+			slow = append(slow, fmt.Sprintf("├── %s: p95 %s, p99 %s (%d requests)",
+				command, p95.Round(time.Millisecond), recent.Percentile(0.99).Round(time.Millisecond), recent.Count))
+		}
+		previous = current
+
+		if len(slow) == 0 {
+			return nil
+		}
+		slow[len(slow)-1] = "└──" + strings.TrimPrefix(slow[len(slow)-1], "├──")
+		text := fmt.Sprintf("🐢 **Slow commands** (p95 over %s)\n%s\n\nDetails: `/metrics commands`", threshold, strings.Join(slow, "\n"))
+
+		logger.Warn("Command latency above threshold", "commands", len(slow), "threshold", threshold)
+		for _, chatID := range admins.AlertChats() {
+			if err := sender.SendMessage(ctx, chatID, text, "Markdown"); err != nil {
+				logger.Error("Failed to send latency alert", "chat_id", chatID, "error", err)
+			}
+		}
+		return nil
+	}
+}
+
+const (
+	// defaultFeedPollInterval is how often a subscribed feed is checked, FEED_POLL_MINUTES overrides it
+	defaultFeedPollInterval = 30 * time.Minute
+	// feedPollBatchSize limits how many feeds are downloaded per run
+	feedPollBatchSize = 20
+)
+
+// feedPollInterval returns the FEED_POLL_MINUTES interval between checks of a feed
+func feedPollInterval() time.Duration {
+	minutes, err := strconv.Atoi(strings.TrimSpace(os.Getenv("FEED_POLL_MINUTES")))
+	if err != nil || minutes < 5 {
+		return defaultFeedPollInterval
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// newFeedPollJob checks the subscribed feeds that are due and posts their new entries as one
+// digest per feed. Seen entries are stored, so a restart does not post them again.
+func newFeedPollJob(db *database.DB, feeds *services.FeedReader, interval time.Duration, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		subscriptions, err := db.GetDueFeedSubscriptions(time.Now().Add(-interval), feedPollBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range subscriptions {
+			subscription := &subscriptions[i]
+			entries, err := commands.PollFeed(ctx, db, feeds, subscription)
+			if err != nil {
+				logger.Warn("Failed to poll feed", "id", subscription.ID, "url", subscription.URL, "error", err)
+				continue
+			}
+			if len(entries) == 0 {
+				continue
+			}
+
+			if err := sender.SendMessage(ctx, subscription.ChatID, commands.FormatFeedDigest(subscription, entries), "Markdown"); err != nil {
+				logger.Error("Failed to send feed digest", "id", subscription.ID, "chat_id", subscription.ChatID, "error", err)
+				continue
+			}
+			logger.Info("Feed digest sent", "id", subscription.ID, "chat_id", subscription.ChatID, "entries", len(entries))
+		}
+
+		return nil
+	}
+}
+
+// dependencyReportBatchSize limits how many repositories are checked per run
+const dependencyReportBatchSize = 10
+
+// newDependencyReportJob posts the weekly Go module update report of every watched repository.
+// A repository that cannot be checked is reported once and retried the next week.
+func newDependencyReportJob(db *database.DB, checker *services.DependencyChecker, logger domain.Logger) services.JobFunc {
+	return func(ctx context.Context, sender domain.MessageSender) error {
+		now := time.Now()
+		watches, err := db.GetDueDependencyWatches(now.Add(-commands.DependencyReportInterval), dependencyReportBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, watch := range watches {
+			var text string
+			report, err := checker.Check(ctx, watch.Repository)
+			if err != nil {
+				logger.Warn("Failed to check dependencies", "id", watch.ID, "repository", watch.Repository, "error", err)
+				text = fmt.Sprintf("⚠️ Could not check the dependencies of `%s` this week: %s", watch.Repository, err)
+			} else {
+				text = commands.FormatDependencyReport(report)
+			}
+
+			if err := sender.SendMessage(ctx, watch.ChatID, text, "Markdown"); err != nil {
+				logger.Error("Failed to send dependency report", "id", watch.ID, "chat_id", watch.ChatID, "error", err)
+				continue
+			}
+			if err := db.MarkDependencyReported(watch.ID, now); err != nil {
+				logger.Error("Failed to save dependency report time", "id", watch.ID, "error", err)
+			}
+			logger.Info("Dependency report sent", "id", watch.ID, "chat_id", watch.ChatID, "repository", watch.Repository)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// senderFunc adapts a function to domain.MessageSender
+type senderFunc func(ctx context.Context, chatID int64, text, parseMode string) error
+
+func (f senderFunc) SendMessage(ctx context.Context, chatID int64, text, parseMode string) error {
+	return f(ctx, chatID, text, parseMode)
+}
+
+func TestScheduledMessageJobClaimsAndRetries(t *testing.T) {
+	db, err := database.NewSQLiteDB(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	defer db.Close()
+
+	due := time.Now().Add(-time.Minute)
+	messages := map[string]*database.ScheduledMessage{}
+	for _, text := range []string{"flaky", "cancelled", "kicked"} {
+		message := &database.ScheduledMessage{ChatID: 7, Text: text, SendAt: due}
+		if err := db.CreateScheduledMessage(message); err != nil {
+			t.Fatal(err)
+		}
+		messages[text] = message
+	}
+
+	var sent []string
+	job := newScheduledMessageJob(db, NewStructuredLogger())
+	sender := senderFunc(func(ctx context.Context, chatID int64, text, parseMode string) error {
+		sent = append(sent, text)
+		switch text {
+		case "flaky":
+			// A cancellation arriving while another message is sent wins
+			if _, err := db.CancelScheduledMessage(7, messages["cancelled"].ID); err != nil {
+				t.Fatal(err)
+			}
+			return &services.TelegramAPIError{Method: "sendMessage", StatusCode: http.StatusBadGateway, Description: "Bad Gateway"}
+		case "kicked":
+			return &services.TelegramAPIError{Method: "sendMessage", StatusCode: http.StatusForbidden, Description: "Forbidden: bot was kicked"}
+		}
+		return nil
+	})
+	if err := job(context.Background(), sender); err != nil {
+		t.Fatalf("job error = %v", err)
+	}
+
+	if len(sent) != 2 || sent[0] != "flaky" || sent[1] != "kicked" {
+		t.Errorf("sent %q, want the cancelled message skipped", sent)
+	}
+	pending, err := db.GetPendingScheduledMessages(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Text != "flaky" || pending[0].Attempts != 1 || !pending[0].SendAt.After(time.Now()) {
+		t.Fatalf("pending = %+v, want only the flaky message, rescheduled after one attempt", pending)
+	}
+
+	// Nothing is due again until the retry time
+	sent = nil
+	if err := job(context.Background(), sender); err != nil || len(sent) != 0 {
+		t.Errorf("second run sent %q, %v, want nothing before the retry", sent, err)
+	}
+}
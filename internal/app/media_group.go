@@ -0,0 +1,81 @@
+package app
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// mediaGroupWindow is how long the bot waits for more files of an album. Telegram
+// delivers the messages of a media group within a second of each other.
+const mediaGroupWindow = 1500 * time.Millisecond
+
+// mediaGroup collects the messages of one album
+type mediaGroup struct {
+	messages []*TelegramMessage
+	timer    *time.Timer
+}
+
+// mediaGroupBuffer holds album messages until no more arrive for the window, then
+// hands them over together
+type mediaGroupBuffer struct {
+	window time.Duration
+	flush  func(messages []*TelegramMessage)
+	groups map[string]*mediaGroup
+	mutex  sync.Mutex
+}
+
+// newMediaGroupBuffer creates a buffer that calls flush with each complete album
+func newMediaGroupBuffer(window time.Duration, flush func(messages []*TelegramMessage)) *mediaGroupBuffer {
+	return &mediaGroupBuffer{
+		window: window,
+		flush:  flush,
+		groups: make(map[string]*mediaGroup),
+	}
+}
+
+// Add buffers a message of a media group, restarting the group's window
+func (b *mediaGroupBuffer) Add(msg *TelegramMessage) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	group := b.groups[msg.MediaGroupID]
+	if group == nil {
+		group = &mediaGroup{}
+		group.timer = time.AfterFunc(b.window, func() { b.release(msg.MediaGroupID, group) })
+		b.groups[msg.MediaGroupID] = group
+	} else {
+		group.timer.Reset(b.window)
+	}
+	group.messages = append(group.messages, msg)
+}
+
+// release removes a group once its window passed and flushes its messages in order
+func (b *mediaGroupBuffer) release(id string, group *mediaGroup) {
+	b.mutex.Lock()
+	if b.groups[id] != group {
+		b.mutex.Unlock()
+		return
+	}
+	delete(b.groups, id)
+	messages := group.messages
+	b.mutex.Unlock()
+
+	sort.Slice(messages, func(i, j int) bool { return messages[i].MessageID < messages[j].MessageID })
+	b.flush(messages)
+}
+
+// Flush hands over every buffered album at once, e.g. when the bot shuts down
+func (b *mediaGroupBuffer) Flush() {
+	b.mutex.Lock()
+	groups := b.groups
+	b.groups = make(map[string]*mediaGroup)
+	b.mutex.Unlock()
+
+	for _, group := range groups {
+		group.timer.Stop()
+		messages := group.messages
+		sort.Slice(messages, func(i, j int) bool { return messages[i].MessageID < messages[j].MessageID })
+		b.flush(messages)
+	}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+func TestMediaGroupBufferFlushesAlbumsTogether(t *testing.T) {
+	flushed := make(chan []*TelegramMessage, 2)
+	buffer := newMediaGroupBuffer(30*time.Millisecond, func(messages []*TelegramMessage) {
+		flushed <- messages
+	})
+
+	buffer.Add(&TelegramMessage{MessageID: 3, MediaGroupID: "album"})
+	buffer.Add(&TelegramMessage{MessageID: 9, MediaGroupID: "other"})
+	time.Sleep(15 * time.Millisecond)
+	buffer.Add(&TelegramMessage{MessageID: 2, MediaGroupID: "album"})
+
+	groups := map[string][]*TelegramMessage{}
+	for i := 0; i < 2; i++ {
+		select {
+		case messages := <-flushed:
+			groups[messages[0].MediaGroupID] = messages
+		case <-time.After(time.Second):
+			t.Fatal("expected both albums to be flushed")
+		}
+	}
+
+	album := groups["album"]
+	if len(album) != 2 || album[0].MessageID != 2 || album[1].MessageID != 3 {
+		t.Errorf("expected the album's two messages in order, got %+v", album)
+	}
+	if len(groups["other"]) != 1 {
+		t.Errorf("expected the other album on its own, got %+v", groups["other"])
+	}
+}
+
+func TestPhotoDocumentUsesLargestSize(t *testing.T) {
+	sizes := []domain.TelegramPhoto{
+		{FileID: "small", Width: 90, Height: 60, FileSize: 1200},
+		{FileID: "large", Width: 1280, Height: 853, FileSize: 98000},
+		{FileID: "medium", Width: 320, Height: 213, FileSize: 14000},
+	}
+
+	document := domain.PhotoDocument(sizes, "photo_2.jpg")
+	if document.FileID != "large" || document.FileSize != 98000 {
+		t.Errorf("expected the largest size, got %+v", document)
+	}
+	if document.FileName != "photo_2.jpg" || document.MimeType != "image/jpeg" {
+		t.Errorf("expected a numbered JPEG, got %q (%s)", document.FileName, document.MimeType)
+	}
+}
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// maxMessageLength is Telegram's limit for a message text, in UTF-16 code units
+const maxMessageLength = 4096
+
+// maxCaptionLength is Telegram's limit for the caption of a file or photo
+const maxCaptionLength = 1024
+
+// codeFence opens and closes Markdown code blocks
+const codeFence = "```"
+
+// fenceReserve leaves room in every chunk to close a code block and reopen it in the next
+const fenceReserve = 40
+
+// messageLength returns the length of a text as Telegram counts it
+func messageLength(text string) int {
+	length := 0
+	for _, r := range text {
+		length += utf16.RuneLen(r)
+	}
+	return length
+}
+
+// splitMessage cuts a text longer than limit into chunks that fit, preferring paragraph
+// boundaries, then line breaks, then spaces. A code block cut in two is closed at the end
+// of its chunk and reopened in the next, so every chunk keeps valid Markdown.
+func splitMessage(text string, limit int) []string {
+	if messageLength(text) <= limit {
+		return []string{text}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	flush := func() {
+		if chunk := strings.Trim(current.String(), "\n"); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		current.Reset()
+	}
+	add := func(piece, separator string) {
+		if current.Len() > 0 && messageLength(current.String())+messageLength(separator+piece) > limit-fenceReserve {
+			flush()
+		}
+		if current.Len() > 0 {
+			current.WriteString(separator)
+		}
+		current.WriteString(piece)
+	}
+
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if messageLength(paragraph) <= limit-fenceReserve {
+			add(paragraph, "\n\n")
+			continue
+		}
+		for i, line := range strings.Split(paragraph, "\n") {
+			separator := "\n"
+			if i == 0 {
+				separator = "\n\n"
+			}
+			for _, piece := range splitLine(line, limit-fenceReserve) {
+				add(piece, separator)
+				separator = " "
+			}
+		}
+	}
+	flush()
+
+	return balanceCodeFences(chunks)
+}
+
+// splitLine cuts a line longer than limit at spaces, or anywhere when a word is too long
+func splitLine(line string, limit int) []string {
+	if messageLength(line) <= limit {
+		return []string{line}
+	}
+
+	var pieces []string
+	var current []rune
+	length := 0
+	for _, word := range strings.Split(line, " ") {
+		for messageLength(word) > limit {
+			// A word longer than a whole chunk is cut by itself
+			if len(current) > 0 {
+				pieces = append(pieces, string(current))
+				current, length = nil, 0
+			}
+			cut, size := 0, 0
+			for _, r := range word {
+				if size+utf16.RuneLen(r) > limit {
+					break
+				}
+				size += utf16.RuneLen(r)
+				cut += len(string(r))
+			}
+			pieces = append(pieces, word[:cut])
+			word = word[cut:]
+		}
+		if len(current) > 0 && length+1+messageLength(word) > limit {
+			pieces = append(pieces, string(current))
+			current, length = nil, 0
+		}
+		if len(current) > 0 {
+			current = append(current, ' ')
+			length++
+		}
+		current = append(current, []rune(word)...)
+		length += messageLength(word)
+	}
+	if len(current) > 0 {
+		pieces = append(pieces, string(current))
+	}
+	return pieces
+}
+
+// balanceCodeFences closes a code block left open at the end of a chunk and reopens it,
+// with its language, at the start of the next one
+func balanceCodeFences(chunks []string) []string {
+	opening := ""
+	for i, chunk := range chunks {
+		if opening != "" {
+			chunk = opening + "\n" + chunk
+		}
+
+		opening = ""
+		for _, line := range strings.Split(chunk, "\n") {
+			if !strings.HasPrefix(strings.TrimSpace(line), codeFence) {
+				continue
+			}
+			if opening == "" {
+				opening = strings.TrimSpace(line)
+			} else {
+				opening = ""
+			}
+		}
+		if opening != "" {
+			chunk += "\n" + codeFence
+		}
+		chunks[i] = chunk
+	}
+	return chunks
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitMessage(t *testing.T) {
+	if chunks := splitMessage("short", 100); len(chunks) != 1 || chunks[0] != "short" {
+		t.Fatalf("expected a short text unchanged, got %q", chunks)
+	}
+
+	// Paragraphs stay whole and in order
+	paragraphs := []string{strings.Repeat("a", 70), strings.Repeat("b", 70), strings.Repeat("c", 70)}
+	chunks := splitMessage(strings.Join(paragraphs, "\n\n"), 200)
+	if len(chunks) != 2 || chunks[0] != paragraphs[0]+"\n\n"+paragraphs[1] || chunks[1] != paragraphs[2] {
+		t.Errorf("expected paragraph boundaries, got %q", chunks)
+	}
+
+	// A paragraph without breaks is cut at spaces, and a single long word anywhere
+	long := strings.TrimSpace(strings.Repeat("word ", 100)) + " " + strings.Repeat("x", 300)
+	for _, chunk := range splitMessage(long, 140) {
+		if messageLength(chunk) > 140 {
+			t.Errorf("chunk of %d characters exceeds the limit", messageLength(chunk))
+		}
+	}
+	if joined := strings.Join(splitMessage(long, 140), " "); strings.ReplaceAll(joined, " ", "") != strings.ReplaceAll(long, " ", "") {
+		t.Errorf("expected no text lost when splitting")
+	}
+
+	// Emojis count as two UTF-16 units
+	if chunks := splitMessage(strings.Repeat("🔥", 60), 100); len(chunks) != 2 {
+		t.Errorf("expected emojis to be counted in UTF-16 units, got %d chunks", len(chunks))
+	}
+}
+
+func TestSplitMessageCodeBlocks(t *testing.T) {
+	var code strings.Builder
+	code.WriteString("**Plan**\n\n```go\n")
+	for i := 0; i < 20; i++ {
+		code.WriteString("fmt.Println(\"line\")\n")
+	}
+	code.WriteString("```")
+
+	chunks := splitMessage(code.String(), 200)
+	if len(chunks) < 2 {
+		t.Fatalf("expected the code block to be split, got %d chunks", len(chunks))
+	}
+	for i, chunk := range chunks {
+		if strings.Count(chunk, codeFence)%2 != 0 {
+			t.Errorf("chunk %d has an unclosed code block: %q", i, chunk)
+		}
+		if messageLength(chunk) > 200 {
+			t.Errorf("chunk %d exceeds the limit with %d characters", i, messageLength(chunk))
+		}
+	}
+	if !strings.HasPrefix(chunks[1], "```go\n") {
+		t.Errorf("expected the code block reopened with its language, got %q", chunks[1])
+	}
+}
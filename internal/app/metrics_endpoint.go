@@ -0,0 +1,127 @@
+package app
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"yordamchi-dev-bot/internal/middleware"
+)
+
+// handleMetrics serves metrics in the Prometheus text format. It is off unless
+// METRICS_TOKEN is set, and scrapers authenticate with "Authorization: Bearer <token>".
+func (b *TelegramBot) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	token := os.Getenv("METRICS_TOKEN")
+	if token == "" || b.dependencies.Metrics == nil {
+		http.NotFound(w, r)
+		return
+	}
+	provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writePrometheusMetrics(w, b.dependencies.Metrics.GetMetrics())
+}
+
+// writePrometheusMetrics writes the request counters, command latency histograms and
+// database pool statistics
+func writePrometheusMetrics(w io.Writer, metrics map[string]interface{}) {
+	metric := func(name, kind, help string, value interface{}) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, kind, name, value)
+	}
+
+	if total, ok := metrics["total_requests"].(int64); ok {
+		metric("yordamchi_requests_total", "counter", "Commands processed.", total)
+	}
+	if failed, ok := metrics["failed_requests"].(int64); ok {
+		metric("yordamchi_requests_failed_total", "counter", "Commands that returned an error.", failed)
+	}
+
+	if limited, ok := metrics["telegram_rate_limited"].(int64); ok {
+		retried, _ := metrics["telegram_retried"].(int64)
+		dropped, _ := metrics["telegram_dropped"].(int64)
+		metric("yordamchi_telegram_rate_limited_total", "counter", "Telegram API answers with 429 Too Many Requests.", limited)
+		metric("yordamchi_telegram_retried_total", "counter", "Rate limited messages delivered on a retry.", retried)
+		metric("yordamchi_telegram_dropped_total", "counter", "Rate limited messages dropped after the last retry.", dropped)
+	}
+
+	if latency, ok := metrics["latency"].(map[string]middleware.LatencyHistogram); ok && len(latency) > 0 {
+		writeLatencyHistograms(w, "yordamchi_command_duration_seconds", "Command response time.", "command", latency)
+	}
+
+	if calls, ok := metrics["telegram_calls"].(map[string]middleware.TelegramCallMetrics); ok && len(calls) > 0 {
+		writeTelegramCalls(w, calls)
+	}
+
+	if pool, ok := metrics["db_pool"].(sql.DBStats); ok {
+		metric("yordamchi_db_max_open_connections", "gauge", "Maximum open database connections.", pool.MaxOpenConnections)
+		metric("yordamchi_db_open_connections", "gauge", "Open database connections.", pool.OpenConnections)
+		metric("yordamchi_db_in_use_connections", "gauge", "Database connections in use.", pool.InUse)
+		metric("yordamchi_db_idle_connections", "gauge", "Idle database connections.", pool.Idle)
+		metric("yordamchi_db_wait_count_total", "counter", "Queries that waited for a free connection.", pool.WaitCount)
+		metric("yordamchi_db_wait_duration_seconds_total", "counter", "Time spent waiting for a free connection.", pool.WaitDuration.Seconds())
+		metric("yordamchi_db_max_idle_closed_total", "counter", "Connections closed because of the idle limit.", pool.MaxIdleClosed)
+		metric("yordamchi_db_max_lifetime_closed_total", "counter", "Connections closed because of their maximum lifetime.", pool.MaxLifetimeClosed)
+	}
+}
+
+// writeLatencyHistograms writes durations by a label, such as the command, as one
+// Prometheus histogram
+func writeLatencyHistograms(w io.Writer, name, help, labelName string, latency map[string]middleware.LatencyHistogram) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+
+	for _, key := range sortedKeys(latency) {
+		histogram := latency[key]
+		label := labelName + "=" + strconv.Quote(key)
+		var cumulative int64
+		for i, bound := range middleware.LatencyBuckets() {
+			cumulative += histogram.Counts[i]
+			fmt.Fprintf(w, "%s_bucket{%s,le=\"%v\"} %d\n", name, label, bound.Seconds(), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, label, histogram.Count)
+		fmt.Fprintf(w, "%s_sum{%s} %v\n", name, label, histogram.Sum.Seconds())
+		fmt.Fprintf(w, "%s_count{%s} %d\n", name, label, histogram.Count)
+	}
+}
+
+// writeTelegramCalls writes the Bot API call and error counters and latency by method
+func writeTelegramCalls(w io.Writer, calls map[string]middleware.TelegramCallMetrics) {
+	methods := sortedKeys(calls)
+
+	const total = "yordamchi_telegram_api_calls_total"
+	fmt.Fprintf(w, "# HELP %s Telegram Bot API calls.\n# TYPE %s counter\n", total, total)
+	for _, method := range methods {
+		fmt.Fprintf(w, "%s{method=%s} %d\n", total, strconv.Quote(method), calls[method].Count)
+	}
+
+	const failed = "yordamchi_telegram_api_errors_total"
+	fmt.Fprintf(w, "# HELP %s Telegram Bot API calls that failed or were rejected.\n# TYPE %s counter\n", failed, failed)
+	for _, method := range methods {
+		fmt.Fprintf(w, "%s{method=%s} %d\n", failed, strconv.Quote(method), calls[method].Errors)
+	}
+
+	latency := make(map[string]middleware.LatencyHistogram, len(calls))
+	for method, metrics := range calls {
+		latency[method] = metrics.Latency
+	}
+	writeLatencyHistograms(w, "yordamchi_telegram_api_duration_seconds", "Telegram Bot API call latency.", "method", latency)
+}
+
+// sortedKeys returns the keys of a map in order, so the output is stable
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"yordamchi-dev-bot/internal/middleware"
+)
+
+func TestWritePrometheusMetrics(t *testing.T) {
+	latency := middleware.NewLatencyHistogram()
+	latency.Observe(40 * time.Millisecond)
+	latency.Observe(3 * time.Second)
+
+	var out strings.Builder
+	writePrometheusMetrics(&out, map[string]interface{}{
+		"total_requests": int64(12),
+		"db_pool":        sql.DBStats{MaxOpenConnections: 20, InUse: 3, Idle: 2, WaitCount: 4, WaitDuration: 1500 * time.Millisecond},
+		"latency":        map[string]middleware.LatencyHistogram{"/analyze": latency},
+	})
+
+	for _, line := range []string{
+		"yordamchi_requests_total 12",
+		"yordamchi_db_in_use_connections 3",
+		"yordamchi_db_wait_count_total 4",
+		"yordamchi_db_wait_duration_seconds_total 1.5",
+		"# TYPE yordamchi_db_idle_connections gauge",
+		"# TYPE yordamchi_command_duration_seconds histogram",
+		`yordamchi_command_duration_seconds_bucket{command="/analyze",le="0.05"} 1`,
+		`yordamchi_command_duration_seconds_bucket{command="/analyze",le="5"} 2`,
+		`yordamchi_command_duration_seconds_bucket{command="/analyze",le="+Inf"} 2`,
+		`yordamchi_command_duration_seconds_count{command="/analyze"} 2`,
+	} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("expected %q in:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestMetricsEndpointRequiresToken(t *testing.T) {
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger(), Metrics: &MetricsProvider{}})
+
+	t.Setenv("METRICS_TOKEN", "")
+	recorder := httptest.NewRecorder()
+	bot.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("expected metrics off without a token, got %d", recorder.Code)
+	}
+
+	t.Setenv("METRICS_TOKEN", "secret")
+	request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	request.Header.Set("Authorization", "Bearer wrong")
+	recorder = httptest.NewRecorder()
+	bot.Handler().ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("expected a wrong token to be rejected, got %d", recorder.Code)
+	}
+}
@@ -1,27 +1,70 @@
 package app
 
-import "yordamchi-dev-bot/internal/middleware"
+import (
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/middleware"
+	"yordamchi-dev-bot/internal/services"
+)
 
 // MetricsProvider combines metrics and cache middleware for command access
 type MetricsProvider struct {
 	metricsMiddleware *middleware.MetricsMiddleware
 	cachingMiddleware *middleware.CachingMiddleware
+	taskAnalyzer      *services.TaskAnalyzer
+	aiStats           *services.AIStats
+	retention         *services.RetentionService
+	db                *database.DB
 }
 
 // NewMetricsProvider creates a new metrics provider
-func NewMetricsProvider(metricsMiddleware *middleware.MetricsMiddleware, cachingMiddleware *middleware.CachingMiddleware) *MetricsProvider {
+func NewMetricsProvider(metricsMiddleware *middleware.MetricsMiddleware, cachingMiddleware *middleware.CachingMiddleware, taskAnalyzer *services.TaskAnalyzer, aiStats *services.AIStats, retention *services.RetentionService, db *database.DB) *MetricsProvider {
 	return &MetricsProvider{
 		metricsMiddleware: metricsMiddleware,
 		cachingMiddleware: cachingMiddleware,
+		taskAnalyzer:      taskAnalyzer,
+		aiStats:           aiStats,
+		retention:         retention,
+		db:                db,
 	}
 }
 
-// GetMetrics returns performance metrics
+// GetMetrics returns performance metrics, including latency histograms, Telegram API calls,
+// AI provider calls, AI JSON repair counts, purged rows and database pool statistics
 func (mp *MetricsProvider) GetMetrics() map[string]interface{} {
-	return mp.metricsMiddleware.GetMetrics()
+	metrics := mp.metricsMiddleware.GetMetrics()
+	metrics["latency"] = mp.metricsMiddleware.LatencyHistograms()
+	metrics["telegram_calls"] = mp.metricsMiddleware.TelegramCalls()
+	metrics["ai_providers"] = mp.aiStats.Stats()
+	metrics["ai_repairs"] = mp.taskAnalyzer.RepairStats()
+	metrics["retention"] = mp.retention.Stats()
+	metrics["db_pool"] = mp.db.PoolStats()
+	return metrics
 }
 
 // GetCacheStats returns cache statistics
 func (mp *MetricsProvider) GetCacheStats() map[string]interface{} {
 	return mp.cachingMiddleware.GetCacheStats()
-}
\ No newline at end of file
+}
+
+// RecordTelegramRateLimit counts a 429 answer of the Telegram Bot API
+func (mp *MetricsProvider) RecordTelegramRateLimit() {
+	if mp != nil {
+		mp.metricsMiddleware.RecordTelegramRateLimit()
+	}
+}
+
+// RecordTelegramRetry counts a queued message that was delivered or dropped
+func (mp *MetricsProvider) RecordTelegramRetry(delivered bool) {
+	if mp != nil {
+		mp.metricsMiddleware.RecordTelegramRetry(delivered)
+	}
+}
+
+// RecordTelegramCall counts a Bot API call with its latency
+func (mp *MetricsProvider) RecordTelegramCall(method string, failed bool, duration time.Duration) {
+	if mp != nil {
+		mp.metricsMiddleware.RecordTelegramCall(method, failed, duration)
+	}
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// pollTimeout is how long Telegram holds a getUpdates request open waiting for updates
+	pollTimeout = 30 * time.Second
+	// maxPollBackoff caps the wait between getUpdates attempts after failures
+	maxPollBackoff = time.Minute
+	// defaultAPITimeout bounds the other Bot API calls of polling and the watchdog
+	defaultAPITimeout = 15 * time.Second
+)
+
+// PollingMode reports whether BOT_MODE=polling asks for updates with getUpdates instead of
+// receiving them at /webhook, for running without a public URL
+func PollingMode() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv("BOT_MODE")), "polling")
+}
+
+// Poll receives updates with long polling until the context is cancelled and processes
+// them like webhook updates. The webhook is removed first, as Telegram refuses getUpdates
+// while one is set.
+func (b *TelegramBot) Poll(ctx context.Context) {
+	if err := b.deleteWebhook(ctx); err != nil {
+		b.dependencies.Logger.Warn("Failed to remove webhook before polling", "error", err)
+	}
+	b.dependencies.Logger.Info("Polling for updates", "timeout", pollTimeout)
+
+	offset := 0
+	backoff := time.Second
+	for ctx.Err() == nil {
+		updates, err := b.getUpdates(ctx, offset)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			b.dependencies.Logger.Warn("Failed to get updates, retrying", "error", err, "retry_in", backoff)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > maxPollBackoff {
+				backoff = maxPollBackoff
+			}
+			continue
+		}
+		backoff = time.Second
+		b.lastPoll.Store(time.Now().UnixNano())
+
+		for i := range updates {
+			// Confirm the update with the next request so it is not delivered again
+			offset = updates[i].UpdateID + 1
+			update := &updates[i]
+			b.goTracked(func() { b.processUpdate(update) })
+		}
+	}
+}
+
+// getUpdates waits for the updates after offset
+func (b *TelegramBot) getUpdates(ctx context.Context, offset int) ([]TelegramUpdate, error) {
+	params := url.Values{}
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("timeout", strconv.Itoa(int(pollTimeout.Seconds())))
+
+	result, err := b.callPollingAPI(ctx, pollTimeout+10*time.Second, "getUpdates", params)
+	if err != nil {
+		return nil, err
+	}
+	var updates []TelegramUpdate
+	if err := json.Unmarshal(result, &updates); err != nil {
+		return nil, fmt.Errorf("failed to decode updates: %w", err)
+	}
+	return updates, nil
+}
+
+// deleteWebhook removes the bot's webhook, keeping pending updates for polling
+func (b *TelegramBot) deleteWebhook(ctx context.Context) error {
+	_, err := b.callPollingAPI(ctx, defaultAPITimeout, "deleteWebhook", url.Values{})
+	return err
+}
+
+// callPollingAPI calls a Bot API method with form parameters and returns its result,
+// waiting at most timeout
+func (b *TelegramBot) callPollingAPI(ctx context.Context, timeout time.Duration, method string, params url.Values) (json.RawMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var result json.RawMessage
+	if err := b.telegram.CallForm(ctx, method, params, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPollTracksOffset(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mutex sync.Mutex
+	var calls, offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		mutex.Lock()
+		calls = append(calls, r.URL.Path)
+		if r.URL.Path == "/getUpdates" {
+			offsets = append(offsets, r.Form.Get("offset"))
+		}
+		count := len(offsets)
+		mutex.Unlock()
+
+		switch {
+		case r.URL.Path == "/deleteWebhook":
+			w.Write([]byte(`{"ok":true,"result":true}`))
+		case count == 1:
+			w.Write([]byte(`{"ok":true,"result":[{"update_id":5},{"update_id":6}]}`))
+		case count == 2:
+			w.Write([]byte(`{"ok":false,"description":"Bad Gateway"}`))
+		default:
+			cancel()
+			w.Write([]byte(`{"ok":true,"result":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger()})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+
+	done := make(chan struct{})
+	go func() {
+		bot.Poll(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("polling did not stop after the context was cancelled")
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(calls) == 0 || calls[0] != "/deleteWebhook" {
+		t.Errorf("calls = %v, want the webhook removed first", calls)
+	}
+	want := []string{"0", "7", "7"}
+	if len(offsets) != len(want) {
+		t.Fatalf("offsets = %v, want %v", offsets, want)
+	}
+	for i := range want {
+		if offsets[i] != want[i] {
+			t.Errorf("offsets = %v, want %v: confirmed after updates, kept after a failure", offsets, want)
+			break
+		}
+	}
+}
+
+func TestPollingMode(t *testing.T) {
+	for value, want := range map[string]bool{"polling": true, " Polling ": true, "webhook": false, "": false} {
+		t.Setenv("BOT_MODE", value)
+		if got := PollingMode(); got != want {
+			t.Errorf("PollingMode() with BOT_MODE=%q = %v, want %v", value, got, want)
+		}
+	}
+}
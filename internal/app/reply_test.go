@@ -0,0 +1,60 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRepliedTaskID(t *testing.T) {
+	bot := NewTelegramBot("12345:secret", &Dependencies{Logger: NewStructuredLogger()})
+	card := "🔴 Login form (TASK-12 · task_1712_0)\n├── Status: todo"
+
+	for name, test := range map[string]struct {
+		reply *TelegramMessage
+		want  string
+	}{
+		"task card":    {&TelegramMessage{From: &TelegramUser{ID: 12345, IsBot: true}, Text: card}, "task_1712_0"},
+		"other bot":    {&TelegramMessage{From: &TelegramUser{ID: 999, IsBot: true}, Text: card}, ""},
+		"user message": {&TelegramMessage{From: &TelegramUser{ID: 12345}, Text: card}, ""},
+		"not a card":   {&TelegramMessage{From: &TelegramUser{ID: 12345, IsBot: true}, Text: "📊 Stats"}, ""},
+		"not a reply":  {nil, ""},
+	} {
+		msg := &TelegramMessage{Text: "Blocked by the API change", ReplyToMessage: test.reply}
+		if got := bot.repliedTaskID(msg); got != test.want {
+			t.Errorf("%s: repliedTaskID() = %q, want %q", name, got, test.want)
+		}
+	}
+}
+
+func TestSendReplyThreadsFirstChunk(t *testing.T) {
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer server.Close()
+
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger()})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+
+	text := strings.Repeat("word ", maxMessageLength/5) + "\n\n" + strings.Repeat("more ", 100)
+	if err := bot.sendReply(7, 42, text, "", nil); err != nil {
+		t.Fatalf("sendReply() error = %v", err)
+	}
+
+	if len(payloads) != 2 {
+		t.Fatalf("sent %d messages, want 2", len(payloads))
+	}
+	parameters, ok := payloads[0]["reply_parameters"].(map[string]interface{})
+	if !ok || parameters["message_id"] != float64(42) || parameters["allow_sending_without_reply"] != true {
+		t.Errorf("first chunk reply_parameters = %v, want a reply to 42", payloads[0]["reply_parameters"])
+	}
+	if _, ok := payloads[1]["reply_parameters"]; ok {
+		t.Error("only the first chunk should reply")
+	}
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// withReportLink stores a generated report and adds its shareable link to the caption. The
+// file is still uploaded when storing fails, just without the link.
+func (b *TelegramBot) withReportLink(ctx context.Context, document *domain.OutgoingDocument, caption, parseMode string) string {
+	links := b.dependencies.ReportLinks
+	if !links.Enabled() {
+		return caption
+	}
+
+	link, expires, err := links.Store(ctx, &services.StoredReport{
+		FileName:    document.FileName,
+		ContentType: documentContentType(document),
+		Content:     document.Content,
+	}, time.Now())
+	if err != nil {
+		b.dependencies.Logger.Error("Failed to store report for sharing", "file_name", document.FileName, "error", err)
+		return caption
+	}
+
+	until := expires.In(services.BotLocation()).Format("Jan 2 15:04 MST")
+	var line string
+	switch parseMode {
+	case "Markdown":
+		line = fmt.Sprintf("🔗 [Link for sharing outside Telegram](%s), works until %s", link, until)
+	case "HTML":
+		line = fmt.Sprintf(`🔗 <a href="%s">Link for sharing outside Telegram</a>, works until %s`, html.EscapeString(link), until)
+	default:
+		line = fmt.Sprintf("🔗 Link for sharing outside Telegram, works until %s: %s", until, link)
+	}
+	if caption == "" {
+		return line
+	}
+	return caption + "\n\n" + line
+}
+
+// handleReport serves a report stored by withReportLink while its signed link is valid
+func (b *TelegramBot) handleReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	token := strings.TrimPrefix(r.URL.Path, "/reports/")
+	links := b.dependencies.ReportLinks
+	if token == "" || strings.Contains(token, "/") || !links.Enabled() {
+		http.NotFound(w, r)
+		return
+	}
+
+	report, err := links.Open(r.Context(), token, time.Now())
+	switch {
+	case errors.Is(err, services.ErrReportLinkExpired):
+		http.Error(w, "This link has expired. Ask for the report again in Telegram.", http.StatusGone)
+		return
+	case errors.Is(err, services.ErrReportLinkInvalid), errors.Is(err, services.ErrReportNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		b.dependencies.Logger.Error("Failed to open shared report", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", report.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": report.FileName}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(report.Content)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if r.Method == http.MethodGet {
+		w.Write(report.Content)
+	}
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResponderPlaceholderAndEdit(t *testing.T) {
+	var edits []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		switch r.URL.Path {
+		case "/sendMessage":
+			w.Write([]byte(`{"ok":true,"result":{"message_id":42}}`))
+		case "/editMessageText":
+			edits = append(edits, payload)
+			if payload["parse_mode"] == "Markdown" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"ok":false,"description":"Bad Request: can't parse entities"}`))
+				return
+			}
+			w.Write([]byte(`{"ok":true,"result":{"message_id":42}}`))
+		}
+	}))
+	defer server.Close()
+
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger()})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+
+	messageID, err := bot.SendPlaceholder(context.Background(), 7, "⏳ Analyzing...")
+	if err != nil || messageID != 42 {
+		t.Fatalf("SendPlaceholder() = %d, %v, want 42", messageID, err)
+	}
+
+	if err := bot.EditMessage(context.Background(), 7, messageID, "**Done**", "Markdown", nil); err != nil {
+		t.Fatalf("EditMessage() error = %v", err)
+	}
+	if len(edits) != 2 {
+		t.Fatalf("edits = %d, want a Markdown edit and a plain text retry", len(edits))
+	}
+	if edits[1]["text"] != "Done" || edits[1]["message_id"] != float64(42) {
+		t.Errorf("retry = %v, want the placeholder edited with plain text", edits[1])
+	}
+}
+
+func TestEditMessageIgnoresUnmodified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"ok":false,"description":"Bad Request: message is not modified"}`))
+	}))
+	defer server.Close()
+
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger()})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+
+	if err := bot.EditMessage(context.Background(), 7, 42, "same", "", nil); err != nil {
+		t.Errorf("EditMessage() error = %v, want an unchanged message to count as edited", err)
+	}
+}
@@ -2,15 +2,104 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"yordamchi-dev-bot/internal/domain"
 )
 
+// AliasResolver resolves chat-specific command aliases
+type AliasResolver interface {
+	ResolveAlias(chatID int64, command string) (string, bool)
+}
+
+// ChatPolicy holds the per-chat settings the router applies before dispatching
+type ChatPolicy interface {
+	// CategoryDisabled reports whether the chat turned a command category off
+	CategoryDisabled(chatID int64, category string) bool
+	// Language returns the chat's default language, or "" when it has not chosen one
+	Language(chatID int64) string
+}
+
+// defaultUnknownCommandText is the reply to unknown commands unless config.json sets one
+const defaultUnknownCommandText = "❓ Noma'lum buyruq. /help yozing"
+
+// routerReplies are the router's own replies to a disabled, timed out or failed command
+type routerReplies struct {
+	disabled string
+	timeout  string
+	failed   string
+}
+
+// localizedReplies holds the router's replies in each chat language, Uzbek by default
+var localizedReplies = map[string]routerReplies{
+	"uz": {
+		disabled: "🚫 `%s` bu chatda o'chirilgan (%s). Yoqish uchun: `/chat_settings enable %s`",
+		timeout:  "⏱️ Buyruq juda uzoq davom etdi. Birozdan keyin qayta urinib ko'ring.",
+		failed:   "❌ Buyruqni bajarishda xatolik yuz berdi",
+	},
+	"ru": {
+		disabled: "🚫 `%s` отключена в этом чате (%s). Чтобы включить: `/chat_settings enable %s`",
+		timeout:  "⏱️ Команда выполнялась слишком долго. Попробуйте ещё раз чуть позже.",
+		failed:   "❌ При выполнении команды произошла ошибка",
+	},
+	"en": {
+		disabled: "🚫 `%s` is turned off in this chat (%s). To turn it on: `/chat_settings enable %s`",
+		timeout:  "⏱️ The command took too long. Please try again in a moment.",
+		failed:   "❌ The command failed",
+	},
+}
+
+// replies returns the router's replies in the language of the command's chat
+func replies(cmd *domain.Command) routerReplies {
+	if cmd.Chat != nil {
+		if localized, ok := localizedReplies[cmd.Chat.Language]; ok {
+			return localized
+		}
+	}
+	return localizedReplies["uz"]
+}
+
+// Command time limits by category; handlers implementing domain.TimeoutHandler override them
+const (
+	defaultCommandTimeout = 30 * time.Second
+	quickCommandTimeout   = 5 * time.Second
+	// aiCommandTimeout leaves room for the AI chain to fall back between providers
+	aiCommandTimeout = 2 * time.Minute
+)
+
+// commandTimeouts maps commands to their category time limit
+var commandTimeouts = map[string]time.Duration{
+	"/start":          quickCommandTimeout,
+	"/help":           quickCommandTimeout,
+	"/ping":           quickCommandTimeout,
+	"/privacy":        quickCommandTimeout,
+	"/menu":           quickCommandTimeout,
+	"/quota":          quickCommandTimeout,
+	"/chat":           aiCommandTimeout,
+	"/interview":      aiCommandTimeout,
+	"/summarize":      aiCommandTimeout,
+	"/summarize_link": aiCommandTimeout,
+	"/translate":      aiCommandTimeout,
+	"/tr":             aiCommandTimeout,
+	"/testplan":       aiCommandTimeout,
+	"/status_report":  aiCommandTimeout,
+	"/import_json":    aiCommandTimeout,
+}
+
 // CommandRouter implements the Router interface
 type CommandRouter struct {
-	handlers    []domain.CommandHandler
-	middlewares []domain.Middleware
+	handlers      []domain.CommandHandler
+	callbacks     []domain.CallbackHandler
+	middlewares   []domain.Middleware
+	aliasResolver AliasResolver
+	chatPolicy    ChatPolicy
+	unknownText   string
+	// botUsername is the bot's @username without the @, set once getMe answered
+	botUsername atomic.Value
 	logger      domain.Logger
 }
 
@@ -19,38 +108,102 @@ func NewCommandRouter(logger domain.Logger) *CommandRouter {
 	return &CommandRouter{
 		handlers:    make([]domain.CommandHandler, 0),
 		middlewares: make([]domain.Middleware, 0),
+		unknownText: defaultUnknownCommandText,
 		logger:      logger,
 	}
 }
 
+// SetUnknownCommandText sets the reply to unknown commands, keeping the default when empty
+func (r *CommandRouter) SetUnknownCommandText(text string) {
+	if strings.TrimSpace(text) != "" {
+		r.unknownText = text
+	}
+}
+
+// SetBotUsername sets the bot's username, so that in groups only /command@username and
+// plain /command are answered and commands addressed to other bots are ignored
+func (r *CommandRouter) SetBotUsername(username string) {
+	r.botUsername.Store(strings.TrimPrefix(username, "@"))
+}
+
+// addressedToOtherBot reports whether a /command@name is meant for another bot. Until the
+// bot's username is known every name is taken as this bot's.
+func (r *CommandRouter) addressedToOtherBot(name string) bool {
+	username, _ := r.botUsername.Load().(string)
+	return username != "" && !strings.EqualFold(name, username)
+}
+
 // RegisterHandler registers a new command handler
 func (r *CommandRouter) RegisterHandler(handler domain.CommandHandler) {
 	r.handlers = append(r.handlers, handler)
 	r.logger.Info("Command handler registered", "handler", handler.Description())
 }
 
+// RegisterCallbackHandler registers a handler of button presses whose data is not a command
+func (r *CommandRouter) RegisterCallbackHandler(handler domain.CallbackHandler) {
+	r.callbacks = append(r.callbacks, handler)
+}
+
 // RegisterMiddleware registers a new middleware
 func (r *CommandRouter) RegisterMiddleware(middleware domain.Middleware) {
 	r.middlewares = append(r.middlewares, middleware)
 	r.logger.Info("Middleware registered")
 }
 
+// SetAliasResolver sets the resolver used to expand chat aliases before handler matching
+func (r *CommandRouter) SetAliasResolver(resolver AliasResolver) {
+	r.aliasResolver = resolver
+}
+
+// SetChatPolicy sets the per-chat settings consulted before a handler runs
+func (r *CommandRouter) SetChatPolicy(policy ChatPolicy) {
+	r.chatPolicy = policy
+}
+
 // Route finds and executes the appropriate handler for a command
 func (r *CommandRouter) Route(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
 	// Add command to context
 	ctx = domain.WithCommand(ctx, cmd)
 
+	// Button presses carrying data other than a command go to their callback handler
+	if cmd.Callback != nil && !strings.HasPrefix(cmd.Text, "/") {
+		if r.chatPolicy != nil && cmd.Chat != nil {
+			cmd.Chat.Language = r.chatPolicy.Language(cmd.Chat.ID)
+		}
+		return r.routeCallback(ctx, cmd)
+	}
+
 	// Find appropriate handler
 	var handler domain.CommandHandler
 	// Extract just the command part (first word) for matching
 	parts := strings.Fields(cmd.Text)
 	if len(parts) == 0 {
 		return &domain.Response{
-			Text:      "❓ Noma'lum buyruq. /help yozing",
+			Text:      r.unknownText,
 			ParseMode: "Markdown",
 		}, nil
 	}
 	command := parts[0]
+
+	// Groups address bots as /command@bot_name; commands for other bots get no reply
+	if at := strings.Index(command, "@"); at > 0 && strings.HasPrefix(command, "/") {
+		if r.addressedToOtherBot(command[at+1:]) {
+			r.logger.Debug("Command addressed to another bot", "command", command)
+			return nil, nil
+		}
+		cmd.Text = command[:at] + strings.TrimPrefix(strings.TrimSpace(cmd.Text), command)
+		command = command[:at]
+	}
+
+	// Expand chat aliases such as /a -> /analyze, keeping any extra arguments
+	if r.aliasResolver != nil && cmd.Chat != nil {
+		if target, ok := r.aliasResolver.ResolveAlias(cmd.Chat.ID, command); ok {
+			r.logger.Debug("Alias resolved", "alias", command, "target", target, "chat_id", cmd.Chat.ID)
+			cmd.Text = strings.TrimSpace(target + " " + strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(cmd.Text), command)))
+			command = strings.Fields(cmd.Text)[0]
+		}
+	}
+
 	for _, h := range r.handlers {
 		if h.CanHandle(command) {
 			handler = h
@@ -60,32 +213,100 @@ func (r *CommandRouter) Route(ctx context.Context, cmd *domain.Command) (*domain
 
 	if handler == nil {
 		return &domain.Response{
-			Text:      "❓ Noma'lum buyruq. /help yozing",
+			Text:      r.unknownText,
 			ParseMode: "Markdown",
 		}, nil
 	}
 
+	// Chats may turn command categories off and choose the language of their replies
+	if r.chatPolicy != nil && cmd.Chat != nil {
+		cmd.Chat.Language = r.chatPolicy.Language(cmd.Chat.ID)
+		if categorized, ok := handler.(domain.Categorized); ok && r.chatPolicy.CategoryDisabled(cmd.Chat.ID, categorized.Category()) {
+			category := categorized.Category()
+			r.logger.Debug("Command disabled in chat", "command", command, "category", category, "chat_id", cmd.Chat.ID)
+			return &domain.Response{
+				Text:      fmt.Sprintf(replies(cmd).disabled, command, category, category),
+				ParseMode: "Markdown",
+			}, nil
+		}
+	}
+
+	// Long-running handlers show their chat action while they work
+	if h, ok := handler.(domain.LongRunningHandler); ok {
+		if action := h.ChatAction(cmd); action != "" {
+			ctx = domain.WithChatAction(ctx, action)
+		}
+	}
+
 	// Build middleware chain
 	handlerFunc := r.buildMiddlewareChain(handler.Handle)
 
+	timeout := commandTimeout(handler, command)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Execute with middleware chain
 	response, err := handlerFunc(ctx, cmd)
+	if err != nil && errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil {
+		r.logger.Warn("Command timed out", "command", command, "timeout", timeout)
+		return &domain.Response{
+			Text:      replies(cmd).timeout,
+			ParseMode: "Markdown",
+		}, nil
+	}
 	if err != nil {
-		r.logger.Error("Command execution failed", "command", cmd.Text, "error", err)
+		r.logger.Error("Command execution failed", "command", domain.LogText(cmd.Text), "error", err)
 		return &domain.Response{
-			Text:      "❌ Buyruqni bajarishda xatolik yuz berdi",
+			Text:      replies(cmd).failed,
 			ParseMode: "Markdown",
 		}, err
 	}
 
 	r.logger.Info("Command executed successfully",
-		"command", cmd.Text,
+		"command", domain.LogText(cmd.Text),
 		"user", cmd.User.TelegramID,
 		"handler", handler.Description())
 
 	return response, nil
 }
 
+// routeCallback runs the callback handler claiming a button's data through the middleware
+// chain. Presses no handler claims, e.g. of a button from an older bot version, are ignored.
+func (r *CommandRouter) routeCallback(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	for _, handler := range r.callbacks {
+		if !handler.CanHandleCallback(cmd.Text) {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, defaultCommandTimeout)
+		defer cancel()
+
+		response, err := r.buildMiddlewareChain(handler.HandleCallback)(ctx, cmd)
+		if err != nil {
+			r.logger.Error("Callback handling failed", "data", cmd.Text, "error", err)
+			return &domain.Response{
+				Text:      replies(cmd).failed,
+				ParseMode: "Markdown",
+			}, err
+		}
+		return response, nil
+	}
+
+	r.logger.Warn("No handler for callback data", "data", cmd.Text)
+	return nil, nil
+}
+
+// commandTimeout returns the time limit of a command: the handler's own, then its category's
+func commandTimeout(handler domain.CommandHandler, command string) time.Duration {
+	if h, ok := handler.(domain.TimeoutHandler); ok && h.Timeout() > 0 {
+		return h.Timeout()
+	}
+	if timeout, ok := commandTimeouts[command]; ok {
+		return timeout
+	}
+	return defaultCommandTimeout
+}
+
 // GetHandlers returns all registered handlers
 func (r *CommandRouter) GetHandlers() []domain.CommandHandler {
 	return r.handlers
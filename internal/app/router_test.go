@@ -0,0 +1,264 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/handlers"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/handlers/commands"
+	"yordamchi-dev-bot/internal/services"
+)
+
+func TestCommandRouterLegacyCompatibility(t *testing.T) {
+	logger := NewStructuredLogger()
+	router := NewCommandRouter(logger)
+	router.RegisterHandler(commands.NewHazilCommand([]string{"joke"}, logger))
+	router.SetUnknownCommandText("")
+
+	route := func(text string) string {
+		response, err := router.Route(context.Background(), &domain.Command{
+			Text: text,
+			User: &domain.User{TelegramID: 1},
+			Chat: &domain.Chat{ID: -100, Type: "group"},
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", text, err)
+		}
+		return response.Text
+	}
+
+	if text := route("/hazil@yordamchi_bot"); text != "joke" {
+		t.Errorf("expected /command@bot to reach the handler, got %q", text)
+	}
+	if text := route("/missing"); text != defaultUnknownCommandText {
+		t.Errorf("expected the default unknown command reply, got %q", text)
+	}
+
+	router.SetUnknownCommandText("Unknown, try /help")
+	if text := route("/missing@yordamchi_bot"); text != "Unknown, try /help" {
+		t.Errorf("expected the configured unknown command reply, got %q", text)
+	}
+}
+
+func TestCommandRouterBotUsername(t *testing.T) {
+	logger := NewStructuredLogger()
+	router := NewCommandRouter(logger)
+	router.RegisterHandler(commands.NewHazilCommand([]string{"joke"}, logger))
+	router.SetBotUsername("@Yordamchi_Bot")
+
+	route := func(text string) *domain.Response {
+		response, err := router.Route(context.Background(), &domain.Command{
+			Text: text,
+			User: &domain.User{TelegramID: 1},
+			Chat: &domain.Chat{ID: -100, Type: "group"},
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", text, err)
+		}
+		return response
+	}
+
+	for _, text := range []string{"/hazil", "/hazil@yordamchi_bot", "/hazil@YORDAMCHI_BOT now"} {
+		if response := route(text); response == nil || response.Text != "joke" {
+			t.Errorf("%s: expected the handler's reply, got %+v", text, response)
+		}
+	}
+	for _, text := range []string{"/hazil@OtherBot", "/missing@OtherBot"} {
+		if response := route(text); response != nil {
+			t.Errorf("%s: expected no reply to a command for another bot, got %+v", text, response)
+		}
+	}
+}
+
+// slowCommand waits for its context, declaring its own time limit
+type slowCommand struct {
+	timeout time.Duration
+}
+
+func (c *slowCommand) CanHandle(command string) bool { return command == "/slow" || command == "/ping" }
+func (c *slowCommand) Description() string           { return "slow" }
+func (c *slowCommand) Usage() string                 { return "/slow" }
+func (c *slowCommand) Timeout() time.Duration        { return c.timeout }
+func (c *slowCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestCommandRouterTimeouts(t *testing.T) {
+	if got := commandTimeout(&slowCommand{}, "/ping"); got != quickCommandTimeout {
+		t.Errorf("expected the quick category limit for /ping, got %s", got)
+	}
+	if got := commandTimeout(&slowCommand{}, "/chat"); got != aiCommandTimeout {
+		t.Errorf("expected the AI category limit for /chat, got %s", got)
+	}
+	if got := commandTimeout(&slowCommand{}, "/slow"); got != defaultCommandTimeout {
+		t.Errorf("expected the default limit, got %s", got)
+	}
+
+	router := NewCommandRouter(NewStructuredLogger())
+	router.RegisterHandler(&slowCommand{timeout: 20 * time.Millisecond})
+
+	start := time.Now()
+	response, err := router.Route(context.Background(), &domain.Command{
+		Text: "/slow",
+		User: &domain.User{TelegramID: 1},
+		Chat: &domain.Chat{ID: 1},
+	})
+	if err != nil || response == nil || !strings.HasPrefix(response.Text, "⏱️") {
+		t.Fatalf("expected a timeout reply, got %+v, %v", response, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the handler's own limit to apply, took %s", elapsed)
+	}
+}
+
+// projectsCallback claims the "projects:" buttons
+type projectsCallback struct{}
+
+func (c *projectsCallback) CanHandleCallback(data string) bool {
+	return strings.HasPrefix(data, "projects:")
+}
+func (c *projectsCallback) HandleCallback(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	return &domain.Response{Text: "opened " + strings.TrimPrefix(cmd.Text, "projects:open:")}, nil
+}
+
+func TestCommandRouterCallbacks(t *testing.T) {
+	logger := NewStructuredLogger()
+	router := NewCommandRouter(logger)
+	router.RegisterHandler(commands.NewHazilCommand([]string{"joke"}, logger))
+	router.RegisterCallbackHandler(&projectsCallback{})
+
+	press := func(data string) *domain.Response {
+		response, err := router.Route(context.Background(), &domain.Command{
+			Text:     data,
+			User:     &domain.User{TelegramID: 1},
+			Chat:     &domain.Chat{ID: 1},
+			Callback: &domain.CallbackQuery{ID: "q1", Data: data, MessageID: 7},
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", data, err)
+		}
+		return response
+	}
+
+	if response := press("projects:open:proj_1"); response == nil || response.Text != "opened proj_1" {
+		t.Errorf("expected the callback handler to answer, got %+v", response)
+	}
+	if response := press("/hazil"); response == nil || response.Text != "joke" {
+		t.Errorf("expected command callback data to be routed as the command, got %+v", response)
+	}
+	if response := press("unknown:data"); response != nil {
+		t.Errorf("expected no reply for unclaimed callback data, got %+v", response)
+	}
+}
+
+// uploadCommand declares itself long-running for everything but its help
+type uploadCommand struct{}
+
+func (c *uploadCommand) CanHandle(command string) bool { return command == "/upload" }
+func (c *uploadCommand) Description() string           { return "upload" }
+func (c *uploadCommand) Usage() string                 { return "/upload" }
+func (c *uploadCommand) ChatAction(cmd *domain.Command) string {
+	if cmd.Text == "/upload help" {
+		return ""
+	}
+	return domain.ChatActionUploadDocument
+}
+func (c *uploadCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	action, _ := domain.GetChatActionFromContext(ctx)
+	return &domain.Response{Text: action}, nil
+}
+
+func TestCommandRouterChatActions(t *testing.T) {
+	router := NewCommandRouter(NewStructuredLogger())
+	router.RegisterHandler(&uploadCommand{})
+
+	for text, want := range map[string]string{"/upload": domain.ChatActionUploadDocument, "/upload help": ""} {
+		response, err := router.Route(context.Background(), &domain.Command{
+			Text: text,
+			User: &domain.User{TelegramID: 1},
+			Chat: &domain.Chat{ID: 1},
+		})
+		if err != nil || response == nil || response.Text != want {
+			t.Errorf("%s: expected declared action %q, got %+v, %v", text, want, response, err)
+		}
+	}
+}
+
+// fixedPolicy turns off the same command categories in every chat
+type fixedPolicy struct {
+	disabled map[string]bool
+	language string
+}
+
+func (p fixedPolicy) CategoryDisabled(chatID int64, category string) bool {
+	return p.disabled[category]
+}
+func (p fixedPolicy) Language(chatID int64) string { return p.language }
+
+// languageCommand replies with the chat language it was given
+type languageCommand struct{}
+
+func (languageCommand) CanHandle(command string) bool { return command == "/lang" }
+func (languageCommand) Description() string           { return "lang" }
+func (languageCommand) Usage() string                 { return "/lang" }
+func (languageCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	return &domain.Response{Text: cmd.Chat.Language}, nil
+}
+
+func TestCommandRouterChatPolicy(t *testing.T) {
+	logger := NewStructuredLogger()
+	router := NewCommandRouter(logger)
+	router.RegisterHandler(commands.NewHazilCommand([]string{"joke"}, logger))
+	router.RegisterHandler(languageCommand{})
+	router.SetChatPolicy(fixedPolicy{disabled: map[string]bool{"fun": true}, language: "ru"})
+
+	route := func(text string) string {
+		response, err := router.Route(context.Background(), &domain.Command{
+			Text: text,
+			User: &domain.User{TelegramID: 1},
+			Chat: &domain.Chat{ID: -100, Type: "group"},
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", text, err)
+		}
+		return response.Text
+	}
+
+	if text := route("/hazil@yordamchi_bot"); !strings.Contains(text, "отключена") || !strings.Contains(text, "/chat_settings enable fun") {
+		t.Errorf("expected the disabled category reply in the chat language, got %q", text)
+	}
+	if text := route("/lang"); text != "ru" {
+		t.Errorf("expected the chat language to reach the handler, got %q", text)
+	}
+}
+
+func TestEveryRegisteredCommandHasACategory(t *testing.T) {
+	db, err := database.NewSQLiteDB(filepath.Join(t.TempDir(), "router.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	defer db.Close()
+	dependencies, err := NewDependencies(&handlers.Config{}, db)
+	if err != nil {
+		t.Fatalf("NewDependencies() error = %v", err)
+	}
+
+	known := map[string]bool{"": true}
+	for _, name := range services.CategoryNames() {
+		known[name] = true
+	}
+	for _, handler := range dependencies.Router.GetHandlers() {
+		categorized, ok := handler.(domain.Categorized)
+		if !ok {
+			t.Errorf("%s has no Category, so chats could never turn it off", handler.Usage())
+		} else if !known[categorized.Category()] {
+			t.Errorf("%s is in unknown category %q", handler.Usage(), categorized.Category())
+		}
+	}
+}
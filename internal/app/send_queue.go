@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/internal/services"
+)
+
+const (
+	// sendQueueSize is how many rate limited messages wait for a retry at most
+	sendQueueSize = 100
+	// maxSendAttempts is how often a rate limited message is retried before it is dropped
+	maxSendAttempts = 5
+	// maxRetryAfter is the longest wait for Telegram a queued message is kept for
+	maxRetryAfter = 5 * time.Minute
+	// sendRetryBackoff is the first wait when Telegram limits a retry again; it doubles
+	// with every attempt unless Telegram asks for longer
+	sendRetryBackoff = time.Second
+)
+
+// errSendQueueFull is returned when a rate limited message cannot be queued
+var errSendQueueFull = errors.New("too many messages waiting for the Telegram rate limit")
+
+// rateLimited returns the 429 answer of a failed Bot API call, or nil for other errors
+func rateLimited(err error) *services.TelegramAPIError {
+	var apiErr *services.TelegramAPIError
+	if errors.As(err, &apiErr) && apiErr.RateLimited() {
+		return apiErr
+	}
+	return nil
+}
+
+// outgoingMessage is a text message on its way to a chat
+type outgoingMessage struct {
+	chatID      int64
+	replyTo     int
+	text        string
+	parseMode   string
+	replyMarkup interface{}
+	// attempts counts the rate limited sends so far; retryAfter is the wait before the next
+	attempts   int
+	retryAfter time.Duration
+}
+
+// sendQueue holds the messages Telegram rate limited until they can be sent again. One
+// worker retries them in order, and later messages to a chat with queued ones wait
+// behind them, so a conversation never arrives out of order.
+type sendQueue struct {
+	bot      *TelegramBot
+	messages chan *outgoingMessage
+	backoff  time.Duration
+	start    sync.Once
+
+	mutex sync.Mutex
+	// pending counts the queued messages of each chat
+	pending map[int64]int
+}
+
+// newSendQueue creates the queue of a bot; its worker starts with the first message
+func newSendQueue(bot *TelegramBot) *sendQueue {
+	return &sendQueue{
+		bot:      bot,
+		messages: make(chan *outgoingMessage, sendQueueSize),
+		backoff:  sendRetryBackoff,
+		pending:  make(map[int64]int),
+	}
+}
+
+// holds reports whether messages to the chat are waiting
+func (q *sendQueue) holds(chatID int64) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.pending[chatID] > 0
+}
+
+// add queues a message to be sent after its retryAfter
+func (q *sendQueue) add(message *outgoingMessage) error {
+	q.start.Do(func() { go q.run() })
+
+	q.mutex.Lock()
+	q.pending[message.chatID]++
+	q.mutex.Unlock()
+
+	select {
+	case q.messages <- message:
+		return nil
+	default:
+		q.done(message.chatID)
+		return errSendQueueFull
+	}
+}
+
+// flush waits until the queued messages are sent or dropped, or the context ends
+func (q *sendQueue) flush(ctx context.Context) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		q.mutex.Lock()
+		waiting := 0
+		for _, count := range q.pending {
+			waiting += count
+		}
+		q.mutex.Unlock()
+		if waiting == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d messages unsent: %w", waiting, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// done removes a message from the chat's pending count
+func (q *sendQueue) done(chatID int64) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.pending[chatID]--; q.pending[chatID] <= 0 {
+		delete(q.pending, chatID)
+	}
+}
+
+// run sends the queued messages one by one, waiting as long as Telegram asks
+func (q *sendQueue) run() {
+	logger := q.bot.dependencies.Logger
+	for message := range q.messages {
+		err := q.send(message)
+		q.done(message.chatID)
+
+		rateLimited := message.attempts > 0
+		if err != nil {
+			logger.Error("Failed to send queued Telegram message",
+				"chat_id", message.chatID,
+				"attempts", message.attempts,
+				"error", err)
+		} else if rateLimited {
+			logger.Info("Rate limited Telegram message delivered", "chat_id", message.chatID, "attempts", message.attempts)
+		}
+		if rateLimited {
+			q.bot.dependencies.Metrics.RecordTelegramRetry(err == nil)
+		}
+	}
+}
+
+// send retries one message until it is delivered, fails for another reason or runs out
+// of attempts
+func (q *sendQueue) send(message *outgoingMessage) error {
+	for {
+		time.Sleep(message.retryAfter)
+		err := q.bot.postMessage(message)
+
+		limited := rateLimited(err)
+		if limited == nil {
+			return err
+		}
+		message.attempts++
+		if message.attempts >= maxSendAttempts {
+			return fmt.Errorf("gave up after %d rate limited attempts: %w", message.attempts, err)
+		}
+		// Telegram's wait is exact for the flood limit, but a limit hit again right after
+		// it suggests more traffic, so the wait grows
+		message.retryAfter = max(limited.RetryAfter, q.backoff<<(message.attempts-1))
+		if message.retryAfter > maxRetryAfter {
+			return fmt.Errorf("retry_after of %s is too long to wait: %w", limited.RetryAfter, err)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"yordamchi-dev-bot/internal/middleware"
+	"yordamchi-dev-bot/internal/services"
+)
+
+func TestRateLimitedMessagesAreQueuedInOrder(t *testing.T) {
+	var mutex sync.Mutex
+	var delivered []string
+	limited := 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if limited > 0 {
+			limited--
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 0","parameters":{"retry_after":0}}`))
+			return
+		}
+		delivered = append(delivered, payload["text"].(string))
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer server.Close()
+
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger()})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+	bot.sendQueue.backoff = time.Millisecond
+
+	// The first message is limited twice; the second waits behind it instead of overtaking
+	if err := bot.SendMessage(context.Background(), 7, "first", ""); err != nil {
+		t.Fatalf("SendMessage() error = %v, want the message queued", err)
+	}
+	if err := bot.SendMessage(context.Background(), 7, "second", ""); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && bot.sendQueue.holds(7) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(delivered) != 2 || delivered[0] != "first" || delivered[1] != "second" {
+		t.Errorf("delivered %q, want first then second", delivered)
+	}
+}
+
+func TestSimulatedRateLimitIsRetried(t *testing.T) {
+	var mutex sync.Mutex
+	var delivered []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		delivered = append(delivered, payload["text"].(string))
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("SIMULATE_ENABLED", "true")
+	simulator := services.NewSimulator()
+	simulator.SetTelegram429(1)
+
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger(), Simulator: simulator})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+
+	// The simulated 429 never reaches Telegram; the reply waits in the queue instead
+	if err := bot.SendMessage(context.Background(), 7, "reply", ""); err != nil {
+		t.Fatalf("SendMessage() error = %v, want the message queued", err)
+	}
+	if !bot.sendQueue.holds(7) {
+		t.Fatal("expected the rate limited reply to be queued")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := bot.sendQueue.flush(ctx); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(delivered) != 1 || delivered[0] != "reply" {
+		t.Errorf("delivered %q, want the reply once after the retry", delivered)
+	}
+	if state := simulator.State(); state.Telegram429 != 0 {
+		t.Errorf("Telegram429 = %d, want the armed failure used up", state.Telegram429)
+	}
+}
+
+func TestSimulatedRateLimitGoesThroughTheQueue(t *testing.T) {
+	var mutex sync.Mutex
+	var delivered []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		delivered = append(delivered, payload["text"].(string))
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("SIMULATE_ENABLED", "true")
+	simulator := services.NewSimulator()
+	simulator.SetTelegram429(2)
+	metrics := middleware.NewMetricsMiddleware(NewStructuredLogger())
+
+	bot := NewTelegramBot("token", &Dependencies{
+		Logger:    NewStructuredLogger(),
+		Simulator: simulator,
+		Metrics:   NewMetricsProvider(metrics, nil, nil, nil, nil, nil),
+	})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL)
+	bot.sendQueue.backoff = time.Millisecond
+
+	// The queued retry of the first message is limited again; the second waits behind it
+	if err := bot.SendMessage(context.Background(), 7, "first", ""); err != nil {
+		t.Fatalf("SendMessage() error = %v, want the message queued", err)
+	}
+	if err := bot.SendMessage(context.Background(), 7, "second", ""); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := bot.sendQueue.flush(ctx); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(delivered) != 2 || delivered[0] != "first" || delivered[1] != "second" {
+		t.Errorf("delivered %q, want first then second", delivered)
+	}
+	stats := metrics.GetMetrics()
+	if stats["telegram_rate_limited"] != int64(2) || stats["telegram_retried"] != int64(1) {
+		t.Errorf("rate limited %v, retried %v, want 2 and 1", stats["telegram_rate_limited"], stats["telegram_retried"])
+	}
+}
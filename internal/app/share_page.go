@@ -0,0 +1,199 @@
+package app
+
+import (
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/handlers/commands"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// sharePageTemplate renders the read-only project status page opened through a share link
+var sharePageTemplate = template.Must(template.New("share").Funcs(template.FuncMap{"estimate": domain.TaskEstimateRange}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Name}} - Project status</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, sans-serif; max-width: 760px; margin: 2rem auto; padding: 0 1rem; color: #222; }
+h1 { margin-bottom: 0.2rem; }
+.muted { color: #777; font-size: 0.9rem; }
+.bar { background: #eee; border-radius: 6px; height: 12px; overflow: hidden; }
+.bar > div { background: #4caf50; height: 100%; }
+table { width: 100%; border-collapse: collapse; margin-top: 0.5rem; }
+td, th { text-align: left; padding: 0.35rem 0.5rem; border-bottom: 1px solid #eee; }
+section { margin-top: 1.8rem; }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+<p>{{.HealthIcon}} Health {{.Health.Score}}/100 · {{.Completed}} of {{.Total}} tasks done</p>
+<div class="bar"><div style="width: {{.ProgressPercent}}%"></div></div>
+{{range .Health.Reasons}}<p class="muted">⚠️ {{.}}</p>{{end}}
+
+<section>
+<h2>Milestones</h2>
+<table>
+<tr><th>Milestone</th><th>Progress</th></tr>
+{{range .Milestones}}<tr><td>{{.Name}}</td><td>{{.Done}}/{{.Total}}</td></tr>
+{{end}}{{if not .Deadline.IsZero}}<tr><td>🏁 Deadline</td><td>{{.Deadline.Format "Jan 2 2006"}}</td></tr>{{end}}
+</table>
+</section>
+
+<section>
+<h2>Tasks</h2>
+<table>
+<tr><th>Task</th><th>Status</th><th>Estimate</th></tr>
+{{range .Tasks}}<tr><td>{{.Title}}</td><td>{{.Status}}</td><td>{{estimate .}}</td></tr>
+{{else}}<tr><td colspan="3" class="muted">No tasks yet</td></tr>
+{{end}}</table>
+</section>
+
+<p class="muted">Read-only view · updated {{.GeneratedAt.Format "Jan 2 2006 15:04"}}</p>
+</body>
+</html>
+`))
+
+// sharePageMilestone is the progress of one task category
+type sharePageMilestone struct {
+	Name  string
+	Done  int
+	Total int
+}
+
+// sharePageData is the view model of the share page
+type sharePageData struct {
+	Name            string
+	Description     string
+	Health          domain.ProjectHealth
+	HealthIcon      string
+	Completed       int
+	Total           int
+	ProgressPercent int
+	Deadline        time.Time
+	Milestones      []sharePageMilestone
+	Tasks           []domain.Task
+	GeneratedAt     time.Time
+}
+
+// handleSharePage serves the read-only status page of a project shared with /share
+func (b *TelegramBot) handleSharePage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	token := strings.TrimPrefix(r.URL.Path, "/share/")
+	if token == "" || strings.Contains(token, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	db := b.dependencies.DB
+	share, err := db.GetProjectShare(token)
+	if err != nil {
+		b.dependencies.Logger.Error("Failed to load project share", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if share == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	// The link is checked on the primary so revoking applies at once; the dashboard
+	// itself is read from the analytics replica when there is one
+	analytics := db.Analytics()
+	projects, err := analytics.GetProjectsByChatID(share.ChatID)
+	if err != nil {
+		b.dependencies.Logger.Error("Failed to load shared project", "project_id", share.ProjectID, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	var project *sharePageData
+	for _, candidate := range projects {
+		if candidate.ID != share.ProjectID {
+			continue
+		}
+
+		health, record, err := commands.EvaluateProjectHealth(r.Context(), analytics, b.dependencies.CalendarService, candidate, time.Now())
+		if err != nil {
+			b.dependencies.Logger.Error("Failed to evaluate shared project", "project_id", share.ProjectID, "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		project = &sharePageData{
+			Name:        candidate.Name,
+			Description: candidate.Description,
+			Health:      health,
+			HealthIcon:  domain.HealthIcon(health.Level),
+			GeneratedAt: time.Now().In(services.BotLocation()),
+		}
+		if record != nil {
+			project.Deadline = record.Deadline
+		}
+	}
+	if project == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	tasks, err := analytics.GetTasksByProjectID(share.ProjectID)
+	if err != nil {
+		b.dependencies.Logger.Error("Failed to load shared project tasks", "project_id", share.ProjectID, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Task categories serve as milestones since projects have no explicit ones
+	milestones := make(map[string]*sharePageMilestone)
+	for _, task := range tasks {
+		project.Tasks = append(project.Tasks, domain.Task{
+			Title:            task.Title,
+			Status:           strings.ReplaceAll(task.Status, "_", " "),
+			EstimateHours:    task.EstimateHours,
+			OptimisticHours:  task.OptimisticHours,
+			PessimisticHours: task.PessimisticHours,
+		})
+
+		category := task.Category
+		if category == "" {
+			category = "general"
+		}
+		milestone, ok := milestones[category]
+		if !ok {
+			milestone = &sharePageMilestone{Name: category}
+			milestones[category] = milestone
+		}
+		milestone.Total++
+		if task.Status == domain.TaskStatusCompleted {
+			milestone.Done++
+			project.Completed++
+		}
+	}
+	project.Total = len(tasks)
+	project.ProgressPercent = int(project.Health.Progress * 100)
+
+	for _, milestone := range milestones {
+		project.Milestones = append(project.Milestones, *milestone)
+	}
+	sort.Slice(project.Milestones, func(i, j int) bool {
+		return project.Milestones[i].Name < project.Milestones[j].Name
+	})
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := sharePageTemplate.Execute(w, project); err != nil {
+		b.dependencies.Logger.Error("Failed to render share page", "project_id", share.ProjectID, "error", err)
+	}
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultShutdownTimeout is how long a shutdown waits for work in progress
+const defaultShutdownTimeout = 30 * time.Second
+
+// ShutdownTimeout returns how long SIGTERM may wait for in-flight work, from
+// SHUTDOWN_TIMEOUT_SECONDS
+func ShutdownTimeout() time.Duration {
+	if seconds, err := strconv.Atoi(strings.TrimSpace(os.Getenv("SHUTDOWN_TIMEOUT_SECONDS"))); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultShutdownTimeout
+}
+
+// goTracked runs fn in a goroutine that Shutdown waits for
+func (b *TelegramBot) goTracked(fn func()) {
+	b.inFlight.Add(1)
+	go func() {
+		defer b.inFlight.Done()
+		fn()
+	}()
+}
+
+// Shutdown stops the bot gracefully: webhooks are turned away, polling and scheduled jobs
+// stop, and the updates, background analyses, project webhook deliveries and rate limited
+// messages in progress are finished until the context ends. Closing the database is left
+// to the caller.
+func (b *TelegramBot) Shutdown(ctx context.Context) error {
+	logger := b.dependencies.Logger
+	logger.Info("Bot shutting down")
+
+	var errs []error
+	b.lifecycle.Lock()
+	// Start checks closing under the same lock, so it either registered its server or won't
+	b.closing.Store(true)
+	server, cancel := b.server, b.cancel
+	b.lifecycle.Unlock()
+	if server != nil {
+		if err := server.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("http server: %w", err))
+		}
+	}
+	if cancel != nil {
+		cancel()
+	}
+
+	// Albums still waiting for more files are analyzed with what arrived
+	b.mediaGroups.Flush()
+
+	done := make(chan struct{})
+	go func() {
+		b.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("updates in progress: %w", ctx.Err()))
+	}
+
+	if b.dependencies.JobQueue != nil {
+		if err := b.dependencies.JobQueue.Drain(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("background jobs: %w", err))
+		}
+	}
+	if b.dependencies.ProjectWebhooks != nil {
+		if err := b.dependencies.ProjectWebhooks.Flush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("project webhooks: %w", err))
+		}
+	}
+	if err := b.sendQueue.flush(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("queued messages: %w", err))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	logger.Info("Bot stopped gracefully")
+	return nil
+}
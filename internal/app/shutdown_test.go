@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShutdownWaitsForUpdatesAndTurnsWebhooksAway(t *testing.T) {
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger()})
+
+	release := make(chan struct{})
+	finished := false
+	bot.goTracked(func() {
+		<-release
+		finished = true
+	})
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- bot.Shutdown(context.Background()) }()
+
+	// New updates are refused while the one in progress is still running
+	deadline := time.Now().Add(2 * time.Second)
+	for !bot.closing.Load() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	recorder := httptest.NewRecorder()
+	bot.handleWebhook(recorder, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"update_id":1}`)))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("webhook status = %d, want 503 while shutting down", recorder.Code)
+	}
+
+	select {
+	case err := <-stopped:
+		t.Fatalf("Shutdown() returned %v before the update finished", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-stopped; err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if !finished {
+		t.Error("Shutdown() returned before the update finished")
+	}
+}
+
+func TestShutdownGivesUpWhenTheContextEnds(t *testing.T) {
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger()})
+	release := make(chan struct{})
+	defer close(release)
+	bot.goTracked(func() { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := bot.Shutdown(ctx); err == nil {
+		t.Error("Shutdown() = nil, want an error for the unfinished update")
+	}
+}
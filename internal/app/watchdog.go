@@ -0,0 +1,271 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/internal/services"
+)
+
+// Watchdog defaults, overridable with the WATCHDOG_* environment variables
+const (
+	watchdogInterval          = time.Minute
+	defaultWatchdogAlertAfter = 5 * time.Minute
+	defaultWatchdogStaleAfter = 5 * time.Minute
+	// pollStaleAfter is how long polling may go without a successful getUpdates; a request
+	// lasts up to pollTimeout, failures are retried within maxPollBackoff
+	pollStaleAfter = pollTimeout + 2*maxPollBackoff
+)
+
+// webhookInfo is the part of getWebhookInfo the watchdog looks at
+type webhookInfo struct {
+	URL                string `json:"url"`
+	PendingUpdateCount int    `json:"pending_update_count"`
+	LastErrorMessage   string `json:"last_error_message"`
+}
+
+// Watchdog checks that the bot can reach Telegram and still receives updates. When the
+// bot stays unhealthy it alerts a channel outside Telegram, by email or webhook, since
+// Telegram may be what is broken, and can register the webhook again.
+type Watchdog struct {
+	bot *TelegramBot
+	// client posts the alerts; Bot API calls go through the bot's Telegram client
+	client *http.Client
+	mailer *services.Mailer
+	// alertEmail and alertWebhook receive the alerts; either may be empty
+	alertEmail   string
+	alertWebhook string
+	// alertAfter is how long the bot must be unhealthy before alerting
+	alertAfter time.Duration
+	// staleAfter is how long updates may wait at Telegram without one reaching the bot
+	staleAfter time.Duration
+	// webhookURL is where the webhook should point; reregister sets it again when unhealthy
+	webhookURL string
+	reregister bool
+
+	mutex          sync.Mutex
+	problem        string
+	unhealthySince time.Time
+	alerted        bool
+}
+
+// NewWatchdog creates a watchdog for the bot from the WATCHDOG_* environment variables
+func NewWatchdog(bot *TelegramBot, mailer *services.Mailer) *Watchdog {
+	// PUBLIC_URL includes HTTP_BASE_PATH
+	webhookURL := ""
+	if publicURL := strings.TrimRight(os.Getenv("PUBLIC_URL"), "/"); publicURL != "" {
+		webhookURL = publicURL + "/webhook"
+	}
+	return &Watchdog{
+		bot:          bot,
+		client:       &http.Client{Timeout: 15 * time.Second},
+		mailer:       mailer,
+		alertEmail:   strings.TrimSpace(os.Getenv("WATCHDOG_ALERT_EMAIL")),
+		alertWebhook: strings.TrimSpace(os.Getenv("WATCHDOG_ALERT_WEBHOOK")),
+		alertAfter:   envMinutes("WATCHDOG_ALERT_MINUTES", defaultWatchdogAlertAfter),
+		staleAfter:   envMinutes("WATCHDOG_STALE_MINUTES", defaultWatchdogStaleAfter),
+		webhookURL:   webhookURL,
+		reregister:   os.Getenv("WATCHDOG_REREGISTER_WEBHOOK") == "true",
+	}
+}
+
+// WatchdogEnabled reports whether the watchdog runs; WATCHDOG_ENABLED=false turns it off
+func WatchdogEnabled() bool {
+	return os.Getenv("WATCHDOG_ENABLED") != "false"
+}
+
+// envMinutes reads a positive number of minutes, or returns the fallback
+func envMinutes(name string, fallback time.Duration) time.Duration {
+	if minutes, err := strconv.Atoi(strings.TrimSpace(os.Getenv(name))); err == nil && minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return fallback
+}
+
+// Run checks the bot every interval until the context is cancelled
+func (w *Watchdog) Run(ctx context.Context, interval time.Duration) {
+	w.bot.dependencies.Logger.Info("Watchdog started",
+		"interval", interval,
+		"alert_after", w.alertAfter,
+		"email", w.alertEmail != "",
+		"webhook", w.alertWebhook != "",
+		"reregister", w.reregister)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Tick(ctx, time.Now())
+		}
+	}
+}
+
+// Status returns the current problem, or "" when the bot is healthy
+func (w *Watchdog) Status() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.problem
+}
+
+// Tick runs one check, alerting once the bot has been unhealthy for alertAfter and again
+// when it recovers
+func (w *Watchdog) Tick(ctx context.Context, now time.Time) {
+	// Request errors quote the API URL, which contains the token
+	problem := strings.ReplaceAll(w.check(ctx, now), w.bot.token, "<token>")
+
+	w.mutex.Lock()
+	w.problem = problem
+	if problem == "" {
+		recovered := w.alerted
+		downtime := now.Sub(w.unhealthySince)
+		w.unhealthySince, w.alerted = time.Time{}, false
+		w.mutex.Unlock()
+
+		if recovered {
+			w.bot.dependencies.Logger.Info("Watchdog: bot recovered", "downtime", downtime)
+			w.alert(ctx, "✅ Bot recovered", fmt.Sprintf("The bot reaches Telegram and receives updates again after %s.", downtime.Round(time.Minute)))
+		}
+		return
+	}
+
+	if w.unhealthySince.IsZero() {
+		w.unhealthySince = now
+	}
+	due := !w.alerted && now.Sub(w.unhealthySince) >= w.alertAfter
+	if due {
+		w.alerted = true
+	}
+	since := w.unhealthySince
+	w.mutex.Unlock()
+
+	w.bot.dependencies.Logger.Warn("Watchdog: bot unhealthy", "problem", problem, "since", since)
+	if !due {
+		return
+	}
+
+	text := fmt.Sprintf("The bot has been unhealthy since %s: %s.", since.Format(time.RFC3339), problem)
+	if w.reregister && !PollingMode() && w.webhookURL != "" {
+		if err := w.setWebhook(ctx); err != nil {
+			w.bot.dependencies.Logger.Error("Watchdog: failed to register the webhook again", "error", err)
+			text += fmt.Sprintf("\n\nRegistering the webhook again failed: %v", err)
+		} else {
+			w.bot.dependencies.Logger.Info("Watchdog: webhook registered again", "url", w.webhookURL)
+			text += "\n\nThe webhook was registered again at " + w.webhookURL + "."
+		}
+	}
+	w.alert(ctx, "🚨 Bot unhealthy", text)
+}
+
+// check returns what is wrong with the bot, or "" when it is healthy
+func (w *Watchdog) check(ctx context.Context, now time.Time) string {
+	if _, err := w.bot.callPollingAPI(ctx, defaultAPITimeout, "getMe", url.Values{}); err != nil {
+		return fmt.Sprintf("getMe failed: %v", err)
+	}
+
+	if PollingMode() {
+		if last := w.bot.LastPoll(); !last.IsZero() && now.Sub(last) > pollStaleAfter {
+			return fmt.Sprintf("no successful getUpdates for %s", now.Sub(last).Round(time.Second))
+		}
+		return ""
+	}
+
+	result, err := w.bot.callPollingAPI(ctx, defaultAPITimeout, "getWebhookInfo", url.Values{})
+	if err != nil {
+		return fmt.Sprintf("getWebhookInfo failed: %v", err)
+	}
+	var info webhookInfo
+	if err := json.Unmarshal(result, &info); err != nil {
+		return fmt.Sprintf("getWebhookInfo returned an unexpected result: %v", err)
+	}
+	if info.URL == "" {
+		return "no webhook is set"
+	}
+	if w.webhookURL != "" && info.URL != w.webhookURL {
+		return fmt.Sprintf("the webhook points to %s instead of %s", info.URL, w.webhookURL)
+	}
+
+	// Waiting updates are only a problem when none has arrived for a while; a quiet bot
+	// receives nothing and has nothing pending
+	last := w.bot.LastUpdate()
+	if last.IsZero() {
+		last = w.bot.dependencies.StartTime
+	}
+	if info.PendingUpdateCount > 0 && now.Sub(last) > w.staleAfter {
+		problem := fmt.Sprintf("%d updates are waiting at Telegram and none arrived for %s", info.PendingUpdateCount, now.Sub(last).Round(time.Second))
+		if info.LastErrorMessage != "" {
+			problem += fmt.Sprintf(" (last delivery error: %s)", info.LastErrorMessage)
+		}
+		return problem
+	}
+	return ""
+}
+
+// setWebhook points the bot's webhook at webhookURL again
+func (w *Watchdog) setWebhook(ctx context.Context) error {
+	params := url.Values{}
+	params.Set("url", w.webhookURL)
+	_, err := w.bot.callPollingAPI(ctx, defaultAPITimeout, "setWebhook", params)
+	return err
+}
+
+// alert sends a message to the configured email address and webhook
+func (w *Watchdog) alert(ctx context.Context, subject, text string) {
+	logger := w.bot.dependencies.Logger
+	if w.alertEmail == "" && w.alertWebhook == "" {
+		logger.Warn("Watchdog: no alert channel configured, set WATCHDOG_ALERT_EMAIL or WATCHDOG_ALERT_WEBHOOK", "subject", subject)
+		return
+	}
+
+	if w.alertEmail != "" && w.mailer != nil && w.mailer.Enabled() {
+		err := w.mailer.Send(services.Email{
+			To:      w.alertEmail,
+			Subject: subject,
+			HTML:    "<p>" + strings.ReplaceAll(html.EscapeString(text), "\n", "<br>") + "</p>",
+		})
+		if err != nil {
+			logger.Error("Watchdog: failed to email alert", "error", err)
+		}
+	}
+
+	if w.alertWebhook != "" {
+		if err := w.postAlert(ctx, subject+"\n"+text); err != nil {
+			logger.Error("Watchdog: failed to post alert", "error", err)
+		}
+	}
+}
+
+// postAlert posts the alert as JSON that Slack ("text") and Discord ("content") accept
+func (w *Watchdog) postAlert(ctx context.Context, text string) error {
+	payload, err := json.Marshal(map[string]string{"text": text, "content": text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.alertWebhook, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchdogAlertsAndReregistersWebhook(t *testing.T) {
+	pending := 3
+	var alerts []string
+	var registered string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getMe"):
+			w.Write([]byte(`{"ok":true,"result":{"id":1}}`))
+		case strings.HasSuffix(r.URL.Path, "/getWebhookInfo"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": map[string]interface{}{
+				"url":                  "https://bot.example.com/webhook",
+				"pending_update_count": pending,
+				"last_error_message":   "Connection timed out",
+			}})
+		case strings.HasSuffix(r.URL.Path, "/setWebhook"):
+			r.ParseForm()
+			registered = r.PostForm.Get("url")
+			w.Write([]byte(`{"ok":true,"result":true}`))
+		case r.URL.Path == "/alert":
+			var payload map[string]string
+			json.NewDecoder(r.Body).Decode(&payload)
+			alerts = append(alerts, payload["text"])
+		}
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	bot := NewTelegramBot("token", &Dependencies{Logger: NewStructuredLogger(), StartTime: start})
+	bot.telegram = bot.telegram.WithEndpoint(server.URL + "/bot")
+	watchdog := &Watchdog{
+		bot:          bot,
+		client:       server.Client(),
+		alertWebhook: server.URL + "/alert",
+		alertAfter:   5 * time.Minute,
+		staleAfter:   5 * time.Minute,
+		webhookURL:   "https://bot.example.com/webhook",
+		reregister:   true,
+	}
+	ctx := context.Background()
+
+	// Pending updates right after startup are not a problem yet
+	watchdog.Tick(ctx, start.Add(time.Minute))
+	if watchdog.Status() != "" {
+		t.Fatalf("expected healthy at startup, got %q", watchdog.Status())
+	}
+
+	watchdog.Tick(ctx, start.Add(6*time.Minute))
+	if !strings.Contains(watchdog.Status(), "3 updates are waiting") || len(alerts) != 0 {
+		t.Fatalf("expected unhealthy without an alert yet, got %q and %d alerts", watchdog.Status(), len(alerts))
+	}
+
+	watchdog.Tick(ctx, start.Add(11*time.Minute))
+	watchdog.Tick(ctx, start.Add(12*time.Minute))
+	if len(alerts) != 1 || !strings.Contains(alerts[0], "Connection timed out") {
+		t.Fatalf("expected one alert with the delivery error, got %q", alerts)
+	}
+	if registered != "https://bot.example.com/webhook" {
+		t.Errorf("expected the webhook to be registered again, got %q", registered)
+	}
+
+	pending = 0
+	watchdog.Tick(ctx, start.Add(13*time.Minute))
+	if watchdog.Status() != "" || len(alerts) != 2 || !strings.Contains(alerts[1], "recovered") {
+		t.Errorf("expected a recovery alert, got %q and %q", watchdog.Status(), alerts)
+	}
+}
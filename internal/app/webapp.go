@@ -0,0 +1,292 @@
+package app
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/handlers/commands"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// webAppInitDataMaxAge limits how long a Mini App session stays valid
+const webAppInitDataMaxAge = 24 * time.Hour
+
+// webAppMaxLogHours bounds the time logged in one request, matching /task log
+const webAppMaxLogHours = 24.0
+
+// webAppPageTemplate is the Mini App UI. It talks to the JSON API with the signed initData.
+var webAppPageTemplate = template.Must(template.New("webapp").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>My Tasks</title>
+<script src="https://telegram.org/js/telegram-web-app.js"></script>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, sans-serif; margin: 0; padding: 12px; background: var(--tg-theme-bg-color, #fff); color: var(--tg-theme-text-color, #222); }
+h3 { margin: 18px 0 6px; }
+.task { padding: 10px; margin-bottom: 8px; border-radius: 8px; background: var(--tg-theme-secondary-bg-color, #f3f3f3); }
+.meta { font-size: 0.85rem; color: var(--tg-theme-hint-color, #777); margin: 4px 0 8px; }
+button { margin-right: 6px; padding: 6px 10px; border: 0; border-radius: 6px; background: var(--tg-theme-button-color, #2481cc); color: var(--tg-theme-button-text-color, #fff); }
+#error { color: #c0392b; }
+</style>
+</head>
+<body>
+<div id="error"></div>
+<div id="tasks">Loading…</div>
+<script>
+const tg = window.Telegram.WebApp;
+tg.ready();
+
+async function api(path, body) {
+  const response = await fetch("{{.APIPath}}" + path, {
+    method: body ? "POST" : "GET",
+    headers: {"X-Telegram-Init-Data": tg.initData, "Content-Type": "application/json"},
+    body: body ? JSON.stringify(body) : undefined,
+  });
+  const data = await response.json();
+  if (!response.ok) throw new Error(data.error || "Request failed");
+  return data;
+}
+
+function render(groups) {
+  const root = document.getElementById("tasks");
+  root.textContent = "";
+  if (groups.length === 0) { root.textContent = "🎉 No open tasks assigned to you."; return; }
+  for (const group of groups) {
+    const title = document.createElement("h3");
+    title.textContent = group.title + " (" + group.tasks.length + ")";
+    root.appendChild(title);
+    for (const task of group.tasks) {
+      const card = document.createElement("div");
+      card.className = "task";
+      const name = document.createElement("b");
+      name.textContent = task.title;
+      const meta = document.createElement("div");
+      meta.className = "meta";
+      meta.textContent = task.project + " · " + task.team + " · " + task.status.replace("_", " ") +
+        " · " + task.actual_hours.toFixed(1) + "h of " + task.estimate_hours.toFixed(1) + "h" +
+        (task.deadline ? " · due " + task.deadline : "");
+      card.append(name, meta);
+      for (const [label, body] of [["▶️ Start", {status: "in_progress"}], ["✅ Done", {status: "completed"}], ["⏱️ +1h", {log_hours: 1}]]) {
+        const button = document.createElement("button");
+        button.textContent = label;
+        button.onclick = () => update(task.id, body);
+        card.appendChild(button);
+      }
+      root.appendChild(card);
+    }
+  }
+}
+
+async function load() {
+  try { render((await api("/tasks")).groups); } catch (e) { document.getElementById("error").textContent = e.message; }
+}
+
+async function update(id, body) {
+  document.getElementById("error").textContent = "";
+  try { await api("/tasks/" + encodeURIComponent(id), body); tg.HapticFeedback.notificationOccurred("success"); await load(); }
+  catch (e) { document.getElementById("error").textContent = e.message; }
+}
+
+load();
+</script>
+</body>
+</html>
+`))
+
+// webAppTask is a task as returned by the Mini App API
+type webAppTask struct {
+	ID            string  `json:"id"`
+	Title         string  `json:"title"`
+	Status        string  `json:"status"`
+	Priority      int     `json:"priority"`
+	Project       string  `json:"project"`
+	Team          string  `json:"team"`
+	Deadline      string  `json:"deadline,omitempty"`
+	EstimateHours float64 `json:"estimate_hours"`
+	ActualHours   float64 `json:"actual_hours"`
+}
+
+// webAppTaskGroup is a due-date bucket of tasks as returned by the Mini App API
+type webAppTaskGroup struct {
+	Title string       `json:"title"`
+	Tasks []webAppTask `json:"tasks"`
+}
+
+// webAppTaskUpdate is a change requested from the Mini App
+type webAppTaskUpdate struct {
+	Status   string  `json:"status"`
+	LogHours float64 `json:"log_hours"`
+}
+
+// handleWebAppPage serves the Mini App UI
+func (b *TelegramBot) handleWebAppPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := webAppPageTemplate.Execute(w, map[string]string{"APIPath": b.basePath + "/webapp/api"}); err != nil {
+		b.dependencies.Logger.Error("Failed to render web app", "error", err)
+	}
+}
+
+// handleWebAppAPI serves the Mini App JSON API: GET /webapp/api/tasks lists the caller's tasks
+// and POST /webapp/api/tasks/{id} changes the status of or logs time on one of them
+func (b *TelegramBot) handleWebAppAPI(w http.ResponseWriter, r *http.Request) {
+	user, err := services.ValidateWebAppInitData(r.Header.Get("X-Telegram-Init-Data"), b.token, webAppInitDataMaxAge, time.Now())
+	if err != nil {
+		b.dependencies.Logger.Warn("Rejected web app request", "error", err)
+		writeJSONError(w, http.StatusUnauthorized, "Please reopen the app from Telegram")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/webapp/api/tasks")
+	switch {
+	case path == "" && r.Method == http.MethodGet:
+		b.webAppListTasks(w, r, user)
+	case strings.HasPrefix(path, "/") && len(path) > 1 && r.Method == http.MethodPost:
+		b.webAppUpdateTask(w, r, user, path[1:])
+	default:
+		writeJSONError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// webAppListTasks returns the caller's open tasks grouped like /my_tasks
+func (b *TelegramBot) webAppListTasks(w http.ResponseWriter, r *http.Request, user *services.WebAppUser) {
+	db := b.dependencies.DB
+	if err := db.LinkTeamMemberships(user.ID, user.Username); err != nil {
+		b.dependencies.Logger.Warn("Failed to link team memberships", "user_id", user.ID, "error", err)
+	}
+
+	tasks, err := db.GetTasksAssignedToUser(user.ID)
+	if err != nil {
+		b.dependencies.Logger.Error("Failed to get assigned tasks", "user_id", user.ID, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "Failed to load tasks")
+		return
+	}
+
+	groups := []webAppTaskGroup{}
+	for _, group := range commands.GroupAssignedTasks(r.Context(), tasks, b.dependencies.CalendarService, time.Now()) {
+		result := webAppTaskGroup{Title: group.Title}
+		for _, task := range group.Tasks {
+			item := webAppTask{
+				ID:            task.ID,
+				Title:         task.Title,
+				Status:        task.Status,
+				Priority:      task.Priority,
+				Project:       task.ProjectName,
+				Team:          task.TeamName,
+				EstimateHours: task.EstimateHours,
+				ActualHours:   task.ActualHours,
+			}
+			if !task.Deadline.IsZero() {
+				item.Deadline = task.Deadline.Format("Jan 2")
+			}
+			result.Tasks = append(result.Tasks, item)
+		}
+		groups = append(groups, result)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"groups": groups})
+}
+
+// webAppUpdateTask applies a status change or time log to a task assigned to the caller.
+// Like /task, starting or completing a task requires its dependencies to be done.
+func (b *TelegramBot) webAppUpdateTask(w http.ResponseWriter, r *http.Request, user *services.WebAppUser, taskID string) {
+	var update webAppTaskUpdate
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&update); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	db := b.dependencies.DB
+	location, err := db.GetTaskLocation(taskID)
+	if err != nil {
+		b.dependencies.Logger.Error("Failed to load task", "task_id", taskID, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "Failed to load the task")
+		return
+	}
+	if location == nil || location.AssigneeUserID != user.ID {
+		writeJSONError(w, http.StatusNotFound, "Task not found among your tasks")
+		return
+	}
+
+	if update.LogHours != 0 {
+		if update.LogHours < 0 || update.LogHours > webAppMaxLogHours {
+			writeJSONError(w, http.StatusBadRequest, "Log between 0 and 24 hours at a time")
+			return
+		}
+		if err := db.AddTaskActualHours(taskID, update.LogHours); err != nil {
+			b.dependencies.Logger.Error("Failed to log task time", "task_id", taskID, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to log time")
+			return
+		}
+		b.dependencies.Logger.Info("Task time logged from web app", "task_id", taskID, "hours", update.LogHours)
+	}
+
+	if update.Status != "" {
+		switch update.Status {
+		case domain.TaskStatusTodo, domain.TaskStatusInProgress, domain.TaskStatusCompleted, domain.TaskStatusBlocked:
+		default:
+			writeJSONError(w, http.StatusBadRequest, "Unknown status")
+			return
+		}
+
+		tasks, err := db.GetTasksByProjectID(location.ProjectID)
+		if err != nil {
+			b.dependencies.Logger.Error("Failed to load project tasks", "project_id", location.ProjectID, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to load the task")
+			return
+		}
+		projectTasks := commands.ToDomainTasks(tasks)
+		for _, task := range projectTasks {
+			if task.ID != taskID || !domain.RequiresDependencies(update.Status) {
+				continue
+			}
+			if pending := domain.PendingDependencies(task, projectTasks); len(pending) > 0 {
+				titles := make([]string, len(pending))
+				for i, dependency := range pending {
+					titles[i] = dependency.Title
+				}
+				writeJSONError(w, http.StatusConflict, "Unfinished dependencies: "+strings.Join(titles, ", "))
+				return
+			}
+		}
+
+		if err := db.UpdateTaskStatus(taskID, update.Status); err != nil {
+			b.dependencies.Logger.Error("Failed to update task status", "task_id", taskID, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to update the task")
+			return
+		}
+		b.dependencies.Logger.Info("Task status changed from web app", "task_id", taskID, "to", update.Status)
+		if update.Status == domain.TaskStatusCompleted {
+			for _, task := range projectTasks {
+				if task.ID == taskID {
+					task.Status = update.Status
+					b.dependencies.ProjectWebhooks.TaskCompleted(task)
+				}
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeJSONError writes a JSON error response
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
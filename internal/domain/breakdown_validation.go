@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Sane bounds of a single task estimate. Brief analyses return epics, which may be larger.
+const (
+	MinTaskHours = 0.5
+	MaxTaskHours = 80
+	MaxEpicHours = 400
+)
+
+// TaskCategories are the categories a breakdown task can have
+var TaskCategories = []string{"backend", "frontend", "qa", "devops"}
+
+// categoryAliases maps common AI category variants to TaskCategories
+var categoryAliases = map[string]string{
+	"back-end": "backend", "back end": "backend", "server": "backend", "api": "backend", "database": "backend",
+	"front-end": "frontend", "front end": "frontend", "ui": "frontend", "ux": "frontend", "mobile": "frontend", "design": "frontend",
+	"testing": "qa", "test": "qa", "tests": "qa", "quality": "qa", "quality assurance": "qa",
+	"ops": "devops", "infra": "devops", "infrastructure": "devops", "deployment": "devops", "ci/cd": "devops",
+}
+
+// ValidateBreakdown checks an AI breakdown before it is shown or saved. Problems that
+// have an obvious fix are corrected in place; the returned notes describe every
+// correction and every remaining problem for the user.
+func ValidateBreakdown(result *TaskBreakdownResponse, detail string) []string {
+	var notes []string
+	maxHours := float64(MaxTaskHours)
+	if detail == AnalysisDetailBrief {
+		maxHours = MaxEpicHours
+	}
+
+	ids := make(map[string]bool, len(result.Tasks))
+	for _, task := range result.Tasks {
+		ids[task.ID] = true
+	}
+
+	for i := range result.Tasks {
+		task := &result.Tasks[i]
+
+		if category, ok := normalizeCategory(task.Category); ok {
+			task.Category = category
+		} else {
+			notes = append(notes, fmt.Sprintf("%s: unknown category %q, filed under backend", task.Title, task.Category))
+			task.Category = "backend"
+		}
+
+		if task.Priority < 1 || task.Priority > 3 {
+			fixed := 2
+			if task.Priority > 3 {
+				fixed = 3
+			}
+			notes = append(notes, fmt.Sprintf("%s: priority %d out of range, set to %d", task.Title, task.Priority, fixed))
+			task.Priority = fixed
+		}
+
+		if task.EstimateHours < MinTaskHours {
+			notes = append(notes, fmt.Sprintf("%s: estimate %sh raised to %sh", task.Title, FormatHours(task.EstimateHours), FormatHours(MinTaskHours)))
+			task.EstimateHours = MinTaskHours
+		} else if task.EstimateHours > maxHours {
+			notes = append(notes, fmt.Sprintf("%s: %sh is above %sh, consider splitting it", task.Title, FormatHours(task.EstimateHours), FormatHours(maxHours)))
+		}
+
+		dependencies := task.Dependencies[:0]
+		for _, dependency := range task.Dependencies {
+			if dependency == task.ID || !ids[dependency] {
+				notes = append(notes, fmt.Sprintf("%s: removed unknown dependency %q", task.Title, dependency))
+				continue
+			}
+			dependencies = append(dependencies, dependency)
+		}
+		task.Dependencies = dependencies
+	}
+
+	criticalPath := result.CriticalPath[:0]
+	for _, id := range result.CriticalPath {
+		if ids[id] {
+			criticalPath = append(criticalPath, id)
+		}
+	}
+	result.CriticalPath = criticalPath
+
+	if len(result.Tasks) > 0 {
+		sum := 0.0
+		for _, task := range result.Tasks {
+			sum += task.EstimateHours
+		}
+		if math.Abs(result.TotalEstimate-sum) > 0.5 {
+			notes = append(notes, fmt.Sprintf("total estimate corrected from %sh to the task sum %sh", FormatHours(result.TotalEstimate), FormatHours(sum)))
+		}
+		result.TotalEstimate = sum
+	}
+
+	// Some models answer with a percentage
+	if result.Confidence > 1 && result.Confidence <= 100 {
+		result.Confidence /= 100
+	}
+	result.Confidence = math.Max(0, math.Min(1, result.Confidence))
+
+	return notes
+}
+
+// normalizeCategory maps a category to TaskCategories, reporting whether it is known
+func normalizeCategory(category string) (string, bool) {
+	category = strings.ToLower(strings.TrimSpace(category))
+	for _, known := range TaskCategories {
+		if category == known {
+			return category, true
+		}
+	}
+	alias, ok := categoryAliases[category]
+	return alias, ok
+}
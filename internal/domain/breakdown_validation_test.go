@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateBreakdown(t *testing.T) {
+	result := &TaskBreakdownResponse{
+		Tasks: []Task{
+			{ID: "t1", Title: "API", Category: "Back-End", Priority: 1, EstimateHours: 6},
+			{ID: "t2", Title: "UI", Category: "design", Priority: 7, EstimateHours: 0.1, Dependencies: []string{"t1", "t9", "t2"}},
+			{ID: "t3", Title: "Magic", Category: "research", Priority: 0, EstimateHours: 120},
+		},
+		TotalEstimate: 40,
+		CriticalPath:  []string{"t1", "t7"},
+		Confidence:    85,
+	}
+
+	notes := ValidateBreakdown(result, "")
+
+	tasks := result.Tasks
+	if tasks[0].Category != "backend" || tasks[1].Category != "frontend" || tasks[2].Category != "backend" {
+		t.Errorf("expected categories to be normalized, got %q %q %q", tasks[0].Category, tasks[1].Category, tasks[2].Category)
+	}
+	if tasks[1].Priority != 3 || tasks[2].Priority != 2 {
+		t.Errorf("expected priorities to be clamped, got %d %d", tasks[1].Priority, tasks[2].Priority)
+	}
+	if tasks[1].EstimateHours != MinTaskHours || tasks[2].EstimateHours != 120 {
+		t.Errorf("expected small estimates raised and large ones kept, got %v %v", tasks[1].EstimateHours, tasks[2].EstimateHours)
+	}
+	if len(tasks[1].Dependencies) != 1 || tasks[1].Dependencies[0] != "t1" {
+		t.Errorf("expected unknown and self dependencies removed, got %v", tasks[1].Dependencies)
+	}
+	if len(result.CriticalPath) != 1 || result.TotalEstimate != 126.5 || result.Confidence != 0.85 {
+		t.Errorf("unexpected critical path %v, total %v or confidence %v", result.CriticalPath, result.TotalEstimate, result.Confidence)
+	}
+
+	joined := strings.Join(notes, "\n")
+	for _, want := range []string{`unknown category "research"`, "priority 7 out of range, set to 3", "Magic: 120h is above 80h", `removed unknown dependency "t9"`, "corrected from 40h to the task sum 126.5h"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected a note containing %q, got:\n%s", want, joined)
+		}
+	}
+
+	// Epics of brief analyses may be larger
+	brief := &TaskBreakdownResponse{Tasks: []Task{{ID: "e1", Title: "Backend", Category: "backend", Priority: 1, EstimateHours: 120}}, TotalEstimate: 120}
+	if notes := ValidateBreakdown(brief, AnalysisDetailBrief); len(notes) != 0 {
+		t.Errorf("expected a valid brief breakdown, got %v", notes)
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Default working calendar values used when a team has not configured its own
+const (
+	DefaultHoursPerDay = 8.0
+	DefaultDaysPerWeek = 5
+)
+
+// WorkCalendar describes a team's working time used for estimates and schedule projections
+type WorkCalendar struct {
+	HoursPerDay float64     `json:"hours_per_day"`
+	DaysPerWeek int         `json:"days_per_week"` // counted from Monday: 5 = Mon-Fri, 6 = Mon-Sat
+	Holidays    []time.Time `json:"holidays"`
+}
+
+// DefaultWorkCalendar returns the standard 8h/day, Monday-Friday calendar
+func DefaultWorkCalendar() WorkCalendar {
+	return WorkCalendar{
+		HoursPerDay: DefaultHoursPerDay,
+		DaysPerWeek: DefaultDaysPerWeek,
+	}
+}
+
+// Normalize replaces invalid values with defaults
+func (c WorkCalendar) Normalize() WorkCalendar {
+	if c.HoursPerDay <= 0 || c.HoursPerDay > 24 {
+		c.HoursPerDay = DefaultHoursPerDay
+	}
+	if c.DaysPerWeek < 1 || c.DaysPerWeek > 7 {
+		c.DaysPerWeek = DefaultDaysPerWeek
+	}
+	return c
+}
+
+// WeeklyHours returns the number of working hours in a regular week
+func (c WorkCalendar) WeeklyHours() float64 {
+	c = c.Normalize()
+	return c.HoursPerDay * float64(c.DaysPerWeek)
+}
+
+// DeveloperDays converts an hour estimate into working days for a single developer
+func (c WorkCalendar) DeveloperDays(hours float64) float64 {
+	c = c.Normalize()
+	return hours / c.HoursPerDay
+}
+
+// IsHoliday checks if the given date is one of the configured holidays
+func (c WorkCalendar) IsHoliday(t time.Time) bool {
+	y, m, d := t.Date()
+	for _, h := range c.Holidays {
+		hy, hm, hd := h.Date()
+		if hy == y && hm == m && hd == d {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWorkingDay checks if the given date is a working day for the team
+func (c WorkCalendar) IsWorkingDay(t time.Time) bool {
+	c = c.Normalize()
+	// Convert Go's Sunday-based weekday into a Monday-based index (Mon=0 ... Sun=6)
+	index := (int(t.Weekday()) + 6) % 7
+	if index >= c.DaysPerWeek {
+		return false
+	}
+	return !c.IsHoliday(t)
+}
+
+// AddWorkingDays returns the date after the given number of working days starting from start.
+// Partial days are rounded up because a started day is a used day.
+func (c WorkCalendar) AddWorkingDays(start time.Time, days float64) time.Time {
+	remaining := int(math.Ceil(days))
+	current := start
+	// Guard against calendars without any working day (e.g. every day a holiday)
+	for guard := 0; remaining > 0 && guard < 3660; guard++ {
+		current = current.AddDate(0, 0, 1)
+		if c.IsWorkingDay(current) {
+			remaining--
+		}
+	}
+	return current
+}
+
+// ProjectCompletion estimates the finish date for the given amount of work split across developers
+func (c WorkCalendar) ProjectCompletion(start time.Time, hours float64, developers int) time.Time {
+	if developers < 1 {
+		developers = 1
+	}
+	return c.AddWorkingDays(start, c.DeveloperDays(hours)/float64(developers))
+}
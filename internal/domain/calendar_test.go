@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkCalendar_DeveloperDays(t *testing.T) {
+	tests := []struct {
+		name     string
+		calendar WorkCalendar
+		hours    float64
+		expected float64
+	}{
+		{"Default 8h day", DefaultWorkCalendar(), 40, 5},
+		{"Six hour day", WorkCalendar{HoursPerDay: 6, DaysPerWeek: 5}, 30, 5},
+		{"Invalid hours fall back to default", WorkCalendar{HoursPerDay: 0, DaysPerWeek: 5}, 16, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := test.calendar.DeveloperDays(test.hours)
+			if result != test.expected {
+				t.Errorf("Expected %.1f days, got %.1f", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestWorkCalendar_IsWorkingDay(t *testing.T) {
+	calendar := WorkCalendar{
+		HoursPerDay: 8,
+		DaysPerWeek: 5,
+		Holidays:    []time.Time{time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		expected bool
+	}{
+		{"Monday", time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC), true},
+		{"Holiday Friday", time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC), false},
+		{"Saturday", time.Date(2026, 3, 21, 10, 0, 0, 0, time.UTC), false},
+		{"Sunday", time.Date(2026, 3, 22, 10, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := calendar.IsWorkingDay(test.date); result != test.expected {
+				t.Errorf("IsWorkingDay(%s): expected %v, got %v", test.date.Format("2006-01-02"), test.expected, result)
+			}
+		})
+	}
+}
+
+func TestWorkCalendar_AddWorkingDays(t *testing.T) {
+	friday := time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC)
+
+	fiveDay := DefaultWorkCalendar()
+	if result := fiveDay.AddWorkingDays(friday, 1); result.Weekday() != time.Monday {
+		t.Errorf("Expected Monday after skipping the weekend, got %s", result.Weekday())
+	}
+
+	sixDay := WorkCalendar{HoursPerDay: 8, DaysPerWeek: 6}
+	if result := sixDay.AddWorkingDays(friday, 1); result.Weekday() != time.Saturday {
+		t.Errorf("Expected Saturday for a six-day week, got %s", result.Weekday())
+	}
+
+	withHoliday := WorkCalendar{
+		HoursPerDay: 8,
+		DaysPerWeek: 5,
+		Holidays:    []time.Time{time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)},
+	}
+	if result := withHoliday.AddWorkingDays(friday, 1); result.Day() != 17 {
+		t.Errorf("Expected holiday Monday to be skipped, got %s", result.Format("2006-01-02"))
+	}
+}
+
+func TestWorkCalendar_ProjectCompletion(t *testing.T) {
+	monday := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	calendar := DefaultWorkCalendar()
+
+	// 80 hours split between two developers is 5 working days
+	result := calendar.ProjectCompletion(monday, 80, 2)
+	expected := time.Date(2026, 3, 23, 9, 0, 0, 0, time.UTC)
+	if !result.Equal(expected) {
+		t.Errorf("Expected %s, got %s", expected.Format("2006-01-02"), result.Format("2006-01-02"))
+	}
+}
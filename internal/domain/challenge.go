@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ChallengeDayLayout formats the day a daily challenge belongs to
+const ChallengeDayLayout = "2006-01-02"
+
+// Challenge is a small Go exercise whose program prints a single answer, so submissions can
+// be checked without running anyone's code
+type Challenge struct {
+	ID         string
+	Title      string
+	Difficulty string
+	// Prompt is the Markdown task description
+	Prompt string
+	// Answer is the expected output; CheckAnswer ignores case and spacing
+	Answer string
+}
+
+// CheckAnswer reports whether a submitted answer matches the challenge's
+func (c Challenge) CheckAnswer(answer string) bool {
+	return normalizeChallengeAnswer(answer) == normalizeChallengeAnswer(c.Answer)
+}
+
+// normalizeChallengeAnswer drops spaces, backticks and case so "4, 5,6" matches "4,5,6"
+func normalizeChallengeAnswer(answer string) string {
+	return strings.ToLower(strings.Map(func(r rune) rune {
+		if r == ' ' || r == '`' || r == '\t' || r == '\n' {
+			return -1
+		}
+		return r
+	}, answer))
+}
+
+// ChallengeDay returns the day key of a time, in the time's location
+func ChallengeDay(t time.Time) string {
+	return t.Format(ChallengeDayLayout)
+}
+
+// DailyChallenge returns the challenge of the day: every chat gets the same one, and the
+// bank is gone through in order before it repeats
+func DailyChallenge(bank []Challenge, t time.Time) Challenge {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	index := int(day.Unix()/int64(24*time.Hour/time.Second)) % len(bank)
+	return bank[index]
+}
+
+// ChallengeStreak returns the current and the longest run of consecutive days with a solved
+// challenge. days are day keys in any order; the current streak still counts when today is
+// not solved yet but yesterday was.
+func ChallengeStreak(days []string, today time.Time) (int, int) {
+	solved := make(map[string]bool, len(days))
+	for _, day := range days {
+		solved[day] = true
+	}
+
+	best := 0
+	for day := range solved {
+		start, err := time.Parse(ChallengeDayLayout, day)
+		if err != nil || solved[ChallengeDay(start.AddDate(0, 0, -1))] {
+			continue
+		}
+		length := 0
+		for solved[ChallengeDay(start.AddDate(0, 0, length))] {
+			length++
+		}
+		if length > best {
+			best = length
+		}
+	}
+
+	current := 0
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if !solved[ChallengeDay(day)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for solved[ChallengeDay(day)] {
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+	return current, best
+}
@@ -0,0 +1,99 @@
+package domain
+
+// ChallengeBank is the built-in set of daily challenges, picked in order by DailyChallenge.
+// Add new challenges at the end so the order of the current cycle stays the same.
+var ChallengeBank = []Challenge{
+	{
+		ID:         "multiples",
+		Title:      "Multiples of 3 or 7",
+		Difficulty: "easy",
+		Prompt:     "Write a Go program that prints the sum of all natural numbers below `10000` that are divisible by 3 or by 7.",
+		Answer:     "21426429",
+	},
+	{
+		ID:         "collatz",
+		Title:      "Longest Collatz Chain",
+		Difficulty: "medium",
+		Prompt: "The Collatz sequence halves even numbers and turns odd `n` into `3n+1` until it reaches 1.\n" +
+			"Which starting number below `100000` produces the longest chain? Memoize with a `map[int]int` " +
+			"or a slice to keep it fast.",
+		Answer: "77031",
+	},
+	{
+		ID:         "runes",
+		Title:      "Bytes and Runes",
+		Difficulty: "easy",
+		Prompt: "For `s := \"héllo, 世界\"`, what do `len(s)` and `utf8.RuneCountInString(s)` return?\n" +
+			"Answer both, separated by a comma.",
+		Answer: "14,9",
+	},
+	{
+		ID:         "fan_in",
+		Title:      "Fan-In Workers",
+		Difficulty: "medium",
+		Prompt: "Start 4 goroutines that each square a quarter of the numbers `1..1000` and send their partial " +
+			"sums on one channel. Print the total once all workers are done. Use a `sync.WaitGroup` to close " +
+			"the channel.",
+		Answer: "333833500",
+	},
+	{
+		ID:         "palindromes",
+		Title:      "Palindromic Numbers",
+		Difficulty: "easy",
+		Prompt:     "How many numbers from `1` to `100000` read the same forwards and backwards? Try it without converting to strings.",
+		Answer:     "1098",
+	},
+	{
+		ID:         "fibonacci",
+		Title:      "Fibonacci in int64",
+		Difficulty: "easy",
+		Prompt: "With `F(0) = 0` and `F(1) = 1`, print `F(90)`. It still fits in an `int64`; " +
+			"check where `uint64` would be needed.",
+		Answer: "2880067194370816120",
+	},
+	{
+		ID:         "primes",
+		Title:      "Sieve of Eratosthenes",
+		Difficulty: "medium",
+		Prompt:     "How many primes are there below `1000000`? A `[]bool` sieve finishes in milliseconds.",
+		Answer:     "78498",
+	},
+	{
+		ID:         "big_digits",
+		Title:      "Digits of 2^100",
+		Difficulty: "easy",
+		Prompt:     "Use `math/big` to compute `2^100` and print the sum of its decimal digits.",
+		Answer:     "115",
+	},
+	{
+		ID:         "anagrams",
+		Title:      "Anagram Groups",
+		Difficulty: "easy",
+		Prompt: "Group these words by anagram, using the sorted letters as a `map` key:\n" +
+			"`listen silent enlist google gogole cat act tac dog`\n" +
+			"How many groups are there?",
+		Answer: "4",
+	},
+	{
+		ID:         "brackets",
+		Title:      "Balanced Brackets",
+		Difficulty: "medium",
+		Prompt: "Write `func balanced(s string) bool` with a slice as a stack. How many of these are balanced?\n" +
+			"`()[]{}`, `([)]`, `{[()()]}`, `((`, `[{}]()`, `}{`",
+		Answer: "3",
+	},
+	{
+		ID:         "lcm",
+		Title:      "Smallest Common Multiple",
+		Difficulty: "medium",
+		Prompt:     "Print the smallest positive number evenly divisible by every number from `1` to `20`. Build it from a `gcd` function.",
+		Answer:     "232792560",
+	},
+	{
+		ID:         "bits",
+		Title:      "Counting Bits",
+		Difficulty: "easy",
+		Prompt:     "What is the total number of set bits in all numbers from `0` to `1023`? Compare a loop with `bits.OnesCount`.",
+		Answer:     "5120",
+	},
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChallengeCheckAnswer(t *testing.T) {
+	challenge := Challenge{Answer: "14,9"}
+	for _, answer := range []string{"14,9", " 14, 9 ", "`14,9`"} {
+		if !challenge.CheckAnswer(answer) {
+			t.Errorf("expected %q to be accepted", answer)
+		}
+	}
+	if challenge.CheckAnswer("9,14") {
+		t.Error("expected a wrong answer to be rejected")
+	}
+}
+
+func TestDailyChallenge(t *testing.T) {
+	day := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	first := DailyChallenge(ChallengeBank, day)
+	if again := DailyChallenge(ChallengeBank, day.Add(10*time.Hour)); again.ID != first.ID {
+		t.Errorf("expected the same challenge all day, got %s and %s", first.ID, again.ID)
+	}
+	if next := DailyChallenge(ChallengeBank, day.AddDate(0, 0, 1)); next.ID == first.ID {
+		t.Errorf("expected a new challenge the next day, got %s again", next.ID)
+	}
+
+	ids := make(map[string]bool)
+	for _, challenge := range ChallengeBank {
+		if challenge.Answer == "" || ids[challenge.ID] {
+			t.Errorf("challenge %q needs a unique ID and an answer", challenge.ID)
+		}
+		ids[challenge.ID] = true
+	}
+}
+
+func TestChallengeStreak(t *testing.T) {
+	today := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	days := []string{"2026-10-15", "2026-10-14", "2026-10-13", "2026-10-01", "2026-10-02", "2026-10-03", "2026-10-04"}
+
+	current, best := ChallengeStreak(days, today)
+	if current != 3 || best != 4 {
+		t.Errorf("expected current 3 and best 4, got %d and %d", current, best)
+	}
+
+	current, _ = ChallengeStreak(append(days, "2026-10-16"), today)
+	if current != 4 {
+		t.Errorf("expected solving today to extend the streak, got %d", current)
+	}
+
+	current, best = ChallengeStreak([]string{"2026-10-10"}, today)
+	if current != 0 || best != 1 {
+		t.Errorf("expected a broken streak, got %d and %d", current, best)
+	}
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -12,9 +13,23 @@ type Command struct {
 	User      *User
 	Chat      *Chat
 	Timestamp time.Time
+	// MessageID is the Telegram message the command came from, 0 for commands without one
+	MessageID int
+	// ReplyToText is the text of the message this command replies to, if any
+	ReplyToText string
+	// ReplyToDocument is the file of the message this command replies to, if any
+	ReplyToDocument *TelegramDocument
 	// File attachments
 	Document *TelegramDocument `json:"document,omitempty"`
 	Photo    []TelegramPhoto   `json:"photo,omitempty"`
+	// Documents holds every file of an album, in order; Document is one of them
+	Documents []TelegramDocument `json:"documents,omitempty"`
+	// Voice is a voice message or audio file whose speech is the requirement
+	Voice *TelegramVoice `json:"voice,omitempty"`
+	// ReplyToVoice is the voice message or audio file this command replies to, if any
+	ReplyToVoice *TelegramVoice
+	// Callback is set when the command comes from an inline keyboard button; Text holds its data
+	Callback *CallbackQuery `json:"callback,omitempty"`
 }
 
 // Response represents a bot response
@@ -23,6 +38,22 @@ type Response struct {
 	ParseMode      string
 	ReplyMarkup    interface{}
 	DisablePreview bool
+	// Document, when set, is uploaded as a file with Text as its caption
+	Document *OutgoingDocument
+	// Shared, when set, is also posted to another chat, e.g. a result shared from a private chat
+	Shared *SharedMessage
+	// EditMessageID, when set, is a placeholder the response replaces instead of sending a new message
+	EditMessageID int
+	// ReplyToMessageID, when set, threads the response as a reply to that message. In groups
+	// a command's response replies to the command unless the handler sets another message.
+	ReplyToMessageID int
+}
+
+// SharedMessage is a message a response posts to a chat other than the command's
+type SharedMessage struct {
+	ChatID    int64
+	Text      string
+	ParseMode string
 }
 
 // CommandHandler defines the interface for command handling
@@ -33,6 +64,19 @@ type CommandHandler interface {
 	Usage() string
 }
 
+// TimeoutHandler is implemented by handlers that need a different time limit than
+// the router's default for their command
+type TimeoutHandler interface {
+	Timeout() time.Duration
+}
+
+// Categorized is implemented by every command handler to name the /chat_settings category
+// of its commands. Chats cannot turn off handlers returning an empty category, such as
+// /help and /chat_settings itself.
+type Categorized interface {
+	Category() string
+}
+
 // Middleware defines the interface for processing pipeline
 type Middleware interface {
 	Process(ctx context.Context, next HandlerFunc) HandlerFunc
@@ -40,9 +84,71 @@ type Middleware interface {
 
 type HandlerFunc func(ctx context.Context, cmd *Command) (*Response, error)
 
+// privateArgumentCommands are commands whose arguments must not be logged or stored, such
+// as the answers of the anonymous mood check-in
+var privateArgumentCommands = map[string]bool{
+	"/mood": true,
+}
+
+// LogText returns command text that is safe to log or store: the arguments of private
+// commands are replaced
+func LogText(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) > 1 && privateArgumentCommands[strings.ToLower(fields[0])] {
+		return fields[0] + " [redacted]"
+	}
+	return text
+}
+
+// Chat actions shown to users while the bot is working
+const (
+	ChatActionTyping         = "typing"
+	ChatActionUploadDocument = "upload_document"
+	ChatActionUploadPhoto    = "upload_photo"
+)
+
+// LongRunningHandler is implemented by handlers with slow commands, such as AI analyses and
+// file uploads. The router shows the returned chat action from the start; an empty action
+// leaves quick subcommands to the default "typing" after a delay.
+type LongRunningHandler interface {
+	ChatAction(cmd *Command) string
+}
+
+// ChatActionSender shows a chat action such as "typing" in a chat
+type ChatActionSender interface {
+	SendChatAction(ctx context.Context, chatID int64, action string) error
+}
+
+// Responder posts a message a handler can edit later, so a long command can show
+// "⏳ Analyzing..." at once and replace it with the result
+type Responder interface {
+	SendPlaceholder(ctx context.Context, chatID int64, text string) (int, error)
+	EditMessage(ctx context.Context, chatID int64, messageID int, text, parseMode string, replyMarkup interface{}) error
+}
+
+// ResponderAware is implemented by handlers that show progress through a Responder. The
+// bot injects itself once it is created, since handlers are built before it.
+type ResponderAware interface {
+	SetResponder(responder Responder)
+}
+
+// Command menu scopes returned by MenuScoped handlers
+const (
+	MenuScopePrivate = "private"
+	MenuScopeGroup   = "group"
+	MenuScopeHidden  = "hidden"
+)
+
+// MenuScoped is implemented by handlers whose commands only work in private or group chats,
+// or are meant for operators and stay out of the Telegram command menu
+type MenuScoped interface {
+	MenuScope() string
+}
+
 // Router manages command routing and middleware
 type Router interface {
 	RegisterHandler(handler CommandHandler)
+	RegisterCallbackHandler(handler CallbackHandler)
 	RegisterMiddleware(middleware Middleware)
 	Route(ctx context.Context, cmd *Command) (*Response, error)
 	GetHandlers() []CommandHandler
@@ -69,6 +175,32 @@ type TelegramDocument struct {
 	Thumbnail    *TelegramPhotoSize `json:"thumb,omitempty"`
 }
 
+// TelegramVoice represents a voice message or audio file sent via Telegram
+type TelegramVoice struct {
+	FileID       string `json:"file_id"`
+	FileUniqueID string `json:"file_unique_id"`
+	Duration     int    `json:"duration"`
+	MimeType     string `json:"mime_type,omitempty"`
+	FileSize     int    `json:"file_size,omitempty"`
+	// FileName is only sent for audio files
+	FileName string `json:"file_name,omitempty"`
+}
+
+// Document describes the audio as a file to download, naming voice messages voice.ogg
+func (v *TelegramVoice) Document() *TelegramDocument {
+	name := v.FileName
+	if name == "" {
+		name = "voice.ogg"
+	}
+	return &TelegramDocument{
+		FileID:       v.FileID,
+		FileUniqueID: v.FileUniqueID,
+		FileName:     name,
+		MimeType:     v.MimeType,
+		FileSize:     v.FileSize,
+	}
+}
+
 // TelegramPhoto represents a photo sent via Telegram
 type TelegramPhoto struct {
 	FileID       string `json:"file_id"`
@@ -78,6 +210,24 @@ type TelegramPhoto struct {
 	FileSize     int    `json:"file_size,omitempty"`
 }
 
+// PhotoDocument describes the largest size of a photo as a JPEG file, so photos can be
+// downloaded and read like documents
+func PhotoDocument(sizes []TelegramPhoto, fileName string) TelegramDocument {
+	largest := sizes[0]
+	for _, size := range sizes[1:] {
+		if size.Width*size.Height > largest.Width*largest.Height {
+			largest = size
+		}
+	}
+	return TelegramDocument{
+		FileID:       largest.FileID,
+		FileUniqueID: largest.FileUniqueID,
+		FileName:     fileName,
+		MimeType:     "image/jpeg",
+		FileSize:     largest.FileSize,
+	}
+}
+
 // TelegramPhotoSize represents different sizes of photos/thumbnails
 type TelegramPhotoSize struct {
 	FileID       string `json:"file_id"`
@@ -93,4 +243,60 @@ type TelegramFile struct {
 	FileUniqueID string `json:"file_unique_id"`
 	FileSize     int    `json:"file_size,omitempty"`
 	FilePath     string `json:"file_path,omitempty"`
-}
\ No newline at end of file
+}
+
+// InlineKeyboardMarkup is an inline keyboard attached to a response via ReplyMarkup
+type InlineKeyboardMarkup struct {
+	InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
+}
+
+// InlineKeyboardButton is a button whose callback data is routed as a command, e.g. "/task 42 done",
+// or that opens a Mini App
+type InlineKeyboardButton struct {
+	Text         string      `json:"text"`
+	CallbackData string      `json:"callback_data,omitempty"`
+	WebApp       *WebAppInfo `json:"web_app,omitempty"`
+}
+
+// WebAppInfo describes a Telegram Mini App opened by a button
+type WebAppInfo struct {
+	URL string `json:"url"`
+}
+
+// ReplyKeyboardMarkup is a persistent custom keyboard shown in place of the phone keyboard
+type ReplyKeyboardMarkup struct {
+	Keyboard       [][]KeyboardButton `json:"keyboard"`
+	ResizeKeyboard bool               `json:"resize_keyboard,omitempty"`
+	IsPersistent   bool               `json:"is_persistent,omitempty"`
+}
+
+// KeyboardButton is a reply keyboard button; pressing it sends its text as a message
+type KeyboardButton struct {
+	Text string `json:"text"`
+}
+
+// ReplyKeyboardRemove hides a reply keyboard previously sent to the chat
+type ReplyKeyboardRemove struct {
+	RemoveKeyboard bool `json:"remove_keyboard"`
+}
+
+// ForceReply opens a reply to the message in the user's client. Answers sent as replies
+// reach the bot in groups where it otherwise only sees commands.
+type ForceReply struct {
+	ForceReply            bool   `json:"force_reply"`
+	InputFieldPlaceholder string `json:"input_field_placeholder,omitempty"`
+}
+
+// OutgoingDocument is a file the bot uploads to the chat
+type OutgoingDocument struct {
+	FileName string
+	Content  []byte
+	// ContentType is the MIME type such as text/csv or application/pdf; when empty it is
+	// derived from the file name
+	ContentType string
+	// Photo uploads an image with sendPhoto so it is shown inline instead of as a file
+	Photo bool
+	// Report marks a generated report or export; it is also stored and linked in the caption
+	// so it can be shared outside Telegram
+	Report bool
+}
@@ -9,6 +9,8 @@ const (
 	UserContextKey    contextKey = "user"
 	CommandContextKey contextKey = "command"
 	LoggerContextKey  contextKey = "logger"
+	// ChatActionContextKey holds the chat action a long-running handler declared
+	ChatActionContextKey contextKey = "chat_action"
 )
 
 // GetUserFromContext extracts user from context
@@ -42,4 +44,14 @@ func GetLoggerFromContext(ctx context.Context) (Logger, bool) {
 // WithLogger adds logger to context
 func WithLogger(ctx context.Context, logger Logger) context.Context {
 	return context.WithValue(ctx, LoggerContextKey, logger)
-}
\ No newline at end of file
+}
+// GetChatActionFromContext extracts the declared chat action from context
+func GetChatActionFromContext(ctx context.Context) (string, bool) {
+	action, ok := ctx.Value(ChatActionContextKey).(string)
+	return action, ok && action != ""
+}
+
+// WithChatAction adds a declared chat action to context
+func WithChatAction(ctx context.Context, action string) context.Context {
+	return context.WithValue(ctx, ChatActionContextKey, action)
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diagram source languages understood by the diagram renderer
+const (
+	DiagramMermaid  = "mermaid"
+	DiagramPlantUML = "plantuml"
+)
+
+// maxDiagramLabel keeps node labels short enough to lay out readably
+const maxDiagramLabel = 40
+
+// TaskDependencyMermaid describes the dependency graph of a project's tasks as a Mermaid
+// flowchart. Nodes are colored by status; dependencies outside the project are skipped.
+func TaskDependencyMermaid(tasks []Task) string {
+	nodes := make(map[string]string, len(tasks))
+	for i, task := range tasks {
+		nodes[task.ID] = fmt.Sprintf("t%d", i)
+	}
+
+	var diagram strings.Builder
+	diagram.WriteString("graph TD\n")
+	for _, task := range tasks {
+		diagram.WriteString(fmt.Sprintf("    %s[\"%s\"]:::%s\n", nodes[task.ID], diagramLabel(task.Title), diagramStatusClass(task.Status)))
+	}
+	for _, task := range tasks {
+		for _, dependency := range task.Dependencies {
+			if node, ok := nodes[dependency]; ok {
+				diagram.WriteString(fmt.Sprintf("    %s --> %s\n", node, nodes[task.ID]))
+			}
+		}
+	}
+
+	diagram.WriteString("    classDef todo fill:#eeeeee,stroke:#999999\n")
+	diagram.WriteString("    classDef in_progress fill:#fff3c4,stroke:#e0a800\n")
+	diagram.WriteString("    classDef completed fill:#d4f5d4,stroke:#2e9e2e\n")
+	diagram.WriteString("    classDef blocked fill:#f9d0d0,stroke:#cc3333\n")
+	return diagram.String()
+}
+
+// diagramStatusClass maps a task status to its Mermaid class
+func diagramStatusClass(status string) string {
+	switch status {
+	case TaskStatusInProgress, TaskStatusCompleted, TaskStatusBlocked:
+		return status
+	default:
+		return TaskStatusTodo
+	}
+}
+
+// diagramLabel shortens a title and removes characters that break Mermaid labels
+func diagramLabel(title string) string {
+	label := strings.NewReplacer(`"`, "'", "[", "(", "]", ")", "\n", " ").Replace(strings.TrimSpace(title))
+	if runes := []rune(label); len(runes) > maxDiagramLabel {
+		label = string(runes[:maxDiagramLabel-1]) + "…"
+	}
+	return label
+}
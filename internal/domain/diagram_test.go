@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaskDependencyMermaid(t *testing.T) {
+	diagram := TaskDependencyMermaid([]Task{
+		{ID: "a", Title: `Design "auth" [API]`, Status: TaskStatusCompleted},
+		{ID: "b", Title: "Implement login", Status: TaskStatusInProgress, Dependencies: []string{"a", "missing"}},
+		{ID: "c", Title: strings.Repeat("x", 60), Dependencies: []string{"b"}},
+	})
+
+	for _, want := range []string{
+		"graph TD\n",
+		`t0["Design 'auth' (API)"]:::completed`,
+		`t1["Implement login"]:::in_progress`,
+		":::todo",
+		"t0 --> t1\n",
+		"t1 --> t2\n",
+	} {
+		if !strings.Contains(diagram, want) {
+			t.Errorf("expected diagram to contain %q, got:\n%s", want, diagram)
+		}
+	}
+	if strings.Count(diagram, "-->") != 2 {
+		t.Errorf("expected dependencies outside the project to be skipped, got:\n%s", diagram)
+	}
+	if strings.Contains(diagram, strings.Repeat("x", 41)) {
+		t.Errorf("expected long titles to be shortened, got:\n%s", diagram)
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// Escalation levels a stale task moves through
+const (
+	EscalationNone      = 0
+	EscalationReminded  = 1
+	EscalationEscalated = 2
+)
+
+// EscalationRule controls reminders for assigned tasks that stay in todo
+type EscalationRule struct {
+	Enabled       bool
+	RemindAfter   time.Duration // time in todo before the assignee is reminded
+	EscalateAfter time.Duration // time after the reminder before the lead is notified
+	MaxPriority   int           // tasks with priority up to this value are covered (1 = high)
+	Lead          string        // username notified on escalation; empty uses members with the lead role
+}
+
+// DefaultEscalationRule returns a disabled rule covering high-priority tasks
+func DefaultEscalationRule() EscalationRule {
+	return EscalationRule{
+		Enabled:       false,
+		RemindAfter:   24 * time.Hour,
+		EscalateAfter: 24 * time.Hour,
+		MaxPriority:   1,
+	}
+}
+
+// Covers reports whether a task with the given priority is subject to the rule
+func (r EscalationRule) Covers(priority int) bool {
+	return r.Enabled && priority >= 1 && priority <= r.MaxPriority
+}
+
+// NextLevel returns the level a task should move to, or the current level when nothing is due.
+// todoSince is when the task entered todo and notifiedAt when the last notification was sent.
+func (r EscalationRule) NextLevel(level int, todoSince, notifiedAt, now time.Time) int {
+	switch level {
+	case EscalationNone:
+		if now.Sub(todoSince) >= r.RemindAfter {
+			return EscalationReminded
+		}
+	case EscalationReminded:
+		if now.Sub(notifiedAt) >= r.EscalateAfter {
+			return EscalationEscalated
+		}
+	}
+	return level
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEscalationRule_NextLevel(t *testing.T) {
+	rule := DefaultEscalationRule()
+	rule.Enabled = true
+
+	todoSince := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		level      int
+		notifiedAt time.Time
+		now        time.Time
+		expected   int
+	}{
+		{"fresh task", EscalationNone, time.Time{}, todoSince.Add(2 * time.Hour), EscalationNone},
+		{"remind due", EscalationNone, time.Time{}, todoSince.Add(25 * time.Hour), EscalationReminded},
+		{"reminded recently", EscalationReminded, todoSince.Add(25 * time.Hour), todoSince.Add(30 * time.Hour), EscalationReminded},
+		{"escalation due", EscalationReminded, todoSince.Add(25 * time.Hour), todoSince.Add(49 * time.Hour), EscalationEscalated},
+		{"already escalated", EscalationEscalated, todoSince.Add(49 * time.Hour), todoSince.Add(200 * time.Hour), EscalationEscalated},
+	}
+
+	for _, test := range tests {
+		if result := rule.NextLevel(test.level, todoSince, test.notifiedAt, test.now); result != test.expected {
+			t.Errorf("%s: expected level %d, got %d", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestEscalationRule_Covers(t *testing.T) {
+	rule := DefaultEscalationRule()
+	if rule.Covers(1) {
+		t.Error("Disabled rule should not cover any task")
+	}
+
+	rule.Enabled = true
+	rule.MaxPriority = 2
+	if !rule.Covers(1) || !rule.Covers(2) || rule.Covers(3) {
+		t.Error("Rule with max priority 2 should cover priorities 1 and 2 only")
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Spread around the most likely estimate for tasks without optimistic/pessimistic bounds
+const (
+	defaultOptimisticFactor  = 0.75
+	defaultPessimisticFactor = 1.5
+)
+
+// EstimateRange is a three-point estimate in hours
+type EstimateRange struct {
+	Optimistic  float64
+	Likely      float64
+	Pessimistic float64
+}
+
+// TaskEstimateRange returns the range of a task. EstimateHours is the most likely value;
+// missing or inconsistent bounds are replaced by the default spread around it.
+func TaskEstimateRange(task Task) EstimateRange {
+	r := EstimateRange{
+		Optimistic:  task.OptimisticHours,
+		Likely:      task.EstimateHours,
+		Pessimistic: task.PessimisticHours,
+	}
+	if r.Optimistic <= 0 || r.Optimistic > r.Likely {
+		r.Optimistic = r.Likely * defaultOptimisticFactor
+	}
+	if r.Pessimistic < r.Likely || r.Pessimistic == 0 {
+		r.Pessimistic = r.Likely * defaultPessimisticFactor
+	}
+	return r
+}
+
+// TotalEstimateRange sums the ranges of the tasks
+func TotalEstimateRange(tasks []Task) EstimateRange {
+	var total EstimateRange
+	for _, task := range tasks {
+		total = total.Add(TaskEstimateRange(task))
+	}
+	return total
+}
+
+// Add returns the sum of two ranges
+func (r EstimateRange) Add(other EstimateRange) EstimateRange {
+	return EstimateRange{
+		Optimistic:  r.Optimistic + other.Optimistic,
+		Likely:      r.Likely + other.Likely,
+		Pessimistic: r.Pessimistic + other.Pessimistic,
+	}
+}
+
+// String formats the range as "12–18h (most likely 14h)"
+func (r EstimateRange) String() string {
+	low, high, likely := FormatHours(r.Optimistic), FormatHours(r.Pessimistic), FormatHours(r.Likely)
+	if low == high {
+		return likely + "h"
+	}
+	return fmt.Sprintf("%s–%sh (most likely %sh)", low, high, likely)
+}
+
+// FormatHours formats hours with at most one decimal, dropping a trailing ".0"
+func FormatHours(hours float64) string {
+	return strconv.FormatFloat(math.Round(hours*10)/10, 'f', -1, 64)
+}
+
+// CompletionRange returns the earliest, most likely and latest finish dates for the range
+func (c WorkCalendar) CompletionRange(start time.Time, r EstimateRange, developers int) (time.Time, time.Time, time.Time) {
+	return c.ProjectCompletion(start, r.Optimistic, developers),
+		c.ProjectCompletion(start, r.Likely, developers),
+		c.ProjectCompletion(start, r.Pessimistic, developers)
+}
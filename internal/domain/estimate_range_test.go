@@ -0,0 +1,64 @@
+package domain
+
+import "testing"
+
+func TestTaskEstimateRange(t *testing.T) {
+	tests := []struct {
+		task     Task
+		expected EstimateRange
+	}{
+		{Task{EstimateHours: 6, OptimisticHours: 4, PessimisticHours: 10}, EstimateRange{4, 6, 10}},
+		{Task{EstimateHours: 8}, EstimateRange{6, 8, 12}},
+		{Task{EstimateHours: 8, OptimisticHours: 9, PessimisticHours: 7}, EstimateRange{6, 8, 12}},
+		{Task{}, EstimateRange{}},
+	}
+
+	for _, test := range tests {
+		if result := TaskEstimateRange(test.task); result != test.expected {
+			t.Errorf("TaskEstimateRange(%+v): expected %+v, got %+v", test.task, test.expected, result)
+		}
+	}
+}
+
+func TestTotalEstimateRange(t *testing.T) {
+	total := TotalEstimateRange([]Task{
+		{EstimateHours: 6, OptimisticHours: 4, PessimisticHours: 10},
+		{EstimateHours: 8},
+	})
+
+	if total != (EstimateRange{10, 14, 22}) {
+		t.Errorf("Expected 10-14-22, got %+v", total)
+	}
+	if total.String() != "10–22h (most likely 14h)" {
+		t.Errorf("Unexpected format: %s", total.String())
+	}
+}
+
+func TestEstimateRange_String(t *testing.T) {
+	tests := []struct {
+		r        EstimateRange
+		expected string
+	}{
+		{EstimateRange{12, 14, 18}, "12–18h (most likely 14h)"},
+		{EstimateRange{3.75, 4.5, 6.75}, "3.8–6.8h (most likely 4.5h)"},
+		{EstimateRange{2, 2, 2}, "2h"},
+	}
+
+	for _, test := range tests {
+		if result := test.r.String(); result != test.expected {
+			t.Errorf("String(%+v): expected %q, got %q", test.r, test.expected, result)
+		}
+	}
+}
+
+func TestEstimateSettings_FormatRange(t *testing.T) {
+	points := EstimateSettings{Unit: EstimateUnitPoints, Scale: DefaultPointScale()}
+	if result := points.FormatRange(EstimateRange{6, 8, 12}); result != "3–5 pts (most likely 3 pts)" {
+		t.Errorf("Unexpected points range: %s", result)
+	}
+
+	hours := EstimateSettings{Unit: EstimateUnitHours}
+	if result := hours.FormatRange(EstimateRange{6, 8, 12}); result != "6–12h (most likely 8h)" {
+		t.Errorf("Unexpected hours range: %s", result)
+	}
+}
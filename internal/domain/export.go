@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExportSchemaVersion is the version of the JSON export format; bump it on incompatible changes
+const ExportSchemaVersion = 1
+
+// Kinds of JSON exports
+const (
+	ExportKindProject  = "project"
+	ExportKindAnalysis = "analysis"
+)
+
+// Export is a machine-readable snapshot of a project with its tasks, or of an analysis
+type Export struct {
+	SchemaVersion int                    `json:"schema_version"`
+	Kind          string                 `json:"kind"`
+	ExportedAt    time.Time              `json:"exported_at"`
+	Project       *Project               `json:"project,omitempty"`
+	Tasks         []Task                 `json:"tasks,omitempty"`
+	Requirement   string                 `json:"requirement,omitempty"`
+	Analysis      *TaskBreakdownResponse `json:"analysis,omitempty"`
+}
+
+// MaxImportTasks limits how many tasks a project import may create
+const MaxImportTasks = 500
+
+// ParseProjectExport decodes a JSON project export and checks that it can be imported
+func ParseProjectExport(data []byte) (*Export, error) {
+	var export Export
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("not a valid JSON export: %w", err)
+	}
+
+	switch {
+	case export.SchemaVersion < 1:
+		return nil, fmt.Errorf("schema_version is missing")
+	case export.SchemaVersion > ExportSchemaVersion:
+		return nil, fmt.Errorf("schema version %d is newer than the supported version %d", export.SchemaVersion, ExportSchemaVersion)
+	case export.Kind != ExportKindProject:
+		return nil, fmt.Errorf("only project exports can be imported, got %q", export.Kind)
+	case export.Project == nil || strings.TrimSpace(export.Project.Name) == "":
+		return nil, fmt.Errorf("project name is missing")
+	case len(export.Tasks) > MaxImportTasks:
+		return nil, fmt.Errorf("too many tasks: %d (limit %d)", len(export.Tasks), MaxImportTasks)
+	}
+
+	ids := make(map[string]bool, len(export.Tasks))
+	for i, task := range export.Tasks {
+		if strings.TrimSpace(task.Title) == "" {
+			return nil, fmt.Errorf("task %d has no title", i+1)
+		}
+		if task.ID != "" {
+			if ids[task.ID] {
+				return nil, fmt.Errorf("duplicate task id %q", task.ID)
+			}
+			ids[task.ID] = true
+		}
+		switch task.Status {
+		case TaskStatusTodo, TaskStatusInProgress, TaskStatusCompleted, TaskStatusBlocked:
+		default:
+			return nil, fmt.Errorf("task %q has unknown status %q", task.Title, task.Status)
+		}
+	}
+
+	return &export, nil
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProjectExport(t *testing.T) {
+	valid := `{"schema_version": 1, "kind": "project", "project": {"id": "proj_1", "name": "Shop"},
+		"tasks": [{"id": "t1", "title": "Cart API", "status": "todo"}, {"id": "t2", "title": "Cart page", "status": "completed", "dependencies": ["t1"]}]}`
+
+	export, err := ParseProjectExport([]byte(valid))
+	if err != nil {
+		t.Fatalf("expected valid export, got %v", err)
+	}
+	if export.Project.Name != "Shop" || len(export.Tasks) != 2 {
+		t.Errorf("unexpected export: %+v", export)
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"not json", `tasks`, "not a valid JSON export"},
+		{"missing version", `{"kind": "project", "project": {"name": "Shop"}}`, "schema_version is missing"},
+		{"newer version", `{"schema_version": 99, "kind": "project", "project": {"name": "Shop"}}`, "newer than the supported version"},
+		{"analysis", `{"schema_version": 1, "kind": "analysis", "analysis": {}}`, "only project exports"},
+		{"no project", `{"schema_version": 1, "kind": "project"}`, "project name is missing"},
+		{"untitled task", `{"schema_version": 1, "kind": "project", "project": {"name": "Shop"}, "tasks": [{"status": "todo"}]}`, "task 1 has no title"},
+		{"duplicate id", `{"schema_version": 1, "kind": "project", "project": {"name": "Shop"},
+			"tasks": [{"id": "t1", "title": "A", "status": "todo"}, {"id": "t1", "title": "B", "status": "todo"}]}`, "duplicate task id"},
+		{"unknown status", `{"schema_version": 1, "kind": "project", "project": {"name": "Shop"}, "tasks": [{"title": "A", "status": "done"}]}`, "unknown status"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseProjectExport([]byte(test.data))
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: expected error containing %q, got %v", test.name, test.want, err)
+		}
+	}
+}
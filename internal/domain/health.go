@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Health levels shown as a traffic light
+const (
+	HealthGreen  = "green"
+	HealthYellow = "yellow"
+	HealthRed    = "red"
+)
+
+// Weights of the health score components, summing to 100
+const (
+	healthScheduleWeight = 40.0
+	healthOverdueWeight  = 25.0
+	healthBlockedWeight  = 20.0
+	healthOverrunWeight  = 15.0
+)
+
+// overrunSampleSize is how many recently completed tasks make up the overrun trend
+const overrunSampleSize = 5
+
+// ProjectHealth is a composite 0-100 health score with the reasons behind it
+type ProjectHealth struct {
+	Score        int
+	Level        string
+	Progress     float64
+	Expected     float64 // expected progress by now, 0 when the project has no deadline
+	OverdueTasks int
+	BlockedTasks int
+	OverrunRatio float64 // average actual/estimate of recently completed tasks, 0 without data
+	Reasons      []string
+}
+
+// HealthIcon returns the traffic light emoji for a health level
+func HealthIcon(level string) string {
+	switch level {
+	case HealthGreen:
+		return "🟢"
+	case HealthYellow:
+		return "🟡"
+	case HealthRed:
+		return "🔴"
+	default:
+		return "⚪"
+	}
+}
+
+// IsTaskOverdue reports whether an open task has taken more than twice its estimate in
+// working days of the team calendar, with a 2-day minimum, or the project deadline has passed
+func IsTaskOverdue(task Task, deadline, now time.Time, calendar WorkCalendar) bool {
+	if task.Status == TaskStatusCompleted {
+		return false
+	}
+	if !deadline.IsZero() && now.After(deadline) {
+		return true
+	}
+
+	days := math.Ceil(calendar.DeveloperDays(task.EstimateHours) * 2)
+	if days < 2 {
+		days = 2
+	}
+	return now.After(calendar.AddWorkingDays(task.CreatedAt, days))
+}
+
+// ComputeProjectHealth scores a project from progress against elapsed time (when a deadline
+// is set), the share of tasks overdue on the team calendar, the number of blocked tasks and
+// the estimate overrun trend of recently completed tasks.
+func ComputeProjectHealth(tasks []Task, start, deadline, now time.Time, calendar WorkCalendar) ProjectHealth {
+	health := ProjectHealth{}
+	if len(tasks) == 0 {
+		health.Score = 100
+		health.Level = HealthGreen
+		return health
+	}
+
+	completed, open := 0, 0
+	var recent []Task
+	for _, task := range tasks {
+		switch {
+		case task.Status == TaskStatusCompleted:
+			completed++
+			if task.EstimateHours > 0 && task.ActualHours > 0 && task.CompletedAt != nil {
+				recent = append(recent, task)
+			}
+		default:
+			open++
+			if task.Status == TaskStatusBlocked {
+				health.BlockedTasks++
+			}
+			if IsTaskOverdue(task, deadline, now, calendar) {
+				health.OverdueTasks++
+			}
+		}
+	}
+	health.Progress = float64(completed) / float64(len(tasks))
+
+	penalty := 0.0
+
+	if !deadline.IsZero() && deadline.After(start) {
+		health.Expected = clamp01(now.Sub(start).Hours() / deadline.Sub(start).Hours())
+		if gap := health.Expected - health.Progress; gap > 0.1 {
+			penalty += clamp01(gap*2) * healthScheduleWeight
+			health.Reasons = append(health.Reasons,
+				fmt.Sprintf("%.0f%% done, %.0f%% of the time elapsed", health.Progress*100, health.Expected*100))
+		}
+	}
+
+	if open > 0 && health.OverdueTasks > 0 {
+		ratio := float64(health.OverdueTasks) / float64(open)
+		penalty += ratio * healthOverdueWeight
+		health.Reasons = append(health.Reasons, fmt.Sprintf("%d of %d open tasks overdue", health.OverdueTasks, open))
+	}
+
+	if health.BlockedTasks > 0 {
+		penalty += clamp01(float64(health.BlockedTasks)/4) * healthBlockedWeight
+		health.Reasons = append(health.Reasons, fmt.Sprintf("%d blocked task(s)", health.BlockedTasks))
+	}
+
+	if len(recent) > 0 {
+		sort.Slice(recent, func(i, j int) bool {
+			return recent[i].CompletedAt.Before(*recent[j].CompletedAt)
+		})
+		if len(recent) > overrunSampleSize {
+			recent = recent[len(recent)-overrunSampleSize:]
+		}
+		total := 0.0
+		for _, task := range recent {
+			total += task.ActualHours / task.EstimateHours
+		}
+		health.OverrunRatio = total / float64(len(recent))
+		if health.OverrunRatio > 1.1 {
+			penalty += clamp01(health.OverrunRatio-1) * healthOverrunWeight
+			health.Reasons = append(health.Reasons,
+				fmt.Sprintf("recent tasks take %.0f%% of their estimate", health.OverrunRatio*100))
+		}
+	}
+
+	health.Score = int(math.Round(100 - penalty))
+	switch {
+	case health.Score >= 75:
+		health.Level = HealthGreen
+	case health.Score >= 50:
+		health.Level = HealthYellow
+	default:
+		health.Level = HealthRed
+	}
+
+	return health
+}
+
+// clamp01 limits a value to the range 0..1
+func clamp01(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeProjectHealth(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	deadline := start.AddDate(0, 0, 20)
+	now := start.AddDate(0, 0, 10)
+	done := start.AddDate(0, 0, 3)
+
+	onTrack := []Task{
+		{Status: "completed", EstimateHours: 4, ActualHours: 4, CompletedAt: &done, CreatedAt: start},
+		{Status: "completed", EstimateHours: 8, ActualHours: 7, CompletedAt: &done, CreatedAt: start},
+		{Status: "in_progress", EstimateHours: 40, CreatedAt: now.AddDate(0, 0, -1)},
+	}
+	health := ComputeProjectHealth(onTrack, start, deadline, now, DefaultWorkCalendar())
+	if health.Level != HealthGreen || health.Score != 100 {
+		t.Errorf("Expected healthy project, got %+v", health)
+	}
+
+	behind := []Task{
+		{Status: "completed", EstimateHours: 4, ActualHours: 10, CompletedAt: &done, CreatedAt: start},
+		{Status: "todo", EstimateHours: 8, CreatedAt: start},
+		{Status: "blocked", EstimateHours: 8, CreatedAt: start},
+		{Status: "blocked", EstimateHours: 8, CreatedAt: start},
+		{Status: "todo", EstimateHours: 8, CreatedAt: start},
+	}
+	health = ComputeProjectHealth(behind, start, deadline, now, DefaultWorkCalendar())
+	if health.Level != HealthRed {
+		t.Errorf("Expected red project, got %+v", health)
+	}
+	if health.OverdueTasks != 4 || health.BlockedTasks != 2 || len(health.Reasons) != 4 {
+		t.Errorf("Unexpected breakdown: %+v", health)
+	}
+
+	if empty := ComputeProjectHealth(nil, start, time.Time{}, now, DefaultWorkCalendar()); empty.Level != HealthGreen {
+		t.Errorf("Expected empty project to be green, got %+v", empty)
+	}
+}
+
+func TestIsTaskOverdue(t *testing.T) {
+	created := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	task := Task{Status: "todo", EstimateHours: 16, CreatedAt: created}
+	calendar := DefaultWorkCalendar()
+
+	if IsTaskOverdue(task, time.Time{}, created.AddDate(0, 0, 3), calendar) {
+		t.Error("16h task should not be overdue after 3 days")
+	}
+	if !IsTaskOverdue(task, time.Time{}, created.AddDate(0, 0, 5), calendar) {
+		t.Error("16h task should be overdue after 5 days")
+	}
+	if !IsTaskOverdue(task, created.AddDate(0, 0, 1), created.AddDate(0, 0, 2), calendar) {
+		t.Error("Open task should be overdue after the project deadline")
+	}
+
+	// Weekends and holidays do not count, and shorter days stretch the estimate
+	thursday := Task{Status: "todo", EstimateHours: 16, CreatedAt: created.AddDate(0, 0, 3)}
+	if IsTaskOverdue(thursday, time.Time{}, thursday.CreatedAt.AddDate(0, 0, 5), calendar) {
+		t.Error("16h task started on Thursday should not be overdue the next Tuesday")
+	}
+	calendar.Holidays = []time.Time{created.AddDate(0, 0, 3)}
+	if IsTaskOverdue(task, time.Time{}, created.AddDate(0, 0, 5), calendar) {
+		t.Error("16h task should not be overdue when a holiday falls in its working days")
+	}
+	short := WorkCalendar{HoursPerDay: 4, DaysPerWeek: 5}
+	if IsTaskOverdue(task, time.Time{}, created.AddDate(0, 0, 9), short) {
+		t.Error("16h task should take eight working days on a 4h calendar")
+	}
+}
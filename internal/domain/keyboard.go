@@ -0,0 +1,70 @@
+package domain
+
+import "context"
+
+// MaxCallbackData is the most bytes Telegram accepts as the callback data of a button
+const MaxCallbackData = 64
+
+// CallbackQuery is an inline keyboard button press. Buttons whose callback data is a
+// command, e.g. "/task 42 done", are routed as that command; other callback data goes to
+// the CallbackHandler claiming it.
+type CallbackQuery struct {
+	ID   string
+	Data string
+	// MessageID is the message carrying the pressed button
+	MessageID int
+}
+
+// CallbackHandler handles button presses whose callback data is not a command. Its
+// buttons' data start with a prefix of its own, e.g. "projects:open:proj_1".
+type CallbackHandler interface {
+	CanHandleCallback(data string) bool
+	HandleCallback(ctx context.Context, cmd *Command) (*Response, error)
+}
+
+// KeyboardBuilder builds an inline keyboard row by row:
+//
+//	NewKeyboard().Button("✅ Done", "/task 42 done").Row().Button("◀️ Back", "projects:list").Build()
+type KeyboardBuilder struct {
+	rows [][]InlineKeyboardButton
+	row  []InlineKeyboardButton
+}
+
+// NewKeyboard starts an empty inline keyboard
+func NewKeyboard() *KeyboardBuilder {
+	return &KeyboardBuilder{}
+}
+
+// Button adds a callback button to the current row. Buttons with empty data or data over
+// MaxCallbackData bytes are left out, as Telegram rejects the whole message for them.
+func (b *KeyboardBuilder) Button(text, data string) *KeyboardBuilder {
+	if data == "" || len(data) > MaxCallbackData {
+		return b
+	}
+	b.row = append(b.row, InlineKeyboardButton{Text: text, CallbackData: data})
+	return b
+}
+
+// WebApp adds a button opening a Mini App to the current row
+func (b *KeyboardBuilder) WebApp(text, url string) *KeyboardBuilder {
+	b.row = append(b.row, InlineKeyboardButton{Text: text, WebApp: &WebAppInfo{URL: url}})
+	return b
+}
+
+// Row ends the current row; later buttons start a new one
+func (b *KeyboardBuilder) Row() *KeyboardBuilder {
+	if len(b.row) > 0 {
+		b.rows = append(b.rows, b.row)
+		b.row = nil
+	}
+	return b
+}
+
+// Build returns the keyboard, or nil when it has no buttons so no markup is sent
+func (b *KeyboardBuilder) Build() *InlineKeyboardMarkup {
+	b.Row()
+	if len(b.rows) == 0 {
+		return nil
+	}
+	return &InlineKeyboardMarkup{InlineKeyboard: b.rows}
+}
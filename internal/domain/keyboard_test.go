@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKeyboardBuilder(t *testing.T) {
+	keyboard := NewKeyboard().
+		Button("Open", "projects:open:proj_1").
+		Button("Health", "/health proj_1").Row().
+		Button("Too long", strings.Repeat("x", MaxCallbackData+1)).
+		Button("Empty", "").
+		Button("Back", "projects:list").
+		Build()
+
+	if keyboard == nil || len(keyboard.InlineKeyboard) != 2 {
+		t.Fatalf("expected two rows, got %+v", keyboard)
+	}
+	if len(keyboard.InlineKeyboard[0]) != 2 || keyboard.InlineKeyboard[0][1].CallbackData != "/health proj_1" {
+		t.Errorf("unexpected first row %+v", keyboard.InlineKeyboard[0])
+	}
+	if row := keyboard.InlineKeyboard[1]; len(row) != 1 || row[0].Text != "Back" {
+		t.Errorf("expected the invalid buttons to be left out, got %+v", row)
+	}
+}
+
+func TestKeyboardBuilderEmpty(t *testing.T) {
+	if keyboard := NewKeyboard().Row().Button("Empty", "").Build(); keyboard != nil {
+		t.Errorf("expected no markup for an empty keyboard, got %+v", keyboard)
+	}
+}
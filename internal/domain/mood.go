@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Mood check-ins are answered on a 1-5 scale
+const (
+	MoodMinScore = 1
+	MoodMaxScore = 5
+)
+
+// MoodMinResponses is the fewest answers a week needs before its results are shown, so
+// that a single member's answer cannot be read from the average
+const MoodMinResponses = 3
+
+// MoodSharpDrop is the drop of the weekly average, in scale points, that raises an alert
+const MoodSharpDrop = 0.75
+
+// MoodLabels describe each score on the check-in buttons
+var MoodLabels = map[int]string{
+	1: "😫",
+	2: "😕",
+	3: "😐",
+	4: "🙂",
+	5: "😄",
+}
+
+// MoodWeek is the anonymous result of one week's check-in: how many members picked each score
+type MoodWeek struct {
+	Week   string
+	Counts [MoodMaxScore + 1]int
+}
+
+// Responses returns the number of answers
+func (w MoodWeek) Responses() int {
+	total := 0
+	for score := MoodMinScore; score <= MoodMaxScore; score++ {
+		total += w.Counts[score]
+	}
+	return total
+}
+
+// Average returns the mean score, 0 without answers
+func (w MoodWeek) Average() float64 {
+	responses := w.Responses()
+	if responses == 0 {
+		return 0
+	}
+	sum := 0
+	for score := MoodMinScore; score <= MoodMaxScore; score++ {
+		sum += score * w.Counts[score]
+	}
+	return float64(sum) / float64(responses)
+}
+
+// Reportable reports whether the week has enough answers to keep them anonymous
+func (w MoodWeek) Reportable() bool {
+	return w.Responses() >= MoodMinResponses
+}
+
+// SharpMoodDrop reports whether the average fell by at least MoodSharpDrop since the
+// previous week. Weeks without enough answers are never compared.
+func SharpMoodDrop(previous, current MoodWeek) bool {
+	return previous.Reportable() && current.Reportable() && previous.Average()-current.Average() >= MoodSharpDrop
+}
+
+// MoodWeekKey returns the ISO week of t, e.g. "2026-W42"
+func MoodWeekKey(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// FormatMoodWeek describes a week's result in one line, hiding weeks with too few answers
+func FormatMoodWeek(week MoodWeek) string {
+	if !week.Reportable() {
+		return fmt.Sprintf("%s: fewer than %d answers, hidden to keep them anonymous", week.Week, MoodMinResponses)
+	}
+	return fmt.Sprintf("%s: %s %.1f/5 (%d answers)", week.Week, MoodLabels[int(week.Average()+0.5)], week.Average(), week.Responses())
+}
+
+// MoodSparkline draws the averages of reportable weeks, oldest first, with one block per week
+func MoodSparkline(weeks []MoodWeek) string {
+	blocks := []rune("▁▂▄▆█")
+	var line strings.Builder
+	for _, week := range weeks {
+		if !week.Reportable() {
+			line.WriteRune('·')
+			continue
+		}
+		line.WriteRune(blocks[int(week.Average()+0.5)-MoodMinScore])
+	}
+	return line.String()
+}
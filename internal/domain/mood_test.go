@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMoodWeekAverage(t *testing.T) {
+	week := MoodWeek{Week: "2026-W42"}
+	week.Counts[2] = 1
+	week.Counts[4] = 2
+	week.Counts[5] = 1
+
+	if week.Responses() != 4 {
+		t.Fatalf("expected 4 responses, got %d", week.Responses())
+	}
+	if week.Average() != 3.75 {
+		t.Errorf("expected average 3.75, got %.2f", week.Average())
+	}
+	if !week.Reportable() {
+		t.Error("expected a week with 4 answers to be reportable")
+	}
+	if (MoodWeek{}).Average() != 0 {
+		t.Error("expected average 0 without answers")
+	}
+}
+
+func TestSharpMoodDrop(t *testing.T) {
+	previous := MoodWeek{Counts: [MoodMaxScore + 1]int{4: 3, 5: 1}}
+	dropped := MoodWeek{Counts: [MoodMaxScore + 1]int{2: 2, 3: 2}}
+	steady := MoodWeek{Counts: [MoodMaxScore + 1]int{4: 4}}
+	hidden := MoodWeek{Counts: [MoodMaxScore + 1]int{1: 2}}
+
+	if !SharpMoodDrop(previous, dropped) {
+		t.Error("expected a drop from 4.25 to 2.5 to be sharp")
+	}
+	if SharpMoodDrop(previous, steady) {
+		t.Error("expected a drop from 4.25 to 4 not to be sharp")
+	}
+	if SharpMoodDrop(previous, hidden) {
+		t.Error("expected weeks with too few answers not to be compared")
+	}
+}
+
+func TestMoodWeekKey(t *testing.T) {
+	if key := MoodWeekKey(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)); key != "2026-W42" {
+		t.Errorf("expected 2026-W42, got %s", key)
+	}
+	// ISO weeks around new year belong to the year of their Thursday
+	if key := MoodWeekKey(time.Date(2027, 1, 1, 12, 0, 0, 0, time.UTC)); key != "2026-W53" {
+		t.Errorf("expected 2026-W53, got %s", key)
+	}
+}
+
+func TestMoodSparkline(t *testing.T) {
+	weeks := []MoodWeek{
+		{Counts: [MoodMaxScore + 1]int{1: 3}},
+		{Counts: [MoodMaxScore + 1]int{5: 1}},
+		{Counts: [MoodMaxScore + 1]int{3: 2, 4: 2}},
+	}
+	if line := MoodSparkline(weeks); line != "▁·▆" {
+		t.Errorf("unexpected sparkline %q", line)
+	}
+}
+
+func TestLogTextRedactsPrivateArguments(t *testing.T) {
+	if text := LogText("/mood -100123 2026-W42 4"); text != "/mood [redacted]" {
+		t.Errorf("expected redacted arguments, got %q", text)
+	}
+	if text := LogText("/task 12"); text != "/task 12" {
+		t.Errorf("expected other commands unchanged, got %q", text)
+	}
+}
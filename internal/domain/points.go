@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Estimate units a team can choose for analysis output
+const (
+	EstimateUnitHours  = "hours"
+	EstimateUnitPoints = "points"
+)
+
+// DefaultSprintLengthDays is the sprint length used when a team has not configured one
+const DefaultSprintLengthDays = 14
+
+// PointLevel maps a story point value to the maximum hours it covers
+type PointLevel struct {
+	Points   int
+	MaxHours float64
+}
+
+// PointScale maps hour estimates to story points, ordered by points ascending
+type PointScale []PointLevel
+
+// DefaultPointScale returns a Fibonacci scale where 1 point is up to 2 hours
+func DefaultPointScale() PointScale {
+	return PointScale{
+		{Points: 1, MaxHours: 2},
+		{Points: 2, MaxHours: 4},
+		{Points: 3, MaxHours: 8},
+		{Points: 5, MaxHours: 16},
+		{Points: 8, MaxHours: 24},
+		{Points: 13, MaxHours: 40},
+	}
+}
+
+// ParsePointScale parses a scale like "1=2,2=4,3=8" (points=max hours)
+func ParsePointScale(value string) (PointScale, error) {
+	var scale PointScale
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		pair := strings.SplitN(part, "=", 2)
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("invalid scale entry %q, use points=hours", part)
+		}
+		points, err := strconv.Atoi(strings.TrimSpace(pair[0]))
+		if err != nil || points <= 0 {
+			return nil, fmt.Errorf("invalid points in %q", part)
+		}
+		hours, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(pair[1]), "h"), 64)
+		if err != nil || hours <= 0 {
+			return nil, fmt.Errorf("invalid hours in %q", part)
+		}
+		scale = append(scale, PointLevel{Points: points, MaxHours: hours})
+	}
+
+	if len(scale) == 0 {
+		return nil, fmt.Errorf("scale is empty")
+	}
+
+	sort.Slice(scale, func(i, j int) bool {
+		return scale[i].Points < scale[j].Points
+	})
+	for i := 1; i < len(scale); i++ {
+		if scale[i].Points == scale[i-1].Points || scale[i].MaxHours <= scale[i-1].MaxHours {
+			return nil, fmt.Errorf("points and hours must both increase (%d=%g after %d=%g)",
+				scale[i].Points, scale[i].MaxHours, scale[i-1].Points, scale[i-1].MaxHours)
+		}
+	}
+
+	return scale, nil
+}
+
+// String formats the scale in the same form ParsePointScale accepts
+func (s PointScale) String() string {
+	parts := make([]string, len(s))
+	for i, level := range s {
+		parts[i] = fmt.Sprintf("%d=%g", level.Points, level.MaxHours)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Points converts an hour estimate to story points; estimates above the scale get the largest value
+func (s PointScale) Points(hours float64) int {
+	if len(s) == 0 {
+		return 0
+	}
+	for _, level := range s {
+		if hours <= level.MaxHours {
+			return level.Points
+		}
+	}
+	return s[len(s)-1].Points
+}
+
+// HourRange returns the hour range covered by a point value
+func (s PointScale) HourRange(points int) (float64, float64) {
+	min := 0.0
+	for _, level := range s {
+		if level.Points == points {
+			return min, level.MaxHours
+		}
+		min = level.MaxHours
+	}
+	return 0, 0
+}
+
+// EstimateSettings controls how a team's estimates are displayed
+type EstimateSettings struct {
+	Unit  string
+	Scale PointScale
+}
+
+// DefaultEstimateSettings returns hour-based estimates with the default point scale
+func DefaultEstimateSettings() EstimateSettings {
+	return EstimateSettings{Unit: EstimateUnitHours, Scale: DefaultPointScale()}
+}
+
+// UsePoints reports whether estimates should be shown in story points
+func (e EstimateSettings) UsePoints() bool {
+	return e.Unit == EstimateUnitPoints && len(e.Scale) > 0
+}
+
+// Format formats an hour estimate in the team's unit
+func (e EstimateSettings) Format(hours float64) string {
+	if e.UsePoints() {
+		return fmt.Sprintf("%d pts (%.1fh)", e.Scale.Points(hours), hours)
+	}
+	return fmt.Sprintf("%.1fh", hours)
+}
+
+// FormatRange formats an estimate range in the team's unit
+func (e EstimateSettings) FormatRange(r EstimateRange) string {
+	if e.UsePoints() {
+		low, likely, high := e.Scale.Points(r.Optimistic), e.Scale.Points(r.Likely), e.Scale.Points(r.Pessimistic)
+		if low == high {
+			return fmt.Sprintf("%d pts (%s)", likely, r)
+		}
+		return fmt.Sprintf("%d–%d pts (most likely %d pts)", low, high, likely)
+	}
+	return r.String()
+}
+
+// TotalPoints sums the points of each task
+func (e EstimateSettings) TotalPoints(tasks []Task) int {
+	total := 0
+	for _, task := range tasks {
+		total += e.Scale.Points(task.EstimateHours)
+	}
+	return total
+}
+
+// Sprint is a numbered fixed-length iteration
+type Sprint struct {
+	Number int
+	Start  time.Time
+	End    time.Time
+}
+
+// RecentSprints returns the last count sprints up to and including the one containing now,
+// oldest first. Sprints are counted from the anchor date in fixed lengths of days.
+func RecentSprints(anchor time.Time, lengthDays int, now time.Time, count int) []Sprint {
+	if lengthDays <= 0 {
+		lengthDays = DefaultSprintLengthDays
+	}
+	anchor = time.Date(anchor.Year(), anchor.Month(), anchor.Day(), 0, 0, 0, 0, now.Location())
+
+	elapsedDays := int(now.Sub(anchor).Hours() / 24)
+	if elapsedDays < 0 {
+		elapsedDays = 0
+	}
+	current := elapsedDays / lengthDays
+
+	var sprints []Sprint
+	for n := current - count + 1; n <= current; n++ {
+		if n < 0 {
+			continue
+		}
+		start := anchor.AddDate(0, 0, n*lengthDays)
+		sprints = append(sprints, Sprint{
+			Number: n + 1,
+			Start:  start,
+			End:    start.AddDate(0, 0, lengthDays),
+		})
+	}
+	return sprints
+}
+
+// CompletedWork is a finished task used for velocity calculations
+type CompletedWork struct {
+	CompletedAt time.Time
+	Hours       float64
+}
+
+// SprintVelocity is the work completed during a sprint
+type SprintVelocity struct {
+	Sprint Sprint
+	Tasks  int
+	Hours  float64
+	Points int
+}
+
+// Velocity buckets completed work into sprints, counting both hours and points
+func (s PointScale) Velocity(sprints []Sprint, work []CompletedWork) []SprintVelocity {
+	velocity := make([]SprintVelocity, len(sprints))
+	for i, sprint := range sprints {
+		velocity[i].Sprint = sprint
+	}
+
+	for _, item := range work {
+		for i, sprint := range sprints {
+			if !item.CompletedAt.Before(sprint.Start) && item.CompletedAt.Before(sprint.End) {
+				velocity[i].Tasks++
+				velocity[i].Hours += item.Hours
+				velocity[i].Points += s.Points(item.Hours)
+				break
+			}
+		}
+	}
+
+	return velocity
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPointScale_Points(t *testing.T) {
+	scale := DefaultPointScale()
+
+	tests := []struct {
+		hours    float64
+		expected int
+	}{
+		{1, 1},
+		{2, 1},
+		{3, 2},
+		{8, 3},
+		{12, 5},
+		{40, 13},
+		{100, 13},
+	}
+
+	for _, test := range tests {
+		if result := scale.Points(test.hours); result != test.expected {
+			t.Errorf("Points(%.1f): expected %d, got %d", test.hours, test.expected, result)
+		}
+	}
+
+	if min, max := scale.HourRange(5); min != 8 || max != 16 {
+		t.Errorf("HourRange(5): expected 8-16, got %.0f-%.0f", min, max)
+	}
+}
+
+func TestParsePointScale(t *testing.T) {
+	scale, err := ParsePointScale("3=8, 1=2h, 2=4")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if scale.String() != "1=2,2=4,3=8" {
+		t.Errorf("Expected sorted scale, got %s", scale.String())
+	}
+
+	invalid := []string{"", "1=2,2=1", "a=2", "1=0", "1=2,1=4", "12"}
+	for _, value := range invalid {
+		if _, err := ParsePointScale(value); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+}
+
+func TestPointScale_Velocity(t *testing.T) {
+	anchor := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	sprints := RecentSprints(anchor, 14, now, 2)
+	if len(sprints) != 2 || sprints[1].Number != 3 {
+		t.Fatalf("Expected sprints 2 and 3, got %+v", sprints)
+	}
+
+	work := []CompletedWork{
+		{CompletedAt: time.Date(2026, 1, 20, 10, 0, 0, 0, time.UTC), Hours: 8},
+		{CompletedAt: time.Date(2026, 1, 25, 10, 0, 0, 0, time.UTC), Hours: 2},
+		{CompletedAt: time.Date(2026, 2, 3, 10, 0, 0, 0, time.UTC), Hours: 16},
+		{CompletedAt: time.Date(2026, 1, 6, 10, 0, 0, 0, time.UTC), Hours: 4}, // sprint 1, not requested
+	}
+
+	velocity := DefaultPointScale().Velocity(sprints, work)
+	if velocity[0].Points != 4 || velocity[0].Hours != 10 || velocity[0].Tasks != 2 {
+		t.Errorf("Sprint 2: expected 4 pts / 10h / 2 tasks, got %+v", velocity[0])
+	}
+	if velocity[1].Points != 5 || velocity[1].Hours != 16 {
+		t.Errorf("Sprint 3: expected 5 pts / 16h, got %+v", velocity[1])
+	}
+}
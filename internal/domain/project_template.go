@@ -0,0 +1,118 @@
+package domain
+
+import "strings"
+
+// TemplateTask is a starter task of a project template. DependsOn holds indexes of
+// earlier tasks in the same template.
+type TemplateTask struct {
+	Title         string
+	Category      string
+	Priority      int
+	EstimateHours float64
+	DependsOn     []int
+}
+
+// ProjectTemplate is a built-in project archetype with a starter task skeleton
+type ProjectTemplate struct {
+	Key         string
+	Name        string
+	Icon        string
+	Description string
+	Tasks       []TemplateTask
+}
+
+// TotalHours sums the baseline estimates of the template's tasks
+func (t ProjectTemplate) TotalHours() float64 {
+	total := 0.0
+	for _, task := range t.Tasks {
+		total += task.EstimateHours
+	}
+	return total
+}
+
+// projectTemplates are the built-in archetypes offered by /create_project
+var projectTemplates = []ProjectTemplate{
+	{
+		Key:         "saas_mvp",
+		Name:        "SaaS MVP",
+		Icon:        "💼",
+		Description: "Web app with accounts, billing and an admin area",
+		Tasks: []TemplateTask{
+			{Title: "Project setup and CI pipeline", Category: "devops", Priority: 1, EstimateHours: 4},
+			{Title: "Database schema", Category: "backend", Priority: 1, EstimateHours: 6},
+			{Title: "User registration and login", Category: "backend", Priority: 1, EstimateHours: 10, DependsOn: []int{1}},
+			{Title: "Subscription billing integration", Category: "backend", Priority: 1, EstimateHours: 14, DependsOn: []int{2}},
+			{Title: "Core feature API", Category: "backend", Priority: 1, EstimateHours: 16, DependsOn: []int{1}},
+			{Title: "Landing and pricing pages", Category: "frontend", Priority: 2, EstimateHours: 8},
+			{Title: "Auth screens", Category: "frontend", Priority: 1, EstimateHours: 6, DependsOn: []int{2}},
+			{Title: "Core feature UI", Category: "frontend", Priority: 1, EstimateHours: 16, DependsOn: []int{4}},
+			{Title: "Admin dashboard", Category: "frontend", Priority: 3, EstimateHours: 10, DependsOn: []int{4}},
+			{Title: "End-to-end tests for sign-up and billing", Category: "qa", Priority: 2, EstimateHours: 8, DependsOn: []int{3, 6}},
+			{Title: "Production deployment and monitoring", Category: "devops", Priority: 2, EstimateHours: 6, DependsOn: []int{0}},
+		},
+	},
+	{
+		Key:         "telegram_bot",
+		Name:        "Telegram bot",
+		Icon:        "🤖",
+		Description: "Bot with commands, persistence and a webhook deployment",
+		Tasks: []TemplateTask{
+			{Title: "Bot registration and project setup", Category: "devops", Priority: 1, EstimateHours: 2},
+			{Title: "Update handling and command router", Category: "backend", Priority: 1, EstimateHours: 6, DependsOn: []int{0}},
+			{Title: "Database storage for users and state", Category: "backend", Priority: 1, EstimateHours: 6, DependsOn: []int{1}},
+			{Title: "Core commands", Category: "backend", Priority: 1, EstimateHours: 12, DependsOn: []int{1, 2}},
+			{Title: "Inline keyboards and callbacks", Category: "backend", Priority: 2, EstimateHours: 6, DependsOn: []int{3}},
+			{Title: "Rate limiting and error handling", Category: "backend", Priority: 2, EstimateHours: 4, DependsOn: []int{1}},
+			{Title: "Command handler tests", Category: "qa", Priority: 2, EstimateHours: 6, DependsOn: []int{3}},
+			{Title: "Webhook deployment", Category: "devops", Priority: 2, EstimateHours: 4, DependsOn: []int{1}},
+		},
+	},
+	{
+		Key:         "rest_api",
+		Name:        "REST API",
+		Icon:        "🔌",
+		Description: "Backend service with authentication, CRUD resources and docs",
+		Tasks: []TemplateTask{
+			{Title: "Service skeleton and configuration", Category: "backend", Priority: 1, EstimateHours: 4},
+			{Title: "Database schema and migrations", Category: "backend", Priority: 1, EstimateHours: 6, DependsOn: []int{0}},
+			{Title: "Token authentication", Category: "backend", Priority: 1, EstimateHours: 8, DependsOn: []int{1}},
+			{Title: "CRUD endpoints for core resources", Category: "backend", Priority: 1, EstimateHours: 16, DependsOn: []int{1}},
+			{Title: "Input validation and error responses", Category: "backend", Priority: 2, EstimateHours: 6, DependsOn: []int{3}},
+			{Title: "OpenAPI documentation", Category: "backend", Priority: 3, EstimateHours: 4, DependsOn: []int{3}},
+			{Title: "Integration tests", Category: "qa", Priority: 2, EstimateHours: 10, DependsOn: []int{2, 3}},
+			{Title: "Docker image and CI pipeline", Category: "devops", Priority: 2, EstimateHours: 5, DependsOn: []int{0}},
+		},
+	},
+	{
+		Key:         "mobile_app",
+		Name:        "Mobile app",
+		Icon:        "📱",
+		Description: "Cross-platform app with a backend API and store release",
+		Tasks: []TemplateTask{
+			{Title: "App project setup and navigation", Category: "frontend", Priority: 1, EstimateHours: 6},
+			{Title: "Backend API for the app", Category: "backend", Priority: 1, EstimateHours: 16},
+			{Title: "Authentication flow", Category: "frontend", Priority: 1, EstimateHours: 10, DependsOn: []int{0, 1}},
+			{Title: "Main screens", Category: "frontend", Priority: 1, EstimateHours: 20, DependsOn: []int{0}},
+			{Title: "Offline storage and sync", Category: "frontend", Priority: 2, EstimateHours: 12, DependsOn: []int{3}},
+			{Title: "Push notifications", Category: "backend", Priority: 3, EstimateHours: 8, DependsOn: []int{1}},
+			{Title: "Device testing", Category: "qa", Priority: 2, EstimateHours: 10, DependsOn: []int{3}},
+			{Title: "Build pipeline and store release", Category: "devops", Priority: 2, EstimateHours: 8, DependsOn: []int{6}},
+		},
+	},
+}
+
+// ProjectTemplates returns the built-in project templates
+func ProjectTemplates() []ProjectTemplate {
+	return projectTemplates
+}
+
+// FindProjectTemplate looks up a template by key, ignoring case and treating "-" as "_"
+func FindProjectTemplate(key string) (ProjectTemplate, bool) {
+	key = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+	for _, template := range projectTemplates {
+		if template.Key == key {
+			return template, true
+		}
+	}
+	return ProjectTemplate{}, false
+}
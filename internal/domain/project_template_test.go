@@ -0,0 +1,36 @@
+package domain
+
+import "testing"
+
+func TestProjectTemplates(t *testing.T) {
+	categories := map[string]bool{"backend": true, "frontend": true, "qa": true, "devops": true}
+
+	for _, template := range ProjectTemplates() {
+		if len(template.Tasks) == 0 {
+			t.Errorf("%s: expected starter tasks", template.Key)
+		}
+		for i, task := range template.Tasks {
+			if !categories[task.Category] {
+				t.Errorf("%s: task %q has unknown category %q", template.Key, task.Title, task.Category)
+			}
+			if task.EstimateHours <= 0 || task.Priority < 1 || task.Priority > 3 {
+				t.Errorf("%s: task %q has invalid estimate or priority", template.Key, task.Title)
+			}
+			for _, dependency := range task.DependsOn {
+				if dependency < 0 || dependency >= i {
+					t.Errorf("%s: task %q depends on %d, expected an earlier task", template.Key, task.Title, dependency)
+				}
+			}
+		}
+	}
+}
+
+func TestFindProjectTemplate(t *testing.T) {
+	template, ok := FindProjectTemplate(" Telegram-Bot ")
+	if !ok || template.Key != "telegram_bot" {
+		t.Errorf("Expected telegram_bot, got %q (found %v)", template.Key, ok)
+	}
+	if _, ok := FindProjectTemplate("unknown"); ok {
+		t.Error("Expected unknown template to be missing")
+	}
+}
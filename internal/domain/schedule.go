@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxScheduleAhead limits how far in the future a message can be scheduled
+const MaxScheduleAhead = 365 * 24 * time.Hour
+
+// MessageSender delivers messages to a chat outside of the request/response flow
+type MessageSender interface {
+	SendMessage(ctx context.Context, chatID int64, text, parseMode string) error
+}
+
+// KeyboardSender is implemented by senders that can attach an inline keyboard to a message
+type KeyboardSender interface {
+	SendMessageWithKeyboard(ctx context.Context, chatID int64, text, parseMode string, keyboard *InlineKeyboardMarkup) error
+}
+
+// DocumentSender is implemented by senders that can upload a file with a caption
+type DocumentSender interface {
+	SendDocument(ctx context.Context, chatID int64, document *OutgoingDocument, caption, parseMode string) error
+}
+
+// weekdayNames maps accepted weekday words to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday,
+}
+
+// ParseScheduleTime parses a schedule expression at the start of the input and returns
+// the resolved time in now's location together with the remaining text.
+//
+// Supported forms: "in 30m", "in 2h", "in 1d", "HH:MM", "today HH:MM",
+// "tomorrow HH:MM", "monday HH:MM" and "YYYY-MM-DD HH:MM".
+func ParseScheduleTime(input string, now time.Time) (time.Time, string, error) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return time.Time{}, "", fmt.Errorf("missing time, e.g. `tomorrow 09:00`")
+	}
+
+	var when time.Time
+	consumed := 0
+	first := strings.ToLower(fields[0])
+
+	switch {
+	case first == "in":
+		if len(fields) < 2 {
+			return time.Time{}, "", fmt.Errorf("missing duration, e.g. `in 2h`")
+		}
+		duration, err := ParseDuration(fields[1])
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		when = now.Add(duration)
+		consumed = 2
+
+	case first == "today" || first == "tomorrow":
+		if len(fields) < 2 {
+			return time.Time{}, "", fmt.Errorf("missing clock time, e.g. `%s 09:00`", first)
+		}
+		day := now
+		if first == "tomorrow" {
+			day = now.AddDate(0, 0, 1)
+		}
+		parsed, err := atClock(day, fields[1])
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		when = parsed
+		consumed = 2
+
+	case isWeekdayName(first):
+		if len(fields) < 2 {
+			return time.Time{}, "", fmt.Errorf("missing clock time, e.g. `%s 09:00`", first)
+		}
+		offset := (int(weekdayNames[first]) - int(now.Weekday()) + 7) % 7
+		parsed, err := atClock(now.AddDate(0, 0, offset), fields[1])
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		if !parsed.After(now) {
+			parsed = parsed.AddDate(0, 0, 7)
+		}
+		when = parsed
+		consumed = 2
+
+	case strings.Count(first, "-") == 2:
+		if len(fields) < 2 {
+			return time.Time{}, "", fmt.Errorf("missing clock time, e.g. `%s 09:00`", first)
+		}
+		date, err := time.ParseInLocation("2006-01-02", first, now.Location())
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("invalid date %q, use YYYY-MM-DD", fields[0])
+		}
+		parsed, err := atClock(date, fields[1])
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		when = parsed
+		consumed = 2
+
+	case strings.Contains(first, ":"):
+		parsed, err := atClock(now, first)
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		if !parsed.After(now) {
+			parsed = parsed.AddDate(0, 0, 1)
+		}
+		when = parsed
+		consumed = 1
+
+	default:
+		return time.Time{}, "", fmt.Errorf("unknown time %q, e.g. `tomorrow 09:00` or `in 2h`", fields[0])
+	}
+
+	if !when.After(now) {
+		return time.Time{}, "", fmt.Errorf("time must be in the future")
+	}
+	if when.Sub(now) > MaxScheduleAhead {
+		return time.Time{}, "", fmt.Errorf("time must be within one year")
+	}
+
+	return when, remainingText(input, consumed), nil
+}
+
+// ParseDuration parses durations like 30m, 2h, 1h30m or 3d
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.ToLower(value)
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil || days <= 0 {
+			return 0, fmt.Errorf("invalid duration %q, e.g. `2h` or `1d`", value)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid duration %q, e.g. `30m` or `2h`", value)
+	}
+	return duration, nil
+}
+
+// atClock returns the given day at an HH:MM clock time
+func atClock(day time.Time, clock string) (time.Time, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid clock time %q, use HH:MM", clock)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), parsed.Hour(), parsed.Minute(), 0, 0, day.Location()), nil
+}
+
+// isWeekdayName checks whether the word is an accepted weekday name
+func isWeekdayName(word string) bool {
+	_, ok := weekdayNames[word]
+	return ok
+}
+
+// remainingText drops the first n words from the input, preserving the rest as written
+func remainingText(input string, n int) string {
+	rest := strings.TrimSpace(input)
+	for i := 0; i < n; i++ {
+		index := strings.IndexAny(rest, " \t\n")
+		if index < 0 {
+			return ""
+		}
+		rest = strings.TrimSpace(rest[index+1:])
+	}
+	return rest
+}
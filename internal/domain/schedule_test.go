@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScheduleTime(t *testing.T) {
+	// Thursday afternoon
+	now := time.Date(2026, 3, 19, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+		text     string
+	}{
+		{"Tomorrow", "tomorrow 09:00 Release freeze starts", time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC), "Release freeze starts"},
+		{"Today", "today 18:00 Standup notes", time.Date(2026, 3, 19, 18, 0, 0, 0, time.UTC), "Standup notes"},
+		{"Relative", "in 2h Deploy window", time.Date(2026, 3, 19, 16, 30, 0, 0, time.UTC), "Deploy window"},
+		{"Relative days", "in 1d Retro", time.Date(2026, 3, 20, 14, 30, 0, 0, time.UTC), "Retro"},
+		{"Clock passed rolls to tomorrow", "09:00 Daily sync", time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC), "Daily sync"},
+		{"Next weekday", "monday 10:00 Sprint planning", time.Date(2026, 3, 23, 10, 0, 0, 0, time.UTC), "Sprint planning"},
+		{"Same weekday passed", "thursday 09:00 Demo", time.Date(2026, 3, 26, 9, 0, 0, 0, time.UTC), "Demo"},
+		{"Absolute date", "2026-04-01 12:00 Quarter starts", time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC), "Quarter starts"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			when, text, err := ParseScheduleTime(test.input, now)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !when.Equal(test.expected) {
+				t.Errorf("Expected %s, got %s", test.expected, when)
+			}
+			if text != test.text {
+				t.Errorf("Expected text %q, got %q", test.text, text)
+			}
+		})
+	}
+}
+
+func TestParseScheduleTime_Invalid(t *testing.T) {
+	now := time.Date(2026, 3, 19, 14, 30, 0, 0, time.UTC)
+
+	inputs := []string{
+		"",
+		"today 10:00 already passed",
+		"tomorrow 25:00 bad clock",
+		"in -2h negative",
+		"2025-01-01 10:00 past date",
+		"2028-01-01 10:00 too far",
+		"someday soon",
+	}
+
+	for _, input := range inputs {
+		if _, _, err := ParseScheduleTime(input, now); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
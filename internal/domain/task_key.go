@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultTaskKeyPrefix is the prefix of task keys like TASK-12 for teams that did not set one
+const DefaultTaskKeyPrefix = "TASK"
+
+var (
+	taskKeyPattern       = regexp.MustCompile(`\b([A-Za-z][A-Za-z0-9]{1,9})-(\d+)\b`)
+	taskKeyPrefixPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
+	taskIDPattern        = regexp.MustCompile(`\btask_\d+(?:_\d+)?\b`)
+)
+
+// FormatTaskKey returns the key of a task's number, e.g. TASK-12
+func FormatTaskKey(prefix string, number int) string {
+	return fmt.Sprintf("%s-%d", prefix, number)
+}
+
+// ValidTaskKeyPrefix reports whether prefix can be used in task keys: 2-10 upper case
+// letters and digits, starting with a letter
+func ValidTaskKeyPrefix(prefix string) bool {
+	return taskKeyPrefixPattern.MatchString(prefix)
+}
+
+// ParseTaskKey returns the number of a key with the prefix, e.g. 12 for "TASK-12"
+func ParseTaskKey(text, prefix string) (int, bool) {
+	head, tail, ok := strings.Cut(strings.TrimSpace(text), "-")
+	if !ok || !strings.EqualFold(head, prefix) {
+		return 0, false
+	}
+	number, err := strconv.Atoi(tail)
+	if err != nil || number <= 0 {
+		return 0, false
+	}
+	return number, true
+}
+
+// FindTaskKeys returns the numbers of the task keys with the prefix mentioned in a text such
+// as a commit message, in order and without duplicates. The prefix matches in any case.
+func FindTaskKeys(text, prefix string) []int {
+	var numbers []int
+	seen := make(map[int]bool)
+	for _, match := range taskKeyPattern.FindAllStringSubmatch(text, -1) {
+		if !strings.EqualFold(match[1], prefix) {
+			continue
+		}
+		number, err := strconv.Atoi(match[2])
+		if err != nil || number == 0 || seen[number] {
+			continue
+		}
+		seen[number] = true
+		numbers = append(numbers, number)
+	}
+	return numbers
+}
+
+// FindTaskID returns the task ID shown in a text such as a task card, or "" when the text
+// mentions no task ID or several different ones
+func FindTaskID(text string) string {
+	found := ""
+	for _, id := range taskIDPattern.FindAllString(text, -1) {
+		if found != "" && id != found {
+			return ""
+		}
+		found = id
+	}
+	return found
+}
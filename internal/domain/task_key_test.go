@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindTaskKeys(t *testing.T) {
+	message := "Fix login redirect (PROJ-12, proj-7)\n\nAlso touches PROJ-12 and OTHER-3, see ISO-8601"
+	if keys := FindTaskKeys(message, "PROJ"); !reflect.DeepEqual(keys, []int{12, 7}) {
+		t.Errorf("expected [12 7], got %v", keys)
+	}
+	if keys := FindTaskKeys("Bump to v1-2", "PROJ"); len(keys) != 0 {
+		t.Errorf("expected no keys, got %v", keys)
+	}
+}
+
+func TestParseTaskKey(t *testing.T) {
+	if number, ok := ParseTaskKey("task-42", "TASK"); !ok || number != 42 {
+		t.Errorf("expected 42, got %d %v", number, ok)
+	}
+	for _, text := range []string{"TASK-", "TASK-0", "PROJ-4", "task_123"} {
+		if _, ok := ParseTaskKey(text, "TASK"); ok {
+			t.Errorf("expected %q not to parse", text)
+		}
+	}
+}
+
+func TestValidTaskKeyPrefix(t *testing.T) {
+	for prefix, valid := range map[string]bool{"PROJ": true, "A1": true, "A": false, "1AB": false, "proj": false, "TOOLONGPREFIX": false} {
+		if ValidTaskKeyPrefix(prefix) != valid {
+			t.Errorf("ValidTaskKeyPrefix(%q) should be %v", prefix, valid)
+		}
+	}
+}
+
+func TestFindTaskID(t *testing.T) {
+	card := "🔴 Login form (TASK-12 · task_1712_0)\n├── Status: todo\n\nSee task_1712_0 for details"
+	if id := FindTaskID(card); id != "task_1712_0" {
+		t.Errorf("expected task_1712_0, got %q", id)
+	}
+	for _, text := range []string{"No task here", "task_1 and task_2", "my_task_3"} {
+		if id := FindTaskID(text); id != "" {
+			t.Errorf("expected no task ID in %q, got %q", text, id)
+		}
+	}
+}
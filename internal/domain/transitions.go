@@ -0,0 +1,56 @@
+package domain
+
+// Task statuses
+const (
+	TaskStatusTodo       = "todo"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusCompleted  = "completed"
+	TaskStatusBlocked    = "blocked"
+)
+
+// RequiresDependencies reports whether moving a task to the status needs its dependencies done
+func RequiresDependencies(status string) bool {
+	return status == TaskStatusInProgress || status == TaskStatusCompleted
+}
+
+// PendingDependencies returns the dependencies of a task that are not completed yet.
+// Dependencies that no longer exist in the project are ignored.
+func PendingDependencies(task Task, projectTasks []Task) []Task {
+	byID := make(map[string]Task, len(projectTasks))
+	for _, other := range projectTasks {
+		byID[other.ID] = other
+	}
+
+	var pending []Task
+	for _, id := range task.Dependencies {
+		if dependency, ok := byID[id]; ok && dependency.Status != TaskStatusCompleted {
+			pending = append(pending, dependency)
+		}
+	}
+	return pending
+}
+
+// NewlyUnblocked returns open tasks that depend on the completed task and have no other
+// pending dependencies, so they can start now
+func NewlyUnblocked(completedID string, projectTasks []Task) []Task {
+	var unblocked []Task
+	for _, task := range projectTasks {
+		if task.Status == TaskStatusCompleted || !dependsOn(task, completedID) {
+			continue
+		}
+		if len(PendingDependencies(task, projectTasks)) == 0 {
+			unblocked = append(unblocked, task)
+		}
+	}
+	return unblocked
+}
+
+// dependsOn checks whether a task lists the given task as a dependency
+func dependsOn(task Task, id string) bool {
+	for _, dependency := range task.Dependencies {
+		if dependency == id {
+			return true
+		}
+	}
+	return false
+}
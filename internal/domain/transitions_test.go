@@ -0,0 +1,31 @@
+package domain
+
+import "testing"
+
+func TestPendingDependencies(t *testing.T) {
+	tasks := []Task{
+		{ID: "a", Status: TaskStatusCompleted},
+		{ID: "b", Status: TaskStatusInProgress},
+		{ID: "c", Status: TaskStatusTodo, Dependencies: []string{"a", "b", "missing"}},
+	}
+
+	pending := PendingDependencies(tasks[2], tasks)
+	if len(pending) != 1 || pending[0].ID != "b" {
+		t.Errorf("Expected only b to be pending, got %+v", pending)
+	}
+}
+
+func TestNewlyUnblocked(t *testing.T) {
+	tasks := []Task{
+		{ID: "a", Status: TaskStatusCompleted},
+		{ID: "b", Status: TaskStatusTodo},
+		{ID: "c", Status: TaskStatusTodo, Dependencies: []string{"a"}},
+		{ID: "d", Status: TaskStatusTodo, Dependencies: []string{"a", "b"}},
+		{ID: "e", Status: TaskStatusCompleted, Dependencies: []string{"a"}},
+	}
+
+	unblocked := NewlyUnblocked("a", tasks)
+	if len(unblocked) != 1 || unblocked[0].ID != "c" {
+		t.Errorf("Expected only c to be unblocked, got %+v", unblocked)
+	}
+}
@@ -25,6 +25,8 @@ type Chat struct {
 	Type     string `json:"type"`
 	Title    string `json:"title"`
 	Username string `json:"username"`
+	// Language is the chat's default language from /chat_settings, empty when not chosen
+	Language string `json:"language,omitempty"`
 }
 
 // UserRepository defines the interface for user data access
@@ -74,7 +76,7 @@ type Task struct {
 	Title         string     `json:"title" db:"title"`
 	Description   string     `json:"description" db:"description"`
 	Category      string     `json:"category" db:"category"`        // backend, frontend, qa, devops
-	EstimateHours float64    `json:"estimate_hours" db:"estimate_hours"`
+	EstimateHours float64    `json:"estimate_hours" db:"estimate_hours"` // most likely
 	ActualHours   float64    `json:"actual_hours" db:"actual_hours"`
 	Status        string     `json:"status" db:"status"`            // todo, in_progress, completed, blocked
 	Priority      int        `json:"priority" db:"priority"`        // 1-5
@@ -83,6 +85,16 @@ type Task struct {
 	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
 	CompletedAt   *time.Time `json:"completed_at" db:"completed_at"`
+
+	// Optimistic and pessimistic bounds around EstimateHours, 0 when unknown (see TaskEstimateRange)
+	OptimisticHours  float64 `json:"optimistic_hours,omitempty" db:"optimistic_hours"`
+	PessimisticHours float64 `json:"pessimistic_hours,omitempty" db:"pessimistic_hours"`
+	// Given/When/Then criteria, requested by detailed analyses and stored with the task
+	AcceptanceCriteria []string `json:"acceptance_criteria,omitempty" db:"acceptance_criteria"`
+	// Implementation steps of detailed analyses, shown before saving only
+	Subtasks []string `json:"subtasks,omitempty" db:"-"`
+	// Date the task should be finished by, nil when not planned
+	DueDate *time.Time `json:"due_date,omitempty" db:"-"`
 }
 
 // Team represents a development team
@@ -106,11 +118,22 @@ type TeamMember struct {
 	Current  float64  `json:"current" db:"current"`   // current workload hours
 }
 
+// Breakdown depth of /analyze --brief and --detailed
+const (
+	AnalysisDetailBrief    = "brief"    // 3-5 epics
+	AnalysisDetailDetailed = "detailed" // tasks with subtasks and acceptance criteria
+)
+
 // TaskBreakdownRequest represents AI analysis request
 type TaskBreakdownRequest struct {
 	Requirement string   `json:"requirement"`
 	TeamSkills  []string `json:"team_skills"`
 	ProjectType string   `json:"project_type"` // web, mobile, api, etc.
+	Detail      string   `json:"detail,omitempty"` // AnalysisDetailBrief, AnalysisDetailDetailed or empty for the default
+
+	// Optional context from the team's stored data, so the AI can pick owners and avoid duplicate work
+	TeamMembers   []TeamMember `json:"team_members,omitempty"`   // Current holds the open hours assigned
+	ExistingTasks []Task       `json:"existing_tasks,omitempty"` // open tasks already planned
 }
 
 // TaskBreakdownResponse represents AI analysis result
@@ -121,6 +144,12 @@ type TaskBreakdownResponse struct {
 	CriticalPath    []string `json:"critical_path"`
 	RiskFactors     []string `json:"risk_factors"`
 	Confidence      float64  `json:"confidence"` // 0-1
+	// ValidationNotes lists what ValidateBreakdown corrected or flagged
+	ValidationNotes []string `json:"validation_notes,omitempty"`
+	// Provider, Model and TokensUsed are set by the analyzer, not by the AI response
+	Provider   string `json:"-"`
+	Model      string `json:"-"`
+	TokensUsed int    `json:"-"`
 }
 
 // ProjectStats represents project analytics
@@ -0,0 +1,88 @@
+package domain
+
+import "time"
+
+// UtilizationTrendDays is the period shown by /utilization, four weeks ending today
+const UtilizationTrendDays = 28
+
+// A member is chronically overloaded when at least chronicOverloadDays of the recorded
+// days, and at least half of them, were above the overload threshold
+const (
+	OverloadThreshold   = 0.9
+	chronicOverloadDays = 7
+)
+
+// UtilizationPoint is a member's capacity and allocated open hours on a day
+type UtilizationPoint struct {
+	Day       time.Time
+	Capacity  float64
+	Allocated float64
+}
+
+// Utilization returns the share of the weekly capacity allocated
+func (p UtilizationPoint) Utilization() float64 {
+	if p.Capacity <= 0 {
+		return 0
+	}
+	return p.Allocated / p.Capacity
+}
+
+// UtilizationWeek summarizes the recorded days of one week
+type UtilizationWeek struct {
+	Start   time.Time
+	Days    int
+	Average float64
+	Peak    float64
+}
+
+// UtilizationTrend summarizes a member's utilization over UtilizationTrendDays
+type UtilizationTrend struct {
+	Start          time.Time
+	Weeks          []UtilizationWeek
+	Days           int
+	OverloadedDays int
+	Average        float64
+	Chronic        bool
+}
+
+// AnalyzeUtilizationTrend buckets the points of the last UtilizationTrendDays into weeks,
+// oldest first, and flags chronic overload
+func AnalyzeUtilizationTrend(points []UtilizationPoint, now time.Time) UtilizationTrend {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	trend := UtilizationTrend{Start: today.AddDate(0, 0, 1-UtilizationTrendDays)}
+
+	weeks := UtilizationTrendDays / 7
+	trend.Weeks = make([]UtilizationWeek, weeks)
+	for i := range trend.Weeks {
+		trend.Weeks[i].Start = trend.Start.AddDate(0, 0, 7*i)
+	}
+
+	total := 0.0
+	for _, point := range points {
+		day := time.Date(point.Day.Year(), point.Day.Month(), point.Day.Day(), 0, 0, 0, 0, now.Location())
+		offset := int(day.Sub(trend.Start).Hours()/24 + 0.5)
+		if offset < 0 || offset >= UtilizationTrendDays {
+			continue
+		}
+
+		utilization := point.Utilization()
+		week := &trend.Weeks[offset/7]
+		week.Average = (week.Average*float64(week.Days) + utilization) / float64(week.Days+1)
+		week.Days++
+		if utilization > week.Peak {
+			week.Peak = utilization
+		}
+
+		trend.Days++
+		total += utilization
+		if utilization > OverloadThreshold {
+			trend.OverloadedDays++
+		}
+	}
+
+	if trend.Days > 0 {
+		trend.Average = total / float64(trend.Days)
+	}
+	trend.Chronic = trend.OverloadedDays >= chronicOverloadDays && trend.OverloadedDays*2 >= trend.Days
+	return trend
+}
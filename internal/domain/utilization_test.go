@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyzeUtilizationTrend(t *testing.T) {
+	now := time.Date(2026, 10, 28, 15, 0, 0, 0, time.UTC)
+	day := func(offset int) time.Time { return time.Date(2026, 10, 28, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -offset) }
+
+	var points []UtilizationPoint
+	// Too old to count
+	points = append(points, UtilizationPoint{Day: day(40), Capacity: 40, Allocated: 80})
+	// First week at half capacity
+	for offset := 27; offset >= 21; offset-- {
+		points = append(points, UtilizationPoint{Day: day(offset), Capacity: 40, Allocated: 20})
+	}
+	// Last ten days overloaded
+	for offset := 9; offset >= 0; offset-- {
+		points = append(points, UtilizationPoint{Day: day(offset), Capacity: 40, Allocated: 44})
+	}
+
+	trend := AnalyzeUtilizationTrend(points, now)
+
+	if trend.Days != 17 || trend.OverloadedDays != 10 {
+		t.Fatalf("expected 10 of 17 days overloaded, got %d of %d", trend.OverloadedDays, trend.Days)
+	}
+	if !trend.Chronic {
+		t.Error("expected chronic overload")
+	}
+	if len(trend.Weeks) != 4 || !trend.Weeks[0].Start.Equal(day(27)) {
+		t.Fatalf("unexpected weeks: %+v", trend.Weeks)
+	}
+	if trend.Weeks[0].Average != 0.5 || trend.Weeks[0].Days != 7 {
+		t.Errorf("first week: expected 50%% over 7 days, got %.2f over %d", trend.Weeks[0].Average, trend.Weeks[0].Days)
+	}
+	if trend.Weeks[1].Days != 0 {
+		t.Errorf("second week: expected no data, got %d days", trend.Weeks[1].Days)
+	}
+	if trend.Weeks[3].Peak != 1.1 {
+		t.Errorf("last week: expected peak 110%%, got %.2f", trend.Weeks[3].Peak)
+	}
+
+	// A few overloaded days are not chronic
+	if AnalyzeUtilizationTrend(points[len(points)-3:], now).Chronic {
+		t.Error("three overloaded days flagged as chronic")
+	}
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Workload windows of /workload
+const (
+	WorkloadWindowWeek       = "week"
+	WorkloadWindowNextWeek   = "next_week"
+	WorkloadWindowMonth      = "month"
+	WorkloadWindowSprint     = "sprint"
+	WorkloadWindowNextSprint = "next_sprint"
+)
+
+// WorkloadWindow is a planning period; Start is inclusive and End exclusive, both at midnight
+type WorkloadWindow struct {
+	Name  string
+	Label string
+	Start time.Time
+	End   time.Time
+}
+
+// ParseWorkloadWindow resolves a window name relative to now. The current week, month and
+// sprint start today, so finished days do not count; the next ones are whole periods.
+func ParseWorkloadWindow(name string, now time.Time, sprintStart time.Time, sprintLengthDays int) (WorkloadWindow, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// Days since Monday
+	weekday := (int(today.Weekday()) + 6) % 7
+	nextMonday := today.AddDate(0, 0, 7-weekday)
+
+	window := WorkloadWindow{Name: strings.ToLower(strings.TrimSpace(name))}
+	switch window.Name {
+	case WorkloadWindowWeek:
+		window.Label = "this week"
+		window.Start, window.End = today, nextMonday
+	case WorkloadWindowNextWeek:
+		window.Label = "next week"
+		window.Start, window.End = nextMonday, nextMonday.AddDate(0, 0, 7)
+	case WorkloadWindowMonth:
+		window.Label = "this month"
+		window.Start = today
+		window.End = time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location())
+	case WorkloadWindowSprint, WorkloadWindowNextSprint:
+		sprints := RecentSprints(sprintStart, sprintLengthDays, now, 1)
+		if len(sprints) == 0 {
+			return WorkloadWindow{}, fmt.Errorf("sprints start on %s", sprintStart.Format("Jan 2 2006"))
+		}
+		sprint := sprints[0]
+		if window.Name == WorkloadWindowSprint {
+			window.Label = fmt.Sprintf("sprint %d", sprint.Number)
+			window.Start, window.End = today, sprint.End
+		} else {
+			window.Label = fmt.Sprintf("sprint %d", sprint.Number+1)
+			window.Start, window.End = sprint.End, sprint.End.AddDate(0, 0, int(sprint.End.Sub(sprint.Start).Hours()/24+0.5))
+		}
+	default:
+		return WorkloadWindow{}, fmt.Errorf("unknown window %q, use week, next_week, month, sprint or next_sprint", name)
+	}
+	return window, nil
+}
+
+// WorkingDays counts the working days of the calendar from start up to, not including, end
+func (c WorkCalendar) WorkingDays(start, end time.Time) int {
+	days := 0
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if c.IsWorkingDay(day) {
+			days++
+		}
+	}
+	return days
+}
+
+// WindowWorkload is the team's planned work within a window
+type WindowWorkload struct {
+	TeamWorkload
+	Window WorkloadWindow
+	// Undated holds the open hours of tasks without a due date per member ID; they
+	// cannot be placed in a window and are not part of the allocation
+	Undated map[string]float64
+}
+
+// TaskWindowHours returns the remaining hours of an open task that fall within the window.
+// The remaining work is spread evenly over the working days from today to the due date;
+// an overdue task lands entirely on today.
+func TaskWindowHours(task Task, window WorkloadWindow, now time.Time, calendar WorkCalendar) float64 {
+	remaining := task.EstimateHours - task.ActualHours
+	if task.DueDate == nil || task.Status == TaskStatusCompleted || remaining <= 0 {
+		return 0
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	due := time.Date(task.DueDate.Year(), task.DueDate.Month(), task.DueDate.Day(), 0, 0, 0, 0, now.Location())
+	if due.Before(today) {
+		due = today
+	}
+	end := due.AddDate(0, 0, 1)
+
+	spanDays := calendar.WorkingDays(today, end)
+	overlapStart, overlapEnd := laterOf(today, window.Start), earlierOf(end, window.End)
+	if spanDays == 0 {
+		// No working day left before the due date: the work is due on that day
+		if !due.Before(window.Start) && due.Before(window.End) {
+			return remaining
+		}
+		return 0
+	}
+	if !overlapStart.Before(overlapEnd) {
+		return 0
+	}
+	return remaining * float64(calendar.WorkingDays(overlapStart, overlapEnd)) / float64(spanDays)
+}
+
+// AnalyzeWindowWorkload allocates the open tasks of each member to the window by due date
+// and compares it with the member's capacity for the window's working days
+func AnalyzeWindowWorkload(teamID string, members []TeamMember, tasks []Task, window WorkloadWindow, now time.Time, calendar WorkCalendar) *WindowWorkload {
+	calendar = calendar.Normalize()
+	windowDays := calendar.WorkingDays(laterOf(window.Start, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())), window.End)
+
+	result := &WindowWorkload{
+		TeamWorkload: TeamWorkload{TeamID: teamID},
+		Window:       window,
+		Undated:      make(map[string]float64),
+	}
+
+	for _, member := range members {
+		capacity := member.Capacity * float64(windowDays) / float64(calendar.DaysPerWeek)
+		allocated := 0.0
+		for _, task := range tasks {
+			if task.AssignedTo != member.ID || task.Status == TaskStatusCompleted {
+				continue
+			}
+			if task.DueDate == nil {
+				if left := task.EstimateHours - task.ActualHours; left > 0 {
+					result.Undated[member.ID] += left
+				}
+				continue
+			}
+			allocated += TaskWindowHours(task, window, now, calendar)
+		}
+
+		workload := MemberWorkload{
+			MemberID: member.ID,
+			Username: member.Username,
+			Capacity: capacity,
+			Current:  allocated,
+			Status:   "available",
+		}
+		if capacity > 0 {
+			workload.Utilization = allocated / capacity
+		} else if allocated > 0 {
+			workload.Utilization = 1
+		}
+		if workload.Utilization > 0.9 {
+			workload.Status = "overloaded"
+		} else if workload.Utilization > 0.75 {
+			workload.Status = "busy"
+		}
+
+		result.Members = append(result.Members, workload)
+		result.Available += capacity
+		result.Allocated += allocated
+	}
+
+	if result.Available > 0 {
+		result.Utilization = result.Allocated / result.Available
+	}
+	return result
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierOf(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseWorkloadWindow(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+	sprintStart := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name       string
+		label      string
+		start, end time.Time
+	}{
+		{"week", "this week", day(10, 14), day(10, 19)},
+		{"next_week", "next week", day(10, 19), day(10, 26)},
+		{"MONTH", "this month", day(10, 14), day(11, 1)},
+		{"sprint", "sprint 1", day(10, 14), day(10, 19)},
+		{"next_sprint", "sprint 2", day(10, 19), day(11, 2)},
+	}
+
+	for _, test := range tests {
+		window, err := ParseWorkloadWindow(test.name, now, sprintStart, 14)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if window.Label != test.label || !window.Start.Equal(test.start) || !window.End.Equal(test.end) {
+			t.Errorf("%s: got %q %s - %s, expected %q %s - %s", test.name,
+				window.Label, window.Start.Format("Jan 2"), window.End.Format("Jan 2"),
+				test.label, test.start.Format("Jan 2"), test.end.Format("Jan 2"))
+		}
+	}
+
+	if _, err := ParseWorkloadWindow("year", now, sprintStart, 14); err == nil {
+		t.Error("expected an error for an unknown window")
+	}
+}
+
+func TestAnalyzeWindowWorkload(t *testing.T) {
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+	dueNextFriday := time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)
+	overdue := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	members := []TeamMember{
+		{ID: "alice", Username: "alice", Capacity: 40},
+		{ID: "bob", Username: "bob", Capacity: 40},
+	}
+	tasks := []Task{
+		// 8 working days until the due date, 3 this week and 5 next week
+		{ID: "1", AssignedTo: "alice", EstimateHours: 12, ActualHours: 2, Status: TaskStatusInProgress, DueDate: &dueNextFriday},
+		// Overdue work lands on today
+		{ID: "2", AssignedTo: "alice", EstimateHours: 4, Status: TaskStatusTodo, DueDate: &overdue},
+		{ID: "3", AssignedTo: "alice", EstimateHours: 5, Status: TaskStatusTodo},
+		{ID: "4", AssignedTo: "bob", EstimateHours: 30, Status: TaskStatusCompleted, DueDate: &dueNextFriday},
+	}
+
+	tests := []struct {
+		window        string
+		aliceCapacity float64
+		alicePlanned  float64
+	}{
+		{"week", 24, 3.75 + 4},
+		{"next_week", 40, 6.25},
+	}
+
+	for _, test := range tests {
+		window, err := ParseWorkloadWindow(test.window, now, now, 14)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.window, err)
+		}
+		workload := AnalyzeWindowWorkload("team_1", members, tasks, window, now, DefaultWorkCalendar())
+
+		alice, bob := workload.Members[0], workload.Members[1]
+		if math.Abs(alice.Capacity-test.aliceCapacity) > 0.01 || math.Abs(alice.Current-test.alicePlanned) > 0.01 {
+			t.Errorf("%s: alice got %.2fh of %.2fh, expected %.2fh of %.2fh", test.window,
+				alice.Current, alice.Capacity, test.alicePlanned, test.aliceCapacity)
+		}
+		if bob.Current != 0 || bob.Status != "available" {
+			t.Errorf("%s: completed work counted for bob: %.2fh, %s", test.window, bob.Current, bob.Status)
+		}
+		if workload.Undated["alice"] != 5 {
+			t.Errorf("%s: expected 5h undated for alice, got %.2f", test.window, workload.Undated["alice"])
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package fixtures holds the sample data shown by /demo and generated by cmd/seed, so
+// contributors and demo chats see the same team and backlog
+package fixtures
+
+import "yordamchi-dev-bot/internal/domain"
+
+// Member is a sample team member
+type Member struct {
+	Key      string
+	Username string
+	Role     string
+	Skills   []string
+}
+
+// Task is a sample task. Ages are in days before now and DependsOn holds indexes of
+// earlier tasks in the same backlog.
+type Task struct {
+	Title         string
+	Category      string
+	Member        string
+	Priority      int
+	EstimateHours float64
+	ActualHours   float64
+	Status        string
+	CreatedAgo    int
+	CompletedAgo  int
+	DependsOn     []int
+}
+
+// SampleMembers is the sample team
+var SampleMembers = []Member{
+	{Key: "alice", Username: "demo_alice", Role: "lead", Skills: []string{"go", "postgresql", "api"}},
+	{Key: "bob", Username: "demo_bob", Role: "developer", Skills: []string{"react", "typescript", "css"}},
+	{Key: "carol", Username: "demo_carol", Role: "developer", Skills: []string{"testing", "cypress", "automation"}},
+	{Key: "dave", Username: "demo_dave", Role: "developer", Skills: []string{"docker", "kubernetes", "ci"}},
+}
+
+// SampleTasks is the sample backlog: finished work over the last weeks, work in progress and upcoming tasks
+var SampleTasks = []Task{
+	{Title: "Design database schema", Category: "backend", Member: "alice", Priority: 1, EstimateHours: 6, ActualHours: 7, Status: domain.TaskStatusCompleted, CreatedAgo: 24, CompletedAgo: 20},
+	{Title: "Set up CI pipeline", Category: "devops", Member: "dave", Priority: 2, EstimateHours: 4, ActualHours: 3, Status: domain.TaskStatusCompleted, CreatedAgo: 24, CompletedAgo: 19},
+	{Title: "Product catalog API", Category: "backend", Member: "alice", Priority: 1, EstimateHours: 10, ActualHours: 12, Status: domain.TaskStatusCompleted, CreatedAgo: 20, CompletedAgo: 13, DependsOn: []int{0}},
+	{Title: "Product list page", Category: "frontend", Member: "bob", Priority: 2, EstimateHours: 8, ActualHours: 8, Status: domain.TaskStatusCompleted, CreatedAgo: 18, CompletedAgo: 9, DependsOn: []int{2}},
+	{Title: "Catalog API tests", Category: "testing", Member: "carol", Priority: 2, EstimateHours: 5, ActualHours: 4, Status: domain.TaskStatusCompleted, CreatedAgo: 14, CompletedAgo: 6, DependsOn: []int{2}},
+	{Title: "Shopping cart API", Category: "backend", Member: "alice", Priority: 1, EstimateHours: 8, ActualHours: 5, Status: domain.TaskStatusInProgress, CreatedAgo: 4},
+	{Title: "Cart page", Category: "frontend", Member: "bob", Priority: 2, EstimateHours: 8, ActualHours: 2, Status: domain.TaskStatusInProgress, CreatedAgo: 1},
+	{Title: "Staging environment", Category: "devops", Member: "dave", Priority: 3, EstimateHours: 6, ActualHours: 1, Status: domain.TaskStatusInProgress, CreatedAgo: 1, DependsOn: []int{1}},
+	{Title: "Checkout and payments", Category: "backend", Member: "alice", Priority: 1, EstimateHours: 12, Status: domain.TaskStatusTodo, CreatedAgo: 1, DependsOn: []int{5}},
+	{Title: "Checkout page", Category: "frontend", Member: "bob", Priority: 2, EstimateHours: 10, Status: domain.TaskStatusTodo, CreatedAgo: 1, DependsOn: []int{6, 8}},
+	{Title: "End-to-end checkout tests", Category: "testing", Member: "carol", Priority: 3, EstimateHours: 6, Status: domain.TaskStatusTodo, DependsOn: []int{9}},
+}
+
+// SampleProjectName is the name of the sample project built from SampleTasks
+const SampleProjectName = "Online Store"
+
+// How long ago the sample project started and how far ahead it is due
+const (
+	SampleStartedDays  = 25
+	SampleDeadlineDays = 21
+)
@@ -0,0 +1,402 @@
+package fixtures
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Options controls how much data Seed generates. The same Seed always produces the same
+// teams, members, projects, tasks, activities and analyses; dates are relative to Now.
+type Options struct {
+	Seed            int64
+	Teams           int
+	ProjectsPerTeam int
+	Now             time.Time
+}
+
+// Summary counts what Seed created
+type Summary struct {
+	Teams      int
+	Members    int
+	Projects   int
+	Tasks      int
+	Activities int
+	Analyses   int
+}
+
+// Seeded chats get negative group-like IDs counting down from here, far from real chats
+const seedChatBase int64 = -1009000000000
+
+// Seeded users get Telegram IDs counting up from here
+const seedUserBase int64 = 9000000000
+
+// ChatID returns the chat ID of the i-th seeded team
+func ChatID(team int) int64 {
+	return seedChatBase - int64(team+1)
+}
+
+// teamNames name the seeded teams; they cycle when more teams are requested
+var teamNames = []string{"Samarkand Soft", "Tashkent Devs", "Bukhara Cloud", "Khiva Labs", "Fergana Apps", "Nukus Systems"}
+
+// extraMembers join the sample team in seeded teams to vary their size and skills
+var extraMembers = []Member{
+	{Key: "dilnoza", Username: "dev_dilnoza", Role: "developer", Skills: []string{"flutter", "dart", "firebase"}},
+	{Key: "sardor", Username: "dev_sardor", Role: "developer", Skills: []string{"go", "grpc", "redis"}},
+	{Key: "madina", Username: "dev_madina", Role: "designer", Skills: []string{"figma", "ux", "css"}},
+	{Key: "jasur", Username: "dev_jasur", Role: "developer", Skills: []string{"python", "data", "ml"}},
+	{Key: "nodira", Username: "dev_nodira", Role: "qa", Skills: []string{"testing", "selenium", "api"}},
+	{Key: "bekzod", Username: "dev_bekzod", Role: "devops", Skills: []string{"terraform", "aws", "monitoring"}},
+}
+
+// categoryOwners are the sample members who usually take tasks of a category
+var categoryOwners = map[string]string{
+	"backend":  "alice",
+	"frontend": "bob",
+	"qa":       "carol",
+	"testing":  "carol",
+	"devops":   "dave",
+}
+
+// seedCommands are the commands recorded as member activity
+var seedCommands = []string{"/help", "/list_projects", "/task", "/workload", "/velocity", "/analyze", "/standup", "/health", "/list_team", "/hazil"}
+
+// Seed fills db with fixture data in one transaction, so a failed run leaves nothing behind.
+// Seeding a database twice fails on the existing IDs; use a fresh database instead.
+func Seed(ctx context.Context, db *database.DB, opts Options) (*Summary, error) {
+	if opts.Teams <= 0 {
+		opts.Teams = 3
+	}
+	if opts.ProjectsPerTeam <= 0 {
+		opts.ProjectsPerTeam = 2
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	summary := &Summary{}
+	err := db.WithTx(ctx, func(tx *database.DB) error {
+		*summary = Summary{}
+		seeder := &seeder{db: tx, rng: rand.New(rand.NewSource(opts.Seed)), now: opts.Now, summary: summary}
+		for team := 0; team < opts.Teams; team++ {
+			if err := seeder.seedTeam(team, opts.ProjectsPerTeam); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// seeder generates the data of one Seed call
+type seeder struct {
+	db      *database.DB
+	rng     *rand.Rand
+	now     time.Time
+	summary *Summary
+}
+
+// seededMember is a member created for a team
+type seededMember struct {
+	Member
+	ID     string
+	UserID int64
+}
+
+// seedTeam creates a team with its members, projects and history
+func (s *seeder) seedTeam(team, projects int) error {
+	chatID := ChatID(team)
+	teamID := fmt.Sprintf("team_%d", chatID)
+	name := teamNames[team%len(teamNames)]
+	if team >= len(teamNames) {
+		name = fmt.Sprintf("%s %d", name, team/len(teamNames)+1)
+	}
+
+	members, err := s.seedMembers(team, chatID, teamID)
+	if err != nil {
+		return err
+	}
+
+	for project := 0; project < projects; project++ {
+		var err error
+		if project == 0 {
+			err = s.seedSampleProject(chatID, teamID, name, members)
+		} else {
+			err = s.seedTemplateProject(chatID, teamID, project, members)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.seedDraftAnalysis(chatID, members[0]); err != nil {
+		return err
+	}
+	if err := s.seedActivity(members); err != nil {
+		return err
+	}
+
+	s.summary.Teams++
+	return nil
+}
+
+// seedMembers creates the sample team plus a few extra members and their users
+func (s *seeder) seedMembers(team int, chatID int64, teamID string) ([]seededMember, error) {
+	candidates := append([]Member{}, SampleMembers...)
+	for _, index := range s.rng.Perm(len(extraMembers))[:s.rng.Intn(3)] {
+		candidates = append(candidates, extraMembers[index])
+	}
+
+	members := make([]seededMember, 0, len(candidates))
+	for i, candidate := range candidates {
+		member := seededMember{
+			Member: candidate,
+			ID:     fmt.Sprintf("seed_%d_%s", chatID, candidate.Key),
+			UserID: seedUserBase + int64(team*100+i),
+		}
+		firstName := strings.ToUpper(candidate.Key[:1]) + candidate.Key[1:]
+		if err := s.db.CreateOrUpdateUser(member.UserID, candidate.Username, firstName, ""); err != nil {
+			return nil, err
+		}
+		err := s.db.CreateTeamMember(&database.TeamMember{
+			ID:       member.ID,
+			TeamID:   teamID,
+			UserID:   member.UserID,
+			Username: candidate.Username,
+			Role:     candidate.Role,
+			Skills:   candidate.Skills,
+			Capacity: []float64{40, 40, 32, 20}[s.rng.Intn(4)],
+		})
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	s.summary.Members += len(members)
+	return members, nil
+}
+
+// seedSampleProject creates the /demo backlog as the team's first project
+func (s *seeder) seedSampleProject(chatID int64, teamID, teamName string, members []seededMember) error {
+	project := &database.Project{
+		ID:          fmt.Sprintf("seed_%d_p1", chatID),
+		Name:        SampleProjectName,
+		Description: fmt.Sprintf("Online store built by %s", teamName),
+		TeamID:      teamID,
+		Status:      "active",
+	}
+	return s.seedProject(chatID, project, SampleTasks, SampleStartedDays, SampleDeadlineDays, members)
+}
+
+// seedTemplateProject creates a project from a random built-in template with random progress
+func (s *seeder) seedTemplateProject(chatID int64, teamID string, index int, members []seededMember) error {
+	templates := domain.ProjectTemplates()
+	template := templates[s.rng.Intn(len(templates))]
+	startedDays := 7 + s.rng.Intn(35)
+	progress := s.rng.Float64()
+
+	tasks := make([]Task, len(template.Tasks))
+	inProgress := 0
+	for i, starter := range template.Tasks {
+		task := Task{
+			Title:         starter.Title,
+			Category:      starter.Category,
+			Member:        s.assignee(starter.Category, members),
+			Priority:      starter.Priority,
+			EstimateHours: starter.EstimateHours,
+			Status:        domain.TaskStatusTodo,
+			CreatedAgo:    startedDays - s.rng.Intn(min(startedDays, 5)),
+			DependsOn:     starter.DependsOn,
+		}
+		switch {
+		case float64(i) < progress*float64(len(template.Tasks)):
+			task.Status = domain.TaskStatusCompleted
+			task.CompletedAgo = s.rng.Intn(task.CreatedAgo)
+			task.ActualHours = roundHalf(task.EstimateHours * (0.7 + s.rng.Float64()*0.6))
+		case inProgress < 2:
+			inProgress++
+			task.Status = domain.TaskStatusInProgress
+			task.ActualHours = roundHalf(task.EstimateHours * s.rng.Float64() * 0.6)
+		}
+		tasks[i] = task
+	}
+
+	project := &database.Project{
+		ID:          fmt.Sprintf("seed_%d_p%d", chatID, index+1),
+		Name:        template.Icon + " " + template.Name,
+		Description: template.Description,
+		TeamID:      teamID,
+		Status:      "active",
+	}
+	return s.seedProject(chatID, project, tasks, startedDays, 7+s.rng.Intn(35), members)
+}
+
+// seedProject creates a project with its backdated tasks and the analysis it was saved from
+func (s *seeder) seedProject(chatID int64, project *database.Project, tasks []Task, startedDays, deadlineDays int, members []seededMember) error {
+	if err := s.db.CreateProject(project); err != nil {
+		return err
+	}
+
+	memberIDs := make(map[string]string, len(members))
+	for _, member := range members {
+		memberIDs[member.Key] = member.ID
+	}
+	taskIDs := make([]string, len(tasks))
+	for i := range tasks {
+		taskIDs[i] = fmt.Sprintf("%s_t%d", project.ID, i+1)
+	}
+
+	breakdown := &domain.TaskBreakdownResponse{Confidence: 0.6 + float64(s.rng.Intn(35))/100}
+	for i, sample := range tasks {
+		var dependencies []string
+		for _, index := range sample.DependsOn {
+			dependencies = append(dependencies, taskIDs[index])
+		}
+
+		task := &database.Task{
+			ID:            taskIDs[i],
+			ProjectID:     project.ID,
+			Title:         sample.Title,
+			Description:   "Seeded task",
+			Category:      sample.Category,
+			EstimateHours: sample.EstimateHours,
+			Status:        sample.Status,
+			Priority:      sample.Priority,
+			AssignedTo:    memberIDs[sample.Member],
+			Dependencies:  dependencies,
+		}
+		if err := s.db.CreateTask(task); err != nil {
+			return err
+		}
+
+		var completedAt *time.Time
+		if sample.Status == domain.TaskStatusCompleted {
+			completed := s.now.AddDate(0, 0, -sample.CompletedAgo)
+			completedAt = &completed
+		}
+		if err := s.db.SetTaskTimeline(task.ID, s.now.AddDate(0, 0, -sample.CreatedAgo), completedAt, sample.ActualHours); err != nil {
+			return err
+		}
+
+		breakdown.Tasks = append(breakdown.Tasks, domain.Task{
+			ID:            task.ID,
+			Title:         task.Title,
+			Category:      task.Category,
+			EstimateHours: task.EstimateHours,
+			Priority:      task.Priority,
+			Dependencies:  dependencies,
+		})
+		breakdown.TotalEstimate += task.EstimateHours
+	}
+	s.summary.Tasks += len(tasks)
+
+	if err := s.db.SetProjectCreatedAt(project.ID, s.now.AddDate(0, 0, -startedDays)); err != nil {
+		return err
+	}
+	if err := s.db.SetProjectDeadline(project.ID, s.now.AddDate(0, 0, deadlineDays)); err != nil {
+		return err
+	}
+	s.summary.Projects++
+
+	requirement := fmt.Sprintf("%s: %s", project.Name, project.Description)
+	run, err := s.saveAnalysis(chatID, members[0].UserID, requirement, breakdown, s.now.AddDate(0, 0, -startedDays))
+	if err != nil {
+		return err
+	}
+	return s.db.MarkAnalysisRunSaved(run.ID, project.ID)
+}
+
+// seedDraftAnalysis records an analysis the team looked at but never saved as a project
+func (s *seeder) seedDraftAnalysis(chatID int64, lead seededMember) error {
+	templates := domain.ProjectTemplates()
+	template := templates[s.rng.Intn(len(templates))]
+
+	breakdown := &domain.TaskBreakdownResponse{Confidence: 0.5 + float64(s.rng.Intn(30))/100}
+	for i, starter := range template.Tasks {
+		breakdown.Tasks = append(breakdown.Tasks, domain.Task{
+			ID:            fmt.Sprintf("task_%d", i+1),
+			Title:         starter.Title,
+			Category:      starter.Category,
+			EstimateHours: starter.EstimateHours,
+			Priority:      starter.Priority,
+		})
+		breakdown.TotalEstimate += starter.EstimateHours
+	}
+
+	requirement := fmt.Sprintf("Draft: %s", template.Description)
+	_, err := s.saveAnalysis(chatID, lead.UserID, requirement, breakdown, s.now.AddDate(0, 0, -s.rng.Intn(7)))
+	return err
+}
+
+// saveAnalysis stores a breakdown as an analysis run of a random provider
+func (s *seeder) saveAnalysis(chatID, userID int64, requirement string, breakdown *domain.TaskBreakdownResponse, at time.Time) (*database.AnalysisRun, error) {
+	encoded, err := json.Marshal(breakdown)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := strings.Join(strings.Fields(strings.ToLower(requirement)), " ")
+	hash := sha256.Sum256([]byte(normalized))
+	run := &database.AnalysisRun{
+		ChatID:          chatID,
+		UserID:          userID,
+		RequirementHash: hex.EncodeToString(hash[:]),
+		Requirement:     requirement,
+		Provider:        []string{"claude", "openai", "gemini", "rules"}[s.rng.Intn(4)],
+		TokensUsed:      800 + s.rng.Intn(2400),
+		TotalEstimate:   breakdown.TotalEstimate,
+		TaskCount:       len(breakdown.Tasks),
+		Result:          string(encoded),
+		CreatedAt:       at,
+	}
+	if run.Provider == "rules" {
+		run.TokensUsed = 0
+	}
+	if err := s.db.SaveAnalysisRun(run); err != nil {
+		return nil, err
+	}
+
+	s.summary.Analyses++
+	return run, nil
+}
+
+// seedActivity records the commands members used over the last month
+func (s *seeder) seedActivity(members []seededMember) error {
+	for _, member := range members {
+		for count := 5 + s.rng.Intn(20); count > 0; count-- {
+			command := seedCommands[s.rng.Intn(len(seedCommands))]
+			at := s.now.Add(-time.Duration(s.rng.Intn(30*24*60)) * time.Minute)
+			if err := s.db.LogUserActivityAt(member.UserID, command, at); err != nil {
+				return err
+			}
+			s.summary.Activities++
+		}
+	}
+	return nil
+}
+
+// assignee picks the member for a task: usually the category's owner, sometimes anyone
+func (s *seeder) assignee(category string, members []seededMember) string {
+	if owner, ok := categoryOwners[category]; ok && s.rng.Intn(4) > 0 {
+		return owner
+	}
+	return members[s.rng.Intn(len(members))].Key
+}
+
+// roundHalf rounds hours to the nearest half hour
+func roundHalf(hours float64) float64 {
+	return math.Round(hours*2) / 2
+}
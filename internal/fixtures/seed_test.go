@@ -0,0 +1,75 @@
+package fixtures
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"yordamchi-dev-bot/database"
+)
+
+// seedSnapshot seeds a fresh database and describes what it contains
+func seedSnapshot(t *testing.T, seed int64) (*database.DB, string) {
+	t.Helper()
+	db, err := database.NewSQLiteDB(filepath.Join(t.TempDir(), "seed.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	now := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	summary, err := Seed(context.Background(), db, Options{Seed: seed, Teams: 2, ProjectsPerTeam: 3, Now: now})
+	if err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+	if summary.Teams != 2 || summary.Projects != 6 || summary.Analyses != 8 {
+		t.Fatalf("summary = %+v, want 2 teams, 6 projects and 8 analyses", summary)
+	}
+
+	snapshot := fmt.Sprintf("%+v\n", *summary)
+	for team := 0; team < 2; team++ {
+		members, _ := db.GetTeamMembersByChatID(ChatID(team))
+		for _, member := range members {
+			snapshot += fmt.Sprintf("%s %d %s %.0f\n", member.ID, member.UserID, member.Role, member.Capacity)
+		}
+		projects, _ := db.GetProjectsByChatID(ChatID(team))
+		for _, project := range projects {
+			tasks, _ := db.GetTasksByProjectID(project.ID)
+			for _, task := range tasks {
+				snapshot += fmt.Sprintf("%s %s %s %s %.1f\n", task.ID, task.Title, task.Status, task.AssignedTo, task.ActualHours)
+			}
+		}
+		runs, _ := db.GetAnalysisRuns(ChatID(team), 10)
+		for _, run := range runs {
+			snapshot += fmt.Sprintf("%s %s %d %v %s\n", run.Requirement, run.Provider, run.TokensUsed, run.Saved, run.ProjectID)
+		}
+	}
+	return db, snapshot
+}
+
+func TestSeedIsDeterministic(t *testing.T) {
+	db, first := seedSnapshot(t, 42)
+	_, second := seedSnapshot(t, 42)
+	if first != second {
+		t.Fatalf("the same seed produced different data:\n%s\nvs\n%s", first, second)
+	}
+	if _, other := seedSnapshot(t, 7); other == first {
+		t.Error("expected another seed to produce different data")
+	}
+
+	// The first project of every team is the /demo backlog
+	tasks, _ := db.GetTasksByProjectID(fmt.Sprintf("seed_%d_p1", ChatID(0)))
+	if len(tasks) != len(SampleTasks) {
+		t.Fatalf("sample project has %d tasks, want %d", len(tasks), len(SampleTasks))
+	}
+
+	// Seeding again fails on the existing IDs and leaves the data as it was
+	if _, err := Seed(context.Background(), db, Options{Seed: 42, Teams: 2, ProjectsPerTeam: 3}); err == nil {
+		t.Fatal("expected seeding the same database twice to fail")
+	}
+	if runs, _ := db.GetAnalysisRuns(ChatID(0), 10); len(runs) != 4 {
+		t.Errorf("analyses after the failed run = %d, want 4", len(runs))
+	}
+}
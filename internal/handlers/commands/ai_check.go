@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// AITestCommand lets bot operators check the configured AI provider keys
+type AITestCommand struct {
+	aiChain *services.AIChain
+	admins  *services.BotAdmins
+	logger  domain.Logger
+}
+
+// NewAITestCommand creates a new AI provider check command handler
+func NewAITestCommand(aiChain *services.AIChain, admins *services.BotAdmins, logger domain.Logger) *AITestCommand {
+	return &AITestCommand{
+		aiChain: aiChain,
+		admins:  admins,
+		logger:  logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *AITestCommand) CanHandle(command string) bool {
+	return command == "/ai_test"
+}
+
+// Description returns the command description
+func (c *AITestCommand) Description() string {
+	return "🩺 Check the configured AI provider keys (admins only)"
+}
+
+// MenuScope keeps the admin-only check out of the command menu
+func (c *AITestCommand) MenuScope() string {
+	return domain.MenuScopeHidden
+}
+
+// Usage returns the command usage instructions
+func (c *AITestCommand) Usage() string {
+	return "/ai_test - Check reachability, model and latency of each AI provider (admins only)"
+}
+
+// Category is empty, as chats cannot turn the command off
+func (c *AITestCommand) Category() string {
+	return ""
+}
+
+// Handle processes the ai_test command
+func (c *AITestCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing ai_test command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	if !c.admins.IsAdmin(cmd.User.TelegramID) {
+		return errorResponse("Only bot admins listed in `BOT_ADMIN_IDS` can run this command."), nil
+	}
+
+	checks := c.aiChain.Check(ctx)
+	c.logger.Info("AI providers checked", "user_id", cmd.User.TelegramID, "providers", len(checks))
+
+	return &domain.Response{
+		Text:      FormatAIChecks(checks),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// FormatAIChecks renders provider checks in fallback order
+func FormatAIChecks(checks []services.ProviderCheck) string {
+	var b strings.Builder
+	b.WriteString("🩺 **AI Provider Check**\n\n")
+
+	working := 0
+	for i, check := range checks {
+		branch := "├──"
+		if i == len(checks)-1 {
+			branch = "└──"
+		}
+
+		switch {
+		case !check.Configured:
+			b.WriteString(fmt.Sprintf("%s ⚪ **%s**: not configured\n", branch, check.Provider))
+		case check.OK():
+			working++
+			b.WriteString(fmt.Sprintf("%s ✅ **%s**: `%s` · %s\n", branch, check.Provider, check.Model, check.Latency.Round(time.Millisecond)))
+		default:
+			b.WriteString(fmt.Sprintf("%s ❌ **%s**: `%s` · %s\n", branch, check.Provider, check.Model, check.Problem))
+		}
+	}
+
+	b.WriteString("\n")
+	if working == 0 {
+		b.WriteString("⚠️ No provider answered, AI features use rule-based fallbacks.")
+	} else {
+		b.WriteString(fmt.Sprintf("🔁 %d provider(s) answering, tried in the order above.", working))
+	}
+	return b.String()
+}
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// AliasCommand manages per-chat command shortcuts such as /a -> /analyze
+type AliasCommand struct {
+	aliasService *services.AliasService
+	router       domain.Router
+	logger       domain.Logger
+}
+
+// NewAliasCommand creates a new alias command handler
+func NewAliasCommand(aliasService *services.AliasService, router domain.Router, logger domain.Logger) *AliasCommand {
+	return &AliasCommand{
+		aliasService: aliasService,
+		router:       router,
+		logger:       logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *AliasCommand) CanHandle(command string) bool {
+	return command == "/alias"
+}
+
+// Description returns the command description
+func (c *AliasCommand) Description() string {
+	return "🔗 Manage command shortcuts for this chat"
+}
+
+// Usage returns the command usage instructions
+func (c *AliasCommand) Usage() string {
+	return "/alias [list | add /short /command | remove /short] - Chat command shortcuts"
+}
+
+// Category is empty, as chats cannot turn the command off
+func (c *AliasCommand) Category() string {
+	return ""
+}
+
+// Handle processes the alias command
+func (c *AliasCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing alias command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/alias")))
+	if len(args) == 0 || strings.ToLower(args[0]) == "list" {
+		return &domain.Response{
+			Text:      c.formatAliases(c.aliasService.ListAliases(cmd.Chat.ID)),
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "add":
+		if len(args) < 3 {
+			return c.usageResponse(), nil
+		}
+		alias := services.NormalizeAlias(args[1])
+		target := strings.Join(args[2:], " ")
+		if !strings.HasPrefix(target, "/") {
+			target = "/" + target
+		}
+
+		if c.isRealCommand(alias) {
+			return errorResponse(fmt.Sprintf("`%s` is already a bot command and cannot be used as an alias", alias)), nil
+		}
+		targetCommand := strings.ToLower(strings.Fields(target)[0])
+		if !c.isRealCommand(targetCommand) {
+			return errorResponse(fmt.Sprintf("`%s` is not a known command. See /help", targetCommand)), nil
+		}
+
+		if err := c.aliasService.AddAlias(cmd.Chat.ID, alias, target); err != nil {
+			c.logger.Error("Failed to add alias", "chat_id", cmd.Chat.ID, "alias", alias, "error", err)
+			return errorResponse(err.Error()), nil
+		}
+
+		c.logger.Info("Alias added", "chat_id", cmd.Chat.ID, "alias", alias, "target", target)
+		return &domain.Response{
+			Text:      fmt.Sprintf("✅ **Alias saved**\n\n`%s` → `%s`\n\nExtra arguments are passed through, e.g. `%s something`.", alias, target, alias),
+			ParseMode: "Markdown",
+		}, nil
+
+	case "remove", "delete":
+		if len(args) < 2 {
+			return c.usageResponse(), nil
+		}
+		alias := services.NormalizeAlias(args[1])
+		if err := c.aliasService.RemoveAlias(cmd.Chat.ID, alias); err != nil {
+			return errorResponse(err.Error()), nil
+		}
+
+		c.logger.Info("Alias removed", "chat_id", cmd.Chat.ID, "alias", alias)
+		return &domain.Response{
+			Text:      fmt.Sprintf("🗑️ Alias `%s` removed", alias),
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	return c.usageResponse(), nil
+}
+
+// isRealCommand checks whether any registered handler owns the command
+func (c *AliasCommand) isRealCommand(command string) bool {
+	for _, handler := range c.router.GetHandlers() {
+		if handler.CanHandle(command) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatAliases formats the chat aliases for display
+func (c *AliasCommand) formatAliases(aliases []services.CommandAlias) string {
+	var response strings.Builder
+
+	response.WriteString("🔗 **Chat Command Aliases**\n\n")
+
+	if len(aliases) == 0 {
+		response.WriteString("No aliases defined yet.\n\n")
+	} else {
+		for i, alias := range aliases {
+			prefix := "├──"
+			if i == len(aliases)-1 {
+				prefix = "└──"
+			}
+			response.WriteString(fmt.Sprintf("%s `%s` → `%s`\n", prefix, alias.Alias, alias.Target))
+		}
+		response.WriteString("\n")
+	}
+
+	response.WriteString("**Manage:**\n")
+	response.WriteString("• `/alias add /a /analyze` - create a shortcut\n")
+	response.WriteString("• `/alias add /wl /workload` - another example\n")
+	response.WriteString("• `/alias remove /a` - delete a shortcut")
+
+	return response.String()
+}
+
+// usageResponse returns the command usage help
+func (c *AliasCommand) usageResponse() *domain.Response {
+	return errorResponse("Unknown alias option.\n\n" +
+		"**Examples:**\n" +
+		"• `/alias list`\n" +
+		"• `/alias add /a /analyze`\n" +
+		"• `/alias remove /a`")
+}
@@ -0,0 +1,307 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+)
+
+const (
+	// analysisHistoryLimit is how many runs /analyses lists
+	analysisHistoryLimit = 10
+	// maxStoredRequirement limits the requirement text kept with a run, in runes
+	maxStoredRequirement = 1000
+)
+
+// requirementHash identifies a requirement regardless of case and whitespace
+func requirementHash(requirement string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(requirement)), " ")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// shortenText cuts text to at most limit runes, marking the cut with an ellipsis
+func shortenText(text string, limit int) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) <= limit {
+		return string(runes)
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+// recordRun stores an analysis run for /analyses and returns its ID, or 0 when it could not be stored
+func (c *AnalyzeCommand) recordRun(cmd *domain.Command, requirement, label string, result *domain.TaskBreakdownResponse) int64 {
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		c.logger.Error("Failed to encode analysis run", "chat_id", cmd.Chat.ID, "error", err)
+		return 0
+	}
+
+	stored := requirement
+	if label != "" {
+		stored = label + ": " + requirement
+	}
+
+	run := &database.AnalysisRun{
+		ChatID:          cmd.Chat.ID,
+		UserID:          cmd.User.TelegramID,
+		RequirementHash: requirementHash(requirement),
+		Requirement:     shortenText(stored, maxStoredRequirement),
+		Provider:        result.Provider,
+		Model:           result.Model,
+		TokensUsed:      result.TokensUsed,
+		TotalEstimate:   result.TotalEstimate,
+		TaskCount:       len(result.Tasks),
+		Result:          string(encoded),
+	}
+	if err := c.db.SaveAnalysisRun(run); err != nil {
+		c.logger.Error("Failed to record analysis run", "chat_id", cmd.Chat.ID, "error", err)
+		return 0
+	}
+	return run.ID
+}
+
+// handleHistory processes /analyses [open id | save id [project_id] | compare id id]
+func (c *AnalyzeCommand) handleHistory(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/analyses")))
+	if len(args) == 0 || strings.ToLower(args[0]) == "list" {
+		return c.listRuns(cmd.Chat.ID)
+	}
+
+	ids := make([]int64, 0, 2)
+	for _, arg := range args[1:] {
+		if id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "open":
+		if len(ids) == 1 {
+			return c.openRun(ctx, cmd.Chat.ID, ids[0])
+		}
+	case "save":
+		if len(ids) == 1 && len(args) <= 3 {
+			projectID := ""
+			if len(args) == 3 {
+				projectID = args[2]
+			}
+			return c.saveRun(ctx, cmd.Chat.ID, ids[0], projectID)
+		}
+	case "compare":
+		if len(ids) == 2 {
+			return c.compareRuns(cmd.Chat.ID, ids[0], ids[1])
+		}
+	}
+
+	return errorResponse("Usage: `/analyses [open id | save id [project_id] | compare id id]`"), nil
+}
+
+// listRuns shows the chat's recent analysis runs
+func (c *AnalyzeCommand) listRuns(chatID int64) (*domain.Response, error) {
+	runs, err := c.db.GetAnalysisRuns(chatID, analysisHistoryLimit)
+	if err != nil {
+		c.logger.Error("Failed to load analysis runs", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to load analyses. Please try again."), nil
+	}
+	if len(runs) == 0 {
+		return &domain.Response{
+			Text:      "🗂️ No analyses yet. Try `/analyze Build user authentication with OAuth`.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	var response strings.Builder
+	response.WriteString("🗂️ **Recent Analyses**\n\n")
+	for i, run := range runs {
+		prefix, indent := "├──", "│  "
+		if i == len(runs)-1 {
+			prefix, indent = "└──", "   "
+		}
+		saved := ""
+		if run.Saved {
+			saved = " 💾"
+		}
+		response.WriteString(fmt.Sprintf("%s **#%d**%s %s\n", prefix, run.ID, saved, shortenText(run.Requirement, 60)))
+		response.WriteString(fmt.Sprintf("%s %d tasks · %.1fh · %s · %s\n",
+			indent, run.TaskCount, run.TotalEstimate, formatRunSource(run), run.CreatedAt.Format("Jan 2 15:04")))
+	}
+
+	response.WriteString("\n💡 `/analyses open id` to re-open, `/analyses save id` to save again, `/analyses compare id id` to compare")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// formatRunSource describes the provider, model and token cost of a run
+func formatRunSource(run database.AnalysisRun) string {
+	source := run.Provider
+	if run.Model != "" {
+		source += " " + run.Model
+	}
+	if run.TokensUsed == 0 {
+		return source
+	}
+	return fmt.Sprintf("%s, %d tokens", source, run.TokensUsed)
+}
+
+// loadRun loads a run of the chat and decodes its breakdown
+func (c *AnalyzeCommand) loadRun(chatID, id int64) (*database.AnalysisRun, *domain.TaskBreakdownResponse, *domain.Response) {
+	run, err := c.db.GetAnalysisRun(chatID, id)
+	if err != nil {
+		c.logger.Warn("Analysis run not found", "chat_id", chatID, "run_id", id, "error", err)
+		return nil, nil, errorResponse(fmt.Sprintf("Analysis #%d not found. See `/analyses` for recent runs.", id))
+	}
+
+	var result domain.TaskBreakdownResponse
+	if err := json.Unmarshal([]byte(run.Result), &result); err != nil {
+		c.logger.Error("Failed to decode analysis run", "run_id", id, "error", err)
+		return nil, nil, errorResponse(fmt.Sprintf("Analysis #%d could not be read.", id))
+	}
+	result.Provider = run.Provider
+	result.Model = run.Model
+	result.TokensUsed = run.TokensUsed
+	return run, &result, nil
+}
+
+// reopenRun makes a stored run the chat's current draft and returns the team members it was shown with
+func (c *AnalyzeCommand) reopenRun(chatID int64, run *database.AnalysisRun, result *domain.TaskBreakdownResponse) []domain.TeamMember {
+	var members []domain.TeamMember
+	stored, err := c.db.GetTeamMembersByChatID(chatID)
+	if err != nil {
+		c.logger.Warn("Failed to load team members for analysis", "chat_id", chatID, "error", err)
+	} else if len(stored) > 0 {
+		members = toDomainMembers(stored, loadOpenTasks(c.db, chatID, c.logger))
+	}
+
+	c.rememberDraft(chatID, run.ID, result.Tasks, members)
+	return members
+}
+
+// openRun shows a stored run again and makes it the draft for /analyze save
+func (c *AnalyzeCommand) openRun(ctx context.Context, chatID, id int64) (*domain.Response, error) {
+	run, result, errResponse := c.loadRun(chatID, id)
+	if errResponse != nil {
+		return errResponse, nil
+	}
+
+	members := c.reopenRun(chatID, run, result)
+
+	teamID := fmt.Sprintf("team_%d", chatID)
+	calendar := c.calendarService.GetCalendar(ctx, teamID)
+	estimates := c.estimationService.GetSettings(teamID)
+
+	header := fmt.Sprintf("🗂️ **Analysis #%d** · %s · %s\n", run.ID, run.CreatedAt.Format("Jan 2 15:04"), formatRunSource(*run))
+	if run.Saved {
+		header += fmt.Sprintf("💾 Saved to `%s`\n", run.ProjectID)
+	}
+	header += fmt.Sprintf("_%s_\n\n", shortenText(run.Requirement, 200))
+
+	return &domain.Response{
+		Text:        header + c.formatTaskBreakdown(result, members, calendar, estimates),
+		ParseMode:   "Markdown",
+		ReplyMarkup: draftKeyboard(result.Tasks),
+	}, nil
+}
+
+// saveRun saves the tasks of a stored run to a project
+func (c *AnalyzeCommand) saveRun(ctx context.Context, chatID, id int64, projectID string) (*domain.Response, error) {
+	run, result, errResponse := c.loadRun(chatID, id)
+	if errResponse != nil {
+		return errResponse, nil
+	}
+
+	c.reopenRun(chatID, run, result)
+	return c.saveDraft(ctx, chatID, projectID)
+}
+
+// compareRuns shows how two stored runs differ in estimate and tasks
+func (c *AnalyzeCommand) compareRuns(chatID, firstID, secondID int64) (*domain.Response, error) {
+	first, firstResult, errResponse := c.loadRun(chatID, firstID)
+	if errResponse != nil {
+		return errResponse, nil
+	}
+	second, secondResult, errResponse := c.loadRun(chatID, secondID)
+	if errResponse != nil {
+		return errResponse, nil
+	}
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("🔀 **Analysis #%d vs #%d**\n\n", first.ID, second.ID))
+	if first.RequirementHash == second.RequirementHash {
+		response.WriteString("✅ Same requirement\n\n")
+	} else {
+		response.WriteString("⚠️ Different requirements\n\n")
+	}
+
+	response.WriteString(fmt.Sprintf("├── Provider: %s → %s\n", formatRunSource(*first), formatRunSource(*second)))
+	response.WriteString(fmt.Sprintf("├── Tasks: %d → %d (%+d)\n", first.TaskCount, second.TaskCount, second.TaskCount-first.TaskCount))
+	response.WriteString(fmt.Sprintf("└── Estimate: %.1fh → %.1fh (%+.1fh)\n",
+		first.TotalEstimate, second.TotalEstimate, second.TotalEstimate-first.TotalEstimate))
+
+	added, removed, changed := diffBreakdowns(firstResult.Tasks, secondResult.Tasks)
+	writeTaskList(&response, fmt.Sprintf("➕ **Only in #%d:**", second.ID), added)
+	writeTaskList(&response, fmt.Sprintf("➖ **Only in #%d:**", first.ID), removed)
+	writeTaskList(&response, "✏️ **Estimate changed:**", changed)
+	if len(added) == 0 && len(removed) == 0 && len(changed) == 0 {
+		response.WriteString("\n🟰 Both runs have the same tasks and estimates.")
+	}
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// diffBreakdowns matches tasks by title and lists the ones only in the second run, only in
+// the first run, and those whose estimate changed
+func diffBreakdowns(first, second []domain.Task) (added, removed, changed []string) {
+	firstByTitle := make(map[string]domain.Task, len(first))
+	for _, task := range first {
+		firstByTitle[strings.ToLower(strings.TrimSpace(task.Title))] = task
+	}
+
+	seen := make(map[string]bool, len(second))
+	for _, task := range second {
+		key := strings.ToLower(strings.TrimSpace(task.Title))
+		seen[key] = true
+		previous, ok := firstByTitle[key]
+		switch {
+		case !ok:
+			added = append(added, fmt.Sprintf("%s (%s)", task.Title, domain.TaskEstimateRange(task)))
+		case domain.TaskEstimateRange(previous) != domain.TaskEstimateRange(task):
+			changed = append(changed, fmt.Sprintf("%s: %s → %s", task.Title, domain.TaskEstimateRange(previous), domain.TaskEstimateRange(task)))
+		}
+	}
+
+	for _, task := range first {
+		if !seen[strings.ToLower(strings.TrimSpace(task.Title))] {
+			removed = append(removed, fmt.Sprintf("%s (%s)", task.Title, domain.TaskEstimateRange(task)))
+		}
+	}
+	return added, removed, changed
+}
+
+// writeTaskList writes a titled tree of task lines, skipping empty lists
+func writeTaskList(response *strings.Builder, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	response.WriteString("\n" + title + "\n")
+	for i, line := range lines {
+		prefix := "├──"
+		if i == len(lines)-1 {
+			prefix = "└──"
+		}
+		response.WriteString(fmt.Sprintf("%s %s\n", prefix, line))
+	}
+}
@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// defaultTeamSkills are assumed when the chat has neither a stack profile nor team members
+var defaultTeamSkills = []string{"go", "react", "python", "docker", "postgresql", "javascript", "typescript", "kubernetes"}
+
+// maxContextTasks limits how many open tasks are sent with an analysis
+const maxContextTasks = 40
+
+// newBreakdownRequest builds an analysis request enriched with the chat's stack profile,
+// stored team members and open tasks. The stack profile takes precedence over the members'
+// skills; without either the default skills are used.
+func newBreakdownRequest(db *database.DB, chatID int64, requirement string, logger domain.Logger) domain.TaskBreakdownRequest {
+	settings, err := db.GetTeamSettings(fmt.Sprintf("team_%d", chatID))
+	if err != nil {
+		logger.Warn("Failed to load stack profile for analysis", "chat_id", chatID, "error", err)
+	}
+	req := domain.TaskBreakdownRequest{
+		Requirement: requirement,
+		TeamSkills:  defaultTeamSkills,
+		ProjectType: TeamProjectType(settings),
+	}
+	stack := TeamStack(settings)
+
+	members, err := db.GetTeamMembersByChatID(chatID)
+	if err != nil {
+		logger.Warn("Failed to load team members for analysis", "chat_id", chatID, "error", err)
+	}
+	openTasks := loadOpenTasks(db, chatID, logger)
+
+	if len(members) > 0 {
+		req.TeamMembers = toDomainMembers(members, openTasks)
+		req.TeamSkills = teamSkills(req.TeamMembers)
+	}
+	if len(stack) > 0 {
+		req.TeamSkills = stack
+	}
+
+	sort.SliceStable(openTasks, func(i, j int) bool {
+		return openTasks[i].Priority < openTasks[j].Priority
+	})
+	if len(openTasks) > maxContextTasks {
+		openTasks = openTasks[:maxContextTasks]
+	}
+	req.ExistingTasks = openTasks
+
+	return req
+}
+
+// LoadTeamWorkload returns the chat's stored members, with the remaining hours of their
+// open tasks as current workload, together with the open tasks
+func LoadTeamWorkload(db *database.DB, chatID int64, logger domain.Logger) ([]domain.TeamMember, []domain.Task, error) {
+	members, err := db.GetTeamMembersByChatID(chatID)
+	if err != nil {
+		return nil, nil, err
+	}
+	openTasks := loadOpenTasks(db, chatID, logger)
+	return toDomainMembers(members, openTasks), openTasks, nil
+}
+
+// loadOpenTasks returns the unfinished tasks of the chat's active projects
+func loadOpenTasks(db *database.DB, chatID int64, logger domain.Logger) []domain.Task {
+	projects, err := db.GetProjectsByChatID(chatID)
+	if err != nil {
+		logger.Warn("Failed to load projects for analysis", "chat_id", chatID, "error", err)
+		return nil
+	}
+
+	var open []domain.Task
+	for _, project := range projects {
+		if project.Status != "active" {
+			continue
+		}
+		tasks, err := db.GetTasksByProjectID(project.ID)
+		if err != nil {
+			logger.Warn("Failed to load project tasks for analysis", "project_id", project.ID, "error", err)
+			continue
+		}
+		for _, task := range ToDomainTasks(tasks) {
+			if task.Status != domain.TaskStatusCompleted {
+				open = append(open, task)
+			}
+		}
+	}
+	return open
+}
+
+// toDomainMembers converts stored members, setting their current workload to the
+// remaining hours of the open tasks assigned to them
+func toDomainMembers(members []database.TeamMember, openTasks []domain.Task) []domain.TeamMember {
+	remaining := make(map[string]float64)
+	for _, task := range openTasks {
+		if task.AssignedTo == "" {
+			continue
+		}
+		if left := task.EstimateHours - task.ActualHours; left > 0 {
+			remaining[task.AssignedTo] += left
+		}
+	}
+
+	result := make([]domain.TeamMember, len(members))
+	for i, member := range members {
+		result[i] = domain.TeamMember{
+			ID:       member.ID,
+			TeamID:   member.TeamID,
+			UserID:   member.UserID,
+			Username: member.Username,
+			Role:     member.Role,
+			Skills:   member.Skills,
+			Capacity: member.Capacity,
+			Current:  remaining[member.ID],
+		}
+	}
+	return result
+}
+
+// teamSkills returns the distinct skills of the members in alphabetical order
+func teamSkills(members []domain.TeamMember) []string {
+	seen := make(map[string]bool)
+	var skills []string
+	for _, member := range members {
+		for _, skill := range member.Skills {
+			skill = strings.ToLower(strings.TrimSpace(skill))
+			if skill != "" && !seen[skill] {
+				seen[skill] = true
+				skills = append(skills, skill)
+			}
+		}
+	}
+	sort.Strings(skills)
+	return skills
+}
+
+// resolveSuggestedOwners maps the usernames the AI put in "assigned_to" to member IDs,
+// clearing suggestions that do not name a team member
+func resolveSuggestedOwners(tasks []domain.Task, members []domain.TeamMember) {
+	byUsername := make(map[string]string, len(members))
+	for _, member := range members {
+		byUsername[strings.ToLower(member.Username)] = member.ID
+	}
+
+	for i := range tasks {
+		username := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tasks[i].AssignedTo), "@"))
+		tasks[i].AssignedTo = byUsername[username]
+	}
+}
+
+// memberUsername returns the username of a member ID, or "" when unknown
+func memberUsername(members []domain.TeamMember, memberID string) string {
+	for _, member := range members {
+		if member.ID == memberID {
+			return member.Username
+		}
+	}
+	return ""
+}
@@ -2,118 +2,239 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"yordamchi-dev-bot/database"
 	"yordamchi-dev-bot/internal/domain"
 	"yordamchi-dev-bot/internal/services"
 )
 
 // AnalyzeCommand handles AI-powered task analysis
 type AnalyzeCommand struct {
-	taskAnalyzer        *services.TaskAnalyzer
-	logger              domain.Logger
-	fileExtractor       *services.FileExtractor
-	telegramFileService *services.TelegramFileService
+	db                   *database.DB
+	taskAnalyzer         *services.TaskAnalyzer
+	logger               domain.Logger
+	fileExtractor        *services.FileExtractor
+	telegramFileService  *services.TelegramFileService
+	calendarService      *services.CalendarService
+	estimationService    *services.EstimationService
+	aiUsageService       *services.AIUsageService
+	fileUsageService     *services.FileUsageService
+	transcriptionService *services.TranscriptionService
+	jobQueue             *services.JobQueue
+	projectWebhooks      *services.ProjectWebhooks
+	teamManager          *services.TeamManager
+	drafts               map[int64]*analysisDraft
+	// batches marks the chats with a batch analysis queued or running
+	batches map[int64]bool
+	mutex                sync.Mutex
+	// responder shows "⏳ Analyzing..." until the breakdown is ready
+	responder domain.Responder
 }
 
 // NewAnalyzeCommand creates a new analyze command handler
-func NewAnalyzeCommand(taskAnalyzer *services.TaskAnalyzer, logger domain.Logger, fileExtractor *services.FileExtractor, telegramFileService *services.TelegramFileService) *AnalyzeCommand {
+func NewAnalyzeCommand(db *database.DB, taskAnalyzer *services.TaskAnalyzer, teamManager *services.TeamManager, logger domain.Logger, fileExtractor *services.FileExtractor, telegramFileService *services.TelegramFileService, calendarService *services.CalendarService, estimationService *services.EstimationService, aiUsageService *services.AIUsageService, fileUsageService *services.FileUsageService, transcriptionService *services.TranscriptionService, jobQueue *services.JobQueue, projectWebhooks *services.ProjectWebhooks) *AnalyzeCommand {
 	return &AnalyzeCommand{
-		taskAnalyzer:        taskAnalyzer,
-		logger:              logger,
-		fileExtractor:       fileExtractor,
-		telegramFileService: telegramFileService,
+		db:                   db,
+		taskAnalyzer:         taskAnalyzer,
+		logger:               logger,
+		fileExtractor:        fileExtractor,
+		telegramFileService:  telegramFileService,
+		calendarService:      calendarService,
+		estimationService:    estimationService,
+		aiUsageService:       aiUsageService,
+		fileUsageService:     fileUsageService,
+		transcriptionService: transcriptionService,
+		jobQueue:             jobQueue,
+		projectWebhooks:      projectWebhooks,
+		teamManager:          teamManager,
+		drafts:               make(map[int64]*analysisDraft),
+		batches:              make(map[int64]bool),
 	}
 }
 
+// SetResponder implements domain.ResponderAware
+func (c *AnalyzeCommand) SetResponder(responder domain.Responder) {
+	c.responder = responder
+}
+
 // Handle processes the analyze command for both text and file analysis
 func (c *AnalyzeCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
 	c.logger.Info("Processing analyze command", "user_id", cmd.User.TelegramID)
 
+	switch command, _ := splitFirstWord(strings.TrimSpace(cmd.Text)); command {
+	case "/analyses":
+		return c.handleHistory(ctx, cmd)
+	case "/analyze_batch":
+		return c.handleBatch(ctx, cmd)
+	}
+
+	// Saving and assigning act on the chat's latest breakdown
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/analyze")))
+	if cmd.Document == nil && len(args) == 3 && strings.ToLower(args[0]) == "share" {
+		return c.shareRun(cmd, args[1:])
+	}
+	if cmd.Document == nil && len(args) > 0 && len(args) <= 2 {
+		switch strings.ToLower(args[0]) {
+		case "save":
+			if len(args) == 1 {
+				return c.saveDraft(ctx, cmd.Chat.ID, "")
+			}
+			if strings.HasPrefix(args[1], "proj_") {
+				return c.saveDraft(ctx, cmd.Chat.ID, args[1])
+			}
+		case "assign":
+			if len(args) == 1 {
+				return c.applyAssignments(ctx, cmd.Chat.ID)
+			}
+		case "details":
+			if len(args) == 1 {
+				return c.showDetails(cmd.Chat.ID)
+			}
+		case "apply":
+			if len(args) == 1 {
+				return c.applyDiff(ctx, cmd.Chat.ID)
+			}
+		}
+	}
+
+	// Teams can keep requirements out of their group chat
+	if response := c.privateOnlyResponse(cmd); response != nil {
+		return response, nil
+	}
+
+	// --brief and --detailed choose the breakdown depth
+	detail, text := parseDetailFlag(cmd.Text)
+
+	// A photo, e.g. a screenshot of requirements, is read with OCR like an image file
+	if cmd.Document == nil && len(cmd.Photo) > 0 {
+		photo := domain.PhotoDocument(cmd.Photo, "photo.jpg")
+		cmd.Document = &photo
+	}
+
 	// Check if message contains a file attachment
 	if cmd.Document != nil {
-		return c.handleFileAnalysis(ctx, cmd)
+		return c.handleFileAnalysis(ctx, cmd, detail)
+	}
+
+	// A voice message, or "/analyze" in reply to one, is transcribed first
+	if voice := cmd.Voice; voice != nil || (cmd.ReplyToVoice != nil && len(strings.Fields(text)) < 2) {
+		if voice == nil {
+			voice = cmd.ReplyToVoice
+		}
+		return c.handleVoiceAnalysis(ctx, cmd, voice, detail)
 	}
 
 	// Handle text-based analysis
-	return c.handleTextAnalysis(ctx, cmd)
+	return c.handleTextAnalysis(ctx, cmd, text, detail)
 }
 
-// handleFileAnalysis processes uploaded files for analysis
-func (c *AnalyzeCommand) handleFileAnalysis(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
-	c.logger.Info("Processing file analysis",
-		"user_id", cmd.User.TelegramID,
-		"filename", cmd.Document.FileName,
-		"file_size", cmd.Document.FileSize)
-
-	// 1. Validate file
-	if err := c.fileExtractor.ValidateFile(cmd.Document); err != nil {
-		return &domain.Response{
-			Text: fmt.Sprintf("❌ **File validation failed:** %s\n\n"+
-				"**Supported formats:** %s\n"+
-				"**Maximum size:** 20MB",
-				err.Error(),
-				strings.Join(c.fileExtractor.GetSupportedFormats(), ", ")),
-			ParseMode: "Markdown",
-		}, nil
+// handleFileAnalysis processes uploaded files for analysis. The files of an album are
+// extracted one by one and analyzed together as one requirement.
+func (c *AnalyzeCommand) handleFileAnalysis(ctx context.Context, cmd *domain.Command, detail string) (*domain.Response, error) {
+	documents := cmd.Documents
+	if len(documents) == 0 {
+		documents = []domain.TelegramDocument{*cmd.Document}
 	}
 
-	// 2. Download file temporarily
-	tempFile, err := c.telegramFileService.DownloadFile(cmd.Document)
-	if err != nil {
-		c.logger.Error("Failed to download file", "error", err)
+	// Without OCR text in images is not read, so photos are left out of the analysis
+	documents, photos := c.withoutImages(documents)
+	if len(documents) == 0 {
 		return &domain.Response{
-			Text: "❌ **Download failed.** Please try uploading the file again.\n\n" +
-				"If the problem persists, try:\n" +
-				"• Reducing file size\n" +
-				"• Converting to a simpler format (TXT, MD)\n" +
-				"• Checking your internet connection",
+			Text: fmt.Sprintf("📷 **No documents to analyze:** %d photo(s) sent, and text in images is not read on this server.\n\n"+
+				"Send the requirements as a document (%s) or as text: `/analyze requirement`",
+				photos, strings.Join(c.fileExtractor.GetSupportedFormats(), ", ")),
 			ParseMode: "Markdown",
 		}, nil
 	}
 
-	// 3. Ensure cleanup
-	defer func() {
-		c.telegramFileService.CleanupFile(tempFile)
-	}()
+	c.logger.Info("Processing file analysis",
+		"user_id", cmd.User.TelegramID,
+		"filename", cmd.Document.FileName,
+		"file_size", cmd.Document.FileSize,
+		"files", len(documents),
+		"photos", photos,
+		"detail", detail)
+
+	// 1. Validate files
+	for i := range documents {
+		if err := c.fileExtractor.ValidateFile(&documents[i]); err != nil {
+			reason := err.Error()
+			if len(documents) > 1 {
+				reason = fmt.Sprintf("`%s`: %s", documents[i].FileName, reason)
+			}
+			return &domain.Response{
+				Text: fmt.Sprintf("❌ **File validation failed:** %s\n\n"+
+					"**Supported formats:** %s\n"+
+					"**Maximum size:** %dMB",
+					reason,
+					strings.Join(c.fileExtractor.GetSupportedFormats(), ", "),
+					c.fileExtractor.MaxFileSizeMB()),
+				ParseMode: "Markdown",
+			}, nil
+		}
+	}
 
-	// 4. Extract content from file
-	content, err := c.fileExtractor.ExtractContent(tempFile, cmd.Document.FileName)
-	if err != nil {
-		c.logger.Error("Failed to extract file content", "error", err, "filename", cmd.Document.FileName)
+	// 2. Check the team's monthly file quota and AI budget before downloading
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	var uploadBytes int64
+	for _, document := range documents {
+		uploadBytes += int64(document.FileSize)
+	}
+	if err := c.fileUsageService.CheckQuota(teamID, len(documents), uploadBytes); err != nil {
+		c.logger.Warn("File quota reached", "team_id", teamID, "error", err)
 		return &domain.Response{
-			Text: fmt.Sprintf("❌ **Content extraction failed:** %s\n\n"+
-				"**Troubleshooting:**\n"+
-				"• Ensure file is not corrupted\n"+
-				"• Try saving in a different format\n"+
-				"• For PDFs, ensure text is selectable (not scanned image)",
-				err.Error()),
+			Text:      "⚠️ **File quota reached:** " + err.Error() + ".\n\nCheck your limits with `/quota`.",
 			ParseMode: "Markdown",
 		}, nil
 	}
+	if err := c.aiUsageService.CheckBudget(teamID); err != nil {
+		return c.budgetResponse(err), nil
+	}
 
-	// 5. Check if content was extracted
-	if strings.TrimSpace(content) == "" {
+	// 3. Download and extract every file
+	var sections []string
+	var extracted []domain.TelegramDocument
+	for i := range documents {
+		content, failure := c.extractDocument(ctx, &documents[i])
+		if failure != nil {
+			return failure, nil
+		}
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+		extracted = append(extracted, documents[i])
+		if len(documents) > 1 {
+			content = fmt.Sprintf("=== File: %s ===\n%s", documents[i].FileName, content)
+		}
+		sections = append(sections, content)
+	}
+	c.fileUsageService.RecordFiles(teamID, len(documents), uploadBytes)
+
+	// 4. Check if content was extracted
+	if len(sections) == 0 {
 		return &domain.Response{
 			Text: fmt.Sprintf("❌ **No readable content found** in `%s`\n\n"+
 				"**Possible causes:**\n"+
 				"• File contains only images/graphics\n"+
 				"• File is corrupted or password-protected\n"+
-				"• Text is embedded in images (OCR not supported yet)\n\n"+
+				"• Text in images or scanned pages could not be recognized\n\n"+
 				"**Suggestion:** Try uploading a plain text file with your requirements.",
-				cmd.Document.FileName),
+				documentNames(documents)),
 			ParseMode: "Markdown",
 		}, nil
 	}
+	content := strings.Join(sections, "\n\n")
 
-	// 6. Analyze extracted content
-	req := domain.TaskBreakdownRequest{
-		Requirement: content,
-		TeamSkills:  []string{"go", "react", "python", "docker", "postgresql", "javascript", "typescript", "kubernetes"},
-		ProjectType: "web",
-	}
+	// 5. Analyze extracted content
+	req := newBreakdownRequest(c.db, cmd.Chat.ID, content, c.logger)
+	req.Detail = detail
 
+	placeholder := sendPlaceholder(ctx, c.responder, cmd, analyzingPlaceholder, c.logger)
 	result, err := c.taskAnalyzer.AnalyzeRequirement(req)
 	if err != nil {
 		c.logger.Error("File content analysis failed", "error", err, "filename", cmd.Document.FileName)
@@ -123,40 +244,127 @@ func (c *AnalyzeCommand) handleFileAnalysis(ctx context.Context, cmd *domain.Com
 				"• Simplifying the requirements document\n" +
 				"• Using more specific technical language\n" +
 				"• Breaking down into smaller sections",
-			ParseMode: "Markdown",
+			ParseMode:     "Markdown",
+			EditMessageID: placeholder,
 		}, nil
 	}
 
-	// 7. Format results with file context
-	responseText := c.formatFileAnalysisResults(result, cmd.Document)
+	c.aiUsageService.RecordAnalysis(teamID)
+	resolveSuggestedOwners(result.Tasks, req.TeamMembers)
+	suggestAssignments(c.teamManager, result.Tasks, req.TeamMembers, req.ExistingTasks)
+	runID := c.recordRun(cmd, content, "📄 "+documentNames(extracted), result)
+	c.rememberDraft(cmd.Chat.ID, runID, result.Tasks, req.TeamMembers)
+
+	// Re-analyzing requirements already saved to the project shows what changed
+	if response := c.diffResponse(cmd.Chat.ID, result, placeholder); response != nil {
+		return response, nil
+	}
+
+	// 6. Format results with file context
+	calendar := c.calendarService.GetCalendar(ctx, teamID)
+	estimates := c.estimationService.GetSettings(teamID)
+	responseText := c.formatFileAnalysisResults(result, req.TeamMembers, documents, len(documents)-len(extracted), photos, calendar, estimates)
 
 	c.logger.Info("File analysis completed",
 		"user_id", cmd.User.TelegramID,
 		"filename", cmd.Document.FileName,
+		"files", len(extracted),
 		"content_length", len(content),
 		"tasks_count", len(result.Tasks),
 		"total_estimate", result.TotalEstimate,
 		"confidence", result.Confidence)
 
 	return &domain.Response{
-		Text:      responseText,
-		ParseMode: "Markdown",
+		Text:          responseText,
+		ParseMode:     "Markdown",
+		ReplyMarkup:   c.analysisKeyboard(cmd, runID, result.Tasks),
+		EditMessageID: placeholder,
 	}, nil
 }
 
+// extractDocument downloads a file and extracts its text, or returns the response
+// explaining why it failed
+func (c *AnalyzeCommand) extractDocument(ctx context.Context, document *domain.TelegramDocument) (string, *domain.Response) {
+	tempFile, err := c.telegramFileService.DownloadFile(ctx, document)
+	if err != nil {
+		c.logger.Error("Failed to download file", "error", err, "filename", document.FileName)
+		return "", &domain.Response{
+			Text: "❌ **Download failed.** Please try uploading the file again.\n\n" +
+				"If the problem persists, try:\n" +
+				"• Reducing file size\n" +
+				"• Converting to a simpler format (TXT, MD)\n" +
+				"• Checking your internet connection",
+			ParseMode: "Markdown",
+		}
+	}
+	defer c.telegramFileService.CleanupFile(tempFile)
+
+	content, err := c.fileExtractor.ExtractContent(tempFile, document.FileName)
+	var rejected *services.FileRejectedError
+	if errors.As(err, &rejected) {
+		return "", &domain.Response{
+			Text: fmt.Sprintf("🚫 **File rejected:** `%s`, %s.\n\n"+
+				"Only real documents are processed. Upload the original file instead of a renamed one.",
+				document.FileName, rejected.Reason),
+			ParseMode: "Markdown",
+		}
+	}
+	if err != nil {
+		c.logger.Error("Failed to extract file content", "error", err, "filename", document.FileName)
+		return "", &domain.Response{
+			Text: fmt.Sprintf("❌ **Content extraction failed** for `%s`: %s\n\n"+
+				"**Troubleshooting:**\n"+
+				"• Ensure file is not corrupted\n"+
+				"• Try saving in a different format\n"+
+				"• For PDFs, ensure text is selectable (not scanned image)",
+				document.FileName, err.Error()),
+			ParseMode: "Markdown",
+		}
+	}
+	return content, nil
+}
+
+// withoutImages removes the images the extractor cannot read, e.g. the photos of an album,
+// and returns how many were removed
+func (c *AnalyzeCommand) withoutImages(documents []domain.TelegramDocument) ([]domain.TelegramDocument, int) {
+	var kept []domain.TelegramDocument
+	for _, document := range documents {
+		if strings.HasPrefix(strings.ToLower(document.MimeType), "image/") && !c.fileExtractor.IsSupported(document.FileName) {
+			continue
+		}
+		kept = append(kept, document)
+	}
+	return kept, len(documents) - len(kept)
+}
+
+// documentNames lists file names, e.g. "spec.pdf" or "spec.pdf +2 more"
+func documentNames(documents []domain.TelegramDocument) string {
+	if len(documents) == 0 {
+		return ""
+	}
+	if len(documents) == 1 {
+		return documents[0].FileName
+	}
+	return fmt.Sprintf("%s +%d more", documents[0].FileName, len(documents)-1)
+}
+
 // handleTextAnalysis handles traditional text-based analysis
-func (c *AnalyzeCommand) handleTextAnalysis(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+func (c *AnalyzeCommand) handleTextAnalysis(ctx context.Context, cmd *domain.Command, text, detail string) (*domain.Response, error) {
 	// Parse command arguments (everything after /analyze)
-	parts := strings.Fields(cmd.Text)
+	parts := strings.Fields(text)
 	if len(parts) < 2 {
 		return &domain.Response{
 			Text: "📋 **AI Requirements Analysis**\n\n" +
 				"**Text Analysis:**\n" +
 				"`/analyze Build user authentication with OAuth`\n\n" +
 				"**File Analysis:**\n" +
-				"Upload any document (PDF, DOCX, TXT, MD, XLSX) with your requirements\n\n" +
+				"Upload any document (PDF, DOCX, TXT, MD, XLSX) or screenshot with your requirements, or several at once as an album\n\n" +
+				"**Voice:**\n" +
+				"Send a voice message in the private chat, or reply `/analyze` to one in a group\n\n" +
 				"**Supported formats:** " + strings.Join(c.fileExtractor.GetSupportedFormats(), ", ") + "\n" +
-				"**Maximum size:** 20MB\n\n" +
+				fmt.Sprintf("**Maximum size:** %dMB\n\n", c.fileExtractor.MaxFileSizeMB()) +
+				"**Depth:**\n" +
+				"`--brief` for 3-5 epics, `--detailed` for subtasks and acceptance criteria\n\n" +
 				"**Tips for better analysis:**\n" +
 				"• Be specific about technologies (React, Go, PostgreSQL)\n" +
 				"• Include project scope (backend, frontend, full-stack)\n" +
@@ -168,14 +376,17 @@ func (c *AnalyzeCommand) handleTextAnalysis(ctx context.Context, cmd *domain.Com
 
 	requirement := strings.Join(parts[1:], " ")
 
-	// Create analysis request with default team skills
-	req := domain.TaskBreakdownRequest{
-		Requirement: requirement,
-		TeamSkills:  []string{"go", "react", "python", "docker", "postgresql", "javascript", "typescript", "kubernetes"},
-		ProjectType: "web", // Default to web project
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	if err := c.aiUsageService.CheckBudget(teamID); err != nil {
+		return c.budgetResponse(err), nil
 	}
 
+	// Create analysis request with the team's members and open tasks when stored
+	req := newBreakdownRequest(c.db, cmd.Chat.ID, requirement, c.logger)
+	req.Detail = detail
+
 	// Analyze with TaskAnalyzer
+	placeholder := sendPlaceholder(ctx, c.responder, cmd, analyzingPlaceholder, c.logger)
 	result, err := c.taskAnalyzer.AnalyzeRequirement(req)
 	if err != nil {
 		c.logger.Error("Task analysis failed", "error", err, "requirement", requirement)
@@ -186,12 +397,26 @@ func (c *AnalyzeCommand) handleTextAnalysis(ctx context.Context, cmd *domain.Com
 				"• Include technology stack details\n" +
 				"• Specify project scope and goals\n" +
 				"• Provide concrete user stories",
-			ParseMode: "Markdown",
+			ParseMode:     "Markdown",
+			EditMessageID: placeholder,
 		}, nil
 	}
 
+	c.aiUsageService.RecordAnalysis(teamID)
+	resolveSuggestedOwners(result.Tasks, req.TeamMembers)
+	suggestAssignments(c.teamManager, result.Tasks, req.TeamMembers, req.ExistingTasks)
+	runID := c.recordRun(cmd, requirement, "", result)
+	c.rememberDraft(cmd.Chat.ID, runID, result.Tasks, req.TeamMembers)
+
+	// Re-analyzing requirements already saved to the project shows what changed
+	if response := c.diffResponse(cmd.Chat.ID, result, placeholder); response != nil {
+		return response, nil
+	}
+
 	// Format and send results
-	responseText := c.formatTaskBreakdown(result)
+	calendar := c.calendarService.GetCalendar(ctx, teamID)
+	estimates := c.estimationService.GetSettings(teamID)
+	responseText := c.formatTaskBreakdown(result, req.TeamMembers, calendar, estimates)
 
 	c.logger.Info("Text analysis completed",
 		"user_id", cmd.User.TelegramID,
@@ -200,13 +425,24 @@ func (c *AnalyzeCommand) handleTextAnalysis(ctx context.Context, cmd *domain.Com
 		"confidence", result.Confidence)
 
 	return &domain.Response{
-		Text:      responseText,
-		ParseMode: "Markdown",
+		Text:          responseText,
+		ParseMode:     "Markdown",
+		ReplyMarkup:   c.analysisKeyboard(cmd, runID, result.Tasks),
+		EditMessageID: placeholder,
 	}, nil
 }
 
+// budgetResponse explains that the team's monthly AI analyses are used up
+func (c *AnalyzeCommand) budgetResponse(err error) *domain.Response {
+	c.logger.Warn("AI analysis budget reached", "error", err)
+	return &domain.Response{
+		Text:      "⚠️ **AI budget reached:** " + err.Error() + ".\n\nCheck your limits with `/quota`.",
+		ParseMode: "Markdown",
+	}
+}
+
 // formatTaskBreakdown formats the analysis results for display
-func (c *AnalyzeCommand) formatTaskBreakdown(result *domain.TaskBreakdownResponse) string {
+func (c *AnalyzeCommand) formatTaskBreakdown(result *domain.TaskBreakdownResponse, members []domain.TeamMember, calendar domain.WorkCalendar, estimates domain.EstimateSettings) string {
 	var response strings.Builder
 
 	response.WriteString("📋 **Task Breakdown Analysis**\n\n")
@@ -243,22 +479,25 @@ func (c *AnalyzeCommand) formatTaskBreakdown(result *domain.TaskBreakdownRespons
 			categoryName = strings.Title(category) + " Tasks"
 		}
 
-		categoryTotal := 0.0
-		response.WriteString(fmt.Sprintf("%s **%s** (Est: %.1fh)\n", icon, categoryName, getCategoryTotal(tasks)))
+		categoryTotal := domain.TotalEstimateRange(tasks)
+		response.WriteString(fmt.Sprintf("%s **%s** (Est: %sh)\n", icon, categoryName, domain.FormatHours(categoryTotal.Likely)))
 
 		for _, task := range tasks {
 			priorityIcon := getPriorityIcon(task.Priority)
-			response.WriteString(fmt.Sprintf("├── %s %s - %.1fh\n", priorityIcon, task.Title, task.EstimateHours))
-			categoryTotal += task.EstimateHours
+			response.WriteString(fmt.Sprintf("├── %s %s - %s%s%s\n", priorityIcon, task.Title, estimates.FormatRange(domain.TaskEstimateRange(task)), formatOwner(members, task), formatDetailMarker(task)))
 		}
 
-		response.WriteString(fmt.Sprintf("└── **Subtotal: %.1f hours**\n\n", categoryTotal))
+		response.WriteString(fmt.Sprintf("└── **Subtotal: %s**\n\n", categoryTotal))
 	}
 
-	// Total estimate with developer days calculation
-	devDays := result.TotalEstimate / 8
-	response.WriteString(fmt.Sprintf("⏱️ **Total Estimate: %.1f hours (%.1f developer days)**\n\n",
-		result.TotalEstimate, devDays))
+	// Total estimate with developer days calculation based on the team calendar
+	total := totalEstimateRange(result)
+	response.WriteString(fmt.Sprintf("⏱️ **Total Estimate: %s, %.1f developer days**\n",
+		total, calendar.DeveloperDays(total.Likely)))
+	if estimates.UsePoints() {
+		response.WriteString(fmt.Sprintf("🎲 **Story Points: %d**\n", estimates.TotalPoints(result.Tasks)))
+	}
+	response.WriteString(formatProjection(result, calendar))
 
 	// Recommended team
 	if len(result.RecommendedTeam) > 0 {
@@ -287,8 +526,13 @@ func (c *AnalyzeCommand) formatTaskBreakdown(result *domain.TaskBreakdownRespons
 	// Analysis confidence and next steps
 	confidenceEmoji := getConfidenceEmoji(result.Confidence)
 	response.WriteString(fmt.Sprintf("📊 **Analysis Confidence:** %s %.0f%%\n\n", confidenceEmoji, result.Confidence*100))
+	response.WriteString(formatValidationNotes(result.ValidationNotes))
+	if hasTaskDetails(result.Tasks) {
+		response.WriteString("📎 Tap **Show more** for subtasks and acceptance criteria\n\n")
+	}
 
 	response.WriteString("**Next Steps:**\n")
+	response.WriteString("• Use `/analyze save` to add these tasks to your active project\n")
 	response.WriteString("• Use `/create_project project_name` to create a project\n")
 	response.WriteString("• Use `/add_member @user skills` to build your team\n")
 	response.WriteString("• Use `/workload` to check team capacity")
@@ -297,21 +541,41 @@ func (c *AnalyzeCommand) formatTaskBreakdown(result *domain.TaskBreakdownRespons
 }
 
 // formatFileAnalysisResults formats analysis results with file context
-func (c *AnalyzeCommand) formatFileAnalysisResults(result *domain.TaskBreakdownResponse, document *domain.TelegramDocument) string {
+func (c *AnalyzeCommand) formatFileAnalysisResults(result *domain.TaskBreakdownResponse, members []domain.TeamMember, documents []domain.TelegramDocument, unreadable, photos int, calendar domain.WorkCalendar, estimates domain.EstimateSettings) string {
 	var response strings.Builder
 
 	// File header with metadata
 	response.WriteString("📄 **File Analysis Complete**\n\n")
-	response.WriteString(fmt.Sprintf("**File:** `%s`\n", document.FileName))
-	response.WriteString(fmt.Sprintf("**Size:** %s\n", c.telegramFileService.GetFileSize(document.FileSize)))
-	response.WriteString(fmt.Sprintf("**Type:** %s\n\n", document.MimeType))
+	if len(documents) == 1 {
+		document := documents[0]
+		response.WriteString(fmt.Sprintf("**File:** `%s`\n", document.FileName))
+		response.WriteString(fmt.Sprintf("**Size:** %s\n", c.telegramFileService.GetFileSize(document.FileSize)))
+		response.WriteString(fmt.Sprintf("**Type:** %s\n\n", document.MimeType))
+	} else {
+		response.WriteString(fmt.Sprintf("**Files:** %d analyzed together\n", len(documents)))
+		for _, document := range documents {
+			response.WriteString(fmt.Sprintf("• `%s` (%s)\n", document.FileName, c.telegramFileService.GetFileSize(document.FileSize)))
+		}
+		if unreadable > 0 {
+			response.WriteString(fmt.Sprintf("⚠️ %d file(s) had no readable text and were skipped\n", unreadable))
+		}
+		response.WriteString("\n")
+	}
+	if photos > 0 {
+		response.WriteString(fmt.Sprintf("📷 %d photo(s) were skipped, text in images is not read on this server\n\n", photos))
+	}
 
 	// Analysis summary
 	response.WriteString("🤖 **AI Analysis Summary:**\n")
 	response.WriteString(fmt.Sprintf("├── **Tasks Generated:** %d\n", len(result.Tasks)))
-	response.WriteString(fmt.Sprintf("├── **Total Estimate:** %.1f hours (%.1f days)\n", result.TotalEstimate, result.TotalEstimate/8))
+	total := totalEstimateRange(result)
+	response.WriteString(fmt.Sprintf("├── **Total Estimate:** %s, %.1f days\n", total, calendar.DeveloperDays(total.Likely)))
+	if estimates.UsePoints() {
+		response.WriteString(fmt.Sprintf("├── **Story Points:** %d\n", estimates.TotalPoints(result.Tasks)))
+	}
 	confidence := getConfidenceEmoji(result.Confidence)
 	response.WriteString(fmt.Sprintf("└── **Confidence:** %s %.0f%%\n\n", confidence, result.Confidence*100))
+	response.WriteString(formatValidationNotes(result.ValidationNotes))
 
 	// Task breakdown by category
 	response.WriteString("📋 **Task Breakdown:**\n\n")
@@ -336,8 +600,7 @@ func (c *AnalyzeCommand) formatFileAnalysisResults(result *domain.TaskBreakdownR
 		}
 
 		categoryName := strings.Title(category)
-		categoryTotal := getCategoryTotal(tasks)
-		response.WriteString(fmt.Sprintf("%s **%s** (%.1fh)\n", icon, categoryName, categoryTotal))
+		response.WriteString(fmt.Sprintf("%s **%s** · %s\n", icon, categoryName, domain.TotalEstimateRange(tasks)))
 
 		// Show up to 3 tasks per category to keep response manageable
 		maxTasks := 3
@@ -348,7 +611,7 @@ func (c *AnalyzeCommand) formatFileAnalysisResults(result *domain.TaskBreakdownR
 			}
 
 			priority := getPriorityIcon(task.Priority)
-			response.WriteString(fmt.Sprintf("├── %s %s (%.1fh)\n", priority, task.Title, task.EstimateHours))
+			response.WriteString(fmt.Sprintf("├── %s %s (%s)%s%s\n", priority, task.Title, estimates.FormatRange(domain.TaskEstimateRange(task)), formatOwner(members, task), formatDetailMarker(task)))
 		}
 		response.WriteString("\n")
 	}
@@ -371,8 +634,14 @@ func (c *AnalyzeCommand) formatFileAnalysisResults(result *domain.TaskBreakdownR
 		response.WriteString("\n")
 	}
 
+	response.WriteString(formatProjection(result, calendar))
+	if hasTaskDetails(result.Tasks) {
+		response.WriteString("📎 Tap **Show more** for subtasks and acceptance criteria\n\n")
+	}
+
 	// Next steps
 	response.WriteString("🚀 **Next Steps:**\n")
+	response.WriteString("• Use `/analyze save` to add these tasks to your active project\n")
 	response.WriteString("• Use `/create_project project_name` to create a project\n")
 	response.WriteString("• Use `/add_member @user skills` to build your team\n")
 	response.WriteString("• Use `/workload` to analyze team capacity\n")
@@ -381,6 +650,66 @@ func (c *AnalyzeCommand) formatFileAnalysisResults(result *domain.TaskBreakdownR
 	return response.String()
 }
 
+// formatProjection formats the projected finish dates of the estimate range using the team calendar
+func formatProjection(result *domain.TaskBreakdownResponse, calendar domain.WorkCalendar) string {
+	developers := len(result.RecommendedTeam)
+	if developers == 0 {
+		developers = 1
+	}
+
+	earliest, likely, latest := calendar.CompletionRange(time.Now(), totalEstimateRange(result), developers)
+	finish := likely.Format("Mon, Jan 2 2006")
+	if !earliest.Equal(latest) {
+		finish = fmt.Sprintf("%s – %s (most likely %s)", earliest.Format("Mon, Jan 2"), latest.Format("Mon, Jan 2 2006"), likely.Format("Mon, Jan 2"))
+	}
+	return fmt.Sprintf("📅 **Projected Finish:** %s\n└── %d developer(s), %.0fh/day, %d-day week\n\n",
+		finish, developers, calendar.HoursPerDay, calendar.DaysPerWeek)
+}
+
+// formatValidationNotes lists what the breakdown validation corrected or flagged
+func formatValidationNotes(notes []string) string {
+	if len(notes) == 0 {
+		return ""
+	}
+
+	const shown = 5
+	var b strings.Builder
+	b.WriteString("🔧 **Checked & adjusted:**\n")
+	for i, note := range notes {
+		if i == shown {
+			b.WriteString(fmt.Sprintf("• …and %d more\n", len(notes)-shown))
+			break
+		}
+		b.WriteString(fmt.Sprintf("• %s\n", note))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// totalEstimateRange sums the task ranges of a breakdown, falling back to its total
+// estimate when it has no tasks
+func totalEstimateRange(result *domain.TaskBreakdownResponse) domain.EstimateRange {
+	if len(result.Tasks) == 0 {
+		return domain.TaskEstimateRange(domain.Task{EstimateHours: result.TotalEstimate})
+	}
+	return domain.TotalEstimateRange(result.Tasks)
+}
+
+// formatOwner formats the suggested owner of a task with their current utilization,
+// or "" when none was suggested
+func formatOwner(members []domain.TeamMember, task domain.Task) string {
+	for _, member := range members {
+		if member.ID != task.AssignedTo {
+			continue
+		}
+		if member.Capacity <= 0 {
+			return " → @" + member.Username
+		}
+		return fmt.Sprintf(" → @%s (%.0f%% busy)", member.Username, member.Current/member.Capacity*100)
+	}
+	return ""
+}
+
 // Helper function for min
 func min(a, b int) int {
 	if a < b {
@@ -390,14 +719,6 @@ func min(a, b int) int {
 }
 
 // Helper functions for formatting
-func getCategoryTotal(tasks []domain.Task) float64 {
-	total := 0.0
-	for _, task := range tasks {
-		total += task.EstimateHours
-	}
-	return total
-}
-
 func getPriorityIcon(priority int) string {
 	switch priority {
 	case 1:
@@ -425,7 +746,12 @@ func getConfidenceEmoji(confidence float64) string {
 
 // CanHandle checks if this handler can process the command
 func (c *AnalyzeCommand) CanHandle(command string) bool {
-	return command == "/analyze"
+	return command == "/analyze" || command == "/analyses" || command == "/analyze_batch"
+}
+
+// Timeout allows for downloading and extracting an attached file before the AI analysis
+func (c *AnalyzeCommand) Timeout() time.Duration {
+	return 3 * time.Minute
 }
 
 // Description returns the command description
@@ -435,5 +761,29 @@ func (c *AnalyzeCommand) Description() string {
 
 // Usage returns the command usage instructions
 func (c *AnalyzeCommand) Usage() string {
-	return "/analyze requirement - Analyze development requirements and break them down into tasks"
+	return "/analyze [--brief | --detailed] requirement | save [project_id] | apply | assign | details - Break requirements down into tasks, save them or apply the changes of a re-analysis, and apply suggested owners; /analyses [open id | save id | compare id id] - Recent runs; /analyze_batch list - One plan for several requirements"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *AnalyzeCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// ChatAction implements domain.LongRunningHandler: attached files are downloaded and requirements analyzed by AI
+func (c *AnalyzeCommand) ChatAction(cmd *domain.Command) string {
+	if cmd.Document != nil || len(cmd.Photo) > 0 || cmd.Voice != nil || cmd.ReplyToVoice != nil {
+		return domain.ChatActionTyping
+	}
+	if command, _ := splitFirstWord(strings.TrimSpace(cmd.Text)); command != "/analyze" {
+		return ""
+	}
+	args := strings.Fields(strings.TrimPrefix(strings.TrimSpace(cmd.Text), "/analyze"))
+	if len(args) == 0 {
+		return ""
+	}
+	switch strings.ToLower(args[0]) {
+	case "save", "assign", "details", "share":
+		return ""
+	}
+	return domain.ChatActionTyping
 }
@@ -0,0 +1,395 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// Batch analysis limits
+const (
+	minBatchRequirements = 2
+	maxBatchRequirements = 10
+	maxBatchFileBytes    = 256 * 1024
+	// batchTasksShown is the number of tasks listed per requirement
+	batchTasksShown = 4
+)
+
+// batchItemPattern matches numbered and bulleted list items such as "1. ", "2) " or "- "
+var batchItemPattern = regexp.MustCompile(`^\s*(?:\d+[.)]|[-*•])\s+(.+)$`)
+
+// batchColumns are CSV header names holding the requirement, in order of preference
+var batchColumns = []string{"requirement", "title", "summary", "name", "description"}
+
+// handleBatch processes /analyze_batch: it reads a numbered list or CSV of requirements
+// and queues their analysis, answering at once with a progress message the job updates
+func (c *AnalyzeCommand) handleBatch(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	if response := c.privateOnlyResponse(cmd); response != nil {
+		return response, nil
+	}
+
+	// Flags are only read from the command line, the list keeps its line breaks
+	_, text := splitFirstWord(cmd.Text)
+	firstLine, rest, _ := strings.Cut(text, "\n")
+	detail, firstLine := parseDetailFlag(firstLine)
+	text = strings.TrimSpace(firstLine + "\n" + rest)
+
+	input, isCSV, message := c.batchInput(ctx, cmd, text)
+	if message != "" {
+		return errorResponse(message), nil
+	}
+	requirements := parseRequirementList(input, isCSV)
+	if len(requirements) < minBatchRequirements {
+		return &domain.Response{
+			Text: "📦 **Batch Analysis**\n\n" +
+				"Send several requirements at once and get one consolidated plan:\n\n" +
+				"`/analyze_batch`\n`1. User login with Google`\n`2. Admin dashboard with user list`\n`3. Email notifications`\n\n" +
+				"Or send a CSV file with a `requirement` or `title` column captioned `/analyze_batch`, or reply `/analyze_batch` to a list.\n\n" +
+				fmt.Sprintf("Each of the %d-%d requirements is broken down on its own, then shared work, dependencies between them, "+
+					"the combined estimate and the team are worked out. Every requirement uses one AI analysis. "+
+					"`--brief` and `--detailed` set the depth.", minBatchRequirements, maxBatchRequirements),
+			ParseMode: "Markdown",
+		}, nil
+	}
+	if len(requirements) > maxBatchRequirements {
+		return errorResponse(fmt.Sprintf("The list has %d requirements, a batch takes at most %d. Split it up and send the rest afterwards.", len(requirements), maxBatchRequirements)), nil
+	}
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	if usage := c.aiUsageService.Usage(teamID); usage.Remaining < len(requirements) {
+		return &domain.Response{
+			Text: fmt.Sprintf("⚠️ **AI budget too low:** the batch needs %d analyses and %d are left this month.\n\nCheck your limits with `/quota`.",
+				len(requirements), usage.Remaining),
+			ParseMode: "Markdown",
+		}, nil
+	}
+	if c.jobQueue == nil || c.responder == nil {
+		return errorResponse("Batch analysis is not available right now."), nil
+	}
+
+	c.mutex.Lock()
+	if c.batches[cmd.Chat.ID] {
+		c.mutex.Unlock()
+		return errorResponse("A batch analysis is already running in this chat. Wait for its plan before starting another."), nil
+	}
+	c.batches[cmd.Chat.ID] = true
+	c.mutex.Unlock()
+	done := func() {
+		c.mutex.Lock()
+		delete(c.batches, cmd.Chat.ID)
+		c.mutex.Unlock()
+	}
+
+	placeholder := sendPlaceholder(ctx, c.responder, cmd, fmt.Sprintf("⏳ %d requirements queued for analysis...", len(requirements)), c.logger)
+	if placeholder == 0 {
+		done()
+		return errorResponse("Could not start the batch analysis. Please try again."), nil
+	}
+
+	ahead, err := c.jobQueue.Enqueue("analyze_batch", func(jobCtx context.Context) {
+		defer done()
+		c.runBatch(jobCtx, cmd, requirements, detail, placeholder)
+	})
+	if err != nil {
+		done()
+		text := "❌ The bot is busy with other analyses. Please try again in a few minutes."
+		if errors.Is(err, services.ErrJobQueueClosed) {
+			text = "❌ The bot is restarting. Please try again in a minute."
+		}
+		return &domain.Response{
+			Text:          text,
+			EditMessageID: placeholder,
+		}, nil
+	}
+	if ahead > 0 {
+		c.editBatchProgress(ctx, cmd.Chat.ID, placeholder, fmt.Sprintf("⏳ %d requirements queued, %d job(s) ahead...", len(requirements), ahead))
+	}
+
+	c.logger.Info("Batch analysis queued", "chat_id", cmd.Chat.ID, "requirements", len(requirements), "ahead", ahead, "detail", detail)
+	// The job replaces the placeholder with the plan
+	return &domain.Response{}, nil
+}
+
+// batchInput returns the list to analyze: the command's text, an attached or replied-to
+// CSV or text file, or the replied-to message. Otherwise it returns a message to show.
+func (c *AnalyzeCommand) batchInput(ctx context.Context, cmd *domain.Command, text string) (string, bool, string) {
+	if text != "" {
+		return text, looksLikeCSV(text), ""
+	}
+
+	document := cmd.Document
+	if document == nil {
+		document = cmd.ReplyToDocument
+	}
+	if document == nil {
+		return cmd.ReplyToText, looksLikeCSV(cmd.ReplyToText), ""
+	}
+
+	ext := strings.ToLower(filepath.Ext(document.FileName))
+	if ext != ".csv" && ext != ".txt" && ext != ".md" {
+		return "", false, "Send the requirements as a `.csv`, `.txt` or `.md` file, or as a numbered list."
+	}
+	if document.FileSize > maxBatchFileBytes {
+		return "", false, fmt.Sprintf("The file is larger than %d KB.", maxBatchFileBytes/1024)
+	}
+
+	path, err := c.telegramFileService.DownloadFile(ctx, document)
+	if err != nil {
+		c.logger.Error("Failed to download batch file", "error", err, "filename", document.FileName)
+		return "", false, "Could not download the file. Please try again."
+	}
+	defer c.telegramFileService.CleanupFile(path)
+
+	if err := c.fileExtractor.SniffFile(path, document.FileName); err != nil {
+		return "", false, fmt.Sprintf("The file was rejected: %s.", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		c.logger.Error("Failed to read batch file", "error", err, "filename", document.FileName)
+		return "", false, "Could not read the file. Please try again."
+	}
+	text = string(bytes.TrimPrefix(content, []byte("\ufeff")))
+	return text, ext == ".csv" || looksLikeCSV(text), ""
+}
+
+// runBatch analyzes the requirements one by one, showing progress in the placeholder, and
+// replaces it with the consolidated plan
+func (c *AnalyzeCommand) runBatch(ctx context.Context, cmd *domain.Command, requirements []string, detail string, placeholder int) {
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	items := make([]services.BatchItem, len(requirements))
+	var members []domain.TeamMember
+	var openTasks []domain.Task
+
+	for i, requirement := range requirements {
+		items[i].Requirement = requirement
+		c.editBatchProgress(ctx, cmd.Chat.ID, placeholder, fmt.Sprintf("⏳ Analyzing requirement %d of %d: %s",
+			i+1, len(requirements), shortenText(requirement, 60)))
+
+		if err := c.aiUsageService.CheckBudget(teamID); err != nil {
+			items[i].Err = err
+			continue
+		}
+		req := newBreakdownRequest(c.db, cmd.Chat.ID, requirement, c.logger)
+		req.Detail = detail
+		result, err := c.taskAnalyzer.AnalyzeRequirement(req)
+		if err != nil {
+			c.logger.Error("Batch requirement analysis failed", "chat_id", cmd.Chat.ID, "requirement", i+1, "error", err)
+			items[i].Err = err
+			continue
+		}
+		c.aiUsageService.RecordAnalysis(teamID)
+		resolveSuggestedOwners(result.Tasks, req.TeamMembers)
+		items[i].Result = result
+		members, openTasks = req.TeamMembers, req.ExistingTasks
+	}
+
+	plan := services.BuildBatchPlan(items)
+	if plan.Analyzed() == 0 {
+		c.editBatchProgress(ctx, cmd.Chat.ID, placeholder, fmt.Sprintf("❌ None of the %d requirements could be analyzed. Please try again later.", len(requirements)))
+		return
+	}
+
+	suggestAssignments(c.teamManager, plan.Tasks, members, openTasks)
+	runID := c.recordRun(cmd, strings.Join(requirements, "\n"), fmt.Sprintf("📦 %d requirements", len(requirements)), plan.Breakdown())
+	c.rememberDraft(cmd.Chat.ID, runID, plan.Tasks, members)
+
+	err := c.responder.EditMessage(ctx, cmd.Chat.ID, placeholder, formatBatchPlan(plan), "Markdown", c.analysisKeyboard(cmd, runID, plan.Tasks))
+	if err != nil {
+		c.logger.Error("Failed to send batch plan", "chat_id", cmd.Chat.ID, "error", err)
+	}
+	c.logger.Info("Batch analysis completed",
+		"chat_id", cmd.Chat.ID,
+		"requirements", len(requirements),
+		"analyzed", plan.Analyzed(),
+		"tasks", len(plan.Tasks),
+		"dependencies", len(plan.Dependencies),
+		"total_estimate", plan.TotalEstimate)
+}
+
+// editBatchProgress updates the progress message; a failed update only costs the progress
+func (c *AnalyzeCommand) editBatchProgress(ctx context.Context, chatID int64, messageID int, text string) {
+	if err := c.responder.EditMessage(ctx, chatID, messageID, text, "Markdown", nil); err != nil {
+		c.logger.Warn("Failed to update batch progress", "chat_id", chatID, "error", err)
+	}
+}
+
+// formatBatchPlan shows the per-requirement breakdowns and the consolidated plan
+func formatBatchPlan(plan *services.BatchPlan) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📦 **Batch Analysis:** %d requirements\n\n", len(plan.Items)))
+
+	for i, item := range plan.Items {
+		text.WriteString(fmt.Sprintf("**%d. %s**\n", i+1, shortenText(item.Requirement, 80)))
+		if item.Result == nil {
+			text.WriteString(fmt.Sprintf("❌ Not analyzed: %s\n\n", shortenText(item.Err.Error(), 120)))
+			continue
+		}
+		text.WriteString(fmt.Sprintf("%d tasks · %.0fh\n", len(item.Result.Tasks), item.Result.TotalEstimate))
+		for j, task := range item.Result.Tasks {
+			if j == batchTasksShown {
+				text.WriteString(fmt.Sprintf("  …and %d more\n", len(item.Result.Tasks)-batchTasksShown))
+				break
+			}
+			text.WriteString(fmt.Sprintf("  • %s (%s)\n", task.Title, domain.TaskEstimateRange(task)))
+		}
+		text.WriteString("\n")
+	}
+
+	text.WriteString("🔗 **Dependencies between requirements:**\n")
+	if len(plan.Dependencies) == 0 {
+		text.WriteString("None found, the requirements can be worked on in parallel\n")
+	}
+	shared := 0.0
+	for _, dependency := range plan.Dependencies {
+		text.WriteString(fmt.Sprintf("• #%d builds on #%d: both need \"%s\"\n", dependency.From, dependency.To, dependency.Task))
+		shared += dependency.Hours
+	}
+	if shared > 0 {
+		text.WriteString(fmt.Sprintf("♻️ Shared work is planned once, saving %.0fh\n", shared))
+	}
+
+	text.WriteString(fmt.Sprintf("\n⏱ **Combined estimate:** %.0fh for %d tasks\n", plan.TotalEstimate, len(plan.Tasks)))
+	if len(plan.Team) > 0 {
+		var roles []string
+		for _, role := range plan.Team {
+			roles = append(roles, fmt.Sprintf("%s (%d/%d)", role.Role, role.Requirements, plan.Analyzed()))
+		}
+		text.WriteString("👥 **Recommended team:** " + strings.Join(roles, ", ") + "\n")
+	}
+	if len(plan.RiskFactors) > 0 {
+		risks := plan.RiskFactors
+		if len(risks) > 3 {
+			risks = risks[:3]
+		}
+		text.WriteString("⚠️ **Top risks:** " + strings.Join(risks, "; ") + "\n")
+	}
+	text.WriteString(fmt.Sprintf("🎯 **Confidence:** %.0f%%\n\n", plan.Confidence*100))
+	text.WriteString("💾 Save all tasks to a project with the button below.")
+	return text.String()
+}
+
+// parseRequirementList splits a numbered or bulleted list, a CSV file or plain lines into
+// requirements. Lines following a list item continue it.
+func parseRequirementList(text string, isCSV bool) []string {
+	if isCSV {
+		if requirements := parseRequirementCSV(text); len(requirements) > 0 {
+			return requirements
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	listed := false
+	for _, line := range lines {
+		if batchItemPattern.MatchString(line) {
+			listed = true
+			break
+		}
+	}
+
+	var requirements []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !listed {
+			requirements = append(requirements, line)
+			continue
+		}
+		if match := batchItemPattern.FindStringSubmatch(line); match != nil {
+			requirements = append(requirements, strings.TrimSpace(match[1]))
+		} else if len(requirements) > 0 {
+			requirements[len(requirements)-1] += " " + line
+		}
+	}
+	return requirements
+}
+
+// parseRequirementCSV reads the requirement column named by the header, or joins the text
+// fields of each row when there is no such header
+func parseRequirementCSV(text string) []string {
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil || len(rows) == 0 {
+		return nil
+	}
+
+	column := -1
+	for _, name := range batchColumns {
+		for i, header := range rows[0] {
+			if strings.EqualFold(strings.TrimSpace(header), name) {
+				column = i
+				break
+			}
+		}
+		if column >= 0 {
+			break
+		}
+	}
+
+	var requirements []string
+	if column >= 0 {
+		description := -1
+		for i, header := range rows[0] {
+			if i != column && strings.EqualFold(strings.TrimSpace(header), "description") {
+				description = i
+			}
+		}
+		for _, row := range rows[1:] {
+			if column >= len(row) || strings.TrimSpace(row[column]) == "" {
+				continue
+			}
+			requirement := strings.TrimSpace(row[column])
+			if description >= 0 && description < len(row) && strings.TrimSpace(row[description]) != "" {
+				requirement += ": " + strings.TrimSpace(row[description])
+			}
+			requirements = append(requirements, requirement)
+		}
+		return requirements
+	}
+
+	for _, row := range rows {
+		var fields []string
+		for _, field := range row {
+			field = strings.TrimSpace(field)
+			// Leading ID columns carry no requirement text
+			if field == "" || strings.Trim(field, "0123456789#") == "" {
+				continue
+			}
+			fields = append(fields, field)
+		}
+		if len(fields) > 0 {
+			requirements = append(requirements, strings.Join(fields, " - "))
+		}
+	}
+	return requirements
+}
+
+// looksLikeCSV reports whether pasted text starts with a CSV header naming a requirement column
+func looksLikeCSV(text string) bool {
+	header, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	if !strings.Contains(header, ",") {
+		return false
+	}
+	for _, field := range strings.Split(header, ",") {
+		field = strings.ToLower(strings.Trim(strings.TrimSpace(field), `"`))
+		for _, name := range batchColumns {
+			if field == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// maxDetailsLength keeps the "show more" message below Telegram's 4096 character limit
+const maxDetailsLength = 3800
+
+// parseDetailFlag removes --brief or --detailed from the command text and returns the
+// requested breakdown depth, empty for the default
+func parseDetailFlag(text string) (string, string) {
+	detail := ""
+	var kept []string
+	for _, word := range strings.Fields(text) {
+		switch strings.ToLower(word) {
+		case "--brief":
+			detail = domain.AnalysisDetailBrief
+		case "--detailed":
+			detail = domain.AnalysisDetailDetailed
+		default:
+			kept = append(kept, word)
+		}
+	}
+	return detail, strings.Join(kept, " ")
+}
+
+// hasTaskDetails reports whether any task carries subtasks or acceptance criteria
+func hasTaskDetails(tasks []domain.Task) bool {
+	for _, task := range tasks {
+		if len(task.Subtasks) > 0 || len(task.AcceptanceCriteria) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// formatDetailMarker marks tasks whose details are behind the "show more" button
+func formatDetailMarker(task domain.Task) string {
+	if len(task.Subtasks) == 0 && len(task.AcceptanceCriteria) == 0 {
+		return ""
+	}
+	return " 📎"
+}
+
+// showDetails lists the subtasks and acceptance criteria of the chat's latest breakdown
+func (c *AnalyzeCommand) showDetails(chatID int64) (*domain.Response, error) {
+	c.mutex.Lock()
+	draft := c.drafts[chatID]
+	c.mutex.Unlock()
+
+	if draft == nil || !hasTaskDetails(draft.Tasks) {
+		return errorResponse("No details to show. Run `/analyze --detailed requirement` first."), nil
+	}
+
+	var response strings.Builder
+	response.WriteString("📎 **Task Details**\n\n")
+
+	for i, task := range draft.Tasks {
+		if len(task.Subtasks) == 0 && len(task.AcceptanceCriteria) == 0 {
+			continue
+		}
+
+		var section strings.Builder
+		section.WriteString(fmt.Sprintf("%s **%s** (%s)\n", getPriorityIcon(task.Priority), task.Title, domain.TaskEstimateRange(task)))
+		items := append([]string(nil), task.Subtasks...)
+		for _, criterion := range task.AcceptanceCriteria {
+			items = append(items, "✅ "+criterion)
+		}
+		for j, item := range items {
+			branch := "├──"
+			if j == len(items)-1 {
+				branch = "└──"
+			}
+			section.WriteString(fmt.Sprintf("%s %s\n", branch, item))
+		}
+		section.WriteString("\n")
+
+		if response.Len()+section.Len() > maxDetailsLength {
+			remaining := 0
+			for _, rest := range draft.Tasks[i:] {
+				if len(rest.Subtasks) > 0 || len(rest.AcceptanceCriteria) > 0 {
+					remaining++
+				}
+			}
+			response.WriteString(fmt.Sprintf("_...and %d more tasks with details._", remaining))
+			break
+		}
+		response.WriteString(section.String())
+	}
+
+	return &domain.Response{
+		Text:      strings.TrimSpace(response.String()),
+		ParseMode: "Markdown",
+	}, nil
+}
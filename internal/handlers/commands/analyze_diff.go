@@ -0,0 +1,266 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+const (
+	// recentRunsForDiff is how many of the chat's latest analyses are searched for one
+	// saved to the active project
+	recentRunsForDiff = 20
+	// maxDiffLines caps each section of a diff message
+	maxDiffLines = 10
+)
+
+// reanalysisProject returns the chat's active project and its tasks when an earlier analysis
+// was saved to it, so a new analysis is a re-analysis of the same requirements
+func (c *AnalyzeCommand) reanalysisProject(chatID int64) (*database.Project, []domain.Task) {
+	projects, err := c.db.GetProjectsByChatID(chatID)
+	if err != nil {
+		c.logger.Warn("Failed to load projects for re-analysis", "chat_id", chatID, "error", err)
+		return nil, nil
+	}
+	var project *database.Project
+	for i := range projects {
+		if projects[i].Status == "active" {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		return nil, nil
+	}
+
+	runs, err := c.db.GetAnalysisRuns(chatID, recentRunsForDiff)
+	if err != nil {
+		c.logger.Warn("Failed to load analysis runs for re-analysis", "chat_id", chatID, "error", err)
+		return nil, nil
+	}
+	analyzed := false
+	for _, run := range runs {
+		if run.Saved && run.ProjectID == project.ID {
+			analyzed = true
+			break
+		}
+	}
+	if !analyzed {
+		return nil, nil
+	}
+
+	tasks, err := c.db.GetTasksByProjectID(project.ID)
+	if err != nil || len(tasks) == 0 {
+		if err != nil {
+			c.logger.Warn("Failed to load project tasks for re-analysis", "project_id", project.ID, "error", err)
+		}
+		return nil, nil
+	}
+	return project, ToDomainTasks(tasks)
+}
+
+// diffResponse compares a new breakdown with the tasks saved to the chat's project by an
+// earlier analysis, and returns the changes instead of the full breakdown. It returns nil
+// when the project has no analyzed tasks yet.
+func (c *AnalyzeCommand) diffResponse(chatID int64, result *domain.TaskBreakdownResponse, placeholder int) *domain.Response {
+	project, existing := c.reanalysisProject(chatID)
+	if project == nil {
+		return nil
+	}
+
+	diff := services.DiffTasks(existing, result.Tasks)
+	c.mutex.Lock()
+	if draft := c.drafts[chatID]; draft != nil {
+		draft.Diff = diff
+		draft.DiffProject = project
+	}
+	c.mutex.Unlock()
+
+	c.logger.Info("Re-analysis compared with saved tasks",
+		"chat_id", chatID,
+		"project_id", project.ID,
+		"added", len(diff.Added),
+		"changed", len(diff.Changed),
+		"removed", len(diff.Removed))
+
+	var keyboard [][]domain.InlineKeyboardButton
+	if !diff.Empty() {
+		keyboard = append(keyboard, []domain.InlineKeyboardButton{{Text: "🔄 Apply changes", CallbackData: "/analyze apply"}})
+	}
+	keyboard = append(keyboard, []domain.InlineKeyboardButton{{Text: "💾 Save all as new tasks", CallbackData: "/analyze save"}})
+	if hasTaskDetails(result.Tasks) {
+		keyboard = append(keyboard, []domain.InlineKeyboardButton{{Text: "📎 Show more", CallbackData: "/analyze details"}})
+	}
+
+	return &domain.Response{
+		Text:          formatTaskDiff(project, len(existing), diff),
+		ParseMode:     "Markdown",
+		ReplyMarkup:   &domain.InlineKeyboardMarkup{InlineKeyboard: keyboard},
+		EditMessageID: placeholder,
+	}
+}
+
+// formatTaskDiff shows what a re-analysis changes in a project
+func formatTaskDiff(project *database.Project, saved int, diff *services.TaskDiff) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("🔄 **Re-analysis of %s**\n", project.Name))
+	response.WriteString(fmt.Sprintf("Compared with the %d tasks already in the project.\n\n", saved))
+
+	if diff.Empty() {
+		response.WriteString(fmt.Sprintf("✅ **No changes:** the requirements still match the saved tasks (%d unchanged).\n\n", diff.Unchanged))
+		if len(diff.Kept) > 0 {
+			response.WriteString(fmt.Sprintf("📌 %d started or finished tasks are no longer in the requirements and stay as they are.\n\n", len(diff.Kept)))
+		}
+		response.WriteString("💡 `/analyze save` still adds the whole breakdown as new tasks.")
+		return response.String()
+	}
+
+	if len(diff.Added) > 0 {
+		lines := make([]string, len(diff.Added))
+		for i, task := range diff.Added {
+			lines[i] = fmt.Sprintf("%s %s - %sh", getPriorityIcon(task.Priority), task.Title, domain.FormatHours(task.EstimateHours))
+		}
+		writeDiffSection(&response, fmt.Sprintf("➕ **New tasks** (%d)", len(diff.Added)), lines)
+	}
+	if len(diff.Changed) > 0 {
+		lines := make([]string, len(diff.Changed))
+		for i, change := range diff.Changed {
+			lines[i] = fmt.Sprintf("%s: %sh → %sh", change.Existing.Title,
+				domain.FormatHours(change.Existing.EstimateHours), domain.FormatHours(change.Proposed.EstimateHours))
+		}
+		writeDiffSection(&response, fmt.Sprintf("✏️ **Changed estimates** (%d)", len(diff.Changed)), lines)
+	}
+	if len(diff.Removed) > 0 {
+		lines := make([]string, len(diff.Removed))
+		for i, task := range diff.Removed {
+			lines[i] = fmt.Sprintf("%s - %sh", task.Title, domain.FormatHours(task.EstimateHours))
+		}
+		writeDiffSection(&response, fmt.Sprintf("➖ **Removed scope** (%d, not started)", len(diff.Removed)), lines)
+	}
+
+	if len(diff.Kept) > 0 {
+		response.WriteString(fmt.Sprintf("📌 %d started or finished tasks are no longer in the requirements and stay as they are.\n", len(diff.Kept)))
+	}
+	if diff.Unchanged > 0 {
+		response.WriteString(fmt.Sprintf("✅ %d tasks unchanged\n", diff.Unchanged))
+	}
+
+	delta := diff.HoursDelta()
+	sign := "+"
+	if delta < 0 {
+		sign = "-"
+		delta = -delta
+	}
+	response.WriteString(fmt.Sprintf("\n📊 **Estimate change: %s%sh**\n\n", sign, domain.FormatHours(delta)))
+	response.WriteString("💡 `/analyze apply` updates the project with only these changes; `/analyze save` adds the whole breakdown as new tasks instead.")
+	return response.String()
+}
+
+// writeDiffSection writes a titled tree of lines, at most maxDiffLines of them
+func writeDiffSection(response *strings.Builder, title string, lines []string) {
+	response.WriteString(title + "\n")
+	shown := lines
+	if len(shown) > maxDiffLines {
+		shown = shown[:maxDiffLines]
+	}
+	for i, line := range shown {
+		prefix := "├──"
+		if i == len(shown)-1 && len(shown) == len(lines) {
+			prefix = "└──"
+		}
+		response.WriteString(fmt.Sprintf("%s %s\n", prefix, line))
+	}
+	if len(shown) < len(lines) {
+		response.WriteString(fmt.Sprintf("└── … and %d more\n", len(lines)-len(shown)))
+	}
+	response.WriteString("\n")
+}
+
+// applyDiff updates the project with the chat's latest re-analysis: new tasks are created,
+// changed estimates updated and dropped tasks that were not started removed, in one
+// transaction so the project never ends up half updated
+func (c *AnalyzeCommand) applyDiff(ctx context.Context, chatID int64) (*domain.Response, error) {
+	draft, refused := c.claimDraft(chatID)
+	if refused != nil {
+		return refused, nil
+	}
+	if draft != nil {
+		defer c.releaseDraft(draft)
+	}
+	if draft == nil || draft.Diff == nil {
+		return errorResponse("No re-analysis to apply. Run `/analyze` with the updated requirements first."), nil
+	}
+	diff, project := draft.Diff, draft.DiffProject
+
+	var savedIDs map[string]string
+	var created []database.Task
+	err := c.db.WithTx(ctx, func(tx *database.DB) error {
+		// New tasks may depend on tasks the project already has
+		var err error
+		if savedIDs, created, err = saveTasks(tx, project.ID, diff.Added, diff.Matches); err != nil {
+			return err
+		}
+
+		for _, change := range diff.Changed {
+			if err := tx.UpdateTaskEstimate(change.Existing.ID, change.Proposed.EstimateHours); err != nil {
+				return fmt.Errorf("estimate of %s: %w", change.Existing.ID, err)
+			}
+			if change.Proposed.OptimisticHours > 0 || change.Proposed.PessimisticHours > 0 {
+				if err := tx.SetTaskEstimateBounds(change.Existing.ID, change.Proposed.OptimisticHours, change.Proposed.PessimisticHours); err != nil {
+					return fmt.Errorf("estimate range of %s: %w", change.Existing.ID, err)
+				}
+			}
+		}
+
+		for _, task := range diff.Removed {
+			if err := tx.DeleteTask(task.ID); err != nil {
+				return fmt.Errorf("removing %s: %w", task.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Error("Failed to apply re-analysis", "chat_id", chatID, "project_id", project.ID, "error", err)
+		return errorResponse("Failed to apply the changes, the project is unchanged. Please try again."), nil
+	}
+	c.tasksCreated(created)
+	updated, removed := len(diff.Changed), len(diff.Removed)
+
+	c.mutex.Lock()
+	draft.SavedIDs = savedIDs
+	draft.ProjectName = project.Name
+	c.mutex.Unlock()
+
+	if draft.RunID != 0 {
+		if err := c.db.MarkAnalysisRunSaved(draft.RunID, project.ID); err != nil {
+			c.logger.Warn("Failed to mark analysis run saved", "run_id", draft.RunID, "error", err)
+		}
+	}
+
+	c.logger.Info("Re-analysis applied", "chat_id", chatID, "project_id", project.ID,
+		"added", len(savedIDs), "updated", updated, "removed", removed)
+
+	response := &domain.Response{
+		Text: fmt.Sprintf("🔄 **Changes applied** to **%s** (`%s`)\n\n"+
+			"➕ %d tasks added\n"+
+			"✏️ %d estimates updated\n"+
+			"➖ %d tasks removed",
+			project.Name, project.ID, len(savedIDs), updated, removed),
+		ParseMode: "Markdown",
+	}
+	// Only the new tasks get suggested owners; saved tasks keep theirs
+	for _, task := range diff.Added {
+		if _, ok := savedIDs[task.ID]; ok && task.AssignedTo != "" {
+			response.Text += "\n\n👥 Apply the suggested owners of the new tasks with `/analyze assign`."
+			response.ReplyMarkup = &domain.InlineKeyboardMarkup{InlineKeyboard: [][]domain.InlineKeyboardButton{
+				{{Text: "👥 Apply assignments", CallbackData: "/analyze assign"}},
+			}}
+			break
+		}
+	}
+	return response, nil
+}
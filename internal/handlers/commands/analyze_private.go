@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// AIPrivateOnlyKey is the team setting that limits /analyze and file uploads to private
+// chats with the bot. It holds the team chat's title and is empty when the policy is off.
+const AIPrivateOnlyKey = "ai_private_only"
+
+// maxSharedTasks is the number of task titles a shared summary lists
+const maxSharedTasks = 10
+
+// privateOnlyTeam is a team with the private-only policy that a user belongs to
+type privateOnlyTeam struct {
+	ChatID int64
+	Title  string
+}
+
+// privateOnlyResponse refuses an analysis in a group chat whose team keeps requirements out
+// of the group. It returns nil when the analysis may run here.
+func (c *AnalyzeCommand) privateOnlyResponse(cmd *domain.Command) *domain.Response {
+	if cmd.Chat.Type == "private" {
+		return nil
+	}
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	settings, err := c.db.GetTeamSettings(teamID)
+	if err != nil {
+		c.logger.Warn("Failed to load team settings", "team_id", teamID, "error", err)
+		return nil
+	}
+	if settings[AIPrivateOnlyKey] == "" {
+		return nil
+	}
+
+	return &domain.Response{
+		Text: "🔒 **AI analysis runs in private chats only for this team.**\n\n" +
+			"Send the requirement or file to me in a private message. " +
+			"You can share the result summary into this chat with a button afterwards.",
+		ParseMode: "Markdown",
+	}
+}
+
+// privateOnlyTeams returns the teams with the private-only policy the user is a member of
+func (c *AnalyzeCommand) privateOnlyTeams(userID int64) []privateOnlyTeam {
+	chats, err := c.db.GetTeamChatsWithSettingPrefix(AIPrivateOnlyKey)
+	if err != nil {
+		c.logger.Warn("Failed to load private-only teams", "error", err)
+		return nil
+	}
+
+	var teams []privateOnlyTeam
+	for _, chat := range chats {
+		settings, err := c.db.GetTeamSettings(chat.TeamID)
+		if err != nil || settings[AIPrivateOnlyKey] == "" || !c.isTeamMember(chat.ChatID, userID) {
+			continue
+		}
+		teams = append(teams, privateOnlyTeam{ChatID: chat.ChatID, Title: settings[AIPrivateOnlyKey]})
+	}
+	return teams
+}
+
+// isTeamMember reports whether the Telegram user is a member of the chat's team
+func (c *AnalyzeCommand) isTeamMember(chatID, userID int64) bool {
+	members, err := c.db.GetTeamMembersByChatID(chatID)
+	if err != nil {
+		c.logger.Warn("Failed to load team members", "chat_id", chatID, "error", err)
+		return false
+	}
+	for _, member := range members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// analysisKeyboard returns the buttons of a breakdown. In a private chat it adds a share
+// button for each of the user's teams that only allow analysis in private chats.
+func (c *AnalyzeCommand) analysisKeyboard(cmd *domain.Command, runID int64, tasks []domain.Task) *domain.InlineKeyboardMarkup {
+	keyboard := draftKeyboard(tasks)
+	if cmd.Chat.Type != "private" || runID == 0 {
+		return keyboard
+	}
+
+	for _, team := range c.privateOnlyTeams(cmd.User.TelegramID) {
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, []domain.InlineKeyboardButton{{
+			Text:         "📤 Share summary to " + team.Title,
+			CallbackData: fmt.Sprintf("/analyze share %d %d", team.ChatID, runID),
+		}})
+	}
+	return keyboard
+}
+
+// shareRun posts the summary of an analysis made in a private chat to a team chat. The
+// requirement itself is not shared.
+func (c *AnalyzeCommand) shareRun(cmd *domain.Command, args []string) (*domain.Response, error) {
+	if cmd.Chat.Type != "private" || len(args) != 2 {
+		return errorResponse("Share an analysis with the button under its result in our private chat."), nil
+	}
+	chatID, err := strconv.ParseInt(args[0], 10, 64)
+	runID, runErr := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || runErr != nil {
+		return errorResponse("Share an analysis with the button under its result in our private chat."), nil
+	}
+
+	if !c.isTeamMember(chatID, cmd.User.TelegramID) {
+		return errorResponse("Only team members can share into the team chat."), nil
+	}
+
+	run, result, errResponse := c.loadRun(cmd.Chat.ID, runID)
+	if errResponse != nil {
+		return errResponse, nil
+	}
+
+	c.logger.Info("Analysis shared", "user_id", cmd.User.TelegramID, "chat_id", chatID, "run_id", run.ID)
+	return &domain.Response{
+		Text:      "✅ Summary shared with the team.",
+		ParseMode: "Markdown",
+		Shared: &domain.SharedMessage{
+			ChatID:    chatID,
+			Text:      formatSharedSummary(run, result, cmd.User.Username),
+			ParseMode: "Markdown",
+		},
+	}, nil
+}
+
+// formatSharedSummary lists the tasks and estimate of a run without its requirement
+func formatSharedSummary(run *database.AnalysisRun, result *domain.TaskBreakdownResponse, username string) string {
+	var text strings.Builder
+	author := "a team member"
+	if username != "" {
+		author = "@" + username
+	}
+	text.WriteString(fmt.Sprintf("📋 **Analysis summary** shared by %s\n\n", author))
+	text.WriteString(fmt.Sprintf("├── Tasks: %d\n", len(result.Tasks)))
+	text.WriteString(fmt.Sprintf("├── Total estimate: %.1fh\n", result.TotalEstimate))
+	text.WriteString(fmt.Sprintf("└── Confidence: %s %.0f%%\n\n", getConfidenceEmoji(result.Confidence), result.Confidence*100))
+
+	for i, task := range result.Tasks {
+		if i == maxSharedTasks {
+			text.WriteString(fmt.Sprintf("• … and %d more\n", len(result.Tasks)-maxSharedTasks))
+			break
+		}
+		text.WriteString(fmt.Sprintf("%s %s (%.1fh)\n", getPriorityIcon(task.Priority), task.Title, task.EstimateHours))
+	}
+
+	text.WriteString(fmt.Sprintf("\n🔒 The requirement stays in the private chat (analysis #%d).", run.ID))
+	return text.String()
+}
@@ -0,0 +1,301 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// analysisDraft is the last breakdown of a chat, kept in memory until it is saved and assigned
+type analysisDraft struct {
+	// RunID is the recorded analysis run, 0 when it was not recorded
+	RunID   int64
+	Tasks   []domain.Task
+	Members []domain.TeamMember
+	// SavedIDs maps generated task IDs to the IDs of the saved tasks
+	SavedIDs    map[string]string
+	ProjectName string
+	// saving is set while the draft is being written, so a second tap on "Save" waits its turn
+	saving bool
+	// Diff compares a re-analysis with the tasks already saved to DiffProject; applying it
+	// changes only what differs
+	Diff        *services.TaskDiff
+	DiffProject *database.Project
+}
+
+// suggestAssignments fills in a best-fit member for every task without one, using
+// TeamManager.RecommendAssignment. Suggested tasks count towards later suggestions,
+// so a breakdown is spread over the team instead of landing on one person.
+func suggestAssignments(teamManager *services.TeamManager, tasks []domain.Task, members []domain.TeamMember, openTasks []domain.Task) {
+	if len(members) == 0 {
+		return
+	}
+
+	current := append([]domain.Task(nil), openTasks...)
+	for i := range tasks {
+		if tasks[i].AssignedTo == "" {
+			if member := teamManager.RecommendAssignment(tasks[i], members, current); member != nil {
+				tasks[i].AssignedTo = member.ID
+			}
+		}
+		if tasks[i].AssignedTo != "" {
+			planned := tasks[i]
+			planned.Status = domain.TaskStatusTodo
+			current = append(current, planned)
+		}
+	}
+}
+
+// hasSuggestedOwners reports whether any task of the draft has a suggested owner
+func (d *analysisDraft) hasSuggestedOwners() bool {
+	for _, task := range d.Tasks {
+		if task.AssignedTo != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// rememberDraft keeps the chat's latest breakdown for /analyze save
+func (c *AnalyzeCommand) rememberDraft(chatID, runID int64, tasks []domain.Task, members []domain.TeamMember) {
+	c.mutex.Lock()
+	c.drafts[chatID] = &analysisDraft{RunID: runID, Tasks: tasks, Members: members}
+	c.mutex.Unlock()
+}
+
+// draftKeyboard offers to save the breakdown that was just shown, and to expand the
+// subtasks and acceptance criteria of detailed analyses
+func draftKeyboard(tasks []domain.Task) *domain.InlineKeyboardMarkup {
+	keyboard := [][]domain.InlineKeyboardButton{
+		{{Text: "💾 Save tasks", CallbackData: "/analyze save"}},
+	}
+	if hasTaskDetails(tasks) {
+		keyboard = append(keyboard, []domain.InlineKeyboardButton{{Text: "📎 Show more", CallbackData: "/analyze details"}})
+	}
+	return &domain.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// claimDraft returns the chat's latest breakdown and marks it as being saved. It returns a
+// message instead when the breakdown is already saved or another save of it is running,
+// and nil for both when the chat has no breakdown. The caller clears the mark with
+// releaseDraft, after setting SavedIDs when the save succeeded.
+func (c *AnalyzeCommand) claimDraft(chatID int64) (*analysisDraft, *domain.Response) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	draft := c.drafts[chatID]
+	switch {
+	case draft == nil:
+		return nil, nil
+	case draft.SavedIDs != nil:
+		return nil, errorResponse(fmt.Sprintf("This analysis is already saved to **%s**.", draft.ProjectName))
+	case draft.saving:
+		return nil, errorResponse("This analysis is being saved right now.")
+	}
+	draft.saving = true
+	return draft, nil
+}
+
+// releaseDraft clears the mark claimDraft set
+func (c *AnalyzeCommand) releaseDraft(draft *analysisDraft) {
+	c.mutex.Lock()
+	draft.saving = false
+	c.mutex.Unlock()
+}
+
+// saveDraft stores the chat's latest breakdown as tasks of a project. The project is
+// given by ID or defaults to the chat's most recent active project. The tasks are saved in
+// one transaction, so a failure leaves no half-saved breakdown behind.
+func (c *AnalyzeCommand) saveDraft(ctx context.Context, chatID int64, projectID string) (*domain.Response, error) {
+	draft, refused := c.claimDraft(chatID)
+	if refused != nil {
+		return refused, nil
+	}
+	if draft == nil {
+		return errorResponse("No analysis to save. Run `/analyze requirement` first."), nil
+	}
+	defer c.releaseDraft(draft)
+
+	projects, err := c.db.GetProjectsByChatID(chatID)
+	if err != nil {
+		c.logger.Error("Failed to load projects", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to load projects. Please try again."), nil
+	}
+
+	var project *database.Project
+	for i := range projects {
+		if (projectID == "" && projects[i].Status == "active") || projects[i].ID == projectID {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		if projectID != "" {
+			return errorResponse(fmt.Sprintf("Project `%s` not found in this chat.", projectID)), nil
+		}
+		return errorResponse("No active project in this chat yet. Create one with `/create_project name`."), nil
+	}
+
+	var savedIDs map[string]string
+	var created []database.Task
+	err = c.db.WithTx(ctx, func(tx *database.DB) error {
+		savedIDs, created, err = saveTasks(tx, project.ID, draft.Tasks, nil)
+		return err
+	})
+	if err != nil {
+		c.logger.Error("Failed to save analysis tasks", "chat_id", chatID, "project_id", project.ID, "error", err)
+		return errorResponse("Failed to save the tasks, none were saved. Please try again."), nil
+	}
+	c.tasksCreated(created)
+	saved := len(savedIDs)
+
+	c.mutex.Lock()
+	draft.SavedIDs = savedIDs
+	draft.ProjectName = project.Name
+	c.mutex.Unlock()
+
+	if draft.RunID != 0 {
+		if err := c.db.MarkAnalysisRunSaved(draft.RunID, project.ID); err != nil {
+			c.logger.Warn("Failed to mark analysis run saved", "run_id", draft.RunID, "error", err)
+		}
+	}
+
+	c.logger.Info("Analysis saved", "chat_id", chatID, "project_id", project.ID, "tasks", saved)
+
+	response := &domain.Response{
+		Text:      fmt.Sprintf("💾 Saved %d tasks to **%s** (`%s`)", saved, project.Name, project.ID),
+		ParseMode: "Markdown",
+	}
+	if draft.hasSuggestedOwners() {
+		response.Text += "\n\n👥 Apply the suggested owners with `/analyze assign`."
+		response.ReplyMarkup = &domain.InlineKeyboardMarkup{InlineKeyboard: [][]domain.InlineKeyboardButton{
+			{{Text: "👥 Apply assignments", CallbackData: "/analyze assign"}},
+		}}
+	}
+	return response, nil
+}
+
+// saveTasks creates the tasks of a breakdown in a project and returns the IDs of the saved
+// tasks by generated ID, and the tasks themselves. known maps generated IDs to tasks the
+// project already has, so dependencies on them are kept. Run it in a transaction: it stops
+// at the first task that fails.
+func saveTasks(db *database.DB, projectID string, tasks []domain.Task, known map[string]string) (map[string]string, []database.Task, error) {
+	ids := make(map[string]string, len(known)+len(tasks))
+	for generated, saved := range known {
+		ids[generated] = saved
+	}
+	for i, task := range tasks {
+		ids[task.ID] = fmt.Sprintf("task_%d_%d", time.Now().UnixNano(), i)
+	}
+
+	savedIDs := make(map[string]string, len(tasks))
+	created := make([]database.Task, 0, len(tasks))
+	for _, task := range tasks {
+		var dependencies []string
+		for _, dependency := range task.Dependencies {
+			if id, ok := ids[dependency]; ok {
+				dependencies = append(dependencies, id)
+			}
+		}
+
+		saved := database.Task{
+			ID:                 ids[task.ID],
+			ProjectID:          projectID,
+			Title:              task.Title,
+			Description:        task.Description,
+			Category:           task.Category,
+			EstimateHours:      task.EstimateHours,
+			OptimisticHours:    task.OptimisticHours,
+			PessimisticHours:   task.PessimisticHours,
+			Status:             domain.TaskStatusTodo,
+			Priority:           task.Priority,
+			Dependencies:       dependencies,
+			AcceptanceCriteria: task.AcceptanceCriteria,
+		}
+		if err := db.CreateTask(&saved); err != nil {
+			return nil, nil, fmt.Errorf("task %q: %w", task.Title, err)
+		}
+		savedIDs[task.ID] = ids[task.ID]
+		created = append(created, saved)
+	}
+	return savedIDs, created, nil
+}
+
+// tasksCreated announces saved tasks to the project webhooks once their transaction committed
+func (c *AnalyzeCommand) tasksCreated(tasks []database.Task) {
+	for _, task := range tasks {
+		c.projectWebhooks.TaskCreated(ToDomainTask(task))
+	}
+}
+
+// applyAssignments assigns the saved tasks of the chat's latest breakdown to their suggested
+// owners, all of them or, when one fails, none
+func (c *AnalyzeCommand) applyAssignments(ctx context.Context, chatID int64) (*domain.Response, error) {
+	c.mutex.Lock()
+	draft := c.drafts[chatID]
+	c.mutex.Unlock()
+
+	if draft == nil || draft.SavedIDs == nil {
+		return errorResponse("Save an analysis first with `/analyze save`."), nil
+	}
+
+	var assigned map[string]int
+	err := c.db.WithTx(ctx, func(tx *database.DB) error {
+		assigned = make(map[string]int)
+		for _, task := range draft.Tasks {
+			savedID, ok := draft.SavedIDs[task.ID]
+			if !ok || task.AssignedTo == "" {
+				continue
+			}
+			if err := tx.AssignTask(savedID, task.AssignedTo); err != nil {
+				return fmt.Errorf("task %s: %w", savedID, err)
+			}
+			assigned[memberUsername(draft.Members, task.AssignedTo)]++
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Error("Failed to apply analysis assignments", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to apply the assignments, no task was changed. Please try again."), nil
+	}
+
+	c.mutex.Lock()
+	delete(c.drafts, chatID)
+	c.mutex.Unlock()
+
+	if len(assigned) == 0 {
+		return errorResponse("No tasks were assigned."), nil
+	}
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("👥 **Assignments Applied** in **%s**\n\n", draft.ProjectName))
+	i := 0
+	for _, member := range draft.Members {
+		count, ok := assigned[member.Username]
+		if !ok {
+			continue
+		}
+		i++
+		prefix := "├──"
+		if i == len(assigned) {
+			prefix = "└──"
+		}
+		noun := "tasks"
+		if count == 1 {
+			noun = "task"
+		}
+		response.WriteString(fmt.Sprintf("%s @%s: %d %s\n", prefix, member.Username, count, noun))
+	}
+	response.WriteString("\n💡 Everyone can see their tasks with `/my_tasks`.")
+
+	c.logger.Info("Analysis assignments applied", "chat_id", chatID, "members", len(assigned))
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}, nil
+}
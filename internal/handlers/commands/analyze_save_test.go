@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+)
+
+func TestSaveDraftSavesOnceWhenTappedTwice(t *testing.T) {
+	db, err := database.NewSQLiteDB(filepath.Join(t.TempDir(), "save.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	defer db.Close()
+
+	const chatID = -100
+	if err := db.CreateProject(&database.Project{ID: "proj_1", Name: "Shop", TeamID: "team_-100", Status: "active"}); err != nil {
+		t.Fatal(err)
+	}
+
+	command := NewAnalyzeCommand(db, nil, nil, &MockLogger{}, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	command.rememberDraft(chatID, 0, []domain.Task{
+		{ID: "t1", Title: "Login", Category: "backend", EstimateHours: 4},
+		{ID: "t2", Title: "Cart", Category: "frontend", EstimateHours: 6, Dependencies: []string{"t1"}},
+	}, nil)
+
+	var wg sync.WaitGroup
+	responses := make([]*domain.Response, 2)
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i], _ = command.saveDraft(context.Background(), chatID, "")
+		}(i)
+	}
+	wg.Wait()
+
+	saved := 0
+	for _, response := range responses {
+		if strings.HasPrefix(response.Text, "💾") {
+			saved++
+		}
+	}
+	if saved != 1 {
+		t.Errorf("%d of two concurrent saves succeeded, want one: %q, %q", saved, responses[0].Text, responses[1].Text)
+	}
+	if tasks, _ := db.GetTasksByProjectID("proj_1"); len(tasks) != 2 {
+		t.Errorf("project has %d tasks, want the breakdown saved once", len(tasks))
+	}
+
+	// A failed save can be retried
+	command.rememberDraft(chatID, 0, []domain.Task{{ID: "t3", Title: "Search", Category: "backend"}}, nil)
+	if response, _ := command.saveDraft(context.Background(), chatID, "missing"); strings.HasPrefix(response.Text, "💾") {
+		t.Fatalf("saving to a missing project = %q, want an error", response.Text)
+	}
+	if response, _ := command.saveDraft(context.Background(), chatID, ""); !strings.HasPrefix(response.Text, "💾") {
+		t.Errorf("retry after a failed save = %q, want it saved", response.Text)
+	}
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// voiceTranscriptPreview is the length of the transcript shown above the breakdown, in runes
+const voiceTranscriptPreview = 400
+
+// handleVoiceAnalysis transcribes a voice message and analyzes the transcript like a
+// typed requirement
+func (c *AnalyzeCommand) handleVoiceAnalysis(ctx context.Context, cmd *domain.Command, voice *domain.TelegramVoice, detail string) (*domain.Response, error) {
+	c.logger.Info("Processing voice analysis",
+		"user_id", cmd.User.TelegramID,
+		"duration", voice.Duration,
+		"file_size", voice.FileSize,
+		"detail", detail)
+
+	if c.transcriptionService == nil || !c.transcriptionService.Enabled() {
+		return c.voiceErrorResponse("Voice requirements are not available: no speech-to-text provider is configured (OPENAI_API_KEY or GROQ_API_KEY)."), nil
+	}
+	if maxSeconds := c.transcriptionService.MaxSeconds(); voice.Duration > maxSeconds {
+		return c.voiceErrorResponse(fmt.Sprintf("The recording is %s long. Voice requirements can be up to %s.",
+			formatVoiceDuration(voice.Duration), formatVoiceDuration(maxSeconds))), nil
+	}
+	if voice.FileSize > services.MaxVoiceBytes {
+		return c.voiceErrorResponse(fmt.Sprintf("The recording is too large (%s). Up to %s can be transcribed.",
+			c.telegramFileService.GetFileSize(voice.FileSize), c.telegramFileService.GetFileSize(services.MaxVoiceBytes))), nil
+	}
+
+	// Recordings count against the team's file quota; the analysis against its AI budget
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	if err := c.fileUsageService.CheckQuota(teamID, 1, int64(voice.FileSize)); err != nil {
+		c.logger.Warn("File quota reached", "team_id", teamID, "error", err)
+		return &domain.Response{
+			Text:      "⚠️ **File quota reached:** " + err.Error() + ".\n\nCheck your limits with `/quota`.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+	if err := c.aiUsageService.CheckBudget(teamID); err != nil {
+		return c.budgetResponse(err), nil
+	}
+
+	document := voice.Document()
+	tempFile, err := c.telegramFileService.DownloadFile(ctx, document)
+	if err != nil {
+		c.logger.Error("Failed to download voice message", "error", err, "filename", document.FileName)
+		return c.voiceErrorResponse("Download failed. Please send the recording again."), nil
+	}
+	defer c.telegramFileService.CleanupFile(tempFile)
+	c.fileUsageService.RecordFiles(teamID, 1, int64(voice.FileSize))
+
+	transcript, err := c.transcriptionService.Transcribe(ctx, tempFile, document.FileName)
+	if err != nil {
+		c.logger.Error("Voice transcription failed", "error", err, "user_id", cmd.User.TelegramID)
+		return c.voiceErrorResponse("Transcription failed. Please try again, or type the requirement: `/analyze requirement`"), nil
+	}
+	if len(strings.Fields(transcript)) < 3 {
+		return c.voiceErrorResponse("No requirement could be heard in the recording. Please speak clearly, or type it: `/analyze requirement`"), nil
+	}
+
+	response, err := c.handleTextAnalysis(ctx, cmd, "/analyze "+transcript, detail)
+	if err != nil || response == nil {
+		return response, err
+	}
+	response.Text = fmt.Sprintf("🎙 **Transcript** (%s): _%s_\n\n", formatVoiceDuration(voice.Duration),
+		escapeMarkdown(shortenText(transcript, voiceTranscriptPreview))) + response.Text
+	return response, nil
+}
+
+// formatVoiceDuration formats seconds as m:ss
+func formatVoiceDuration(seconds int) string {
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// voiceErrorResponse creates an error response for voice requirements
+func (c *AnalyzeCommand) voiceErrorResponse(message string) *domain.Response {
+	return &domain.Response{
+		Text:      "🎙 " + message,
+		ParseMode: "Markdown",
+	}
+}
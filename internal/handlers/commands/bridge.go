@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// bridgeEventNames describes the events that can be mirrored
+var bridgeEventNames = map[string]string{
+	services.BridgeEventProjectCreated: "📝 Project created",
+	services.BridgeEventSprintSummary:  "🏁 Sprint summary",
+	services.BridgeEventIncident:       "🔴 Incident (project turned red)",
+	services.BridgeEventStatusReport:   "📰 Weekly status report",
+}
+
+// BridgeCommand configures mirroring of team events to Slack and Discord
+type BridgeCommand struct {
+	bridge *services.NotificationBridge
+	logger domain.Logger
+}
+
+// NewBridgeCommand creates a new bridge command handler
+func NewBridgeCommand(bridge *services.NotificationBridge, logger domain.Logger) *BridgeCommand {
+	return &BridgeCommand{
+		bridge: bridge,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *BridgeCommand) CanHandle(command string) bool {
+	return command == "/bridge"
+}
+
+// Description returns the command description
+func (c *BridgeCommand) Description() string {
+	return "🌉 Mirror team events to Slack or Discord"
+}
+
+// Usage returns the command usage instructions
+func (c *BridgeCommand) Usage() string {
+	return "/bridge [slack URL|off | discord URL|off | events e1,e2 | test] - Slack/Discord notifications"
+}
+
+// Category puts the command in the team category a chat can turn off
+func (c *BridgeCommand) Category() string {
+	return services.CategoryTeam
+}
+
+// Handle processes the bridge command
+func (c *BridgeCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing bridge command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/bridge")))
+
+	if len(args) == 0 {
+		return c.configResponse(teamID, ""), nil
+	}
+
+	var err error
+	var notice string
+	switch option := strings.ToLower(args[0]); option {
+	case services.BridgeSlack, services.BridgeDiscord:
+		if len(args) < 2 {
+			return errorResponse(fmt.Sprintf("Usage: `/bridge %s https://...` or `/bridge %s off`", option, option)), nil
+		}
+		if strings.EqualFold(args[1], "off") {
+			err = c.bridge.SetWebhook(teamID, option, "")
+			notice = fmt.Sprintf("✅ %s bridge removed", strings.Title(option))
+		} else {
+			err = c.bridge.SetWebhook(teamID, option, args[1])
+			notice = fmt.Sprintf("✅ %s webhook saved. Try it with `/bridge test`.", strings.Title(option))
+		}
+	case "events":
+		if len(args) < 2 {
+			return errorResponse(fmt.Sprintf("Usage: `/bridge events %s`", strings.Join(services.BridgeEvents, ","))), nil
+		}
+		err = c.bridge.SetEvents(teamID, strings.Split(strings.ToLower(args[1]), ","))
+		notice = "✅ Mirrored events updated"
+	case "test":
+		if err := c.bridge.Test(ctx, teamID, fmt.Sprintf("👋 Test message from **%s** via Yordamchi Dev Bot", chatTitle(cmd))); err != nil {
+			c.logger.Warn("Bridge test failed", "team_id", teamID, "error", err)
+			return errorResponse(fmt.Sprintf("Test failed: %s", err.Error())), nil
+		}
+		return c.configResponse(teamID, "✅ Test message delivered"), nil
+	default:
+		return c.configResponse(teamID, ""), nil
+	}
+
+	if err != nil {
+		c.logger.Error("Failed to update bridge settings", "team_id", teamID, "error", err)
+		return errorResponse(err.Error()), nil
+	}
+
+	c.logger.Info("Bridge settings updated", "team_id", teamID, "option", args[0])
+	return c.configResponse(teamID, notice), nil
+}
+
+// configResponse shows the team's bridge configuration
+func (c *BridgeCommand) configResponse(teamID, notice string) *domain.Response {
+	config := c.bridge.GetConfig(teamID)
+
+	var response strings.Builder
+	if notice != "" {
+		response.WriteString(notice + "\n\n")
+	}
+
+	response.WriteString("🌉 **Slack / Discord Bridge**\n\n")
+	response.WriteString(fmt.Sprintf("├── Slack: %s\n", maskWebhook(config.SlackURL)))
+	response.WriteString(fmt.Sprintf("├── Discord: %s\n", maskWebhook(config.DiscordURL)))
+	response.WriteString("└── Events:\n")
+	for _, event := range services.BridgeEvents {
+		mark := "⬜"
+		if containsString(config.Events, event) {
+			mark = "✅"
+		}
+		response.WriteString(fmt.Sprintf("    %s %s (`%s`)\n", mark, bridgeEventNames[event], event))
+	}
+
+	response.WriteString("\n**Configure:**\n")
+	response.WriteString("• `/bridge slack https://hooks.slack.com/services/...`\n")
+	response.WriteString("• `/bridge discord https://discord.com/api/webhooks/...`\n")
+	response.WriteString("• `/bridge slack off` - remove a target\n")
+	response.WriteString(fmt.Sprintf("• `/bridge events %s` - choose events\n", strings.Join(services.BridgeEvents, ",")))
+	response.WriteString("• `/bridge test` - send a test message\n")
+	response.WriteString("\n💡 Webhook URLs are secrets: anyone who has one can post to the channel.")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// maskWebhook hides the secret part of a webhook URL
+func maskWebhook(webhookURL string) string {
+	if webhookURL == "" {
+		return "not set"
+	}
+	if len(webhookURL) <= 36 {
+		return "configured"
+	}
+	return "`" + webhookURL[:32] + "…`"
+}
+
+// chatTitle returns a display name of the command's chat
+func chatTitle(cmd *domain.Command) string {
+	if cmd.Chat.Title != "" {
+		return cmd.Chat.Title
+	}
+	return "@" + cmd.User.Username
+}
+
+// containsString checks whether a slice contains a value
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,270 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// bugQuestions are the questions of the /bug dialog, in the order of its steps
+var bugQuestions = []struct {
+	Text        string
+	Placeholder string
+}{
+	{"What happened?", "The login button does nothing"},
+	{"What did you expect to happen?", "The dashboard opens"},
+	{"What are the steps to reproduce it?", "1. Open the app 2. Tap Login"},
+}
+
+// BugCommand files bug reports collected in a short dialog
+type BugCommand struct {
+	db                  *database.DB
+	dialogs             *services.BugDialogService
+	githubService       *services.GitHubService
+	telegramFileService *services.TelegramFileService
+	webhooks            *services.ProjectWebhooks
+	logger              domain.Logger
+}
+
+// NewBugCommand creates a new bug command handler
+func NewBugCommand(db *database.DB, dialogs *services.BugDialogService, githubService *services.GitHubService, telegramFileService *services.TelegramFileService, webhooks *services.ProjectWebhooks, logger domain.Logger) *BugCommand {
+	return &BugCommand{
+		db:                  db,
+		dialogs:             dialogs,
+		githubService:       githubService,
+		telegramFileService: telegramFileService,
+		webhooks:            webhooks,
+		logger:              logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *BugCommand) CanHandle(command string) bool {
+	return command == "/bug"
+}
+
+// Description returns the command description
+func (c *BugCommand) Description() string {
+	return "🐞 Report a bug step by step"
+}
+
+// Usage returns the command usage instructions
+func (c *BugCommand) Usage() string {
+	return "/bug [cancel] - Report a bug to the active project"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *BugCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the bug command. While a dialog is open, the user's messages and
+// screenshots arrive here as /bug with the message as its argument.
+func (c *BugCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing bug command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	chatID, userID := cmd.Chat.ID, cmd.User.TelegramID
+	answer := strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/bug"))
+	if strings.EqualFold(answer, "cancel") {
+		c.dialogs.Finish(chatID, userID)
+		return &domain.Response{Text: "✖️ Bug report cancelled."}, nil
+	}
+
+	report := c.dialogs.Get(chatID, userID)
+	if report == nil {
+		// Check for a project before asking questions whose answers could not be filed
+		if _, response := c.activeProject(chatID); response != nil {
+			return response, nil
+		}
+		report = c.dialogs.Start(chatID, userID)
+	}
+
+	attached := report.AddScreenshot(screenshotFileID(cmd))
+	if answer != "" {
+		report.Answer(answer)
+	}
+	if report.Step < services.BugStepDone {
+		return c.question(report, attached), nil
+	}
+
+	c.dialogs.Finish(chatID, userID)
+	return c.file(ctx, cmd, report), nil
+}
+
+// question asks the report's current question
+func (c *BugCommand) question(report *services.BugReport, attached bool) *domain.Response {
+	question := bugQuestions[report.Step]
+
+	var text strings.Builder
+	if attached {
+		text.WriteString(fmt.Sprintf("📎 Screenshot attached (%d)\n\n", len(report.Screenshots)))
+	}
+	text.WriteString(fmt.Sprintf("🐞 **Bug report** (%d/%d)\n\n", report.Step+1, len(bugQuestions)))
+	text.WriteString("**" + question.Text + "**\n\n")
+	text.WriteString("Reply to this message. Screenshots are welcome at any step; `/bug cancel` stops.")
+
+	return &domain.Response{
+		Text:        text.String(),
+		ParseMode:   "Markdown",
+		ReplyMarkup: &domain.ForceReply{ForceReply: true, InputFieldPlaceholder: question.Placeholder},
+	}
+}
+
+// activeProject returns the chat's most recent active project, or an error response when it has none
+func (c *BugCommand) activeProject(chatID int64) (*database.Project, *domain.Response) {
+	projects, err := c.db.GetProjectsByChatID(chatID)
+	if err != nil {
+		c.logger.Error("Failed to load projects", "chat_id", chatID, "error", err)
+		return nil, errorResponse("Failed to load projects. Please try again.")
+	}
+	for i := range projects {
+		if projects[i].Status == "active" {
+			return &projects[i], nil
+		}
+	}
+	return nil, errorResponse("No active project in this chat. Report bugs in the team chat, or create a project with `/create_project name`.")
+}
+
+// file files the report as a GitHub issue when the team linked a repository and a token is
+// configured, and as a task of the active project otherwise
+func (c *BugCommand) file(ctx context.Context, cmd *domain.Command, report *services.BugReport) *domain.Response {
+	project, response := c.activeProject(cmd.Chat.ID)
+	if response != nil {
+		return response
+	}
+
+	settings, err := c.db.GetTeamSettings(project.TeamID)
+	if err != nil {
+		c.logger.Warn("Failed to load team settings", "team_id", project.TeamID, "error", err)
+	}
+
+	title := "Bug: " + shortenText(firstLine(report.Happened), 80)
+	reporter := auditActor(cmd.User)
+	notice := ""
+	if repository := settings[GitHubRepoKey]; repository != "" && c.githubService.CanCreateIssues() {
+		issue, err := c.githubService.CreateIssue(ctx, repository, title, formatBugIssue(report, reporter), []string{"bug"})
+		if err == nil {
+			text := fmt.Sprintf("🐞 **Bug filed** · [%s#%d](%s)\n", repository, issue.Number, issue.URL)
+			text += fmt.Sprintf("└── %s\n", title)
+			return c.withScreenshot(ctx, report, text)
+		}
+		c.logger.Error("Failed to create GitHub issue", "repository", repository, "error", err)
+		notice = "⚠️ Could not open a GitHub issue, filed as a task instead.\n\n"
+	}
+
+	task := &database.Task{
+		ID:          fmt.Sprintf("task_%d", time.Now().UnixNano()),
+		ProjectID:   project.ID,
+		Title:       title,
+		Description: formatBugDescription(report, reporter),
+		Category:    "qa",
+		Status:      domain.TaskStatusTodo,
+		Priority:    2,
+	}
+	if err := c.db.CreateTask(task); err != nil {
+		c.logger.Error("Failed to file bug task", "project_id", project.ID, "error", err)
+		return errorResponse("Failed to file the bug. Please try again with `/bug`.")
+	}
+	c.webhooks.TaskCreated(ToDomainTask(*task))
+	for _, fileID := range report.Screenshots {
+		if err := c.db.AddTaskAttachment(task.ID, fileID, database.AttachmentScreenshot); err != nil {
+			c.logger.Error("Failed to attach screenshot", "task_id", task.ID, "error", err)
+		}
+	}
+
+	reference := task.ID
+	if number, err := c.db.EnsureTaskKey(task.ID); err == nil {
+		reference = domain.FormatTaskKey(TaskKeyPrefix(settings), number)
+	}
+
+	var text strings.Builder
+	text.WriteString(notice)
+	text.WriteString(fmt.Sprintf("🐞 **Bug filed** · `%s`\n", reference))
+	text.WriteString(fmt.Sprintf("├── %s\n", title))
+	text.WriteString(fmt.Sprintf("├── Project: %s\n", project.Name))
+	text.WriteString(fmt.Sprintf("└── Screenshots: %d\n\n", len(report.Screenshots)))
+	text.WriteString(fmt.Sprintf("Track it with `/task %s`.", reference))
+	return c.withScreenshot(ctx, report, text.String())
+}
+
+// withScreenshot posts the confirmation as the caption of the report's first screenshot, so
+// the chat sees what was filed. Without one, or when it cannot be downloaded, it is plain text.
+func (c *BugCommand) withScreenshot(ctx context.Context, report *services.BugReport, text string) *domain.Response {
+	response := &domain.Response{Text: text, ParseMode: "Markdown"}
+	if len(report.Screenshots) == 0 {
+		return response
+	}
+
+	tempFile, err := c.telegramFileService.DownloadFile(ctx, &domain.TelegramDocument{FileID: report.Screenshots[0], FileName: "screenshot.jpg"})
+	if err != nil {
+		c.logger.Warn("Failed to download bug screenshot", "error", err)
+		return response
+	}
+	defer c.telegramFileService.CleanupFile(tempFile)
+
+	content, err := os.ReadFile(tempFile)
+	if err != nil {
+		c.logger.Warn("Failed to read bug screenshot", "error", err)
+		return response
+	}
+	response.Document = &domain.OutgoingDocument{FileName: "screenshot.jpg", Content: content, Photo: true}
+	return response
+}
+
+// screenshotFileID returns the file ID of an image sent with the command: the largest size
+// of a photo, or an image sent as a file
+func screenshotFileID(cmd *domain.Command) string {
+	if len(cmd.Photo) > 0 {
+		largest := cmd.Photo[0]
+		for _, photo := range cmd.Photo[1:] {
+			if photo.Width*photo.Height > largest.Width*largest.Height {
+				largest = photo
+			}
+		}
+		return largest.FileID
+	}
+	if cmd.Document != nil && strings.HasPrefix(cmd.Document.MimeType, "image/") {
+		return cmd.Document.FileID
+	}
+	return ""
+}
+
+// formatBugDescription is the description of a bug filed as a task
+func formatBugDescription(report *services.BugReport, reporter string) string {
+	var text strings.Builder
+	text.WriteString("What happened:\n" + report.Happened + "\n\n")
+	text.WriteString("Expected:\n" + report.Expected + "\n\n")
+	text.WriteString("Steps to reproduce:\n" + report.Steps + "\n\n")
+	text.WriteString("Reported by " + reporter + " via /bug")
+	return text.String()
+}
+
+// formatBugIssue is the body of a bug filed as a GitHub issue
+func formatBugIssue(report *services.BugReport, reporter string) string {
+	var text strings.Builder
+	text.WriteString("### What happened\n\n" + report.Happened + "\n\n")
+	text.WriteString("### Expected behavior\n\n" + report.Expected + "\n\n")
+	text.WriteString("### Steps to reproduce\n\n" + report.Steps + "\n\n")
+	text.WriteString("---\n")
+	text.WriteString("Reported from Telegram by " + reporter + ".")
+	if count := len(report.Screenshots); count > 0 {
+		text.WriteString(fmt.Sprintf(" %d screenshot(s) were posted with the report in the team chat.", count))
+	}
+	return text.String()
+}
+
+// firstLine returns the first non-empty line of a text
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
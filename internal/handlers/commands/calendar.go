@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// CalendarCommand handles team working calendar configuration. The calendar turns hour
+// estimates into developer days and finish dates in /analyze, /analyses and /workload, and
+// decides when tasks count as overdue in due alerts and health scores.
+type CalendarCommand struct {
+	calendarService *services.CalendarService
+	logger          domain.Logger
+}
+
+// NewCalendarCommand creates a new calendar command handler
+func NewCalendarCommand(calendarService *services.CalendarService, logger domain.Logger) *CalendarCommand {
+	return &CalendarCommand{
+		calendarService: calendarService,
+		logger:          logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *CalendarCommand) CanHandle(command string) bool {
+	return command == "/calendar"
+}
+
+// Description returns the command description
+func (c *CalendarCommand) Description() string {
+	return "📅 Configure team working hours, working days and holidays"
+}
+
+// Usage returns the command usage instructions
+func (c *CalendarCommand) Usage() string {
+	return "/calendar [hours N | days N | holiday add|remove YYYY-MM-DD] - Team working calendar"
+}
+
+// Category puts the command in the team category a chat can turn off
+func (c *CalendarCommand) Category() string {
+	return services.CategoryTeam
+}
+
+// Handle processes the calendar command
+func (c *CalendarCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing calendar command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/calendar")))
+
+	if len(args) == 0 {
+		return &domain.Response{
+			Text:      c.formatCalendar(c.calendarService.GetCalendar(ctx, teamID)),
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	var err error
+	switch strings.ToLower(args[0]) {
+	case "hours":
+		if len(args) < 2 {
+			return c.usageResponse(), nil
+		}
+		hours, parseErr := strconv.ParseFloat(args[1], 64)
+		if parseErr != nil {
+			return errorResponse("Hours must be a number, e.g. `/calendar hours 6`"), nil
+		}
+		err = c.calendarService.SetHoursPerDay(teamID, hours)
+	case "days":
+		if len(args) < 2 {
+			return c.usageResponse(), nil
+		}
+		days, parseErr := strconv.Atoi(args[1])
+		if parseErr != nil {
+			return errorResponse("Days must be a whole number, e.g. `/calendar days 6`"), nil
+		}
+		err = c.calendarService.SetDaysPerWeek(teamID, days)
+	case "holiday":
+		if len(args) < 3 {
+			return c.usageResponse(), nil
+		}
+		date, parseErr := services.ParseHolidayDate(args[2])
+		if parseErr != nil {
+			return errorResponse(parseErr.Error()), nil
+		}
+		switch strings.ToLower(args[1]) {
+		case "add":
+			err = c.calendarService.AddHoliday(teamID, date)
+		case "remove":
+			err = c.calendarService.RemoveHoliday(teamID, date)
+		default:
+			return c.usageResponse(), nil
+		}
+	default:
+		return c.usageResponse(), nil
+	}
+
+	if err != nil {
+		c.logger.Error("Failed to update team calendar", "team_id", teamID, "error", err)
+		return errorResponse(err.Error()), nil
+	}
+
+	c.logger.Info("Team calendar updated", "team_id", teamID, "setting", args[0])
+
+	return &domain.Response{
+		Text:      "✅ **Calendar updated**\n\n" + c.formatCalendar(c.calendarService.GetCalendar(ctx, teamID)),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// formatCalendar formats the team calendar for display
+func (c *CalendarCommand) formatCalendar(calendar domain.WorkCalendar) string {
+	var response strings.Builder
+
+	response.WriteString("📅 **Team Working Calendar**\n\n")
+	response.WriteString(fmt.Sprintf("├── ⏰ Hours per day: %.1fh\n", calendar.HoursPerDay))
+	response.WriteString(fmt.Sprintf("├── 📆 Working days: %s\n", formatWorkingDays(calendar.DaysPerWeek)))
+	response.WriteString(fmt.Sprintf("└── 📊 Weekly hours: %.1fh\n\n", calendar.WeeklyHours()))
+	response.WriteString("_Used for developer days and finish dates in /analyze, /analyses and /workload, and for overdue tasks in task lists, /health and due alerts._\n\n")
+
+	upcoming := upcomingHolidays(calendar.Holidays, time.Now())
+	if len(upcoming) > 5 {
+		upcoming = upcoming[:5]
+	}
+	if len(upcoming) > 0 {
+		response.WriteString("🏖️ **Upcoming Days Off:**\n")
+		for _, holiday := range upcoming {
+			response.WriteString(fmt.Sprintf("• %s\n", holiday.Format("Mon, Jan 2 2006")))
+		}
+		response.WriteString("\n")
+	}
+
+	response.WriteString("**Configure:**\n")
+	response.WriteString("• `/calendar hours 6` - working hours per day\n")
+	response.WriteString("• `/calendar days 6` - working days per week (from Monday)\n")
+	response.WriteString("• `/calendar holiday add 2026-03-21` - add a day off\n")
+	response.WriteString("• `/calendar holiday remove 2026-03-21` - remove a day off\n")
+	response.WriteString("• `/holidays country UZ` - include public holidays")
+
+	return response.String()
+}
+
+// usageResponse returns the command usage help
+func (c *CalendarCommand) usageResponse() *domain.Response {
+	return errorResponse("Unknown calendar option.\n\n" +
+		"**Examples:**\n" +
+		"• `/calendar hours 6`\n" +
+		"• `/calendar days 5`\n" +
+		"• `/calendar holiday add 2026-03-21`")
+}
+
+// formatWorkingDays returns a readable weekday range for a Monday-based working week
+func formatWorkingDays(daysPerWeek int) string {
+	weekdays := []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	if daysPerWeek < 1 || daysPerWeek > len(weekdays) {
+		return "-"
+	}
+	if daysPerWeek == 1 {
+		return weekdays[0]
+	}
+	return fmt.Sprintf("%s-%s (%d days)", weekdays[0], weekdays[daysPerWeek-1], daysPerWeek)
+}
+
+// upcomingHolidays returns holidays from today onwards
+func upcomingHolidays(holidays []time.Time, now time.Time) []time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	upcoming := []time.Time{}
+	for _, holiday := range holidays {
+		if !holiday.Before(today) {
+			upcoming = append(upcoming, holiday)
+		}
+	}
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].Before(upcoming[j])
+	})
+	return upcoming
+}
@@ -0,0 +1,255 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// Team settings of the daily challenge. ChallengeKey is "on" when the chat gets the daily
+// challenge; ChallengeSentKey holds the day it was last posted.
+const (
+	ChallengeKey     = "challenge"
+	ChallengeSentKey = "challenge_sent"
+)
+
+// ChallengeHour is the hour, in the bot's time zone, the daily challenge is posted at
+const ChallengeHour = 10
+
+// maxLeaderboardEntries limits the members shown on the challenge leaderboard
+const maxLeaderboardEntries = 10
+
+// ChallengeCommand shows the daily Go challenge, checks answers sent in a private chat and
+// keeps the members' solving streaks
+type ChallengeCommand struct {
+	db     *database.DB
+	logger domain.Logger
+}
+
+// NewChallengeCommand creates a new challenge command handler
+func NewChallengeCommand(db *database.DB, logger domain.Logger) *ChallengeCommand {
+	return &ChallengeCommand{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *ChallengeCommand) CanHandle(command string) bool {
+	return command == "/challenge"
+}
+
+// Description returns the command description
+func (c *ChallengeCommand) Description() string {
+	return "🧩 Daily Go coding challenge with streaks"
+}
+
+// Usage returns the command usage instructions
+func (c *ChallengeCommand) Usage() string {
+	return "/challenge [on | off | submit answer | streak | leaderboard] - Daily Go challenge"
+}
+
+// Category puts the command in the fun category a chat can turn off
+func (c *ChallengeCommand) Category() string {
+	return services.CategoryFun
+}
+
+// Handle processes the challenge command
+func (c *ChallengeCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing challenge command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	now := time.Now().In(services.BotLocation())
+	args := strings.Fields(strings.TrimPrefix(cmd.Text, "/challenge"))
+	if len(args) == 0 {
+		return &domain.Response{
+			Text:        FormatChallenge(domain.DailyChallenge(domain.ChallengeBank, now)),
+			ParseMode:   "Markdown",
+			ReplyMarkup: ChallengeKeyboard(),
+		}, nil
+	}
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	switch strings.ToLower(args[0]) {
+	case "on", "off":
+		if cmd.Chat.Type == "private" {
+			return errorResponse("Turn the daily challenge on in the team chat."), nil
+		}
+		value := ""
+		if strings.ToLower(args[0]) == "on" {
+			value = "on"
+		}
+		if err := c.db.SetTeamSetting(teamID, ChallengeKey, value); err != nil {
+			c.logger.Error("Failed to update daily challenge", "team_id", teamID, "error", err)
+			return errorResponse("Failed to save the setting. Please try again."), nil
+		}
+		if value == "" {
+			return &domain.Response{Text: "✅ Daily challenge disabled", ParseMode: "Markdown"}, nil
+		}
+		return &domain.Response{
+			Text: fmt.Sprintf("✅ Daily challenge enabled. A new Go challenge is posted here every day at %02d:00; "+
+				"members send their answers to the bot in a private chat.", ChallengeHour),
+			ParseMode: "Markdown",
+		}, nil
+	case "submit":
+		return c.submit(cmd, strings.Join(args[1:], " "), now), nil
+	case "streak":
+		return c.streak(cmd, now), nil
+	case "leaderboard", "top":
+		return c.leaderboard(cmd, now), nil
+	default:
+		return errorResponse("Usage: `" + c.Usage() + "`"), nil
+	}
+}
+
+// submit checks an answer to today's challenge and records a correct one
+func (c *ChallengeCommand) submit(cmd *domain.Command, answer string, now time.Time) *domain.Response {
+	if cmd.Chat.Type != "private" {
+		return errorResponse("Send your answer to the bot in a private chat so you don't spoil it for the team.")
+	}
+	if strings.TrimSpace(answer) == "" {
+		return errorResponse("Usage: `/challenge submit 42`")
+	}
+
+	challenge := domain.DailyChallenge(domain.ChallengeBank, now)
+	if !challenge.CheckAnswer(answer) {
+		c.logger.Info("Challenge answer rejected", "user_id", cmd.User.TelegramID, "challenge", challenge.ID)
+		return &domain.Response{Text: "🤔 Not quite. Check your program and try again.", ParseMode: "Markdown"}
+	}
+
+	recorded, err := c.db.RecordChallengeCompletion(cmd.User.TelegramID, domain.ChallengeDay(now), challenge.ID)
+	if err != nil {
+		c.logger.Error("Failed to record challenge completion", "user_id", cmd.User.TelegramID, "error", err)
+		return errorResponse("Failed to record your answer. Please try again.")
+	}
+	days, err := c.db.GetChallengeDays(cmd.User.TelegramID)
+	if err != nil {
+		c.logger.Error("Failed to load challenge days", "user_id", cmd.User.TelegramID, "error", err)
+		return errorResponse("Failed to load your streak. Please try again.")
+	}
+	current, best := domain.ChallengeStreak(days, now)
+	c.logger.Info("Challenge solved", "user_id", cmd.User.TelegramID, "challenge", challenge.ID, "streak", current)
+
+	title := "🎉 **Correct!**"
+	if !recorded {
+		title = "✅ **Already solved today**"
+	}
+	return &domain.Response{
+		Text: fmt.Sprintf("%s\n\n🔥 Streak: %d days\n🏆 Best: %d days\n🧩 Solved: %d\n\nThe next challenge comes tomorrow.",
+			title, current, best, len(days)),
+		ParseMode: "Markdown",
+	}
+}
+
+// streak shows the user's own challenge record
+func (c *ChallengeCommand) streak(cmd *domain.Command, now time.Time) *domain.Response {
+	days, err := c.db.GetChallengeDays(cmd.User.TelegramID)
+	if err != nil {
+		c.logger.Error("Failed to load challenge days", "user_id", cmd.User.TelegramID, "error", err)
+		return errorResponse("Failed to load your streak. Please try again.")
+	}
+	current, best := domain.ChallengeStreak(days, now)
+
+	today := "not yet · `/challenge` to see it"
+	if len(days) > 0 && days[0] == domain.ChallengeDay(now) {
+		today = "solved ✅"
+	}
+	return &domain.Response{
+		Text: fmt.Sprintf("🧩 **Your Challenge Streak**\n\n"+
+			"├── Today: %s\n"+
+			"├── 🔥 Current streak: %d days\n"+
+			"├── 🏆 Best streak: %d days\n"+
+			"└── Solved: %d", today, current, best, len(days)),
+		ParseMode: "Markdown",
+	}
+}
+
+// challengeStanding is a team member's challenge record on the leaderboard
+type challengeStanding struct {
+	name    string
+	current int
+	best    int
+	solved  int
+}
+
+// leaderboard ranks the chat's team members by their current streak
+func (c *ChallengeCommand) leaderboard(cmd *domain.Command, now time.Time) *domain.Response {
+	members, err := c.db.GetTeamMembersByChatID(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to load team members", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to load the team. Please try again.")
+	}
+
+	var standings []challengeStanding
+	seen := make(map[int64]bool)
+	for _, member := range members {
+		// Members added by username are linked to their Telegram ID once they use the bot
+		if member.UserID == 0 || seen[member.UserID] {
+			continue
+		}
+		seen[member.UserID] = true
+		days, err := c.db.GetChallengeDays(member.UserID)
+		if err != nil {
+			c.logger.Error("Failed to load challenge days", "user_id", member.UserID, "error", err)
+			return errorResponse("Failed to load the leaderboard. Please try again.")
+		}
+		if len(days) == 0 {
+			continue
+		}
+		current, best := domain.ChallengeStreak(days, now)
+		standings = append(standings, challengeStanding{name: member.Username, current: current, best: best, solved: len(days)})
+	}
+
+	if len(standings) == 0 {
+		return &domain.Response{
+			Text:      "🏆 **Challenge Leaderboard**\n\nNo team member has solved a challenge yet. See today's with `/challenge`.",
+			ParseMode: "Markdown",
+		}
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].current != standings[j].current {
+			return standings[i].current > standings[j].current
+		}
+		if standings[i].solved != standings[j].solved {
+			return standings[i].solved > standings[j].solved
+		}
+		return standings[i].name < standings[j].name
+	})
+	if len(standings) > maxLeaderboardEntries {
+		standings = standings[:maxLeaderboardEntries]
+	}
+
+	medals := []string{"🥇", "🥈", "🥉"}
+	var text strings.Builder
+	text.WriteString("🏆 **Challenge Leaderboard**\n\n")
+	for i, standing := range standings {
+		rank := fmt.Sprintf("%d.", i+1)
+		if i < len(medals) {
+			rank = medals[i]
+		}
+		text.WriteString(fmt.Sprintf("%s %s · 🔥 %d · best %d · solved %d\n",
+			rank, escapeMarkdown(standing.name), standing.current, standing.best, standing.solved))
+	}
+	return &domain.Response{Text: text.String(), ParseMode: "Markdown"}
+}
+
+// FormatChallenge renders a challenge with how to answer it
+func FormatChallenge(challenge domain.Challenge) string {
+	return fmt.Sprintf("🧩 **Daily Challenge: %s** (%s)\n\n%s\n\n"+
+		"Send your program's output to the bot in a private chat: `/challenge submit your-answer`",
+		challenge.Title, challenge.Difficulty, challenge.Prompt)
+}
+
+// ChallengeKeyboard links a challenge post to the user's streak and the leaderboard
+func ChallengeKeyboard() *domain.InlineKeyboardMarkup {
+	return domain.NewKeyboard().
+		Button("🔥 My streak", "/challenge streak").
+		Button("🏆 Leaderboard", "/challenge leaderboard").
+		Build()
+}
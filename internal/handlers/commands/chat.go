@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// ChatCommand answers general development questions with short conversation memory
+type ChatCommand struct {
+	chatService *services.ChatService
+	logger      domain.Logger
+}
+
+// NewChatCommand creates a new AI chat command handler
+func NewChatCommand(chatService *services.ChatService, logger domain.Logger) *ChatCommand {
+	return &ChatCommand{
+		chatService: chatService,
+		logger:      logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *ChatCommand) CanHandle(command string) bool {
+	return command == "/chat"
+}
+
+// Description returns the command description
+func (c *ChatCommand) Description() string {
+	return "💬 Ask the AI general development questions"
+}
+
+// Usage returns the command usage instructions
+func (c *ChatCommand) Usage() string {
+	return "/chat question | /chat reset - AI developer assistant with short memory"
+}
+
+// Category puts the command in the ai category a chat can turn off
+func (c *ChatCommand) Category() string {
+	return services.CategoryAI
+}
+
+// ChatAction implements domain.LongRunningHandler: answers come from the AI chain
+func (c *ChatCommand) ChatAction(cmd *domain.Command) string {
+	if strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/chat")) == "reset" {
+		return ""
+	}
+	return domain.ChatActionTyping
+}
+
+// Handle processes the chat command
+func (c *ChatCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing chat command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	_, question := splitFirstWord(strings.TrimSpace(cmd.Text))
+
+	if question == "" {
+		return c.usageResponse(cmd.User.TelegramID), nil
+	}
+	if strings.EqualFold(question, "reset") {
+		c.chatService.Reset(cmd.User.TelegramID)
+		return &domain.Response{
+			Text:      "🧹 Conversation memory cleared. Ask a new question with `/chat`.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	if !c.chatService.IsConfigured() {
+		return &domain.Response{
+			Text:      "❌ AI chat needs an AI provider. Set `CLAUDE_API_KEY`, `OPENAI_API_KEY` or `GEMINI_API_KEY`.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	answer, err := c.chatService.Ask(ctx, cmd.Chat.ID, cmd.User.TelegramID, question)
+	if err != nil {
+		var limitErr *services.ChatLimitError
+		if errors.As(err, &limitErr) {
+			c.logger.Warn("AI chat limit reached", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID, "reason", limitErr.Reason)
+			return &domain.Response{
+				Text:      "⚠️ " + limitErr.Error() + ".\n\n`/analyze` has a separate budget and is not affected.",
+				ParseMode: "Markdown",
+			}, nil
+		}
+
+		c.logger.Error("AI chat failed", "user_id", cmd.User.TelegramID, "error", err)
+		return &domain.Response{
+			Text:      "❌ AI is not available right now. Please try again later.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	return &domain.Response{
+		Text:      "💬 " + answer,
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// usageResponse explains the chat mode and shows remaining questions
+func (c *ChatCommand) usageResponse(userID int64) *domain.Response {
+	remaining, _ := c.chatService.UserLimiter().Remaining(userID)
+	turns := len(c.chatService.History(userID))
+
+	return &domain.Response{
+		Text: "💬 **AI Developer Chat**\n\n" +
+			"**Examples:**\n" +
+			"• `/chat How do I add a context timeout to an HTTP call in Go?`\n" +
+			"• `/chat What about retries?` - follow-ups remember the last few turns\n" +
+			"• `/chat reset` - forget the conversation\n\n" +
+			fmt.Sprintf("├── 🧠 Remembered turns: %d\n", turns) +
+			fmt.Sprintf("└── ⏳ Questions left this hour: %d/%d", remaining, c.chatService.UserLimiter().Limit()),
+		ParseMode: "Markdown",
+	}
+}
@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// categoryLabels describes the command categories a chat can turn off
+var categoryLabels = map[string]string{
+	services.CategoryFun:      "🎭 Fun",
+	services.CategoryAI:       "🤖 AI assistant",
+	services.CategoryDev:      "🛠 Developer tools",
+	services.CategoryTeam:     "👥 Team",
+	services.CategoryProjects: "🚀 Projects and tasks",
+}
+
+// ChatSettingsCommand configures which command categories a chat allows, its default
+// language and whether files are analyzed without a command
+type ChatSettingsCommand struct {
+	settings *services.ChatSettingsService
+	chats    *services.TelegramChatService
+	router   domain.Router
+	logger   domain.Logger
+}
+
+// NewChatSettingsCommand creates a new chat settings command handler
+func NewChatSettingsCommand(settings *services.ChatSettingsService, chats *services.TelegramChatService, router domain.Router, logger domain.Logger) *ChatSettingsCommand {
+	return &ChatSettingsCommand{
+		settings: settings,
+		chats:    chats,
+		router:   router,
+		logger:   logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *ChatSettingsCommand) CanHandle(command string) bool {
+	return command == "/chat_settings"
+}
+
+// Description returns the command description
+func (c *ChatSettingsCommand) Description() string {
+	return "🎛 Commands, language and file analysis of this chat"
+}
+
+// Usage returns the command usage instructions
+func (c *ChatSettingsCommand) Usage() string {
+	return "/chat_settings [enable|disable category | language uz|ru|en|default | autoanalyze on|off]"
+}
+
+// Category is empty, as chats cannot turn the command off
+func (c *ChatSettingsCommand) Category() string {
+	return ""
+}
+
+// Handle processes the chat_settings command. Anyone can view the settings; in groups only
+// administrators change them.
+func (c *ChatSettingsCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing chat_settings command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/chat_settings"))))
+	if len(args) == 0 {
+		return c.settingsResponse(cmd.Chat, ""), nil
+	}
+	if len(args) < 2 {
+		return errorResponse("Usage: `" + c.Usage() + "`"), nil
+	}
+
+	if isGroupChat(cmd.Chat.Type) {
+		admins, err := c.chats.GetChatAdministrators(ctx, cmd.Chat.ID)
+		if err != nil {
+			c.logger.Error("Failed to get chat administrators", "chat_id", cmd.Chat.ID, "error", err)
+			return errorResponse("Failed to check the chat administrators. Please try again."), nil
+		}
+		if !isChatAdministrator(admins, cmd.User.TelegramID) {
+			return errorResponse("Only chat administrators can change the chat settings."), nil
+		}
+	}
+
+	var err error
+	var notice string
+	switch option, value := args[0], args[1]; option {
+	case "enable", "disable":
+		err = c.settings.SetCategory(cmd.Chat.ID, value, option == "enable")
+		notice = fmt.Sprintf("✅ %s commands %sd", categoryLabels[value], option)
+	case "language":
+		if value == "default" {
+			value = ""
+		}
+		err = c.settings.SetLanguage(cmd.Chat.ID, value)
+		notice = "✅ Default language updated"
+	case "autoanalyze":
+		if value != "on" && value != "off" {
+			return errorResponse("Usage: `/chat_settings autoanalyze on|off`"), nil
+		}
+		err = c.settings.SetAutoAnalyze(cmd.Chat.ID, value == "on")
+		notice = "✅ File auto-analysis turned " + value
+	default:
+		return errorResponse("Usage: `" + c.Usage() + "`"), nil
+	}
+
+	if err != nil {
+		c.logger.Error("Failed to update chat settings", "chat_id", cmd.Chat.ID, "option", args[0], "error", err)
+		return errorResponse(err.Error()), nil
+	}
+
+	c.logger.Info("Chat settings updated", "chat_id", cmd.Chat.ID, "option", args[0], "value", args[1], "user_id", cmd.User.TelegramID)
+	return c.settingsResponse(cmd.Chat, notice), nil
+}
+
+// settingsResponse shows the chat's settings with the commands that change them
+func (c *ChatSettingsCommand) settingsResponse(chat *domain.Chat, notice string) *domain.Response {
+	settings := c.settings.Get(chat.ID)
+
+	var response strings.Builder
+	if notice != "" {
+		response.WriteString(notice + "\n\n")
+	}
+
+	scope := "private chat"
+	if isGroupChat(chat.Type) {
+		scope = "group"
+	}
+	response.WriteString(fmt.Sprintf("🎛 **Settings of this %s**\n\n", scope))
+
+	response.WriteString("**Command categories:**\n")
+	for _, category := range services.CategoryNames() {
+		mark := "✅"
+		if !settings.CategoryEnabled(category) {
+			mark = "⬜"
+		}
+		commands := c.categoryCommands(category)
+		response.WriteString(fmt.Sprintf("%s %s (`%s`): %s\n", mark, categoryLabels[category], category, strings.Join(commands, " ")))
+	}
+
+	language := "not set, replies follow each command's default"
+	if settings.Language != "" {
+		language = services.LanguageNames[settings.Language]
+	}
+	autoAnalyze := "on"
+	if !settings.AutoAnalyze {
+		autoAnalyze = "off"
+	}
+	response.WriteString(fmt.Sprintf("\n🌐 Default language: %s\n", language))
+	response.WriteString(fmt.Sprintf("📎 Analyze files sent without a command: %s\n", autoAnalyze))
+
+	response.WriteString("\n**Configure:**\n")
+	response.WriteString("• `/chat_settings disable fun` - turn a category off\n")
+	response.WriteString("• `/chat_settings enable fun` - turn it back on\n")
+	response.WriteString("• `/chat_settings language ru` - default language, or `default`\n")
+	response.WriteString("• `/chat_settings autoanalyze off` - only analyze files captioned with `/analyze`\n")
+	if isGroupChat(chat.Type) {
+		response.WriteString("\n💡 Only group administrators can change these settings.")
+	}
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// categoryCommands lists the main command of each registered handler in a category
+func (c *ChatSettingsCommand) categoryCommands(category string) []string {
+	var commands []string
+	for _, handler := range c.router.GetHandlers() {
+		categorized, ok := handler.(domain.Categorized)
+		if !ok || categorized.Category() != category {
+			continue
+		}
+		if fields := strings.Fields(handler.Usage()); len(fields) > 0 {
+			commands = append(commands, fields[0])
+		}
+	}
+	return commands
+}
+
+// isGroupChat reports whether a chat type is a group or supergroup
+func isGroupChat(chatType string) bool {
+	return chatType == "group" || chatType == "supergroup"
+}
@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// Team settings of the weekly mood check-in. MoodCheckinKey holds the chat title shown in
+// the check-in message and is empty when check-ins are off.
+const (
+	MoodCheckinKey         = "mood_checkin"
+	MoodCheckinSentKey     = "mood_checkin_sent"
+	MoodCheckinReportedKey = "mood_checkin_reported"
+)
+
+// moodTrendWeeks is the number of weeks shown by /checkin and the weekly pulse
+const moodTrendWeeks = 4
+
+// CheckinCommand manages a team's anonymous weekly mood check-in and records the answers
+// members give with the buttons of the check-in message
+type CheckinCommand struct {
+	db     *database.DB
+	logger domain.Logger
+}
+
+// NewCheckinCommand creates a new check-in command handler
+func NewCheckinCommand(db *database.DB, logger domain.Logger) *CheckinCommand {
+	return &CheckinCommand{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *CheckinCommand) CanHandle(command string) bool {
+	return command == "/checkin" || command == "/mood"
+}
+
+// Description returns the command description
+func (c *CheckinCommand) Description() string {
+	return "🧭 Anonymous weekly mood check-in"
+}
+
+// Usage returns the command usage instructions
+func (c *CheckinCommand) Usage() string {
+	return "/checkin [on | off] - Weekly anonymous 1-5 mood check-in by private message, with the team trend"
+}
+
+// Category puts the command in the fun category a chat can turn off
+func (c *CheckinCommand) Category() string {
+	return services.CategoryFun
+}
+
+// Handle processes the checkin command and check-in answers
+func (c *CheckinCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing checkin command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	if strings.HasPrefix(cmd.Text, "/mood") {
+		return c.recordAnswer(cmd), nil
+	}
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	args := strings.Fields(strings.TrimPrefix(cmd.Text, "/checkin"))
+	if len(args) == 0 {
+		return c.statusResponse(teamID, ""), nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "on":
+		if cmd.Chat.Type == "private" {
+			return errorResponse("Turn check-ins on in the team chat."), nil
+		}
+		title := cmd.Chat.Title
+		if title == "" {
+			title = "your team"
+		}
+		if err := c.db.SetTeamSetting(teamID, MoodCheckinKey, title); err != nil {
+			c.logger.Error("Failed to enable mood check-ins", "team_id", teamID, "error", err)
+			return errorResponse("Failed to save the setting. Please try again."), nil
+		}
+		return c.statusResponse(teamID, "✅ Weekly check-ins enabled. Members get a private message on Fridays; they need to have started a chat with the bot."), nil
+	case "off":
+		if err := c.db.SetTeamSetting(teamID, MoodCheckinKey, ""); err != nil {
+			c.logger.Error("Failed to disable mood check-ins", "team_id", teamID, "error", err)
+			return errorResponse("Failed to save the setting. Please try again."), nil
+		}
+		return c.statusResponse(teamID, "✅ Weekly check-ins disabled"), nil
+	default:
+		return errorResponse("Usage: `/checkin on` or `/checkin off`"), nil
+	}
+}
+
+// recordAnswer stores a check-in answer pressed as "/mood chat_id week score"
+func (c *CheckinCommand) recordAnswer(cmd *domain.Command) *domain.Response {
+	args := strings.Fields(strings.TrimPrefix(cmd.Text, "/mood"))
+	if len(args) != 3 {
+		return errorResponse("Answer the check-in with the buttons of its message.")
+	}
+	chatID, err := strconv.ParseInt(args[0], 10, 64)
+	score, scoreErr := strconv.Atoi(args[2])
+	if err != nil || scoreErr != nil || score < domain.MoodMinScore || score > domain.MoodMaxScore {
+		return errorResponse("Answer the check-in with the buttons of its message.")
+	}
+
+	teamID := fmt.Sprintf("team_%d", chatID)
+	week := args[1]
+	now := time.Now().In(services.BotLocation())
+	settings, err := c.db.GetTeamSettings(teamID)
+	if err != nil {
+		c.logger.Error("Failed to load team settings", "team_id", teamID, "error", err)
+		return errorResponse("Failed to record your answer. Please try again.")
+	}
+	open := week == domain.MoodWeekKey(now) || week == domain.MoodWeekKey(now.AddDate(0, 0, -7))
+	if !open || settings[MoodCheckinReportedKey] == week || settings[MoodCheckinKey] == "" {
+		return errorResponse("This check-in is closed. The next one comes on Friday.")
+	}
+
+	members, err := c.db.GetTeamMembersByChatID(chatID)
+	if err != nil {
+		c.logger.Error("Failed to load team members", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to record your answer. Please try again.")
+	}
+	member := false
+	for _, m := range members {
+		if m.UserID == cmd.User.TelegramID {
+			member = true
+			break
+		}
+	}
+	if !member {
+		return errorResponse("Only team members can answer this check-in.")
+	}
+
+	recorded, err := c.db.RecordMoodAnswer(teamID, week, moodRespondent(teamID, week, cmd.User.TelegramID), score)
+	if err != nil {
+		// The score is deliberately not logged
+		c.logger.Error("Failed to record mood answer", "team_id", teamID, "error", err)
+		return errorResponse("Failed to record your answer. Please try again.")
+	}
+	if !recorded {
+		return &domain.Response{Text: "ℹ️ You already answered this week's check-in.", ParseMode: "Markdown"}
+	}
+
+	return &domain.Response{
+		Text:      fmt.Sprintf("🙏 Thanks! Your answer %s was recorded anonymously.\n\nOnly the team average is shown, and only when at least %d members answered.", domain.MoodLabels[score], domain.MoodMinResponses),
+		ParseMode: "Markdown",
+	}
+}
+
+// statusResponse shows whether check-ins are on and the recent trend
+func (c *CheckinCommand) statusResponse(teamID, notice string) *domain.Response {
+	var text strings.Builder
+	if notice != "" {
+		text.WriteString(notice + "\n\n")
+	}
+
+	settings, err := c.db.GetTeamSettings(teamID)
+	if err != nil {
+		c.logger.Warn("Failed to load team settings", "team_id", teamID, "error", err)
+	}
+	text.WriteString("🧭 **Mood Check-in**\n")
+	if settings[MoodCheckinKey] == "" {
+		text.WriteString("└── Status: off\n\n")
+		text.WriteString("Turn on with `/checkin on`. Members rate their week from 1 to 5 in a private message; answers are anonymous.")
+		return &domain.Response{Text: text.String(), ParseMode: "Markdown"}
+	}
+	text.WriteString("├── Status: on, private messages on Fridays\n")
+	text.WriteString("└── Results: team pulse in this chat on Mondays\n\n")
+
+	weeks, err := LoadMoodWeeks(c.db, teamID, time.Now().In(services.BotLocation()), moodTrendWeeks)
+	if err != nil {
+		c.logger.Warn("Failed to load mood results", "team_id", teamID, "error", err)
+	} else {
+		text.WriteString(FormatMoodTrend(weeks))
+	}
+
+	return &domain.Response{Text: text.String(), ParseMode: "Markdown"}
+}
+
+// moodRespondent is the opaque key that prevents a member from answering twice. It only
+// records that someone answered; the score is stored separately as a count.
+func moodRespondent(teamID, week string, userID int64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d", teamID, week, userID)))
+	return hex.EncodeToString(sum[:])
+}
+
+// MoodCheckinMessage returns the private check-in question for a team and its answer buttons
+func MoodCheckinMessage(chatID int64, title, week string) (string, *domain.InlineKeyboardMarkup) {
+	text := fmt.Sprintf("🧭 **Weekly check-in · %s**\n\nHow did this week feel, from 1 (exhausted) to 5 (great)?\n\nYour answer is anonymous: only the team average is shared.", title)
+
+	row := make([]domain.InlineKeyboardButton, 0, domain.MoodMaxScore)
+	for score := domain.MoodMinScore; score <= domain.MoodMaxScore; score++ {
+		row = append(row, domain.InlineKeyboardButton{
+			Text:         fmt.Sprintf("%d %s", score, domain.MoodLabels[score]),
+			CallbackData: fmt.Sprintf("/mood %d %s %d", chatID, week, score),
+		})
+	}
+	return text, &domain.InlineKeyboardMarkup{InlineKeyboard: [][]domain.InlineKeyboardButton{row}}
+}
+
+// LoadMoodWeeks returns the results of the count weeks up to the week before now, oldest first
+func LoadMoodWeeks(db *database.DB, teamID string, now time.Time, count int) ([]domain.MoodWeek, error) {
+	keys := make([]string, count)
+	for i := range keys {
+		keys[i] = domain.MoodWeekKey(now.AddDate(0, 0, -7*(count-i)))
+	}
+
+	results, err := db.GetMoodResults(teamID, keys)
+	if err != nil {
+		return nil, err
+	}
+	weeks := make([]domain.MoodWeek, len(results))
+	for i, result := range results {
+		weeks[i].Week = result.Week
+		for score, responses := range result.Counts {
+			if score >= domain.MoodMinScore && score <= domain.MoodMaxScore {
+				weeks[i].Counts[score] = responses
+			}
+		}
+	}
+	return weeks, nil
+}
+
+// FormatMoodTrend lists the weekly results, oldest first, and alerts on a sharp drop in the last week
+func FormatMoodTrend(weeks []domain.MoodWeek) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📈 **Trend** %s\n", domain.MoodSparkline(weeks)))
+	for i, week := range weeks {
+		branch := "├──"
+		if i == len(weeks)-1 {
+			branch = "└──"
+		}
+		text.WriteString(fmt.Sprintf("%s %s\n", branch, domain.FormatMoodWeek(week)))
+	}
+
+	if n := len(weeks); n >= 2 && domain.SharpMoodDrop(weeks[n-2], weeks[n-1]) {
+		text.WriteString(fmt.Sprintf("\n🚨 **Mood dropped sharply:** %.1f → %.1f. Check in with the team, e.g. in the next retro.\n",
+			weeks[n-2].Average(), weeks[n-1].Average()))
+	}
+	return text.String()
+}
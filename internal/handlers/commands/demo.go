@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/fixtures"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// Team setting keys that mark the chat's sample data so it can be removed again
+const (
+	demoProjectKey = "demo_project"
+	demoMembersKey = "demo_members"
+)
+
+// DemoCommand creates and removes a sample project so new users can try the bot right away
+type DemoCommand struct {
+	db     *database.DB
+	logger domain.Logger
+}
+
+// NewDemoCommand creates a new demo command handler
+func NewDemoCommand(db *database.DB, logger domain.Logger) *DemoCommand {
+	return &DemoCommand{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *DemoCommand) CanHandle(command string) bool {
+	return command == "/demo"
+}
+
+// Description returns the command description
+func (c *DemoCommand) Description() string {
+	return "🧪 Create a sample project to try the bot"
+}
+
+// Usage returns the command usage instructions
+func (c *DemoCommand) Usage() string {
+	return "/demo [remove] - Sample project with tasks, members and history"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *DemoCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the demo command
+func (c *DemoCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing demo command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/demo")))
+
+	settings, err := c.db.GetTeamSettings(teamID)
+	if err != nil {
+		c.logger.Error("Failed to get team settings", "team_id", teamID, "error", err)
+		return errorResponse("Failed to load the chat settings. Please try again."), nil
+	}
+	projectID := settings[demoProjectKey]
+
+	if len(args) > 0 && strings.EqualFold(args[0], "remove") {
+		if projectID == "" {
+			return errorResponse("There is no demo project in this chat. Create one with `/demo`."), nil
+		}
+		return c.removeDemo(teamID, projectID, settings[demoMembersKey]), nil
+	}
+
+	if projectID != "" {
+		return &domain.Response{
+			Text: fmt.Sprintf("🧪 This chat already has a demo project (`%s`).\n\n", projectID) +
+				"Remove it with `/demo remove` before creating a new one.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	return c.createDemo(cmd, teamID), nil
+}
+
+// createDemo creates the sample team, project and tasks and marks them as demo data
+func (c *DemoCommand) createDemo(cmd *domain.Command, teamID string) *domain.Response {
+	now := time.Now()
+	project := &database.Project{
+		ID:          generateProjectID(),
+		Name:        "🧪 Demo: " + fixtures.SampleProjectName,
+		Description: "Sample project created by /demo. Remove it with /demo remove.",
+		TeamID:      teamID,
+		Status:      "active",
+	}
+	if err := c.db.CreateProject(project); err != nil {
+		c.logger.Error("Failed to create demo project", "team_id", teamID, "error", err)
+		return errorResponse("Failed to create the demo project. Please try again.")
+	}
+
+	// Mark the project right away so a partial setup can still be removed
+	if err := c.db.SetTeamSetting(teamID, demoProjectKey, project.ID); err != nil {
+		c.logger.Error("Failed to mark demo project", "team_id", teamID, "error", err)
+		c.db.DeleteProject(project.ID)
+		return errorResponse("Failed to create the demo project. Please try again.")
+	}
+
+	memberIDs := make(map[string]string, len(fixtures.SampleMembers))
+	var createdMembers []string
+	for _, member := range fixtures.SampleMembers {
+		id := fmt.Sprintf("demo_%d_%s", cmd.Chat.ID, member.Key)
+		err := c.db.CreateTeamMember(&database.TeamMember{
+			ID:       id,
+			TeamID:   teamID,
+			Username: member.Username,
+			Role:     member.Role,
+			Skills:   member.Skills,
+			Capacity: 40.0,
+		})
+		if err != nil {
+			c.logger.Warn("Failed to create demo member", "username", member.Username, "error", err)
+			continue
+		}
+		memberIDs[member.Key] = id
+		createdMembers = append(createdMembers, id)
+	}
+	if err := c.db.SetTeamSetting(teamID, demoMembersKey, strings.Join(createdMembers, ",")); err != nil {
+		c.logger.Error("Failed to mark demo members", "team_id", teamID, "error", err)
+	}
+
+	taskIDs := make([]string, len(fixtures.SampleTasks))
+	for i := range fixtures.SampleTasks {
+		taskIDs[i] = fmt.Sprintf("%s_t%d", project.ID, i+1)
+	}
+
+	created := 0
+	for i, sample := range fixtures.SampleTasks {
+		var dependencies []string
+		for _, index := range sample.DependsOn {
+			dependencies = append(dependencies, taskIDs[index])
+		}
+
+		task := &database.Task{
+			ID:            taskIDs[i],
+			ProjectID:     project.ID,
+			Title:         sample.Title,
+			Description:   "Sample task from /demo",
+			Category:      sample.Category,
+			EstimateHours: sample.EstimateHours,
+			Status:        sample.Status,
+			Priority:      sample.Priority,
+			AssignedTo:    memberIDs[sample.Member],
+			Dependencies:  dependencies,
+		}
+		if err := c.db.CreateTask(task); err != nil {
+			c.logger.Warn("Failed to create demo task", "task_id", task.ID, "error", err)
+			continue
+		}
+
+		// Backdate the task so velocity and health have some history to show
+		var completedAt *time.Time
+		if sample.Status == domain.TaskStatusCompleted {
+			completed := now.AddDate(0, 0, -sample.CompletedAgo)
+			completedAt = &completed
+		}
+		if err := c.db.SetTaskTimeline(task.ID, now.AddDate(0, 0, -sample.CreatedAgo), completedAt, sample.ActualHours); err != nil {
+			c.logger.Warn("Failed to backdate demo task", "task_id", task.ID, "error", err)
+		}
+		created++
+	}
+
+	if err := c.db.SetProjectCreatedAt(project.ID, now.AddDate(0, 0, -fixtures.SampleStartedDays)); err != nil {
+		c.logger.Warn("Failed to backdate demo project", "project_id", project.ID, "error", err)
+	}
+	if err := c.db.SetProjectDeadline(project.ID, now.AddDate(0, 0, fixtures.SampleDeadlineDays)); err != nil {
+		c.logger.Warn("Failed to set demo project deadline", "project_id", project.ID, "error", err)
+	}
+
+	c.logger.Info("Demo project created", "team_id", teamID, "project_id", project.ID,
+		"members", len(createdMembers), "tasks", created)
+
+	var response strings.Builder
+	response.WriteString("🧪 **Demo Project Created**\n\n")
+	response.WriteString(fmt.Sprintf("├── Project: **%s** (`%s`)\n", project.Name, project.ID))
+	response.WriteString(fmt.Sprintf("├── Team: %d sample members\n", len(createdMembers)))
+	response.WriteString(fmt.Sprintf("├── Tasks: %d (done, in progress and upcoming)\n", created))
+	response.WriteString(fmt.Sprintf("└── Deadline: %s\n\n", now.AddDate(0, 0, fixtures.SampleDeadlineDays).Format("Jan 2, 2006")))
+	response.WriteString("**Try it out:**\n")
+	response.WriteString("• `/list_projects` - projects with progress and health\n")
+	response.WriteString(fmt.Sprintf("• `/health %s` - health score breakdown\n", project.ID))
+	response.WriteString(fmt.Sprintf("• `/task %s` - task details and dependencies\n", taskIDs[5]))
+	response.WriteString("• `/velocity` - completed work per sprint\n")
+	response.WriteString("• `/list_team` and `/workload` - team and capacity\n")
+	response.WriteString("\n🗑️ Everything marked demo is removed with `/demo remove`.")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// removeDemo deletes the chat's sample project and members
+func (c *DemoCommand) removeDemo(teamID, projectID, members string) *domain.Response {
+	if err := c.db.DeleteProject(projectID); err != nil {
+		c.logger.Error("Failed to delete demo project", "project_id", projectID, "error", err)
+		return errorResponse("Failed to remove the demo project. Please try again.")
+	}
+
+	removed := 0
+	for _, memberID := range strings.Split(members, ",") {
+		if memberID == "" {
+			continue
+		}
+		if err := c.db.DeleteTeamMember(memberID); err != nil {
+			c.logger.Warn("Failed to delete demo member", "member_id", memberID, "error", err)
+			continue
+		}
+		removed++
+	}
+
+	for _, key := range []string{demoProjectKey, demoMembersKey} {
+		if err := c.db.SetTeamSetting(teamID, key, ""); err != nil {
+			c.logger.Error("Failed to clear demo marker", "team_id", teamID, "key", key, "error", err)
+		}
+	}
+
+	c.logger.Info("Demo project removed", "team_id", teamID, "project_id", projectID, "members", removed)
+	return &domain.Response{
+		Text: fmt.Sprintf("🗑️ Demo project and %d sample members removed.\n\n", removed) +
+			"Ready for the real thing? Start with `/create_project name`.",
+		ParseMode: "Markdown",
+	}
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// maxInlineDiagramSource is the longest diagram source sent as a message; longer source is uploaded as a file
+const maxInlineDiagramSource = 3500
+
+// DependenciesCommand shows a project's task dependency graph as a rendered diagram
+type DependenciesCommand struct {
+	db       *database.DB
+	renderer services.DiagramRenderer
+	logger   domain.Logger
+}
+
+// NewDependenciesCommand creates a new dependencies command handler
+func NewDependenciesCommand(db *database.DB, renderer services.DiagramRenderer, logger domain.Logger) *DependenciesCommand {
+	return &DependenciesCommand{
+		db:       db,
+		renderer: renderer,
+		logger:   logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *DependenciesCommand) CanHandle(command string) bool {
+	return command == "/dependencies"
+}
+
+// Description returns the command description
+func (c *DependenciesCommand) Description() string {
+	return "🔗 Task dependency graph of a project"
+}
+
+// Usage returns the command usage instructions
+func (c *DependenciesCommand) Usage() string {
+	return "/dependencies [project_id] [source] - Dependency diagram, or its Mermaid source"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *DependenciesCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// ChatAction implements domain.LongRunningHandler: the diagram is sent as a file
+func (c *DependenciesCommand) ChatAction(cmd *domain.Command) string {
+	return domain.ChatActionUploadDocument
+}
+
+// Handle processes the dependencies command
+func (c *DependenciesCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing dependencies command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	projectID := ""
+	sourceOnly := false
+	for _, arg := range strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/dependencies"))) {
+		if strings.EqualFold(arg, "source") {
+			sourceOnly = true
+			continue
+		}
+		if projectID != "" {
+			return errorResponse("Usage: `/dependencies [project_id] [source]`"), nil
+		}
+		projectID = arg
+	}
+
+	projects, err := c.db.GetProjectsByChatID(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to get projects", "error", err, "chat_id", cmd.Chat.ID)
+		return errorResponse("Failed to retrieve projects. Please try again."), nil
+	}
+
+	var project *database.Project
+	for i := range projects {
+		if (projectID == "" && projects[i].Status == "active") || projects[i].ID == projectID {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		if projectID != "" {
+			return errorResponse(fmt.Sprintf("Project `%s` not found in this chat.", projectID)), nil
+		}
+		return errorResponse("No active project in this chat. Use `/dependencies project_id`."), nil
+	}
+
+	stored, err := c.db.GetTasksByProjectID(project.ID)
+	if err != nil {
+		c.logger.Error("Failed to get project tasks", "error", err, "project_id", project.ID)
+		return errorResponse("Failed to retrieve tasks. Please try again."), nil
+	}
+	if len(stored) == 0 {
+		return errorResponse(fmt.Sprintf("**%s** has no tasks yet.", project.Name)), nil
+	}
+
+	tasks := ToDomainTasks(stored)
+	source := domain.TaskDependencyMermaid(tasks)
+
+	links := 0
+	for _, task := range tasks {
+		links += len(task.Dependencies)
+	}
+	caption := fmt.Sprintf("🔗 **%s** · %d tasks, %d dependencies", project.Name, len(tasks), links)
+
+	if !sourceOnly && c.renderer.IsConfigured() {
+		image, err := c.renderer.Render(ctx, domain.DiagramMermaid, source)
+		if err == nil {
+			return &domain.Response{
+				Text:      caption,
+				ParseMode: "Markdown",
+				Document: &domain.OutgoingDocument{
+					FileName: fmt.Sprintf("dependencies_%s.png", project.ID),
+					Content:  image,
+					Photo:    true,
+					Report:   true,
+				},
+			}, nil
+		}
+		c.logger.Warn("Failed to render dependency diagram", "project_id", project.ID, "error", err)
+		caption += "\n⚠️ Diagram rendering is unavailable, showing the Mermaid source"
+	}
+
+	if len(source) > maxInlineDiagramSource {
+		return &domain.Response{
+			Text:      caption,
+			ParseMode: "Markdown",
+			Document: &domain.OutgoingDocument{
+				FileName: fmt.Sprintf("dependencies_%s.mmd", project.ID),
+				Content:  []byte(source),
+				Report:   true,
+			},
+		}, nil
+	}
+
+	return &domain.Response{
+		Text:      fmt.Sprintf("%s\n\n```\n%s```", caption, source),
+		ParseMode: "Markdown",
+	}, nil
+}
@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// DeployMonitorDelay is how long after a deployment the team is reminded to check production
+const DeployMonitorDelay = 30 * time.Minute
+
+// Errors of RecordDeployment
+var (
+	ErrInvalidVersion     = errors.New("invalid version")
+	ErrDeploymentRecorded = errors.New("deployment already recorded")
+)
+
+var deployVersionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]{0,63}$`)
+
+// DeploymentRelease is a recorded deployment with the tasks it released and its release notes
+type DeploymentRelease struct {
+	Deployment database.Deployment
+	Tasks      []database.ReleasedTask
+	Notes      string
+}
+
+// DeployCommand records deployments from chat
+type DeployCommand struct {
+	db     *database.DB
+	logger domain.Logger
+}
+
+// NewDeployCommand creates a new deploy command handler
+func NewDeployCommand(db *database.DB, logger domain.Logger) *DeployCommand {
+	return &DeployCommand{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *DeployCommand) CanHandle(command string) bool {
+	return command == "/deployed"
+}
+
+// Description returns the command description
+func (c *DeployCommand) Description() string {
+	return "🚀 Record a deployment and post release notes"
+}
+
+// Usage returns the command usage instructions
+func (c *DeployCommand) Usage() string {
+	return "/deployed project_id version - Record a deployment"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *DeployCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the deployed command
+func (c *DeployCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing deployed command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/deployed")))
+	if len(args) != 2 {
+		return errorResponse("Usage: `/deployed project_id version`, e.g. `/deployed proj_123 v1.4.0`"), nil
+	}
+
+	projects, err := c.db.GetProjectsByChatID(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to get projects", "error", err, "chat_id", cmd.Chat.ID)
+		return errorResponse("Failed to retrieve projects. Please try again."), nil
+	}
+	project := findProject(projects, args[0])
+	if project == nil {
+		return errorResponse(fmt.Sprintf("Project `%s` not found. See `/list_projects`.", args[0])), nil
+	}
+
+	release, err := RecordDeployment(c.db, c.logger, *project, cmd.Chat.ID, args[1], auditActor(cmd.User), cmd.User.TelegramID)
+	switch {
+	case errors.Is(err, ErrInvalidVersion):
+		return errorResponse("Versions are up to 64 letters, digits and `._+-`, e.g. `v1.4.0`."), nil
+	case errors.Is(err, ErrDeploymentRecorded):
+		return errorResponse(fmt.Sprintf("`%s` of **%s** is already recorded.", args[1], project.Name)), nil
+	case err != nil:
+		c.logger.Error("Failed to record deployment", "project_id", project.ID, "error", err)
+		return errorResponse("Failed to record the deployment. Please try again."), nil
+	}
+
+	return &domain.Response{Text: release.Notes, ParseMode: "Markdown"}, nil
+}
+
+// RecordDeployment records a deployment of the project, marks its completed tasks not yet
+// shipped as released, and schedules a post-deploy check reminder to the chat. The returned
+// release notes are meant to be posted to the chat. createdBy is the Telegram ID of the user
+// who reported the deployment, 0 for CI.
+func RecordDeployment(db *database.DB, logger domain.Logger, project database.Project, chatID int64, version, actor string, createdBy int64) (*DeploymentRelease, error) {
+	if !deployVersionPattern.MatchString(version) {
+		return nil, ErrInvalidVersion
+	}
+
+	deployment := database.Deployment{
+		ProjectID:  project.ID,
+		Version:    version,
+		DeployedBy: actor,
+		DeployedAt: time.Now(),
+	}
+	created, err := db.CreateDeployment(&deployment)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrDeploymentRecorded
+	}
+
+	tasks, err := db.ReleaseCompletedTasks(project.ID, deployment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := db.GetLatestDeployment(project.ID, deployment.ID)
+	if err != nil {
+		logger.Warn("Failed to load previous deployment", "project_id", project.ID, "error", err)
+	}
+	settings, err := db.GetTeamSettings(project.TeamID)
+	if err != nil {
+		logger.Warn("Failed to load team settings", "team_id", project.TeamID, "error", err)
+	}
+
+	if err := db.LogAudit(&database.AuditEntry{
+		ChatID:  chatID,
+		Actor:   actor,
+		Action:  database.AuditDeployment,
+		Subject: project.ID,
+		Details: fmt.Sprintf("%s deployed, %d tasks released", version, len(tasks)),
+	}); err != nil {
+		logger.Error("Failed to write audit entry", "project_id", project.ID, "error", err)
+	}
+
+	reminder := &database.ScheduledMessage{
+		ChatID:    chatID,
+		Text:      formatDeployCheck(project, version),
+		SendAt:    deployment.DeployedAt.Add(DeployMonitorDelay),
+		CreatedBy: createdBy,
+	}
+	monitored := true
+	if err := db.CreateScheduledMessage(reminder); err != nil {
+		logger.Error("Failed to schedule post-deploy check", "project_id", project.ID, "error", err)
+		monitored = false
+	}
+
+	logger.Info("Deployment recorded", "project_id", project.ID, "version", version, "released", len(tasks))
+	return &DeploymentRelease{
+		Deployment: deployment,
+		Tasks:      tasks,
+		Notes:      formatReleaseNotes(project, deployment, previous, tasks, TaskKeyPrefix(settings), monitored),
+	}, nil
+}
+
+// formatReleaseNotes lists the tasks a deployment released
+func formatReleaseNotes(project database.Project, deployment database.Deployment, previous *database.Deployment, tasks []database.ReleasedTask, prefix string, monitored bool) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🚀 **Deployed: %s** `%s`\n", project.Name, deployment.Version))
+	details := []string{"by " + deployment.DeployedBy}
+	if previous != nil {
+		details = append(details, fmt.Sprintf("since `%s`", previous.Version))
+	}
+	text.WriteString(strings.Join(details, " · ") + "\n\n")
+
+	if len(tasks) == 0 {
+		text.WriteString("No newly completed tasks since the last deployment.\n")
+	} else {
+		text.WriteString(fmt.Sprintf("✅ **Released (%d)**\n", len(tasks)))
+		for i, task := range tasks {
+			branch := "├──"
+			if i == len(tasks)-1 {
+				branch = "└──"
+			}
+			line := task.Title
+			if task.Number > 0 {
+				line = fmt.Sprintf("`%s` %s", domain.FormatTaskKey(prefix, task.Number), task.Title)
+			}
+			text.WriteString(fmt.Sprintf("%s %s\n", branch, line))
+		}
+	}
+
+	if monitored {
+		text.WriteString(fmt.Sprintf("\n🩺 Post-deploy check reminder in %d minutes.", int(DeployMonitorDelay.Minutes())))
+	}
+	return text.String()
+}
+
+// formatDeployCheck is the post-deploy check reminder
+func formatDeployCheck(project database.Project, version string) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🩺 **Post-deploy check** · %s `%s`\n\n", project.Name, version))
+	text.WriteString(fmt.Sprintf("Deployed %d minutes ago. Please check:\n", int(DeployMonitorDelay.Minutes())))
+	text.WriteString("├── Error rates and logs\n")
+	text.WriteString("├── Key user flows\n")
+	text.WriteString("└── Alerts and dashboards\n\n")
+	text.WriteString("Something off? Roll back and post a note here.")
+	return text.String()
+}
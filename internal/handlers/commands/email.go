@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// EmailCommand manages a user's opt-in to receive the task digest and overdue alerts by email
+type EmailCommand struct {
+	db     *database.DB
+	mailer *services.Mailer
+	logger domain.Logger
+}
+
+// NewEmailCommand creates a new email command handler
+func NewEmailCommand(db *database.DB, mailer *services.Mailer, logger domain.Logger) *EmailCommand {
+	return &EmailCommand{
+		db:     db,
+		mailer: mailer,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *EmailCommand) CanHandle(command string) bool {
+	return command == "/email"
+}
+
+// Description returns the command description
+func (c *EmailCommand) Description() string {
+	return "📧 Weekly task digest and overdue alerts by email"
+}
+
+// MenuScope lists the command only in private chats, where addresses are set up
+func (c *EmailCommand) MenuScope() string {
+	return domain.MenuScopePrivate
+}
+
+// Usage returns the command usage instructions
+func (c *EmailCommand) Usage() string {
+	return "/email [address | digest on|off | alerts on|off | off] - Email notifications"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *EmailCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the email command
+func (c *EmailCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing email command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	// Addresses are personal, so keep them out of group chats
+	if cmd.Chat.Type != "private" {
+		return errorResponse("Please send `/email` to the bot in a private chat."), nil
+	}
+	if !c.mailer.Enabled() {
+		return errorResponse("Email delivery is not configured on this bot."), nil
+	}
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/email")))
+
+	sub, err := c.db.GetEmailSubscription(cmd.User.TelegramID)
+	if err != nil {
+		c.logger.Error("Failed to get email subscription", "user_id", cmd.User.TelegramID, "error", err)
+		return errorResponse("Failed to load your email settings. Please try again."), nil
+	}
+
+	if len(args) == 0 {
+		return c.settingsResponse(sub, ""), nil
+	}
+
+	option := strings.ToLower(args[0])
+	switch option {
+	case "off":
+		if _, err := c.db.DeleteEmailSubscription(cmd.User.TelegramID); err != nil {
+			c.logger.Error("Failed to delete email subscription", "user_id", cmd.User.TelegramID, "error", err)
+			return errorResponse("Failed to unsubscribe. Please try again."), nil
+		}
+		c.logger.Info("Email subscription removed", "user_id", cmd.User.TelegramID)
+		return c.settingsResponse(nil, "✅ Unsubscribed. Your address was deleted."), nil
+	case "digest", "alerts":
+		if sub == nil {
+			return errorResponse("Subscribe first with `/email you@example.com`."), nil
+		}
+		if len(args) < 2 || (args[1] != "on" && args[1] != "off") {
+			return errorResponse(fmt.Sprintf("Usage: `/email %s on` or `/email %s off`", option, option)), nil
+		}
+		if option == "digest" {
+			sub.Digest = args[1] == "on"
+		} else {
+			sub.Alerts = args[1] == "on"
+		}
+	default:
+		if err := services.ValidateEmail(args[0]); err != nil {
+			return errorResponse("That does not look like an email address. Example: `/email you@example.com`"), nil
+		}
+		if sub == nil {
+			token, err := newSecretToken()
+			if err != nil {
+				c.logger.Error("Failed to generate unsubscribe token", "error", err)
+				return errorResponse("Failed to subscribe. Please try again."), nil
+			}
+			sub = &database.EmailSubscription{UserID: cmd.User.TelegramID, Digest: true, Alerts: true, Token: token}
+		}
+		sub.Email = args[0]
+
+		// The digest lists tasks assigned by username, so link memberships now
+		if err := c.db.LinkTeamMemberships(cmd.User.TelegramID, cmd.User.Username); err != nil {
+			c.logger.Warn("Failed to link team memberships", "user_id", cmd.User.TelegramID, "error", err)
+		}
+	}
+
+	if err := c.db.SaveEmailSubscription(sub); err != nil {
+		c.logger.Error("Failed to save email subscription", "user_id", cmd.User.TelegramID, "error", err)
+		return errorResponse("Failed to save your email settings. Please try again."), nil
+	}
+
+	c.logger.Info("Email subscription updated", "user_id", cmd.User.TelegramID, "option", option)
+	return c.settingsResponse(sub, "✅ Email settings saved"), nil
+}
+
+// settingsResponse shows the user's email settings
+func (c *EmailCommand) settingsResponse(sub *database.EmailSubscription, notice string) *domain.Response {
+	var response strings.Builder
+	if notice != "" {
+		response.WriteString(notice + "\n\n")
+	}
+
+	response.WriteString("📧 **Email Notifications**\n\n")
+	if sub == nil {
+		response.WriteString("Not subscribed.\n\n")
+		response.WriteString("• `/email you@example.com` - get the weekly digest and overdue alerts\n")
+	} else {
+		response.WriteString(fmt.Sprintf("├── Address: %s\n", sub.Email))
+		response.WriteString(fmt.Sprintf("├── Weekly digest (Mondays): %s\n", onOff(sub.Digest)))
+		response.WriteString(fmt.Sprintf("└── Overdue alerts (daily): %s\n\n", onOff(sub.Alerts)))
+		response.WriteString("• `/email digest on|off`\n")
+		response.WriteString("• `/email alerts on|off`\n")
+		response.WriteString("• `/email new@example.com` - change address\n")
+		response.WriteString("• `/email off` - unsubscribe and delete the address\n")
+	}
+	response.WriteString("\n💡 Emails list your open tasks across all teams, like `/my_tasks`.")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// onOff formats a boolean setting
+func onOff(enabled bool) string {
+	if enabled {
+		return "🟢 On"
+	}
+	return "🔴 Off"
+}
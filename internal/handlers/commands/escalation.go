@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// escalationLogLimit is the number of audit entries shown by /escalation log
+const escalationLogLimit = 10
+
+// EscalationCommand configures reminders and escalation for stale tasks
+type EscalationCommand struct {
+	db                *database.DB
+	escalationService *services.EscalationService
+	logger            domain.Logger
+}
+
+// NewEscalationCommand creates a new escalation command handler
+func NewEscalationCommand(db *database.DB, escalationService *services.EscalationService, logger domain.Logger) *EscalationCommand {
+	return &EscalationCommand{
+		db:                db,
+		escalationService: escalationService,
+		logger:            logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *EscalationCommand) CanHandle(command string) bool {
+	return command == "/escalation"
+}
+
+// Description returns the command description
+func (c *EscalationCommand) Description() string {
+	return "🚨 Remind and escalate stale high-priority tasks"
+}
+
+// Usage returns the command usage instructions
+func (c *EscalationCommand) Usage() string {
+	return "/escalation [on | off | remind 24h | escalate 1d | priority 1-3 | lead @user | log] - Task escalation rules"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *EscalationCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the escalation command
+func (c *EscalationCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing escalation command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/escalation")))
+
+	if len(args) == 0 {
+		return c.rulesResponse(teamID, ""), nil
+	}
+
+	option := strings.ToLower(args[0])
+	if option == "log" {
+		return c.logResponse(cmd.Chat.ID), nil
+	}
+
+	var err error
+	var notice string
+	switch option {
+	case "on":
+		err = c.escalationService.SetEnabled(teamID, true)
+		notice = "✅ Task escalation enabled"
+	case "off":
+		err = c.escalationService.SetEnabled(teamID, false)
+		notice = "✅ Task escalation disabled"
+	case "remind", "escalate":
+		if len(args) < 2 {
+			return errorResponse(fmt.Sprintf("Usage: `/escalation %s 24h` or `/escalation %s 2d`", option, option)), nil
+		}
+		interval, parseErr := domain.ParseDuration(args[1])
+		if parseErr != nil {
+			return errorResponse(parseErr.Error()), nil
+		}
+		if option == "remind" {
+			err = c.escalationService.SetRemindAfter(teamID, interval)
+			notice = fmt.Sprintf("✅ Assignees are reminded after %s in todo", formatStaleInterval(interval))
+		} else {
+			err = c.escalationService.SetEscalateAfter(teamID, interval)
+			notice = fmt.Sprintf("✅ Leads are notified %s after the reminder", formatStaleInterval(interval))
+		}
+	case "priority":
+		if len(args) < 2 {
+			return errorResponse("Usage: `/escalation priority 1` (1 = high only, 2 = high and medium, 3 = all)"), nil
+		}
+		priority, parseErr := strconv.Atoi(args[1])
+		if parseErr != nil {
+			return errorResponse("Priority must be 1, 2 or 3"), nil
+		}
+		err = c.escalationService.SetMaxPriority(teamID, priority)
+		notice = "✅ Covered priorities updated"
+	case "lead":
+		if len(args) < 2 || !strings.HasPrefix(args[1], "@") {
+			return errorResponse("Usage: `/escalation lead @username`"), nil
+		}
+		err = c.escalationService.SetLead(teamID, args[1])
+		notice = fmt.Sprintf("✅ Escalations go to %s", args[1])
+	default:
+		return c.rulesResponse(teamID, ""), nil
+	}
+
+	if err != nil {
+		c.logger.Error("Failed to update escalation rule", "team_id", teamID, "error", err)
+		return errorResponse(err.Error()), nil
+	}
+
+	if err := c.db.LogAudit(&database.AuditEntry{
+		ChatID:  cmd.Chat.ID,
+		Actor:   auditActor(cmd.User),
+		Action:  database.AuditEscalationRule,
+		Subject: teamID,
+		Details: strings.Join(args, " "),
+	}); err != nil {
+		c.logger.Error("Failed to write audit entry", "chat_id", cmd.Chat.ID, "error", err)
+	}
+
+	c.logger.Info("Escalation rule updated", "team_id", teamID, "option", option)
+	return c.rulesResponse(teamID, notice), nil
+}
+
+// rulesResponse shows the team's escalation rule
+func (c *EscalationCommand) rulesResponse(teamID, notice string) *domain.Response {
+	rule := c.escalationService.GetRule(teamID)
+
+	var response strings.Builder
+	if notice != "" {
+		response.WriteString(notice + "\n\n")
+	}
+
+	status := "🔴 Off"
+	if rule.Enabled {
+		status = "🟢 On"
+	}
+	priorities := map[int]string{1: "🔴 High", 2: "🔴 High, 🟡 Medium", 3: "All"}
+	lead := "members with the lead role"
+	if rule.Lead != "" {
+		lead = "@" + rule.Lead
+	}
+
+	response.WriteString("🚨 **Task Escalation**\n\n")
+	response.WriteString(fmt.Sprintf("├── Status: %s\n", status))
+	response.WriteString(fmt.Sprintf("├── Priorities: %s\n", priorities[rule.MaxPriority]))
+	response.WriteString(fmt.Sprintf("├── 1️⃣ Remind assignee after %s in todo\n", formatStaleInterval(rule.RemindAfter)))
+	response.WriteString(fmt.Sprintf("├── 2️⃣ Notify lead %s after the reminder\n", formatStaleInterval(rule.EscalateAfter)))
+	response.WriteString(fmt.Sprintf("└── Lead: %s\n\n", lead))
+
+	response.WriteString("**Configure:**\n")
+	response.WriteString("• `/escalation on` / `/escalation off`\n")
+	response.WriteString("• `/escalation remind 24h` - first reminder\n")
+	response.WriteString("• `/escalation escalate 2d` - lead notification\n")
+	response.WriteString("• `/escalation priority 2` - cover high and medium tasks\n")
+	response.WriteString("• `/escalation lead @username` - who gets escalations\n")
+	response.WriteString("• `/escalation log` - recent reminders and changes")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// logResponse shows recent escalation entries from the audit trail
+func (c *EscalationCommand) logResponse(chatID int64) *domain.Response {
+	entries, err := c.db.GetAuditLog(chatID, "escalation_", escalationLogLimit)
+	if err != nil {
+		c.logger.Error("Failed to load audit log", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to load the escalation log. Please try again.")
+	}
+
+	icons := map[string]string{
+		database.AuditEscalationReminder: "⏰",
+		database.AuditEscalationLead:     "🚨",
+		database.AuditEscalationRule:     "⚙️",
+	}
+
+	var response strings.Builder
+	response.WriteString("📜 **Escalation Log**\n\n")
+
+	for _, entry := range entries {
+		response.WriteString(fmt.Sprintf("%s %s - %s by %s\n",
+			icons[entry.Action], entry.CreatedAt.Format("Jan 2 15:04"), entry.Details, entry.Actor))
+	}
+
+	if len(entries) == 0 {
+		response.WriteString("No reminders or rule changes yet.")
+	}
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// formatStaleInterval formats an escalation interval in hours or days
+func formatStaleInterval(d time.Duration) string {
+	hours := int(d.Hours())
+	if hours >= 24 && hours%24 == 0 {
+		return fmt.Sprintf("%dd", hours/24)
+	}
+	return fmt.Sprintf("%dh", hours)
+}
+
+// auditActor identifies the user in audit entries
+func auditActor(user *domain.User) string {
+	if user.Username != "" {
+		return "@" + user.Username
+	}
+	return fmt.Sprintf("user %d", user.TelegramID)
+}
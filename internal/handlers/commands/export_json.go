@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// ExportJSONCommand uploads projects and analyses as JSON documents for external tools
+type ExportJSONCommand struct {
+	db     *database.DB
+	logger domain.Logger
+}
+
+// NewExportJSONCommand creates a new JSON export command handler
+func NewExportJSONCommand(db *database.DB, logger domain.Logger) *ExportJSONCommand {
+	return &ExportJSONCommand{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *ExportJSONCommand) CanHandle(command string) bool {
+	return command == "/export_json"
+}
+
+// Description returns the command description
+func (c *ExportJSONCommand) Description() string {
+	return "📦 Export a project or analysis as a JSON document"
+}
+
+// Usage returns the command usage instructions
+func (c *ExportJSONCommand) Usage() string {
+	return "/export_json [project_id | analysis [id]] - Download machine-readable JSON"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *ExportJSONCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// ChatAction implements domain.LongRunningHandler: exports are sent as files
+func (c *ExportJSONCommand) ChatAction(cmd *domain.Command) string {
+	return domain.ChatActionUploadDocument
+}
+
+// Handle processes the export_json command
+func (c *ExportJSONCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing export_json command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/export_json")))
+	if len(args) > 0 && strings.ToLower(args[0]) == "analysis" {
+		switch len(args) {
+		case 1:
+			return c.exportAnalysis(cmd.Chat.ID, 0)
+		case 2:
+			if id, err := strconv.ParseInt(strings.TrimPrefix(args[1], "#"), 10, 64); err == nil {
+				return c.exportAnalysis(cmd.Chat.ID, id)
+			}
+		}
+		return errorResponse("Usage: `/export_json analysis [id]`"), nil
+	}
+	if len(args) > 1 {
+		return errorResponse("Usage: `/export_json [project_id | analysis [id]]`"), nil
+	}
+
+	projectID := ""
+	if len(args) == 1 {
+		projectID = args[0]
+	}
+	return c.exportProject(cmd.Chat.ID, projectID)
+}
+
+// exportProject uploads a project with its tasks. Without an ID the chat's most recent
+// active project is exported.
+func (c *ExportJSONCommand) exportProject(chatID int64, projectID string) (*domain.Response, error) {
+	projects, err := c.db.GetProjectsByChatID(chatID)
+	if err != nil {
+		c.logger.Error("Failed to get projects", "error", err, "chat_id", chatID)
+		return errorResponse("Failed to retrieve projects. Please try again."), nil
+	}
+
+	var project *database.Project
+	for i := range projects {
+		if (projectID == "" && projects[i].Status == "active") || projects[i].ID == projectID {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		if projectID != "" {
+			return errorResponse(fmt.Sprintf("Project `%s` not found in this chat.", projectID)), nil
+		}
+		return errorResponse("No active project in this chat. Use `/export_json project_id` or `/export_json analysis`."), nil
+	}
+
+	tasks, err := c.db.GetTasksByProjectID(project.ID)
+	if err != nil {
+		c.logger.Error("Failed to get project tasks", "error", err, "project_id", project.ID)
+		return errorResponse("Failed to retrieve tasks. Please try again."), nil
+	}
+
+	export := domain.Export{
+		Kind: domain.ExportKindProject,
+		Project: &domain.Project{
+			ID:          project.ID,
+			Name:        project.Name,
+			Description: project.Description,
+			TeamID:      project.TeamID,
+			Status:      project.Status,
+			CreatedAt:   project.CreatedAt,
+			UpdatedAt:   project.UpdatedAt,
+		},
+		Tasks: ToDomainTasks(tasks),
+	}
+
+	caption := fmt.Sprintf("📦 **%s** · %d tasks · schema v%d", project.Name, len(tasks), domain.ExportSchemaVersion)
+	return c.documentResponse(export, fmt.Sprintf("%s.json", project.ID), caption)
+}
+
+// exportAnalysis uploads a recorded analysis run, the latest one when id is 0
+func (c *ExportJSONCommand) exportAnalysis(chatID, id int64) (*domain.Response, error) {
+	var run *database.AnalysisRun
+	if id == 0 {
+		runs, err := c.db.GetAnalysisRuns(chatID, 1)
+		if err != nil {
+			c.logger.Error("Failed to load analysis runs", "chat_id", chatID, "error", err)
+			return errorResponse("Failed to load analyses. Please try again."), nil
+		}
+		if len(runs) == 0 {
+			return errorResponse("No analyses yet. Run `/analyze requirement` first."), nil
+		}
+		run = &runs[0]
+	} else {
+		found, err := c.db.GetAnalysisRun(chatID, id)
+		if err != nil {
+			c.logger.Warn("Analysis run not found", "chat_id", chatID, "run_id", id, "error", err)
+			return errorResponse(fmt.Sprintf("Analysis #%d not found. See `/analyses` for recent runs.", id)), nil
+		}
+		run = found
+	}
+
+	var result domain.TaskBreakdownResponse
+	if err := json.Unmarshal([]byte(run.Result), &result); err != nil {
+		c.logger.Error("Failed to decode analysis run", "run_id", run.ID, "error", err)
+		return errorResponse(fmt.Sprintf("Analysis #%d could not be read.", run.ID)), nil
+	}
+
+	export := domain.Export{
+		Kind:        domain.ExportKindAnalysis,
+		Requirement: run.Requirement,
+		Analysis:    &result,
+	}
+
+	caption := fmt.Sprintf("📦 **Analysis #%d** · %d tasks · %.1fh · schema v%d",
+		run.ID, len(result.Tasks), result.TotalEstimate, domain.ExportSchemaVersion)
+	return c.documentResponse(export, fmt.Sprintf("analysis_%d.json", run.ID), caption)
+}
+
+// documentResponse encodes an export and attaches it as a document
+func (c *ExportJSONCommand) documentResponse(export domain.Export, fileName, caption string) (*domain.Response, error) {
+	export.SchemaVersion = domain.ExportSchemaVersion
+	export.ExportedAt = time.Now().UTC().Truncate(time.Second)
+
+	content, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		c.logger.Error("Failed to encode export", "file_name", fileName, "error", err)
+		return errorResponse("Failed to build the export. Please try again."), nil
+	}
+
+	return &domain.Response{
+		Text:      caption,
+		ParseMode: "Markdown",
+		Document: &domain.OutgoingDocument{
+			FileName: fileName,
+			Content:  content,
+			Report:   true,
+		},
+	}, nil
+}
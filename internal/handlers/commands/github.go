@@ -5,19 +5,22 @@ import (
 	"strings"
 	"time"
 
+	"yordamchi-dev-bot/database"
 	"yordamchi-dev-bot/internal/domain"
 	"yordamchi-dev-bot/internal/services"
 )
 
 // GitHubCommand handles GitHub-related commands
 type GitHubCommand struct {
+	db            *database.DB
 	githubService *services.GitHubService
 	logger        domain.Logger
 }
 
 // NewGitHubCommand creates a new GitHub command handler
-func NewGitHubCommand(githubService *services.GitHubService, logger domain.Logger) *GitHubCommand {
+func NewGitHubCommand(db *database.DB, githubService *services.GitHubService, logger domain.Logger) *GitHubCommand {
 	return &GitHubCommand{
+		db:            db,
 		githubService: githubService,
 		logger:        logger,
 	}
@@ -37,6 +40,9 @@ func (h *GitHubCommand) Handle(ctx context.Context, cmd *domain.Command) (*domai
 
 	switch command {
 	case "/github":
+		if response := h.handleLinkCommand(cmd, parts[1:]); response != nil {
+			return response, nil
+		}
 		return &domain.Response{
 			Text:      h.getUsageMessage(),
 			ParseMode: "Markdown",
@@ -66,7 +72,12 @@ func (h *GitHubCommand) Description() string {
 
 // Usage returns the command usage instructions
 func (h *GitHubCommand) Usage() string {
-	return "/repo owner/name - Repository ma'lumoti\n/user username - Foydalanuvchi profili"
+	return "/repo owner/name - Repository ma'lumoti\n/user username - Foydalanuvchi profili\n/github link owner/name - Link commits to tasks"
+}
+
+// Category puts the command in the dev category a chat can turn off
+func (h *GitHubCommand) Category() string {
+	return services.CategoryDev
 }
 
 // handleRepoCommand handles repository lookup
@@ -146,5 +157,8 @@ func (h *GitHubCommand) getUsageMessage() string {
 		"Misol: `/repo microsoft/vscode`\n\n" +
 		"**Foydalanuvchi profili:**\n" +
 		"`/user username`\n" +
-		"Misol: `/user torvalds`"
+		"Misol: `/user torvalds`\n\n" +
+		"**Commit linking:**\n" +
+		"`/github link owner/repository` - link commits mentioning task keys like TASK-12\n" +
+		"`/github unlink`, `/github autostart on|off`, `/github prefix PROJ`"
 }
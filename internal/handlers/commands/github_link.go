@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Team settings of commit linking. GitHubRepoKey holds the linked "owner/name" and is
+// empty when the team has no repository linked.
+const (
+	GitHubRepoKey      = "github_repo"
+	GitHubAutoStartKey = "github_autostart"
+	TaskKeyPrefixKey   = "task_key_prefix"
+)
+
+var githubRepoPattern = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
+// TaskKeyPrefix returns the team's task key prefix
+func TaskKeyPrefix(settings map[string]string) string {
+	if prefix := settings[TaskKeyPrefixKey]; prefix != "" {
+		return prefix
+	}
+	return domain.DefaultTaskKeyPrefix
+}
+
+// GitHubAutoStart reports whether the first linked commit moves a to-do task to in progress
+func GitHubAutoStart(settings map[string]string) bool {
+	return settings[GitHubAutoStartKey] != "off"
+}
+
+// handleLinkCommand processes /github link|unlink|autostart|prefix, returning nil for other arguments
+func (h *GitHubCommand) handleLinkCommand(cmd *domain.Command, args []string) *domain.Response {
+	if len(args) == 0 {
+		return nil
+	}
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	var key, value, notice string
+	switch strings.ToLower(args[0]) {
+	case "link":
+		if len(args) != 2 || !githubRepoPattern.MatchString(args[1]) {
+			return errorResponse("Usage: `/github link owner/repository`")
+		}
+		key, value = GitHubRepoKey, args[1]
+		notice = fmt.Sprintf("✅ Linked `%s`. Add a push webhook in its GitHub settings to `/github/webhook` of this bot with the configured secret.", value)
+	case "unlink":
+		key, value, notice = GitHubRepoKey, "", "✅ Repository unlinked"
+	case "autostart":
+		if len(args) != 2 || (args[1] != "on" && args[1] != "off") {
+			return errorResponse("Usage: `/github autostart on` or `/github autostart off`")
+		}
+		key, value = GitHubAutoStartKey, args[1]
+		notice = "✅ Auto-start on first commit turned " + args[1]
+	case "prefix":
+		if len(args) != 2 || !domain.ValidTaskKeyPrefix(strings.ToUpper(args[1])) {
+			return errorResponse("Usage: `/github prefix PROJ` (2-10 letters and digits)")
+		}
+		key, value = TaskKeyPrefixKey, strings.ToUpper(args[1])
+		notice = fmt.Sprintf("✅ Task keys now look like `%s`", domain.FormatTaskKey(value, 12))
+	default:
+		return nil
+	}
+
+	if cmd.Chat.Type == "private" {
+		return errorResponse("Set up commit linking in the team chat.")
+	}
+	if err := h.db.SetTeamSetting(teamID, key, value); err != nil {
+		h.logger.Error("Failed to save GitHub setting", "team_id", teamID, "key", key, "error", err)
+		return errorResponse("Failed to save the setting. Please try again.")
+	}
+	return h.linkStatus(teamID, notice)
+}
+
+// linkStatus shows the team's commit linking settings
+func (h *GitHubCommand) linkStatus(teamID, notice string) *domain.Response {
+	settings, err := h.db.GetTeamSettings(teamID)
+	if err != nil {
+		h.logger.Warn("Failed to load team settings", "team_id", teamID, "error", err)
+	}
+
+	repository := "none"
+	if settings[GitHubRepoKey] != "" {
+		repository = "`" + settings[GitHubRepoKey] + "`"
+	}
+	autoStart := "off"
+	if GitHubAutoStart(settings) {
+		autoStart = "on"
+	}
+
+	var text strings.Builder
+	text.WriteString(notice + "\n\n")
+	text.WriteString("🔗 **Commit Linking**\n")
+	text.WriteString(fmt.Sprintf("├── Repository: %s\n", repository))
+	text.WriteString(fmt.Sprintf("├── Task keys: `%s`\n", domain.FormatTaskKey(TaskKeyPrefix(settings), 12)))
+	text.WriteString(fmt.Sprintf("└── Start task on first commit: %s\n", autoStart))
+	return &domain.Response{Text: text.String(), ParseMode: "Markdown"}
+}
@@ -62,3 +62,8 @@ func (h *HaqidaCommand) Description() string {
 func (h *HaqidaCommand) Usage() string {
 	return "/haqida - Bot haqida to'liq ma'lumot"
 }
+
+// Category is empty, as chats cannot turn the command off
+func (h *HaqidaCommand) Category() string {
+	return ""
+}
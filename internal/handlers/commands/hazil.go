@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
 )
 
 // HazilCommand handles /hazil command for random programming jokes
@@ -60,3 +61,8 @@ func (h *HazilCommand) Description() string {
 func (h *HazilCommand) Usage() string {
 	return "/hazil - Tasodifiy hazil olish"
 }
+
+// Category puts the command in the fun category a chat can turn off
+func (h *HazilCommand) Category() string {
+	return services.CategoryFun
+}
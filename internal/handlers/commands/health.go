@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// HealthCommand shows project health scores and manages project deadlines
+type HealthCommand struct {
+	db        *database.DB
+	calendars *services.CalendarService
+	logger    domain.Logger
+}
+
+// NewHealthCommand creates a new health command handler
+func NewHealthCommand(db *database.DB, calendars *services.CalendarService, logger domain.Logger) *HealthCommand {
+	return &HealthCommand{
+		db:        db,
+		calendars: calendars,
+		logger:    logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *HealthCommand) CanHandle(command string) bool {
+	return command == "/health"
+}
+
+// Description returns the command description
+func (c *HealthCommand) Description() string {
+	return "🚦 Project health scores and early warnings"
+}
+
+// Usage returns the command usage instructions
+func (c *HealthCommand) Usage() string {
+	return "/health [project_id | deadline project_id YYYY-MM-DD] - Project health score"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *HealthCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the health command
+func (c *HealthCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing health command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/health")))
+
+	projects, err := c.db.GetProjectsByChatID(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to get projects", "error", err, "chat_id", cmd.Chat.ID)
+		return errorResponse("Failed to retrieve projects. Please try again."), nil
+	}
+	if len(projects) == 0 {
+		return errorResponse("No projects yet. Create one with `/create_project project_name`."), nil
+	}
+
+	if len(args) >= 1 && strings.EqualFold(args[0], "deadline") {
+		return c.setDeadline(ctx, cmd, projects, args[1:]), nil
+	}
+
+	if len(args) >= 1 {
+		project := findProject(projects, args[0])
+		if project == nil {
+			return errorResponse(fmt.Sprintf("Project `%s` not found. See `/list_projects`.", args[0])), nil
+		}
+		return c.projectResponse(ctx, *project), nil
+	}
+
+	var response strings.Builder
+	response.WriteString("🚦 **Project Health**\n\n")
+	for _, project := range projects {
+		if project.Status != "active" {
+			continue
+		}
+		health, _, err := EvaluateProjectHealth(ctx, c.db, c.calendars, project, time.Now())
+		if err != nil {
+			c.logger.Error("Failed to evaluate project health", "project_id", project.ID, "error", err)
+			continue
+		}
+		response.WriteString(fmt.Sprintf("%s **%s** (`%s`) - %d/100\n",
+			domain.HealthIcon(health.Level), project.Name, project.ID, health.Score))
+	}
+	response.WriteString("\n• `/health project_id` - score breakdown\n")
+	response.WriteString("• `/health deadline project_id 2026-12-31` - compare progress with time\n")
+	response.WriteString("\n💡 Scores are refreshed nightly and the chat is alerted when a project turns red.")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// setDeadline stores a project's target date
+func (c *HealthCommand) setDeadline(ctx context.Context, cmd *domain.Command, projects []database.Project, args []string) *domain.Response {
+	if len(args) < 2 {
+		return errorResponse("Usage: `/health deadline project_id YYYY-MM-DD`")
+	}
+
+	project := findProject(projects, args[0])
+	if project == nil {
+		return errorResponse(fmt.Sprintf("Project `%s` not found. See `/list_projects`.", args[0]))
+	}
+
+	deadline, err := time.Parse("2006-01-02", args[1])
+	if err != nil {
+		return errorResponse("Deadline must be YYYY-MM-DD")
+	}
+	if !deadline.After(project.CreatedAt) {
+		return errorResponse("Deadline must be after the project start")
+	}
+
+	if err := c.db.SetProjectDeadline(project.ID, deadline); err != nil {
+		c.logger.Error("Failed to set project deadline", "project_id", project.ID, "error", err)
+		return errorResponse("Failed to save the deadline. Please try again.")
+	}
+
+	c.logger.Info("Project deadline set", "project_id", project.ID, "deadline", args[1])
+	response := c.projectResponse(ctx, *project)
+	response.Text = fmt.Sprintf("✅ Deadline set to %s\n\n", deadline.Format("Jan 2 2006")) + response.Text
+	return response
+}
+
+// projectResponse shows the health breakdown of a single project
+func (c *HealthCommand) projectResponse(ctx context.Context, project database.Project) *domain.Response {
+	health, record, err := EvaluateProjectHealth(ctx, c.db, c.calendars, project, time.Now())
+	if err != nil {
+		c.logger.Error("Failed to evaluate project health", "project_id", project.ID, "error", err)
+		return errorResponse("Failed to compute project health. Please try again.")
+	}
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("🚦 **%s** - %s %d/100\n\n", project.Name, domain.HealthIcon(health.Level), health.Score))
+
+	response.WriteString(fmt.Sprintf("├── Progress: %s %.0f%%\n", getProgressBar(health.Progress), health.Progress*100))
+	if record != nil && !record.Deadline.IsZero() {
+		response.WriteString(fmt.Sprintf("├── Time elapsed: %.0f%% (deadline %s)\n", health.Expected*100, record.Deadline.Format("Jan 2 2006")))
+	} else {
+		response.WriteString("├── Deadline: not set\n")
+	}
+	response.WriteString(fmt.Sprintf("├── Overdue tasks: %d\n", health.OverdueTasks))
+	response.WriteString(fmt.Sprintf("├── Blocked tasks: %d\n", health.BlockedTasks))
+	if health.OverrunRatio > 0 {
+		response.WriteString(fmt.Sprintf("└── Estimate accuracy: %.0f%% of estimate used\n", health.OverrunRatio*100))
+	} else {
+		response.WriteString("└── Estimate accuracy: no completed tasks with actual hours\n")
+	}
+
+	if len(health.Reasons) > 0 {
+		response.WriteString("\n⚠️ **Warnings:**\n")
+		for _, reason := range health.Reasons {
+			response.WriteString(fmt.Sprintf("• %s\n", reason))
+		}
+	}
+
+	if record != nil && !record.ComputedAt.IsZero() {
+		response.WriteString(fmt.Sprintf("\n🕐 Last nightly score: %s %d (%s)",
+			domain.HealthIcon(record.Level), record.Score, record.ComputedAt.Format("Jan 2 15:04")))
+	}
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// EvaluateProjectHealth computes the current health of a project from its tasks and deadline
+// on its team's working calendar. It also returns the stored health record, which is nil
+// before the first score or deadline.
+func EvaluateProjectHealth(ctx context.Context, db *database.DB, calendars *services.CalendarService, project database.Project, now time.Time) (domain.ProjectHealth, *database.ProjectHealthRecord, error) {
+	record, err := db.GetProjectHealth(project.ID)
+	if err != nil {
+		return domain.ProjectHealth{}, nil, err
+	}
+
+	tasks, err := db.GetTasksByProjectID(project.ID)
+	if err != nil {
+		return domain.ProjectHealth{}, nil, err
+	}
+
+	var deadline time.Time
+	if record != nil {
+		deadline = record.Deadline
+	}
+
+	return domain.ComputeProjectHealth(ToDomainTasks(tasks), project.CreatedAt, deadline, now, calendars.GetCalendar(ctx, project.TeamID)), record, nil
+}
+
+// findProject looks up a project by ID or case-insensitive name
+func findProject(projects []database.Project, key string) *database.Project {
+	for i := range projects {
+		if projects[i].ID == key || strings.EqualFold(projects[i].Name, key) {
+			return &projects[i]
+		}
+	}
+	return nil
+}
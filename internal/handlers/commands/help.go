@@ -56,6 +56,11 @@ func (h *HelpCommand) Usage() string {
 	return "/help - Bu yordam xabari"
 }
 
+// Category is empty, as chats cannot turn the command off
+func (h *HelpCommand) Category() string {
+	return ""
+}
+
 // generateDynamicHelp creates help message from registered handlers
 func (h *HelpCommand) generateDynamicHelp() string {
 	handlers := h.router.GetHandlers()
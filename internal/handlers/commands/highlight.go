@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// HighlightCommand renders a code snippet to a syntax-highlighted image, which stays
+// readable on mobile clients where long code messages wrap
+type HighlightCommand struct {
+	logger domain.Logger
+}
+
+// NewHighlightCommand creates a new highlight command handler
+func NewHighlightCommand(logger domain.Logger) *HighlightCommand {
+	return &HighlightCommand{
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *HighlightCommand) CanHandle(command string) bool {
+	return command == "/highlight"
+}
+
+// Description returns the command description
+func (c *HighlightCommand) Description() string {
+	return "🖍 Render code as a syntax-highlighted image"
+}
+
+// Usage returns the command usage instructions
+func (c *HighlightCommand) Usage() string {
+	return "/highlight [language] code - Or reply to a message with code"
+}
+
+// Category puts the command in the dev category a chat can turn off
+func (c *HighlightCommand) Category() string {
+	return services.CategoryDev
+}
+
+// ChatAction implements domain.LongRunningHandler: the image is uploaded as a photo
+func (c *HighlightCommand) ChatAction(cmd *domain.Command) string {
+	return domain.ChatActionUploadPhoto
+}
+
+// Handle processes the highlight command
+func (c *HighlightCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing highlight command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	language, code := parseHighlightInput(strings.TrimPrefix(cmd.Text, "/highlight"))
+	if strings.TrimSpace(code) == "" {
+		code = cmd.ReplyToText
+	}
+	if strings.TrimSpace(code) == "" {
+		return &domain.Response{
+			Text: "🖍 **Highlight Code**\n\n" +
+				"`/highlight go` followed by your code on the next lines, or reply to a message with code using `/highlight`.\n\n" +
+				fmt.Sprintf("**Languages:** %s; detected automatically when left out.", strings.Join(services.CodeLanguageNames(), ", ")),
+			ParseMode: "Markdown",
+		}, nil
+	}
+	if language == "" {
+		language = services.DetectCodeLanguage(code)
+	}
+
+	image, err := services.RenderCodeImage(code, language)
+	if err != nil {
+		c.logger.Warn("Failed to render code image", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse(fmt.Sprintf("Could not render the code: %s. Snippets can have up to %d lines.", err, services.MaxHighlightLines)), nil
+	}
+
+	lines := strings.Count(strings.TrimRight(code, "\n"), "\n") + 1
+	return &domain.Response{
+		Text:      fmt.Sprintf("🖍 **%s** · %d lines", services.CodeLanguageLabel(language), lines),
+		ParseMode: "Markdown",
+		Document: &domain.OutgoingDocument{
+			FileName: "snippet.png",
+			Content:  image,
+			Photo:    true,
+		},
+	}, nil
+}
+
+// parseHighlightInput splits the arguments into an optional language and the code, keeping
+// the code's indentation. A Markdown code fence around the code is removed, and its
+// language used when none is given.
+func parseHighlightInput(input string) (string, string) {
+	input = strings.TrimLeft(input, " \t")
+	language := ""
+	if end := strings.IndexAny(input, " \t\n"); end > 0 || (end < 0 && input != "") {
+		if end < 0 {
+			end = len(input)
+		}
+		if name, ok := services.CodeLanguage(input[:end]); ok {
+			language = name
+			input = input[end:]
+		}
+	}
+
+	// Code on the command's line starts after the spaces; code on the next lines keeps the
+	// indentation of its first line
+	code := strings.TrimLeft(input, " \t")
+	for {
+		line, rest, found := strings.Cut(code, "\n")
+		if !found || strings.TrimSpace(line) != "" {
+			break
+		}
+		code = rest
+	}
+
+	if strings.HasPrefix(code, "```") {
+		fence, body, _ := strings.Cut(code, "\n")
+		if name, ok := services.CodeLanguage(strings.TrimPrefix(fence, "```")); ok && language == "" {
+			language = name
+		}
+		code = strings.TrimSuffix(strings.TrimRight(body, " \n"), "```")
+	}
+	return language, code
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// maxProfileCommands limits the most used commands shown in the usage profile
+const maxProfileCommands = 5
+
+// HintsCommand shows a user's usage profile and turns their "did you know" hints on or off
+type HintsCommand struct {
+	hints  *services.HintService
+	logger domain.Logger
+}
+
+// NewHintsCommand creates a new hints command handler
+func NewHintsCommand(hints *services.HintService, logger domain.Logger) *HintsCommand {
+	return &HintsCommand{
+		hints:  hints,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *HintsCommand) CanHandle(command string) bool {
+	return command == "/hints"
+}
+
+// Description returns the command description
+func (c *HintsCommand) Description() string {
+	return "💡 Your usage profile and command tips"
+}
+
+// Usage returns the command usage instructions
+func (c *HintsCommand) Usage() string {
+	return "/hints [on | off] - Show the commands you use and tips, or turn tips on or off"
+}
+
+// Category is empty, as chats cannot turn the command off
+func (c *HintsCommand) Category() string {
+	return ""
+}
+
+// Handle processes the hints command
+func (c *HintsCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing hints command", "user_id", cmd.User.TelegramID)
+
+	switch option := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/hints"))); option {
+	case "":
+	case "on", "off":
+		if err := c.hints.SetEnabled(cmd.User.TelegramID, option == "on"); err != nil {
+			c.logger.Error("Failed to update hints", "user_id", cmd.User.TelegramID, "error", err)
+			return errorResponse("Failed to update your tips. Please try again."), nil
+		}
+		if option == "off" {
+			return &domain.Response{Text: "💡 Tips are off. Turn them back on with `/hints on`.", ParseMode: "Markdown"}, nil
+		}
+		return &domain.Response{Text: "💡 Tips are on. Now and then a reply suggests a related command you have not tried.", ParseMode: "Markdown"}, nil
+	default:
+		return errorResponse("Usage: `" + c.Usage() + "`"), nil
+	}
+
+	profile, err := c.hints.Profile(cmd.User.TelegramID)
+	if err != nil {
+		c.logger.Error("Failed to load usage profile", "user_id", cmd.User.TelegramID, "error", err)
+		return errorResponse("Failed to load your usage profile. Please try again."), nil
+	}
+
+	return &domain.Response{Text: formatHintProfile(profile), ParseMode: "Markdown"}, nil
+}
+
+// formatHintProfile renders the most used and the untried commands of a profile
+func formatHintProfile(profile services.HintProfile) string {
+	var text strings.Builder
+	text.WriteString("💡 **Your Usage Profile**\n\n")
+
+	if len(profile.Used) == 0 {
+		text.WriteString("You have not used any commands yet. See /help to get started.\n")
+	} else {
+		used := profile.Used
+		if len(used) > maxProfileCommands {
+			used = used[:maxProfileCommands]
+		}
+		text.WriteString("📊 **Most Used**\n")
+		for i, usage := range used {
+			prefix := "├──"
+			if i == len(used)-1 {
+				prefix = "└──"
+			}
+			text.WriteString(fmt.Sprintf("%s `%s` × %d\n", prefix, usage.Command, usage.Uses))
+		}
+	}
+
+	if len(profile.Untried) > 0 {
+		text.WriteString("\n🧭 **Worth a Try**\n")
+		for i, hint := range profile.Untried {
+			prefix := "├──"
+			if i == len(profile.Untried)-1 {
+				prefix = "└──"
+			}
+			text.WriteString(fmt.Sprintf("%s `%s` %s\n", prefix, hint.Suggest, hint.Text))
+		}
+	}
+
+	status := "on · `/hints off` to stop them"
+	if !profile.Enabled {
+		status = "off · `/hints on` to get them"
+	}
+	text.WriteString(fmt.Sprintf("\nTips: %s\n", status))
+	return text.String()
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// HolidaysCommand shows upcoming public holidays and team days off
+type HolidaysCommand struct {
+	holidayService  *services.HolidayService
+	calendarService *services.CalendarService
+	logger          domain.Logger
+}
+
+// NewHolidaysCommand creates a new holidays command handler
+func NewHolidaysCommand(holidayService *services.HolidayService, calendarService *services.CalendarService, logger domain.Logger) *HolidaysCommand {
+	return &HolidaysCommand{
+		holidayService:  holidayService,
+		calendarService: calendarService,
+		logger:          logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *HolidaysCommand) CanHandle(command string) bool {
+	return command == "/holidays"
+}
+
+// Description returns the command description
+func (c *HolidaysCommand) Description() string {
+	return "🏖️ Show upcoming public holidays and team days off"
+}
+
+// Usage returns the command usage instructions
+func (c *HolidaysCommand) Usage() string {
+	return "/holidays [country CODE] - Upcoming days off for the team"
+}
+
+// Category puts the command in the team category a chat can turn off
+func (c *HolidaysCommand) Category() string {
+	return services.CategoryTeam
+}
+
+// Handle processes the holidays command
+func (c *HolidaysCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing holidays command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/holidays")))
+
+	if len(args) >= 2 && strings.ToLower(args[0]) == "country" {
+		if err := c.calendarService.SetCountry(ctx, teamID, args[1]); err != nil {
+			c.logger.Error("Failed to set team country", "team_id", teamID, "error", err)
+			return &domain.Response{
+				Text:      "❌ " + err.Error(),
+				ParseMode: "Markdown",
+			}, nil
+		}
+		c.logger.Info("Team holiday country updated", "team_id", teamID, "country", args[1])
+	}
+
+	country := c.calendarService.GetCountry(teamID)
+	if country == "" {
+		return &domain.Response{
+			Text: "🏖️ **Public Holidays**\n\n" +
+				"No country configured for this team yet.\n\n" +
+				"**Set one with:** `/holidays country UZ`\n" +
+				"**Built-in countries:** " + strings.Join(c.holidayService.SupportedCountries(), ", ") + "\n\n" +
+				"Once configured, schedule projections skip holidays and task reminders pause on them.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	now := time.Now()
+	holidays := c.holidayService.UpcomingHolidays(ctx, country, now, 90)
+	teamDaysOff := upcomingHolidays(c.calendarService.GetTeamHolidays(teamID), now)
+
+	return &domain.Response{
+		Text:      c.formatHolidays(country, holidays, teamDaysOff),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// formatHolidays formats upcoming holidays for display
+func (c *HolidaysCommand) formatHolidays(country string, holidays []services.PublicHoliday, teamDaysOff []time.Time) string {
+	var response strings.Builder
+
+	response.WriteString(fmt.Sprintf("🏖️ **Upcoming Days Off (%s)**\n\n", country))
+
+	if len(holidays) == 0 {
+		response.WriteString("No public holidays in the next 90 days.\n\n")
+	} else {
+		response.WriteString("🎉 **Public Holidays (next 90 days):**\n")
+		for _, holiday := range holidays {
+			response.WriteString(fmt.Sprintf("• %s - %s\n", holiday.Date.Format("Mon, Jan 2"), holiday.Name))
+		}
+		response.WriteString("\n")
+	}
+
+	if len(teamDaysOff) > 0 {
+		response.WriteString("📌 **Team Days Off:**\n")
+		for _, day := range teamDaysOff {
+			response.WriteString(fmt.Sprintf("• %s\n", day.Format("Mon, Jan 2 2006")))
+		}
+		response.WriteString("\n")
+	}
+
+	response.WriteString("💡 Schedule projections in `/analyze` skip these days and task reminders pause on them.\n")
+	response.WriteString("Use `/calendar holiday add YYYY-MM-DD` for team-specific days off.")
+
+	return response.String()
+}
@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// ImportAdminsCommand adds a group's administrators as team members in one step and asks
+// each of them in a private chat for their skills and capacity
+type ImportAdminsCommand struct {
+	db       *database.DB
+	chats    *services.TelegramChatService
+	profiles *services.MemberProfileService
+	logger   domain.Logger
+}
+
+// NewImportAdminsCommand creates a new import admins command handler
+func NewImportAdminsCommand(db *database.DB, chats *services.TelegramChatService, profiles *services.MemberProfileService, logger domain.Logger) *ImportAdminsCommand {
+	return &ImportAdminsCommand{
+		db:       db,
+		chats:    chats,
+		profiles: profiles,
+		logger:   logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *ImportAdminsCommand) CanHandle(command string) bool {
+	return command == "/import_admins"
+}
+
+// Description returns the command description
+func (c *ImportAdminsCommand) Description() string {
+	return "👥 Add the group's administrators as team members"
+}
+
+// MenuScope lists the command only in groups, which have administrators to import
+func (c *ImportAdminsCommand) MenuScope() string {
+	return domain.MenuScopeGroup
+}
+
+// Usage returns the command usage instructions
+func (c *ImportAdminsCommand) Usage() string {
+	return "/import_admins - Add the chat administrators to the team"
+}
+
+// Category puts the command in the team category a chat can turn off
+func (c *ImportAdminsCommand) Category() string {
+	return services.CategoryTeam
+}
+
+// Handle processes the import_admins command. Without arguments it lists the administrators
+// who are not members yet, with buttons to confirm or cancel the import.
+func (c *ImportAdminsCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing import_admins command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	if cmd.Chat.Type != "group" && cmd.Chat.Type != "supergroup" {
+		return errorResponse("`/import_admins` works in group chats, where the team's administrators are."), nil
+	}
+
+	admins, err := c.chats.GetChatAdministrators(ctx, cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to get chat administrators", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to get the chat administrators. Please try again."), nil
+	}
+	if !isChatAdministrator(admins, cmd.User.TelegramID) {
+		return errorResponse("Only chat administrators can import the team."), nil
+	}
+
+	members, err := c.db.GetTeamMembersByChatID(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to get team members", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to load the team. Please try again."), nil
+	}
+	candidates := newTeamAdmins(admins, members)
+
+	switch option := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/import_admins"))); option {
+	case "":
+		return c.previewResponse(candidates), nil
+	case "cancel":
+		return &domain.Response{Text: "👥 Import cancelled, the team is unchanged.", ParseMode: "Markdown"}, nil
+	case "confirm":
+		return c.importAdmins(ctx, cmd.Chat, candidates), nil
+	default:
+		return errorResponse("Usage: `" + c.Usage() + "`"), nil
+	}
+}
+
+// previewResponse lists the administrators to import with buttons to confirm or cancel
+func (c *ImportAdminsCommand) previewResponse(candidates []services.ChatAdministrator) *domain.Response {
+	if len(candidates) == 0 {
+		return &domain.Response{Text: "✅ All chat administrators are already team members. See `/list_team`.", ParseMode: "Markdown"}
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("👥 **Import %d Administrators**\n\n", len(candidates)))
+	for i, admin := range candidates {
+		prefix := "├──"
+		if i == len(candidates)-1 {
+			prefix = "└──"
+		}
+		text.WriteString(fmt.Sprintf("%s %s\n", prefix, escapeMarkdown(admin.DisplayName())))
+	}
+	text.WriteString("\nEach is added with 40h/week capacity and asked in a private chat for their skills.")
+
+	return &domain.Response{
+		Text:      text.String(),
+		ParseMode: "Markdown",
+		ReplyMarkup: domain.NewKeyboard().
+			Button(fmt.Sprintf("✅ Add %d", len(candidates)), "/import_admins confirm").
+			Button("✖️ Cancel", "/import_admins cancel").
+			Build(),
+	}
+}
+
+// importAdmins adds the administrators as members and sends each the profile question
+func (c *ImportAdminsCommand) importAdmins(ctx context.Context, chat *domain.Chat, candidates []services.ChatAdministrator) *domain.Response {
+	if len(candidates) == 0 {
+		return c.previewResponse(candidates)
+	}
+
+	teamID := fmt.Sprintf("team_%d", chat.ID)
+	teamName := chat.Title
+	if teamName == "" {
+		teamName = fmt.Sprintf("Chat %d", chat.ID)
+	}
+
+	var added, unreachable []string
+	for i, admin := range candidates {
+		member := &database.TeamMember{
+			ID:       fmt.Sprintf("%s_%d", generateMemberID(), i),
+			TeamID:   teamID,
+			UserID:   admin.UserID,
+			Username: strings.TrimPrefix(admin.DisplayName(), "@"),
+			Capacity: 40.0,        // Default 40h/week until they answer
+			Role:     "developer", // Default role
+		}
+		if err := c.db.CreateTeamMember(member); err != nil {
+			c.logger.Error("Failed to import administrator", "chat_id", chat.ID, "user_id", admin.UserID, "error", err)
+			continue
+		}
+		added = append(added, escapeMarkdown(admin.DisplayName()))
+
+		c.profiles.Request(admin.UserID, services.MemberProfileRequest{TeamID: teamID, MemberID: member.ID, TeamName: teamName})
+		if err := c.chats.SendMessage(ctx, admin.UserID, memberProfileQuestion(teamName), ""); err != nil {
+			// Users who never started the bot cannot be messaged first
+			c.logger.Warn("Failed to ask imported member for profile", "user_id", admin.UserID, "error", err)
+			unreachable = append(unreachable, escapeMarkdown(admin.DisplayName()))
+		}
+	}
+
+	c.logger.Info("Administrators imported", "chat_id", chat.ID, "added", len(added), "unreachable", len(unreachable))
+	if len(added) == 0 {
+		return errorResponse("Failed to add the administrators. Please try again.")
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("✅ **%d Team Members Added**\n", len(added)))
+	text.WriteString(fmt.Sprintf("└── %s\n\n", strings.Join(added, ", ")))
+	text.WriteString("Each was asked in a private chat for their skills and capacity.")
+	if len(unreachable) > 0 {
+		text.WriteString(fmt.Sprintf("\n\n⚠️ Could not message %s: they should open a private chat with the bot "+
+			"and send `/member_profile go, react 30h`.", strings.Join(unreachable, ", ")))
+	}
+	return &domain.Response{Text: text.String(), ParseMode: "Markdown"}
+}
+
+// memberProfileQuestion asks an imported member for their skills and capacity
+func memberProfileQuestion(teamName string) string {
+	return fmt.Sprintf("👋 You were added to the team of %s.\n\n"+
+		"Reply with your skills and weekly capacity so tasks fit you, e.g.:\n"+
+		"go, react, docker 30h\n\n"+
+		"Capacity stays 40h/week if you leave it out.", teamName)
+}
+
+// isChatAdministrator reports whether the user is one of the administrators
+func isChatAdministrator(admins []services.ChatAdministrator, userID int64) bool {
+	for _, admin := range admins {
+		if admin.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// newTeamAdmins returns the administrators that are not bots and not team members yet,
+// matched by Telegram ID or username
+func newTeamAdmins(admins []services.ChatAdministrator, members []database.TeamMember) []services.ChatAdministrator {
+	var candidates []services.ChatAdministrator
+	for _, admin := range admins {
+		if admin.IsBot {
+			continue
+		}
+		known := false
+		for _, member := range members {
+			if member.UserID == admin.UserID || (admin.Username != "" && strings.EqualFold(member.Username, admin.Username)) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			candidates = append(candidates, admin)
+		}
+	}
+	return candidates
+}
+
+// markdownEscaper escapes the characters that start formatting in legacy Markdown
+var markdownEscaper = strings.NewReplacer("_", "\\_", "*", "\\*", "`", "\\`", "[", "\\[")
+
+// escapeMarkdown makes user-provided text such as a username safe in a Markdown reply
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
@@ -0,0 +1,248 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// maxImportFileSize limits the size of an uploaded JSON export in bytes
+const maxImportFileSize = 2 * 1024 * 1024
+
+// ImportJSONCommand creates a project from a JSON document produced by /export_json
+type ImportJSONCommand struct {
+	db                  *database.DB
+	telegramFileService *services.TelegramFileService
+	logger              domain.Logger
+	pending             map[int64]*domain.Export
+	mutex               sync.Mutex
+}
+
+// NewImportJSONCommand creates a new JSON import command handler
+func NewImportJSONCommand(db *database.DB, telegramFileService *services.TelegramFileService, logger domain.Logger) *ImportJSONCommand {
+	return &ImportJSONCommand{
+		db:                  db,
+		telegramFileService: telegramFileService,
+		logger:              logger,
+		pending:             make(map[int64]*domain.Export),
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *ImportJSONCommand) CanHandle(command string) bool {
+	return command == "/import_json"
+}
+
+// Description returns the command description
+func (c *ImportJSONCommand) Description() string {
+	return "📥 Import a project exported with /export_json"
+}
+
+// Usage returns the command usage instructions
+func (c *ImportJSONCommand) Usage() string {
+	return "/import_json [confirm | cancel] - Send with or in reply to a JSON export to restore a project"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *ImportJSONCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the import_json command
+func (c *ImportJSONCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing import_json command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	document := cmd.Document
+	if document == nil {
+		document = cmd.ReplyToDocument
+	}
+	if document != nil {
+		return c.preview(ctx, cmd.Chat.ID, document)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/import_json"))) {
+	case "confirm":
+		return c.importPending(cmd)
+	case "cancel":
+		c.mutex.Lock()
+		delete(c.pending, cmd.Chat.ID)
+		c.mutex.Unlock()
+		return &domain.Response{Text: "✖️ Import cancelled.", ParseMode: "Markdown"}, nil
+	}
+
+	return &domain.Response{
+		Text: "📥 **Import a Project**\n\n" +
+			"Send a file exported with `/export_json` and caption it `/import_json`, " +
+			"or reply `/import_json` to the file.\n\n" +
+			"The project and its tasks are created with new IDs after you confirm the preview.",
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// preview downloads and validates an export, keeping it until the import is confirmed
+func (c *ImportJSONCommand) preview(ctx context.Context, chatID int64, document *domain.TelegramDocument) (*domain.Response, error) {
+	if document.FileSize > maxImportFileSize {
+		return errorResponse(fmt.Sprintf("The file is too large (%s). Exports up to 2 MB can be imported.",
+			c.telegramFileService.GetFileSize(document.FileSize))), nil
+	}
+
+	tempFile, err := c.telegramFileService.DownloadFile(ctx, document)
+	if err != nil {
+		c.logger.Error("Failed to download import file", "error", err, "filename", document.FileName)
+		return errorResponse("Download failed. Please send the file again."), nil
+	}
+	defer c.telegramFileService.CleanupFile(tempFile)
+
+	data, err := os.ReadFile(tempFile)
+	if err != nil {
+		c.logger.Error("Failed to read import file", "error", err, "filename", document.FileName)
+		return errorResponse("Failed to read the file. Please try again."), nil
+	}
+
+	export, err := domain.ParseProjectExport(data)
+	if err != nil {
+		c.logger.Warn("Invalid import file", "error", err, "filename", document.FileName)
+		return errorResponse(fmt.Sprintf("`%s` can't be imported: %s", document.FileName, err.Error())), nil
+	}
+
+	c.mutex.Lock()
+	c.pending[chatID] = export
+	c.mutex.Unlock()
+
+	completed := 0
+	for _, task := range export.Tasks {
+		if task.Status == domain.TaskStatusCompleted {
+			completed++
+		}
+	}
+
+	var response strings.Builder
+	response.WriteString("📥 **Import Preview**\n\n")
+	response.WriteString(fmt.Sprintf("├── **Project:** %s\n", export.Project.Name))
+	response.WriteString(fmt.Sprintf("├── **Tasks:** %d (%d completed, %d open)\n", len(export.Tasks), completed, len(export.Tasks)-completed))
+	response.WriteString(fmt.Sprintf("├── **Estimate:** %s\n", domain.TotalEstimateRange(export.Tasks)))
+	response.WriteString(fmt.Sprintf("└── **Exported:** %s (schema v%d)\n\n", export.ExportedAt.Format("Jan 2, 2006 15:04"), export.SchemaVersion))
+	response.WriteString("The project and tasks get new IDs. Confirm with `/import_json confirm`.")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+		ReplyMarkup: &domain.InlineKeyboardMarkup{InlineKeyboard: [][]domain.InlineKeyboardButton{{
+			{Text: "✅ Import", CallbackData: "/import_json confirm"},
+			{Text: "✖️ Cancel", CallbackData: "/import_json cancel"},
+		}}},
+	}, nil
+}
+
+// importPending creates the previewed project and its tasks with new IDs, keeping their
+// statuses, dependencies and timeline. Assignees are kept only when they belong to this chat's team.
+func (c *ImportJSONCommand) importPending(cmd *domain.Command) (*domain.Response, error) {
+	c.mutex.Lock()
+	export := c.pending[cmd.Chat.ID]
+	delete(c.pending, cmd.Chat.ID)
+	c.mutex.Unlock()
+
+	if export == nil {
+		return errorResponse("Nothing to import. Send a JSON export with `/import_json` first."), nil
+	}
+
+	status := export.Project.Status
+	if status == "" {
+		status = "active"
+	}
+	project := &database.Project{
+		ID:          generateProjectID(),
+		Name:        export.Project.Name,
+		Description: export.Project.Description,
+		TeamID:      fmt.Sprintf("team_%d", cmd.Chat.ID),
+		Status:      status,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := c.db.CreateProject(project); err != nil {
+		c.logger.Error("Failed to create imported project", "error", err, "chat_id", cmd.Chat.ID)
+		return errorResponse("Project creation failed. Please try again."), nil
+	}
+	if !export.Project.CreatedAt.IsZero() {
+		if err := c.db.SetProjectCreatedAt(project.ID, export.Project.CreatedAt); err != nil {
+			c.logger.Warn("Failed to keep imported project start", "project_id", project.ID, "error", err)
+		}
+	}
+
+	members := make(map[string]bool)
+	if stored, err := c.db.GetTeamMembersByChatID(cmd.Chat.ID); err == nil {
+		for _, member := range stored {
+			members[member.ID] = true
+		}
+	}
+
+	newIDs := make(map[string]string, len(export.Tasks))
+	for i, task := range export.Tasks {
+		if task.ID != "" {
+			newIDs[task.ID] = fmt.Sprintf("task_%d_%d", time.Now().UnixNano(), i)
+		}
+	}
+
+	imported := 0
+	for i, task := range export.Tasks {
+		id, ok := newIDs[task.ID]
+		if !ok {
+			id = fmt.Sprintf("task_%d_%d", time.Now().UnixNano(), i)
+		}
+
+		var dependencies []string
+		for _, dependency := range task.Dependencies {
+			if newID, ok := newIDs[dependency]; ok {
+				dependencies = append(dependencies, newID)
+			}
+		}
+
+		assignee := ""
+		if members[task.AssignedTo] {
+			assignee = task.AssignedTo
+		}
+
+		err := c.db.CreateTask(&database.Task{
+			ID:                 id,
+			ProjectID:          project.ID,
+			Title:              task.Title,
+			Description:        task.Description,
+			Category:           task.Category,
+			EstimateHours:      task.EstimateHours,
+			OptimisticHours:    task.OptimisticHours,
+			PessimisticHours:   task.PessimisticHours,
+			Status:             task.Status,
+			Priority:           task.Priority,
+			AssignedTo:         assignee,
+			Dependencies:       dependencies,
+			AcceptanceCriteria: task.AcceptanceCriteria,
+		})
+		if err != nil {
+			c.logger.Error("Failed to import task", "project_id", project.ID, "title", task.Title, "error", err)
+			continue
+		}
+		imported++
+
+		if !task.CreatedAt.IsZero() {
+			if err := c.db.SetTaskTimeline(id, task.CreatedAt, task.CompletedAt, task.ActualHours); err != nil {
+				c.logger.Warn("Failed to keep imported task timeline", "task_id", id, "error", err)
+			}
+		}
+	}
+
+	c.logger.Info("Project imported", "chat_id", cmd.Chat.ID, "project_id", project.ID, "tasks", imported)
+
+	text := fmt.Sprintf("✅ **Imported %s** as `%s` with %d of %d tasks.\n\n💡 See it with `/list_projects`.",
+		project.Name, project.ID, imported, len(export.Tasks))
+	return &domain.Response{
+		Text:      text,
+		ParseMode: "Markdown",
+	}, nil
+}
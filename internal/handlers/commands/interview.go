@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// maxInterviewHistory limits the finished sessions shown by /interview history
+const maxInterviewHistory = 5
+
+// InterviewCommand runs Go and system design interview practice: the AI asks a question,
+// the user answers and gets a score with feedback, then the next question follows
+type InterviewCommand struct {
+	interviews *services.InterviewService
+	logger     domain.Logger
+	// responder shows a placeholder while an answer is evaluated
+	responder domain.Responder
+}
+
+// NewInterviewCommand creates a new interview command handler
+func NewInterviewCommand(interviews *services.InterviewService, logger domain.Logger) *InterviewCommand {
+	return &InterviewCommand{
+		interviews: interviews,
+		logger:     logger,
+	}
+}
+
+// SetResponder implements domain.ResponderAware
+func (c *InterviewCommand) SetResponder(responder domain.Responder) {
+	c.responder = responder
+}
+
+// CanHandle checks if this handler can process the command
+func (c *InterviewCommand) CanHandle(command string) bool {
+	return command == "/interview"
+}
+
+// Description returns the command description
+func (c *InterviewCommand) Description() string {
+	return "🎤 Practice interview questions with AI feedback"
+}
+
+// Usage returns the command usage instructions
+func (c *InterviewCommand) Usage() string {
+	return "/interview [topic | stop | history] - Interview practice; answer each question with a message"
+}
+
+// Category puts the command in the ai category a chat can turn off
+func (c *InterviewCommand) Category() string {
+	return services.CategoryAI
+}
+
+// ChatAction implements domain.LongRunningHandler: questions and feedback come from the AI chain
+func (c *InterviewCommand) ChatAction(cmd *domain.Command) string {
+	switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/interview"))) {
+	case "stop", "history":
+		return ""
+	}
+	return domain.ChatActionTyping
+}
+
+// Handle processes the interview command. While a session is open, anything but stop and
+// history answers its question; in private chats plain messages are routed here too.
+func (c *InterviewCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing interview command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	userID := cmd.User.TelegramID
+	args := strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/interview"))
+	switch strings.ToLower(args) {
+	case "stop":
+		session := c.interviews.Stop(userID)
+		if session == nil {
+			return errorResponse("No interview in progress. Start one with `/interview`."), nil
+		}
+		return &domain.Response{Text: formatInterviewSummary("🛑 **Interview Stopped**", *session), ParseMode: "Markdown"}, nil
+	case "history":
+		return c.historyResponse(userID), nil
+	}
+
+	if session := c.interviews.Session(userID); session != nil {
+		if args == "" {
+			return &domain.Response{
+				Text:      fmt.Sprintf("🎤 **Question %d** (%s)\n\n%s\n\nReply with your answer, or `/interview stop`.", len(session.Turns)+1, topicLabel(session.Topic), session.Question),
+				ParseMode: "Markdown",
+			}, nil
+		}
+		return c.answer(ctx, cmd, args), nil
+	}
+
+	topic := strings.ToLower(args)
+	if topic == "" {
+		topic = services.DefaultInterviewTopic
+	}
+	if _, ok := services.InterviewTopics[topic]; !ok {
+		return errorResponse(fmt.Sprintf("Unknown topic. Pick one of: %s", formatTopicList())), nil
+	}
+	if !c.interviews.IsConfigured() {
+		return errorResponse("Interview practice needs an AI provider. Set `CLAUDE_API_KEY`, `OPENAI_API_KEY` or `GEMINI_API_KEY`."), nil
+	}
+
+	question, err := c.interviews.Start(ctx, userID, topic)
+	if err != nil {
+		return c.aiErrorResponse(userID, err), nil
+	}
+
+	how := "Reply to this message with your answer."
+	if cmd.Chat.Type != "private" {
+		how = "Answer with `/interview your answer`."
+	}
+	return &domain.Response{
+		Text: fmt.Sprintf("🎤 **%s Interview** · question 1 of %d\n\n%s\n\n%s Stop any time with `/interview stop`.",
+			topicLabel(topic), services.InterviewQuestions, question, how),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// answer evaluates the user's answer and shows the feedback with the next question
+func (c *InterviewCommand) answer(ctx context.Context, cmd *domain.Command, answer string) *domain.Response {
+	placeholder := sendPlaceholder(ctx, c.responder, cmd, evaluatingPlaceholder, c.logger)
+	evaluation, session, err := c.interviews.Answer(ctx, cmd.User.TelegramID, answer)
+	if err != nil {
+		response := c.aiErrorResponse(cmd.User.TelegramID, err)
+		response.EditMessageID = placeholder
+		return response
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📝 **Score: %d/10** %s\n\n%s\n\n", evaluation.Score, scoreBar(evaluation.Score), evaluation.Feedback))
+	if session.Finished() {
+		text.WriteString(formatInterviewSummary("🏁 **Interview Complete**", *session))
+		text.WriteString("\n\nPractice again with `/interview`, or see past sessions with `/interview history`.")
+	} else {
+		text.WriteString(fmt.Sprintf("🎤 **Question %d**\n\n%s", len(session.Turns)+1, session.Question))
+	}
+	return &domain.Response{Text: text.String(), ParseMode: "Markdown", EditMessageID: placeholder}
+}
+
+// historyResponse lists the user's finished sessions with their scores
+func (c *InterviewCommand) historyResponse(userID int64) *domain.Response {
+	history := c.interviews.History(userID)
+	if len(history) == 0 {
+		return &domain.Response{
+			Text:      fmt.Sprintf("🎤 No finished interviews yet. Start one with `/interview [topic]`, topics: %s.", formatTopicList()),
+			ParseMode: "Markdown",
+		}
+	}
+	if len(history) > maxInterviewHistory {
+		history = history[:maxInterviewHistory]
+	}
+
+	var text strings.Builder
+	text.WriteString("🎤 **Interview History**\n\n")
+	for i, session := range history {
+		prefix := "├──"
+		if i == len(history)-1 {
+			prefix = "└──"
+		}
+		text.WriteString(fmt.Sprintf("%s %s · %s · %d questions · avg %.1f/10\n",
+			prefix, session.StartedAt.Format("Jan 2 15:04"), topicLabel(session.Topic), len(session.Turns), session.AverageScore()))
+	}
+	return &domain.Response{Text: text.String(), ParseMode: "Markdown"}
+}
+
+// aiErrorResponse explains a failed AI step, telling limits apart from outages
+func (c *InterviewCommand) aiErrorResponse(userID int64, err error) *domain.Response {
+	var limitErr *services.ChatLimitError
+	if errors.As(err, &limitErr) {
+		c.logger.Warn("Interview limit reached", "user_id", userID, "reason", limitErr.Reason)
+		return &domain.Response{Text: "⚠️ " + limitErr.Error() + ".", ParseMode: "Markdown"}
+	}
+	c.logger.Error("Interview AI step failed", "user_id", userID, "error", err)
+	return errorResponse("The AI interviewer is not available right now. Please try again.")
+}
+
+// formatInterviewSummary renders the scores of a session's answered questions
+func formatInterviewSummary(title string, session services.InterviewSession) string {
+	var text strings.Builder
+	text.WriteString(title + "\n")
+	if len(session.Turns) == 0 {
+		text.WriteString("└── No questions answered")
+		return text.String()
+	}
+	for i, turn := range session.Turns {
+		text.WriteString(fmt.Sprintf("├── Q%d: %d/10\n", i+1, turn.Score))
+	}
+	text.WriteString(fmt.Sprintf("└── Average: %.1f/10 on %s", session.AverageScore(), topicLabel(session.Topic)))
+	return text.String()
+}
+
+// scoreBar draws a score out of 10 as five blocks
+func scoreBar(score int) string {
+	filled := (score + 1) / 2
+	return strings.Repeat("🟩", filled) + strings.Repeat("⬜", 5-filled)
+}
+
+// topicLabel returns a readable topic name, e.g. "System design"
+func topicLabel(topic string) string {
+	if topic == "go" {
+		return "Go"
+	}
+	label := strings.ReplaceAll(topic, "_", " ")
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// formatTopicList lists the interview topics as code spans
+func formatTopicList() string {
+	names := services.InterviewTopicNames()
+	for i, name := range names {
+		names[i] = "`" + name + "`"
+	}
+	return strings.Join(names, ", ")
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
 )
 
 // IqtibosCommand handles /iqtibos command for motivational quotes
@@ -60,3 +61,8 @@ func (h *IqtibosCommand) Description() string {
 func (h *IqtibosCommand) Usage() string {
 	return "/iqtibos - Motivatsion iqtibos olish"
 }
+
+// Category puts the command in the fun category a chat can turn off
+func (h *IqtibosCommand) Category() string {
+	return services.CategoryFun
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// lintFindingLimit is the number of go vet findings listed
+const lintFindingLimit = 15
+
+// LintCommand runs gofmt and go vet on a Go snippet, so learners get feedback on their
+// code without leaving the chat
+type LintCommand struct {
+	linter              *services.GoLinter
+	telegramFileService *services.TelegramFileService
+	logger              domain.Logger
+}
+
+// NewLintCommand creates a new lint command handler
+func NewLintCommand(linter *services.GoLinter, telegramFileService *services.TelegramFileService, logger domain.Logger) *LintCommand {
+	return &LintCommand{
+		linter:              linter,
+		telegramFileService: telegramFileService,
+		logger:              logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *LintCommand) CanHandle(command string) bool {
+	return command == "/lint"
+}
+
+// Description returns the command description
+func (c *LintCommand) Description() string {
+	return "🧹 Check Go code with gofmt and go vet"
+}
+
+// Usage returns the command usage instructions
+func (c *LintCommand) Usage() string {
+	return "/lint code - Or send with or in reply to a .go file or a message with code"
+}
+
+// Category puts the command in the dev category a chat can turn off
+func (c *LintCommand) Category() string {
+	return services.CategoryDev
+}
+
+// ChatAction implements domain.LongRunningHandler: go vet type-checks the code
+func (c *LintCommand) ChatAction(cmd *domain.Command) string {
+	return domain.ChatActionTyping
+}
+
+// Handle processes the lint command
+func (c *LintCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing lint command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	code, message := goCodeInput(ctx, cmd, "/lint", c.telegramFileService, services.MaxLintBytes, c.logger)
+	if message != "" {
+		return errorResponse(message), nil
+	}
+	if strings.TrimSpace(code) == "" {
+		return &domain.Response{
+			Text: "🧹 **Lint Go Code**\n\n" +
+				"`/lint` followed by your code on the next lines, or send a `.go` file captioned `/lint`, " +
+				"or reply `/lint` to a message or file with code.\n\n" +
+				fmt.Sprintf("gofmt shows how to format the code and go vet reports suspicious constructs. "+
+					"Files up to %d KB that import only the standard library can be vetted, offline and "+
+					"with limited CPU time and memory.", services.MaxLintBytes/1024),
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	report, err := c.linter.Lint(ctx, cmd.User.TelegramID, code)
+	if err != nil {
+		var limitErr *services.ChatLimitError
+		if errors.As(err, &limitErr) {
+			return errorResponse(fmt.Sprintf("Slow down: %s.", limitErr.Error())), nil
+		}
+		c.logger.Warn("Lint failed", "user_id", cmd.User.TelegramID, "error", err)
+		return errorResponse(fmt.Sprintf("Could not lint the code: %s.", err)), nil
+	}
+	c.logger.Info("Code linted", "user_id", cmd.User.TelegramID, "formatted", report.Diff == "" && report.FormatError == "", "findings", len(report.Findings))
+
+	return &domain.Response{
+		Text:      formatLintReport(report),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// formatLintReport shows the gofmt and go vet results
+func formatLintReport(report *services.LintReport) string {
+	var text strings.Builder
+	text.WriteString("🧹 **Lint Report**\n\n")
+
+	switch {
+	case report.FormatError != "":
+		text.WriteString("❌ **gofmt:** the code does not parse\n")
+		text.WriteString("```\n" + strings.ReplaceAll(report.FormatError, "```", "'''") + "\n```\n")
+	case report.Diff != "":
+		text.WriteString("✏️ **gofmt:** formatting changes needed\n")
+		text.WriteString("```diff\n" + strings.ReplaceAll(strings.TrimRight(report.Diff, "\n"), "```", "'''") + "\n```\n")
+	default:
+		text.WriteString("✅ **gofmt:** formatted\n")
+	}
+
+	switch {
+	case report.VetSkipped != "":
+		text.WriteString(fmt.Sprintf("\nℹ️ **go vet:** skipped, %s\n", report.VetSkipped))
+	case len(report.Findings) == 0:
+		text.WriteString("\n✅ **go vet:** no issues found\n")
+	default:
+		text.WriteString(fmt.Sprintf("\n⚠️ **go vet:** %d finding(s)\n", len(report.Findings)))
+		findings := report.Findings
+		if len(findings) > lintFindingLimit {
+			findings = findings[:lintFindingLimit]
+		}
+		text.WriteString("```\n" + strings.ReplaceAll(strings.Join(findings, "\n"), "```", "'''") + "\n```\n")
+		if len(report.Findings) > lintFindingLimit {
+			text.WriteString(fmt.Sprintf("…and %d more\n", len(report.Findings)-lintFindingLimit))
+		}
+	}
+	return text.String()
+}
@@ -3,22 +3,33 @@ package commands
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"yordamchi-dev-bot/database"
 	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
 )
 
+// projectsCallbackPrefix starts the callback data of the project list buttons
+const projectsCallbackPrefix = "projects:"
+
+// maxProjectButtons limits the project buttons under the list
+const maxProjectButtons = 8
+
 // ListProjectsCommand handles listing user projects
 type ListProjectsCommand struct {
-	db     *database.DB
-	logger domain.Logger
+	db        *database.DB
+	calendars *services.CalendarService
+	logger    domain.Logger
 }
 
 // NewListProjectsCommand creates a new list projects command handler
-func NewListProjectsCommand(db *database.DB, logger domain.Logger) *ListProjectsCommand {
+func NewListProjectsCommand(db *database.DB, calendars *services.CalendarService, logger domain.Logger) *ListProjectsCommand {
 	return &ListProjectsCommand{
-		db:     db,
-		logger: logger,
+		db:        db,
+		calendars: calendars,
+		logger:    logger,
 	}
 }
 
@@ -37,6 +48,11 @@ func (c *ListProjectsCommand) Usage() string {
 	return "/list_projects - Show all development projects"
 }
 
+// Category puts the command in the projects category a chat can turn off
+func (c *ListProjectsCommand) Category() string {
+	return services.CategoryProjects
+}
+
 // Handle processes the list_projects command
 func (c *ListProjectsCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
 	c.logger.Info("Processing list_projects command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
@@ -64,20 +80,97 @@ func (c *ListProjectsCommand) Handle(ctx context.Context, cmd *domain.Command) (
 		}, nil
 	}
 
-	response := c.formatProjectsList(projects)
+	response := c.formatProjectsList(ctx, projects)
 
 	c.logger.Info("Projects listed",
 		"user_id", cmd.User.TelegramID,
 		"projects_count", len(projects))
 
 	return &domain.Response{
-		Text:      response,
-		ParseMode: "Markdown",
+		Text:        response,
+		ParseMode:   "Markdown",
+		ReplyMarkup: projectsKeyboard(projects),
 	}, nil
 }
 
+// CanHandleCallback claims the buttons of the project list
+func (c *ListProjectsCommand) CanHandleCallback(data string) bool {
+	return strings.HasPrefix(data, projectsCallbackPrefix)
+}
+
+// HandleCallback opens a project card from the list, or goes back to the list
+func (c *ListProjectsCommand) HandleCallback(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	projectID, open := strings.CutPrefix(cmd.Text, projectsCallbackPrefix+"open:")
+	if !open {
+		return c.Handle(ctx, cmd)
+	}
+
+	projects, err := c.db.GetProjectsByChatID(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to get projects", "error", err, "chat_id", cmd.Chat.ID)
+		return &domain.Response{Text: "❌ Failed to retrieve projects. Please try again.", ParseMode: "Markdown"}, nil
+	}
+	// The button may be older than the project's removal, or come from another chat's list
+	project := findProject(projects, projectID)
+	if project == nil || project.ID != projectID {
+		return &domain.Response{Text: "❌ This project no longer exists. See `/list_projects`.", ParseMode: "Markdown"}, nil
+	}
+
+	return &domain.Response{
+		Text:        c.formatProjectCard(ctx, *project),
+		ParseMode:   "Markdown",
+		ReplyMarkup: projectActionsKeyboard(project.ID),
+	}, nil
+}
+
+// formatProjectCard shows one project's progress and health
+func (c *ListProjectsCommand) formatProjectCard(ctx context.Context, project database.Project) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📂 **%s** (`%s`)\n", project.Name, project.ID))
+	text.WriteString(fmt.Sprintf("├── Status: %s\n", project.Status))
+	if stats, err := c.db.GetProjectStats(project.ID); err == nil {
+		text.WriteString(fmt.Sprintf("├── Tasks: %d/%d done\n", stats.CompletedTasks, stats.TotalTasks))
+		text.WriteString(fmt.Sprintf("├── Progress: %s %.0f%%\n", getProgressBar(stats.Progress), stats.Progress*100))
+	}
+	level, score := c.getProjectHealth(ctx, project)
+	text.WriteString(fmt.Sprintf("└── Health: %s %d/100\n", domain.HealthIcon(level), score))
+	return text.String()
+}
+
+// projectsKeyboard has a button opening each listed project, active ones first
+func projectsKeyboard(projects []database.Project) *domain.InlineKeyboardMarkup {
+	ordered := make([]database.Project, 0, len(projects))
+	for _, status := range []string{"active", "paused", "completed"} {
+		for _, project := range projects {
+			if project.Status == status {
+				ordered = append(ordered, project)
+			}
+		}
+	}
+
+	keyboard := domain.NewKeyboard()
+	for i, project := range ordered {
+		if i == maxProjectButtons {
+			break
+		}
+		keyboard.Button("📂 "+shortenText(project.Name, 40), projectsCallbackPrefix+"open:"+project.ID).Row()
+	}
+	return keyboard.Build()
+}
+
+// projectActionsKeyboard links a project card to the commands that take the project
+func projectActionsKeyboard(projectID string) *domain.InlineKeyboardMarkup {
+	return domain.NewKeyboard().
+		Button("🩺 Health", "/health "+projectID).
+		Button("🔗 Dependencies", "/dependencies "+projectID).Row().
+		Button("🧪 Test plan", "/testplan "+projectID).
+		Button("📰 Status report", "/status_report "+projectID).Row().
+		Button("◀️ All projects", projectsCallbackPrefix+"list").
+		Build()
+}
+
 // formatProjectsList formats projects for display
-func (c *ListProjectsCommand) formatProjectsList(projects []database.Project) string {
+func (c *ListProjectsCommand) formatProjectsList(ctx context.Context, projects []database.Project) string {
 	response := "📊 **Your Development Projects**\n\n"
 
 	activeProjects := []database.Project{}
@@ -101,8 +194,10 @@ func (c *ListProjectsCommand) formatProjectsList(projects []database.Project) st
 		response += "🟢 **Active Projects:**\n"
 		for _, project := range activeProjects {
 			progress := c.getProjectProgress(project.ID)
-			response += fmt.Sprintf("├── **%s** (`%s`)\n", project.Name, project.ID)
-			response += fmt.Sprintf("│   └── Progress: %s %.0f%% complete\n", getProgressBar(progress), progress*100)
+			level, score := c.getProjectHealth(ctx, project)
+			response += fmt.Sprintf("├── %s **%s** (`%s`)\n", domain.HealthIcon(level), project.Name, project.ID)
+			response += fmt.Sprintf("│   ├── Progress: %s %.0f%% complete\n", getProgressBar(progress), progress*100)
+			response += fmt.Sprintf("│   └── Health: %d/100\n", score)
 		}
 		response += "\n"
 	}
@@ -139,6 +234,7 @@ func (c *ListProjectsCommand) formatProjectsList(projects []database.Project) st
 	response += "• `/analyze requirement` - Break down new features\n"
 	response += "• `/workload` - Check team capacity\n"
 	response += "• `/project_stats <id>` - Detailed project analytics\n"
+	response += "• `/health <id>` - Health score breakdown\n"
 	response += "• `/create_project project_name` - Start a new project"
 
 	return response
@@ -185,6 +281,21 @@ func (c *ListProjectsCommand) getProjectProgress(projectID string) float64 {
 	return stats.Progress
 }
 
+// getProjectHealth returns the nightly health score, computing it when none is stored yet
+func (c *ListProjectsCommand) getProjectHealth(ctx context.Context, project database.Project) (string, int) {
+	record, err := c.db.GetProjectHealth(project.ID)
+	if err == nil && record != nil && record.Level != "" {
+		return record.Level, record.Score
+	}
+
+	health, _, err := EvaluateProjectHealth(ctx, c.db, c.calendars, project, time.Now())
+	if err != nil {
+		c.logger.Error("Failed to evaluate project health", "error", err, "project_id", project.ID)
+		return "", 0
+	}
+	return health.Level, health.Score
+}
+
 // Helper function to create progress bar
 func getProgressBar(progress float64) string {
 	bars := int(progress * 10)
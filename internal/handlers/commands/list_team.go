@@ -7,6 +7,7 @@ import (
 
 	"yordamchi-dev-bot/database"
 	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
 )
 
 // ListTeamCommand handles listing team members
@@ -38,17 +39,23 @@ func (c *ListTeamCommand) Usage() string {
 	return "/list_team - Show all team members and workload"
 }
 
+// Category puts the command in the team category a chat can turn off
+func (c *ListTeamCommand) Category() string {
+	return services.CategoryTeam
+}
+
 // Handle processes the list_team command
 func (c *ListTeamCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
 	c.logger.Info("Processing list_team command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
 
-	teamID := fmt.Sprintf("chat_%d", cmd.Chat.ID)
-
-	// For MVP, show mock team data
-	// In production, this would query the database for team members
-	mockMembers := c.getMockTeamMembers(teamID)
+	// Current workload is the remaining estimate of each member's open tasks
+	members, _, err := LoadTeamWorkload(c.db, cmd.Chat.ID, c.logger)
+	if err != nil {
+		c.logger.Error("Failed to load team members", "chat_id", cmd.Chat.ID, "error", err)
+		return &domain.Response{Text: "❌ Failed to load the team. Please try again.", ParseMode: "Markdown"}, nil
+	}
 
-	if len(mockMembers) == 0 {
+	if len(members) == 0 {
 		return &domain.Response{
 			Text: "👥 **No Team Members Found**\n\n" +
 				"This chat doesn't have any team members yet.\n\n" +
@@ -61,11 +68,11 @@ func (c *ListTeamCommand) Handle(ctx context.Context, cmd *domain.Command) (*dom
 		}, nil
 	}
 
-	response := c.formatTeamList(mockMembers)
+	response := c.formatTeamList(members)
 
 	c.logger.Info("Team listed",
 		"chat_id", cmd.Chat.ID,
-		"members_count", len(mockMembers))
+		"members_count", len(members))
 
 	return &domain.Response{
 		Text:      response,
@@ -91,7 +98,11 @@ func (c *ListTeamCommand) formatTeamList(members []domain.TeamMember) string {
 		utilizationBar := getTeamUtilizationBar(utilization)
 
 		response += fmt.Sprintf("%s **@%s** (%s)\n", roleEmoji, member.Username, strings.Title(member.Role))
-		response += fmt.Sprintf("├── 🛠️ Skills: %s\n", strings.Join(member.Skills, ", "))
+		skills := strings.Join(member.Skills, ", ")
+		if skills == "" {
+			skills = "not set yet"
+		}
+		response += fmt.Sprintf("├── 🛠️ Skills: %s\n", skills)
 		response += fmt.Sprintf("├── %s Capacity: %.0fh/week\n", utilizationBar, member.Capacity)
 		response += fmt.Sprintf("├── Current: %.0fh (%.0f%% utilization) %s\n", member.Current, utilization*100, statusEmoji)
 		response += fmt.Sprintf("└── Status: %s\n\n", getStatusText(utilization))
@@ -145,48 +156,6 @@ func (c *ListTeamCommand) formatTeamList(members []domain.TeamMember) string {
 	return response
 }
 
-// Mock data generator (would be replaced with database queries)
-func (c *ListTeamCommand) getMockTeamMembers(teamID string) []domain.TeamMember {
-	return []domain.TeamMember{
-		{
-			ID:       "member_1",
-			TeamID:   teamID,
-			Username: "alice",
-			Skills:   []string{"go", "postgresql", "docker", "kubernetes"},
-			Capacity: 40.0,
-			Role:     "lead",
-			Current:  34.0, // 85% utilization
-		},
-		{
-			ID:       "member_2",
-			TeamID:   teamID,
-			Username: "bob",
-			Skills:   []string{"react", "typescript", "css", "node.js"},
-			Capacity: 40.0,
-			Role:     "senior",
-			Current:  37.0, // 92% utilization
-		},
-		{
-			ID:       "member_3",
-			TeamID:   teamID,
-			Username: "carol",
-			Skills:   []string{"kubernetes", "docker", "aws", "terraform"},
-			Capacity: 40.0,
-			Role:     "mid",
-			Current:  24.0, // 60% utilization
-		},
-		{
-			ID:       "member_4",
-			TeamID:   teamID,
-			Username: "david",
-			Skills:   []string{"testing", "automation", "selenium", "jest"},
-			Capacity: 35.0, // Part-time
-			Role:     "junior",
-			Current:  24.5, // 70% utilization
-		},
-	}
-}
-
 // Helper functions for formatting
 func getTeamStatusEmoji(utilization float64) string {
 	if utilization > 0.9 {
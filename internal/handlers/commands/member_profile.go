@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// MemberProfileCommand records the skills and capacity of a member added by /import_admins.
+// Plain messages in the private chat answer the pending question as this command.
+type MemberProfileCommand struct {
+	db       *database.DB
+	profiles *services.MemberProfileService
+	logger   domain.Logger
+}
+
+// NewMemberProfileCommand creates a new member profile command handler
+func NewMemberProfileCommand(db *database.DB, profiles *services.MemberProfileService, logger domain.Logger) *MemberProfileCommand {
+	return &MemberProfileCommand{
+		db:       db,
+		profiles: profiles,
+		logger:   logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *MemberProfileCommand) CanHandle(command string) bool {
+	return command == "/member_profile"
+}
+
+// Description returns the command description
+func (c *MemberProfileCommand) Description() string {
+	return "🛠️ Fill in your skills and capacity for a team"
+}
+
+// Usage returns the command usage instructions
+func (c *MemberProfileCommand) Usage() string {
+	return "/member_profile skills [hours] - e.g. /member_profile go, react 30h"
+}
+
+// Category puts the command in the team category a chat can turn off
+func (c *MemberProfileCommand) Category() string {
+	return services.CategoryTeam
+}
+
+// Handle processes the member_profile command
+func (c *MemberProfileCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing member_profile command", "user_id", cmd.User.TelegramID)
+
+	request := c.profiles.Pending(cmd.User.TelegramID)
+	if request == nil {
+		return errorResponse("No team is waiting for your profile. Team admins add members with `/add_member` or `/import_admins`."), nil
+	}
+
+	answer := strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/member_profile"))
+	if answer == "" {
+		return &domain.Response{Text: memberProfileQuestion(request.TeamName)}, nil
+	}
+	skills, capacity, err := services.ParseMemberProfile(answer)
+	if err != nil {
+		return errorResponse(fmt.Sprintf("Could not read that: %s. Try e.g. `go, react, docker 30h`.", err)), nil
+	}
+	if capacity == 0 {
+		capacity = 40.0 // Default 40h/week
+	}
+
+	if err := c.db.UpdateTeamMemberProfile(request.MemberID, skills, capacity); err != nil {
+		c.logger.Error("Failed to update member profile", "member_id", request.MemberID, "error", err)
+		return errorResponse("Failed to save your profile. Please try again."), nil
+	}
+	remaining := c.profiles.Finish(cmd.User.TelegramID)
+	c.logger.Info("Member profile saved", "member_id", request.MemberID, "team_id", request.TeamID, "skills", skills)
+
+	text := fmt.Sprintf("✅ **Profile Saved** for %s\n"+
+		"├── 🛠️ Skills: %s\n"+
+		"└── 📊 Capacity: %.0fh/week",
+		escapeMarkdown(request.TeamName), escapeMarkdown(strings.Join(skills, ", ")), capacity)
+	if next := c.profiles.Pending(cmd.User.TelegramID); remaining > 0 && next != nil {
+		text += "\n\n" + escapeMarkdown(memberProfileQuestion(next.TeamName))
+	}
+	return &domain.Response{Text: text, ParseMode: "Markdown"}, nil
+}
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// MenuCommand toggles the chat's quick action keyboard
+type MenuCommand struct {
+	menuService *services.MenuService
+	router      domain.Router
+	logger      domain.Logger
+}
+
+// NewMenuCommand creates a new menu command handler
+func NewMenuCommand(menuService *services.MenuService, router domain.Router, logger domain.Logger) *MenuCommand {
+	return &MenuCommand{
+		menuService: menuService,
+		router:      router,
+		logger:      logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *MenuCommand) CanHandle(command string) bool {
+	return command == "/menu"
+}
+
+// Description returns the command description
+func (c *MenuCommand) Description() string {
+	return "⌨️ Quick action keyboard for common commands"
+}
+
+// Usage returns the command usage instructions
+func (c *MenuCommand) Usage() string {
+	return "/menu [on | off | set /cmd1 /cmd2 ... | reset] - Quick action keyboard"
+}
+
+// Category is empty, as chats cannot turn the command off
+func (c *MenuCommand) Category() string {
+	return ""
+}
+
+// Handle processes the menu command
+func (c *MenuCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing menu command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/menu")))
+	if len(args) == 0 {
+		return c.statusResponse(cmd.Chat.ID), nil
+	}
+
+	switch option := strings.ToLower(args[0]); option {
+	case "on", "off":
+		if err := c.menuService.SetEnabled(cmd.Chat.ID, option == "on"); err != nil {
+			c.logger.Error("Failed to update chat menu", "chat_id", cmd.Chat.ID, "error", err)
+			return errorResponse("Failed to update the menu. Please try again."), nil
+		}
+		c.logger.Info("Chat menu toggled", "chat_id", cmd.Chat.ID, "enabled", option == "on")
+
+		if option == "off" {
+			return &domain.Response{
+				Text:        "⌨️ Quick action menu hidden. Bring it back with `/menu on`.",
+				ParseMode:   "Markdown",
+				ReplyMarkup: &domain.ReplyKeyboardRemove{RemoveKeyboard: true},
+			}, nil
+		}
+		return c.keyboardResponse(cmd.Chat.ID, "⌨️ Quick action menu is on. Tap a button instead of typing a command."), nil
+	case "set":
+		commands := make([]string, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			command := services.NormalizeAlias(arg)
+			if !c.isRealCommand(command) {
+				return errorResponse(fmt.Sprintf("Unknown command `%s`. See /help for the list.", command)), nil
+			}
+			if command == "/menu" {
+				return errorResponse("The menu cannot contain `/menu` itself."), nil
+			}
+			if !containsString(commands, command) {
+				commands = append(commands, command)
+			}
+		}
+		if len(commands) == 0 {
+			return errorResponse("Usage: `/menu set /analyze /my_tasks /workload`"), nil
+		}
+		return c.saveCommands(cmd.Chat.ID, commands)
+	case "reset":
+		return c.saveCommands(cmd.Chat.ID, nil)
+	default:
+		return c.statusResponse(cmd.Chat.ID), nil
+	}
+}
+
+// saveCommands stores the chat's quick actions and shows the updated menu
+func (c *MenuCommand) saveCommands(chatID int64, commands []string) (*domain.Response, error) {
+	if err := c.menuService.SetCommands(chatID, commands); err != nil {
+		c.logger.Error("Failed to update chat menu buttons", "chat_id", chatID, "error", err)
+		return errorResponse(err.Error()), nil
+	}
+	c.logger.Info("Chat menu buttons updated", "chat_id", chatID, "buttons", len(commands))
+
+	if !c.menuService.GetConfig(chatID).Enabled {
+		return c.statusResponse(chatID), nil
+	}
+	return c.keyboardResponse(chatID, "✅ Menu buttons updated"), nil
+}
+
+// keyboardResponse sends the chat's quick action keyboard
+func (c *MenuCommand) keyboardResponse(chatID int64, notice string) *domain.Response {
+	config := c.menuService.GetConfig(chatID)
+	return &domain.Response{
+		Text:        notice,
+		ParseMode:   "Markdown",
+		ReplyMarkup: c.menuService.Keyboard(config.Commands),
+	}
+}
+
+// statusResponse shows the chat's menu settings
+func (c *MenuCommand) statusResponse(chatID int64) *domain.Response {
+	config := c.menuService.GetConfig(chatID)
+
+	var response strings.Builder
+	response.WriteString("⌨️ **Quick Action Menu**\n\n")
+	response.WriteString(fmt.Sprintf("├── Status: %s\n", onOff(config.Enabled)))
+	response.WriteString("└── Buttons:\n")
+	for _, command := range config.Commands {
+		response.WriteString(fmt.Sprintf("    • %s → `%s`\n", services.MenuLabel(command), command))
+	}
+
+	response.WriteString("\n**Configure:**\n")
+	response.WriteString("• `/menu on` - show the keyboard\n")
+	response.WriteString("• `/menu off` - hide it\n")
+	response.WriteString("• `/menu set /analyze /my_tasks /velocity` - choose buttons\n")
+	response.WriteString("• `/menu reset` - default buttons\n")
+	response.WriteString("\n💡 Buttons run the command without arguments, commands such as `/analyze` then show how to use them.")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// isRealCommand checks whether any registered handler owns the command
+func (c *MenuCommand) isRealCommand(command string) bool {
+	for _, handler := range c.router.GetHandlers() {
+		if handler.CanHandle(command) {
+			return true
+		}
+	}
+	return false
+}
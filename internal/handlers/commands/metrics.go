@@ -2,11 +2,15 @@ package commands
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/middleware"
+	"yordamchi-dev-bot/internal/services"
 )
 
 // MetricsProvider interface for getting metrics from middleware
@@ -29,100 +33,283 @@ func NewMetricsCommand(metricsProvider MetricsProvider, logger domain.Logger) *M
 	}
 }
 
+// metricsScopes are the focused views of /metrics, in the order of their buttons
+var metricsScopes = []struct {
+	Name  string
+	Label string
+}{
+	{"ai", "🤖 AI"},
+	{"cache", "💾 Cache"},
+	{"commands", "⚡ Commands"},
+	{"db", "🗄️ DB"},
+}
+
+// maxMetricsCommands limits the commands listed in the commands view
+const maxMetricsCommands = 8
+
 // Handle processes the /metrics command
 func (h *MetricsCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
-	// Get performance metrics
-	metrics := h.metricsProvider.GetMetrics()
+	scope := ""
+	if args := strings.Fields(cmd.Text); len(args) > 1 {
+		scope = strings.ToLower(args[1])
+	}
 
-	// Get cache metrics
+	metrics := h.metricsProvider.GetMetrics()
 	cacheStats := h.metricsProvider.GetCacheStats()
 
-	message := h.formatMetricsMessage(metrics, cacheStats)
+	var message string
+	switch scope {
+	case "":
+		message = h.formatOverview(metrics, cacheStats)
+	case "ai":
+		message = h.formatAIMetrics(metrics)
+	case "cache":
+		message = h.formatCacheMetrics(cacheStats)
+	case "commands":
+		message = h.formatCommandMetrics(metrics)
+	case "db":
+		message = h.formatDBMetrics(metrics)
+	default:
+		return &domain.Response{
+			Text:        "❌ Unknown metrics view. Use `/metrics ai|cache|commands|db`.",
+			ParseMode:   "Markdown",
+			ReplyMarkup: metricsKeyboard(""),
+		}, nil
+	}
 
 	h.logger.Info("Metrics command processed",
-		"user_id", cmd.User.TelegramID)
+		"user_id", cmd.User.TelegramID,
+		"scope", scope)
 
 	return &domain.Response{
-		Text:      message,
-		ParseMode: "Markdown",
+		Text:        message,
+		ParseMode:   "Markdown",
+		ReplyMarkup: metricsKeyboard(scope),
 	}, nil
 }
 
-// formatMetricsMessage formats metrics data into readable message
-func (h *MetricsCommand) formatMetricsMessage(metrics, cacheStats map[string]interface{}) string {
+// metricsKeyboard has a button for every view except the one shown
+func metricsKeyboard(current string) *domain.InlineKeyboardMarkup {
+	var row []domain.InlineKeyboardButton
+	if current != "" {
+		row = append(row, domain.InlineKeyboardButton{Text: "📈 Overview", CallbackData: "/metrics"})
+	}
+	for _, scope := range metricsScopes {
+		if scope.Name != current {
+			row = append(row, domain.InlineKeyboardButton{Text: scope.Label, CallbackData: "/metrics " + scope.Name})
+		}
+	}
+	return &domain.InlineKeyboardMarkup{InlineKeyboard: [][]domain.InlineKeyboardButton{row}}
+}
+
+// formatOverview shows the headline numbers of every view
+func (h *MetricsCommand) formatOverview(metrics, cacheStats map[string]interface{}) string {
 	var message strings.Builder
 
 	message.WriteString("📈 **Bot Performance Metrics**\n\n")
 
-	// System metrics
-	message.WriteString("🖥️ **System:**\n")
 	if uptime, ok := metrics["uptime_seconds"].(int64); ok {
-		message.WriteString(fmt.Sprintf("   • Uptime: %s\n", formatDuration(time.Duration(uptime)*time.Second)))
+		message.WriteString(fmt.Sprintf("🖥️ Uptime: %s\n", formatDuration(time.Duration(uptime)*time.Second)))
 	}
 
-	// Request metrics
-	message.WriteString("\n📊 **Requests:**\n")
-	if total, ok := metrics["total_requests"].(int64); ok {
-		message.WriteString(fmt.Sprintf("   • Total: %d\n", total))
+	total, _ := metrics["total_requests"].(int64)
+	rate, _ := metrics["success_rate_percent"].(float64)
+	rpm, _ := metrics["requests_per_minute"].(float64)
+	message.WriteString(fmt.Sprintf("📊 Requests: %d, %.1f%% successful, %.1f/min\n", total, rate, rpm))
+
+	if cmdMetrics, ok := metrics["command_metrics"].(map[string]interface{}); ok {
+		message.WriteString(fmt.Sprintf("⚡ Avg response: %dms\n", h.calculateAverageResponseTime(cmdMetrics)))
 	}
-	if successful, ok := metrics["successful_requests"].(int64); ok {
-		message.WriteString(fmt.Sprintf("   • Successful: %d\n", successful))
+
+	if providers, ok := metrics["ai_providers"].([]services.ProviderStats); ok && len(providers) > 0 {
+		var calls, failures int64
+		var spend float64
+		for _, stats := range providers {
+			calls += stats.Calls
+			failures += stats.Failures
+			spend += stats.SpendUSD
+		}
+		message.WriteString(fmt.Sprintf("🤖 AI: %d calls, %d failed, ~$%.2f\n", calls, failures, spend))
 	}
-	if failed, ok := metrics["failed_requests"].(int64); ok {
-		message.WriteString(fmt.Sprintf("   • Failed: %d\n", failed))
+
+	if lookups, ok := cacheStats["command_stats"].([]middleware.CacheCommandStats); ok && len(lookups) > 0 {
+		var all middleware.CacheCommandStats
+		for _, stats := range lookups {
+			all.Hits += stats.Hits
+			all.Misses += stats.Misses
+		}
+		message.WriteString(fmt.Sprintf("💾 Cache hits: %.0f%%\n", all.HitRatio()*100))
 	}
-	if rate, ok := metrics["success_rate_percent"].(float64); ok {
-		message.WriteString(fmt.Sprintf("   • Success Rate: %.1f%%\n", rate))
+
+	if pool, ok := metrics["db_pool"].(sql.DBStats); ok {
+		message.WriteString(fmt.Sprintf("🗄️ DB connections: %d in use of %d\n", pool.InUse, pool.MaxOpenConnections))
+	}
+
+	message.WriteString("\nPick a view for details.")
+	return message.String()
+}
+
+// formatAIMetrics shows success rates, tokens and estimated spend per AI provider
+func (h *MetricsCommand) formatAIMetrics(metrics map[string]interface{}) string {
+	var message strings.Builder
+	message.WriteString("🤖 **AI Providers**\n\n")
+
+	providers, _ := metrics["ai_providers"].([]services.ProviderStats)
+	if len(providers) == 0 {
+		message.WriteString("No AI requests since startup.\n")
+	}
+	var spend float64
+	for _, stats := range providers {
+		message.WriteString(fmt.Sprintf("**%s**\n", stats.Provider))
+		message.WriteString(fmt.Sprintf("├── Calls: %d, %.0f%% successful\n", stats.Calls, stats.SuccessRate()*100))
+		message.WriteString(fmt.Sprintf("└── Tokens: %d (~$%.2f)\n", stats.Tokens, stats.SpendUSD))
+		spend += stats.SpendUSD
 	}
-	if rpm, ok := metrics["requests_per_minute"].(float64); ok {
-		message.WriteString(fmt.Sprintf("   • Req/min: %.1f\n", rpm))
+	if len(providers) > 0 {
+		message.WriteString(fmt.Sprintf("\n💵 Estimated spend since startup: ~$%.2f\n", spend))
 	}
 
-	// Top commands
-	if topCommands, ok := metrics["top_commands"].([]map[string]interface{}); ok && len(topCommands) > 0 {
-		message.WriteString("\n🔥 **Popular Commands:**\n")
-		for i, cmdData := range topCommands {
-			if i >= 5 { // Limit to top 5
-				break
-			}
-			if cmd, ok := cmdData["command"].(string); ok {
-				if count, ok := cmdData["count"].(int64); ok {
-					message.WriteString(fmt.Sprintf("   %d. %s: %d\n", i+1, cmd, count))
-				}
-			}
+	// AI answers that needed a JSON repair round-trip
+	if repairs, ok := metrics["ai_repairs"].([]services.RepairStats); ok && len(repairs) > 0 {
+		message.WriteString("\n🛠️ **JSON Repairs:**\n")
+		for _, stats := range repairs {
+			message.WriteString(fmt.Sprintf("   • %s: %d/%d repaired (%.0f%%)\n",
+				stats.Provider, stats.Repaired, stats.Attempts, stats.Rate()*100))
 		}
 	}
+	return message.String()
+}
 
-	// Cache metrics
-	if cacheStats != nil {
-		message.WriteString("\n💾 **Cache:**\n")
-		if size, ok := cacheStats["cache_size"].(int); ok {
-			message.WriteString(fmt.Sprintf("   • Size: %d items\n", size))
-		}
-		if ttl, ok := cacheStats["ttl_minutes"].(int); ok {
-			message.WriteString(fmt.Sprintf("   • TTL: %d minutes\n", ttl))
+// formatCacheMetrics shows the response cache and its hit ratio per command
+func (h *MetricsCommand) formatCacheMetrics(cacheStats map[string]interface{}) string {
+	var message strings.Builder
+	message.WriteString("💾 **Cache**\n\n")
+
+	if size, ok := cacheStats["cache_size"].(int); ok {
+		message.WriteString(fmt.Sprintf("   • Size: %d items\n", size))
+	}
+	if ttl, ok := cacheStats["ttl_minutes"].(int); ok {
+		message.WriteString(fmt.Sprintf("   • TTL: %d minutes\n", ttl))
+	}
+	if commands, ok := cacheStats["cacheable_commands"].(int); ok {
+		message.WriteString(fmt.Sprintf("   • Cached Commands: %d\n", commands))
+	}
+
+	lookups, _ := cacheStats["command_stats"].([]middleware.CacheCommandStats)
+	if len(lookups) == 0 {
+		message.WriteString("\nNo cached commands used since startup.\n")
+		return message.String()
+	}
+	message.WriteString("\n🎯 **Hit Ratio:**\n")
+	for _, stats := range lookups {
+		message.WriteString(fmt.Sprintf("   • %s: %.0f%% (%d hits, %d misses)\n",
+			stats.Command, stats.HitRatio()*100, stats.Hits, stats.Misses))
+	}
+	return message.String()
+}
+
+// formatCommandMetrics shows requests and the busiest commands with their latency percentiles
+func (h *MetricsCommand) formatCommandMetrics(metrics map[string]interface{}) string {
+	var message strings.Builder
+	message.WriteString("⚡ **Commands**\n\n")
+
+	if total, ok := metrics["total_requests"].(int64); ok {
+		failed, _ := metrics["failed_requests"].(int64)
+		message.WriteString(fmt.Sprintf("   • Requests: %d, %d failed\n", total, failed))
+	}
+	if limited, ok := metrics["telegram_rate_limited"].(int64); ok && limited > 0 {
+		retried, _ := metrics["telegram_retried"].(int64)
+		dropped, _ := metrics["telegram_dropped"].(int64)
+		message.WriteString(fmt.Sprintf("   • Telegram 429s: %d, %d messages retried, %d dropped\n", limited, retried, dropped))
+	}
+	if calls, ok := metrics["telegram_calls"].(map[string]middleware.TelegramCallMetrics); ok && len(calls) > 0 {
+		var count, errors int64
+		for _, call := range calls {
+			count += call.Count
+			errors += call.Errors
 		}
-		if commands, ok := cacheStats["cacheable_commands"].(int); ok {
-			message.WriteString(fmt.Sprintf("   • Cached Commands: %d\n", commands))
+		message.WriteString(fmt.Sprintf("   • Telegram API: %d calls, %d failed\n", count, errors))
+	}
+	cmdMetrics, _ := metrics["command_metrics"].(map[string]interface{})
+	message.WriteString(fmt.Sprintf("   • Avg Response: %dms\n", h.calculateAverageResponseTime(cmdMetrics)))
+	if slowest := h.findSlowestCommand(cmdMetrics); slowest.Command != "" {
+		message.WriteString(fmt.Sprintf("   • Slowest p95: %s (%dms)\n", slowest.Command, slowest.Duration))
+	}
+
+	usage := h.commandUsage(cmdMetrics)
+	if len(usage) == 0 {
+		return message.String()
+	}
+	message.WriteString("\n🔥 **Busiest:**\n")
+	for i, stats := range usage {
+		if i >= maxMetricsCommands {
+			break
 		}
+		message.WriteString(fmt.Sprintf("   %d. %s: %d, %d errors\n      p50 %dms · p95 %dms · p99 %dms\n",
+			i+1, stats.Command, stats.Count, stats.Errors, stats.P50, stats.P95, stats.P99))
 	}
+	return message.String()
+}
 
-	// Performance indicators
-	message.WriteString("\n⚡ **Performance:**\n")
-	if cmdMetrics, ok := metrics["command_metrics"].(map[string]interface{}); ok {
-		avgDuration := h.calculateAverageResponseTime(cmdMetrics)
-		message.WriteString(fmt.Sprintf("   • Avg Response: %dms\n", avgDuration))
+// formatDBMetrics shows the connection pool and the rows removed by data retention
+func (h *MetricsCommand) formatDBMetrics(metrics map[string]interface{}) string {
+	var message strings.Builder
+	message.WriteString("🗄️ **Database**\n\n")
+
+	// Database connection pool, to spot saturation under load
+	if pool, ok := metrics["db_pool"].(sql.DBStats); ok {
+		message.WriteString(fmt.Sprintf("   • Connections: %d in use, %d idle, max %d\n", pool.InUse, pool.Idle, pool.MaxOpenConnections))
+		message.WriteString(fmt.Sprintf("   • Waits: %d, %s total\n", pool.WaitCount, pool.WaitDuration.Round(time.Millisecond)))
+		message.WriteString(fmt.Sprintf("   • Closed: %d idle, %d at max lifetime\n", pool.MaxIdleClosed, pool.MaxLifetimeClosed))
+	}
 
-		slowestCommand := h.findSlowestCommand(cmdMetrics)
-		if slowestCommand.Command != "" {
-			message.WriteString(fmt.Sprintf("   • Slowest: %s (%dms)\n",
-				slowestCommand.Command, slowestCommand.Duration))
+	// Rows removed by the data retention policy
+	if retention, ok := metrics["retention"].(services.RetentionStats); ok && !retention.LastRun.IsZero() {
+		message.WriteString(fmt.Sprintf("\n🧹 **Data Retention** (last purge %s):\n", retention.LastRun.Format("Jan 2 15:04")))
+		for _, category := range []string{services.RetentionUserActivity, services.RetentionAnalyses, services.RetentionTemporary, services.RetentionMessageBuffer} {
+			message.WriteString(fmt.Sprintf("   • %s: %d last run, %d since start\n",
+				category, retention.LastPurged[category], retention.Total[category]))
 		}
 	}
+	return message.String()
+}
 
-	message.WriteString("\n🤖 *Real-time performance monitoring*")
+// commandUsageStats is the usage and latency of one command
+type commandUsageStats struct {
+	Command string
+	Count   int64
+	Errors  int64
+	P50     int64
+	P95     int64
+	P99     int64
+}
 
-	return message.String()
+// commandUsage returns the metrics of each command, busiest first
+func (h *MetricsCommand) commandUsage(cmdMetrics map[string]interface{}) []commandUsageStats {
+	var usage []commandUsageStats
+	for command, metricData := range cmdMetrics {
+		metrics, ok := metricData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		stats := commandUsageStats{Command: command}
+		stats.Count, _ = metrics["count"].(int64)
+		stats.Errors, _ = metrics["error_count"].(int64)
+		stats.P50, _ = metrics["p50_ms"].(int64)
+		stats.P95, _ = metrics["p95_ms"].(int64)
+		stats.P99, _ = metrics["p99_ms"].(int64)
+		if stats.Count > 0 {
+			usage = append(usage, stats)
+		}
+	}
+
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Count != usage[j].Count {
+			return usage[i].Count > usage[j].Count
+		}
+		return usage[i].Command < usage[j].Command
+	})
+	return usage
 }
 
 type CommandPerformance struct {
@@ -153,16 +340,16 @@ func (h *MetricsCommand) calculateAverageResponseTime(cmdMetrics map[string]inte
 	return totalDuration / totalCommands
 }
 
-// findSlowestCommand finds the command with highest average response time
+// findSlowestCommand finds the command with the highest 95th percentile response time
 func (h *MetricsCommand) findSlowestCommand(cmdMetrics map[string]interface{}) CommandPerformance {
 	var slowest CommandPerformance
 
 	for command, metricData := range cmdMetrics {
 		if metrics, ok := metricData.(map[string]interface{}); ok {
-			if avgDuration, ok := metrics["average_duration"].(int64); ok {
-				if avgDuration > slowest.Duration {
+			if p95, ok := metrics["p95_ms"].(int64); ok {
+				if p95 > slowest.Duration {
 					slowest.Command = command
-					slowest.Duration = avgDuration
+					slowest.Duration = p95
 				}
 			}
 		}
@@ -196,5 +383,10 @@ func (h *MetricsCommand) Description() string {
 
 // Usage returns the command usage
 func (h *MetricsCommand) Usage() string {
-	return "/metrics - Bot performance va statistikasini ko'rish"
+	return "/metrics [ai|cache|commands|db] - Bot performance va statistikasini ko'rish"
+}
+
+// Category is empty, as chats cannot turn the command off
+func (h *MetricsCommand) Category() string {
+	return ""
 }
@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// myTasksLimit caps the tasks listed with quick action buttons
+const myTasksLimit = 10
+
+// MyTasksCommand lists the caller's open tasks across all teams and projects
+type MyTasksCommand struct {
+	db        *database.DB
+	calendars *services.CalendarService
+	logger    domain.Logger
+}
+
+// NewMyTasksCommand creates a new my tasks command handler
+func NewMyTasksCommand(db *database.DB, calendars *services.CalendarService, logger domain.Logger) *MyTasksCommand {
+	return &MyTasksCommand{
+		db:        db,
+		calendars: calendars,
+		logger:    logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *MyTasksCommand) CanHandle(command string) bool {
+	return command == "/my_tasks"
+}
+
+// Description returns the command description
+func (c *MyTasksCommand) Description() string {
+	return "🗂️ Your tasks across all teams and projects"
+}
+
+// Usage returns the command usage instructions
+func (c *MyTasksCommand) Usage() string {
+	return "/my_tasks - Your open tasks grouped by due date and priority"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *MyTasksCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the my tasks command
+func (c *MyTasksCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing my_tasks command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	// Members are added by username, so link them to the caller's Telegram ID first
+	if err := c.db.LinkTeamMemberships(cmd.User.TelegramID, cmd.User.Username); err != nil {
+		c.logger.Warn("Failed to link team memberships", "user_id", cmd.User.TelegramID, "error", err)
+	}
+
+	tasks, err := c.db.GetTasksAssignedToUser(cmd.User.TelegramID)
+	if err != nil {
+		c.logger.Error("Failed to get assigned tasks", "user_id", cmd.User.TelegramID, "error", err)
+		return errorResponse("Failed to retrieve your tasks. Please try again."), nil
+	}
+
+	if len(tasks) == 0 {
+		return &domain.Response{
+			Text:      "🎉 **No open tasks assigned to you.**\n\nTasks show up here once they are assigned to you in any team you belong to.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("🗂️ **Your Tasks** (%d open)\n", len(tasks)))
+
+	keyboard := &domain.InlineKeyboardMarkup{}
+	listed := 0
+	for _, group := range GroupAssignedTasks(ctx, tasks, c.calendars, time.Now()) {
+		response.WriteString(fmt.Sprintf("\n**%s** (%d)\n", group.Title, len(group.Tasks)))
+		for _, task := range group.Tasks {
+			response.WriteString(fmt.Sprintf("%s `%s` %s - %s\n",
+				getPriorityIcon(task.Priority), task.ID, task.Title, formatTaskStatus(task.Status)))
+			due := ""
+			if !task.Deadline.IsZero() {
+				due = fmt.Sprintf(", due %s", task.Deadline.Format("Jan 2"))
+			}
+			response.WriteString(fmt.Sprintf("   └── %s · %s%s\n", task.ProjectName, task.TeamName, due))
+
+			if listed < myTasksLimit {
+				keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, taskQuickActions(task))
+				listed++
+			}
+		}
+	}
+
+	if len(tasks) > myTasksLimit {
+		response.WriteString(fmt.Sprintf("\n💡 Quick actions are shown for the first %d tasks; use `/task task_id` for the rest.", myTasksLimit))
+	} else {
+		response.WriteString("\n💡 Use the buttons below or `/task task_id` for details.")
+	}
+
+	return &domain.Response{
+		Text:        response.String(),
+		ParseMode:   "Markdown",
+		ReplyMarkup: keyboard,
+	}, nil
+}
+
+// AssignedTaskGroup is a due-date bucket of a user's tasks
+type AssignedTaskGroup struct {
+	Title   string
+	Overdue bool
+	Tasks   []database.AssignedTask
+}
+
+// GroupAssignedTasks buckets open tasks into overdue on their team's calendar, due this week
+// and later, each sorted by priority. Empty groups are left out. It backs both /my_tasks and
+// the email digest.
+func GroupAssignedTasks(ctx context.Context, tasks []database.AssignedTask, calendars *services.CalendarService, now time.Time) []AssignedTaskGroup {
+	teamCalendars := make(map[int64]domain.WorkCalendar)
+	var overdue, thisWeek, later []database.AssignedTask
+	for _, task := range tasks {
+		calendar, ok := teamCalendars[task.ChatID]
+		if !ok {
+			calendar = calendars.GetCalendar(ctx, fmt.Sprintf("team_%d", task.ChatID))
+			teamCalendars[task.ChatID] = calendar
+		}
+
+		switch {
+		case domain.IsTaskOverdue(ToDomainTasks([]database.Task{task.Task})[0], task.Deadline, now, calendar):
+			overdue = append(overdue, task)
+		case !task.Deadline.IsZero() && task.Deadline.Sub(now) <= 7*24*time.Hour:
+			thisWeek = append(thisWeek, task)
+		default:
+			later = append(later, task)
+		}
+	}
+
+	var groups []AssignedTaskGroup
+	for _, group := range []AssignedTaskGroup{
+		{Title: "🚨 Overdue", Overdue: true, Tasks: overdue},
+		{Title: "📅 Due this week", Tasks: thisWeek},
+		{Title: "🗓️ Later", Tasks: later},
+	} {
+		if len(group.Tasks) == 0 {
+			continue
+		}
+		sortAssignedTasks(group.Tasks)
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// sortAssignedTasks orders tasks by priority, then by deadline and age
+func sortAssignedTasks(tasks []database.AssignedTask) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Priority != tasks[j].Priority {
+			return tasks[i].Priority < tasks[j].Priority
+		}
+		if !tasks[i].Deadline.Equal(tasks[j].Deadline) {
+			// Tasks without a deadline go last
+			if tasks[i].Deadline.IsZero() || tasks[j].Deadline.IsZero() {
+				return tasks[j].Deadline.IsZero()
+			}
+			return tasks[i].Deadline.Before(tasks[j].Deadline)
+		}
+		return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+	})
+}
+
+// taskQuickActions builds a row of buttons that run /task actions for a task
+func taskQuickActions(task database.AssignedTask) []domain.InlineKeyboardButton {
+	label := task.ID
+	if len(label) > 8 {
+		label = label[:8]
+	}
+
+	start := domain.InlineKeyboardButton{Text: "▶️ " + label, CallbackData: fmt.Sprintf("/task %s start", task.ID)}
+	if task.Status == domain.TaskStatusInProgress {
+		start = domain.InlineKeyboardButton{Text: "🔄 " + label, CallbackData: fmt.Sprintf("/task %s", task.ID)}
+	}
+
+	return []domain.InlineKeyboardButton{
+		start,
+		{Text: "✅ Done", CallbackData: fmt.Sprintf("/task %s done", task.ID)},
+		{Text: "⏱️ +1h", CallbackData: fmt.Sprintf("/task %s log 1h", task.ID)},
+	}
+}
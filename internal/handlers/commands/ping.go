@@ -62,6 +62,11 @@ func (h *PingCommand) Usage() string {
 	return "/ping - Bot ishlaganligini tekshirish"
 }
 
+// Category is empty, as chats cannot turn the command off
+func (h *PingCommand) Category() string {
+	return ""
+}
+
 // formatUptime formats duration into human readable format
 func formatUptime(d time.Duration) string {
 	days := int(d.Hours()) / 24
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// PointsCommand configures story point estimates for a team
+type PointsCommand struct {
+	estimationService *services.EstimationService
+	logger            domain.Logger
+}
+
+// NewPointsCommand creates a new points command handler
+func NewPointsCommand(estimationService *services.EstimationService, logger domain.Logger) *PointsCommand {
+	return &PointsCommand{
+		estimationService: estimationService,
+		logger:            logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *PointsCommand) CanHandle(command string) bool {
+	return command == "/points"
+}
+
+// Description returns the command description
+func (c *PointsCommand) Description() string {
+	return "🎲 Configure story point estimates and sprints"
+}
+
+// Usage returns the command usage instructions
+func (c *PointsCommand) Usage() string {
+	return "/points [on | off | scale 1=2,2=4,3=8 | sprint DAYS YYYY-MM-DD] - Story point settings"
+}
+
+// Category puts the command in the team category a chat can turn off
+func (c *PointsCommand) Category() string {
+	return services.CategoryTeam
+}
+
+// Handle processes the points command
+func (c *PointsCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing points command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/points")))
+
+	if len(args) == 0 {
+		return c.settingsResponse(teamID, ""), nil
+	}
+
+	var err error
+	var notice string
+	switch strings.ToLower(args[0]) {
+	case "on":
+		err = c.estimationService.SetUnit(teamID, domain.EstimateUnitPoints)
+		notice = "✅ `/analyze` now shows story points"
+	case "off":
+		err = c.estimationService.SetUnit(teamID, domain.EstimateUnitHours)
+		notice = "✅ `/analyze` now shows hours"
+	case "scale":
+		if len(args) < 2 {
+			return errorResponse("Usage: `/points scale 1=2,2=4,3=8,5=16`"), nil
+		}
+		_, err = c.estimationService.SetScale(teamID, strings.Join(args[1:], ""))
+		notice = "✅ Point scale updated"
+	case "sprint":
+		if len(args) < 2 {
+			return errorResponse("Usage: `/points sprint 14 2026-01-05` (length in days and any sprint start date)"), nil
+		}
+		days, parseErr := strconv.Atoi(args[1])
+		if parseErr != nil {
+			return errorResponse("Sprint length must be a number of days"), nil
+		}
+		start := time.Now()
+		if len(args) >= 3 {
+			start, parseErr = time.Parse("2006-01-02", args[2])
+			if parseErr != nil {
+				return errorResponse("Start date must be YYYY-MM-DD"), nil
+			}
+		}
+		err = c.estimationService.SetSprintConfig(teamID, days, start)
+		notice = "✅ Sprint settings updated"
+	default:
+		return c.settingsResponse(teamID, ""), nil
+	}
+
+	if err != nil {
+		c.logger.Error("Failed to update estimate settings", "team_id", teamID, "error", err)
+		return errorResponse(err.Error()), nil
+	}
+
+	c.logger.Info("Estimate settings updated", "team_id", teamID, "option", args[0])
+	return c.settingsResponse(teamID, notice), nil
+}
+
+// settingsResponse shows the team's estimate settings and point scale
+func (c *PointsCommand) settingsResponse(teamID, notice string) *domain.Response {
+	settings := c.estimationService.GetSettings(teamID)
+	sprint := c.estimationService.GetSprintConfig(teamID)
+
+	var response strings.Builder
+	if notice != "" {
+		response.WriteString(notice + "\n\n")
+	}
+
+	response.WriteString("🎲 **Story Point Settings**\n\n")
+	unit := "⏱️ Hours"
+	if settings.UsePoints() {
+		unit = "🎲 Story points"
+	}
+	response.WriteString(fmt.Sprintf("├── Estimate unit: %s\n", unit))
+	response.WriteString(fmt.Sprintf("└── Sprint: %d days (from %s)\n\n", sprint.LengthDays, sprint.Start.Format("Jan 2 2006")))
+
+	response.WriteString("📏 **Point Scale:**\n")
+	for i, level := range settings.Scale {
+		prefix := "├──"
+		if i == len(settings.Scale)-1 {
+			prefix = "└──"
+		}
+		min, max := settings.Scale.HourRange(level.Points)
+		response.WriteString(fmt.Sprintf("%s %d pts: %g-%gh\n", prefix, level.Points, min, max))
+	}
+
+	response.WriteString("\n**Configure:**\n")
+	response.WriteString("• `/points on` / `/points off` - points or hours in `/analyze`\n")
+	response.WriteString("• `/points scale 1=2,2=4,3=8,5=16` - points=max hours\n")
+	response.WriteString("• `/points sprint 14 2026-01-05` - sprint length and start\n")
+	response.WriteString("• `/velocity` - points and hours completed per sprint")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
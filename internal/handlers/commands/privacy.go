@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// PrivacyCommand explains what the bot stores and manages the chat message history
+type PrivacyCommand struct {
+	db        *database.DB
+	history   *services.ChatHistoryService
+	telemetry *services.Telemetry
+	logger    domain.Logger
+}
+
+// NewPrivacyCommand creates a new privacy command handler
+func NewPrivacyCommand(db *database.DB, history *services.ChatHistoryService, telemetry *services.Telemetry, logger domain.Logger) *PrivacyCommand {
+	return &PrivacyCommand{
+		db:        db,
+		history:   history,
+		telemetry: telemetry,
+		logger:    logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *PrivacyCommand) CanHandle(command string) bool {
+	return command == "/privacy"
+}
+
+// Description returns the command description
+func (c *PrivacyCommand) Description() string {
+	return "🔒 Show what the bot stores and control chat message history"
+}
+
+// Usage returns the command usage instructions
+func (c *PrivacyCommand) Usage() string {
+	return "/privacy [history on|off | size N | retention 12h | purge | ai private|group] - Data storage controls"
+}
+
+// Category is empty, as chats cannot turn the command off
+func (c *PrivacyCommand) Category() string {
+	return ""
+}
+
+// Handle processes the privacy command
+func (c *PrivacyCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing privacy command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	chatID := cmd.Chat.ID
+	args := strings.Fields(strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/privacy"))))
+	if len(args) == 0 {
+		return c.overview(chatID, ""), nil
+	}
+
+	var err error
+	var notice string
+	switch args[0] {
+	case "purge":
+		removed := c.history.Purge(chatID)
+		notice = fmt.Sprintf("🗑️ Purged %d stored messages", removed)
+	case "history":
+		if len(args) < 2 || (args[1] != "on" && args[1] != "off") {
+			return errorResponse("Usage: `/privacy history on` or `/privacy history off`"), nil
+		}
+		err = c.history.SetEnabled(chatID, args[1] == "on")
+		notice = "✅ Message history turned " + args[1]
+	case "size":
+		if len(args) < 2 {
+			return errorResponse(fmt.Sprintf("Usage: `/privacy size N` (%d-%d)", services.MinHistorySize, services.MaxHistorySize)), nil
+		}
+		size, parseErr := strconv.Atoi(args[1])
+		if parseErr != nil {
+			return errorResponse("Size must be a whole number of messages"), nil
+		}
+		err = c.history.SetSize(chatID, size)
+		notice = fmt.Sprintf("✅ History size set to %d messages", size)
+	case "ai":
+		if len(args) < 2 || (args[1] != "private" && args[1] != "group") {
+			return errorResponse("Usage: `/privacy ai private` or `/privacy ai group`"), nil
+		}
+		if cmd.Chat.Type == "private" {
+			return errorResponse("Set the AI policy in the team chat."), nil
+		}
+		title := ""
+		if args[1] == "private" {
+			title = cmd.Chat.Title
+			if title == "" {
+				title = "team chat"
+			}
+		}
+		err = c.db.SetTeamSetting(fmt.Sprintf("team_%d", chatID), AIPrivateOnlyKey, title)
+		notice = "✅ AI analysis allowed in this chat"
+		if title != "" {
+			notice = "✅ AI analysis limited to private chats. Members can share result summaries here."
+		}
+	case "retention":
+		if len(args) < 2 {
+			return errorResponse("Usage: `/privacy retention 12h` or `/privacy retention 2d`"), nil
+		}
+		retention, parseErr := parseRetention(args[1])
+		if parseErr != nil {
+			return errorResponse(parseErr.Error()), nil
+		}
+		err = c.history.SetRetention(chatID, retention)
+		notice = fmt.Sprintf("✅ Retention set to %dh", int(retention.Hours()))
+	default:
+		return c.overview(chatID, ""), nil
+	}
+
+	if err != nil {
+		c.logger.Error("Failed to update privacy settings", "chat_id", chatID, "error", err)
+		return errorResponse(err.Error()), nil
+	}
+
+	c.logger.Info("Privacy settings updated", "chat_id", chatID, "option", args[0])
+	return c.overview(chatID, notice), nil
+}
+
+// overview explains stored data and shows the chat history settings
+func (c *PrivacyCommand) overview(chatID int64, notice string) *domain.Response {
+	settings := c.history.GetSettings(chatID)
+
+	var response strings.Builder
+	if notice != "" {
+		response.WriteString(notice + "\n\n")
+	}
+
+	response.WriteString("🔒 **Privacy & Data Storage**\n\n")
+	response.WriteString("**Always stored (database):**\n")
+	response.WriteString("├── Your Telegram ID, username and name\n")
+	response.WriteString("├── Commands you run (for /stats)\n")
+	response.WriteString("├── How often you use each command (for tips, `/hints off` to stop them)\n")
+	response.WriteString("└── Projects, tasks, team members and chat settings\n\n")
+
+	response.WriteString("**Chat message history (opt-in, memory only):**\n")
+	status := "❌ Off (default)"
+	if settings.Enabled {
+		status = "✅ On"
+	}
+	response.WriteString(fmt.Sprintf("├── Status: %s\n", status))
+	response.WriteString(fmt.Sprintf("├── Keeps last: %d messages\n", settings.Size))
+	response.WriteString(fmt.Sprintf("├── Retention: %dh\n", int(settings.Retention.Hours())))
+	response.WriteString(fmt.Sprintf("└── Stored now: %d messages\n\n", c.history.Count(chatID)))
+
+	response.WriteString("History is used only for `/summarize`, is never written to the database " +
+		"and is lost on restart. Messages sent to AI providers are not stored by the bot.\n\n")
+	response.WriteString("Shared links are fetched only for `/summarize_link`, or for every link once " +
+		"`/summarize_link auto on` is set; the page text goes to the AI provider and is not stored.\n\n")
+
+	aiPolicy := "└── 💬 Allowed in this chat\n\n"
+	if settings, err := c.db.GetTeamSettings(fmt.Sprintf("team_%d", chatID)); err != nil {
+		c.logger.Warn("Failed to load team settings", "chat_id", chatID, "error", err)
+	} else if settings[AIPrivateOnlyKey] != "" {
+		aiPolicy = "└── 🔒 Private chats only: requirements and files are not posted here\n\n"
+	}
+	response.WriteString("**AI analysis (`/analyze`, file uploads):**\n")
+	response.WriteString(aiPolicy)
+
+	response.WriteString("**Usage statistics (set by the bot operator):**\n")
+	if c.telemetry.Enabled() {
+		response.WriteString("└── ✅ Anonymized totals only: command counts, error rates and AI provider mix. " +
+			"No chats, users or messages are included.\n\n")
+	} else {
+		response.WriteString("└── ❌ Off - nothing is reported\n\n")
+	}
+
+	response.WriteString("**Manage:**\n")
+	response.WriteString("• `/privacy history on|off` - opt in or out\n")
+	response.WriteString(fmt.Sprintf("• `/privacy size 100` - messages kept (%d-%d)\n", services.MinHistorySize, services.MaxHistorySize))
+	response.WriteString(fmt.Sprintf("• `/privacy retention 12h` - how long (1h-%dh)\n", int(services.MaxHistoryRetention.Hours())))
+	response.WriteString("• `/privacy purge` - delete stored messages now\n")
+	response.WriteString("• `/privacy ai private|group` - where the team may run AI analysis")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// parseRetention parses retention values like 12h or 2d
+func parseRetention(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid retention %q, e.g. `12h` or `2d`", value)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	hours, err := strconv.Atoi(strings.TrimSuffix(value, "h"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid retention %q, e.g. `12h` or `2d`", value)
+	}
+	return time.Duration(hours) * time.Hour, nil
+}
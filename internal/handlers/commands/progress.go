@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"context"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Placeholders shown while a slow AI step runs; the response replaces them
+const (
+	analyzingPlaceholder  = "⏳ Analyzing..."
+	evaluatingPlaceholder = "⏳ Evaluating your answer..."
+)
+
+// sendPlaceholder posts a progress message and returns its ID for Response.EditMessageID.
+// It returns 0, so the result is sent as a new message, when no responder is set or the
+// placeholder could not be posted.
+func sendPlaceholder(ctx context.Context, responder domain.Responder, cmd *domain.Command, text string, logger domain.Logger) int {
+	if responder == nil || cmd.Chat == nil {
+		return 0
+	}
+	messageID, err := responder.SendPlaceholder(ctx, cmd.Chat.ID, text)
+	if err != nil {
+		logger.Warn("Failed to send placeholder", "chat_id", cmd.Chat.ID, "error", err)
+		return 0
+	}
+	return messageID
+}
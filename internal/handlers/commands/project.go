@@ -8,19 +8,24 @@ import (
 
 	"yordamchi-dev-bot/database"
 	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
 )
 
 // ProjectCommand handles project management operations
 type ProjectCommand struct {
-	db     *database.DB
-	logger domain.Logger
+	db       *database.DB
+	bridge   *services.NotificationBridge
+	webhooks *services.ProjectWebhooks
+	logger   domain.Logger
 }
 
 // NewProjectCommand creates a new project command handler
-func NewProjectCommand(db *database.DB, logger domain.Logger) *ProjectCommand {
+func NewProjectCommand(db *database.DB, bridge *services.NotificationBridge, webhooks *services.ProjectWebhooks, logger domain.Logger) *ProjectCommand {
 	return &ProjectCommand{
-		db:     db,
-		logger: logger,
+		db:       db,
+		bridge:   bridge,
+		webhooks: webhooks,
+		logger:   logger,
 	}
 }
 
@@ -36,7 +41,12 @@ func (c *ProjectCommand) Description() string {
 
 // Usage returns the command usage instructions
 func (c *ProjectCommand) Usage() string {
-	return "/create_project project_name - Create new development project"
+	return "/create_project [--template key] project_name | templates - Create new development project, optionally from a starter template"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *ProjectCommand) Category() string {
+	return services.CategoryProjects
 }
 
 // Handle processes the create_project command
@@ -47,6 +57,27 @@ func (c *ProjectCommand) Handle(ctx context.Context, cmd *domain.Command) (*doma
 	cmdText := strings.TrimPrefix(cmd.Text, "/create_project")
 	cmdText = strings.TrimSpace(cmdText)
 
+	args := strings.Fields(cmdText)
+	switch {
+	case len(args) == 1 && strings.EqualFold(args[0], "templates"):
+		return c.listTemplates(), nil
+	case len(args) == 3 && strings.EqualFold(args[0], "template"):
+		return c.applyToProject(cmd, args[1], args[2]), nil
+	}
+
+	cmdText, templateKey := parseTemplateFlag(cmdText)
+	var template *domain.ProjectTemplate
+	if templateKey != "" {
+		found, ok := domain.FindProjectTemplate(templateKey)
+		if !ok {
+			return &domain.Response{
+				Text:      fmt.Sprintf("❌ Unknown template `%s`. See `/create_project templates`.", templateKey),
+				ParseMode: "Markdown",
+			}, nil
+		}
+		template = &found
+	}
+
 	if cmdText == "" {
 		return &domain.Response{
 			Text: "❌ Please provide a project name.\n\n" +
@@ -90,6 +121,9 @@ func (c *ProjectCommand) Handle(ctx context.Context, cmd *domain.Command) (*doma
 		"name", project.Name,
 		"created_by", cmd.User.TelegramID)
 
+	c.bridge.Notify(ctx, project.TeamID, services.BridgeEventProjectCreated,
+		fmt.Sprintf("📝 **New project:** %s (`%s`), created by @%s", project.Name, project.ID, cmd.User.Username))
+
 	response := fmt.Sprintf("✅ **Project Created Successfully!**\n\n"+
 		"📝 **Name:** %s\n"+
 		"🆔 **Project ID:** `%s`\n"+
@@ -106,12 +140,177 @@ func (c *ProjectCommand) Handle(ctx context.Context, cmd *domain.Command) (*doma
 		project.CreatedAt.Format("Jan 2, 2006 15:04"),
 		strings.Title(project.Status))
 
+	if template == nil {
+		return &domain.Response{
+			Text:        response + "\n\n🧩 Start from a template to get a task skeleton:",
+			ParseMode:   "Markdown",
+			ReplyMarkup: templateKeyboard(project.ID),
+		}, nil
+	}
+
+	created := c.applyTemplate(project, *template)
 	return &domain.Response{
-		Text:      response,
+		Text:      response + "\n\n" + formatTemplateApplied(*template, project.Name, created),
 		ParseMode: "Markdown",
 	}, nil
 }
 
+// listTemplates shows the built-in project templates
+func (c *ProjectCommand) listTemplates() *domain.Response {
+	var response strings.Builder
+	response.WriteString("🧩 **Project Templates**\n\n")
+	for _, template := range domain.ProjectTemplates() {
+		response.WriteString(fmt.Sprintf("%s **%s** (`%s`)\n", template.Icon, template.Name, template.Key))
+		response.WriteString(fmt.Sprintf("├── %s\n", template.Description))
+		response.WriteString(fmt.Sprintf("└── %d tasks, %sh baseline\n\n", len(template.Tasks), domain.FormatHours(template.TotalHours())))
+	}
+	response.WriteString("**Usage:** `/create_project --template saas_mvp My Product`")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// applyToProject applies a template to an existing project of the chat that has no tasks yet
+func (c *ProjectCommand) applyToProject(cmd *domain.Command, projectID, templateKey string) *domain.Response {
+	template, ok := domain.FindProjectTemplate(templateKey)
+	if !ok {
+		return &domain.Response{
+			Text:      fmt.Sprintf("❌ Unknown template `%s`. See `/create_project templates`.", templateKey),
+			ParseMode: "Markdown",
+		}
+	}
+
+	projects, err := c.db.GetProjectsByChatID(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to get projects", "error", err, "chat_id", cmd.Chat.ID)
+		return &domain.Response{Text: "❌ Failed to retrieve projects. Please try again.", ParseMode: "Markdown"}
+	}
+
+	var project *database.Project
+	for i := range projects {
+		if projects[i].ID == projectID {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		return &domain.Response{Text: fmt.Sprintf("❌ Project `%s` not found in this chat.", projectID), ParseMode: "Markdown"}
+	}
+
+	tasks, err := c.db.GetTasksByProjectID(project.ID)
+	if err != nil {
+		c.logger.Error("Failed to get project tasks", "error", err, "project_id", project.ID)
+		return &domain.Response{Text: "❌ Failed to retrieve tasks. Please try again.", ParseMode: "Markdown"}
+	}
+	if len(tasks) > 0 {
+		return &domain.Response{
+			Text:      fmt.Sprintf("❌ **%s** already has %d tasks. Templates can only be applied to empty projects.", project.Name, len(tasks)),
+			ParseMode: "Markdown",
+		}
+	}
+
+	created := c.applyTemplate(project, template)
+	return &domain.Response{
+		Text:      formatTemplateApplied(template, project.Name, created),
+		ParseMode: "Markdown",
+	}
+}
+
+// applyTemplate creates the template's starter tasks in the project and returns the created tasks
+func (c *ProjectCommand) applyTemplate(project *database.Project, template domain.ProjectTemplate) []database.Task {
+	taskIDs := make([]string, len(template.Tasks))
+	for i := range template.Tasks {
+		taskIDs[i] = fmt.Sprintf("%s_t%d", project.ID, i+1)
+	}
+
+	var created []database.Task
+	for i, starter := range template.Tasks {
+		var dependencies []string
+		for _, index := range starter.DependsOn {
+			dependencies = append(dependencies, taskIDs[index])
+		}
+
+		task := database.Task{
+			ID:            taskIDs[i],
+			ProjectID:     project.ID,
+			Title:         starter.Title,
+			Description:   fmt.Sprintf("Starter task from the %s template", template.Name),
+			Category:      starter.Category,
+			EstimateHours: starter.EstimateHours,
+			Status:        domain.TaskStatusTodo,
+			Priority:      starter.Priority,
+			Dependencies:  dependencies,
+		}
+		if err := c.db.CreateTask(&task); err != nil {
+			c.logger.Warn("Failed to create template task", "task_id", task.ID, "error", err)
+			continue
+		}
+		created = append(created, task)
+		c.webhooks.TaskCreated(ToDomainTask(task))
+	}
+
+	c.logger.Info("Project template applied", "project_id", project.ID, "template", template.Key, "tasks", len(created))
+	return created
+}
+
+// formatTemplateApplied summarizes the starter tasks created from a template
+func formatTemplateApplied(template domain.ProjectTemplate, projectName string, created []database.Task) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("%s **%s** template applied to **%s**\n\n", template.Icon, template.Name, projectName))
+
+	var tasks []domain.Task
+	for i, task := range created {
+		branch := "├──"
+		if i == len(created)-1 {
+			branch = "└──"
+		}
+		response.WriteString(fmt.Sprintf("%s `%s` %s (%s, %sh)\n", branch, task.ID, task.Title, task.Category, domain.FormatHours(task.EstimateHours)))
+		tasks = append(tasks, domain.Task{EstimateHours: task.EstimateHours})
+	}
+
+	response.WriteString(fmt.Sprintf("\n⏱️ **Baseline:** %s\n", domain.TotalEstimateRange(tasks)))
+	response.WriteString("✏️ Adjust with `/task task_id estimate 6h`, or add work with `/analyze requirement`.")
+	return response.String()
+}
+
+// templateKeyboard offers the built-in templates for a newly created project, two per row
+func templateKeyboard(projectID string) *domain.InlineKeyboardMarkup {
+	var rows [][]domain.InlineKeyboardButton
+	for i, template := range domain.ProjectTemplates() {
+		button := domain.InlineKeyboardButton{
+			Text:         template.Icon + " " + template.Name,
+			CallbackData: fmt.Sprintf("/create_project template %s %s", projectID, template.Key),
+		}
+		if i%2 == 0 {
+			rows = append(rows, []domain.InlineKeyboardButton{button})
+		} else {
+			rows[len(rows)-1] = append(rows[len(rows)-1], button)
+		}
+	}
+	return &domain.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// parseTemplateFlag extracts "--template key" or "--template=key" from the project name text
+func parseTemplateFlag(text string) (string, string) {
+	fields := strings.Fields(text)
+	var name []string
+	key := ""
+	for i := 0; i < len(fields); i++ {
+		switch {
+		case strings.HasPrefix(fields[i], "--template="):
+			key = strings.TrimPrefix(fields[i], "--template=")
+		case fields[i] == "--template" && i+1 < len(fields):
+			key = fields[i+1]
+			i++
+		default:
+			name = append(name, fields[i])
+		}
+	}
+	return strings.Join(name, " "), key
+}
+
 // Helper function to generate project IDs
 func generateProjectID() string {
 	return fmt.Sprintf("proj_%d", time.Now().UnixNano()%1000000)
@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// projectEventNames describes the events a project webhook receives
+var projectEventNames = map[string]string{
+	services.ProjectEventTaskCreated:   "➕ Task created",
+	services.ProjectEventTaskCompleted: "✅ Task completed",
+	services.ProjectEventStatusChanged: "🚦 Project health changed",
+}
+
+// ProjectWebhookCommand configures the outbound webhook of a project. In groups only
+// administrators change it, and the signing secret goes to their private chat.
+type ProjectWebhookCommand struct {
+	db       *database.DB
+	webhooks *services.ProjectWebhooks
+	chats    *services.TelegramChatService
+	logger   domain.Logger
+}
+
+// NewProjectWebhookCommand creates a new project webhook command handler
+func NewProjectWebhookCommand(db *database.DB, webhooks *services.ProjectWebhooks, chats *services.TelegramChatService, logger domain.Logger) *ProjectWebhookCommand {
+	return &ProjectWebhookCommand{
+		db:       db,
+		webhooks: webhooks,
+		chats:    chats,
+		logger:   logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *ProjectWebhookCommand) CanHandle(command string) bool {
+	return command == "/project_webhook"
+}
+
+// Description returns the command description
+func (c *ProjectWebhookCommand) Description() string {
+	return "🪝 Send project events to your own tools"
+}
+
+// Usage returns the command usage instructions
+func (c *ProjectWebhookCommand) Usage() string {
+	return "/project_webhook [project_id] [https://URL | off | events e1,e2 | test] - Signed JSON events of a project"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *ProjectWebhookCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the project_webhook command
+func (c *ProjectWebhookCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing project_webhook command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/project_webhook")))
+	projectID := ""
+	if len(args) > 0 && strings.HasPrefix(args[0], "proj_") {
+		projectID, args = args[0], args[1:]
+	}
+
+	project, err := c.findProject(cmd.Chat.ID, projectID)
+	if err != nil {
+		c.logger.Error("Failed to get projects", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to retrieve projects. Please try again."), nil
+	}
+	if project == nil {
+		if projectID != "" {
+			return errorResponse(fmt.Sprintf("Project `%s` not found in this chat.", projectID)), nil
+		}
+		return errorResponse("No active project in this chat. Create one with `/create_project name`."), nil
+	}
+
+	if len(args) == 0 {
+		return c.configResponse(project, ""), nil
+	}
+
+	if isGroupChat(cmd.Chat.Type) {
+		admins, err := c.chats.GetChatAdministrators(ctx, cmd.Chat.ID)
+		if err != nil {
+			c.logger.Error("Failed to get chat administrators", "chat_id", cmd.Chat.ID, "error", err)
+			return errorResponse("Failed to check the chat administrators. Please try again."), nil
+		}
+		if !isChatAdministrator(admins, cmd.User.TelegramID) {
+			return errorResponse("Only chat administrators can change the project webhook."), nil
+		}
+	}
+
+	var notice string
+	switch option := strings.ToLower(args[0]); {
+	case option == "off":
+		err = c.webhooks.Remove(project.ID)
+		notice = "✅ Webhook removed"
+	case option == "events":
+		if len(args) < 2 {
+			return errorResponse(fmt.Sprintf("Usage: `/project_webhook events %s`", strings.Join(services.ProjectEvents, ","))), nil
+		}
+		err = c.webhooks.SetEvents(project.ID, strings.Split(strings.ToLower(args[1]), ","))
+		notice = "✅ Webhook events updated"
+	case option == "test":
+		if err := c.webhooks.Test(ctx, project.ID); err != nil {
+			c.logger.Warn("Project webhook test failed", "project_id", project.ID, "error", err)
+			return errorResponse(fmt.Sprintf("Test failed: %s", err.Error())), nil
+		}
+		return c.configResponse(project, "✅ Test event delivered"), nil
+	case strings.HasPrefix(option, "https://") || strings.HasPrefix(option, "http://"):
+		notice, err = c.configure(ctx, cmd, project, args[0])
+	default:
+		return c.configResponse(project, ""), nil
+	}
+
+	if err != nil {
+		c.logger.Error("Failed to update project webhook", "project_id", project.ID, "error", err)
+		return errorResponse(err.Error()), nil
+	}
+
+	c.logger.Info("Project webhook updated", "project_id", project.ID, "option", args[0], "user_id", cmd.User.TelegramID)
+	return c.configResponse(project, notice), nil
+}
+
+// configure saves a webhook URL with a new signing secret. The secret is shown in a
+// private chat; in a group it is sent to the caller privately, and the previous
+// configuration is kept when that fails.
+func (c *ProjectWebhookCommand) configure(ctx context.Context, cmd *domain.Command, project *database.Project, webhookURL string) (string, error) {
+	previous, err := c.webhooks.GetConfig(project.ID)
+	if err != nil {
+		return "", err
+	}
+	secret, err := c.webhooks.Configure(project.ID, webhookURL)
+	if err != nil {
+		return "", err
+	}
+
+	secretText := fmt.Sprintf("🔑 Signing secret of the **%s** webhook, shown only now:\n`%s`\n\n"+
+		"Each request carries `%s: sha256=<HMAC-SHA256 of the body>`. Sending a URL again creates a new secret.",
+		project.Name, secret, services.WebhookSignatureHeader)
+	if !isGroupChat(cmd.Chat.Type) {
+		return "✅ Webhook saved.\n\n" + secretText, nil
+	}
+
+	if err := c.chats.SendMessage(ctx, cmd.User.TelegramID, secretText, "Markdown"); err != nil {
+		c.logger.Warn("Failed to send the webhook secret privately", "project_id", project.ID, "user_id", cmd.User.TelegramID, "error", err)
+		if restoreErr := c.webhooks.Restore(project.ID, previous); restoreErr != nil {
+			c.logger.Error("Failed to restore the project webhook", "project_id", project.ID, "error", restoreErr)
+		}
+		return "", fmt.Errorf("could not send you the signing secret privately, so the webhook was not changed. Start a private chat with the bot and try again")
+	}
+	return "✅ Webhook saved. The signing secret was sent to you privately.", nil
+}
+
+// findProject returns a project of the chat by ID, or its active project without one
+func (c *ProjectWebhookCommand) findProject(chatID int64, projectID string) (*database.Project, error) {
+	projects, err := c.db.GetProjectsByChatID(chatID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		if (projectID == "" && projects[i].Status == "active") || projects[i].ID == projectID {
+			return &projects[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// configResponse shows a project's webhook configuration
+func (c *ProjectWebhookCommand) configResponse(project *database.Project, notice string) *domain.Response {
+	config, err := c.webhooks.GetConfig(project.ID)
+	if err != nil {
+		c.logger.Error("Failed to load project webhook", "project_id", project.ID, "error", err)
+		return errorResponse("Failed to load the webhook. Please try again.")
+	}
+
+	var response strings.Builder
+	if notice != "" {
+		response.WriteString(notice + "\n\n")
+	}
+
+	response.WriteString(fmt.Sprintf("🪝 **Webhook of %s** (`%s`)\n\n", project.Name, project.ID))
+	response.WriteString(fmt.Sprintf("├── URL: %s\n", maskWebhook(config.URL)))
+	response.WriteString("└── Events:\n")
+	for _, event := range services.ProjectEvents {
+		mark := "⬜"
+		if containsString(config.Events, event) {
+			mark = "✅"
+		}
+		response.WriteString(fmt.Sprintf("    %s %s (`%s`)\n", mark, projectEventNames[event], event))
+	}
+
+	response.WriteString("\n**Configure:**\n")
+	response.WriteString("• `/project_webhook https://tools.example.com/hooks/yordamchi`\n")
+	response.WriteString("• `/project_webhook off` - stop sending events\n")
+	response.WriteString(fmt.Sprintf("• `/project_webhook events %s` - choose events\n", strings.Join(services.ProjectEvents, ",")))
+	response.WriteString("• `/project_webhook test` - send a ping event\n")
+	response.WriteString("\n💡 Add the project ID first to configure another project. Failed deliveries are retried with a growing backoff.")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+func TestProjectWebhookIsConfiguredByAdminsWithAPrivateSecret(t *testing.T) {
+	db, err := database.NewSQLiteDB(filepath.Join(t.TempDir(), "webhook.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	defer db.Close()
+	if err := db.CreateProject(&database.Project{ID: "proj_1", Name: "Shop", TeamID: "team_-100", Status: "active"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Telegram knows user 1 as the only admin; the admin's private chat fails while closed
+	var private []string
+	closed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getChatAdministrators"):
+			w.Write([]byte(`{"ok":true,"result":[{"status":"creator","user":{"id":1,"first_name":"Lead"}}]}`))
+		case closed:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"ok":false,"error_code":403,"description":"Forbidden: bot can't initiate conversation with a user"}`))
+		default:
+			private = append(private, payload["text"].(string))
+			w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+		}
+	}))
+	defer server.Close()
+
+	chats := services.NewTelegramChatService(services.NewTelegramClient("token", nil).WithEndpoint(server.URL), &MockLogger{})
+	webhooks := services.NewProjectWebhooks(db, &MockLogger{})
+	command := NewProjectWebhookCommand(db, webhooks, chats, &MockLogger{})
+	run := func(userID int64, text string) string {
+		response, err := command.Handle(context.Background(), &domain.Command{
+			Text: text,
+			User: &domain.User{TelegramID: userID},
+			Chat: &domain.Chat{ID: -100, Type: "group"},
+		})
+		if err != nil {
+			t.Fatalf("Handle(%q) error = %v", text, err)
+		}
+		return response.Text
+	}
+
+	if text := run(2, "/project_webhook https://tools.example.com/hook"); !strings.Contains(text, "Only chat administrators") {
+		t.Errorf("member's change = %q, want it refused", text)
+	}
+
+	text := run(1, "/project_webhook https://tools.example.com/hook")
+	config, _ := webhooks.GetConfig("proj_1")
+	if config.URL != "https://tools.example.com/hook" || strings.Contains(text, config.Secret) {
+		t.Errorf("admin's change = %q with URL %q, want it saved without the secret in the group", text, config.URL)
+	}
+	if len(private) != 1 || !strings.Contains(private[0], config.Secret) {
+		t.Errorf("private messages = %q, want the secret sent to the admin", private)
+	}
+
+	// Without a private chat the secret cannot be handed over, so nothing changes
+	closed = true
+	if text := run(1, "/project_webhook https://other.example.com/hook"); !strings.Contains(text, "not changed") {
+		t.Errorf("change without a private chat = %q, want it refused", text)
+	}
+	if kept, _ := webhooks.GetConfig("proj_1"); kept.URL != config.URL || kept.Secret != config.Secret {
+		t.Errorf("config = %+v, want the previous webhook kept", kept)
+	}
+}
@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// RequestQuota reports a user's command rate limit, implemented by the rate limit middleware
+type RequestQuota interface {
+	Remaining(userID int64) (int, time.Time)
+	Limit() int
+	Window() time.Duration
+}
+
+// QuotaCommand shows the user's remaining command, chat, AI analysis and file budgets
+type QuotaCommand struct {
+	requestQuota     RequestQuota
+	cooldowns        *services.CooldownService
+	chatService      *services.ChatService
+	aiUsageService   *services.AIUsageService
+	fileUsageService *services.FileUsageService
+	fileExtractor    *services.FileExtractor
+	logger           domain.Logger
+}
+
+// NewQuotaCommand creates a new quota command handler
+func NewQuotaCommand(requestQuota RequestQuota, cooldowns *services.CooldownService, chatService *services.ChatService, aiUsageService *services.AIUsageService, fileUsageService *services.FileUsageService, fileExtractor *services.FileExtractor, logger domain.Logger) *QuotaCommand {
+	return &QuotaCommand{
+		requestQuota:     requestQuota,
+		cooldowns:        cooldowns,
+		chatService:      chatService,
+		aiUsageService:   aiUsageService,
+		fileUsageService: fileUsageService,
+		fileExtractor:    fileExtractor,
+		logger:           logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *QuotaCommand) CanHandle(command string) bool {
+	return command == "/quota"
+}
+
+// Description returns the command description
+func (c *QuotaCommand) Description() string {
+	return "📊 Show your rate limits and AI budget"
+}
+
+// Usage returns the command usage instructions
+func (c *QuotaCommand) Usage() string {
+	return "/quota - Remaining commands, AI chat questions, analyses and file uploads"
+}
+
+// Category is empty, as chats cannot turn the command off
+func (c *QuotaCommand) Category() string {
+	return ""
+}
+
+// Handle processes the quota command
+func (c *QuotaCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing quota command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	now := time.Now()
+	userID := cmd.User.TelegramID
+	var response strings.Builder
+
+	response.WriteString("📊 **Your Quota**\n\n")
+
+	requests, requestsReset := c.requestQuota.Remaining(userID)
+	response.WriteString(fmt.Sprintf("⚡ **Commands** (per %s)\n", formatQuotaWindow(c.requestQuota.Window())))
+	response.WriteString(fmt.Sprintf("├── Remaining: %d/%d\n", requests, c.requestQuota.Limit()))
+	response.WriteString(fmt.Sprintf("└── Resets: %s\n\n", formatQuotaReset(requestsReset, now)))
+
+	if commands := c.cooldowns.Commands(); len(commands) > 0 {
+		remaining := c.cooldowns.Remaining(cmd.Chat.ID)
+		response.WriteString("⏳ **Chat Cooldowns**\n")
+		for i, command := range commands {
+			branch := "├──"
+			if i == len(commands)-1 {
+				branch = "└──"
+			}
+			status := "ready"
+			if wait, ok := remaining[command]; ok {
+				status = "available " + formatQuotaReset(now.Add(wait), now)
+			}
+			response.WriteString(fmt.Sprintf("%s `%s` once per %s: %s\n", branch, command, formatQuotaWindow(c.cooldowns.Rules()[command]), status))
+		}
+		response.WriteString("\n")
+	}
+
+	userLimiter := c.chatService.UserLimiter()
+	questions, questionsReset := userLimiter.Remaining(userID)
+	chatBudget := c.chatService.ChatBudget()
+	chatQuestions, chatReset := chatBudget.Remaining(cmd.Chat.ID)
+	response.WriteString("💬 **AI Chat** (`/chat`)\n")
+	response.WriteString(fmt.Sprintf("├── Your questions this hour: %d/%d (resets %s)\n",
+		questions, userLimiter.Limit(), formatQuotaReset(questionsReset, now)))
+	response.WriteString(fmt.Sprintf("└── Chat budget today: %d/%d (resets %s)\n\n",
+		chatQuestions, chatBudget.Limit(), formatQuotaReset(chatReset, now)))
+
+	usage := c.aiUsageService.Usage(fmt.Sprintf("team_%d", cmd.Chat.ID))
+	response.WriteString("🤖 **AI Analyses** (`/analyze`, team)\n")
+	response.WriteString(fmt.Sprintf("├── Used this month: %d/%d\n", usage.Used, usage.Limit))
+	response.WriteString(fmt.Sprintf("├── Remaining: %d\n", usage.Remaining))
+	response.WriteString(fmt.Sprintf("└── Resets: %s\n\n", usage.ResetAt.Format("Jan 2 2006")))
+
+	files := c.fileUsageService.Usage(fmt.Sprintf("team_%d", cmd.Chat.ID))
+	response.WriteString("📁 **File Uploads** (team)\n")
+	response.WriteString(fmt.Sprintf("├── Files this month: %d/%d\n", files.Files, files.FilesLimit))
+	response.WriteString(fmt.Sprintf("├── Uploaded this month: %s/%s\n",
+		services.FormatFileSize(files.Bytes), services.FormatFileSize(files.BytesLimit)))
+	response.WriteString(fmt.Sprintf("├── Max file size: %dMB\n", c.fileExtractor.MaxFileSizeMB()))
+	response.WriteString(fmt.Sprintf("├── Formats: %s\n", strings.Join(c.fileExtractor.GetSupportedFormats(), ", ")))
+	response.WriteString(fmt.Sprintf("└── Resets: %s\n", files.ResetAt.Format("Jan 2 2006")))
+
+	filesExhausted := files.RemainingFiles() == 0 || files.RemainingBytes() == 0
+	if requests == 0 || questions == 0 || chatQuestions == 0 || usage.Remaining == 0 || filesExhausted {
+		response.WriteString("\n⚠️ Some limits are exhausted. They recover automatically at the reset times above.")
+	}
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// formatQuotaWindow formats a limiter window like "minute" or "24h"
+func formatQuotaWindow(window time.Duration) string {
+	switch window {
+	case time.Minute:
+		return "minute"
+	case time.Hour:
+		return "hour"
+	case 24 * time.Hour:
+		return "day"
+	}
+	return window.String()
+}
+
+// formatQuotaReset formats a reset time relative to now
+func formatQuotaReset(resetAt, now time.Time) string {
+	if !resetAt.After(now) {
+		return "now"
+	}
+	if until := resetAt.Sub(now); until < time.Minute {
+		return fmt.Sprintf("in %ds", int(until.Seconds())+1)
+	}
+	return "in " + formatUntil(resetAt.Sub(now))
+}
@@ -0,0 +1,11 @@
+package commands
+
+import "yordamchi-dev-bot/internal/domain"
+
+// errorResponse wraps an error message into a response
+func errorResponse(message string) *domain.Response {
+	return &domain.Response{
+		Text:      "❌ " + message,
+		ParseMode: "Markdown",
+	}
+}
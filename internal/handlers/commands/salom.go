@@ -84,3 +84,8 @@ func (h *SalomCommand) Description() string {
 func (h *SalomCommand) Usage() string {
 	return "/salom - Shaxsiylashtirilgan salom olish"
 }
+
+// Category is empty, as chats cannot turn the command off
+func (h *SalomCommand) Category() string {
+	return ""
+}
@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// maxPendingScheduledMessages limits pending scheduled messages per chat
+const maxPendingScheduledMessages = 20
+
+// ScheduleMessageCommand schedules messages to be sent to the chat later
+type ScheduleMessageCommand struct {
+	db       *database.DB
+	location *time.Location
+	logger   domain.Logger
+}
+
+// NewScheduleMessageCommand creates a new schedule message command handler
+func NewScheduleMessageCommand(db *database.DB, location *time.Location, logger domain.Logger) *ScheduleMessageCommand {
+	return &ScheduleMessageCommand{
+		db:       db,
+		location: location,
+		logger:   logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *ScheduleMessageCommand) CanHandle(command string) bool {
+	return command == "/schedule_message"
+}
+
+// Description returns the command description
+func (c *ScheduleMessageCommand) Description() string {
+	return "⏰ Schedule a message to the chat at a future time"
+}
+
+// Usage returns the command usage instructions
+func (c *ScheduleMessageCommand) Usage() string {
+	return "/schedule_message [when text | list | cancel ID] - Send a message later"
+}
+
+// Category puts the command in the team category a chat can turn off
+func (c *ScheduleMessageCommand) Category() string {
+	return services.CategoryTeam
+}
+
+// Handle processes the schedule message command
+func (c *ScheduleMessageCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing schedule message command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	input := strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/schedule_message"))
+	args := strings.Fields(input)
+
+	if len(args) == 0 {
+		return c.usageResponse(), nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "list":
+		return c.listMessages(cmd.Chat.ID)
+	case "cancel":
+		if len(args) < 2 {
+			return errorResponse("Usage: `/schedule_message cancel ID`"), nil
+		}
+		id, err := strconv.ParseInt(strings.TrimPrefix(args[1], "#"), 10, 64)
+		if err != nil {
+			return errorResponse("ID must be a number, see `/schedule_message list`"), nil
+		}
+		return c.cancelMessage(cmd.Chat.ID, id)
+	}
+
+	now := time.Now().In(c.location)
+	sendAt, text, err := domain.ParseScheduleTime(input, now)
+	if err != nil {
+		return errorResponse(err.Error()), nil
+	}
+	if text == "" {
+		return errorResponse("Message text is empty.\n\nExample: `/schedule_message tomorrow 09:00 Release freeze starts`"), nil
+	}
+
+	pending, err := c.db.GetPendingScheduledMessages(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to load scheduled messages", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to schedule message. Please try again."), nil
+	}
+	if len(pending) >= maxPendingScheduledMessages {
+		return errorResponse(fmt.Sprintf("This chat already has %d pending messages. Cancel some first.", maxPendingScheduledMessages)), nil
+	}
+
+	message := &database.ScheduledMessage{
+		ChatID:    cmd.Chat.ID,
+		Text:      text,
+		SendAt:    sendAt,
+		CreatedBy: cmd.User.TelegramID,
+	}
+	if err := c.db.CreateScheduledMessage(message); err != nil {
+		c.logger.Error("Failed to save scheduled message", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to schedule message. Please try again."), nil
+	}
+
+	c.logger.Info("Message scheduled", "id", message.ID, "chat_id", cmd.Chat.ID, "send_at", sendAt)
+
+	return &domain.Response{
+		Text: fmt.Sprintf("✅ **Message scheduled** (#%d)\n\n"+
+			"├── 🕐 When: %s\n"+
+			"└── ⏳ In: %s\n\n"+
+			"Cancel with `/schedule_message cancel %d`",
+			message.ID, c.formatTime(sendAt), formatUntil(sendAt.Sub(now)), message.ID),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// listMessages shows pending scheduled messages of the chat
+func (c *ScheduleMessageCommand) listMessages(chatID int64) (*domain.Response, error) {
+	messages, err := c.db.GetPendingScheduledMessages(chatID)
+	if err != nil {
+		c.logger.Error("Failed to list scheduled messages", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to load scheduled messages. Please try again."), nil
+	}
+
+	var response strings.Builder
+	response.WriteString("⏰ **Scheduled Messages**\n\n")
+
+	if len(messages) == 0 {
+		response.WriteString("No pending messages.\n\n")
+		response.WriteString("Example: `/schedule_message tomorrow 09:00 Release freeze starts`")
+	} else {
+		for _, message := range messages {
+			preview := message.Text
+			if len(preview) > 60 {
+				preview = preview[:57] + "..."
+			}
+			preview = strings.ReplaceAll(preview, "\n", " ")
+			response.WriteString(fmt.Sprintf("**#%d** - %s\n└── %s\n\n", message.ID, c.formatTime(message.SendAt), preview))
+		}
+		response.WriteString("Cancel with `/schedule_message cancel ID`")
+	}
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// cancelMessage cancels a pending scheduled message
+func (c *ScheduleMessageCommand) cancelMessage(chatID, id int64) (*domain.Response, error) {
+	cancelled, err := c.db.CancelScheduledMessage(chatID, id)
+	if err != nil {
+		c.logger.Error("Failed to cancel scheduled message", "chat_id", chatID, "id", id, "error", err)
+		return errorResponse("Failed to cancel message. Please try again."), nil
+	}
+	if !cancelled {
+		return errorResponse(fmt.Sprintf("No pending message #%d in this chat", id)), nil
+	}
+
+	c.logger.Info("Scheduled message cancelled", "chat_id", chatID, "id", id)
+
+	return &domain.Response{
+		Text:      fmt.Sprintf("🗑️ Scheduled message #%d cancelled", id),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// formatTime formats a delivery time in the bot timezone
+func (c *ScheduleMessageCommand) formatTime(t time.Time) string {
+	return t.In(c.location).Format("Mon, Jan 2 15:04 MST")
+}
+
+// usageResponse returns the command usage help
+func (c *ScheduleMessageCommand) usageResponse() *domain.Response {
+	return &domain.Response{
+		Text: "⏰ **Schedule Message**\n\n" +
+			"**Examples:**\n" +
+			"• `/schedule_message tomorrow 09:00 Release freeze starts`\n" +
+			"• `/schedule_message in 2h Deploy window opens`\n" +
+			"• `/schedule_message friday 17:00 Weekly demo`\n" +
+			"• `/schedule_message 2026-12-31 18:00 Year-end freeze`\n\n" +
+			"**Manage:**\n" +
+			"• `/schedule_message list` - pending messages\n" +
+			"• `/schedule_message cancel ID` - cancel a message",
+		ParseMode: "Markdown",
+	}
+}
+
+// formatUntil formats a duration as a short human readable string
+func formatUntil(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
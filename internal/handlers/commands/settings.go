@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// SettingsCommand shows an overview of the chat's settings and the commands that change them
+type SettingsCommand struct {
+	db      *database.DB
+	history *services.ChatHistoryService
+	menu    *services.MenuService
+	chats   *services.ChatSettingsService
+	logger  domain.Logger
+}
+
+// NewSettingsCommand creates a new settings command handler
+func NewSettingsCommand(db *database.DB, history *services.ChatHistoryService, menu *services.MenuService, chats *services.ChatSettingsService, logger domain.Logger) *SettingsCommand {
+	return &SettingsCommand{
+		db:      db,
+		history: history,
+		menu:    menu,
+		chats:   chats,
+		logger:  logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *SettingsCommand) CanHandle(command string) bool {
+	return command == "/settings"
+}
+
+// Description returns the command description
+func (c *SettingsCommand) Description() string {
+	return "⚙️ Chat settings overview"
+}
+
+// Usage returns the command usage instructions
+func (c *SettingsCommand) Usage() string {
+	return "/settings - Show the chat's settings"
+}
+
+// Category is empty, as chats cannot turn the command off
+func (c *SettingsCommand) Category() string {
+	return ""
+}
+
+// Handle processes the settings command
+func (c *SettingsCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing settings command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	settings, err := c.db.GetTeamSettings(teamID)
+	if err != nil {
+		c.logger.Error("Failed to load team settings", "team_id", teamID, "error", err)
+		return &domain.Response{Text: "❌ Failed to load settings. Please try again.", ParseMode: "Markdown"}, nil
+	}
+
+	var text strings.Builder
+	text.WriteString("⚙️ **Chat Settings**\n\n")
+
+	text.WriteString("🧱 **Stack Profile** · `/stack`, `/project_type`\n")
+	text.WriteString(formatStackProfile(settings))
+
+	repository := "none"
+	if settings[GitHubRepoKey] != "" {
+		repository = "`" + settings[GitHubRepoKey] + "`"
+	}
+	text.WriteString("\n🔗 **Commit Linking** · `/github link`\n")
+	text.WriteString(fmt.Sprintf("├── Repository: %s\n", repository))
+	text.WriteString(fmt.Sprintf("└── Task keys: `%s`\n", domain.FormatTaskKey(TaskKeyPrefix(settings), 12)))
+
+	weekly := "off"
+	if language := settings[StatusReportWeeklyKey]; language != "" {
+		weekly = services.LanguageNames[language]
+	}
+	history := c.history.GetSettings(cmd.Chat.ID)
+	historyStatus := "off"
+	if history.Enabled {
+		historyStatus = fmt.Sprintf("last %d messages, %dh", history.Size, int(history.Retention.Hours()))
+	}
+	menuStatus := "off"
+	if c.menu.GetConfig(cmd.Chat.ID).Enabled {
+		menuStatus = "on"
+	}
+	text.WriteString("\n💬 **Chat**\n")
+	text.WriteString(fmt.Sprintf("├── Weekly status report: %s · `/status_report weekly`\n", weekly))
+	text.WriteString(fmt.Sprintf("├── Message history: %s · `/summarize`\n", historyStatus))
+	text.WriteString(fmt.Sprintf("└── Quick action menu: %s · `/menu`\n", menuStatus))
+
+	chatSettings := c.chats.Get(cmd.Chat.ID)
+	categories := "all on"
+	if len(chatSettings.DisabledCategories) > 0 {
+		categories = strings.Join(chatSettings.DisabledCategories, ", ") + " off"
+	}
+	language := "not set"
+	if chatSettings.Language != "" {
+		language = services.LanguageNames[chatSettings.Language]
+	}
+	autoAnalyze := "on"
+	if !chatSettings.AutoAnalyze {
+		autoAnalyze = "off"
+	}
+	text.WriteString("\n🎛 **Behavior** · `/chat_settings`\n")
+	text.WriteString(fmt.Sprintf("├── Command categories: %s\n", categories))
+	text.WriteString(fmt.Sprintf("├── Default language: %s\n", language))
+	text.WriteString(fmt.Sprintf("└── File auto-analysis: %s\n", autoAnalyze))
+
+	return &domain.Response{Text: text.String(), ParseMode: "Markdown"}, nil
+}
@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// ShareCommand manages read-only status links of projects for people outside Telegram
+type ShareCommand struct {
+	db      *database.DB
+	baseURL string
+	logger  domain.Logger
+}
+
+// NewShareCommand creates a new share command handler.
+// baseURL is the public address of the bot's HTTP server, e.g. https://bot.example.com
+func NewShareCommand(db *database.DB, baseURL string, logger domain.Logger) *ShareCommand {
+	return &ShareCommand{
+		db:      db,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		logger:  logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *ShareCommand) CanHandle(command string) bool {
+	return command == "/share"
+}
+
+// Description returns the command description
+func (c *ShareCommand) Description() string {
+	return "🔗 Read-only project status links"
+}
+
+// Usage returns the command usage instructions
+func (c *ShareCommand) Usage() string {
+	return "/share [project_id | revoke project_id] - Share project status with stakeholders"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *ShareCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the share command
+func (c *ShareCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing share command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/share")))
+
+	projects, err := c.db.GetProjectsByChatID(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to get projects", "error", err, "chat_id", cmd.Chat.ID)
+		return errorResponse("Failed to retrieve projects. Please try again."), nil
+	}
+
+	if len(args) == 0 {
+		return c.listResponse(cmd, projects), nil
+	}
+
+	if strings.EqualFold(args[0], "revoke") {
+		if len(args) < 2 {
+			return errorResponse("Usage: `/share revoke project_id`"), nil
+		}
+		return c.revoke(cmd, projects, args[1]), nil
+	}
+
+	project := findProject(projects, args[0])
+	if project == nil {
+		return errorResponse(fmt.Sprintf("Project `%s` not found. See `/list_projects`.", args[0])), nil
+	}
+
+	// Reuse the active link so stakeholders keep a single URL
+	shares, err := c.db.GetActiveProjectShares(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to get project shares", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to load share links. Please try again."), nil
+	}
+	for _, share := range shares {
+		if share.ProjectID == project.ID {
+			return c.linkResponse(*project, share.Token, "🔗 **Existing read-only link**"), nil
+		}
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		c.logger.Error("Failed to generate share token", "error", err)
+		return errorResponse("Failed to create the link. Please try again."), nil
+	}
+
+	if err := c.db.CreateProjectShare(&database.ProjectShare{
+		Token:     token,
+		ProjectID: project.ID,
+		ChatID:    cmd.Chat.ID,
+		CreatedBy: cmd.User.TelegramID,
+	}); err != nil {
+		c.logger.Error("Failed to create project share", "project_id", project.ID, "error", err)
+		return errorResponse("Failed to create the link. Please try again."), nil
+	}
+	c.audit(cmd, database.AuditProjectShareCreated, project.ID, project.Name)
+
+	c.logger.Info("Project share created", "project_id", project.ID, "chat_id", cmd.Chat.ID)
+	return c.linkResponse(*project, token, "✅ **Read-only link created**"), nil
+}
+
+// revoke disables all links of a project
+func (c *ShareCommand) revoke(cmd *domain.Command, projects []database.Project, key string) *domain.Response {
+	project := findProject(projects, key)
+	if project == nil {
+		return errorResponse(fmt.Sprintf("Project `%s` not found. See `/list_projects`.", key))
+	}
+
+	revoked, err := c.db.RevokeProjectShares(project.ID)
+	if err != nil {
+		c.logger.Error("Failed to revoke project shares", "project_id", project.ID, "error", err)
+		return errorResponse("Failed to revoke the link. Please try again.")
+	}
+	if revoked == 0 {
+		return errorResponse(fmt.Sprintf("**%s** has no active link.", project.Name))
+	}
+	c.audit(cmd, database.AuditProjectShareRevoked, project.ID, project.Name)
+
+	c.logger.Info("Project shares revoked", "project_id", project.ID, "count", revoked)
+	return &domain.Response{
+		Text:      fmt.Sprintf("🚫 Link to **%s** revoked. It stops working immediately.", project.Name),
+		ParseMode: "Markdown",
+	}
+}
+
+// listResponse shows the chat's active links
+func (c *ShareCommand) listResponse(cmd *domain.Command, projects []database.Project) *domain.Response {
+	shares, err := c.db.GetActiveProjectShares(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to get project shares", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to load share links. Please try again.")
+	}
+
+	var response strings.Builder
+	response.WriteString("🔗 **Shared Projects**\n\n")
+	if len(shares) == 0 {
+		response.WriteString("No active links.\n")
+	}
+	for _, share := range shares {
+		name := share.ProjectID
+		if project := findProject(projects, share.ProjectID); project != nil {
+			name = project.Name
+		}
+		response.WriteString(fmt.Sprintf("• **%s** (`%s`) - since %s\n", name, share.ProjectID, share.CreatedAt.Format("Jan 2 2006")))
+	}
+	response.WriteString("\n• `/share project_id` - create or show a link\n")
+	response.WriteString("• `/share revoke project_id` - disable a link\n")
+	response.WriteString("\n💡 Anyone with the link can view progress and tasks without Telegram.")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// linkResponse shows a project's share URL
+func (c *ShareCommand) linkResponse(project database.Project, token, title string) *domain.Response {
+	path := "/share/" + token
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("%s for **%s**\n\n", title, project.Name))
+	if c.baseURL != "" {
+		response.WriteString(c.baseURL + path + "\n\n")
+	} else {
+		response.WriteString(fmt.Sprintf("`%s`\n\n⚠️ Set `PUBLIC_URL` to the bot's public address to get a full link.\n\n", path))
+	}
+	response.WriteString(fmt.Sprintf("Anyone with this link sees progress, tasks and milestones. Revoke it with `/share revoke %s`.", project.ID))
+
+	return &domain.Response{
+		Text:           response.String(),
+		ParseMode:      "Markdown",
+		DisablePreview: true,
+	}
+}
+
+// audit records a share change, logging failures without interrupting the command
+func (c *ShareCommand) audit(cmd *domain.Command, action, projectID, projectName string) {
+	if err := c.db.LogAudit(&database.AuditEntry{
+		ChatID:  cmd.Chat.ID,
+		Actor:   auditActor(cmd.User),
+		Action:  action,
+		Subject: projectID,
+		Details: projectName,
+	}); err != nil {
+		c.logger.Error("Failed to write audit entry", "project_id", projectID, "error", err)
+	}
+}
+
+// newSecretToken generates an unguessable URL-safe token for links
+func newSecretToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// shareExampleDisplayLimit is the longest formatted code shown in the chat; longer code is
+// only linked so the answer stays a single message
+const shareExampleDisplayLimit = 3000
+
+// ShareExampleCommand formats a Go snippet with gofmt and shares it on the Go Playground,
+// so an example can be run and tweaked by everyone in the chat
+type ShareExampleCommand struct {
+	playground          *services.PlaygroundService
+	telegramFileService *services.TelegramFileService
+	logger              domain.Logger
+}
+
+// NewShareExampleCommand creates a new share example command handler
+func NewShareExampleCommand(playground *services.PlaygroundService, telegramFileService *services.TelegramFileService, logger domain.Logger) *ShareExampleCommand {
+	return &ShareExampleCommand{
+		playground:          playground,
+		telegramFileService: telegramFileService,
+		logger:              logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *ShareExampleCommand) CanHandle(command string) bool {
+	return command == "/share_example"
+}
+
+// Description returns the command description
+func (c *ShareExampleCommand) Description() string {
+	return "▶️ Share Go code on the Go Playground"
+}
+
+// Usage returns the command usage instructions
+func (c *ShareExampleCommand) Usage() string {
+	return "/share_example code - Or send with or in reply to a .go file or a message with code"
+}
+
+// Category puts the command in the dev category a chat can turn off
+func (c *ShareExampleCommand) Category() string {
+	return services.CategoryDev
+}
+
+// ChatAction implements domain.LongRunningHandler: the code is uploaded to the Playground
+func (c *ShareExampleCommand) ChatAction(cmd *domain.Command) string {
+	return domain.ChatActionTyping
+}
+
+// Handle processes the share_example command
+func (c *ShareExampleCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing share_example command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	code, message := goCodeInput(ctx, cmd, "/share_example", c.telegramFileService, services.MaxPlaygroundBytes, c.logger)
+	if message != "" {
+		return errorResponse(message), nil
+	}
+	if strings.TrimSpace(code) == "" {
+		return &domain.Response{
+			Text: "▶️ **Share a Go Example**\n\n" +
+				"`/share_example` followed by your code on the next lines, or send a `.go` file captioned `/share_example`, " +
+				"or reply `/share_example` to a message or file with code.\n\n" +
+				"The code is formatted with gofmt and shared on the Go Playground.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+	if len(code) > services.MaxPlaygroundBytes {
+		return errorResponse(fmt.Sprintf("The code is too large. The Go Playground accepts up to %d KB.", services.MaxPlaygroundBytes/1024)), nil
+	}
+
+	// Code that does not parse is shared as it is so it can be fixed on the Playground
+	formatted, formatErr := services.FormatGo(code)
+	if formatErr == nil {
+		code = formatted
+	}
+
+	link, err := c.playground.Share(ctx, code)
+	if err != nil {
+		c.logger.Warn("Failed to share code on the Go Playground", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("The Go Playground is not reachable right now. Please try again later."), nil
+	}
+	c.logger.Info("Code shared on the Go Playground", "chat_id", cmd.Chat.ID, "link", link)
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("▶️ **Go Playground:** %s\n", link))
+	if formatErr != nil {
+		response.WriteString(fmt.Sprintf("⚠️ Not formatted, the code has a syntax error: `%s`\n", strings.TrimPrefix(formatErr.Error(), "gofmt: ")))
+	} else {
+		response.WriteString("✨ Formatted with gofmt\n")
+	}
+
+	trimmed := strings.TrimRight(code, "\n")
+	if len(trimmed) <= shareExampleDisplayLimit && !strings.Contains(trimmed, "```") {
+		response.WriteString("\n```go\n" + trimmed + "\n```")
+	} else {
+		lines := strings.Count(trimmed, "\n") + 1
+		response.WriteString(fmt.Sprintf("\n%d lines, open the link to see the code.", lines))
+	}
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// goCodeInput returns the Go code of a command: its arguments, an attached or replied-to
+// .go file, or the replied-to message. The message explains a file that cannot be used.
+func goCodeInput(ctx context.Context, cmd *domain.Command, command string, files *services.TelegramFileService, maxBytes int, logger domain.Logger) (string, string) {
+	_, code := parseHighlightInput(strings.TrimPrefix(cmd.Text, command))
+	if strings.TrimSpace(code) != "" {
+		return code, ""
+	}
+
+	document := cmd.Document
+	if document == nil {
+		document = cmd.ReplyToDocument
+	}
+	if document != nil {
+		return readGoFile(ctx, document, files, maxBytes, logger)
+	}
+
+	_, code = parseHighlightInput(cmd.ReplyToText)
+	return code, ""
+}
+
+// readGoFile downloads an attached Go file, returning a message for the user when it
+// cannot be used
+func readGoFile(ctx context.Context, document *domain.TelegramDocument, files *services.TelegramFileService, maxBytes int, logger domain.Logger) (string, string) {
+	if !strings.HasSuffix(strings.ToLower(document.FileName), ".go") {
+		return "", fmt.Sprintf("`%s` is not a Go file. Send a `.go` file or paste the code.", document.FileName)
+	}
+	if document.FileSize > maxBytes {
+		return "", fmt.Sprintf("The file is too large (%s). Files up to %d KB are accepted.",
+			files.GetFileSize(document.FileSize), maxBytes/1024)
+	}
+
+	tempFile, err := files.DownloadFile(ctx, document)
+	if err != nil {
+		logger.Error("Failed to download Go file", "error", err, "filename", document.FileName)
+		return "", "Download failed. Please send the file again."
+	}
+	defer files.CleanupFile(tempFile)
+
+	data, err := os.ReadFile(tempFile)
+	if err != nil {
+		logger.Error("Failed to read Go file", "error", err, "filename", document.FileName)
+		return "", "Failed to read the file. Please try again."
+	}
+	return string(data), ""
+}
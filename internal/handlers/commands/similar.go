@@ -0,0 +1,257 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+const (
+	// similarLimit is the number of matches /similar shows
+	similarLimit = 6
+	// minSimilarity hides matches too far from the query to be related
+	minSimilarity = 0.3
+)
+
+// SimilarCommand finds related tasks, analyses and snippets by meaning rather than words
+type SimilarCommand struct {
+	db         *database.DB
+	embeddings *services.EmbeddingService
+	logger     domain.Logger
+}
+
+// NewSimilarCommand creates a new similar command handler
+func NewSimilarCommand(db *database.DB, embeddings *services.EmbeddingService, logger domain.Logger) *SimilarCommand {
+	return &SimilarCommand{
+		db:         db,
+		embeddings: embeddings,
+		logger:     logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *SimilarCommand) CanHandle(command string) bool {
+	return command == "/similar"
+}
+
+// Description returns the command description
+func (c *SimilarCommand) Description() string {
+	return "🔎 Find related tasks, analyses and snippets"
+}
+
+// Usage returns the command usage instructions
+func (c *SimilarCommand) Usage() string {
+	return "/similar task_id | text - Semantic search over the team's work"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *SimilarCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the similar command
+func (c *SimilarCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing similar command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	query := strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/similar"))
+	if query == "" {
+		return errorResponse("Usage: `/similar TASK-12` for tasks like it, or `/similar payment webhook retries` to search by meaning."), nil
+	}
+	if !c.embeddings.Enabled() {
+		return errorResponse("Semantic search needs an embeddings provider. Set `OPENAI_API_KEY` or `GEMINI_API_KEY`."), nil
+	}
+	model := c.embeddings.Model()
+
+	settings, err := c.db.GetTeamSettings(fmt.Sprintf("team_%d", cmd.Chat.ID))
+	if err != nil {
+		c.logger.Warn("Failed to load team settings", "chat_id", cmd.Chat.ID, "error", err)
+	}
+	prefix := TaskKeyPrefix(settings)
+
+	var vector []float32
+	var heading, excludeID string
+	if taskID := c.resolveTaskID(cmd.Chat.ID, query, prefix); taskID != "" {
+		embedding, err := c.taskEmbedding(ctx, cmd.Chat.ID, taskID, model)
+		if err == nil && embedding == nil {
+			err = fmt.Errorf("task %s was not indexed", taskID)
+		}
+		if err != nil {
+			c.logger.Error("Failed to embed task", "task_id", taskID, "error", err)
+			return errorResponse("Failed to index the task. Please try again later."), nil
+		}
+		vector, excludeID = embedding.Vector, taskID
+		heading = fmt.Sprintf("🔎 **Similar to** %s\n\n", c.taskLabel(taskID, embedding.Title, prefix))
+	} else {
+		vectors, err := c.embeddings.Embed(ctx, []string{query})
+		if err != nil {
+			c.logger.Error("Failed to embed search query", "error", err)
+			return errorResponse("Search failed. Please try again later."), nil
+		}
+		vector = vectors[0]
+		heading = fmt.Sprintf("🔎 **Search:** %s\n\n", shortenText(query, 80))
+	}
+
+	candidates, err := c.db.GetChatEmbeddings(cmd.Chat.ID, model)
+	if err != nil {
+		c.logger.Error("Failed to load embeddings", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Search failed. Please try again later."), nil
+	}
+
+	type match struct {
+		embedding database.Embedding
+		score     float64
+	}
+	var matches []match
+	for _, candidate := range candidates {
+		if candidate.Kind == database.EmbeddingKindTask && candidate.RefID == excludeID {
+			continue
+		}
+		if score := services.CosineSimilarity(vector, candidate.Vector); score >= minSimilarity {
+			matches = append(matches, match{candidate, score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	if len(matches) > similarLimit {
+		matches = matches[:similarLimit]
+	}
+
+	var response strings.Builder
+	response.WriteString(heading)
+	if len(matches) == 0 {
+		response.WriteString("Nothing related found. New tasks, analyses and snippets are indexed every few minutes.")
+		return &domain.Response{Text: response.String(), ParseMode: "Markdown"}, nil
+	}
+	for i, m := range matches {
+		branch := "├──"
+		if i == len(matches)-1 {
+			branch = "└──"
+		}
+		response.WriteString(fmt.Sprintf("%s %s · %d%%\n", branch, c.formatMatch(m.embedding, prefix), int(m.score*100+0.5)))
+	}
+
+	return &domain.Response{Text: response.String(), ParseMode: "Markdown"}, nil
+}
+
+// resolveTaskID returns the ID of the chat's task given by ID or key, or "" when the query is not one
+func (c *SimilarCommand) resolveTaskID(chatID int64, query, prefix string) string {
+	if strings.ContainsAny(query, " \n") {
+		return ""
+	}
+	taskID := query
+	if number, ok := domain.ParseTaskKey(query, prefix); ok {
+		found, err := c.db.FindTaskByKey(fmt.Sprintf("team_%d", chatID), number)
+		if err != nil || found == "" {
+			return ""
+		}
+		taskID = found
+	} else if !strings.HasPrefix(query, "task_") {
+		return ""
+	}
+
+	location, err := c.db.GetTaskLocation(taskID)
+	if err != nil || location == nil || location.ChatID != chatID {
+		return ""
+	}
+	return taskID
+}
+
+// taskEmbedding returns the task's vector, indexing the task first when it is new or changed
+func (c *SimilarCommand) taskEmbedding(ctx context.Context, chatID int64, taskID, model string) (*database.Embedding, error) {
+	location, err := c.db.GetTaskLocation(taskID)
+	if err != nil || location == nil {
+		return nil, fmt.Errorf("task %s not found", taskID)
+	}
+	tasks, err := c.db.GetTasksByProjectID(location.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if task.ID != taskID {
+			continue
+		}
+		existing, err := c.db.GetEmbedding(database.EmbeddingKindTask, chatID, taskID, model)
+		if err != nil {
+			return nil, err
+		}
+		source := database.EmbeddingSource{
+			Kind: database.EmbeddingKindTask, ChatID: chatID, RefID: taskID,
+			Title: task.Title, Body: task.Description,
+		}
+		if existing != nil {
+			source.ContentHash = existing.ContentHash
+		}
+		if _, err := IndexEmbeddingSources(ctx, c.db, c.embeddings, model, []database.EmbeddingSource{source}); err != nil {
+			return nil, err
+		}
+		return c.db.GetEmbedding(database.EmbeddingKindTask, chatID, taskID, model)
+	}
+	return nil, fmt.Errorf("task %s not found", taskID)
+}
+
+// taskLabel shows a task by key and title
+func (c *SimilarCommand) taskLabel(taskID, title, prefix string) string {
+	if number, err := c.db.EnsureTaskKey(taskID); err == nil {
+		return fmt.Sprintf("`%s` %s", domain.FormatTaskKey(prefix, number), title)
+	}
+	return fmt.Sprintf("`%s` %s", taskID, title)
+}
+
+// formatMatch shows a match with the command that opens it
+func (c *SimilarCommand) formatMatch(embedding database.Embedding, prefix string) string {
+	switch embedding.Kind {
+	case database.EmbeddingKindTask:
+		return "🗂️ " + c.taskLabel(embedding.RefID, embedding.Title, prefix)
+	case database.EmbeddingKindAnalysis:
+		return fmt.Sprintf("🧠 %s (`/analyses open %s`)", shortenText(embedding.Title, 60), embedding.RefID)
+	default:
+		return fmt.Sprintf("📌 Snippet `/snippet %s`", embedding.RefID)
+	}
+}
+
+// IndexEmbeddingSources embeds the documents whose content changed since they were indexed
+// and marks the others as indexed. It returns the number of documents embedded.
+func IndexEmbeddingSources(ctx context.Context, db *database.DB, embeddings *services.EmbeddingService, model string, sources []database.EmbeddingSource) (int, error) {
+	var changed []database.EmbeddingSource
+	var texts, hashes []string
+	for _, source := range sources {
+		text := strings.TrimSpace(source.Title + "\n\n" + source.Body)
+		hash := services.EmbeddingContentHash(text)
+		if hash == source.ContentHash {
+			if err := db.TouchEmbedding(source.Kind, source.ChatID, source.RefID); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		changed = append(changed, source)
+		texts = append(texts, text)
+		hashes = append(hashes, hash)
+	}
+	if len(changed) == 0 {
+		return 0, nil
+	}
+
+	vectors, err := embeddings.Embed(ctx, texts)
+	if err != nil {
+		return 0, err
+	}
+	for i, source := range changed {
+		err := db.SaveEmbedding(database.Embedding{
+			Kind:        source.Kind,
+			ChatID:      source.ChatID,
+			RefID:       source.RefID,
+			Title:       shortenText(source.Title, 200),
+			Model:       model,
+			ContentHash: hashes[i],
+			Vector:      vectors[i],
+		})
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(changed), nil
+}
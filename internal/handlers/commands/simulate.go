@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// maxSimulated429 limits how many messages /simulate telegram_429 can rate limit at once
+const maxSimulated429 = 10
+
+// SimulateCommand injects faults on staging deployments. It is only registered when
+// SIMULATE_ENABLED=true.
+type SimulateCommand struct {
+	simulator *services.Simulator
+	logger    domain.Logger
+}
+
+// NewSimulateCommand creates a new simulate command handler
+func NewSimulateCommand(simulator *services.Simulator, logger domain.Logger) *SimulateCommand {
+	return &SimulateCommand{
+		simulator: simulator,
+		logger:    logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *SimulateCommand) CanHandle(command string) bool {
+	return command == "/simulate"
+}
+
+// Description returns the command description
+func (c *SimulateCommand) Description() string {
+	return "🧨 Inject faults to test fallbacks (staging only)"
+}
+
+// MenuScope keeps fault injection out of the command menu; it is for operators on staging
+func (c *SimulateCommand) MenuScope() string {
+	return domain.MenuScopeHidden
+}
+
+// Usage returns the command usage instructions
+func (c *SimulateCommand) Usage() string {
+	return "/simulate [latency 5s | ai_failure on|off | telegram_429 [count] | off] - Staging fault injection"
+}
+
+// Category is empty, as chats cannot turn the command off
+func (c *SimulateCommand) Category() string {
+	return ""
+}
+
+// Handle processes the simulate command
+func (c *SimulateCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing simulate command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/simulate"))))
+	if len(args) == 0 {
+		return c.statusResponse(""), nil
+	}
+
+	var notice string
+	switch args[0] {
+	case "latency":
+		if len(args) != 2 {
+			return errorResponse("Usage: `/simulate latency 5s` or `/simulate latency off`"), nil
+		}
+		latency := time.Duration(0)
+		if args[1] != "off" && args[1] != "0" {
+			parsed, err := time.ParseDuration(args[1])
+			if err != nil {
+				return errorResponse(fmt.Sprintf("Invalid duration `%s`. Use e.g. `500ms`, `5s`.", args[1])), nil
+			}
+			latency = parsed
+		}
+		if err := c.simulator.SetLatency(latency); err != nil {
+			return errorResponse(err.Error() + "."), nil
+		}
+		notice = "🐢 Latency off"
+		if latency > 0 {
+			notice = fmt.Sprintf("🐢 Latency set to %s", latency)
+		}
+
+	case "ai_failure":
+		if len(args) != 2 || (args[1] != "on" && args[1] != "off") {
+			return errorResponse("Usage: `/simulate ai_failure on|off`"), nil
+		}
+		c.simulator.SetAIFailure(args[1] == "on")
+		notice = fmt.Sprintf("🤖 AI failure %s", args[1])
+
+	case "telegram_429":
+		count := 1
+		if len(args) == 2 {
+			if args[1] == "off" {
+				count = 0
+			} else {
+				parsed, err := strconv.Atoi(args[1])
+				if err != nil || parsed < 1 || parsed > maxSimulated429 {
+					return errorResponse(fmt.Sprintf("Count must be between 1 and %d.", maxSimulated429)), nil
+				}
+				count = parsed
+			}
+		}
+		// This confirmation is the first message rate limited, so it arrives after the retry
+		c.simulator.SetTelegram429(count)
+		notice = "📵 Telegram 429 off"
+		if count > 0 {
+			notice = fmt.Sprintf("📵 The next %d messages get Telegram 429 and are retried", count)
+		}
+
+	case "off", "reset":
+		c.simulator.Reset()
+		notice = "✅ All simulated faults removed"
+
+	default:
+		return errorResponse("Usage: `/simulate [latency 5s | ai_failure on|off | telegram_429 [count] | off]`"), nil
+	}
+
+	c.logger.Warn("Simulated faults changed", "chat_id", cmd.Chat.ID, "user_id", cmd.User.TelegramID, "change", strings.Join(args, " "))
+	return c.statusResponse(notice), nil
+}
+
+// statusResponse shows the injected faults
+func (c *SimulateCommand) statusResponse(notice string) *domain.Response {
+	state := c.simulator.State()
+
+	var response strings.Builder
+	if notice != "" {
+		response.WriteString(notice + "\n\n")
+	}
+	response.WriteString("🧨 **Fault Simulation** (staging)\n\n")
+
+	latency := "off"
+	if state.Latency > 0 {
+		latency = state.Latency.String()
+	}
+	aiFailure := "off"
+	if state.AIFailure {
+		aiFailure = "on"
+	}
+	response.WriteString(fmt.Sprintf("├── Latency: %s\n", latency))
+	response.WriteString(fmt.Sprintf("├── AI failure: %s\n", aiFailure))
+	response.WriteString(fmt.Sprintf("└── Telegram 429: %d pending\n\n", state.Telegram429))
+
+	response.WriteString("**Try:**\n")
+	response.WriteString("• `/simulate latency 5s` - delay every command\n")
+	response.WriteString("• `/simulate ai_failure on` - make AI providers fail\n")
+	response.WriteString("• `/simulate telegram_429 2` - rate limit the next messages\n")
+	response.WriteString("• `/simulate off` - remove all faults")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
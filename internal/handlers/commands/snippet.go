@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// snippetNamePattern validates snippet names such as deploy or pr-template
+var snippetNamePattern = regexp.MustCompile(`^[a-z0-9_\-]{1,32}$`)
+
+// reservedSnippetNames cannot be used as snippet names because they are subcommands
+var reservedSnippetNames = map[string]bool{
+	"save":   true,
+	"list":   true,
+	"delete": true,
+	"remove": true,
+}
+
+// SnippetCommand manages reusable canned responses per chat
+type SnippetCommand struct {
+	db     *database.DB
+	logger domain.Logger
+}
+
+// NewSnippetCommand creates a new snippet command handler
+func NewSnippetCommand(db *database.DB, logger domain.Logger) *SnippetCommand {
+	return &SnippetCommand{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *SnippetCommand) CanHandle(command string) bool {
+	return command == "/snippet" || command == "/snippets"
+}
+
+// Description returns the command description
+func (c *SnippetCommand) Description() string {
+	return "📋 Save and recall reusable team snippets"
+}
+
+// Usage returns the command usage instructions
+func (c *SnippetCommand) Usage() string {
+	return "/snippet [list | save name text | delete name | name] - Team canned responses"
+}
+
+// Category puts the command in the dev category a chat can turn off
+func (c *SnippetCommand) Category() string {
+	return services.CategoryDev
+}
+
+// Handle processes the snippet command
+func (c *SnippetCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing snippet command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	text := strings.TrimSpace(cmd.Text)
+	command, rest := splitFirstWord(text)
+	if command == "/snippets" {
+		return c.listSnippets(cmd.Chat.ID)
+	}
+
+	action, rest := splitFirstWord(rest)
+	switch strings.ToLower(action) {
+	case "", "list":
+		return c.listSnippets(cmd.Chat.ID)
+	case "save":
+		name, content := splitFirstWord(rest)
+		return c.saveSnippet(cmd, strings.ToLower(name), content)
+	case "delete", "remove":
+		name, _ := splitFirstWord(rest)
+		return c.deleteSnippet(cmd.Chat.ID, strings.ToLower(name))
+	default:
+		return c.recallSnippet(cmd.Chat.ID, strings.ToLower(action))
+	}
+}
+
+// saveSnippet stores a snippet in the chat namespace
+func (c *SnippetCommand) saveSnippet(cmd *domain.Command, name, content string) (*domain.Response, error) {
+	if name == "" || content == "" {
+		return errorResponse("Usage: `/snippet save name text`\n\nThe text can span multiple lines and use Markdown."), nil
+	}
+	if !snippetNamePattern.MatchString(name) || reservedSnippetNames[name] {
+		return errorResponse("Snippet name must use letters, digits, `-` or `_` (max 32) and not be a subcommand"), nil
+	}
+
+	snippet := &database.Snippet{
+		ChatID:    cmd.Chat.ID,
+		Name:      name,
+		Content:   content,
+		CreatedBy: cmd.User.TelegramID,
+	}
+	if err := c.db.SaveSnippet(snippet); err != nil {
+		c.logger.Error("Failed to save snippet", "chat_id", cmd.Chat.ID, "name", name, "error", err)
+		return errorResponse("Failed to save snippet. Please try again."), nil
+	}
+
+	c.logger.Info("Snippet saved", "chat_id", cmd.Chat.ID, "name", name, "length", len(content))
+
+	return &domain.Response{
+		Text:      fmt.Sprintf("✅ **Snippet saved:** `%s`\n\nRecall it with `/snippet %s`", name, name),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// recallSnippet returns the snippet content and bumps its usage count
+func (c *SnippetCommand) recallSnippet(chatID int64, name string) (*domain.Response, error) {
+	snippet, err := c.db.GetSnippet(chatID, name)
+	if err != nil {
+		c.logger.Error("Failed to get snippet", "chat_id", chatID, "name", name, "error", err)
+		return errorResponse("Failed to load snippet. Please try again."), nil
+	}
+	if snippet == nil {
+		return errorResponse(fmt.Sprintf("Snippet `%s` not found. See `/snippet list`", name)), nil
+	}
+
+	if err := c.db.IncrementSnippetUsage(chatID, name); err != nil {
+		c.logger.Warn("Failed to update snippet usage", "chat_id", chatID, "name", name, "error", err)
+	}
+
+	return &domain.Response{
+		Text:      snippet.Content,
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// deleteSnippet removes a snippet from the chat namespace
+func (c *SnippetCommand) deleteSnippet(chatID int64, name string) (*domain.Response, error) {
+	if name == "" {
+		return errorResponse("Usage: `/snippet delete name`"), nil
+	}
+
+	deleted, err := c.db.DeleteSnippet(chatID, name)
+	if err != nil {
+		c.logger.Error("Failed to delete snippet", "chat_id", chatID, "name", name, "error", err)
+		return errorResponse("Failed to delete snippet. Please try again."), nil
+	}
+	if !deleted {
+		return errorResponse(fmt.Sprintf("Snippet `%s` not found", name)), nil
+	}
+
+	c.logger.Info("Snippet deleted", "chat_id", chatID, "name", name)
+
+	return &domain.Response{
+		Text:      fmt.Sprintf("🗑️ Snippet `%s` deleted", name),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// listSnippets shows all snippets of the chat with usage counts
+func (c *SnippetCommand) listSnippets(chatID int64) (*domain.Response, error) {
+	snippets, err := c.db.GetSnippetsByChatID(chatID)
+	if err != nil {
+		c.logger.Error("Failed to list snippets", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to load snippets. Please try again."), nil
+	}
+
+	var response strings.Builder
+	response.WriteString("📋 **Team Snippets**\n\n")
+
+	if len(snippets) == 0 {
+		response.WriteString("No snippets saved yet.\n\n")
+	} else {
+		for i, snippet := range snippets {
+			prefix := "├──"
+			if i == len(snippets)-1 {
+				prefix = "└──"
+			}
+			response.WriteString(fmt.Sprintf("%s `%s` - used %d times\n", prefix, snippet.Name, snippet.UsageCount))
+		}
+		response.WriteString("\n")
+	}
+
+	response.WriteString("**Manage:**\n")
+	response.WriteString("• `/snippet save deploy text` - save (multi-line Markdown supported)\n")
+	response.WriteString("• `/snippet deploy` - recall\n")
+	response.WriteString("• `/snippet delete deploy` - delete")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// splitFirstWord splits text into its first word and the remaining text,
+// preserving line breaks in the remainder
+func splitFirstWord(text string) (string, string) {
+	text = strings.TrimLeft(text, " \t\n")
+	index := strings.IndexAny(text, " \t\n")
+	if index < 0 {
+		return text, ""
+	}
+	return text[:index], strings.TrimSpace(text[index+1:])
+}
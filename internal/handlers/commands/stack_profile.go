@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// Team settings of the stack profile analyses are made for. StackKey holds a comma-separated
+// list of technologies; both are empty when the team has not set them.
+const (
+	StackKey       = "stack"
+	ProjectTypeKey = "project_type"
+)
+
+// defaultProjectType is assumed when the team has not set a project type
+const defaultProjectType = "web"
+
+// maxStackItems limits the technologies of a stack profile
+const maxStackItems = 20
+
+// ProjectTypes are the project types a team can choose
+var ProjectTypes = []string{"web", "mobile", "api", "desktop", "cli", "data", "embedded"}
+
+var stackItemPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.+#_-]{0,29}$`)
+
+// TeamStack returns the technologies of the team's stack profile, or nil when it is not set
+func TeamStack(settings map[string]string) []string {
+	if settings[StackKey] == "" {
+		return nil
+	}
+	return strings.Split(settings[StackKey], ",")
+}
+
+// TeamProjectType returns the team's project type
+func TeamProjectType(settings map[string]string) string {
+	if projectType := settings[ProjectTypeKey]; projectType != "" {
+		return projectType
+	}
+	return defaultProjectType
+}
+
+// StackCommand sets the stack profile analyses are made for
+type StackCommand struct {
+	db     *database.DB
+	logger domain.Logger
+}
+
+// NewStackCommand creates a new stack profile command handler
+func NewStackCommand(db *database.DB, logger domain.Logger) *StackCommand {
+	return &StackCommand{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *StackCommand) CanHandle(command string) bool {
+	return command == "/stack" || command == "/project_type"
+}
+
+// Description returns the command description
+func (c *StackCommand) Description() string {
+	return "🧱 Team tech stack and project type"
+}
+
+// Usage returns the command usage instructions
+func (c *StackCommand) Usage() string {
+	return "/stack set go,react,postgres | /project_type set mobile - Stack profile used by /analyze"
+}
+
+// Category puts the command in the team category a chat can turn off
+func (c *StackCommand) Category() string {
+	return services.CategoryTeam
+}
+
+// Handle processes the stack and project type commands
+func (c *StackCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing stack command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	parts := strings.Fields(cmd.Text)
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	if len(parts) < 2 {
+		return c.profile(teamID, ""), nil
+	}
+
+	var key, value, notice string
+	switch strings.ToLower(parts[1]) {
+	case "set":
+		if len(parts) < 3 {
+			return errorResponse(c.usage(parts[0])), nil
+		}
+		if parts[0] == "/stack" {
+			stack, err := parseStack(strings.Join(parts[2:], " "))
+			if err != nil {
+				return errorResponse(err.Error()), nil
+			}
+			key, value, notice = StackKey, strings.Join(stack, ","), "✅ Stack saved"
+		} else {
+			projectType := strings.ToLower(parts[2])
+			if !validProjectType(projectType) || len(parts) > 3 {
+				return errorResponse(fmt.Sprintf("Unknown project type. Choose one of: %s", strings.Join(ProjectTypes, ", "))), nil
+			}
+			key, value, notice = ProjectTypeKey, projectType, "✅ Project type saved"
+		}
+	case "clear":
+		key = StackKey
+		notice = "✅ Stack cleared, analyses use the team members' skills"
+		if parts[0] == "/project_type" {
+			key = ProjectTypeKey
+			notice = fmt.Sprintf("✅ Project type reset to %s", defaultProjectType)
+		}
+	default:
+		return errorResponse(c.usage(parts[0])), nil
+	}
+
+	if err := c.db.SetTeamSetting(teamID, key, value); err != nil {
+		c.logger.Error("Failed to save stack profile", "team_id", teamID, "key", key, "error", err)
+		return errorResponse("Failed to save the profile. Please try again."), nil
+	}
+	c.logger.Info("Stack profile updated", "team_id", teamID, "key", key, "value", value)
+	return c.profile(teamID, notice), nil
+}
+
+// profile shows the team's stack profile
+func (c *StackCommand) profile(teamID, notice string) *domain.Response {
+	settings, err := c.db.GetTeamSettings(teamID)
+	if err != nil {
+		c.logger.Warn("Failed to load team settings", "team_id", teamID, "error", err)
+	}
+
+	var text strings.Builder
+	if notice != "" {
+		text.WriteString(notice + "\n\n")
+	}
+	text.WriteString("🧱 **Stack Profile**\n")
+	text.WriteString(formatStackProfile(settings))
+	text.WriteString("\nAnalyses plan tasks for this stack. Change it with `/stack set go,react,postgres` or `/project_type set mobile`.")
+	return &domain.Response{Text: text.String(), ParseMode: "Markdown"}
+}
+
+// usage returns the usage of the stack or project type command
+func (c *StackCommand) usage(command string) string {
+	if command == "/project_type" {
+		return fmt.Sprintf("Usage: `/project_type set %s` or `/project_type clear`", strings.Join(ProjectTypes, "|"))
+	}
+	return "Usage: `/stack set go,react,postgres` or `/stack clear`"
+}
+
+// formatStackProfile shows the team's stack and project type as tree lines
+func formatStackProfile(settings map[string]string) string {
+	stack := "team members' skills"
+	if items := TeamStack(settings); len(items) > 0 {
+		stack = strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("├── Stack: %s\n└── Project type: %s\n", stack, TeamProjectType(settings))
+}
+
+// parseStack splits a comma-separated stack into distinct lowercase technologies
+func parseStack(value string) ([]string, error) {
+	seen := make(map[string]bool)
+	var stack []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" || seen[item] {
+			continue
+		}
+		if !stackItemPattern.MatchString(item) {
+			return nil, fmt.Errorf("`%s` is not a technology name. Use letters, digits and . + # _ -", item)
+		}
+		seen[item] = true
+		stack = append(stack, item)
+	}
+	if len(stack) == 0 {
+		return nil, fmt.Errorf("Usage: `/stack set go,react,postgres`")
+	}
+	if len(stack) > maxStackItems {
+		return nil, fmt.Errorf("A stack can list up to %d technologies", maxStackItems)
+	}
+	return stack, nil
+}
+
+// validProjectType reports whether a project type is one of ProjectTypes
+func validProjectType(projectType string) bool {
+	for _, known := range ProjectTypes {
+		if projectType == known {
+			return true
+		}
+	}
+	return false
+}
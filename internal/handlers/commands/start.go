@@ -26,7 +26,8 @@ func (h *StartCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain
 
 	message := h.welcomeMessage
 	if user != nil && user.FirstName != "" {
-		message += "\n\n👋 Salom, " + user.FirstName + "!"
+		// The greeting is sent as Markdown, so a name like "*Ali*" must not become markup
+		message += "\n\n👋 Salom, " + escapeMarkdown(user.FirstName) + "!"
 	}
 	message += "\n\n/help - barcha buyruqlar ro'yxati"
 
@@ -52,3 +53,8 @@ func (h *StartCommand) Description() string {
 func (h *StartCommand) Usage() string {
 	return "/start - Botni ishga tushirish"
 }
+
+// Category is empty, as chats cannot turn the command off
+func (h *StartCommand) Category() string {
+	return ""
+}
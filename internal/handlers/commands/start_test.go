@@ -56,8 +56,26 @@ func TestStartCommand_Handle(t *testing.T) {
 		t.Errorf("Expected response to contain welcome message '%s'", welcomeMsg)
 	}
 
-	if response.ParseMode != "HTML" {
-		t.Errorf("Expected parse mode 'HTML', got '%s'", response.ParseMode)
+	if response.ParseMode != "Markdown" {
+		t.Errorf("Expected parse mode 'Markdown', got '%s'", response.ParseMode)
+	}
+}
+
+func TestStartCommand_EscapesFirstName(t *testing.T) {
+	startCmd := NewStartCommand("Welcome", &MockLogger{})
+	cmd := &domain.Command{
+		Text: "/start",
+		User: &domain.User{TelegramID: 12345},
+		Chat: &domain.Chat{ID: 12345, Type: "private"},
+	}
+	ctx := domain.WithUser(context.Background(), &domain.User{TelegramID: 12345, FirstName: "*Ali* _co_"})
+
+	response, err := startCmd.Handle(ctx, cmd)
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if !contains(response.Text, "Salom, \\*Ali\\* \\_co\\_!") {
+		t.Errorf("response = %q, want the first name Markdown-escaped", response.Text)
 	}
 }
 
@@ -31,7 +31,7 @@ func NewStatsCommand(userService domain.UserService, db *database.DB, startTime
 // Handle processes the /stats command
 func (h *StatsCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
 	// Get basic user statistics
-	totalUsers, err := h.db.GetUserStats()
+	totalUsers, err := h.db.Analytics().GetUserStats()
 	if err != nil {
 		h.logger.Error("Failed to get user stats", "error", err)
 		return &domain.Response{
@@ -41,14 +41,14 @@ func (h *StatsCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain
 	}
 
 	// Get daily statistics
-	dailyStats, err := h.db.GetDailyStats()
+	dailyStats, err := h.db.Analytics().GetDailyStats()
 	if err != nil {
 		h.logger.Error("Failed to get daily stats", "error", err)
 		dailyStats = make(map[string]int) // Continue with empty stats
 	}
 
 	// Get popular commands
-	popularCommands, err := h.db.GetPopularCommands(5)
+	popularCommands, err := h.db.Analytics().GetPopularCommands(5)
 	if err != nil {
 		h.logger.Error("Failed to get popular commands", "error", err)
 		popularCommands = make(map[string]int)
@@ -106,3 +106,8 @@ func (h *StatsCommand) Description() string {
 func (h *StatsCommand) Usage() string {
 	return "/stats - Bot statistikasini ko'rish"
 }
+
+// Category is empty, as chats cannot turn the command off
+func (h *StatsCommand) Category() string {
+	return ""
+}
@@ -0,0 +1,333 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// Team setting keys of the weekly status report: the report language, and the last week it was sent
+const (
+	StatusReportWeeklyKey = "status_report_weekly"
+	StatusReportLastKey   = "status_report_last"
+)
+
+// statusReportPeriod is how far back a status report looks for finished work
+const statusReportPeriod = 7 * 24 * time.Hour
+
+// statusReportBold matches **bold** Markdown for the email version of a report
+var statusReportBold = regexp.MustCompile(`\*\*(.+?)\*\*`)
+
+// statusReportDraft is the chat's latest status report, kept for exporting
+type statusReportDraft struct {
+	ProjectName string
+	TeamID      string
+	Text        string
+}
+
+// StatusReportCommand writes stakeholder-friendly project status updates
+type StatusReportCommand struct {
+	db            *database.DB
+	calendars     *services.CalendarService
+	reportService *services.StatusReportService
+	bridge        *services.NotificationBridge
+	mailer        *services.Mailer
+	logger        domain.Logger
+	drafts        map[int64]*statusReportDraft
+	mutex         sync.Mutex
+}
+
+// NewStatusReportCommand creates a new status report command handler
+func NewStatusReportCommand(db *database.DB, calendars *services.CalendarService, reportService *services.StatusReportService, bridge *services.NotificationBridge, mailer *services.Mailer, logger domain.Logger) *StatusReportCommand {
+	return &StatusReportCommand{
+		db:            db,
+		calendars:     calendars,
+		reportService: reportService,
+		bridge:        bridge,
+		mailer:        mailer,
+		logger:        logger,
+		drafts:        make(map[int64]*statusReportDraft),
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *StatusReportCommand) CanHandle(command string) bool {
+	return command == "/status_report"
+}
+
+// Description returns the command description
+func (c *StatusReportCommand) Description() string {
+	return "📰 Weekly project status update for stakeholders"
+}
+
+// Usage returns the command usage instructions
+func (c *StatusReportCommand) Usage() string {
+	return "/status_report [project_id] [uz|ru|en] | send | email | weekly uz|ru|en|off - Write, export or schedule a status report"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *StatusReportCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the status_report command
+func (c *StatusReportCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing status_report command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/status_report")))
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "send":
+			return c.sendToBridge(ctx, cmd.Chat.ID), nil
+		case "email":
+			return c.sendEmail(cmd), nil
+		case "weekly":
+			if len(args) != 2 {
+				return errorResponse("Usage: `/status_report weekly uz|ru|en|off`"), nil
+			}
+			return c.setWeekly(teamID, strings.ToLower(args[1])), nil
+		}
+	}
+
+	projectID, language := "", ""
+	for _, arg := range args {
+		if _, ok := services.LanguageNames[strings.ToLower(arg)]; ok && language == "" {
+			language = strings.ToLower(arg)
+			continue
+		}
+		if projectID != "" {
+			return errorResponse("Usage: `/status_report [project_id] [uz|ru|en]`"), nil
+		}
+		projectID = arg
+	}
+	if language == "" {
+		language = c.weeklyLanguage(teamID, cmd.Chat.Language)
+	}
+
+	projects, err := c.db.GetProjectsByChatID(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to get projects", "error", err, "chat_id", cmd.Chat.ID)
+		return errorResponse("Failed to retrieve projects. Please try again."), nil
+	}
+
+	var project *database.Project
+	for i := range projects {
+		if (projectID == "" && projects[i].Status == "active") || projects[i].ID == projectID {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		if projectID != "" {
+			return errorResponse(fmt.Sprintf("Project `%s` not found in this chat.", projectID)), nil
+		}
+		return errorResponse("No active project in this chat. Use `/status_report project_id`."), nil
+	}
+
+	facts, err := BuildStatusFacts(ctx, c.db, c.calendars, *project, time.Now())
+	if err != nil {
+		c.logger.Error("Failed to collect project metrics", "project_id", project.ID, "error", err)
+		return errorResponse("Failed to collect project metrics. Please try again."), nil
+	}
+	if facts.TotalTasks == 0 {
+		return errorResponse(fmt.Sprintf("**%s** has no tasks to report on yet.", project.Name)), nil
+	}
+
+	report, err := c.reportService.Compose(ctx, facts, language)
+	if err != nil {
+		return errorResponse(err.Error()), nil
+	}
+
+	text := FormatStatusReport(project.Name, report)
+	c.mutex.Lock()
+	c.drafts[cmd.Chat.ID] = &statusReportDraft{ProjectName: project.Name, TeamID: project.TeamID, Text: text}
+	c.mutex.Unlock()
+
+	return &domain.Response{
+		Text:      text,
+		ParseMode: "Markdown",
+		ReplyMarkup: &domain.InlineKeyboardMarkup{InlineKeyboard: [][]domain.InlineKeyboardButton{{
+			{Text: "📤 Slack/Discord", CallbackData: "/status_report send"},
+			{Text: "📧 Email", CallbackData: "/status_report email"},
+		}}},
+	}, nil
+}
+
+// sendToBridge posts the chat's latest report to the team's Slack and Discord webhooks
+func (c *StatusReportCommand) sendToBridge(ctx context.Context, chatID int64) *domain.Response {
+	draft := c.draft(chatID)
+	if draft == nil {
+		return errorResponse("No status report yet. Run `/status_report` first.")
+	}
+
+	if err := c.bridge.Send(ctx, draft.TeamID, draft.Text); err != nil {
+		c.logger.Warn("Failed to send status report to bridge", "team_id", draft.TeamID, "error", err)
+		return errorResponse(fmt.Sprintf("Delivery failed: %s. Configure webhooks with `/bridge`.", err))
+	}
+
+	c.logger.Info("Status report sent to bridge", "team_id", draft.TeamID)
+	return &domain.Response{
+		Text:      fmt.Sprintf("📤 Status report of **%s** sent to Slack/Discord", draft.ProjectName),
+		ParseMode: "Markdown",
+	}
+}
+
+// sendEmail emails the chat's latest report to the requesting user's /email address
+func (c *StatusReportCommand) sendEmail(cmd *domain.Command) *domain.Response {
+	draft := c.draft(cmd.Chat.ID)
+	if draft == nil {
+		return errorResponse("No status report yet. Run `/status_report` first.")
+	}
+	if !c.mailer.Enabled() {
+		return errorResponse("Email is not configured on this bot.")
+	}
+
+	sub, err := c.db.GetEmailSubscription(cmd.User.TelegramID)
+	if err != nil {
+		c.logger.Error("Failed to get email subscription", "user_id", cmd.User.TelegramID, "error", err)
+		return errorResponse("Failed to load your email settings. Please try again.")
+	}
+	if sub == nil {
+		return errorResponse("Set your address first with `/email you@example.com`.")
+	}
+
+	err = c.mailer.Send(services.Email{
+		To:      sub.Email,
+		Subject: fmt.Sprintf("Status report: %s", draft.ProjectName),
+		HTML:    statusReportHTML(draft.Text),
+	})
+	if err != nil {
+		c.logger.Error("Failed to email status report", "user_id", cmd.User.TelegramID, "error", err)
+		return errorResponse("Failed to send the email. Please try again.")
+	}
+
+	return &domain.Response{
+		Text:      fmt.Sprintf("📧 Status report of **%s** sent to %s", draft.ProjectName, sub.Email),
+		ParseMode: "Markdown",
+	}
+}
+
+// setWeekly turns the Monday status report on in a language, or off
+func (c *StatusReportCommand) setWeekly(teamID, option string) *domain.Response {
+	value := option
+	if option == "off" {
+		value = ""
+	} else if _, ok := services.LanguageNames[option]; !ok {
+		return errorResponse("Usage: `/status_report weekly uz|ru|en|off`")
+	}
+
+	if err := c.db.SetTeamSetting(teamID, StatusReportWeeklyKey, value); err != nil {
+		c.logger.Error("Failed to save status report setting", "team_id", teamID, "error", err)
+		return errorResponse("Failed to save the setting. Please try again.")
+	}
+
+	text := "🔕 Weekly status reports are off"
+	if value != "" {
+		text = fmt.Sprintf("📰 Weekly status reports are on (%s). Active projects get a report every Monday, "+
+			"mirrored to Slack/Discord when the `%s` bridge event is enabled.", services.LanguageNames[value], services.BridgeEventStatusReport)
+	}
+	return &domain.Response{
+		Text:      text,
+		ParseMode: "Markdown",
+	}
+}
+
+// weeklyLanguage returns the team's weekly report language, then the chat's default
+// language, English when neither is set
+func (c *StatusReportCommand) weeklyLanguage(teamID, chatLanguage string) string {
+	settings, err := c.db.GetTeamSettings(teamID)
+	if err != nil {
+		c.logger.Warn("Failed to load team settings", "team_id", teamID, "error", err)
+	}
+	if language := settings[StatusReportWeeklyKey]; language != "" {
+		return language
+	}
+	if chatLanguage != "" {
+		return chatLanguage
+	}
+	return "en"
+}
+
+// draft returns the chat's latest status report
+func (c *StatusReportCommand) draft(chatID int64) *statusReportDraft {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.drafts[chatID]
+}
+
+// BuildStatusFacts collects a project's metrics for its status report
+func BuildStatusFacts(ctx context.Context, db *database.DB, calendars *services.CalendarService, project database.Project, now time.Time) (services.StatusFacts, error) {
+	health, record, err := EvaluateProjectHealth(ctx, db, calendars, project, now)
+	if err != nil {
+		return services.StatusFacts{}, err
+	}
+	tasks, err := db.GetTasksByProjectID(project.ID)
+	if err != nil {
+		return services.StatusFacts{}, err
+	}
+
+	facts := services.StatusFacts{
+		ProjectName: project.Name,
+		Since:       now.Add(-statusReportPeriod),
+		Health:      health,
+		TotalTasks:  len(tasks),
+	}
+	if record != nil {
+		facts.Deadline = record.Deadline
+	}
+
+	for _, task := range tasks {
+		if task.Status == domain.TaskStatusCompleted {
+			facts.DoneTasks++
+			if task.CompletedAt != nil && !task.CompletedAt.Before(facts.Since) {
+				facts.Completed = append(facts.Completed, task.Title)
+			}
+			continue
+		}
+
+		if left := task.EstimateHours - task.ActualHours; left > 0 {
+			facts.RemainingHours += left
+		}
+		switch task.Status {
+		case domain.TaskStatusInProgress:
+			facts.InProgress = append(facts.InProgress, task.Title)
+		case domain.TaskStatusBlocked:
+			facts.Blocked = append(facts.Blocked, task.Title)
+		default:
+			facts.Upcoming = append(facts.Upcoming, task.Title)
+		}
+	}
+
+	return facts, nil
+}
+
+// FormatStatusReport adds the project header and source note to a report
+func FormatStatusReport(projectName string, report *services.StatusReport) string {
+	source := "📊 Compiled from project metrics"
+	if report.Generated {
+		source = "🤖 AI-written from project metrics"
+	}
+	return fmt.Sprintf("📰 **Status Report: %s**\n\n%s\n\n%s · %s", projectName, report.Text, source, services.LanguageNames[report.Language])
+}
+
+// statusReportHTML converts a report's Telegram Markdown to simple email HTML
+func statusReportHTML(text string) string {
+	body := statusReportBold.ReplaceAllString(html.EscapeString(text), "<b>$1</b>")
+	return `<!DOCTYPE html>
+<html>
+<body style="font-family: -apple-system, 'Segoe UI', Roboto, sans-serif; color: #222; max-width: 640px;">
+<p>` + strings.ReplaceAll(body, "\n", "<br>\n") + `</p>
+</body>
+</html>
+`
+}
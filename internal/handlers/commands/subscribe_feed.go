@@ -0,0 +1,311 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// maxFeedSubscriptions limits the feeds a chat can follow
+const maxFeedSubscriptions = 10
+
+// maxDigestEntries limits the entries listed in one feed digest
+const maxDigestEntries = 5
+
+// SubscribeFeedCommand subscribes a chat to RSS and Atom feeds such as the Go blog or the
+// release feed of a dependency; new entries are posted by the feed poll job
+type SubscribeFeedCommand struct {
+	db     *database.DB
+	feeds  *services.FeedReader
+	logger domain.Logger
+}
+
+// NewSubscribeFeedCommand creates a new feed subscription command handler
+func NewSubscribeFeedCommand(db *database.DB, feeds *services.FeedReader, logger domain.Logger) *SubscribeFeedCommand {
+	return &SubscribeFeedCommand{
+		db:     db,
+		feeds:  feeds,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *SubscribeFeedCommand) CanHandle(command string) bool {
+	return command == "/subscribe_feed"
+}
+
+// Description returns the command description
+func (c *SubscribeFeedCommand) Description() string {
+	return "📡 Follow RSS/Atom feeds and changelogs"
+}
+
+// Usage returns the command usage instructions
+func (c *SubscribeFeedCommand) Usage() string {
+	return "/subscribe_feed [url | list | remove ID] - Post new feed entries to the chat"
+}
+
+// Category puts the command in the dev category a chat can turn off
+func (c *SubscribeFeedCommand) Category() string {
+	return services.CategoryDev
+}
+
+// ChatAction implements domain.LongRunningHandler: subscribing downloads the feed
+func (c *SubscribeFeedCommand) ChatAction(cmd *domain.Command) string {
+	if !strings.Contains(cmd.Text, "://") {
+		return ""
+	}
+	return domain.ChatActionTyping
+}
+
+// Handle processes the subscribe_feed command
+func (c *SubscribeFeedCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing subscribe_feed command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimPrefix(cmd.Text, "/subscribe_feed"))
+	if len(args) == 0 || strings.ToLower(args[0]) == "list" {
+		return c.listFeeds(cmd.Chat.ID), nil
+	}
+
+	if strings.ToLower(args[0]) == "remove" {
+		if len(args) < 2 {
+			return errorResponse("Usage: `/subscribe_feed remove ID`"), nil
+		}
+		id, err := strconv.ParseInt(strings.TrimPrefix(args[1], "#"), 10, 64)
+		if err != nil {
+			return errorResponse("ID must be a number, see `/subscribe_feed list`"), nil
+		}
+		return c.removeFeed(cmd.Chat.ID, id), nil
+	}
+
+	return c.subscribe(ctx, cmd, args[0]), nil
+}
+
+// subscribe validates a feed by downloading it and subscribes the chat. The entries already
+// in the feed are marked as seen so only later ones are posted.
+func (c *SubscribeFeedCommand) subscribe(ctx context.Context, cmd *domain.Command, rawURL string) *domain.Response {
+	feedURL, err := services.NormalizeFeedURL(rawURL)
+	if err != nil {
+		return errorResponse("Send the address of an RSS or Atom feed, e.g. `/subscribe_feed https://go.dev/blog/feed.atom`")
+	}
+
+	subscriptions, err := c.db.GetFeedSubscriptions(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to load feed subscriptions", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to subscribe. Please try again.")
+	}
+	if len(subscriptions) >= maxFeedSubscriptions {
+		return errorResponse(fmt.Sprintf("This chat already follows %d feeds. Remove some first.", maxFeedSubscriptions))
+	}
+
+	result, err := c.feeds.Fetch(ctx, feedURL, "", "")
+	if err != nil || result.Feed == nil {
+		c.logger.Warn("Failed to fetch feed", "chat_id", cmd.Chat.ID, "url", feedURL, "error", err)
+		return errorResponse(fmt.Sprintf("Could not read the feed: %s.", feedFetchError(err)))
+	}
+
+	subscription := &database.FeedSubscription{
+		ChatID:    cmd.Chat.ID,
+		URL:       feedURL,
+		Title:     result.Feed.Title,
+		CreatedBy: cmd.User.TelegramID,
+	}
+	added, err := c.db.AddFeedSubscription(subscription)
+	if err != nil {
+		c.logger.Error("Failed to save feed subscription", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to subscribe. Please try again.")
+	}
+	if !added {
+		return errorResponse("This chat already follows that feed, see `/subscribe_feed list`")
+	}
+
+	if _, err := storeFeedResult(c.db, subscription, result); err != nil {
+		c.logger.Error("Failed to store feed entries", "id", subscription.ID, "error", err)
+	}
+
+	c.logger.Info("Feed subscribed", "id", subscription.ID, "chat_id", cmd.Chat.ID, "url", feedURL)
+
+	latest := "no entries yet"
+	if len(result.Feed.Entries) > 0 {
+		latest = feedEntryLink(result.Feed.Entries[0])
+	}
+	return &domain.Response{
+		Text: fmt.Sprintf("✅ **Subscribed to %s** (#%d)\n\n"+
+			"├── Entries: %d\n"+
+			"└── Latest: %s\n\n"+
+			"New entries are posted here as they appear. Remove with `/subscribe_feed remove %d`",
+			escapeMarkdown(feedTitle(subscription)), subscription.ID, len(result.Feed.Entries), latest, subscription.ID),
+		ParseMode:      "Markdown",
+		DisablePreview: true,
+	}
+}
+
+// listFeeds shows the feeds the chat follows
+func (c *SubscribeFeedCommand) listFeeds(chatID int64) *domain.Response {
+	subscriptions, err := c.db.GetFeedSubscriptions(chatID)
+	if err != nil {
+		c.logger.Error("Failed to list feed subscriptions", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to load feeds. Please try again.")
+	}
+
+	var response strings.Builder
+	response.WriteString("📡 **Feed Subscriptions**\n\n")
+
+	if len(subscriptions) == 0 {
+		response.WriteString("This chat follows no feeds.\n\n")
+		response.WriteString("Example: `/subscribe_feed https://go.dev/blog/feed.atom`\n")
+		response.WriteString("GitHub releases: `https://github.com/owner/repo/releases.atom`")
+	} else {
+		for _, subscription := range subscriptions {
+			checked := "not checked yet"
+			if !subscription.CheckedAt.IsZero() {
+				checked = "checked " + formatUntil(time.Since(subscription.CheckedAt)) + " ago"
+			}
+			response.WriteString(fmt.Sprintf("**#%d** - %s\n├── %s\n└── %s\n\n",
+				subscription.ID, escapeMarkdown(feedTitle(&subscription)), escapeMarkdown(subscription.URL), checked))
+		}
+		response.WriteString("Remove with `/subscribe_feed remove ID`")
+	}
+
+	return &domain.Response{
+		Text:           response.String(),
+		ParseMode:      "Markdown",
+		DisablePreview: true,
+	}
+}
+
+// removeFeed unsubscribes the chat from a feed
+func (c *SubscribeFeedCommand) removeFeed(chatID, id int64) *domain.Response {
+	removed, err := c.db.DeleteFeedSubscription(chatID, id)
+	if err != nil {
+		c.logger.Error("Failed to remove feed subscription", "chat_id", chatID, "id", id, "error", err)
+		return errorResponse("Failed to remove the feed. Please try again.")
+	}
+	if !removed {
+		return errorResponse(fmt.Sprintf("No feed #%d in this chat", id))
+	}
+
+	c.logger.Info("Feed unsubscribed", "chat_id", chatID, "id", id)
+
+	return &domain.Response{
+		Text:      fmt.Sprintf("🗑️ Feed #%d removed", id),
+		ParseMode: "Markdown",
+	}
+}
+
+// PollFeed downloads a subscribed feed, sending the validators of the last response, and
+// returns its entries that were not seen before. The subscription's title, validators and
+// check time are saved.
+func PollFeed(ctx context.Context, db *database.DB, feeds *services.FeedReader, subscription *database.FeedSubscription) ([]services.FeedEntry, error) {
+	result, err := feeds.Fetch(ctx, subscription.URL, subscription.ETag, subscription.LastModified)
+	if err != nil {
+		// Count a failed check so a broken feed waits for the next interval
+		subscription.CheckedAt = time.Now()
+		if updateErr := db.UpdateFeedSubscription(subscription); updateErr != nil {
+			return nil, updateErr
+		}
+		return nil, err
+	}
+	return storeFeedResult(db, subscription, result)
+}
+
+// storeFeedResult saves a download's validators, marks its entries as seen and returns the
+// ones that are new
+func storeFeedResult(db *database.DB, subscription *database.FeedSubscription, result *services.FeedResult) ([]services.FeedEntry, error) {
+	subscription.ETag = result.ETag
+	subscription.LastModified = result.LastModified
+	subscription.CheckedAt = time.Now()
+
+	var fresh []services.FeedEntry
+	if result.Feed != nil {
+		if result.Feed.Title != "" {
+			subscription.Title = result.Feed.Title
+		}
+
+		ids := make([]string, 0, len(result.Feed.Entries))
+		for _, entry := range result.Feed.Entries {
+			ids = append(ids, entry.ID)
+		}
+		unseen, err := db.MarkFeedEntriesSeen(subscription.ID, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		isNew := make(map[string]bool, len(unseen))
+		for _, id := range unseen {
+			isNew[id] = true
+		}
+		for _, entry := range result.Feed.Entries {
+			if isNew[entry.ID] {
+				fresh = append(fresh, entry)
+				// An entry repeated in the document is posted once
+				delete(isNew, entry.ID)
+			}
+		}
+	}
+
+	return fresh, db.UpdateFeedSubscription(subscription)
+}
+
+// FormatFeedDigest formats the new entries of a feed as one compact message
+func FormatFeedDigest(subscription *database.FeedSubscription, entries []services.FeedEntry) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📡 **%s** · %d new\n\n", escapeMarkdown(feedTitle(subscription)), len(entries)))
+
+	shown := entries
+	if len(shown) > maxDigestEntries {
+		shown = shown[:maxDigestEntries]
+	}
+	for i, entry := range shown {
+		branch := "├──"
+		if i == len(shown)-1 && len(entries) == len(shown) {
+			branch = "└──"
+		}
+		line := feedEntryLink(entry)
+		if !entry.Published.IsZero() {
+			line += " · " + entry.Published.Format("Jan 2")
+		}
+		text.WriteString(branch + " " + line + "\n")
+	}
+	if more := len(entries) - len(shown); more > 0 {
+		text.WriteString(fmt.Sprintf("└── and %d more\n", more))
+	}
+
+	return strings.TrimRight(text.String(), "\n")
+}
+
+// feedTitle returns the title of a subscribed feed, or its address when the feed has none
+func feedTitle(subscription *database.FeedSubscription) string {
+	if subscription.Title != "" {
+		return subscription.Title
+	}
+	return subscription.URL
+}
+
+// feedLinkText keeps entry titles from closing a Markdown link early
+var feedLinkText = strings.NewReplacer("[", "(", "]", ")")
+
+// feedEntryLink formats an entry as a Markdown link, or its escaped title without a link
+func feedEntryLink(entry services.FeedEntry) string {
+	title := entry.Title
+	if len([]rune(title)) > 80 {
+		title = string([]rune(title)[:77]) + "..."
+	}
+	if entry.Link == "" {
+		return escapeMarkdown(title)
+	}
+	return fmt.Sprintf("[%s](%s)", feedLinkText.Replace(title), strings.ReplaceAll(entry.Link, ")", "%29"))
+}
+
+// feedFetchError describes why a feed could not be read
+func feedFetchError(err error) string {
+	if err == nil {
+		return "the server did not return the feed"
+	}
+	return err.Error()
+}
@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// defaultSummaryMessages is the number of messages summarized when N is omitted
+const defaultSummaryMessages = 50
+
+// SummarizeCommand summarizes recent chat discussion and turns action items into tasks
+type SummarizeCommand struct {
+	history        *services.ChatHistoryService
+	summaryService *services.SummaryService
+	db             *database.DB
+	webhooks       *services.ProjectWebhooks
+	actionItems    map[int64][]string
+	mutex          sync.Mutex
+	logger         domain.Logger
+}
+
+// NewSummarizeCommand creates a new summarize command handler
+func NewSummarizeCommand(history *services.ChatHistoryService, summaryService *services.SummaryService, db *database.DB, webhooks *services.ProjectWebhooks, logger domain.Logger) *SummarizeCommand {
+	return &SummarizeCommand{
+		history:        history,
+		summaryService: summaryService,
+		db:             db,
+		webhooks:       webhooks,
+		actionItems:    make(map[int64][]string),
+		logger:         logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *SummarizeCommand) CanHandle(command string) bool {
+	return command == "/summarize"
+}
+
+// Description returns the command description
+func (c *SummarizeCommand) Description() string {
+	return "📝 Summarize recent chat discussion with decisions and action items"
+}
+
+// Usage returns the command usage instructions
+func (c *SummarizeCommand) Usage() string {
+	return "/summarize [N | on | off | tasks] - AI summary of the last N messages"
+}
+
+// Category puts the command in the ai category a chat can turn off
+func (c *SummarizeCommand) Category() string {
+	return services.CategoryAI
+}
+
+// ChatAction implements domain.LongRunningHandler: summaries come from the AI chain
+func (c *SummarizeCommand) ChatAction(cmd *domain.Command) string {
+	switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/summarize"))) {
+	case "on", "off":
+		return ""
+	}
+	return domain.ChatActionTyping
+}
+
+// Handle processes the summarize command
+func (c *SummarizeCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing summarize command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/summarize")))
+	count := defaultSummaryMessages
+
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "on", "enable":
+			return c.setHistory(cmd.Chat.ID, true)
+		case "off", "disable":
+			return c.setHistory(cmd.Chat.ID, false)
+		case "tasks":
+			return c.createTasks(cmd.Chat.ID)
+		default:
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n <= 0 {
+				return errorResponse("N must be a positive number, e.g. `/summarize 30`"), nil
+			}
+			count = n
+		}
+	}
+
+	settings := c.history.GetSettings(cmd.Chat.ID)
+	if !settings.Enabled {
+		return &domain.Response{
+			Text: "📝 **Chat Summaries**\n\n" +
+				"Message history is off for this chat, so there is nothing to summarize.\n\n" +
+				"Turn it on with `/summarize on` and see `/privacy` for what is stored and how to purge it.\n\n" +
+				"💡 In groups the bot must be allowed to read messages (disable privacy mode in @BotFather).",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	if count > settings.Size {
+		count = settings.Size
+	}
+
+	messages := c.history.Recent(cmd.Chat.ID, count)
+	if len(messages) == 0 {
+		return errorResponse("No messages recorded yet. Summaries cover messages sent after history was turned on."), nil
+	}
+
+	if !c.summaryService.IsConfigured() {
+		return errorResponse("Summaries need an AI provider. Set `CLAUDE_API_KEY`, `OPENAI_API_KEY` or `GEMINI_API_KEY`."), nil
+	}
+
+	summary, err := c.summaryService.Summarize(ctx, messages)
+	if err != nil {
+		c.logger.Error("Failed to summarize chat", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to generate summary. Please try again later."), nil
+	}
+
+	c.mutex.Lock()
+	c.actionItems[cmd.Chat.ID] = summary.ActionItems
+	c.mutex.Unlock()
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("📝 **Discussion Summary** (last %d messages)\n\n", len(messages)))
+	response.WriteString(summary.Text)
+	if len(summary.ActionItems) > 0 {
+		response.WriteString(fmt.Sprintf("\n\n💡 Create %d tasks from the action items with `/summarize tasks`", len(summary.ActionItems)))
+	}
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// setHistory turns message history on or off for the chat
+func (c *SummarizeCommand) setHistory(chatID int64, enabled bool) (*domain.Response, error) {
+	if err := c.history.SetEnabled(chatID, enabled); err != nil {
+		c.logger.Error("Failed to change chat history setting", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to update setting. Please try again."), nil
+	}
+
+	if enabled {
+		settings := c.history.GetSettings(chatID)
+		return &domain.Response{
+			Text: fmt.Sprintf("✅ **Message history enabled**\n\n"+
+				"The bot keeps the last %d messages of this chat for up to %dh, in memory only.\n"+
+				"Configure or purge it with `/privacy`, turn off with `/summarize off`.",
+				settings.Size, int(settings.Retention.Hours())),
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	return &domain.Response{
+		Text:      "🔒 **Message history disabled**\n\nStored messages of this chat were cleared.",
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// createTasks creates tasks from the action items of the last summary
+func (c *SummarizeCommand) createTasks(chatID int64) (*domain.Response, error) {
+	c.mutex.Lock()
+	items := c.actionItems[chatID]
+	c.mutex.Unlock()
+
+	if len(items) == 0 {
+		return errorResponse("No action items to convert. Run `/summarize` first."), nil
+	}
+
+	projects, err := c.db.GetProjectsByChatID(chatID)
+	if err != nil {
+		c.logger.Error("Failed to load projects", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to load projects. Please try again."), nil
+	}
+	if len(projects) == 0 {
+		return errorResponse("No project in this chat yet. Create one with `/create_project name`."), nil
+	}
+	project := projects[0]
+
+	created := 0
+	for i, item := range items {
+		task := &database.Task{
+			ID:          fmt.Sprintf("task_%d_%d", time.Now().UnixNano(), i),
+			ProjectID:   project.ID,
+			Title:       item,
+			Description: "Action item from chat summary",
+			Category:    "general",
+			Status:      "todo",
+			Priority:    2,
+		}
+		if err := c.db.CreateTask(task); err != nil {
+			c.logger.Error("Failed to create task from action item", "chat_id", chatID, "error", err)
+			continue
+		}
+		c.webhooks.TaskCreated(ToDomainTask(*task))
+		created++
+	}
+
+	c.mutex.Lock()
+	delete(c.actionItems, chatID)
+	c.mutex.Unlock()
+
+	c.logger.Info("Tasks created from chat summary", "chat_id", chatID, "project_id", project.ID, "count", created)
+
+	return &domain.Response{
+		Text:      fmt.Sprintf("✅ Created %d tasks in **%s** from the summary action items", created, project.Name),
+		ParseMode: "Markdown",
+	}, nil
+}
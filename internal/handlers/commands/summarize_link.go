@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// SummarizeLinkCommand summarizes a shared article in three bullets with its reading time,
+// and manages the chat's automatic summaries and domain lists
+type SummarizeLinkCommand struct {
+	links  *services.LinkSummaryService
+	logger domain.Logger
+}
+
+// NewSummarizeLinkCommand creates a new link summary command handler
+func NewSummarizeLinkCommand(links *services.LinkSummaryService, logger domain.Logger) *SummarizeLinkCommand {
+	return &SummarizeLinkCommand{
+		links:  links,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *SummarizeLinkCommand) CanHandle(command string) bool {
+	return command == "/summarize_link"
+}
+
+// Description returns the command description
+func (c *SummarizeLinkCommand) Description() string {
+	return "📰 Summarize a shared article in three bullets"
+}
+
+// Usage returns the command usage instructions
+func (c *SummarizeLinkCommand) Usage() string {
+	return "/summarize_link url | auto on|off | allow domain | deny domain | remove domain - Article summaries"
+}
+
+// Category puts the command in the ai category a chat can turn off
+func (c *SummarizeLinkCommand) Category() string {
+	return services.CategoryAI
+}
+
+// ChatAction implements domain.LongRunningHandler: the page is fetched and summarized by AI
+func (c *SummarizeLinkCommand) ChatAction(cmd *domain.Command) string {
+	if services.FindLink(cmd.Text) == "" && services.FindLink(cmd.ReplyToText) == "" {
+		return ""
+	}
+	return domain.ChatActionTyping
+}
+
+// Handle processes the summarize_link command
+func (c *SummarizeLinkCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing summarize_link command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimPrefix(cmd.Text, "/summarize_link"))
+	if len(args) == 2 {
+		switch strings.ToLower(args[0]) {
+		case "auto":
+			return c.setAuto(cmd.Chat.ID, strings.ToLower(args[1])), nil
+		case "allow", "deny":
+			if err := c.links.AddDomain(cmd.Chat.ID, args[1], strings.ToLower(args[0]) == "allow"); err != nil {
+				c.logger.Warn("Failed to add link domain", "chat_id", cmd.Chat.ID, "error", err)
+				return errorResponse("Failed to update the list. Use a domain such as `go.dev`."), nil
+			}
+			return c.settingsResponse(cmd.Chat.ID, "✅ Domain lists updated"), nil
+		case "remove":
+			if err := c.links.RemoveDomain(cmd.Chat.ID, args[1]); err != nil {
+				c.logger.Error("Failed to remove link domain", "chat_id", cmd.Chat.ID, "error", err)
+				return errorResponse("Failed to update the list. Please try again."), nil
+			}
+			return c.settingsResponse(cmd.Chat.ID, "✅ Domain lists updated"), nil
+		}
+	}
+
+	link := services.FindLink(cmd.Text)
+	if link == "" {
+		link = services.FindLink(cmd.ReplyToText)
+	}
+	if link == "" {
+		return c.settingsResponse(cmd.Chat.ID, "📰 **Link Summaries**"), nil
+	}
+	if !c.links.IsConfigured() {
+		return errorResponse("Link summaries need an AI provider. Set `CLAUDE_API_KEY`, `OPENAI_API_KEY` or `GEMINI_API_KEY`."), nil
+	}
+
+	summary, err := c.links.Summarize(ctx, cmd.Chat.ID, link)
+	if err != nil {
+		var limitErr *services.ChatLimitError
+		if errors.As(err, &limitErr) {
+			return &domain.Response{Text: "⚠️ " + limitErr.Error() + ".", ParseMode: "Markdown"}, nil
+		}
+		c.logger.Warn("Failed to summarize link", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse(fmt.Sprintf("Could not summarize the link: %s.", err)), nil
+	}
+
+	host := summary.URL
+	if parsed, err := url.Parse(summary.URL); err == nil {
+		host = strings.TrimPrefix(parsed.Hostname(), "www.")
+	}
+	title := summary.Title
+	if title == "" {
+		title = host
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📰 **%s**\n\n", escapeMarkdown(title)))
+	for _, bullet := range summary.Bullets {
+		text.WriteString("• " + bullet + "\n")
+	}
+	text.WriteString(fmt.Sprintf("\n⏱ %d min read · [%s](%s)", summary.ReadingMinutes, host, summary.URL))
+	return &domain.Response{Text: text.String(), ParseMode: "Markdown", DisablePreview: true}, nil
+}
+
+// setAuto turns automatic summaries of shared links on or off
+func (c *SummarizeLinkCommand) setAuto(chatID int64, value string) *domain.Response {
+	if value != "on" && value != "off" {
+		return errorResponse("Usage: `/summarize_link auto on|off`")
+	}
+	if err := c.links.SetAuto(chatID, value == "on"); err != nil {
+		c.logger.Error("Failed to update automatic link summaries", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to save the setting. Please try again.")
+	}
+	if value == "off" {
+		return &domain.Response{Text: "✅ Automatic link summaries disabled", ParseMode: "Markdown"}
+	}
+	return &domain.Response{
+		Text: "✅ Automatic link summaries enabled. Links shared here are summarized without a command.\n\n" +
+			"💡 In groups the bot must be allowed to read messages (disable privacy mode in @BotFather).",
+		ParseMode: "Markdown",
+	}
+}
+
+// settingsResponse shows the chat's link options and how to use the command
+func (c *SummarizeLinkCommand) settingsResponse(chatID int64, title string) *domain.Response {
+	settings, err := c.links.Settings(chatID)
+	if err != nil {
+		c.logger.Error("Failed to load link settings", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to load the settings. Please try again.")
+	}
+
+	auto := "off"
+	if settings.Auto {
+		auto = "on"
+	}
+	list := func(domains []string) string {
+		if len(domains) == 0 {
+			return "none"
+		}
+		return strings.Join(domains, ", ")
+	}
+
+	return &domain.Response{
+		Text: fmt.Sprintf("%s\n\n"+
+			"├── Automatic: %s\n"+
+			"├── Allowed: %s\n"+
+			"└── Denied: %s\n\n"+
+			"`/summarize_link url` or reply to a link with `/summarize_link`\n"+
+			"`/summarize_link auto on|off` - Summarize shared links without a command\n"+
+			"`/summarize_link allow|deny|remove domain` - Only allowed domains are summarized when the allow list is set",
+			title, auto, escapeMarkdown(list(settings.Allow)), escapeMarkdown(list(settings.Deny))),
+		ParseMode: "Markdown",
+	}
+}
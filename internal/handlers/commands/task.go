@@ -0,0 +1,545 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// forceFlag overrides the dependency check on status changes
+const forceFlag = "--force"
+
+// taskCommitLimit is the number of linked commits shown with a task
+const taskCommitLimit = 5
+
+// taskCommentLimit is the number of latest comments shown with a task
+const taskCommentLimit = 3
+
+// taskActions maps /task actions to task statuses
+var taskActions = map[string]string{
+	"start": domain.TaskStatusInProgress,
+	"done":  domain.TaskStatusCompleted,
+	"todo":  domain.TaskStatusTodo,
+	"block": domain.TaskStatusBlocked,
+}
+
+// TaskCommand shows a task and changes its status, respecting dependencies
+type TaskCommand struct {
+	db       *database.DB
+	webhooks *services.ProjectWebhooks
+	logger   domain.Logger
+}
+
+// NewTaskCommand creates a new task command handler
+func NewTaskCommand(db *database.DB, webhooks *services.ProjectWebhooks, logger domain.Logger) *TaskCommand {
+	return &TaskCommand{
+		db:       db,
+		webhooks: webhooks,
+		logger:   logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *TaskCommand) CanHandle(command string) bool {
+	return command == "/task"
+}
+
+// Description returns the command description
+func (c *TaskCommand) Description() string {
+	return "✅ Show a task or change its status"
+}
+
+// Usage returns the command usage instructions
+func (c *TaskCommand) Usage() string {
+	return "/task [show] task_id|TASK-12 [start | done | todo | block | log 2h | estimate 6h | due YYYY-MM-DD | comment text] [--force] - Task status, time, estimate, due date and comments"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *TaskCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the task command
+func (c *TaskCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing task command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/task")))
+	force := false
+	filtered := args[:0]
+	for _, arg := range args {
+		if arg == forceFlag {
+			force = true
+			continue
+		}
+		filtered = append(filtered, arg)
+	}
+	args = filtered
+
+	// "/task show id" is the same as "/task id"
+	if len(args) > 1 && strings.EqualFold(args[0], "show") {
+		args = args[1:]
+	}
+
+	if len(args) == 0 {
+		return errorResponse("Usage: `/task task_id [start | done | todo | block | log 2h | estimate 6h | due YYYY-MM-DD | comment text] [--force]`"), nil
+	}
+
+	task, projectTasks, teamChatID, err := c.loadTask(cmd, args[0])
+	if err != nil {
+		c.logger.Error("Failed to load task", "task_id", args[0], "error", err)
+		return errorResponse("Failed to load the task. Please try again."), nil
+	}
+	if task == nil {
+		return errorResponse(fmt.Sprintf("Task `%s` not found in this chat's projects or your tasks.", args[0])), nil
+	}
+
+	members := c.memberNames(teamChatID)
+
+	if len(args) == 1 {
+		return c.taskResponse(*task, projectTasks, members, ""), nil
+	}
+
+	if strings.EqualFold(args[1], "log") {
+		return c.logTime(*task, projectTasks, members, args[2:]), nil
+	}
+	if strings.EqualFold(args[1], "estimate") {
+		return c.setEstimate(*task, projectTasks, members, args[2:]), nil
+	}
+	if strings.EqualFold(args[1], "due") {
+		return c.setDueDate(*task, projectTasks, members, args[2:]), nil
+	}
+	if strings.EqualFold(args[1], "comment") {
+		return c.addComment(cmd, *task), nil
+	}
+
+	status, ok := taskActions[strings.ToLower(args[1])]
+	if !ok {
+		return errorResponse("Action must be `start`, `done`, `todo`, `block`, `log 2h`, `estimate 6h`, `due YYYY-MM-DD` or `comment text`."), nil
+	}
+	if task.Status == status {
+		return c.taskResponse(*task, projectTasks, members, fmt.Sprintf("ℹ️ Task is already %s", formatTaskStatus(status))), nil
+	}
+
+	pending := domain.PendingDependencies(*task, projectTasks)
+	if domain.RequiresDependencies(status) && len(pending) > 0 {
+		if !force {
+			var response strings.Builder
+			response.WriteString(fmt.Sprintf("⛔ **%s** has unfinished dependencies:\n", task.Title))
+			for _, dependency := range pending {
+				response.WriteString(fmt.Sprintf("• `%s` %s - %s\n", dependency.ID, dependency.Title, formatTaskStatus(dependency.Status)))
+			}
+			response.WriteString(fmt.Sprintf("\nFinish them first, or override with `/task %s %s %s`.", task.ID, args[1], forceFlag))
+			return &domain.Response{
+				Text:      response.String(),
+				ParseMode: "Markdown",
+			}, nil
+		}
+
+		if err := c.db.LogAudit(&database.AuditEntry{
+			ChatID:  cmd.Chat.ID,
+			Actor:   auditActor(cmd.User),
+			Action:  database.AuditTaskDependencyOverride,
+			Subject: task.ID,
+			Details: fmt.Sprintf("%s -> %s with %d pending dependencies", task.Title, status, len(pending)),
+		}); err != nil {
+			c.logger.Error("Failed to write audit entry", "task_id", task.ID, "error", err)
+		}
+	}
+
+	if err := c.db.UpdateTaskStatus(task.ID, status); err != nil {
+		c.logger.Error("Failed to update task status", "task_id", task.ID, "error", err)
+		return errorResponse("Failed to update the task. Please try again."), nil
+	}
+	c.logger.Info("Task status changed", "task_id", task.ID, "from", task.Status, "to", status, "forced", force)
+
+	task.Status = status
+	if status == domain.TaskStatusCompleted {
+		c.webhooks.TaskCompleted(*task)
+	}
+	for i := range projectTasks {
+		if projectTasks[i].ID == task.ID {
+			projectTasks[i].Status = status
+		}
+	}
+
+	notice := fmt.Sprintf("✅ Moved to %s", formatTaskStatus(status))
+	if force && len(pending) > 0 {
+		notice += " (dependency check overridden)"
+	}
+
+	response := c.taskResponse(*task, projectTasks, members, notice)
+	if status == domain.TaskStatusCompleted {
+		response.Text += formatUnblocked(domain.NewlyUnblocked(task.ID, projectTasks), members)
+	}
+	return response, nil
+}
+
+// loadTask finds a task of the chat's team, or one assigned to the caller in any team,
+// together with all tasks of its project and the chat of its team
+func (c *TaskCommand) loadTask(cmd *domain.Command, taskID string) (*domain.Task, []domain.Task, int64, error) {
+	// Tasks of the chat's team can also be given by key, e.g. TASK-12
+	if !strings.HasPrefix(taskID, "task_") {
+		teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+		settings, err := c.db.GetTeamSettings(teamID)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if number, ok := domain.ParseTaskKey(taskID, TaskKeyPrefix(settings)); ok {
+			if taskID, err = c.db.FindTaskByKey(teamID, number); err != nil || taskID == "" {
+				return nil, nil, 0, err
+			}
+		}
+	}
+
+	location, err := c.db.GetTaskLocation(taskID)
+	if err != nil || location == nil {
+		return nil, nil, 0, err
+	}
+	if location.ChatID != cmd.Chat.ID && location.AssigneeUserID != cmd.User.TelegramID {
+		return nil, nil, 0, nil
+	}
+
+	tasks, err := c.db.GetTasksByProjectID(location.ProjectID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	projectTasks := ToDomainTasks(tasks)
+	for i := range projectTasks {
+		if projectTasks[i].ID == taskID {
+			task := projectTasks[i]
+			return &task, projectTasks, location.ChatID, nil
+		}
+	}
+	return nil, nil, 0, nil
+}
+
+// logTime adds spent time to a task
+func (c *TaskCommand) logTime(task domain.Task, projectTasks []domain.Task, members map[string]string, args []string) *domain.Response {
+	if len(args) == 0 {
+		return errorResponse(fmt.Sprintf("Usage: `/task %s log 2h` or `/task %s log 30m`", task.ID, task.ID))
+	}
+
+	duration, err := domain.ParseDuration(args[0])
+	if err != nil {
+		return errorResponse(err.Error())
+	}
+	if duration > 24*time.Hour {
+		return errorResponse("Log at most 24h at a time")
+	}
+
+	hours := duration.Hours()
+	if err := c.db.AddTaskActualHours(task.ID, hours); err != nil {
+		c.logger.Error("Failed to log task time", "task_id", task.ID, "error", err)
+		return errorResponse("Failed to log time. Please try again.")
+	}
+	c.logger.Info("Task time logged", "task_id", task.ID, "hours", hours)
+
+	task.ActualHours += hours
+	return c.taskResponse(task, projectTasks, members, fmt.Sprintf("⏱️ Logged %.1fh", hours))
+}
+
+// setEstimate replaces the most likely estimate of a task; the range falls back to the default spread
+func (c *TaskCommand) setEstimate(task domain.Task, projectTasks []domain.Task, members map[string]string, args []string) *domain.Response {
+	if len(args) == 0 {
+		return errorResponse(fmt.Sprintf("Usage: `/task %s estimate 6h`", task.ID))
+	}
+
+	duration, err := domain.ParseDuration(args[0])
+	if err != nil {
+		return errorResponse(err.Error())
+	}
+	if duration > 200*time.Hour {
+		return errorResponse("Estimate at most 200h per task")
+	}
+
+	hours := duration.Hours()
+	if err := c.db.UpdateTaskEstimate(task.ID, hours); err != nil {
+		c.logger.Error("Failed to update task estimate", "task_id", task.ID, "error", err)
+		return errorResponse("Failed to update the estimate. Please try again.")
+	}
+	c.logger.Info("Task estimate changed", "task_id", task.ID, "from", task.EstimateHours, "to", hours)
+
+	previous := task.EstimateHours
+	task.EstimateHours, task.OptimisticHours, task.PessimisticHours = hours, 0, 0
+	return c.taskResponse(task, projectTasks, members, fmt.Sprintf("✏️ Estimate %sh → %sh", domain.FormatHours(previous), domain.FormatHours(hours)))
+}
+
+// setDueDate plans a task for a date, or clears its due date with "clear"
+func (c *TaskCommand) setDueDate(task domain.Task, projectTasks []domain.Task, members map[string]string, args []string) *domain.Response {
+	if len(args) == 0 {
+		return errorResponse(fmt.Sprintf("Usage: `/task %s due 2026-11-20` or `/task %s due clear`", task.ID, task.ID))
+	}
+
+	if strings.EqualFold(args[0], "clear") {
+		if err := c.db.ClearTaskDueDate(task.ID); err != nil {
+			c.logger.Error("Failed to clear task due date", "task_id", task.ID, "error", err)
+			return errorResponse("Failed to clear the due date. Please try again.")
+		}
+		task.DueDate = nil
+		return c.taskResponse(task, projectTasks, members, "🗓️ Due date cleared")
+	}
+
+	due, err := time.Parse("2006-01-02", args[0])
+	if err != nil {
+		return errorResponse("Due date must look like `2026-11-20`")
+	}
+	if err := c.db.SetTaskDueDate(task.ID, due); err != nil {
+		c.logger.Error("Failed to set task due date", "task_id", task.ID, "error", err)
+		return errorResponse("Failed to set the due date. Please try again.")
+	}
+	c.logger.Info("Task due date set", "task_id", task.ID, "due", args[0])
+
+	task.DueDate = &due
+	return c.taskResponse(task, projectTasks, members, fmt.Sprintf("🗓️ Due %s", due.Format("Jan 2 2006")))
+}
+
+// addComment links a comment to a task. Replies to a task card arrive here as
+// "/task task_id comment text"; the answer is threaded under the comment.
+func (c *TaskCommand) addComment(cmd *domain.Command, task domain.Task) *domain.Response {
+	text := ""
+	if i := strings.Index(strings.ToLower(cmd.Text), " comment"); i >= 0 {
+		text = strings.TrimSpace(cmd.Text[i+len(" comment"):])
+	}
+	if text == "" {
+		return errorResponse(fmt.Sprintf("Usage: `/task %s comment text`, or reply to the task card", task.ID))
+	}
+
+	comment := &database.TaskComment{
+		TaskID:    task.ID,
+		ChatID:    cmd.Chat.ID,
+		UserID:    cmd.User.TelegramID,
+		Author:    auditActor(cmd.User),
+		Text:      text,
+		MessageID: cmd.MessageID,
+	}
+	if err := c.db.AddTaskComment(comment); err != nil {
+		c.logger.Error("Failed to save task comment", "task_id", task.ID, "error", err)
+		return errorResponse("Failed to save the comment. Please try again.")
+	}
+	c.logger.Info("Task comment added", "task_id", task.ID, "comment_id", comment.ID, "chat_id", cmd.Chat.ID)
+
+	id := "`" + task.ID + "`"
+	if key := c.taskKey(task.ID); key != "" {
+		id = "`" + key + "`"
+	}
+	count, err := c.db.CountTaskComments(task.ID)
+	if err != nil {
+		c.logger.Warn("Failed to count task comments", "task_id", task.ID, "error", err)
+	}
+	return &domain.Response{
+		Text:             fmt.Sprintf("💬 Comment added to **%s** (%s) · %d in total", task.Title, id, count),
+		ParseMode:        "Markdown",
+		ReplyToMessageID: cmd.MessageID,
+	}
+}
+
+// memberNames maps team member IDs to usernames
+func (c *TaskCommand) memberNames(chatID int64) map[string]string {
+	names := make(map[string]string)
+
+	members, err := c.db.GetTeamMembersByChatID(chatID)
+	if err != nil {
+		c.logger.Warn("Failed to load team members", "chat_id", chatID, "error", err)
+		return names
+	}
+	for _, member := range members {
+		names[member.ID] = member.Username
+	}
+	return names
+}
+
+// taskResponse shows a task with its dependencies
+func (c *TaskCommand) taskResponse(task domain.Task, projectTasks []domain.Task, members map[string]string, notice string) *domain.Response {
+	var response strings.Builder
+	if notice != "" {
+		response.WriteString(notice + "\n\n")
+	}
+
+	id := "`" + task.ID + "`"
+	if key := c.taskKey(task.ID); key != "" {
+		id = "`" + key + "` · " + id
+	}
+	response.WriteString(fmt.Sprintf("%s **%s** (%s)\n", getPriorityIcon(task.Priority), task.Title, id))
+	response.WriteString(fmt.Sprintf("├── Status: %s\n", formatTaskStatus(task.Status)))
+	response.WriteString(fmt.Sprintf("├── Time: %sh logged of %s estimate\n", domain.FormatHours(task.ActualHours), domain.TaskEstimateRange(task)))
+	if name, ok := members[task.AssignedTo]; ok {
+		response.WriteString(fmt.Sprintf("├── Assignee: @%s\n", name))
+	} else {
+		response.WriteString("├── Assignee: unassigned\n")
+	}
+	if task.DueDate != nil {
+		response.WriteString(fmt.Sprintf("├── Due: %s\n", task.DueDate.Format("Jan 2 2006")))
+	}
+	if count, err := c.db.CountTaskAttachments(task.ID); err == nil && count > 0 {
+		response.WriteString(fmt.Sprintf("├── Screenshots: %d\n", count))
+	}
+
+	if len(task.Dependencies) == 0 {
+		response.WriteString("└── Dependencies: none\n")
+	} else {
+		pending := domain.PendingDependencies(task, projectTasks)
+		response.WriteString(fmt.Sprintf("└── Dependencies: %d/%d done\n", len(task.Dependencies)-len(pending), len(task.Dependencies)))
+		for _, dependency := range pending {
+			response.WriteString(fmt.Sprintf("    • ⏳ `%s` %s\n", dependency.ID, dependency.Title))
+		}
+	}
+
+	if len(task.AcceptanceCriteria) > 0 {
+		response.WriteString("\n✅ **Acceptance Criteria:**\n")
+		for _, criterion := range task.AcceptanceCriteria {
+			response.WriteString(fmt.Sprintf("• %s\n", criterion))
+		}
+	}
+
+	response.WriteString(c.formatCommits(task.ID))
+	response.WriteString(c.formatComments(task.ID))
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// taskKey returns the task's key with its team's prefix, e.g. TASK-12, or "" when it cannot be loaded
+func (c *TaskCommand) taskKey(taskID string) string {
+	location, err := c.db.GetTaskLocation(taskID)
+	if err != nil || location == nil {
+		return ""
+	}
+	settings, err := c.db.GetTeamSettings(fmt.Sprintf("team_%d", location.ChatID))
+	if err != nil {
+		c.logger.Warn("Failed to load team settings", "chat_id", location.ChatID, "error", err)
+	}
+	number, err := c.db.EnsureTaskKey(taskID)
+	if err != nil {
+		c.logger.Warn("Failed to number task", "task_id", taskID, "error", err)
+		return ""
+	}
+	return domain.FormatTaskKey(TaskKeyPrefix(settings), number)
+}
+
+// formatCommits lists the latest commits linked to a task
+func (c *TaskCommand) formatCommits(taskID string) string {
+	commits, err := c.db.GetTaskCommits(taskID, taskCommitLimit)
+	if err != nil {
+		c.logger.Warn("Failed to load task commits", "task_id", taskID, "error", err)
+		return ""
+	}
+	if len(commits) == 0 {
+		return ""
+	}
+
+	var text strings.Builder
+	text.WriteString("\n🔗 **Commits:**\n")
+	for _, commit := range commits {
+		title, _, _ := strings.Cut(commit.Message, "\n")
+		text.WriteString(fmt.Sprintf("• [%s](%s) %s (%s)\n", ShortSHA(commit.SHA), commit.URL, shortenText(title, 60), commit.Author))
+	}
+	return text.String()
+}
+
+// formatComments lists the latest comments of a task and how to add one
+func (c *TaskCommand) formatComments(taskID string) string {
+	count, err := c.db.CountTaskComments(taskID)
+	if err != nil {
+		c.logger.Warn("Failed to count task comments", "task_id", taskID, "error", err)
+		return ""
+	}
+	if count == 0 {
+		return "\n💬 Reply to this message to comment on the task\n"
+	}
+	comments, err := c.db.GetTaskComments(taskID, taskCommentLimit)
+	if err != nil {
+		c.logger.Warn("Failed to load task comments", "task_id", taskID, "error", err)
+		return ""
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("\n💬 **Comments (%d):**\n", count))
+	for _, comment := range comments {
+		text.WriteString(fmt.Sprintf("• %s: %s (%s)\n", escapeMarkdown(comment.Author), escapeMarkdown(shortenText(comment.Text, 80)), comment.CreatedAt.Format("Jan 2")))
+	}
+	text.WriteString("Reply to this message to add one\n")
+	return text.String()
+}
+
+// ShortSHA returns the abbreviated commit hash shown by git
+func ShortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// formatUnblocked notifies assignees of tasks that can start now
+func formatUnblocked(tasks []domain.Task, members map[string]string) string {
+	if len(tasks) == 0 {
+		return ""
+	}
+
+	var text strings.Builder
+	text.WriteString("\n🔓 **Unblocked:**\n")
+	for _, task := range tasks {
+		assignee := "unassigned"
+		if name, ok := members[task.AssignedTo]; ok {
+			assignee = "@" + name
+		}
+		text.WriteString(fmt.Sprintf("• %s: `%s` %s is ready to start\n", assignee, task.ID, task.Title))
+	}
+	return text.String()
+}
+
+// formatTaskStatus formats a task status for display
+func formatTaskStatus(status string) string {
+	switch status {
+	case domain.TaskStatusTodo:
+		return "📝 todo"
+	case domain.TaskStatusInProgress:
+		return "🔄 in progress"
+	case domain.TaskStatusCompleted:
+		return "✅ completed"
+	case domain.TaskStatusBlocked:
+		return "⛔ blocked"
+	default:
+		return status
+	}
+}
+
+// ToDomainTasks converts stored tasks to domain tasks
+func ToDomainTasks(tasks []database.Task) []domain.Task {
+	result := make([]domain.Task, len(tasks))
+	for i, task := range tasks {
+		result[i] = ToDomainTask(task)
+	}
+	return result
+}
+
+// ToDomainTask converts a stored task to a domain task
+func ToDomainTask(task database.Task) domain.Task {
+	return domain.Task{
+		ID:                 task.ID,
+		ProjectID:          task.ProjectID,
+		Title:              task.Title,
+		Description:        task.Description,
+		Category:           task.Category,
+		EstimateHours:      task.EstimateHours,
+		OptimisticHours:    task.OptimisticHours,
+		PessimisticHours:   task.PessimisticHours,
+		ActualHours:        task.ActualHours,
+		Status:             task.Status,
+		Priority:           task.Priority,
+		AssignedTo:         task.AssignedTo,
+		Dependencies:       task.Dependencies,
+		CreatedAt:          task.CreatedAt,
+		UpdatedAt:          task.UpdatedAt,
+		CompletedAt:        task.CompletedAt,
+		AcceptanceCriteria: task.AcceptanceCriteria,
+		DueDate:            task.DueDate,
+	}
+}
@@ -42,6 +42,11 @@ func (c *TeamCommand) Usage() string {
 	return "/add_member @username skills - Add team member with skills"
 }
 
+// Category puts the command in the team category a chat can turn off
+func (c *TeamCommand) Category() string {
+	return services.CategoryTeam
+}
+
 // Handle processes the add_member command
 func (c *TeamCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
 	c.logger.Info("Processing add_member command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
@@ -84,14 +89,36 @@ func (c *TeamCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.
 		}, nil
 	}
 
+	// The team row is named after the chat the first time a member is added
+	teamID, err := c.db.EnsureTeam(cmd.Chat.ID, cmd.Chat.Title)
+	if err != nil {
+		c.logger.Error("Failed to create team", "error", err, "chat_id", cmd.Chat.ID)
+		return &domain.Response{
+			Text:      "❌ Failed to add team member. Please try again.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	existing, err := c.db.GetTeamMemberByUsername(teamID, username)
+	if err != nil {
+		c.logger.Error("Failed to look up team member", "error", err, "username", username)
+		return &domain.Response{
+			Text:      "❌ Failed to add team member. Please try again.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+	if existing != nil {
+		return c.updateSkills(existing, cleanSkills), nil
+	}
+
 	// Generate member ID
 	memberID := generateMemberID()
 
 	// Create team member
 	member := &database.TeamMember{
 		ID:       memberID,
-		TeamID:   fmt.Sprintf("team_%d", cmd.Chat.ID),
-		UserID:   0, // Would be set when user interacts
+		TeamID:   teamID,
+		UserID:   0, // Linked to the Telegram ID when the user first uses the bot
 		Username: username,
 		Skills:   cleanSkills,
 		Capacity: 40.0,        // Default 40h/week
@@ -100,8 +127,7 @@ func (c *TeamCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.
 	}
 
 	// Save to database
-	err := c.db.CreateTeamMember(member)
-	if err != nil {
+	if err := c.db.CreateTeamMember(member); err != nil {
 		c.logger.Error("Failed to create team member", "error", err, "username", username)
 		return &domain.Response{
 			Text:      "❌ Failed to add team member. Please try again.",
@@ -137,6 +163,27 @@ func (c *TeamCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.
 	}, nil
 }
 
+// updateSkills replaces the skills of a member added before, keeping their capacity
+func (c *TeamCommand) updateSkills(member *database.TeamMember, skills []string) *domain.Response {
+	if err := c.db.UpdateTeamMemberProfile(member.ID, skills, member.Capacity); err != nil {
+		c.logger.Error("Failed to update team member", "error", err, "member_id", member.ID)
+		return &domain.Response{
+			Text:      "❌ Failed to update team member. Please try again.",
+			ParseMode: "Markdown",
+		}
+	}
+
+	c.logger.Info("Team member skills updated", "member_id", member.ID, "skills", skills)
+	return &domain.Response{
+		Text: fmt.Sprintf("✅ **@%s is already on the team**\n\n"+
+			"🛠️ **Skills updated:** %s\n"+
+			"📊 **Capacity:** %.0fh/week\n\n"+
+			"Use `/list_team` to see all team members.",
+			member.Username, strings.Join(skills, ", "), member.Capacity),
+		ParseMode: "Markdown",
+	}
+}
+
 // Helper function to generate member IDs
 func generateMemberID() string {
 	return fmt.Sprintf("member_%d", time.Now().UnixNano()%1000000)
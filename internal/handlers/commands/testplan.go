@@ -0,0 +1,272 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// testPlanDraft is the chat's latest test plan, kept until it is exported or added as tasks
+type testPlanDraft struct {
+	Project database.Project
+	Tasks   []domain.Task
+	Plan    *services.TestPlan
+}
+
+// TestPlanCommand generates QA test plans from a project's tasks and acceptance criteria
+type TestPlanCommand struct {
+	db              *database.DB
+	testPlanService *services.TestPlanService
+	webhooks        *services.ProjectWebhooks
+	logger          domain.Logger
+	drafts          map[int64]*testPlanDraft
+	mutex           sync.Mutex
+}
+
+// NewTestPlanCommand creates a new test plan command handler
+func NewTestPlanCommand(db *database.DB, testPlanService *services.TestPlanService, webhooks *services.ProjectWebhooks, logger domain.Logger) *TestPlanCommand {
+	return &TestPlanCommand{
+		db:              db,
+		testPlanService: testPlanService,
+		webhooks:        webhooks,
+		logger:          logger,
+		drafts:          make(map[int64]*testPlanDraft),
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *TestPlanCommand) CanHandle(command string) bool {
+	return command == "/testplan"
+}
+
+// Description returns the command description
+func (c *TestPlanCommand) Description() string {
+	return "🧪 QA test plan from a project's tasks"
+}
+
+// Usage returns the command usage instructions
+func (c *TestPlanCommand) Usage() string {
+	return "/testplan [project_id | export | add] - Generate a test plan, download it as Markdown or add it as QA tasks"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *TestPlanCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// ChatAction implements domain.LongRunningHandler: the plan is written by AI, or sent as a file on export
+func (c *TestPlanCommand) ChatAction(cmd *domain.Command) string {
+	switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/testplan"))) {
+	case "export":
+		return domain.ChatActionUploadDocument
+	case "add":
+		return ""
+	}
+	return domain.ChatActionTyping
+}
+
+// Handle processes the testplan command
+func (c *TestPlanCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing testplan command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimSpace(strings.TrimPrefix(cmd.Text, "/testplan")))
+	if len(args) > 1 {
+		return errorResponse("Usage: `/testplan [project_id | export | add]`"), nil
+	}
+
+	if len(args) == 1 {
+		switch strings.ToLower(args[0]) {
+		case "export":
+			return c.exportPlan(cmd.Chat.ID)
+		case "add":
+			return c.addTasks(cmd.Chat.ID)
+		}
+		return c.generate(ctx, cmd.Chat.ID, args[0])
+	}
+	return c.generate(ctx, cmd.Chat.ID, "")
+}
+
+// generate creates a test plan for a project, the chat's most recent active project by default
+func (c *TestPlanCommand) generate(ctx context.Context, chatID int64, projectID string) (*domain.Response, error) {
+	projects, err := c.db.GetProjectsByChatID(chatID)
+	if err != nil {
+		c.logger.Error("Failed to get projects", "error", err, "chat_id", chatID)
+		return errorResponse("Failed to retrieve projects. Please try again."), nil
+	}
+
+	var project *database.Project
+	for i := range projects {
+		if (projectID == "" && projects[i].Status == "active") || projects[i].ID == projectID {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		if projectID != "" {
+			return errorResponse(fmt.Sprintf("Project `%s` not found in this chat.", projectID)), nil
+		}
+		return errorResponse("No active project in this chat. Use `/testplan project_id`."), nil
+	}
+
+	stored, err := c.db.GetTasksByProjectID(project.ID)
+	if err != nil {
+		c.logger.Error("Failed to get project tasks", "error", err, "project_id", project.ID)
+		return errorResponse("Failed to retrieve tasks. Please try again."), nil
+	}
+	tasks := ToDomainTasks(stored)
+
+	plan, err := c.testPlanService.Generate(ctx, project.Name, tasks)
+	if err != nil {
+		c.logger.Warn("Test plan not generated", "project_id", project.ID, "error", err)
+		return errorResponse(fmt.Sprintf("**%s** has no tasks to test yet. Add some with `/analyze save`.", project.Name)), nil
+	}
+
+	c.mutex.Lock()
+	c.drafts[chatID] = &testPlanDraft{Project: *project, Tasks: tasks, Plan: plan}
+	c.mutex.Unlock()
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("🧪 **Test Plan: %s**\n\n", project.Name))
+
+	for _, feature := range plan.Features {
+		high := 0
+		hours := 0.0
+		for _, scenario := range feature.Scenarios {
+			if scenario.Priority == 1 {
+				high++
+			}
+			hours += scenario.EstimateHours
+		}
+		response.WriteString(fmt.Sprintf("📂 **%s** (%.1fh)\n", feature.Name, hours))
+		for i, scenario := range feature.Scenarios {
+			if i == 3 {
+				response.WriteString(fmt.Sprintf("├── ... and %d more scenarios\n", len(feature.Scenarios)-i))
+				break
+			}
+			response.WriteString(fmt.Sprintf("├── %s %s\n", getPriorityIcon(scenario.Priority), scenario.Title))
+		}
+		response.WriteString(fmt.Sprintf("└── Scenarios: %d, high priority: %d\n\n", len(feature.Scenarios), high))
+	}
+
+	response.WriteString(fmt.Sprintf("⏱️ **Total:** %d scenarios, %.1f hours\n", plan.ScenarioCount(), plan.TotalHours()))
+	if !plan.Generated {
+		response.WriteString("ℹ️ _Built from acceptance criteria; configure an AI provider for richer scenarios._\n")
+	}
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+		ReplyMarkup: &domain.InlineKeyboardMarkup{InlineKeyboard: [][]domain.InlineKeyboardButton{{
+			{Text: "📄 Markdown", CallbackData: "/testplan export"},
+			{Text: "➕ Add QA tasks", CallbackData: "/testplan add"},
+		}}},
+	}, nil
+}
+
+// loadDraft returns the chat's latest test plan
+func (c *TestPlanCommand) loadDraft(chatID int64) *testPlanDraft {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.drafts[chatID]
+}
+
+// exportPlan uploads the chat's latest test plan as a Markdown document
+func (c *TestPlanCommand) exportPlan(chatID int64) (*domain.Response, error) {
+	draft := c.loadDraft(chatID)
+	if draft == nil {
+		return errorResponse("No test plan yet. Run `/testplan` first."), nil
+	}
+
+	return &domain.Response{
+		Text:      fmt.Sprintf("📄 **Test plan for %s** · %d scenarios", draft.Project.Name, draft.Plan.ScenarioCount()),
+		ParseMode: "Markdown",
+		Document: &domain.OutgoingDocument{
+			FileName: fmt.Sprintf("testplan_%s.md", draft.Project.ID),
+			Content:  []byte(draft.Plan.Markdown(draft.Project.Name)),
+			Report:   true,
+		},
+	}, nil
+}
+
+// addTasks adds one QA task per feature of the chat's latest test plan. Each task
+// depends on the tasks its scenarios verify and lists the scenarios as acceptance criteria.
+func (c *TestPlanCommand) addTasks(chatID int64) (*domain.Response, error) {
+	c.mutex.Lock()
+	draft := c.drafts[chatID]
+	delete(c.drafts, chatID)
+	c.mutex.Unlock()
+
+	if draft == nil {
+		return errorResponse("No test plan to add. Run `/testplan` first."), nil
+	}
+
+	known := make(map[string]bool, len(draft.Tasks))
+	for _, task := range draft.Tasks {
+		known[task.ID] = true
+	}
+
+	var lines []string
+	for i, feature := range draft.Plan.Features {
+		var criteria, dependencies []string
+		seen := make(map[string]bool)
+		hours := 0.0
+		priority := 3
+		for _, scenario := range feature.Scenarios {
+			criteria = append(criteria, scenario.Title)
+			hours += scenario.EstimateHours
+			if scenario.Priority < priority {
+				priority = scenario.Priority
+			}
+			if known[scenario.TaskID] && !seen[scenario.TaskID] {
+				seen[scenario.TaskID] = true
+				dependencies = append(dependencies, scenario.TaskID)
+			}
+		}
+
+		task := &database.Task{
+			ID:                 fmt.Sprintf("task_%d_%d", time.Now().UnixNano(), i),
+			ProjectID:          draft.Project.ID,
+			Title:              fmt.Sprintf("Test: %s", feature.Name),
+			Description:        fmt.Sprintf("Run the %d test plan scenarios for %s", len(feature.Scenarios), feature.Name),
+			Category:           "qa",
+			EstimateHours:      hours,
+			Status:             domain.TaskStatusTodo,
+			Priority:           priority,
+			Dependencies:       dependencies,
+			AcceptanceCriteria: criteria,
+		}
+		if err := c.db.CreateTask(task); err != nil {
+			c.logger.Error("Failed to add test plan task", "project_id", draft.Project.ID, "feature", feature.Name, "error", err)
+			continue
+		}
+		c.webhooks.TaskCreated(ToDomainTask(*task))
+		lines = append(lines, fmt.Sprintf("`%s` %s (%.1fh)", task.ID, task.Title, task.EstimateHours))
+	}
+
+	if len(lines) == 0 {
+		return errorResponse("Failed to add the QA tasks. Please try again."), nil
+	}
+	c.logger.Info("Test plan tasks added", "chat_id", chatID, "project_id", draft.Project.ID, "tasks", len(lines))
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("✅ **Added %d QA tasks to %s**\n\n", len(lines), draft.Project.Name))
+	for i, line := range lines {
+		branch := "├──"
+		if i == len(lines)-1 {
+			branch = "└──"
+		}
+		response.WriteString(fmt.Sprintf("%s %s\n", branch, line))
+	}
+	response.WriteString("\n💡 Scenarios are listed with `/task show id`.")
+
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}, nil
+}
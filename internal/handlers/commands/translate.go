@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// TranslateCommand translates text between Uzbek, Russian and English
+type TranslateCommand struct {
+	translationService *services.TranslationService
+	logger             domain.Logger
+}
+
+// NewTranslateCommand creates a new translate command handler
+func NewTranslateCommand(translationService *services.TranslationService, logger domain.Logger) *TranslateCommand {
+	return &TranslateCommand{
+		translationService: translationService,
+		logger:             logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *TranslateCommand) CanHandle(command string) bool {
+	return command == "/translate" || command == "/tr"
+}
+
+// Description returns the command description
+func (c *TranslateCommand) Description() string {
+	return "🌐 Translate text between Uzbek, Russian and English"
+}
+
+// Usage returns the command usage instructions
+func (c *TranslateCommand) Usage() string {
+	return "/translate [uz|ru|en] text - Translate text or a replied message"
+}
+
+// Category puts the command in the ai category a chat can turn off
+func (c *TranslateCommand) Category() string {
+	return services.CategoryAI
+}
+
+// ChatAction implements domain.LongRunningHandler: translations come from the AI chain
+func (c *TranslateCommand) ChatAction(cmd *domain.Command) string {
+	return domain.ChatActionTyping
+}
+
+// Handle processes the translate command
+func (c *TranslateCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing translate command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	_, input := splitFirstWord(strings.TrimSpace(cmd.Text))
+
+	// Optional target language as the first argument
+	target := ""
+	first, rest := splitFirstWord(input)
+	if _, ok := services.LanguageNames[strings.ToLower(first)]; ok {
+		target = strings.ToLower(first)
+		input = rest
+	}
+
+	// Fall back to the replied-to message
+	text := input
+	if text == "" {
+		text = cmd.ReplyToText
+	}
+	if text == "" {
+		return c.usageResponse(), nil
+	}
+
+	if !c.translationService.IsConfigured() {
+		return &domain.Response{
+			Text: "❌ Translation needs an AI provider.\n\n" +
+				"Set `CLAUDE_API_KEY`, `OPENAI_API_KEY` or `GEMINI_API_KEY` to enable `/translate`.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	translation, err := c.translationService.Translate(ctx, text, target)
+	if err != nil {
+		c.logger.Error("Translation failed", "chat_id", cmd.Chat.ID, "error", err)
+		return &domain.Response{
+			Text:      "❌ Translation failed: " + err.Error(),
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	return &domain.Response{
+		Text: fmt.Sprintf("🌐 **%s → %s**\n\n%s",
+			strings.ToUpper(translation.Source), strings.ToUpper(translation.Target), translation.Text),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// usageResponse returns the command usage help
+func (c *TranslateCommand) usageResponse() *domain.Response {
+	return &domain.Response{
+		Text: "🌐 **Translate**\n\n" +
+			"**Examples:**\n" +
+			"• `/translate en Foydalanuvchi ro'yxatdan o'tishi kerak`\n" +
+			"• `/translate uz Add password reset via email`\n" +
+			"• `/translate ru` as a reply to any message\n\n" +
+			"The source language is detected automatically. Without a target, " +
+			"English text goes to Uzbek and everything else to English.",
+		ParseMode: "Markdown",
+	}
+}
@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// UtilizationCommand shows members' utilization over the last four weeks from the
+// daily snapshots, so leads can spot chronic overload
+type UtilizationCommand struct {
+	db     *database.DB
+	logger domain.Logger
+}
+
+// NewUtilizationCommand creates a new utilization command handler
+func NewUtilizationCommand(db *database.DB, logger domain.Logger) *UtilizationCommand {
+	return &UtilizationCommand{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *UtilizationCommand) CanHandle(command string) bool {
+	return command == "/utilization"
+}
+
+// Description returns the command description
+func (c *UtilizationCommand) Description() string {
+	return "📈 Member utilization trend over four weeks"
+}
+
+// Usage returns the command usage instructions
+func (c *UtilizationCommand) Usage() string {
+	return "/utilization [@username] - Four-week utilization of the team, or a member's trend chart"
+}
+
+// Category puts the command in the team category a chat can turn off
+func (c *UtilizationCommand) Category() string {
+	return services.CategoryTeam
+}
+
+// Handle processes the utilization command
+func (c *UtilizationCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing utilization command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	members, err := c.db.GetTeamMembersByChatID(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to load team members", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to load the team. Please try again."), nil
+	}
+	if len(members) == 0 {
+		return errorResponse("No team members in this chat yet. Add them with `/add_member @username skills`."), nil
+	}
+
+	now := time.Now().In(services.BotLocation())
+	args := strings.Fields(strings.TrimPrefix(cmd.Text, "/utilization"))
+	if len(args) == 0 {
+		return c.teamOverview(members, now), nil
+	}
+
+	username := strings.ToLower(strings.TrimPrefix(args[0], "@"))
+	for _, member := range members {
+		if strings.ToLower(member.Username) == username {
+			return c.memberTrend(member, now), nil
+		}
+	}
+	return errorResponse(fmt.Sprintf("@%s is not a member of this team. See `/list_team`.", username)), nil
+}
+
+// memberTrend shows a member's weekly utilization with a daily chart
+func (c *UtilizationCommand) memberTrend(member database.TeamMember, now time.Time) *domain.Response {
+	points, err := c.history(member.ID, now)
+	if err != nil {
+		c.logger.Error("Failed to load utilization history", "member_id", member.ID, "error", err)
+		return errorResponse("Failed to load the utilization history. Please try again.")
+	}
+	trend := domain.AnalyzeUtilizationTrend(points, now)
+	if trend.Days == 0 {
+		return errorResponse(fmt.Sprintf("No utilization recorded for @%s yet. Snapshots are taken daily.", member.Username))
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📈 **@%s** · last %d days\n", member.Username, domain.UtilizationTrendDays))
+	for i, week := range trend.Weeks {
+		branch := "├──"
+		if i == len(trend.Weeks)-1 {
+			branch = "└──"
+		}
+		if week.Days == 0 {
+			text.WriteString(fmt.Sprintf("%s %s: no data\n", branch, week.Start.Format("Jan 2")))
+			continue
+		}
+		text.WriteString(fmt.Sprintf("%s %s: %s %.0f%% avg, peak %.0f%%\n",
+			branch, week.Start.Format("Jan 2"), getUtilizationEmoji(week.Average), week.Average*100, week.Peak*100))
+	}
+	text.WriteString(formatOverload(trend))
+
+	chart, err := services.RenderUtilizationChart(points, trend.Start)
+	if err != nil {
+		c.logger.Warn("Failed to render utilization chart", "member_id", member.ID, "error", err)
+		return &domain.Response{Text: text.String(), ParseMode: "Markdown"}
+	}
+	return &domain.Response{
+		Text:      text.String(),
+		ParseMode: "Markdown",
+		Document: &domain.OutgoingDocument{
+			FileName: fmt.Sprintf("utilization_%s.png", member.Username),
+			Content:  chart,
+			Photo:    true,
+			Report:   true,
+		},
+	}
+}
+
+// teamOverview lists every member's four-week average, most utilized first
+func (c *UtilizationCommand) teamOverview(members []database.TeamMember, now time.Time) *domain.Response {
+	type memberTrend struct {
+		username string
+		trend    domain.UtilizationTrend
+	}
+
+	var trends []memberTrend
+	for _, member := range members {
+		points, err := c.history(member.ID, now)
+		if err != nil {
+			c.logger.Warn("Failed to load utilization history", "member_id", member.ID, "error", err)
+			continue
+		}
+		trends = append(trends, memberTrend{member.Username, domain.AnalyzeUtilizationTrend(points, now)})
+	}
+	sort.SliceStable(trends, func(i, j int) bool { return trends[i].trend.Average > trends[j].trend.Average })
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📈 **Team Utilization** · last %d days\n\n", domain.UtilizationTrendDays))
+	chronic := 0
+	for _, item := range trends {
+		if item.trend.Days == 0 {
+			text.WriteString(fmt.Sprintf("👤 **@%s**: no data yet\n", item.username))
+			continue
+		}
+		flag := ""
+		if item.trend.Chronic {
+			flag = " 🚨"
+			chronic++
+		}
+		text.WriteString(fmt.Sprintf("👤 **@%s**%s: %s %.0f%% avg, %d/%d days overloaded\n",
+			item.username, flag, getUtilizationEmoji(item.trend.Average), item.trend.Average*100,
+			item.trend.OverloadedDays, item.trend.Days))
+	}
+
+	if chronic > 0 {
+		text.WriteString("\n🚨 Chronic overload: rebalance work before it turns into burnout. See `/workload`.\n")
+	}
+	text.WriteString("\nUse `/utilization @username` for a member's chart.")
+
+	return &domain.Response{
+		Text:      text.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// history loads a member's snapshots of the trend period
+func (c *UtilizationCommand) history(memberID string, now time.Time) ([]domain.UtilizationPoint, error) {
+	snapshots, err := c.db.Analytics().GetUtilizationHistory(memberID, now.AddDate(0, 0, 1-domain.UtilizationTrendDays))
+	if err != nil {
+		return nil, err
+	}
+	points := make([]domain.UtilizationPoint, len(snapshots))
+	for i, snapshot := range snapshots {
+		points[i] = domain.UtilizationPoint{Day: snapshot.Day, Capacity: snapshot.Capacity, Allocated: snapshot.Allocated}
+	}
+	return points, nil
+}
+
+// formatOverload summarizes overloaded days and flags chronic overload
+func formatOverload(trend domain.UtilizationTrend) string {
+	if trend.Chronic {
+		return fmt.Sprintf("\n🚨 **Chronic overload:** %d of %d days above %.0f%%. Rebalance work before it turns into burnout.",
+			trend.OverloadedDays, trend.Days, domain.OverloadThreshold*100)
+	}
+	if trend.OverloadedDays > 0 {
+		return fmt.Sprintf("\n⚠️ %d of %d days above %.0f%%.", trend.OverloadedDays, trend.Days, domain.OverloadThreshold*100)
+	}
+	return "\n✅ No overloaded days."
+}
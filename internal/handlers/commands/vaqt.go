@@ -62,3 +62,8 @@ func (h *VaqtCommand) Description() string {
 func (h *VaqtCommand) Usage() string {
 	return "/vaqt - Hozirgi vaqtni ko'rish"
 }
+
+// Category is empty, as chats cannot turn the command off
+func (h *VaqtCommand) Category() string {
+	return ""
+}
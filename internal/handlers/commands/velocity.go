@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// velocitySprints is the number of recent sprints shown by /velocity
+const velocitySprints = 4
+
+// VelocityCommand shows completed work per sprint in points and hours
+type VelocityCommand struct {
+	db                *database.DB
+	estimationService *services.EstimationService
+	logger            domain.Logger
+}
+
+// NewVelocityCommand creates a new velocity command handler
+func NewVelocityCommand(db *database.DB, estimationService *services.EstimationService, logger domain.Logger) *VelocityCommand {
+	return &VelocityCommand{
+		db:                db,
+		estimationService: estimationService,
+		logger:            logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *VelocityCommand) CanHandle(command string) bool {
+	return command == "/velocity"
+}
+
+// Description returns the command description
+func (c *VelocityCommand) Description() string {
+	return "🚀 Team velocity per sprint in points and hours"
+}
+
+// Usage returns the command usage instructions
+func (c *VelocityCommand) Usage() string {
+	return "/velocity - Points and hours completed in recent sprints"
+}
+
+// Category puts the command in the team category a chat can turn off
+func (c *VelocityCommand) Category() string {
+	return services.CategoryTeam
+}
+
+// Handle processes the velocity command
+func (c *VelocityCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing velocity command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
+	settings := c.estimationService.GetSettings(teamID)
+	sprintConfig := c.estimationService.GetSprintConfig(teamID)
+
+	sprints := domain.RecentSprints(sprintConfig.Start, sprintConfig.LengthDays, time.Now(), velocitySprints)
+	if len(sprints) == 0 {
+		return &domain.Response{
+			Text:      "🚀 **Team Velocity**\n\nThe first sprint has not started yet. Check `/points` for sprint settings.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	tasks, err := c.db.Analytics().GetCompletedTasksByChatID(cmd.Chat.ID, sprints[0].Start)
+	if err != nil {
+		c.logger.Error("Failed to load completed tasks", "chat_id", cmd.Chat.ID, "error", err)
+		return &domain.Response{
+			Text:      "❌ Failed to load completed tasks. Please try again.",
+			ParseMode: "Markdown",
+		}, nil
+	}
+
+	work := make([]domain.CompletedWork, len(tasks))
+	for i, task := range tasks {
+		hours := task.ActualHours
+		if hours == 0 {
+			hours = task.EstimateHours
+		}
+		work[i] = domain.CompletedWork{CompletedAt: task.CompletedAt, Hours: hours}
+	}
+
+	velocity := settings.Scale.Velocity(sprints, work)
+
+	return &domain.Response{
+		Text:      c.formatVelocity(velocity, settings),
+		ParseMode: "Markdown",
+	}, nil
+}
+
+// formatVelocity formats sprint velocity with points and hours side by side
+func (c *VelocityCommand) formatVelocity(velocity []domain.SprintVelocity, settings domain.EstimateSettings) string {
+	var response strings.Builder
+
+	response.WriteString("🚀 **Team Velocity**\n\n")
+
+	totalPoints, totalHours, finished := 0, 0.0, 0
+	for i, sprint := range velocity {
+		current := i == len(velocity)-1
+		label := fmt.Sprintf("Sprint %d", sprint.Sprint.Number)
+		if current {
+			label += " (current)"
+		}
+		response.WriteString(fmt.Sprintf("**%s** - %s → %s\n", label,
+			sprint.Sprint.Start.Format("Jan 2"), sprint.Sprint.End.AddDate(0, 0, -1).Format("Jan 2")))
+		response.WriteString(fmt.Sprintf("└── 🎲 %d pts | ⏱️ %.1fh | ✅ %d tasks\n\n", sprint.Points, sprint.Hours, sprint.Tasks))
+
+		if !current {
+			totalPoints += sprint.Points
+			totalHours += sprint.Hours
+			finished++
+		}
+	}
+
+	if finished > 0 {
+		response.WriteString(fmt.Sprintf("📊 **Average (finished sprints):** %.1f pts | %.1fh\n\n",
+			float64(totalPoints)/float64(finished), totalHours/float64(finished)))
+	}
+
+	if !settings.UsePoints() {
+		response.WriteString("💡 Points use the team scale even in hours mode. Switch `/analyze` to points with `/points on`.")
+	} else {
+		response.WriteString("💡 Points are derived from task hours using the team scale (`/points`).")
+	}
+
+	return response.String()
+}
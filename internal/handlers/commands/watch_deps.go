@@ -0,0 +1,263 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// maxDependencyWatches limits the repositories a chat can watch
+const maxDependencyWatches = 5
+
+// maxReportLines limits the updates and vulnerabilities listed in a dependency report
+const maxReportLines = 15
+
+// DependencyReportInterval is how often the update report of a watched repository is posted
+const DependencyReportInterval = 7 * 24 * time.Hour
+
+// WatchDepsCommand watches the Go modules of GitHub repositories and reports available
+// updates and known vulnerabilities; the weekly reports are posted by a background job
+type WatchDepsCommand struct {
+	db      *database.DB
+	checker *services.DependencyChecker
+	logger  domain.Logger
+}
+
+// NewWatchDepsCommand creates a new dependency watch command handler
+func NewWatchDepsCommand(db *database.DB, checker *services.DependencyChecker, logger domain.Logger) *WatchDepsCommand {
+	return &WatchDepsCommand{
+		db:      db,
+		checker: checker,
+		logger:  logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *WatchDepsCommand) CanHandle(command string) bool {
+	return command == "/watch_deps"
+}
+
+// Description returns the command description
+func (c *WatchDepsCommand) Description() string {
+	return "📦 Weekly Go module updates and vulnerabilities"
+}
+
+// Usage returns the command usage instructions
+func (c *WatchDepsCommand) Usage() string {
+	return "/watch_deps [owner/repo | check owner/repo | remove owner/repo] - Go module update reports"
+}
+
+// Category puts the command in the dev category a chat can turn off
+func (c *WatchDepsCommand) Category() string {
+	return services.CategoryDev
+}
+
+// ChatAction implements domain.LongRunningHandler: checks query GitHub, the module proxy and OSV
+func (c *WatchDepsCommand) ChatAction(cmd *domain.Command) string {
+	args := strings.Fields(strings.TrimPrefix(cmd.Text, "/watch_deps"))
+	if len(args) == 0 || strings.ToLower(args[0]) == "remove" {
+		return ""
+	}
+	return domain.ChatActionTyping
+}
+
+// Timeout allows for looking up every direct requirement on the module proxy
+func (c *WatchDepsCommand) Timeout() time.Duration {
+	return 2 * time.Minute
+}
+
+// Handle processes the watch_deps command
+func (c *WatchDepsCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing watch_deps command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	args := strings.Fields(strings.TrimPrefix(cmd.Text, "/watch_deps"))
+	if len(args) == 0 || strings.ToLower(args[0]) == "list" {
+		return c.listWatches(cmd.Chat.ID), nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "remove", "check":
+		if len(args) != 2 || !githubRepoPattern.MatchString(args[1]) {
+			return errorResponse(fmt.Sprintf("Usage: `/watch_deps %s owner/repo`", strings.ToLower(args[0]))), nil
+		}
+		if strings.ToLower(args[0]) == "remove" {
+			return c.removeWatch(cmd.Chat.ID, args[1]), nil
+		}
+		return c.check(ctx, cmd.Chat.ID, args[1]), nil
+	}
+
+	if len(args) != 1 || !githubRepoPattern.MatchString(args[0]) {
+		return errorResponse("Usage: `/watch_deps owner/repo`, e.g. `/watch_deps golang/example`"), nil
+	}
+	return c.watch(ctx, cmd, args[0]), nil
+}
+
+// watch checks a repository and, when its go.mod can be read, adds it to the weekly reports
+func (c *WatchDepsCommand) watch(ctx context.Context, cmd *domain.Command, repository string) *domain.Response {
+	watches, err := c.db.GetDependencyWatches(cmd.Chat.ID)
+	if err != nil {
+		c.logger.Error("Failed to load dependency watches", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to watch the repository. Please try again.")
+	}
+	if len(watches) >= maxDependencyWatches {
+		return errorResponse(fmt.Sprintf("This chat already watches %d repositories. Remove some first.", maxDependencyWatches))
+	}
+
+	report, err := c.checker.Check(ctx, repository)
+	if err != nil {
+		c.logger.Warn("Failed to check dependencies", "chat_id", cmd.Chat.ID, "repository", repository, "error", err)
+		return errorResponse(fmt.Sprintf("Could not check %s: %s.", escapeMarkdown(repository), escapeMarkdown(err.Error())))
+	}
+
+	watch := &database.DependencyWatch{
+		ChatID:     cmd.Chat.ID,
+		Repository: repository,
+		CreatedBy:  cmd.User.TelegramID,
+		ReportedAt: time.Now(),
+	}
+	added, err := c.db.AddDependencyWatch(watch)
+	if err != nil {
+		c.logger.Error("Failed to save dependency watch", "chat_id", cmd.Chat.ID, "error", err)
+		return errorResponse("Failed to watch the repository. Please try again.")
+	}
+
+	notice := fmt.Sprintf("✅ Watching `%s`. The next report is posted in a week.", repository)
+	if !added {
+		notice = fmt.Sprintf("ℹ️ This chat already watches `%s`.", repository)
+	}
+	c.logger.Info("Dependency watch added", "chat_id", cmd.Chat.ID, "repository", repository, "updates", len(report.Updates))
+
+	return &domain.Response{
+		Text:           FormatDependencyReport(report) + "\n\n" + notice,
+		ParseMode:      "Markdown",
+		DisablePreview: true,
+	}
+}
+
+// check reports a repository's updates without watching it
+func (c *WatchDepsCommand) check(ctx context.Context, chatID int64, repository string) *domain.Response {
+	report, err := c.checker.Check(ctx, repository)
+	if err != nil {
+		c.logger.Warn("Failed to check dependencies", "chat_id", chatID, "repository", repository, "error", err)
+		return errorResponse(fmt.Sprintf("Could not check %s: %s.", escapeMarkdown(repository), escapeMarkdown(err.Error())))
+	}
+	return &domain.Response{
+		Text:           FormatDependencyReport(report),
+		ParseMode:      "Markdown",
+		DisablePreview: true,
+	}
+}
+
+// listWatches shows the repositories the chat watches
+func (c *WatchDepsCommand) listWatches(chatID int64) *domain.Response {
+	watches, err := c.db.GetDependencyWatches(chatID)
+	if err != nil {
+		c.logger.Error("Failed to list dependency watches", "chat_id", chatID, "error", err)
+		return errorResponse("Failed to load watched repositories. Please try again.")
+	}
+
+	var response strings.Builder
+	response.WriteString("📦 **Dependency Watch**\n\n")
+
+	if len(watches) == 0 {
+		response.WriteString("This chat watches no repositories.\n\n")
+	} else {
+		for i, watch := range watches {
+			branch := "├──"
+			if i == len(watches)-1 {
+				branch = "└──"
+			}
+			next := "soon"
+			if !watch.ReportedAt.IsZero() {
+				if until := time.Until(watch.ReportedAt.Add(DependencyReportInterval)); until > 0 {
+					next = "in " + formatUntil(until)
+				}
+			}
+			response.WriteString(fmt.Sprintf("%s `%s` · next report %s\n", branch, watch.Repository, next))
+		}
+		response.WriteString("\n")
+	}
+
+	response.WriteString("`/watch_deps owner/repo` - Weekly report of the repository's Go module updates\n" +
+		"`/watch_deps check owner/repo` - Report now without watching\n" +
+		"`/watch_deps remove owner/repo` - Stop the reports")
+	return &domain.Response{
+		Text:      response.String(),
+		ParseMode: "Markdown",
+	}
+}
+
+// removeWatch stops the reports of a repository
+func (c *WatchDepsCommand) removeWatch(chatID int64, repository string) *domain.Response {
+	removed, err := c.db.DeleteDependencyWatch(chatID, repository)
+	if err != nil {
+		c.logger.Error("Failed to remove dependency watch", "chat_id", chatID, "repository", repository, "error", err)
+		return errorResponse("Failed to remove the repository. Please try again.")
+	}
+	if !removed {
+		return errorResponse(fmt.Sprintf("This chat does not watch `%s`", repository))
+	}
+
+	c.logger.Info("Dependency watch removed", "chat_id", chatID, "repository", repository)
+
+	return &domain.Response{
+		Text:      fmt.Sprintf("🗑️ Stopped watching `%s`", repository),
+		ParseMode: "Markdown",
+	}
+}
+
+// FormatDependencyReport formats the available updates and vulnerabilities of a repository,
+// vulnerabilities first
+func FormatDependencyReport(report *services.DependencyReport) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📦 **Dependency Report** · `%s`\n", report.Repository))
+	text.WriteString(fmt.Sprintf("%d requirements, %d direct\n\n", report.Requirements, report.Direct))
+
+	if len(report.Vulnerabilities) > 0 {
+		text.WriteString(fmt.Sprintf("🚨 **Known vulnerabilities (%d)**\n", len(report.Vulnerabilities)))
+		writeReportLines(&text, len(report.Vulnerabilities), func(i int) string {
+			vulnerability := report.Vulnerabilities[i]
+			links := make([]string, 0, len(vulnerability.IDs))
+			for _, id := range vulnerability.IDs {
+				links = append(links, fmt.Sprintf("[%s](https://osv.dev/vulnerability/%s)", id, id))
+			}
+			return fmt.Sprintf("`%s` %s: %s", vulnerability.Path, vulnerability.Version, strings.Join(links, ", "))
+		})
+		text.WriteString("\n")
+	}
+
+	if len(report.Updates) == 0 {
+		text.WriteString("✅ Direct dependencies are up to date")
+		return text.String()
+	}
+
+	text.WriteString(fmt.Sprintf("⬆️ **Available updates (%d)**\n", len(report.Updates)))
+	writeReportLines(&text, len(report.Updates), func(i int) string {
+		update := report.Updates[i]
+		return fmt.Sprintf("`%s` %s → %s · [changelog](%s)", update.Path, update.Current, update.Latest, update.Changelog)
+	})
+	return strings.TrimRight(text.String(), "\n")
+}
+
+// writeReportLines writes up to maxReportLines tree lines and a count of the rest
+func writeReportLines(text *strings.Builder, count int, line func(i int) string) {
+	shown := count
+	if shown > maxReportLines {
+		shown = maxReportLines
+	}
+	for i := 0; i < shown; i++ {
+		branch := "├──"
+		if i == shown-1 && shown == count {
+			branch = "└──"
+		}
+		text.WriteString(branch + " " + line(i) + "\n")
+	}
+	if count > shown {
+		text.WriteString(fmt.Sprintf("└── and %d more\n", count-shown))
+	}
+}
@@ -93,3 +93,8 @@ func (h *WeatherCommand) Description() string {
 func (h *WeatherCommand) Usage() string {
 	return "/weather city - Ob-havo ma'lumoti"
 }
+
+// Category puts the command in the fun category a chat can turn off
+func (h *WeatherCommand) Category() string {
+	return services.CategoryFun
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// WebAppCommand opens the task management Mini App
+type WebAppCommand struct {
+	baseURL string
+	logger  domain.Logger
+}
+
+// NewWebAppCommand creates a new web app command handler.
+// baseURL is the public https address of the bot's HTTP server.
+func NewWebAppCommand(baseURL string, logger domain.Logger) *WebAppCommand {
+	return &WebAppCommand{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		logger:  logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *WebAppCommand) CanHandle(command string) bool {
+	return command == "/app"
+}
+
+// Description returns the command description
+func (c *WebAppCommand) Description() string {
+	return "📱 Manage your tasks in a Telegram Mini App"
+}
+
+// MenuScope lists the command only in private chats, the only place the Mini App button works
+func (c *WebAppCommand) MenuScope() string {
+	return domain.MenuScopePrivate
+}
+
+// Usage returns the command usage instructions
+func (c *WebAppCommand) Usage() string {
+	return "/app - Open the task manager (private chat)"
+}
+
+// Category puts the command in the projects category a chat can turn off
+func (c *WebAppCommand) Category() string {
+	return services.CategoryProjects
+}
+
+// Handle processes the web app command
+func (c *WebAppCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	c.logger.Info("Processing app command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
+
+	if !strings.HasPrefix(c.baseURL, "https://") {
+		return errorResponse("The Mini App needs `PUBLIC_URL` set to an https address."), nil
+	}
+
+	// Telegram only allows Mini App buttons in private chats
+	if cmd.Chat.Type != "private" {
+		return errorResponse("Open a private chat with the bot and send `/app` there."), nil
+	}
+
+	return &domain.Response{
+		Text:      "📱 **Task Manager**\n\nBrowse your tasks across all teams, start or finish them and log time.",
+		ParseMode: "Markdown",
+		ReplyMarkup: &domain.InlineKeyboardMarkup{
+			InlineKeyboard: [][]domain.InlineKeyboardButton{{
+				{Text: "📱 Open tasks", WebApp: &domain.WebAppInfo{URL: c.baseURL + "/webapp"}},
+			}},
+		},
+	}, nil
+}
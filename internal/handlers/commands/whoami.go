@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/database"
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// WhoAmICommand shows what the bot stores about the caller in one view: profile, team
+// roles, workload, language and notifications
+type WhoAmICommand struct {
+	db     *database.DB
+	chats  *services.ChatSettingsService
+	logger domain.Logger
+}
+
+// NewWhoAmICommand creates a new whoami command handler
+func NewWhoAmICommand(db *database.DB, chats *services.ChatSettingsService, logger domain.Logger) *WhoAmICommand {
+	return &WhoAmICommand{
+		db:     db,
+		chats:  chats,
+		logger: logger,
+	}
+}
+
+// CanHandle checks if this handler can process the command
+func (c *WhoAmICommand) CanHandle(command string) bool {
+	return command == "/whoami"
+}
+
+// Description returns the command description
+func (c *WhoAmICommand) Description() string {
+	return "🪪 Your stored profile, teams and settings"
+}
+
+// Usage returns the command usage instructions
+func (c *WhoAmICommand) Usage() string {
+	return "/whoami - Your profile, team roles, workload, language and notifications"
+}
+
+// Category is empty, as chats cannot turn the command off
+func (c *WhoAmICommand) Category() string {
+	return ""
+}
+
+// Handle processes the whoami command
+func (c *WhoAmICommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+	userID := cmd.User.TelegramID
+	c.logger.Info("Processing whoami command", "user_id", userID, "chat_id", cmd.Chat.ID)
+
+	// Members are added by username, so link them to the caller's Telegram ID first
+	if err := c.db.LinkTeamMemberships(userID, cmd.User.Username); err != nil {
+		c.logger.Warn("Failed to link team memberships", "user_id", userID, "error", err)
+	}
+
+	user, err := c.db.GetUser(userID)
+	if err != nil {
+		c.logger.Error("Failed to load user", "user_id", userID, "error", err)
+		return errorResponse("Failed to load your profile. Please try again."), nil
+	}
+	memberships, err := c.db.GetTeamMembershipsByUser(userID)
+	if err != nil {
+		c.logger.Error("Failed to load team memberships", "user_id", userID, "error", err)
+		return errorResponse("Failed to load your profile. Please try again."), nil
+	}
+	tasks, err := c.db.GetTasksAssignedToUser(userID)
+	if err != nil {
+		c.logger.Error("Failed to get assigned tasks", "user_id", userID, "error", err)
+		return errorResponse("Failed to load your profile. Please try again."), nil
+	}
+
+	var response strings.Builder
+	response.WriteString("🪪 **Who Am I**\n\n")
+	response.WriteString(formatWhoAmIProfile(cmd.User, user))
+	response.WriteString("\n👥 **Teams** · `/list_team`\n")
+	response.WriteString(formatWhoAmITeams(memberships, tasks))
+	response.WriteString("\n📋 **Workload** · `/my_tasks`\n")
+	response.WriteString(formatWhoAmIWorkload(memberships, tasks))
+	response.WriteString("\n⚙️ **Preferences**\n")
+	response.WriteString(c.formatPreferences(cmd))
+
+	return &domain.Response{Text: response.String(), ParseMode: "Markdown"}, nil
+}
+
+// formatWhoAmIProfile lists the caller's Telegram identity and when the bot first saw them
+func formatWhoAmIProfile(caller *domain.User, stored *database.User) string {
+	name := strings.TrimSpace(caller.FirstName + " " + caller.LastName)
+	if name == "" {
+		name = "unknown"
+	}
+	if caller.Username != "" {
+		name += " (@" + caller.Username + ")"
+	}
+
+	since := "not stored yet"
+	if stored != nil && !stored.CreatedAt.IsZero() {
+		since = stored.CreatedAt.Format("Jan 2, 2006")
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("├── Name: %s\n", name))
+	text.WriteString(fmt.Sprintf("├── Telegram ID: `%d`\n", caller.TelegramID))
+	text.WriteString(fmt.Sprintf("└── Known since: %s\n", since))
+	return text.String()
+}
+
+// formatWhoAmITeams lists the caller's role, skills and open hours in every team
+func formatWhoAmITeams(memberships []database.TeamMembership, tasks []database.AssignedTask) string {
+	if len(memberships) == 0 {
+		return "└── Not a member of any team yet · `/add_member`\n"
+	}
+
+	var text strings.Builder
+	for i, membership := range memberships {
+		branch, indent := "├──", "│  "
+		if i == len(memberships)-1 {
+			branch, indent = "└──", "   "
+		}
+		role := membership.Role
+		if role == "" {
+			role = "developer"
+		}
+		text.WriteString(fmt.Sprintf("%s %s: %s, %.0fh open of %.0fh a week\n",
+			branch, membership.TeamName, role, openHours(tasks, membership.ChatID), membership.Capacity))
+		if len(membership.Skills) > 0 {
+			text.WriteString(fmt.Sprintf("%s └── Skills: %s\n", indent, strings.Join(membership.Skills, ", ")))
+		}
+	}
+	return text.String()
+}
+
+// formatWhoAmIWorkload sums the caller's open tasks and hours over all teams
+func formatWhoAmIWorkload(memberships []database.TeamMembership, tasks []database.AssignedTask) string {
+	inProgress := 0
+	for _, task := range tasks {
+		if task.Status == "in_progress" {
+			inProgress++
+		}
+	}
+	capacity := 0.0
+	for _, membership := range memberships {
+		capacity += membership.Capacity
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("├── Open tasks: %d (%d in progress)\n", len(tasks), inProgress))
+	if capacity > 0 {
+		text.WriteString(fmt.Sprintf("└── Remaining estimate: %.0fh of %.0fh weekly capacity (%.0f%%)\n",
+			openHours(tasks, 0), capacity, openHours(tasks, 0)/capacity*100))
+	} else {
+		text.WriteString(fmt.Sprintf("└── Remaining estimate: %.0fh\n", openHours(tasks, 0)))
+	}
+	return text.String()
+}
+
+// openHours returns the estimated hours left on the tasks of a team chat, or of all teams
+// when chatID is 0
+func openHours(tasks []database.AssignedTask, chatID int64) float64 {
+	hours := 0.0
+	for _, task := range tasks {
+		if chatID != 0 && task.ChatID != chatID {
+			continue
+		}
+		if left := task.EstimateHours - task.ActualHours; left > 0 {
+			hours += left
+		}
+	}
+	return hours
+}
+
+// formatPreferences lists the caller's language, email and hint settings. The email address
+// is only shown in a private chat.
+func (c *WhoAmICommand) formatPreferences(cmd *domain.Command) string {
+	userID := cmd.User.TelegramID
+
+	// A private chat's ID is the user's, so its settings are the user's own
+	language := "not set · `/chat_settings` in a private chat"
+	if code := c.chats.Language(userID); code != "" {
+		language = services.LanguageNames[code]
+	}
+
+	email := "off · `/email`"
+	subscription, err := c.db.GetEmailSubscription(userID)
+	if err != nil {
+		c.logger.Warn("Failed to load email subscription", "user_id", userID, "error", err)
+		email = "unknown"
+	} else if subscription != nil {
+		email = fmt.Sprintf("digest %s, alerts %s", onOff(subscription.Digest), onOff(subscription.Alerts))
+		if cmd.Chat.Type == "private" {
+			email += " to " + subscription.Email
+		}
+	}
+
+	hints := "on · `/hints`"
+	disabled, _, _, err := c.db.GetHintState(userID)
+	if err != nil {
+		c.logger.Warn("Failed to load hint state", "user_id", userID, "error", err)
+		hints = "unknown"
+	} else if disabled {
+		hints = "off · `/hints on`"
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("├── Language: %s\n", language))
+	text.WriteString(fmt.Sprintf("├── Email: %s\n", email))
+	text.WriteString(fmt.Sprintf("└── Command tips: %s\n", hints))
+	return text.String()
+}
@@ -3,7 +3,10 @@ package commands
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"yordamchi-dev-bot/database"
 	"yordamchi-dev-bot/internal/domain"
@@ -12,17 +15,21 @@ import (
 
 // WorkloadCommand handles team workload analysis
 type WorkloadCommand struct {
-	db          *database.DB
-	teamManager *services.TeamManager
-	logger      domain.Logger
+	db                *database.DB
+	teamManager       *services.TeamManager
+	calendarService   *services.CalendarService
+	estimationService *services.EstimationService
+	logger            domain.Logger
 }
 
 // NewWorkloadCommand creates a new workload command handler
-func NewWorkloadCommand(db *database.DB, teamManager *services.TeamManager, logger domain.Logger) *WorkloadCommand {
+func NewWorkloadCommand(db *database.DB, teamManager *services.TeamManager, calendarService *services.CalendarService, estimationService *services.EstimationService, logger domain.Logger) *WorkloadCommand {
 	return &WorkloadCommand{
-		db:          db,
-		teamManager: teamManager,
-		logger:      logger,
+		db:                db,
+		teamManager:       teamManager,
+		calendarService:   calendarService,
+		estimationService: estimationService,
+		logger:            logger,
 	}
 }
 
@@ -38,21 +45,27 @@ func (c *WorkloadCommand) Description() string {
 
 // Usage returns the command usage instructions
 func (c *WorkloadCommand) Usage() string {
-	return "/workload - Analyze team workload and capacity"
+	return "/workload [week | next_week | month | sprint | next_sprint] - Team workload now, or planned by due date for a period"
+}
+
+// Category puts the command in the team category a chat can turn off
+func (c *WorkloadCommand) Category() string {
+	return services.CategoryTeam
 }
 
 // Handle processes the workload command
 func (c *WorkloadCommand) Handle(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
 	c.logger.Info("Processing workload command", "user_id", cmd.User.TelegramID, "chat_id", cmd.Chat.ID)
 
-	teamID := fmt.Sprintf("chat_%d", cmd.Chat.ID)
+	teamID := fmt.Sprintf("team_%d", cmd.Chat.ID)
 
-	// For MVP, we'll show mock data since we don't have database integration yet
-	// In production, this would fetch real data from database
-	mockMembers := c.getMockTeamMembers(teamID)
-	mockTasks := c.getMockTasks()
+	members, openTasks, err := LoadTeamWorkload(c.db, cmd.Chat.ID, c.logger)
+	if err != nil {
+		c.logger.Error("Failed to load team members", "chat_id", cmd.Chat.ID, "error", err)
+		return &domain.Response{Text: "❌ Failed to load the team. Please try again.", ParseMode: "Markdown"}, nil
+	}
 
-	if len(mockMembers) == 0 {
+	if len(members) == 0 {
 		return &domain.Response{
 			Text: "❌ No team members found for this chat.\n\n" +
 				"**Get Started:**\n" +
@@ -64,8 +77,12 @@ func (c *WorkloadCommand) Handle(ctx context.Context, cmd *domain.Command) (*dom
 		}, nil
 	}
 
+	if args := strings.Fields(strings.TrimPrefix(cmd.Text, "/workload")); len(args) > 0 {
+		return c.handleWindow(ctx, teamID, args[0], members, openTasks), nil
+	}
+
 	// Analyze workload using TeamManager
-	workload := c.teamManager.AnalyzeWorkload(teamID, mockMembers, mockTasks)
+	workload := c.teamManager.AnalyzeWorkload(teamID, members, openTasks)
 
 	// Format and return results
 	response := c.formatWorkloadAnalysis(workload)
@@ -145,60 +162,74 @@ func (c *WorkloadCommand) formatWorkloadAnalysis(workload *domain.TeamWorkload)
 	return response.String()
 }
 
-// Mock data generators (would be replaced with database queries in production)
-func (c *WorkloadCommand) getMockTeamMembers(teamID string) []domain.TeamMember {
-	return []domain.TeamMember{
-		{
-			ID:       "member_1",
-			TeamID:   teamID,
-			Username: "alice",
-			Skills:   []string{"go", "postgresql", "docker"},
-			Capacity: 40.0,
-			Role:     "lead",
-			Current:  34.0,
-		},
-		{
-			ID:       "member_2",
-			TeamID:   teamID,
-			Username: "bob",
-			Skills:   []string{"react", "typescript", "css"},
-			Capacity: 40.0,
-			Role:     "senior",
-			Current:  37.0,
-		},
-		{
-			ID:       "member_3",
-			TeamID:   teamID,
-			Username: "carol",
-			Skills:   []string{"kubernetes", "docker", "aws"},
-			Capacity: 40.0,
-			Role:     "mid",
-			Current:  24.0,
-		},
+// handleWindow shows the workload planned for a period from the tasks' due dates
+func (c *WorkloadCommand) handleWindow(ctx context.Context, teamID, name string, members []domain.TeamMember, tasks []domain.Task) *domain.Response {
+	now := time.Now().In(services.BotLocation())
+	sprint := c.estimationService.GetSprintConfig(teamID)
+	window, err := domain.ParseWorkloadWindow(name, now, sprint.Start, sprint.LengthDays)
+	if err != nil {
+		return &domain.Response{
+			Text:      "❌ " + err.Error() + "\n\n**Example:** `/workload next_week`",
+			ParseMode: "Markdown",
+		}
+	}
+
+	workload := domain.AnalyzeWindowWorkload(teamID, members, tasks, window, now, c.calendarService.GetCalendar(ctx, teamID))
+
+	c.logger.Info("Window workload analysis completed",
+		"team_id", teamID,
+		"window", window.Name,
+		"members_count", len(workload.Members),
+		"total_utilization", workload.Utilization)
+
+	return &domain.Response{
+		Text:      formatWindowWorkload(workload),
+		ParseMode: "Markdown",
 	}
 }
 
-func (c *WorkloadCommand) getMockTasks() []domain.Task {
-	return []domain.Task{
-		{
-			ID:            "task_1",
-			AssignedTo:    "member_1",
-			EstimateHours: 20.0,
-			Status:        "in_progress",
-		},
-		{
-			ID:            "task_2",
-			AssignedTo:    "member_2",
-			EstimateHours: 25.0,
-			Status:        "todo",
-		},
-		{
-			ID:            "task_3",
-			AssignedTo:    "member_3",
-			EstimateHours: 15.0,
-			Status:        "todo",
-		},
+// formatWindowWorkload lists each member's planned hours and free time in the window,
+// most free first
+func formatWindowWorkload(workload *domain.WindowWorkload) string {
+	var response strings.Builder
+
+	window := workload.Window
+	last := window.End.AddDate(0, 0, -1)
+	response.WriteString(fmt.Sprintf("📅 **Workload: %s** (%s - %s)\n\n", window.Label, window.Start.Format("Jan 2"), last.Format("Jan 2")))
+
+	response.WriteString("**Team Overview:**\n")
+	response.WriteString(fmt.Sprintf("├── Capacity: %.1fh\n", workload.Available))
+	response.WriteString(fmt.Sprintf("├── Planned: %.1fh\n", workload.Allocated))
+	response.WriteString(fmt.Sprintf("└── %s Utilization: %.0f%%\n\n", getUtilizationEmoji(workload.Utilization), workload.Utilization*100))
+
+	members := append([]domain.MemberWorkload(nil), workload.Members...)
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].Capacity-members[i].Current > members[j].Capacity-members[j].Current
+	})
+
+	response.WriteString("👥 **Who Is Free:**\n")
+	undatedTotal := 0.0
+	for _, member := range members {
+		free := member.Capacity - member.Current
+		if free < 0 {
+			free = 0
+		}
+		response.WriteString(fmt.Sprintf("👤 **@%s** %s\n", member.Username, getStatusEmoji(member.Status)))
+		response.WriteString(fmt.Sprintf("├── %s Planned: %.1fh of %.1fh\n", getUtilizationBar(member.Utilization), member.Current, member.Capacity))
+		if undated := workload.Undated[member.MemberID]; undated > 0 {
+			undatedTotal += undated
+			response.WriteString(fmt.Sprintf("├── Free: %.1fh\n", free))
+			response.WriteString(fmt.Sprintf("└── Without due date: %.1fh\n\n", undated))
+		} else {
+			response.WriteString(fmt.Sprintf("└── Free: %.1fh\n\n", free))
+		}
+	}
+
+	if undatedTotal > 0 {
+		response.WriteString(fmt.Sprintf("ℹ️ %.1fh of open work has no due date and is not planned in this period. Set one with `/task id due YYYY-MM-DD`.\n", undatedTotal))
 	}
+
+	return response.String()
 }
 
 // Helper functions for formatting
@@ -228,7 +259,7 @@ func getStatusEmoji(status string) string {
 }
 
 func getUtilizationBar(utilization float64) string {
-	bars := int(utilization * 10)
+	bars := int(math.Min(math.Max(utilization, 0), 1) * 10)
 	filled := strings.Repeat("█", bars)
 	empty := strings.Repeat("░", 10-bars)
 	return filled + empty
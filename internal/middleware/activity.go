@@ -2,11 +2,15 @@ package middleware
 
 import (
 	"context"
+	"time"
 
 	"yordamchi-dev-bot/database"
 	"yordamchi-dev-bot/internal/domain"
 )
 
+// activityRetryDelay is the wait before logging an activity again after a failure
+const activityRetryDelay = 500 * time.Millisecond
+
 // ActivityMiddleware logs user activity for analytics
 type ActivityMiddleware struct {
 	db     *database.DB
@@ -28,19 +32,26 @@ func (m *ActivityMiddleware) Process(ctx context.Context, next domain.HandlerFun
 		response, err := next(ctx, cmd)
 
 		// Log activity after successful command execution
-		if err == nil && cmd.User != nil {
+		// Button presses are not commands, and their callback data may hold IDs
+		if err == nil && cmd.User != nil && (cmd.Callback == nil || metricName(cmd.Text) != textMessages) {
 			// Log user activity in background to avoid blocking response
 			go func() {
-				logErr := m.db.LogUserActivity(cmd.User.TelegramID, cmd.Text)
+				// The write is one transaction, so a failed attempt, e.g. on a busy SQLite
+				// database, is retried once without duplicating anything
+				logErr := m.db.LogUserActivity(cmd.User.TelegramID, domain.LogText(cmd.Text))
+				if logErr != nil {
+					time.Sleep(activityRetryDelay)
+					logErr = m.db.LogUserActivity(cmd.User.TelegramID, domain.LogText(cmd.Text))
+				}
 				if logErr != nil {
 					m.logger.Warn("Failed to log user activity",
 						"telegram_id", cmd.User.TelegramID,
-						"command", cmd.Text,
+						"command", domain.LogText(cmd.Text),
 						"error", logErr)
 				} else {
 					m.logger.Debug("User activity logged",
 						"telegram_id", cmd.User.TelegramID,
-						"command", cmd.Text)
+						"command", domain.LogText(cmd.Text))
 				}
 			}()
 		}
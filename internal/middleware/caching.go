@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/md5"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"yordamchi-dev-bot/internal/cache"
@@ -17,6 +19,24 @@ type CachingMiddleware struct {
 	logger       domain.Logger
 	cacheTTL     time.Duration
 	cacheableCommands map[string]bool
+	// lookups counts cache hits and misses per command
+	lookups      map[string]*CacheCommandStats
+	lookupsMutex sync.Mutex
+}
+
+// CacheCommandStats counts the cache lookups of one command
+type CacheCommandStats struct {
+	Command string
+	Hits    int64
+	Misses  int64
+}
+
+// HitRatio returns the share of lookups answered from the cache
+func (s CacheCommandStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
 }
 
 // NewCachingMiddleware creates a new caching middleware
@@ -33,6 +53,7 @@ func NewCachingMiddleware(logger domain.Logger) *CachingMiddleware {
 		logger:            logger,
 		cacheTTL:          10 * time.Minute,
 		cacheableCommands: cacheableCommands,
+		lookups:           make(map[string]*CacheCommandStats),
 	}
 }
 
@@ -57,15 +78,17 @@ func (m *CachingMiddleware) Process(ctx context.Context, next domain.HandlerFunc
 		if cachedResponse, found := m.cache.Get(cacheKey); found {
 			if response, ok := cachedResponse.(*domain.Response); ok {
 				m.logger.Debug("Cache hit", 
-					"command", cmd.Text, 
+					"command", domain.LogText(cmd.Text), 
 					"user_id", cmd.User.TelegramID,
 					"cache_key", cacheKey)
 
 				// Add cache indicator to response
+				m.recordLookup(baseCommand, true)
 				response.Text = "🔄 " + response.Text
 				return response, nil
 			}
 		}
+		m.recordLookup(baseCommand, false)
 
 		// Execute command
 		response, err := next(ctx, cmd)
@@ -80,7 +103,7 @@ func (m *CachingMiddleware) Process(ctx context.Context, next domain.HandlerFunc
 			m.cache.SetWithTTL(cacheKey, response, ttl)
 
 			m.logger.Debug("Response cached", 
-				"command", cmd.Text,
+				"command", domain.LogText(cmd.Text),
 				"user_id", cmd.User.TelegramID,
 				"cache_key", cacheKey,
 				"ttl", ttl)
@@ -110,12 +133,43 @@ func (m *CachingMiddleware) getCacheTTL(command string) time.Duration {
 	}
 }
 
+// recordLookup counts a cache hit or miss of a command
+func (m *CachingMiddleware) recordLookup(command string, hit bool) {
+	m.lookupsMutex.Lock()
+	defer m.lookupsMutex.Unlock()
+
+	stats := m.lookups[command]
+	if stats == nil {
+		stats = &CacheCommandStats{Command: command}
+		m.lookups[command] = stats
+	}
+	if hit {
+		stats.Hits++
+	} else {
+		stats.Misses++
+	}
+}
+
+// commandStats returns the cache lookups per command, by command name
+func (m *CachingMiddleware) commandStats() []CacheCommandStats {
+	m.lookupsMutex.Lock()
+	defer m.lookupsMutex.Unlock()
+
+	stats := make([]CacheCommandStats, 0, len(m.lookups))
+	for _, s := range m.lookups {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Command < stats[j].Command })
+	return stats
+}
+
 // GetCacheStats returns cache statistics for monitoring
 func (m *CachingMiddleware) GetCacheStats() map[string]interface{} {
 	return map[string]interface{}{
 		"cache_size": m.cache.Size(),
 		"ttl_minutes": int(m.cacheTTL.Minutes()),
 		"cacheable_commands": len(m.cacheableCommands),
+		"command_stats": m.commandStats(),
 	}
 }
 
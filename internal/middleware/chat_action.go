@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Telegram shows a chat action for about 5 seconds, so it is refreshed a little sooner
+const (
+	chatActionDelay   = time.Second
+	chatActionRefresh = 4 * time.Second
+)
+
+// ChatActionMiddleware shows the chat action declared by a long-running handler while it
+// runs, and "typing" while any other command takes longer than a second
+type ChatActionMiddleware struct {
+	sender  domain.ChatActionSender
+	delay   time.Duration
+	refresh time.Duration
+	logger  domain.Logger
+	mutex   sync.RWMutex
+}
+
+// NewChatActionMiddleware creates a new chat action middleware. It does nothing until
+// a sender is set, since the bot that delivers actions is created after the router.
+func NewChatActionMiddleware(logger domain.Logger) *ChatActionMiddleware {
+	return &ChatActionMiddleware{
+		delay:   chatActionDelay,
+		refresh: chatActionRefresh,
+		logger:  logger,
+	}
+}
+
+// SetSender sets where chat actions are delivered
+func (m *ChatActionMiddleware) SetSender(sender domain.ChatActionSender) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sender = sender
+}
+
+// Process implements the Middleware interface
+func (m *ChatActionMiddleware) Process(ctx context.Context, next domain.HandlerFunc) domain.HandlerFunc {
+	return func(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+		m.mutex.RLock()
+		sender := m.sender
+		m.mutex.RUnlock()
+		if sender == nil || cmd.Chat == nil {
+			return next(ctx, cmd)
+		}
+
+		// Declared actions show at once, since the handler is known to be slow
+		action, delay := domain.ChatActionTyping, m.delay
+		if declared, ok := domain.GetChatActionFromContext(ctx); ok {
+			action, delay = declared, 0
+		}
+
+		done, stopped := make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(stopped)
+			m.showAction(ctx, sender, cmd.Chat.ID, action, delay, done)
+		}()
+
+		response, err := next(ctx, cmd)
+
+		// Wait for an in-flight action so it cannot arrive after the reply
+		close(done)
+		<-stopped
+		return response, err
+	}
+}
+
+// showAction sends the action once the handler has run for the delay, then keeps it
+// visible until the handler returns
+func (m *ChatActionMiddleware) showAction(ctx context.Context, sender domain.ChatActionSender, chatID int64, action string, delay time.Duration, done <-chan struct{}) {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-done:
+		return
+	case <-ctx.Done():
+		return
+	}
+
+	ticker := time.NewTicker(m.refresh)
+	defer ticker.Stop()
+
+	for {
+		if err := sender.SendChatAction(ctx, chatID, action); err != nil {
+			m.logger.Debug("Failed to send chat action", "chat_id", chatID, "action", action, "error", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
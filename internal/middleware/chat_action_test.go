@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+type recordingActionSender struct {
+	mutex   sync.Mutex
+	actions []string
+}
+
+func (s *recordingActionSender) SendChatAction(ctx context.Context, chatID int64, action string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.actions = append(s.actions, action)
+	return nil
+}
+
+func (s *recordingActionSender) sent() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.actions...)
+}
+
+func TestChatActionMiddleware(t *testing.T) {
+	sender := &recordingActionSender{}
+	chatActions := NewChatActionMiddleware(&MockLogger{})
+	chatActions.delay = 20 * time.Millisecond
+	chatActions.refresh = 30 * time.Millisecond
+	chatActions.SetSender(sender)
+
+	slowHandler := func(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+		time.Sleep(100 * time.Millisecond)
+		return &domain.Response{Text: "done"}, nil
+	}
+	chat := &domain.Chat{ID: 1}
+
+	// Fast commands never show an action
+	chatActions.Process(context.Background(), mockHandler)(context.Background(), &domain.Command{Text: "/ping", Chat: chat})
+	if actions := sender.sent(); len(actions) != 0 {
+		t.Fatalf("expected no actions for a fast command, got %v", actions)
+	}
+
+	// Slow commands show the action right after the delay and refresh it until they finish
+	chatActions.Process(context.Background(), slowHandler)(context.Background(), &domain.Command{Text: "/analyze shop", Chat: chat})
+	actions := sender.sent()
+	if len(actions) < 2 || actions[0] != domain.ChatActionTyping {
+		t.Fatalf("expected refreshed typing actions, got %v", actions)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if after := sender.sent(); len(after) != len(actions) {
+		t.Errorf("expected no actions after the handler returned, got %v", after[len(actions):])
+	}
+
+	// Actions declared by long-running handlers show without the delay
+	declared := &recordingActionSender{}
+	chatActions.SetSender(declared)
+	chatActions.delay = time.Hour
+	ctx := domain.WithChatAction(context.Background(), domain.ChatActionUploadDocument)
+	chatActions.Process(ctx, slowHandler)(ctx, &domain.Command{Text: "/export_json", Chat: chat})
+	if actions := declared.sent(); len(actions) == 0 || actions[0] != domain.ChatActionUploadDocument {
+		t.Errorf("expected upload_document declared by the handler, got %v", actions)
+	}
+}
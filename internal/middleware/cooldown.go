@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// CooldownMiddleware lets a chat run the commands configured with a cooldown only once per
+// cooldown, independent of the per-user rate limit. A run that fails does not count.
+type CooldownMiddleware struct {
+	cooldowns *services.CooldownService
+	logger    domain.Logger
+}
+
+// NewCooldownMiddleware creates a new cooldown middleware
+func NewCooldownMiddleware(cooldowns *services.CooldownService, logger domain.Logger) *CooldownMiddleware {
+	return &CooldownMiddleware{
+		cooldowns: cooldowns,
+		logger:    logger,
+	}
+}
+
+// Process implements the Middleware interface
+func (m *CooldownMiddleware) Process(ctx context.Context, next domain.HandlerFunc) domain.HandlerFunc {
+	return func(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+		if cmd.Chat == nil || !strings.HasPrefix(cmd.Text, "/") {
+			return next(ctx, cmd)
+		}
+
+		rule, wait, release := m.cooldowns.Begin(cmd.Chat.ID, cmd.Text)
+		if wait > 0 {
+			m.logger.Info("Command on cooldown",
+				"chat_id", cmd.Chat.ID,
+				"command", rule,
+				"wait", wait.Round(time.Second))
+			return &domain.Response{
+				Text:      fmt.Sprintf("⏳ `%s` bu chatda yaqinda ishlatilgan. %s dan keyin qayta urinib ko'ring. Limitlar: /quota", rule, formatCooldown(wait)),
+				ParseMode: "Markdown",
+			}, nil
+		}
+
+		response, err := next(ctx, cmd)
+		if err != nil || (response != nil && strings.HasPrefix(response.Text, "❌")) {
+			release()
+		}
+		return response, err
+	}
+}
+
+// formatCooldown formats the time left of a cooldown in Uzbek, e.g. "1 soat 5 daqiqa"
+func formatCooldown(wait time.Duration) string {
+	minutes := int((wait + time.Minute - 1) / time.Minute)
+	hours, minutes := minutes/60, minutes%60
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%d soat %d daqiqa", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%d soat", hours)
+	default:
+		return fmt.Sprintf("%d daqiqa", minutes)
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// maxHintedText leaves room below Telegram's 4096 character message limit for a hint
+const maxHintedText = 3800
+
+// HintMiddleware records the commands each user runs and adds an occasional "did you know"
+// hint about a related command they never used to the reply
+type HintMiddleware struct {
+	hints  *services.HintService
+	logger domain.Logger
+}
+
+// NewHintMiddleware creates a new hint middleware
+func NewHintMiddleware(hints *services.HintService, logger domain.Logger) *HintMiddleware {
+	return &HintMiddleware{
+		hints:  hints,
+		logger: logger,
+	}
+}
+
+// Process implements the Middleware interface
+func (m *HintMiddleware) Process(ctx context.Context, next domain.HandlerFunc) domain.HandlerFunc {
+	return func(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+		response, err := next(ctx, cmd)
+
+		if err != nil || cmd.User == nil || !strings.HasPrefix(cmd.Text, "/") {
+			return response, err
+		}
+		command := strings.ToLower(strings.Fields(cmd.Text)[0])
+		command, _, _ = strings.Cut(command, "@")
+
+		hint := m.hints.Observe(cmd.User.TelegramID, command)
+		if hint == nil || response == nil || strings.HasPrefix(response.Text, "❌") || len(response.Text) > maxHintedText {
+			return response, err
+		}
+
+		m.logger.Debug("Showing hint", "telegram_id", cmd.User.TelegramID, "after", command, "suggest", hint.Suggest)
+		// Copy the reply so a response shared by the handler or the cache keeps its text
+		hinted := *response
+		hinted.Text += formatHint(hint, response.ParseMode)
+		return &hinted, err
+	}
+}
+
+// formatHint renders a hint for a reply in the given parse mode
+func formatHint(hint *services.Hint, parseMode string) string {
+	suggest := hint.Suggest
+	if parseMode == "Markdown" {
+		// Underscores in command names would start italics
+		suggest = strings.ReplaceAll(suggest, "_", "\\_")
+	}
+	return fmt.Sprintf("\n\n💡 Did you know? %s %s. Turn tips off with /hints off.", suggest, hint.Text)
+}
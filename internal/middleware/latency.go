@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"math"
+	"time"
+)
+
+// latencyBuckets are the upper bounds of the latency histogram buckets. A last, unbounded
+// bucket holds slower requests.
+var latencyBuckets = []time.Duration{
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+}
+
+// LatencyBuckets returns the upper bounds of the histogram buckets
+func LatencyBuckets() []time.Duration {
+	return latencyBuckets
+}
+
+// LatencyHistogram counts durations in fixed buckets. Percentiles are interpolated within
+// a bucket, accurate enough to spot spikes at a fixed size per command.
+type LatencyHistogram struct {
+	// Counts has one count per bucket of latencyBuckets plus the unbounded bucket
+	Counts []int64
+	Count  int64
+	Sum    time.Duration
+	// Max is the slowest duration seen, the upper end of the unbounded bucket
+	Max time.Duration
+}
+
+// NewLatencyHistogram creates an empty histogram
+func NewLatencyHistogram() LatencyHistogram {
+	return LatencyHistogram{Counts: make([]int64, len(latencyBuckets)+1)}
+}
+
+// Observe counts one duration
+func (h *LatencyHistogram) Observe(duration time.Duration) {
+	bucket := len(latencyBuckets)
+	for i, bound := range latencyBuckets {
+		if duration <= bound {
+			bucket = i
+			break
+		}
+	}
+	h.Counts[bucket]++
+	h.Count++
+	h.Sum += duration
+	if duration > h.Max {
+		h.Max = duration
+	}
+}
+
+// Clone returns a copy that does not share its counts
+func (h LatencyHistogram) Clone() LatencyHistogram {
+	clone := h
+	clone.Counts = append([]int64(nil), h.Counts...)
+	return clone
+}
+
+// Since returns the durations observed after an earlier copy of the same histogram was taken
+func (h LatencyHistogram) Since(earlier LatencyHistogram) LatencyHistogram {
+	delta := h.Clone()
+	if len(earlier.Counts) != len(h.Counts) {
+		return delta
+	}
+	for i := range delta.Counts {
+		delta.Counts[i] -= earlier.Counts[i]
+	}
+	delta.Count -= earlier.Count
+	delta.Sum -= earlier.Sum
+	return delta
+}
+
+// Average returns the mean duration
+func (h LatencyHistogram) Average() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// Percentile returns the duration below which the share q of the durations fall, e.g. 0.95
+func (h LatencyHistogram) Percentile(q float64) time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+
+	rank := q * float64(h.Count)
+	var seen int64
+	for i, count := range h.Counts {
+		if count == 0 || float64(seen+count) < rank {
+			seen += count
+			continue
+		}
+
+		var lower, upper time.Duration
+		if i > 0 {
+			lower = latencyBuckets[i-1]
+		}
+		if i < len(latencyBuckets) {
+			upper = latencyBuckets[i]
+		} else {
+			upper = h.Max
+		}
+		if upper < lower {
+			upper = lower
+		}
+		fraction := math.Max(0, (rank-float64(seen))/float64(count))
+		return lower + time.Duration(fraction*float64(upper-lower))
+	}
+	return h.Max
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyHistogramPercentiles(t *testing.T) {
+	histogram := NewLatencyHistogram()
+	for i := 0; i < 90; i++ {
+		histogram.Observe(20 * time.Millisecond)
+	}
+	for i := 0; i < 10; i++ {
+		histogram.Observe(4 * time.Second)
+	}
+
+	if p50 := histogram.Percentile(0.50); p50 < 10*time.Millisecond || p50 > 25*time.Millisecond {
+		t.Errorf("p50 = %v, want within the 10-25ms bucket", p50)
+	}
+	if p95 := histogram.Percentile(0.95); p95 < 2500*time.Millisecond || p95 > 5*time.Second {
+		t.Errorf("p95 = %v, want within the 2.5-5s bucket of the spike", p95)
+	}
+	if histogram.Average() != (90*20*time.Millisecond+10*4*time.Second)/100 {
+		t.Errorf("average = %v", histogram.Average())
+	}
+	if NewLatencyHistogram().Percentile(0.99) != 0 {
+		t.Error("expected 0 for an empty histogram")
+	}
+}
+
+func TestLatencyHistogramSlowestBucket(t *testing.T) {
+	histogram := NewLatencyHistogram()
+	histogram.Observe(90 * time.Second)
+	if p99 := histogram.Percentile(0.99); p99 < time.Minute || p99 > 90*time.Second {
+		t.Errorf("p99 = %v, want between the last bound and the maximum", p99)
+	}
+}
+
+func TestLatencyHistogramSince(t *testing.T) {
+	histogram := NewLatencyHistogram()
+	for i := 0; i < 50; i++ {
+		histogram.Observe(5 * time.Millisecond)
+	}
+	earlier := histogram.Clone()
+	for i := 0; i < 20; i++ {
+		histogram.Observe(8 * time.Second)
+	}
+
+	recent := histogram.Since(earlier)
+	if recent.Count != 20 || recent.Sum != 160*time.Second {
+		t.Errorf("recent = %d requests, %v, want only the 20 slow ones", recent.Count, recent.Sum)
+	}
+	if p50 := recent.Percentile(0.5); p50 < 5*time.Second {
+		t.Errorf("recent p50 = %v, want the slow requests only", p50)
+	}
+	if earlier.Count != 50 {
+		t.Errorf("earlier copy changed to %d requests", earlier.Count)
+	}
+}
+
+func TestMetricName(t *testing.T) {
+	cases := map[string]string{
+		"/analyze build a login page": "/analyze",
+		"/Metrics@yordamchi_bot ai":   "/metrics",
+		"how do I deploy?":            textMessages,
+		"":                            textMessages,
+	}
+	for text, want := range cases {
+		if got := metricName(text); got != want {
+			t.Errorf("metricName(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
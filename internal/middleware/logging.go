@@ -26,7 +26,7 @@ func (m *LoggingMiddleware) Process(ctx context.Context, next domain.HandlerFunc
 		
 		// Log request start
 		m.logger.Info("Command processing started",
-			"command", cmd.Text,
+			"command", domain.LogText(cmd.Text),
 			"user_id", cmd.User.TelegramID,
 			"username", cmd.User.Username,
 			"timestamp", start)
@@ -38,13 +38,13 @@ func (m *LoggingMiddleware) Process(ctx context.Context, next domain.HandlerFunc
 		
 		if err != nil {
 			m.logger.Error("Command processing failed",
-				"command", cmd.Text,
+				"command", domain.LogText(cmd.Text),
 				"user_id", cmd.User.TelegramID,
 				"duration", duration,
 				"error", err)
 		} else {
 			m.logger.Info("Command processing completed",
-				"command", cmd.Text,
+				"command", domain.LogText(cmd.Text),
 				"user_id", cmd.User.TelegramID,
 				"duration", duration,
 				"response_length", len(response.Text))
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -18,23 +19,47 @@ type MetricsMiddleware struct {
 	commandMetrics   map[string]*CommandMetrics
 	mutex           sync.RWMutex
 	startTime       time.Time
+	// Telegram rate limiting of outgoing messages: 429 answers, and queued messages that
+	// were delivered on a retry or given up on
+	telegramRateLimited int64
+	telegramRetried     int64
+	telegramDropped     int64
+	// telegramCalls tracks every Bot API call by method
+	telegramCalls map[string]*TelegramCallMetrics
 }
 
+// maxTrackedCommands limits the commands with their own metrics; others share otherCommand
+const maxTrackedCommands = 100
+
+// Metric names of requests that are not a tracked command
+const (
+	textMessages = "text"
+	otherCommand = "other"
+)
+
 // CommandMetrics tracks metrics for individual commands
 type CommandMetrics struct {
 	Count            int64
 	TotalDuration    time.Duration
-	AverageDuration  time.Duration
+	Latency          LatencyHistogram
 	LastUsed         time.Time
 	ErrorCount       int64
 	mutex           sync.RWMutex
 }
 
+// TelegramCallMetrics tracks the Bot API calls of one method
+type TelegramCallMetrics struct {
+	Count   int64
+	Errors  int64
+	Latency LatencyHistogram
+}
+
 // NewMetricsMiddleware creates a new metrics collection middleware
 func NewMetricsMiddleware(logger domain.Logger) *MetricsMiddleware {
 	return &MetricsMiddleware{
 		logger:         logger,
 		commandMetrics: make(map[string]*CommandMetrics),
+		telegramCalls:  make(map[string]*TelegramCallMetrics),
 		startTime:      time.Now(),
 	}
 }
@@ -54,7 +79,7 @@ func (m *MetricsMiddleware) Process(ctx context.Context, next domain.HandlerFunc
 		duration := time.Since(startTime)
 		
 		// Update metrics
-		m.updateCommandMetrics(cmd.Text, duration, err)
+		m.updateCommandMetrics(metricName(cmd.Text), duration, err)
 		
 		// Update success/failure counters
 		if err != nil {
@@ -66,7 +91,7 @@ func (m *MetricsMiddleware) Process(ctx context.Context, next domain.HandlerFunc
 		// Log performance metrics for slow commands
 		if duration > 2*time.Second {
 			m.logger.Warn("Slow command execution",
-				"command", cmd.Text,
+				"command", domain.LogText(cmd.Text),
 				"user_id", cmd.User.TelegramID,
 				"duration", duration,
 				"threshold", "2s")
@@ -76,13 +101,73 @@ func (m *MetricsMiddleware) Process(ctx context.Context, next domain.HandlerFunc
 	}
 }
 
+// RecordTelegramRateLimit counts a 429 answer of the Telegram Bot API
+func (m *MetricsMiddleware) RecordTelegramRateLimit() {
+	atomic.AddInt64(&m.telegramRateLimited, 1)
+}
+
+// RecordTelegramCall counts a Bot API call with its latency; failed calls are network
+// errors and 4xx or 5xx answers
+func (m *MetricsMiddleware) RecordTelegramCall(method string, failed bool, duration time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	calls := m.telegramCalls[method]
+	if calls == nil {
+		if len(m.telegramCalls) >= maxTrackedCommands {
+			method = otherCommand
+			calls = m.telegramCalls[method]
+		}
+		if calls == nil {
+			calls = &TelegramCallMetrics{Latency: NewLatencyHistogram()}
+			m.telegramCalls[method] = calls
+		}
+	}
+	calls.Count++
+	if failed {
+		calls.Errors++
+	}
+	calls.Latency.Observe(duration)
+}
+
+// TelegramCalls returns a copy of the Bot API call metrics, by method
+func (m *MetricsMiddleware) TelegramCalls() map[string]TelegramCallMetrics {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	calls := make(map[string]TelegramCallMetrics, len(m.telegramCalls))
+	for method, metrics := range m.telegramCalls {
+		calls[method] = TelegramCallMetrics{
+			Count:   metrics.Count,
+			Errors:  metrics.Errors,
+			Latency: metrics.Latency.Clone(),
+		}
+	}
+	return calls
+}
+
+// RecordTelegramRetry counts a rate limited message that was delivered on a retry, or
+// dropped after the last one
+func (m *MetricsMiddleware) RecordTelegramRetry(delivered bool) {
+	if delivered {
+		atomic.AddInt64(&m.telegramRetried, 1)
+	} else {
+		atomic.AddInt64(&m.telegramDropped, 1)
+	}
+}
+
 // updateCommandMetrics updates metrics for a specific command
 func (m *MetricsMiddleware) updateCommandMetrics(command string, duration time.Duration, err error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	if m.commandMetrics[command] == nil {
-		m.commandMetrics[command] = &CommandMetrics{}
+		if len(m.commandMetrics) >= maxTrackedCommands {
+			command = otherCommand
+		}
+		if m.commandMetrics[command] == nil {
+			m.commandMetrics[command] = &CommandMetrics{Latency: NewLatencyHistogram()}
+		}
 	}
 
 	metrics := m.commandMetrics[command]
@@ -93,9 +178,7 @@ func (m *MetricsMiddleware) updateCommandMetrics(command string, duration time.D
 	metrics.Count++
 	metrics.LastUsed = time.Now()
 	metrics.TotalDuration += duration
-
-	// Calculate average duration
-	metrics.AverageDuration = time.Duration(int64(metrics.TotalDuration) / metrics.Count)
+	metrics.Latency.Observe(duration)
 
 	// Update error count
 	if err != nil {
@@ -128,11 +211,39 @@ func (m *MetricsMiddleware) GetMetrics() map[string]interface{} {
 		"requests_per_minute":  m.getRequestsPerMinute(totalRequests, uptime),
 		"command_metrics":      m.getCommandMetricsData(),
 		"top_commands":         m.getTopCommands(5),
+		"telegram_rate_limited": atomic.LoadInt64(&m.telegramRateLimited),
+		"telegram_retried":      atomic.LoadInt64(&m.telegramRetried),
+		"telegram_dropped":      atomic.LoadInt64(&m.telegramDropped),
 	}
 
 	return metrics
 }
 
+// LatencyHistograms returns a copy of each command's latency histogram, by command name
+func (m *MetricsMiddleware) LatencyHistograms() map[string]LatencyHistogram {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	histograms := make(map[string]LatencyHistogram, len(m.commandMetrics))
+	for command, metrics := range m.commandMetrics {
+		metrics.mutex.RLock()
+		histograms[command] = metrics.Latency.Clone()
+		metrics.mutex.RUnlock()
+	}
+	return histograms
+}
+
+// metricName returns the command of a message, without arguments or the bot's @username,
+// so metrics never hold message text. Plain messages share one name.
+func metricName(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+		return textMessages
+	}
+	command, _, _ := strings.Cut(strings.ToLower(fields[0]), "@")
+	return command
+}
+
 // getRequestsPerMinute calculates requests per minute
 func (m *MetricsMiddleware) getRequestsPerMinute(totalRequests int64, uptime time.Duration) float64 {
 	minutes := uptime.Minutes()
@@ -150,7 +261,10 @@ func (m *MetricsMiddleware) getCommandMetricsData() map[string]interface{} {
 		metrics.mutex.RLock()
 		cmdMetrics[cmd] = map[string]interface{}{
 			"count":             metrics.Count,
-			"average_duration":  metrics.AverageDuration.Milliseconds(),
+			"average_duration":  metrics.Latency.Average().Milliseconds(),
+			"p50_ms":            metrics.Latency.Percentile(0.50).Milliseconds(),
+			"p95_ms":            metrics.Latency.Percentile(0.95).Milliseconds(),
+			"p99_ms":            metrics.Latency.Percentile(0.99).Milliseconds(),
 			"total_duration":    metrics.TotalDuration.Milliseconds(),
 			"error_count":       metrics.ErrorCount,
 			"last_used":         metrics.LastUsed.Format(time.RFC3339),
@@ -220,7 +334,11 @@ func (m *MetricsMiddleware) ResetMetrics() {
 	atomic.StoreInt64(&m.totalRequests, 0)
 	atomic.StoreInt64(&m.successfulRequests, 0)
 	atomic.StoreInt64(&m.failedRequests, 0)
+	atomic.StoreInt64(&m.telegramRateLimited, 0)
+	atomic.StoreInt64(&m.telegramRetried, 0)
+	atomic.StoreInt64(&m.telegramDropped, 0)
 	m.commandMetrics = make(map[string]*CommandMetrics)
+	m.telegramCalls = make(map[string]*TelegramCallMetrics)
 	m.startTime = time.Now()
 
 	m.logger.Info("Metrics reset")
@@ -44,10 +44,13 @@ func (m *RateLimitMiddleware) Process(ctx context.Context, next domain.HandlerFu
 			m.logger.Warn("User rate limited",
 				"user_id", userID,
 				"username", cmd.User.Username,
-				"command", cmd.Text)
+				"command", domain.LogText(cmd.Text))
+
+			_, resetAt := m.Remaining(userID)
+			retry := int(time.Until(resetAt).Seconds()) + 1
 
 			return &domain.Response{
-				Text:      fmt.Sprintf("⚠️ Juda ko'p so'rov! %d soniyadan keyin qayta urinib ko'ring.", int(m.window.Seconds())),
+				Text:      fmt.Sprintf("⚠️ Juda ko'p so'rov! %d soniyadan keyin qayta urinib ko'ring. Limitlar: /quota", retry),
 				ParseMode: "HTML",
 			}, nil
 		}
@@ -89,6 +92,51 @@ func (m *RateLimitMiddleware) isRateLimited(userID int64) bool {
 	return len(limit.requests) >= m.maxReqs
 }
 
+// Remaining returns how many requests the user has left in the current window
+// and when the oldest counted request expires
+func (m *RateLimitMiddleware) Remaining(userID int64) (int, time.Time) {
+	now := time.Now()
+
+	m.mutex.RLock()
+	limit, exists := m.limits[userID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return m.maxReqs, now
+	}
+
+	limit.mutex.Lock()
+	defer limit.mutex.Unlock()
+
+	cutoff := now.Add(-m.window)
+	count := 0
+	resetAt := now
+	for _, reqTime := range limit.requests {
+		if reqTime.After(cutoff) {
+			if count == 0 {
+				resetAt = reqTime.Add(m.window)
+			}
+			count++
+		}
+	}
+
+	remaining := m.maxReqs - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, resetAt
+}
+
+// Limit returns the maximum number of requests per window
+func (m *RateLimitMiddleware) Limit() int {
+	return m.maxReqs
+}
+
+// Window returns the rate limit window
+func (m *RateLimitMiddleware) Window() time.Duration {
+	return m.window
+}
+
 // recordRequest records a new request for the user
 func (m *RateLimitMiddleware) recordRequest(userID int64) {
 	m.mutex.Lock()
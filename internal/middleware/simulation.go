@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// SimulationMiddleware adds the latency injected with /simulate latency to every command
+type SimulationMiddleware struct {
+	simulator *services.Simulator
+	logger    domain.Logger
+}
+
+// NewSimulationMiddleware creates a new fault injection middleware
+func NewSimulationMiddleware(simulator *services.Simulator, logger domain.Logger) *SimulationMiddleware {
+	return &SimulationMiddleware{
+		simulator: simulator,
+		logger:    logger,
+	}
+}
+
+// Process implements the Middleware interface
+func (m *SimulationMiddleware) Process(ctx context.Context, next domain.HandlerFunc) domain.HandlerFunc {
+	return func(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+		// /simulate itself is never delayed so faults can always be turned off
+		latency := m.simulator.Latency()
+		if latency > 0 && !strings.HasPrefix(cmd.Text, "/simulate") {
+			m.logger.Warn("Injecting simulated latency", "command", domain.LogText(cmd.Text), "latency", latency)
+			select {
+			case <-time.After(latency):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		return next(ctx, cmd)
+	}
+}
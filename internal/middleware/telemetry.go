@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+	"yordamchi-dev-bot/internal/services"
+)
+
+// TelemetryMiddleware counts commands and failures for the anonymized usage statistics
+type TelemetryMiddleware struct {
+	telemetry *services.Telemetry
+}
+
+// NewTelemetryMiddleware creates a new telemetry middleware
+func NewTelemetryMiddleware(telemetry *services.Telemetry) *TelemetryMiddleware {
+	return &TelemetryMiddleware{telemetry: telemetry}
+}
+
+// Process implements the Middleware interface
+func (m *TelemetryMiddleware) Process(ctx context.Context, next domain.HandlerFunc) domain.HandlerFunc {
+	return func(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+		// Only the command name is recorded, never arguments. Button presses run through
+		// the chain with their callback data as text, which may hold IDs, so they are
+		// left out like plain messages.
+		command := metricName(cmd.Text)
+		if command == textMessages {
+			command = ""
+		}
+
+		response, err := next(ctx, cmd)
+
+		if m.telemetry.Enabled() && command != "" {
+			failed := err != nil || (response != nil && strings.HasPrefix(response.Text, "❌"))
+			m.telemetry.RecordCommand(command, failed)
+		}
+
+		return response, err
+	}
+}
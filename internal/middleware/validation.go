@@ -14,6 +14,8 @@ type ValidationMiddleware struct {
 	logger     domain.Logger
 	maxLength  int
 	validators map[string]*CommandValidator
+	// longText lists commands that accept multi-line text with their own length limit
+	longText map[string]int
 }
 
 // CommandValidator defines validation rules for specific commands
@@ -55,30 +57,49 @@ func NewValidationMiddleware(logger domain.Logger) *ValidationMiddleware {
 		Usage:       "/user username",
 	}
 
+	// Commands carrying multi-line content keep their newlines and get a larger limit
+	longText := map[string]int{
+		"/snippet":   4000,
+		"/translate": 2000,
+		"/tr":        2000,
+		"/chat":      2000,
+	}
+
 	return &ValidationMiddleware{
 		logger:     logger,
 		maxLength:  500, // Maximum command length
 		validators: validators,
+		longText:   longText,
 	}
 }
 
 // Process implements the Middleware interface
 func (m *ValidationMiddleware) Process(ctx context.Context, next domain.HandlerFunc) domain.HandlerFunc {
 	return func(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+		maxLength := m.maxLength
+		longLimit, isLongText := m.longText[m.commandName(cmd.Text)]
+		if isLongText {
+			maxLength = longLimit
+		}
+
 		// Basic length validation
-		if len(cmd.Text) > m.maxLength {
+		if len(cmd.Text) > maxLength {
 			m.logger.Warn("Command too long",
 				"user_id", cmd.User.TelegramID,
 				"command_length", len(cmd.Text),
-				"max_length", m.maxLength)
+				"max_length", maxLength)
 
 			return &domain.Response{
-				Text:      fmt.Sprintf("❌ Buyruq juda uzun. Maksimal uzunlik: %d belgi", m.maxLength),
+				Text:      fmt.Sprintf("❌ Buyruq juda uzun. Maksimal uzunlik: %d belgi", maxLength),
 				ParseMode: "Markdown",
 			}, nil
 		}
 
 		// Sanitize input
+		if isLongText {
+			cmd.Text = m.sanitizeMultiline(cmd.Text)
+			return next(ctx, cmd)
+		}
 		cmd.Text = m.sanitizeInput(cmd.Text)
 
 		// Get command parts
@@ -135,7 +156,7 @@ func (m *ValidationMiddleware) Process(ctx context.Context, next domain.HandlerF
 		if !validator.Pattern.MatchString(cmd.Text) {
 			m.logger.Warn("Command pattern validation failed",
 				"user_id", cmd.User.TelegramID,
-				"command", cmd.Text,
+				"command", domain.LogText(cmd.Text),
 				"pattern", validator.Pattern.String())
 
 			return &domain.Response{
@@ -173,6 +194,31 @@ func (m *ValidationMiddleware) sanitizeInput(input string) string {
 	return input
 }
 
+// sanitizeMultiline cleans input while preserving line breaks and indentation
+func (m *ValidationMiddleware) sanitizeMultiline(input string) string {
+	lines := strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	input = strings.TrimSpace(strings.Join(lines, "\n"))
+
+	// Remove potentially dangerous characters (basic XSS prevention)
+	input = strings.ReplaceAll(input, "<script>", "")
+	input = strings.ReplaceAll(input, "</script>", "")
+	input = strings.ReplaceAll(input, "javascript:", "")
+
+	return input
+}
+
+// commandName returns the lowercased first word of the command text
+func (m *ValidationMiddleware) commandName(text string) string {
+	parts := strings.Fields(text)
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.ToLower(parts[0])
+}
+
 // getExampleUsage returns example usage for commands
 func (m *ValidationMiddleware) getExampleUsage(command string) string {
 	examples := map[string]string{
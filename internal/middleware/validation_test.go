@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"yordamchi-dev-bot/internal/domain"
@@ -169,6 +170,36 @@ func TestValidationMiddleware_InputSanitization(t *testing.T) {
 	}
 }
 
+func TestValidationMiddleware_LongTextKeepsNewlines(t *testing.T) {
+	logger := &MockLogger{}
+	validation := NewValidationMiddleware(logger)
+
+	original := "/snippet save deploy   \n1. Run tests\n2. Tag release\n" + strings.Repeat("x", 600)
+
+	cmd := &domain.Command{
+		Text: original,
+		User: &domain.User{
+			TelegramID: 12345,
+			FirstName:  "Test",
+		},
+	}
+
+	handler := validation.Process(context.Background(), func(ctx context.Context, cmd *domain.Command) (*domain.Response, error) {
+		if !strings.Contains(cmd.Text, "deploy\n1. Run tests\n2. Tag release\n") {
+			t.Errorf("Expected newlines to be preserved, got %q", cmd.Text)
+		}
+		return mockHandler(ctx, cmd)
+	})
+
+	response, err := handler(context.Background(), cmd)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if response.Text != "Mock response" {
+		t.Errorf("Expected long snippet to pass validation, got %q", response.Text)
+	}
+}
+
 // Helper function to check if string contains substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && findSubstring(s, substr)
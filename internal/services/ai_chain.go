@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// TextCompleter generates free-form text from a prompt
+type TextCompleter interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+	IsConfigured() bool
+}
+
+// namedCompleter pairs a completer with a provider name for logging
+type namedCompleter struct {
+	name      string
+	completer TextCompleter
+}
+
+// AIChain sends prompts to the configured AI providers using the same
+// Claude → OpenAI → Gemini fallback order as task analysis
+type AIChain struct {
+	providers []namedCompleter
+	telemetry *Telemetry
+	simulator *Simulator
+	stats     *AIStats
+	logger    domain.Logger
+}
+
+// NewAIChain creates an AI chain with all supported providers
+func NewAIChain(logger domain.Logger) *AIChain {
+	return &AIChain{
+		providers: []namedCompleter{
+			{name: "claude", completer: NewClaudeService(logger)},
+			{name: "openai", completer: NewOpenAIService(logger)},
+			{name: "gemini", completer: NewGeminiService(logger)},
+		},
+		logger: logger,
+	}
+}
+
+// SetTelemetry reports which provider answered each completion in the usage statistics
+func (c *AIChain) SetTelemetry(telemetry *Telemetry) {
+	c.telemetry = telemetry
+}
+
+// SetSimulator lets /simulate ai_failure make the AI providers fail
+func (c *AIChain) SetSimulator(simulator *Simulator) {
+	c.simulator = simulator
+}
+
+// SetAIStats counts each provider call in the AI statistics of /metrics
+func (c *AIChain) SetAIStats(stats *AIStats) {
+	c.stats = stats
+}
+
+// IsConfigured returns true if at least one AI provider is configured
+func (c *AIChain) IsConfigured() bool {
+	for _, provider := range c.providers {
+		if provider.completer.IsConfigured() {
+			return true
+		}
+	}
+	return false
+}
+
+// Complete returns the response of the first configured provider that succeeds
+func (c *AIChain) Complete(ctx context.Context, prompt string) (string, error) {
+	var lastErr error
+	for _, provider := range c.providers {
+		if !provider.completer.IsConfigured() {
+			continue
+		}
+
+		var response string
+		err := c.simulator.AIError(provider.name)
+		if err == nil {
+			response, err = provider.completer.Complete(ctx, prompt)
+		}
+		if err == nil && response != "" {
+			c.logger.Debug("AI completion succeeded", "provider", provider.name)
+			c.telemetry.RecordAIProvider(provider.name)
+			c.stats.Record(provider.name, EstimateTokens(prompt)+EstimateTokens(response), nil)
+			return response, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("empty response from %s", provider.name)
+		}
+		c.stats.Record(provider.name, 0, err)
+
+		c.logger.Warn("AI completion failed, trying next provider", "provider", provider.name, "error", err)
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		return "", fmt.Errorf("no AI provider configured")
+	}
+	return "", fmt.Errorf("all AI providers failed: %w", lastErr)
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// aiCheckPrompt is the smallest useful prompt for checking a provider
+const aiCheckPrompt = "Reply with the single word OK."
+
+// aiCheckTimeout limits each provider check
+const aiCheckTimeout = 20 * time.Second
+
+// apiErrorStatus finds the HTTP status in provider errors such as "API error 401: ..."
+var apiErrorStatus = regexp.MustCompile(`API error (\d{3})`)
+
+// ProviderCheck is the result of a minimal call against one AI provider
+type ProviderCheck struct {
+	Provider   string
+	Configured bool
+	Model      string
+	Latency    time.Duration
+	// Problem describes why the call failed, empty when it succeeded
+	Problem string
+}
+
+// OK reports whether the provider answered
+func (c ProviderCheck) OK() bool {
+	return c.Configured && c.Problem == ""
+}
+
+// modelReporter is implemented by providers that report their model
+type modelReporter interface {
+	Model() string
+}
+
+// Check sends a minimal prompt to every configured provider at once and reports
+// reachability, model availability and latency in the fallback order
+func (c *AIChain) Check(ctx context.Context) []ProviderCheck {
+	checks := make([]ProviderCheck, len(c.providers))
+	var wg sync.WaitGroup
+
+	for i, provider := range c.providers {
+		checks[i] = ProviderCheck{Provider: provider.name, Configured: provider.completer.IsConfigured()}
+		if reporter, ok := provider.completer.(modelReporter); ok {
+			checks[i].Model = reporter.Model()
+		}
+		if !checks[i].Configured {
+			continue
+		}
+
+		wg.Add(1)
+		go func(check *ProviderCheck, completer TextCompleter) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, aiCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			response, err := completer.Complete(checkCtx, aiCheckPrompt)
+			check.Latency = time.Since(start)
+			if err == nil && response == "" {
+				err = fmt.Errorf("empty response")
+			}
+			if err != nil {
+				check.Problem = describeCheckError(err)
+			}
+		}(&checks[i], provider.completer)
+	}
+
+	wg.Wait()
+	return checks
+}
+
+// LogCheckReport checks the providers and logs the result, for startup validation
+func (c *AIChain) LogCheckReport(ctx context.Context) {
+	if !c.IsConfigured() {
+		c.logger.Warn("AI validation: no provider configured, set CLAUDE_API_KEY, OPENAI_API_KEY or GEMINI_API_KEY")
+		return
+	}
+
+	for _, check := range c.Check(ctx) {
+		switch {
+		case !check.Configured:
+			c.logger.Info("AI validation: provider not configured", "provider", check.Provider)
+		case check.OK():
+			c.logger.Info("AI validation: provider OK", "provider", check.Provider, "model", check.Model, "latency", check.Latency.Round(time.Millisecond))
+		default:
+			c.logger.Error("AI validation: provider failed", "provider", check.Provider, "model", check.Model, "problem", check.Problem)
+		}
+	}
+}
+
+// describeCheckError turns a provider error into an operator-facing explanation. URLs are
+// dropped since Gemini carries its API key in the query string.
+func describeCheckError(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timed out"
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return "unreachable: " + urlErr.Err.Error()
+	}
+
+	if match := apiErrorStatus.FindStringSubmatch(err.Error()); match != nil {
+		switch match[1] {
+		case "400":
+			return "request rejected (400), check the model name"
+		case "401", "403":
+			return "API key rejected (" + match[1] + ")"
+		case "404":
+			return "model not available (404)"
+		case "429":
+			return "rate limited or out of quota (429)"
+		default:
+			return "provider error (" + match[1] + ")"
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type fakeCompleter struct {
+	configured bool
+	response   string
+	err        error
+}
+
+func (f *fakeCompleter) Complete(ctx context.Context, prompt string) (string, error) {
+	return f.response, f.err
+}
+
+func (f *fakeCompleter) IsConfigured() bool { return f.configured }
+
+func TestAIChainCheck(t *testing.T) {
+	chain := &AIChain{providers: []namedCompleter{
+		{name: "claude", completer: &fakeCompleter{configured: true, err: fmt.Errorf("Claude API request failed: API error 401: invalid x-api-key")}},
+		{name: "openai", completer: &fakeCompleter{}},
+		{name: "gemini", completer: &fakeCompleter{configured: true, response: "OK"}},
+	}}
+
+	checks := chain.Check(context.Background())
+	if len(checks) != 3 {
+		t.Fatalf("expected a check per provider, got %d", len(checks))
+	}
+	if checks[0].OK() || checks[0].Problem != "API key rejected (401)" {
+		t.Errorf("expected claude's key to be rejected, got %+v", checks[0])
+	}
+	if checks[1].Configured || checks[1].OK() {
+		t.Errorf("expected openai to be unconfigured, got %+v", checks[1])
+	}
+	if !checks[2].OK() {
+		t.Errorf("expected gemini to answer, got %+v", checks[2])
+	}
+}
+
+func TestDescribeCheckErrorHidesURLs(t *testing.T) {
+	err := fmt.Errorf("request failed: %w", &url.Error{Op: "Post", URL: "https://example.com/models?key=secret", Err: errors.New("no such host")})
+	problem := describeCheckError(err)
+	if strings.Contains(problem, "secret") || problem != "unreachable: no such host" {
+		t.Errorf("expected the URL to be dropped, got %q", problem)
+	}
+	if problem := describeCheckError(errors.New("OpenAI API error 404: model not found")); problem != "model not available (404)" {
+		t.Errorf("unexpected 404 description %q", problem)
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultTokenPrices are rough blended input/output prices in USD per million tokens of the
+// default models, used to estimate AI spend. AI_TOKEN_PRICES overrides them, e.g.
+// "claude=6,openai=1.5,gemini=0.4".
+var defaultTokenPrices = map[string]float64{
+	"claude": 6,
+	"openai": 1.5,
+	"gemini": 0.4,
+}
+
+// ProviderStats counts the AI requests of one provider since startup
+type ProviderStats struct {
+	Provider string
+	Calls    int64
+	Failures int64
+	Tokens   int64
+	// SpendUSD is estimated from Tokens and the provider's token price
+	SpendUSD float64
+}
+
+// SuccessRate returns the share of calls that succeeded
+func (s ProviderStats) SuccessRate() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return float64(s.Calls-s.Failures) / float64(s.Calls)
+}
+
+// AIStats counts AI requests, failures and tokens per provider for /metrics
+type AIStats struct {
+	prices    map[string]float64
+	providers map[string]*ProviderStats
+	mutex     sync.Mutex
+}
+
+// NewAIStats creates AI statistics priced with AI_TOKEN_PRICES or the default prices
+func NewAIStats() *AIStats {
+	return &AIStats{
+		prices:    parseTokenPrices(os.Getenv("AI_TOKEN_PRICES")),
+		providers: make(map[string]*ProviderStats),
+	}
+}
+
+// Record counts one request to the provider. tokens is ignored for failed requests.
+func (s *AIStats) Record(provider string, tokens int, err error) {
+	if s == nil || provider == "" {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats := s.providers[provider]
+	if stats == nil {
+		stats = &ProviderStats{Provider: provider}
+		s.providers[provider] = stats
+	}
+	stats.Calls++
+	if err != nil {
+		stats.Failures++
+		return
+	}
+	stats.Tokens += int64(tokens)
+}
+
+// Stats returns the counts per provider, by provider name
+func (s *AIStats) Stats() []ProviderStats {
+	if s == nil {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats := make([]ProviderStats, 0, len(s.providers))
+	for _, provider := range s.providers {
+		snapshot := *provider
+		snapshot.SpendUSD = float64(snapshot.Tokens) / 1e6 * s.prices[snapshot.Provider]
+		stats = append(stats, snapshot)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Provider < stats[j].Provider })
+	return stats
+}
+
+// parseTokenPrices reads "provider=price" pairs over the default prices, skipping invalid ones
+func parseTokenPrices(value string) map[string]float64 {
+	prices := make(map[string]float64, len(defaultTokenPrices))
+	for provider, price := range defaultTokenPrices {
+		prices[provider] = price
+	}
+	for _, pair := range strings.Split(value, ",") {
+		provider, price, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		if parsed, err := strconv.ParseFloat(strings.TrimSpace(price), 64); err == nil && parsed >= 0 {
+			prices[strings.ToLower(strings.TrimSpace(provider))] = parsed
+		}
+	}
+	return prices
+}
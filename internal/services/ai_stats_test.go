@@ -0,0 +1,45 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAIStatsCountsCallsAndSpend(t *testing.T) {
+	t.Setenv("AI_TOKEN_PRICES", "openai=2, gemini=bad, custom=1")
+	stats := NewAIStats()
+
+	stats.Record("openai", 400000, nil)
+	stats.Record("openai", 100000, nil)
+	stats.Record("openai", 999, errors.New("timeout"))
+	stats.Record("gemini", 1000000, nil)
+	stats.Record("", 10, nil)
+
+	got := stats.Stats()
+	if len(got) != 2 || got[0].Provider != "gemini" || got[1].Provider != "openai" {
+		t.Fatalf("Stats() = %+v, want gemini and openai by name", got)
+	}
+
+	openai := got[1]
+	if openai.Calls != 3 || openai.Failures != 1 || openai.Tokens != 500000 {
+		t.Errorf("openai counts = %+v, want 3 calls, 1 failure, 500000 tokens", openai)
+	}
+	if math.Abs(openai.SpendUSD-1) > 1e-9 {
+		t.Errorf("openai spend = %v, want 1 at the overridden price", openai.SpendUSD)
+	}
+	if math.Abs(openai.SuccessRate()-2.0/3) > 1e-9 {
+		t.Errorf("openai success rate = %v", openai.SuccessRate())
+	}
+	if math.Abs(got[0].SpendUSD-defaultTokenPrices["gemini"]) > 1e-9 {
+		t.Errorf("gemini spend = %v, want the default price kept for an invalid override", got[0].SpendUSD)
+	}
+}
+
+func TestAIStatsNilIsSafe(t *testing.T) {
+	var stats *AIStats
+	stats.Record("openai", 10, nil)
+	if stats.Stats() != nil {
+		t.Error("expected no stats from a nil recorder")
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// defaultMonthlyAnalyses is the number of AI analyses a team may run per month
+const defaultMonthlyAnalyses = 100
+
+// AIUsageStore persists monthly AI usage counters per team
+type AIUsageStore interface {
+	GetAIUsage(teamID, period string) (int, error)
+	IncrementAIUsage(teamID, period string) error
+}
+
+// AIUsage is a team's AI analysis usage for the current month
+type AIUsage struct {
+	Used      int
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+}
+
+// AIBudgetError is returned when a team has used its monthly AI analyses
+type AIBudgetError struct {
+	Limit   int
+	ResetAt time.Time
+}
+
+// Error implements the error interface
+func (e *AIBudgetError) Error() string {
+	return fmt.Sprintf("monthly AI analysis budget of %d reached, resets %s", e.Limit, e.ResetAt.Format("Jan 2"))
+}
+
+// AIUsageService tracks monthly AI analysis budgets per team
+type AIUsageService struct {
+	store    AIUsageStore
+	limit    int
+	location *time.Location
+	logger   domain.Logger
+}
+
+// NewAIUsageService creates a usage service with the AI_MONTHLY_ANALYSES budget
+func NewAIUsageService(store AIUsageStore, location *time.Location, logger domain.Logger) *AIUsageService {
+	return &AIUsageService{
+		store:    store,
+		limit:    envInt("AI_MONTHLY_ANALYSES", defaultMonthlyAnalyses),
+		location: location,
+		logger:   logger,
+	}
+}
+
+// Usage returns the team's usage for the current month
+func (s *AIUsageService) Usage(teamID string) AIUsage {
+	period, resetAt := s.currentPeriod(time.Now())
+
+	used, err := s.store.GetAIUsage(teamID, period)
+	if err != nil {
+		s.logger.Warn("Failed to load AI usage", "team_id", teamID, "error", err)
+	}
+
+	remaining := s.limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return AIUsage{
+		Used:      used,
+		Limit:     s.limit,
+		Remaining: remaining,
+		ResetAt:   resetAt,
+	}
+}
+
+// CheckBudget returns an AIBudgetError when the team has no analyses left this month
+func (s *AIUsageService) CheckBudget(teamID string) error {
+	usage := s.Usage(teamID)
+	if usage.Remaining == 0 {
+		return &AIBudgetError{Limit: usage.Limit, ResetAt: usage.ResetAt}
+	}
+	return nil
+}
+
+// RecordAnalysis counts one AI analysis against the team's monthly budget
+func (s *AIUsageService) RecordAnalysis(teamID string) {
+	period, _ := s.currentPeriod(time.Now())
+	if err := s.store.IncrementAIUsage(teamID, period); err != nil {
+		s.logger.Error("Failed to record AI usage", "team_id", teamID, "error", err)
+	}
+}
+
+// currentPeriod returns the month key and the start of the next month in the bot timezone
+func (s *AIUsageService) currentPeriod(now time.Time) (string, time.Time) {
+	return monthPeriod(now, s.location)
+}
+
+// monthPeriod returns the month key of now, e.g. "2026-10", and the start of the next month
+func monthPeriod(now time.Time, location *time.Location) (string, time.Time) {
+	now = now.In(location)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location)
+	return monthStart.Format("2006-01"), monthStart.AddDate(0, 1, 0)
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// aliasKeyPrefix prefixes alias entries stored in chat settings
+const aliasKeyPrefix = "alias:"
+
+// maxAliasesPerChat limits how many shortcuts a single chat can define
+const maxAliasesPerChat = 50
+
+// aliasPattern validates alias names such as /a or /wl
+var aliasPattern = regexp.MustCompile(`^/[a-z0-9_]{1,32}$`)
+
+// ChatSettingsStore provides key/value storage for chat-level settings
+type ChatSettingsStore interface {
+	GetChatSettings(chatID int64) (map[string]string, error)
+	SetChatSetting(chatID int64, key, value string) error
+	DeleteChatSetting(chatID int64, key string) error
+}
+
+// CommandAlias maps a chat-specific shortcut to a real command
+type CommandAlias struct {
+	Alias  string
+	Target string
+}
+
+// AliasService manages per-chat command aliases
+type AliasService struct {
+	store  ChatSettingsStore
+	cache  map[int64]map[string]string
+	mutex  sync.RWMutex
+	logger domain.Logger
+}
+
+// NewAliasService creates a new alias service
+func NewAliasService(store ChatSettingsStore, logger domain.Logger) *AliasService {
+	return &AliasService{
+		store:  store,
+		cache:  make(map[int64]map[string]string),
+		logger: logger,
+	}
+}
+
+// NormalizeAlias lowercases an alias and adds the leading slash when missing
+func NormalizeAlias(alias string) string {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if alias != "" && !strings.HasPrefix(alias, "/") {
+		alias = "/" + alias
+	}
+	return alias
+}
+
+// ValidateAlias checks that an alias has a valid command format
+func ValidateAlias(alias string) error {
+	if !aliasPattern.MatchString(alias) {
+		return fmt.Errorf("alias must look like `/name` using letters, digits or underscores (max 32)")
+	}
+	return nil
+}
+
+// ResolveAlias returns the target command for an alias defined in the chat
+func (s *AliasService) ResolveAlias(chatID int64, command string) (string, bool) {
+	target, ok := s.aliases(chatID)[strings.ToLower(command)]
+	return target, ok
+}
+
+// ListAliases returns all aliases for a chat sorted by name
+func (s *AliasService) ListAliases(chatID int64) []CommandAlias {
+	aliases := s.aliases(chatID)
+
+	result := make([]CommandAlias, 0, len(aliases))
+	for alias, target := range aliases {
+		result = append(result, CommandAlias{Alias: alias, Target: target})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Alias < result[j].Alias
+	})
+
+	return result
+}
+
+// AddAlias stores an alias for the chat. Conflict checks against real commands
+// are done by the caller, which knows the registered handlers.
+func (s *AliasService) AddAlias(chatID int64, alias, target string) error {
+	alias = NormalizeAlias(alias)
+	if err := ValidateAlias(alias); err != nil {
+		return err
+	}
+
+	target = strings.TrimSpace(target)
+	if target == "" || !strings.HasPrefix(target, "/") {
+		return fmt.Errorf("target must be a command, e.g. `/analyze`")
+	}
+
+	targetCommand := strings.ToLower(strings.Fields(target)[0])
+	if targetCommand == alias {
+		return fmt.Errorf("alias cannot point to itself")
+	}
+	if _, isAlias := s.ResolveAlias(chatID, targetCommand); isAlias {
+		return fmt.Errorf("alias cannot point to another alias (%s)", targetCommand)
+	}
+
+	existing := s.aliases(chatID)
+	if _, exists := existing[alias]; !exists && len(existing) >= maxAliasesPerChat {
+		return fmt.Errorf("alias limit reached (%d per chat)", maxAliasesPerChat)
+	}
+
+	if err := s.store.SetChatSetting(chatID, aliasKeyPrefix+alias, target); err != nil {
+		return err
+	}
+
+	s.invalidate(chatID)
+	return nil
+}
+
+// RemoveAlias deletes an alias from the chat
+func (s *AliasService) RemoveAlias(chatID int64, alias string) error {
+	alias = NormalizeAlias(alias)
+	if _, ok := s.ResolveAlias(chatID, alias); !ok {
+		return fmt.Errorf("alias %s is not defined", alias)
+	}
+
+	if err := s.store.DeleteChatSetting(chatID, aliasKeyPrefix+alias); err != nil {
+		return err
+	}
+
+	s.invalidate(chatID)
+	return nil
+}
+
+// aliases returns the cached alias map for a chat, loading it from the store on first use
+func (s *AliasService) aliases(chatID int64) map[string]string {
+	s.mutex.RLock()
+	cached, found := s.cache[chatID]
+	s.mutex.RUnlock()
+	if found {
+		return cached
+	}
+
+	aliases := make(map[string]string)
+	settings, err := s.store.GetChatSettings(chatID)
+	if err != nil {
+		s.logger.Warn("Failed to load chat aliases", "chat_id", chatID, "error", err)
+		return aliases
+	}
+
+	for key, value := range settings {
+		if strings.HasPrefix(key, aliasKeyPrefix) {
+			aliases[strings.TrimPrefix(key, aliasKeyPrefix)] = value
+		}
+	}
+
+	s.mutex.Lock()
+	s.cache[chatID] = aliases
+	s.mutex.Unlock()
+
+	return aliases
+}
+
+// invalidate drops the cached aliases for a chat
+func (s *AliasService) invalidate(chatID int64) {
+	s.mutex.Lock()
+	delete(s.cache, chatID)
+	s.mutex.Unlock()
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// sharedTaskSimilarity is the share of title words two tasks need in common to count as
+// the same work planned by two requirements
+const sharedTaskSimilarity = 0.6
+
+// titleStopWords are left out when comparing task titles
+var titleStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "into": true,
+	"add": true, "create": true, "implement": true, "build": true, "setup": true, "set": true,
+}
+
+// BatchItem is one requirement of a batch analysis and its breakdown, or the error that
+// kept it from being analyzed
+type BatchItem struct {
+	Requirement string
+	Result      *domain.TaskBreakdownResponse
+	Err         error
+}
+
+// RequirementDependency is a requirement that builds on work another one plans. Numbers
+// are 1-based positions in the batch.
+type RequirementDependency struct {
+	From, To int
+	// Task is the shared work, as titled by the requirement it is kept in
+	Task string
+	// Hours is the estimate of the duplicate left out of the combined total
+	Hours float64
+}
+
+// TeamRole is a role recommended for the batch and how many requirements asked for it
+type TeamRole struct {
+	Role         string
+	Requirements int
+}
+
+// BatchPlan consolidates the breakdowns of several requirements into one plan
+type BatchPlan struct {
+	Items []BatchItem
+	// Dependencies lists work that two requirements both plan; the later requirement
+	// depends on the earlier one, which keeps the task
+	Dependencies []RequirementDependency
+	// Tasks are the tasks of all requirements without the duplicates, with IDs made
+	// unique across requirements
+	Tasks []domain.Task
+	// TotalEstimate sums the requirements' estimates, counting shared work once
+	TotalEstimate float64
+	Team          []TeamRole
+	RiskFactors   []string
+	Confidence    float64
+}
+
+// Analyzed returns the number of requirements that have a breakdown
+func (p *BatchPlan) Analyzed() int {
+	analyzed := 0
+	for _, item := range p.Items {
+		if item.Result != nil {
+			analyzed++
+		}
+	}
+	return analyzed
+}
+
+// Breakdown returns the plan as one breakdown, for the analysis history and saving
+func (p *BatchPlan) Breakdown() *domain.TaskBreakdownResponse {
+	breakdown := &domain.TaskBreakdownResponse{
+		Tasks:         p.Tasks,
+		TotalEstimate: p.TotalEstimate,
+		RiskFactors:   p.RiskFactors,
+		Confidence:    p.Confidence,
+	}
+	for _, role := range p.Team {
+		breakdown.RecommendedTeam = append(breakdown.RecommendedTeam, role.Role)
+	}
+	for _, item := range p.Items {
+		if item.Result == nil {
+			continue
+		}
+		if breakdown.Provider == "" {
+			breakdown.Provider, breakdown.Model = item.Result.Provider, item.Result.Model
+		}
+		breakdown.TokensUsed += item.Result.TokensUsed
+	}
+	return breakdown
+}
+
+// BuildBatchPlan merges the breakdowns of a batch. Tasks of a later requirement whose
+// titles match a task of an earlier one are treated as shared work: they are dropped,
+// their dependents point to the kept task, and the requirement is marked as depending on
+// the earlier one.
+func BuildBatchPlan(items []BatchItem) *BatchPlan {
+	plan := &BatchPlan{Items: items}
+
+	type keptTask struct {
+		requirement int
+		words       map[string]bool
+		index       int
+	}
+	var kept []keptTask
+	linked := make(map[[2]int]bool)
+	roles := make(map[string]int)
+	risks := make(map[string]bool)
+	confidence, analyzed := 0.0, 0
+
+	for i, item := range items {
+		if item.Result == nil {
+			continue
+		}
+		number := i + 1
+		analyzed++
+		confidence += item.Result.Confidence
+		plan.TotalEstimate += item.Result.TotalEstimate
+
+		// IDs are only unique within one breakdown
+		ids := make(map[string]string)
+		for _, task := range item.Result.Tasks {
+			ids[task.ID] = fmt.Sprintf("r%d_%s", number, task.ID)
+		}
+
+		for _, task := range item.Result.Tasks {
+			words := titleWords(task.Title)
+			duplicate := -1
+			for k, other := range kept {
+				if other.requirement != number && sameCategory(plan.Tasks[other.index], task) && wordSimilarity(words, other.words) >= sharedTaskSimilarity {
+					duplicate = k
+					break
+				}
+			}
+			if duplicate >= 0 {
+				original := plan.Tasks[kept[duplicate].index]
+				ids[task.ID] = original.ID
+				plan.TotalEstimate -= task.EstimateHours
+				if key := [2]int{number, kept[duplicate].requirement}; !linked[key] {
+					linked[key] = true
+					plan.Dependencies = append(plan.Dependencies, RequirementDependency{
+						From: number, To: kept[duplicate].requirement, Task: original.Title, Hours: task.EstimateHours,
+					})
+				}
+				continue
+			}
+
+			task.ID = ids[task.ID]
+			kept = append(kept, keptTask{requirement: number, words: words, index: len(plan.Tasks)})
+			plan.Tasks = append(plan.Tasks, task)
+		}
+
+		// Dependencies may point to tasks listed later in the breakdown, so they are mapped
+		// once all IDs of the requirement are known
+		for t := range plan.Tasks {
+			if !strings.HasPrefix(plan.Tasks[t].ID, fmt.Sprintf("r%d_", number)) {
+				continue
+			}
+			var dependencies []string
+			for _, dependency := range plan.Tasks[t].Dependencies {
+				if id, ok := ids[dependency]; ok {
+					dependencies = append(dependencies, id)
+				}
+			}
+			plan.Tasks[t].Dependencies = dependencies
+		}
+
+		seen := make(map[string]bool)
+		for _, role := range item.Result.RecommendedTeam {
+			key := strings.ToLower(strings.TrimSpace(role))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			if _, ok := roles[key]; !ok {
+				plan.Team = append(plan.Team, TeamRole{Role: strings.TrimSpace(role)})
+			}
+			roles[key]++
+		}
+		for _, risk := range item.Result.RiskFactors {
+			if key := strings.ToLower(strings.TrimSpace(risk)); key != "" && !risks[key] {
+				risks[key] = true
+				plan.RiskFactors = append(plan.RiskFactors, risk)
+			}
+		}
+	}
+
+	for i := range plan.Team {
+		plan.Team[i].Requirements = roles[strings.ToLower(plan.Team[i].Role)]
+	}
+	sort.SliceStable(plan.Team, func(i, j int) bool {
+		return plan.Team[i].Requirements > plan.Team[j].Requirements
+	})
+	if analyzed > 0 {
+		plan.Confidence = confidence / float64(analyzed)
+	}
+	if plan.TotalEstimate < 0 {
+		plan.TotalEstimate = 0
+	}
+	return plan
+}
+
+// titleWords returns the significant lowercase words of a task title, in singular
+func titleWords(title string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) < 3 || titleStopWords[word] {
+			continue
+		}
+		// "users" and "user" name the same thing
+		if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			word = strings.TrimSuffix(word, "s")
+		}
+		words[word] = true
+	}
+	return words
+}
+
+// wordSimilarity is the Jaccard similarity of two word sets; titles need at least two
+// words in common so short generic titles do not match
+func wordSimilarity(a, b map[string]bool) float64 {
+	common := 0
+	for word := range a {
+		if b[word] {
+			common++
+		}
+	}
+	if common < 2 {
+		return 0
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// sameCategory reports whether two tasks may be the same work
+func sameCategory(a, b domain.Task) bool {
+	return a.Category == "" || b.Category == "" || strings.EqualFold(a.Category, b.Category)
+}
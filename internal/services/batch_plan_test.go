@@ -0,0 +1,78 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+func TestBuildBatchPlanMergesSharedWork(t *testing.T) {
+	items := []BatchItem{
+		{Requirement: "Login with Google", Result: &domain.TaskBreakdownResponse{
+			Tasks: []domain.Task{
+				{ID: "task_1", Title: "User authentication API", Category: "backend", EstimateHours: 8},
+				{ID: "task_2", Title: "Login screen", Category: "frontend", EstimateHours: 4, Dependencies: []string{"task_1"}},
+			},
+			TotalEstimate:   12,
+			RecommendedTeam: []string{"Backend developer", "Frontend developer"},
+			Confidence:      0.8,
+		}},
+		{Requirement: "Payments", Err: errors.New("AI unavailable")},
+		{Requirement: "Admin dashboard", Result: &domain.TaskBreakdownResponse{
+			Tasks: []domain.Task{
+				{ID: "task_1", Title: "Authentication API for users", Category: "backend", EstimateHours: 6},
+				{ID: "task_2", Title: "Dashboard page", Category: "frontend", EstimateHours: 10, Dependencies: []string{"task_1"}},
+			},
+			TotalEstimate:   16,
+			RecommendedTeam: []string{"frontend developer"},
+			RiskFactors:     []string{"Unclear permissions"},
+			Confidence:      0.6,
+		}},
+	}
+
+	plan := BuildBatchPlan(items)
+
+	if plan.Analyzed() != 2 {
+		t.Errorf("Analyzed() = %d, want 2", plan.Analyzed())
+	}
+	if len(plan.Dependencies) != 1 || plan.Dependencies[0].From != 3 || plan.Dependencies[0].To != 1 || plan.Dependencies[0].Task != "User authentication API" {
+		t.Fatalf("Dependencies = %+v, want #3 building on #1", plan.Dependencies)
+	}
+	if plan.TotalEstimate != 22 {
+		t.Errorf("TotalEstimate = %v, want 22 with the shared API counted once", plan.TotalEstimate)
+	}
+
+	var ids []string
+	for _, task := range plan.Tasks {
+		ids = append(ids, task.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"r1_task_1", "r1_task_2", "r3_task_2"}) {
+		t.Errorf("task IDs = %v", ids)
+	}
+	if deps := plan.Tasks[2].Dependencies; len(deps) != 1 || deps[0] != "r1_task_1" {
+		t.Errorf("dashboard dependencies = %v, want the shared API", deps)
+	}
+
+	want := []TeamRole{{Role: "Frontend developer", Requirements: 2}, {Role: "Backend developer", Requirements: 1}}
+	if !reflect.DeepEqual(plan.Team, want) {
+		t.Errorf("Team = %+v, want %+v", plan.Team, want)
+	}
+	if breakdown := plan.Breakdown(); len(breakdown.Tasks) != 3 || breakdown.Confidence != 0.7 || len(breakdown.RiskFactors) != 1 {
+		t.Errorf("Breakdown() = %+v", breakdown)
+	}
+}
+
+func TestBuildBatchPlanKeepsDistinctTasks(t *testing.T) {
+	items := []BatchItem{
+		{Result: &domain.TaskBreakdownResponse{Tasks: []domain.Task{{ID: "a", Title: "Database schema for orders", Category: "backend", EstimateHours: 3}}, TotalEstimate: 3}},
+		{Result: &domain.TaskBreakdownResponse{Tasks: []domain.Task{{ID: "a", Title: "Database schema for orders", Category: "qa", EstimateHours: 2}}, TotalEstimate: 2}},
+		{Result: &domain.TaskBreakdownResponse{Tasks: []domain.Task{{ID: "a", Title: "Orders page", Category: "frontend", EstimateHours: 5}}, TotalEstimate: 5}},
+	}
+
+	plan := BuildBatchPlan(items)
+	if len(plan.Dependencies) != 0 || len(plan.Tasks) != 3 || plan.TotalEstimate != 10 {
+		t.Errorf("expected no shared work, got %d dependencies, %d tasks, %vh", len(plan.Dependencies), len(plan.Tasks), plan.TotalEstimate)
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BotAdmins holds the operators of the bot, listed as Telegram user IDs in BOT_ADMIN_IDS
+type BotAdmins struct {
+	ids map[int64]bool
+	// alertChat is the ADMIN_CHAT_ID chat that receives operator alerts, 0 when unset
+	alertChat int64
+}
+
+// NewBotAdmins reads the comma-separated BOT_ADMIN_IDS and the ADMIN_CHAT_ID alert chat
+func NewBotAdmins() *BotAdmins {
+	admins := ParseBotAdmins(os.Getenv("BOT_ADMIN_IDS"))
+	admins.alertChat, _ = strconv.ParseInt(strings.TrimSpace(os.Getenv("ADMIN_CHAT_ID")), 10, 64)
+	return admins
+}
+
+// ParseBotAdmins parses a comma-separated list of Telegram user IDs, skipping invalid entries
+func ParseBotAdmins(value string) *BotAdmins {
+	admins := &BotAdmins{ids: make(map[int64]bool)}
+	for _, field := range strings.Split(value, ",") {
+		if id, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64); err == nil && id != 0 {
+			admins.ids[id] = true
+		}
+	}
+	return admins
+}
+
+// IsAdmin reports whether the user may run operator commands
+func (a *BotAdmins) IsAdmin(userID int64) bool {
+	return a.ids[userID]
+}
+
+// Configured reports whether any operator is listed
+func (a *BotAdmins) Configured() bool {
+	return len(a.ids) > 0
+}
+
+// AlertChats returns the chats operator alerts go to: the admin chat when one is set, and
+// otherwise the private chat of each operator
+func (a *BotAdmins) AlertChats() []int64 {
+	if a.alertChat != 0 {
+		return []int64{a.alertChat}
+	}
+	chats := make([]int64, 0, len(a.ids))
+	for id := range a.ids {
+		chats = append(chats, id)
+	}
+	sort.Slice(chats, func(i, j int) bool { return chats[i] < chats[j] })
+	return chats
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// maxRepairOutput limits how much of an invalid response is sent back for repair
+const maxRepairOutput = 12000
+
+// BreakdownParseError is returned when a provider answered but its task breakdown could
+// not be parsed. Output is the raw answer, kept for a repair round-trip.
+type BreakdownParseError struct {
+	Output string
+	Err    error
+}
+
+// Error implements error
+func (e *BreakdownParseError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the parse error
+func (e *BreakdownParseError) Unwrap() error {
+	return e.Err
+}
+
+// RepairStats counts JSON repair round-trips of one provider
+type RepairStats struct {
+	Provider string
+	Attempts int64
+	Repaired int64
+}
+
+// Rate returns the share of repair attempts that produced a valid breakdown
+func (s RepairStats) Rate() float64 {
+	if s.Attempts == 0 {
+		return 0
+	}
+	return float64(s.Repaired) / float64(s.Attempts)
+}
+
+// breakdownRepairPrompt asks the model to turn its invalid output into valid JSON
+func breakdownRepairPrompt(output string, cause error) string {
+	if len(output) > maxRepairOutput {
+		output = output[:maxRepairOutput]
+	}
+	return fmt.Sprintf(`Your previous answer could not be parsed: %s
+
+Fix this into valid JSON matching the schema below. Keep the tasks and estimates, change only what is needed.
+Respond ONLY with the JSON object, no code fences or explanations.
+
+Schema:
+{"tasks": [{"id": string, "title": string, "description": string, "category": "backend"|"frontend"|"qa"|"devops",
+  "estimate_hours": number, "optimistic_hours": number, "pessimistic_hours": number, "priority": 1|2|3,
+  "dependencies": [string], "acceptance_criteria": [string], "subtasks": [string]}],
+ "total_estimate": number, "recommended_team": [string], "critical_path": [string], "risk_factors": [string], "confidence": number}
+
+Previous answer:
+%s`, cause, strings.TrimSpace(output))
+}
+
+// repairBreakdown sends an unparseable breakdown back to the provider once to be fixed
+func (ta *TaskAnalyzer) repairBreakdown(ctx context.Context, name string, provider breakdownProvider, parseErr *BreakdownParseError) (*domain.TaskBreakdownResponse, error) {
+	ta.logger.Warn("AI breakdown unparseable, asking for a repair", "provider", name, "error", parseErr.Err)
+
+	prompt := breakdownRepairPrompt(parseErr.Output, parseErr.Err)
+	response, err := provider.Complete(ctx, prompt)
+	var result *domain.TaskBreakdownResponse
+	if err == nil {
+		result, err = provider.parseTaskBreakdown(response)
+	}
+	ta.recordRepair(name, err == nil)
+	if err != nil {
+		return nil, fmt.Errorf("repair failed: %w", err)
+	}
+
+	result.Model = provider.Model()
+	result.TokensUsed = EstimateTokens(prompt) + EstimateTokens(response)
+	ta.logger.Info("AI breakdown repaired", "provider", name, "tasks_count", len(result.Tasks))
+	return result, nil
+}
+
+// recordRepair counts a repair attempt of a provider
+func (ta *TaskAnalyzer) recordRepair(provider string, repaired bool) {
+	ta.repairMutex.Lock()
+	defer ta.repairMutex.Unlock()
+
+	stats := ta.repairs[provider]
+	if stats == nil {
+		stats = &RepairStats{Provider: provider}
+		ta.repairs[provider] = stats
+	}
+	stats.Attempts++
+	if repaired {
+		stats.Repaired++
+	}
+}
+
+// RepairStats returns the JSON repair counts per provider, by provider name
+func (ta *TaskAnalyzer) RepairStats() []RepairStats {
+	ta.repairMutex.Lock()
+	defer ta.repairMutex.Unlock()
+
+	stats := make([]RepairStats, 0, len(ta.repairs))
+	for _, s := range ta.repairs {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Provider < stats[j].Provider })
+	return stats
+}
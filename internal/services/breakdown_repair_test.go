@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// repairableProvider answers the analysis with broken JSON and the repair with its fix
+type repairableProvider struct {
+	fixed   string
+	prompts []string
+}
+
+func (p *repairableProvider) IsConfigured() bool { return true }
+func (p *repairableProvider) Model() string      { return "test-model" }
+
+func (p *repairableProvider) AnalyzeRequirement(ctx context.Context, req domain.TaskBreakdownRequest) (*domain.TaskBreakdownResponse, error) {
+	output := `{"tasks": [{"title": "API",}]`
+	_, err := p.parseTaskBreakdown(output)
+	return nil, fmt.Errorf("failed to parse response: %w", &BreakdownParseError{Output: output, Err: err})
+}
+
+func (p *repairableProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	p.prompts = append(p.prompts, prompt)
+	return p.fixed, nil
+}
+
+func (p *repairableProvider) parseTaskBreakdown(response string) (*domain.TaskBreakdownResponse, error) {
+	var result domain.TaskBreakdownResponse
+	if err := json.Unmarshal([]byte(response), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func TestCallProviderRepairsUnparseableBreakdown(t *testing.T) {
+	analyzer := NewTaskAnalyzer(silentLogger{})
+
+	provider := &repairableProvider{fixed: validBreakdown}
+	result, err := analyzer.callProvider(context.Background(), "claude", provider, domain.TaskBreakdownRequest{})
+	if err != nil || len(result.Tasks) != 1 || result.Model != "test-model" {
+		t.Fatalf("expected the repaired breakdown, got %+v, %v", result, err)
+	}
+	if len(provider.prompts) != 1 || !strings.Contains(provider.prompts[0], `{"tasks": [{"title": "API",}]`) {
+		t.Errorf("expected one repair prompt carrying the invalid output, got %v", provider.prompts)
+	}
+
+	// A failed repair is reported so the analyzer moves on to the next provider
+	if _, err := analyzer.callProvider(context.Background(), "claude", &repairableProvider{fixed: "still not JSON"}, domain.TaskBreakdownRequest{}); err == nil {
+		t.Error("expected an error when the repair fails")
+	}
+
+	stats := analyzer.RepairStats()
+	if len(stats) != 1 || stats[0].Attempts != 2 || stats[0].Repaired != 1 || stats[0].Rate() != 0.5 {
+		t.Errorf("expected 1 of 2 repairs to succeed, got %+v", stats)
+	}
+}
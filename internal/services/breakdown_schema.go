@@ -0,0 +1,159 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// taskBreakdownToolName is the tool Claude is asked to call with the breakdown
+const taskBreakdownToolName = "submit_task_breakdown"
+
+// errStructuredUnsupported is returned when a provider or model rejects tool use or a JSON
+// response format, so the caller falls back to parsing JSON out of plain text
+var errStructuredUnsupported = errors.New("structured output not supported")
+
+// stringArraySchema is the schema of a list of strings
+var stringArraySchema = map[string]interface{}{
+	"type":  "array",
+	"items": map[string]interface{}{"type": "string"},
+}
+
+// taskBreakdownSchema is the JSON schema of domain.TaskBreakdownResponse as the AI returns it
+var taskBreakdownSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"tasks": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id":                  map[string]interface{}{"type": "string"},
+					"title":               map[string]interface{}{"type": "string"},
+					"description":         map[string]interface{}{"type": "string"},
+					"category":            map[string]interface{}{"type": "string", "enum": []interface{}{"backend", "frontend", "qa", "devops"}},
+					"estimate_hours":      map[string]interface{}{"type": "number"},
+					"optimistic_hours":    map[string]interface{}{"type": "number"},
+					"pessimistic_hours":   map[string]interface{}{"type": "number"},
+					"priority":            map[string]interface{}{"type": "integer"},
+					"dependencies":        stringArraySchema,
+					"acceptance_criteria": stringArraySchema,
+					"subtasks":            stringArraySchema,
+				},
+				"required": []interface{}{"id", "title", "description", "category", "estimate_hours", "priority", "dependencies"},
+			},
+		},
+		"total_estimate":   map[string]interface{}{"type": "number"},
+		"recommended_team": stringArraySchema,
+		"critical_path":    stringArraySchema,
+		"risk_factors":     stringArraySchema,
+		"confidence":       map[string]interface{}{"type": "number"},
+	},
+	"required": []interface{}{"tasks", "total_estimate", "recommended_team", "critical_path", "risk_factors", "confidence"},
+}
+
+// decodeStructuredBreakdown validates structured output against taskBreakdownSchema and decodes it
+func decodeStructuredBreakdown(data []byte) (*domain.TaskBreakdownResponse, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if err := validateSchema(taskBreakdownSchema, value, "$"); err != nil {
+		return nil, fmt.Errorf("response does not match the schema: %w", err)
+	}
+
+	var result domain.TaskBreakdownResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if len(result.Tasks) == 0 {
+		return nil, fmt.Errorf("no tasks found in response")
+	}
+	for i := range result.Tasks {
+		if result.Tasks[i].ID == "" {
+			result.Tasks[i].ID = fmt.Sprintf("task_%d_%d", time.Now().UnixNano(), i)
+		}
+	}
+	return &result, nil
+}
+
+// validateSchema checks a decoded JSON value against the subset of JSON schema used here:
+// type, properties, required, items and enum
+func validateSchema(schema map[string]interface{}, value interface{}, path string) error {
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object", path)
+		}
+		required, _ := schema["required"].([]interface{})
+		for _, key := range required {
+			if _, ok := object[key.(string)]; !ok {
+				return fmt.Errorf("%s.%s is required", path, key)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		keys := make([]string, 0, len(properties))
+		for key := range properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if field, ok := object[key]; ok && field != nil {
+				if err := validateSchema(properties[key].(map[string]interface{}), field, path+"."+key); err != nil {
+					return err
+				}
+			}
+		}
+
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an array", path)
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range array {
+				if err := validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", path)
+		}
+		if enum, ok := schema["enum"].([]interface{}); ok {
+			for _, allowed := range enum {
+				if text == allowed {
+					return nil
+				}
+			}
+			return fmt.Errorf("%s must be one of %v, got %q", path, enum, text)
+		}
+
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s must be a number", path)
+		}
+
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != float64(int64(number)) {
+			return fmt.Errorf("%s must be an integer", path)
+		}
+	}
+	return nil
+}
+
+// structuredRejected reports whether a 400 response rejects a structured output feature,
+// e.g. "tools" for Claude or "response_format" for OpenAI
+func structuredRejected(status int, body []byte, feature string) bool {
+	return status == 400 && strings.Contains(strings.ToLower(string(body)), feature)
+}
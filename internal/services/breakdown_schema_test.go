@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+const validBreakdown = `{"tasks":[{"id":"t1","title":"API","description":"REST API","category":"backend","estimate_hours":6,"priority":1,"dependencies":[]}],
+"total_estimate":6,"recommended_team":["Backend Developer"],"critical_path":["t1"],"risk_factors":[],"confidence":0.8}`
+
+func TestDecodeStructuredBreakdownValidatesSchema(t *testing.T) {
+	if result, err := decodeStructuredBreakdown([]byte(validBreakdown)); err != nil || len(result.Tasks) != 1 {
+		t.Fatalf("expected a valid breakdown, got %+v, %v", result, err)
+	}
+
+	invalid := map[string]string{
+		"missing field":  strings.Replace(validBreakdown, `"critical_path":["t1"],`, "", 1),
+		"wrong category": strings.Replace(validBreakdown, `"backend"`, `"design"`, 1),
+		"wrong type":     strings.Replace(validBreakdown, `"estimate_hours":6`, `"estimate_hours":"6h"`, 1),
+		"no tasks":       `{"tasks":[],"total_estimate":0,"recommended_team":[],"critical_path":[],"risk_factors":[],"confidence":1}`,
+	}
+	for name, data := range invalid {
+		if _, err := decodeStructuredBreakdown([]byte(data)); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
+
+func TestClaudeAnalyzeUsesToolAndFallsBackToText(t *testing.T) {
+	toolsSupported := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request ClaudeRequest
+		json.Unmarshal(body, &request)
+
+		if len(request.Tools) > 0 {
+			if !toolsSupported {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"message":"tools: not supported"}}`))
+				return
+			}
+			if request.ToolChoice == nil || request.ToolChoice.Name != taskBreakdownToolName {
+				t.Errorf("expected a forced tool call, got %+v", request.ToolChoice)
+			}
+			w.Write([]byte(`{"content":[{"type":"tool_use","name":"` + taskBreakdownToolName + `","input":` + validBreakdown + `}]}`))
+			return
+		}
+		text, _ := json.Marshal("```json\n" + validBreakdown + "\n```")
+		w.Write([]byte(`{"content":[{"type":"text","text":` + string(text) + `}]}`))
+	}))
+	defer server.Close()
+
+	claude := &ClaudeService{
+		apiKey:     "key",
+		models:     NewModelSelector("TEST_CLAUDE", "model", "large-model"),
+		httpClient: server.Client(),
+		baseURL:    server.URL,
+		logger:     silentLogger{},
+	}
+
+	for _, supported := range []bool{true, false} {
+		toolsSupported = supported
+		result, err := claude.AnalyzeRequirement(context.Background(), domain.TaskBreakdownRequest{Requirement: "API"})
+		if err != nil || len(result.Tasks) != 1 || result.Tasks[0].Title != "API" {
+			t.Errorf("tools supported=%v: expected one task, got %+v, %v", supported, result, err)
+		}
+	}
+}
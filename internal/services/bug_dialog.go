@@ -0,0 +1,127 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// bugDialogTTL is how long an unanswered /bug dialog stays open
+const bugDialogTTL = 15 * time.Minute
+
+// maxBugScreenshots limits the screenshots kept for one bug report
+const maxBugScreenshots = 5
+
+// Steps of the /bug dialog, in the order their questions are asked
+const (
+	BugStepHappened = iota
+	BugStepExpected
+	BugStepSteps
+	BugStepDone
+)
+
+// BugReport is a bug report being collected by the /bug dialog
+type BugReport struct {
+	Step     int
+	Happened string
+	Expected string
+	Steps    string
+	// Screenshots holds the Telegram file IDs of the images sent during the dialog
+	Screenshots []string
+	UpdatedAt   time.Time
+}
+
+// Answer records the answer to the current question and moves to the next one
+func (r *BugReport) Answer(text string) {
+	switch r.Step {
+	case BugStepHappened:
+		r.Happened = text
+	case BugStepExpected:
+		r.Expected = text
+	case BugStepSteps:
+		r.Steps = text
+	default:
+		return
+	}
+	r.Step++
+}
+
+// AddScreenshot attaches an image and reports whether it was kept
+func (r *BugReport) AddScreenshot(fileID string) bool {
+	if fileID == "" || len(r.Screenshots) >= maxBugScreenshots {
+		return false
+	}
+	for _, existing := range r.Screenshots {
+		if existing == fileID {
+			return false
+		}
+	}
+	r.Screenshots = append(r.Screenshots, fileID)
+	return true
+}
+
+type bugDialogKey struct {
+	chatID int64
+	userID int64
+}
+
+// BugDialogService keeps the open /bug dialogs, one per user and chat. Dialogs are held in
+// memory and expire when left unanswered.
+type BugDialogService struct {
+	dialogs map[bugDialogKey]*BugReport
+	mutex   sync.Mutex
+	now     func() time.Time
+}
+
+// NewBugDialogService creates a new bug dialog service
+func NewBugDialogService() *BugDialogService {
+	return &BugDialogService{
+		dialogs: make(map[bugDialogKey]*BugReport),
+		now:     time.Now,
+	}
+}
+
+// Start opens a dialog for the user in the chat, replacing any open one
+func (s *BugDialogService) Start(chatID, userID int64) *BugReport {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	report := &BugReport{UpdatedAt: s.now()}
+	s.dialogs[bugDialogKey{chatID, userID}] = report
+	return report
+}
+
+// Get returns the user's open dialog in the chat, or nil when there is none. Getting a
+// dialog keeps it open for another bugDialogTTL; callers change the report in place.
+func (s *BugDialogService) Get(chatID, userID int64) *BugReport {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := bugDialogKey{chatID, userID}
+	report := s.dialogs[key]
+	if report == nil {
+		return nil
+	}
+	if s.now().Sub(report.UpdatedAt) > bugDialogTTL {
+		delete(s.dialogs, key)
+		return nil
+	}
+	report.UpdatedAt = s.now()
+	return report
+}
+
+// Active reports whether the user has an open dialog in the chat
+func (s *BugDialogService) Active(chatID, userID int64) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	report := s.dialogs[bugDialogKey{chatID, userID}]
+	return report != nil && s.now().Sub(report.UpdatedAt) <= bugDialogTTL
+}
+
+// Finish closes the user's dialog in the chat
+func (s *BugDialogService) Finish(chatID, userID int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.dialogs, bugDialogKey{chatID, userID})
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBugDialogSteps(t *testing.T) {
+	dialogs := NewBugDialogService()
+	report := dialogs.Start(1, 10)
+
+	report.Answer("Login button does nothing")
+	report.Answer("It opens the dashboard")
+	report.Answer("1. Open /login 2. Tap Login")
+	report.Answer("ignored")
+
+	if report.Step != BugStepDone {
+		t.Fatalf("step = %d, want done", report.Step)
+	}
+	if report.Happened != "Login button does nothing" || report.Expected != "It opens the dashboard" ||
+		report.Steps != "1. Open /login 2. Tap Login" {
+		t.Errorf("unexpected answers: %+v", report)
+	}
+
+	if dialogs.Active(1, 11) || dialogs.Active(2, 10) {
+		t.Error("dialog leaked to another user or chat")
+	}
+	dialogs.Finish(1, 10)
+	if dialogs.Get(1, 10) != nil {
+		t.Error("finished dialog still open")
+	}
+}
+
+func TestBugDialogScreenshots(t *testing.T) {
+	report := NewBugDialogService().Start(1, 10)
+
+	if !report.AddScreenshot("file_a") || report.AddScreenshot("file_a") || report.AddScreenshot("") {
+		t.Fatal("screenshots not deduplicated")
+	}
+	for i := 0; i < maxBugScreenshots; i++ {
+		report.AddScreenshot(string(rune('b' + i)))
+	}
+	if len(report.Screenshots) != maxBugScreenshots {
+		t.Errorf("kept %d screenshots, want %d", len(report.Screenshots), maxBugScreenshots)
+	}
+}
+
+func TestBugDialogExpires(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	dialogs := NewBugDialogService()
+	dialogs.now = func() time.Time { return now }
+	dialogs.Start(1, 10)
+
+	now = now.Add(bugDialogTTL - time.Minute)
+	if dialogs.Get(1, 10) == nil {
+		t.Fatal("dialog expired early")
+	}
+
+	// Getting the dialog kept it open
+	now = now.Add(bugDialogTTL - time.Minute)
+	if !dialogs.Active(1, 10) {
+		t.Fatal("answered dialog expired")
+	}
+
+	now = now.Add(bugDialogTTL + time.Minute)
+	if dialogs.Active(1, 10) || dialogs.Get(1, 10) != nil {
+		t.Error("abandoned dialog still open")
+	}
+}
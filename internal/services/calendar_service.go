@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Team setting keys used by the calendar service
+const (
+	settingHoursPerDay = "hours_per_day"
+	settingDaysPerWeek = "days_per_week"
+	settingHolidays    = "holidays"
+	settingCountry     = "country"
+)
+
+// holidayDateLayout is the date format used for holidays in settings and commands
+const holidayDateLayout = "2006-01-02"
+
+// TeamSettingsStore provides key/value storage for team-level settings
+type TeamSettingsStore interface {
+	GetTeamSettings(teamID string) (map[string]string, error)
+	SetTeamSetting(teamID, key, value string) error
+}
+
+// CalendarService manages team working calendars used for schedule projections
+type CalendarService struct {
+	store    TeamSettingsStore
+	holidays *HolidayService
+	logger   domain.Logger
+}
+
+// NewCalendarService creates a new calendar service
+func NewCalendarService(store TeamSettingsStore, holidays *HolidayService, logger domain.Logger) *CalendarService {
+	return &CalendarService{
+		store:    store,
+		holidays: holidays,
+		logger:   logger,
+	}
+}
+
+// GetCalendar returns the team's working calendar including public holidays of the
+// configured country for this and next year, falling back to defaults on errors
+func (s *CalendarService) GetCalendar(ctx context.Context, teamID string) domain.WorkCalendar {
+	calendar := domain.DefaultWorkCalendar()
+	if s == nil {
+		return calendar
+	}
+
+	settings, err := s.store.GetTeamSettings(teamID)
+	if err != nil {
+		s.logger.Warn("Failed to load team calendar, using defaults", "team_id", teamID, "error", err)
+		return calendar
+	}
+
+	if value, ok := settings[settingHoursPerDay]; ok {
+		if hours, err := strconv.ParseFloat(value, 64); err == nil {
+			calendar.HoursPerDay = hours
+		}
+	}
+	if value, ok := settings[settingDaysPerWeek]; ok {
+		if days, err := strconv.Atoi(value); err == nil {
+			calendar.DaysPerWeek = days
+		}
+	}
+	calendar.Holidays = parseHolidays(settings[settingHolidays])
+
+	if country := settings[settingCountry]; country != "" {
+		year := time.Now().Year()
+		for _, y := range []int{year, year + 1} {
+			for _, holiday := range s.holidays.GetHolidays(ctx, country, y) {
+				if !calendar.IsHoliday(holiday.Date) {
+					calendar.Holidays = append(calendar.Holidays, holiday.Date)
+				}
+			}
+		}
+	}
+
+	return calendar.Normalize()
+}
+
+// GetCountry returns the team's configured holiday country code
+func (s *CalendarService) GetCountry(teamID string) string {
+	settings, err := s.store.GetTeamSettings(teamID)
+	if err != nil {
+		s.logger.Warn("Failed to load team country", "team_id", teamID, "error", err)
+		return ""
+	}
+	return settings[settingCountry]
+}
+
+// SetCountry updates the country whose public holidays apply to the team. Countries
+// without known holidays are rejected.
+func (s *CalendarService) SetCountry(ctx context.Context, teamID, country string) error {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if len(country) != 2 {
+		return fmt.Errorf("country must be a two-letter code, e.g. UZ, US or EU")
+	}
+	if err := s.holidays.ValidateCountry(ctx, country); err != nil {
+		return err
+	}
+	return s.store.SetTeamSetting(teamID, settingCountry, country)
+}
+
+// GetTeamHolidays returns custom team holidays stored in settings, without public holidays
+func (s *CalendarService) GetTeamHolidays(teamID string) []time.Time {
+	settings, err := s.store.GetTeamSettings(teamID)
+	if err != nil {
+		s.logger.Warn("Failed to load team holidays", "team_id", teamID, "error", err)
+		return nil
+	}
+	return parseHolidays(settings[settingHolidays])
+}
+
+// SetHoursPerDay updates the number of working hours per day
+func (s *CalendarService) SetHoursPerDay(teamID string, hours float64) error {
+	if hours <= 0 || hours > 24 {
+		return fmt.Errorf("hours per day must be between 1 and 24")
+	}
+	return s.store.SetTeamSetting(teamID, settingHoursPerDay, strconv.FormatFloat(hours, 'f', -1, 64))
+}
+
+// SetDaysPerWeek updates the number of working days per week
+func (s *CalendarService) SetDaysPerWeek(teamID string, days int) error {
+	if days < 1 || days > 7 {
+		return fmt.Errorf("days per week must be between 1 and 7")
+	}
+	return s.store.SetTeamSetting(teamID, settingDaysPerWeek, strconv.Itoa(days))
+}
+
+// AddHoliday adds a team holiday
+func (s *CalendarService) AddHoliday(teamID string, date time.Time) error {
+	holidays := s.GetTeamHolidays(teamID)
+	if (domain.WorkCalendar{Holidays: holidays}).IsHoliday(date) {
+		return nil
+	}
+	return s.saveHolidays(teamID, append(holidays, date))
+}
+
+// RemoveHoliday removes a team holiday
+func (s *CalendarService) RemoveHoliday(teamID string, date time.Time) error {
+	holidays := s.GetTeamHolidays(teamID)
+
+	remaining := make([]time.Time, 0, len(holidays))
+	for _, holiday := range holidays {
+		if holiday.Format(holidayDateLayout) != date.Format(holidayDateLayout) {
+			remaining = append(remaining, holiday)
+		}
+	}
+
+	return s.saveHolidays(teamID, remaining)
+}
+
+// ParseHolidayDate parses a holiday date in YYYY-MM-DD format
+func ParseHolidayDate(value string) (time.Time, error) {
+	date, err := time.Parse(holidayDateLayout, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return date, nil
+}
+
+// saveHolidays stores holidays as a sorted comma-separated list
+func (s *CalendarService) saveHolidays(teamID string, holidays []time.Time) error {
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Before(holidays[j])
+	})
+
+	dates := make([]string, 0, len(holidays))
+	for _, holiday := range holidays {
+		dates = append(dates, holiday.Format(holidayDateLayout))
+	}
+
+	return s.store.SetTeamSetting(teamID, settingHolidays, strings.Join(dates, ","))
+}
+
+// parseHolidays parses a comma-separated list of dates, skipping invalid entries
+func parseHolidays(value string) []time.Time {
+	var holidays []time.Time
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		if date, err := ParseHolidayDate(part); err == nil {
+			holidays = append(holidays, date)
+		}
+	}
+	return holidays
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// AI chat defaults, overridable with CHAT_HOURLY_LIMIT and CHAT_DAILY_BUDGET
+const (
+	defaultChatHourlyLimit = 20
+	defaultChatDailyBudget = 200
+	chatMemoryTurns        = 6
+	chatMemoryTTL          = 30 * time.Minute
+	maxChatAnswerChars     = 3500
+)
+
+// ChatTurn is a single question and answer in a user's conversation
+type ChatTurn struct {
+	Question string
+	Answer   string
+	At       time.Time
+}
+
+// ChatLimitError is returned when a user or chat ran out of AI chat budget
+type ChatLimitError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *ChatLimitError) Error() string {
+	return fmt.Sprintf("%s, try again in %s", e.Reason, e.RetryAfter.Round(time.Minute))
+}
+
+// ChatService answers general development questions with short per-user memory.
+// It has its own per-user hourly limit and per-chat daily budget, separate from /analyze.
+type ChatService struct {
+	ai          *AIChain
+	memory      map[int64][]ChatTurn
+	userLimiter *SlidingWindowLimiter
+	chatBudget  *SlidingWindowLimiter
+	mutex       sync.Mutex
+	logger      domain.Logger
+}
+
+// NewChatService creates a new AI chat service
+func NewChatService(ai *AIChain, logger domain.Logger) *ChatService {
+	return &ChatService{
+		ai:          ai,
+		memory:      make(map[int64][]ChatTurn),
+		userLimiter: NewSlidingWindowLimiter(envInt("CHAT_HOURLY_LIMIT", defaultChatHourlyLimit), time.Hour),
+		chatBudget:  NewSlidingWindowLimiter(envInt("CHAT_DAILY_BUDGET", defaultChatDailyBudget), 24*time.Hour),
+		logger:      logger,
+	}
+}
+
+// IsConfigured returns true if an AI provider is available
+func (s *ChatService) IsConfigured() bool {
+	return s.ai.IsConfigured()
+}
+
+// Ask answers a question using the user's recent turns as context
+func (s *ChatService) Ask(ctx context.Context, chatID, userID int64, question string) (string, error) {
+	if allowed, retry := s.userLimiter.Allow(userID); !allowed {
+		return "", &ChatLimitError{Reason: fmt.Sprintf("hourly limit of %d questions reached", s.userLimiter.Limit()), RetryAfter: retry}
+	}
+	if allowed, retry := s.chatBudget.Allow(chatID); !allowed {
+		return "", &ChatLimitError{Reason: fmt.Sprintf("daily chat budget of %d questions reached", s.chatBudget.Limit()), RetryAfter: retry}
+	}
+
+	history := s.History(userID)
+
+	var prompt strings.Builder
+	prompt.WriteString("You are a helpful senior software engineer answering questions from a development team in Telegram.\n")
+	prompt.WriteString("Answer concisely (under 300 words), use short code blocks when useful, and reply in the language of the question.\n\n")
+	if len(history) > 0 {
+		prompt.WriteString("Previous conversation:\n")
+		for _, turn := range history {
+			prompt.WriteString(fmt.Sprintf("User: %s\nAssistant: %s\n\n", turn.Question, turn.Answer))
+		}
+	}
+	prompt.WriteString(fmt.Sprintf("User: %s\nAssistant:", question))
+
+	answer, err := s.ai.Complete(ctx, prompt.String())
+	if err != nil {
+		return "", err
+	}
+	if len(answer) > maxChatAnswerChars {
+		answer = answer[:maxChatAnswerChars] + "..."
+	}
+
+	s.remember(userID, ChatTurn{Question: question, Answer: answer, At: time.Now()})
+	s.logger.Info("AI chat answered", "user_id", userID, "chat_id", chatID, "history_turns", len(history))
+
+	return answer, nil
+}
+
+// History returns the user's recent turns that have not expired
+func (s *ChatService) History(userID int64) []ChatTurn {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	turns := s.memory[userID]
+	if len(turns) > 0 && time.Since(turns[len(turns)-1].At) > chatMemoryTTL {
+		delete(s.memory, userID)
+		return nil
+	}
+
+	result := make([]ChatTurn, len(turns))
+	copy(result, turns)
+	return result
+}
+
+// Reset clears the user's conversation memory
+func (s *ChatService) Reset(userID int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.memory, userID)
+}
+
+// UserLimiter returns the per-user hourly limiter
+func (s *ChatService) UserLimiter() *SlidingWindowLimiter {
+	return s.userLimiter
+}
+
+// ChatBudget returns the per-chat daily budget limiter
+func (s *ChatService) ChatBudget() *SlidingWindowLimiter {
+	return s.chatBudget
+}
+
+// remember appends a turn keeping only the most recent ones
+func (s *ChatService) remember(userID int64, turn ChatTurn) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	turns := append(s.memory[userID], turn)
+	if len(turns) > chatMemoryTurns {
+		turns = turns[len(turns)-chatMemoryTurns:]
+	}
+	s.memory[userID] = turns
+}
+
+// envInt reads a positive integer from the environment with a default
+func envInt(name string, fallback int) int {
+	if value, err := strconv.Atoi(os.Getenv(name)); err == nil && value > 0 {
+		return value
+	}
+	return fallback
+}
+
+// envString reads a non-empty environment variable, or returns the fallback
+func envString(name, fallback string) string {
+	if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+		return value
+	}
+	return fallback
+}
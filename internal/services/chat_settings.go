@@ -0,0 +1,210 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Chat setting keys of the command categories, default language and file auto-analysis
+const (
+	disabledCategoriesKey = "disabled_categories"
+	chatLanguageKey       = "language"
+	autoAnalyzeKey        = "auto_analyze"
+)
+
+// Command categories a chat can turn off, named by each handler's Category. Commands outside
+// them, such as /help, /settings and /chat_settings, always work so a chat can turn a
+// category back on.
+const (
+	CategoryFun      = "fun"
+	CategoryAI       = "ai"
+	CategoryDev      = "dev"
+	CategoryTeam     = "team"
+	CategoryProjects = "projects"
+)
+
+// CategoryNames returns the command categories in alphabetical order
+func CategoryNames() []string {
+	return []string{CategoryAI, CategoryDev, CategoryFun, CategoryProjects, CategoryTeam}
+}
+
+// ChatSettings are the preferences of a group or private chat
+type ChatSettings struct {
+	// DisabledCategories are the command categories the chat turned off
+	DisabledCategories []string
+	// Language is the chat's default language (uz, ru or en), empty when not chosen
+	Language string
+	// AutoAnalyze analyzes files sent without a command
+	AutoAnalyze bool
+}
+
+// CategoryEnabled reports whether the chat allows the commands of a category
+func (s ChatSettings) CategoryEnabled(category string) bool {
+	for _, disabled := range s.DisabledCategories {
+		if disabled == category {
+			return false
+		}
+	}
+	return true
+}
+
+// ChatSettingsService manages which commands a chat allows, its language and whether files
+// are analyzed unasked. Settings are cached, as the router reads them for every command.
+type ChatSettingsService struct {
+	store  ChatSettingsStore
+	cache  map[int64]ChatSettings
+	mutex  sync.RWMutex
+	logger domain.Logger
+}
+
+// NewChatSettingsService creates a new chat settings service
+func NewChatSettingsService(store ChatSettingsStore, logger domain.Logger) *ChatSettingsService {
+	return &ChatSettingsService{
+		store:  store,
+		cache:  make(map[int64]ChatSettings),
+		logger: logger,
+	}
+}
+
+// Get returns the chat's settings, loading them from the store on first use
+func (s *ChatSettingsService) Get(chatID int64) ChatSettings {
+	s.mutex.RLock()
+	cached, found := s.cache[chatID]
+	s.mutex.RUnlock()
+	if found {
+		return cached
+	}
+
+	settings := ChatSettings{AutoAnalyze: true}
+	values, err := s.store.GetChatSettings(chatID)
+	if err != nil {
+		s.logger.Warn("Failed to load chat settings", "chat_id", chatID, "error", err)
+		return settings
+	}
+
+	if disabled := values[disabledCategoriesKey]; disabled != "" {
+		settings.DisabledCategories = strings.Split(disabled, ",")
+	}
+	settings.Language = values[chatLanguageKey]
+	settings.AutoAnalyze = values[autoAnalyzeKey] != "off"
+
+	s.mutex.Lock()
+	s.cache[chatID] = settings
+	s.mutex.Unlock()
+
+	return settings
+}
+
+// CategoryDisabled reports whether the chat turned a command category off. The empty
+// category of commands that always work is never disabled.
+func (s *ChatSettingsService) CategoryDisabled(chatID int64, category string) bool {
+	if s == nil || category == "" {
+		return false
+	}
+	return !s.Get(chatID).CategoryEnabled(category)
+}
+
+// Language returns the chat's default language, or "" when it has not chosen one
+func (s *ChatSettingsService) Language(chatID int64) string {
+	if s == nil {
+		return ""
+	}
+	return s.Get(chatID).Language
+}
+
+// AutoAnalyzes reports whether files sent without a command are analyzed in the chat,
+// which also needs the projects category
+func (s *ChatSettingsService) AutoAnalyzes(chatID int64) bool {
+	if s == nil {
+		return true
+	}
+	settings := s.Get(chatID)
+	return settings.AutoAnalyze && settings.CategoryEnabled(CategoryProjects)
+}
+
+// SetCategory turns a command category on or off
+func (s *ChatSettingsService) SetCategory(chatID int64, category string, enabled bool) error {
+	if !knownCategory(category) {
+		return fmt.Errorf("unknown category %q, use %s", category, strings.Join(CategoryNames(), ", "))
+	}
+
+	var disabled []string
+	for _, name := range s.Get(chatID).DisabledCategories {
+		if name != category {
+			disabled = append(disabled, name)
+		}
+	}
+	if !enabled {
+		disabled = append(disabled, category)
+		sort.Strings(disabled)
+	}
+
+	var err error
+	if len(disabled) == 0 {
+		err = s.store.DeleteChatSetting(chatID, disabledCategoriesKey)
+	} else {
+		err = s.store.SetChatSetting(chatID, disabledCategoriesKey, strings.Join(disabled, ","))
+	}
+	if err != nil {
+		return err
+	}
+
+	s.invalidate(chatID)
+	return nil
+}
+
+// SetLanguage sets the chat's default language; an empty language clears it
+func (s *ChatSettingsService) SetLanguage(chatID int64, language string) error {
+	var err error
+	if language == "" {
+		err = s.store.DeleteChatSetting(chatID, chatLanguageKey)
+	} else {
+		if _, ok := LanguageNames[language]; !ok {
+			return fmt.Errorf("unsupported language %q, use uz, ru or en", language)
+		}
+		err = s.store.SetChatSetting(chatID, chatLanguageKey, language)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.invalidate(chatID)
+	return nil
+}
+
+// SetAutoAnalyze turns the analysis of files sent without a command on or off
+func (s *ChatSettingsService) SetAutoAnalyze(chatID int64, enabled bool) error {
+	var err error
+	if enabled {
+		err = s.store.DeleteChatSetting(chatID, autoAnalyzeKey)
+	} else {
+		err = s.store.SetChatSetting(chatID, autoAnalyzeKey, "off")
+	}
+	if err != nil {
+		return err
+	}
+
+	s.invalidate(chatID)
+	return nil
+}
+
+// knownCategory reports whether a name is one of the command categories
+func knownCategory(category string) bool {
+	for _, name := range CategoryNames() {
+		if name == category {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidate drops the cached settings of a chat
+func (s *ChatSettingsService) invalidate(chatID int64) {
+	s.mutex.Lock()
+	delete(s.cache, chatID)
+	s.mutex.Unlock()
+}
@@ -0,0 +1,59 @@
+package services
+
+import "testing"
+
+func TestChatSettingsService(t *testing.T) {
+	store := memorySettings{}
+	settings := NewChatSettingsService(store, silentLogger{})
+
+	if settings.CategoryDisabled(-100, CategoryFun) || !settings.AutoAnalyzes(-100) || settings.Language(-100) != "" {
+		t.Fatalf("expected everything on without settings, got %+v", settings.Get(-100))
+	}
+
+	if err := settings.SetCategory(-100, CategoryFun, false); err != nil {
+		t.Fatalf("SetCategory() error = %v", err)
+	}
+	if err := settings.SetCategory(-100, CategoryProjects, false); err != nil {
+		t.Fatalf("SetCategory() error = %v", err)
+	}
+	if !settings.CategoryDisabled(-100, CategoryFun) {
+		t.Error("expected the fun category to be off")
+	}
+	if settings.CategoryDisabled(-100, "") {
+		t.Error("expected uncategorized commands to stay allowed")
+	}
+	if settings.AutoAnalyzes(-100) {
+		t.Error("expected no auto-analysis while the projects category is off")
+	}
+	if settings.CategoryDisabled(42, CategoryFun) {
+		t.Error("settings leaked into another chat")
+	}
+
+	if err := settings.SetCategory(-100, CategoryProjects, true); err != nil {
+		t.Fatalf("SetCategory() error = %v", err)
+	}
+	if err := settings.SetAutoAnalyze(-100, false); err != nil {
+		t.Fatalf("SetAutoAnalyze() error = %v", err)
+	}
+	if err := settings.SetLanguage(-100, "ru"); err != nil {
+		t.Fatalf("SetLanguage() error = %v", err)
+	}
+	if store[-100][disabledCategoriesKey] != CategoryFun || store[-100][autoAnalyzeKey] != "off" {
+		t.Errorf("stored %v, want fun off and auto-analysis off", store[-100])
+	}
+	if settings.AutoAnalyzes(-100) || settings.Language(-100) != "ru" {
+		t.Errorf("got %+v, want auto-analysis off and ru", settings.Get(-100))
+	}
+
+	if err := settings.SetCategory(-100, "games", false); err == nil {
+		t.Error("expected an unknown category to be rejected")
+	}
+	if err := settings.SetLanguage(-100, "de"); err == nil {
+		t.Error("expected an unsupported language to be rejected")
+	}
+
+	var unset *ChatSettingsService
+	if unset.CategoryDisabled(1, CategoryFun) || !unset.AutoAnalyzes(1) {
+		t.Error("expected a nil service to allow everything")
+	}
+}
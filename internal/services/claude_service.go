@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,7 +18,7 @@ import (
 // ClaudeService handles integration with Claude.ai API
 type ClaudeService struct {
 	apiKey     string
-	model      string
+	models     *ModelSelector
 	httpClient *http.Client
 	baseURL    string
 	logger     domain.Logger
@@ -28,6 +29,22 @@ type ClaudeRequest struct {
 	Model     string          `json:"model"`
 	MaxTokens int             `json:"max_tokens"`
 	Messages  []ClaudeMessage `json:"messages"`
+	// Tools and ToolChoice request structured output through a forced tool call
+	Tools      []ClaudeTool      `json:"tools,omitempty"`
+	ToolChoice *ClaudeToolChoice `json:"tool_choice,omitempty"`
+}
+
+// ClaudeTool describes a tool whose input follows a JSON schema
+type ClaudeTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+// ClaudeToolChoice forces Claude to call a specific tool
+type ClaudeToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
 }
 
 // ClaudeMessage represents a message in Claude API format
@@ -49,6 +66,9 @@ type ClaudeResponse struct {
 type ClaudeContent struct {
 	Type string `json:"type"`
 	Text string `json:"text"`
+	// Name and Input are set on "tool_use" blocks
+	Name  string          `json:"name,omitempty"`
+	Input json.RawMessage `json:"input,omitempty"`
 }
 
 // ClaudeUsage represents token usage in Claude response
@@ -59,15 +79,9 @@ type ClaudeUsage struct {
 
 // NewClaudeService creates a new Claude service
 func NewClaudeService(logger domain.Logger) *ClaudeService {
-	// Default to Claude-3 Haiku if no model specified
-	model := os.Getenv("CLAUDE_MODEL")
-	if model == "" {
-		model = "claude-3-haiku-20240307"
-	}
-	
 	return &ClaudeService{
 		apiKey:  os.Getenv("CLAUDE_API_KEY"),
-		model:   model,
+		models:  NewModelSelector("CLAUDE", "claude-3-haiku-20240307", "claude-3-5-sonnet-20241022"),
 		baseURL: "https://api.anthropic.com/v1/messages",
 		httpClient: &http.Client{
 			Timeout: 60 * time.Second,
@@ -81,6 +95,26 @@ func (c *ClaudeService) IsConfigured() bool {
 	return c.apiKey != ""
 }
 
+// Model returns the model used for regular-sized prompts
+func (c *ClaudeService) Model() string {
+	return c.models.Model()
+}
+
+// Complete sends a free-form prompt to Claude and returns the text response
+func (c *ClaudeService) Complete(ctx context.Context, prompt string) (string, error) {
+	if !c.IsConfigured() {
+		return "", fmt.Errorf("Claude API key not configured")
+	}
+
+	model, _ := c.models.Select(prompt)
+	response, _, err := c.sendRequest(ctx, model, prompt)
+	if err != nil {
+		return "", fmt.Errorf("Claude API request failed: %w", err)
+	}
+
+	return strings.TrimSpace(response), nil
+}
+
 // AnalyzeRequirement sends requirement to Claude for task breakdown
 func (c *ClaudeService) AnalyzeRequirement(ctx context.Context, req domain.TaskBreakdownRequest) (*domain.TaskBreakdownResponse, error) {
 	if !c.IsConfigured() {
@@ -89,17 +123,29 @@ func (c *ClaudeService) AnalyzeRequirement(ctx context.Context, req domain.TaskB
 
 	prompt := c.buildAnalysisPrompt(req)
 	
-	response, err := c.sendRequest(ctx, prompt)
-	if err != nil {
-		return nil, fmt.Errorf("Claude API request failed: %w", err)
+	model, estimated := c.models.Select(prompt)
+	result, tokens, err := c.analyzeStructured(ctx, model, prompt)
+	if errors.Is(err, errStructuredUnsupported) {
+		c.logger.Warn("Claude tool use unavailable, parsing JSON from text", "model", model, "error", err)
+		var response string
+		response, tokens, err = c.sendRequest(ctx, model, prompt)
+		if err != nil {
+			return nil, fmt.Errorf("Claude API request failed: %w", err)
+		}
+		if result, err = c.parseTaskBreakdown(response); err != nil {
+			return nil, fmt.Errorf("failed to parse Claude response: %w", &BreakdownParseError{Output: response, Err: err})
+		}
 	}
-
-	result, err := c.parseTaskBreakdown(response)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse Claude response: %w", err)
+		return nil, err
 	}
+	result.TokensUsed = tokens
+	result.Model = model
 
-	c.logger.Info("Claude analysis completed", 
+	c.logger.Info("Claude analysis completed",
+		"model", model,
+		"estimated_tokens", estimated,
+		"tokens_used", tokens,
 		"tasks_count", len(result.Tasks),
 		"confidence", result.Confidence,
 		"total_estimate", result.TotalEstimate)
@@ -118,7 +164,7 @@ Break down this development requirement into actionable tasks:
 **Requirement:** %s
 **Project Type:** %s
 **Team Skills:** %s
-
+%s
 Please provide a detailed task breakdown in the following JSON format:
 
 {
@@ -129,6 +175,8 @@ Please provide a detailed task breakdown in the following JSON format:
       "description": "Detailed description",
       "category": "backend|frontend|qa|devops",
       "estimate_hours": 4.5,
+      "optimistic_hours": 3,
+      "pessimistic_hours": 8,
       "priority": 1,
       "dependencies": []
     }
@@ -143,19 +191,65 @@ Please provide a detailed task breakdown in the following JSON format:
 Guidelines:
 - Break down into 3-15 specific, actionable tasks
 - Estimate hours realistically (consider complexity)
+- estimate_hours is the most likely effort; optimistic_hours and pessimistic_hours bound it
 - Use priority 1 (high), 2 (medium), 3 (low)
 - Categories: backend, frontend, qa, devops
 - Include dependencies between tasks
 - Confidence: 0.6-1.0 based on requirement clarity
 - Consider the team's available skills
 
-Respond only with valid JSON.`, req.Requirement, req.ProjectType, skillsStr)
+Respond only with valid JSON.`, req.Requirement, req.ProjectType, skillsStr, buildTeamContext(req)+buildDetailContext(req))
 }
 
-// sendRequest sends request to Claude API
-func (c *ClaudeService) sendRequest(ctx context.Context, prompt string) (string, error) {
-	reqData := ClaudeRequest{
-		Model:     c.model,
+// analyzeStructured asks Claude for the breakdown through a forced tool call whose input
+// is checked against taskBreakdownSchema
+func (c *ClaudeService) analyzeStructured(ctx context.Context, model, prompt string) (*domain.TaskBreakdownResponse, int, error) {
+	claudeResp, err := c.post(ctx, ClaudeRequest{
+		Model:     model,
+		MaxTokens: 4000,
+		Messages:  []ClaudeMessage{{Role: "user", Content: prompt}},
+		Tools: []ClaudeTool{{
+			Name:        taskBreakdownToolName,
+			Description: "Submit the task breakdown of the requirement",
+			InputSchema: taskBreakdownSchema,
+		}},
+		ToolChoice: &ClaudeToolChoice{Type: "tool", Name: taskBreakdownToolName},
+	}, "tool")
+	if err != nil {
+		if errors.Is(err, errStructuredUnsupported) {
+			return nil, 0, err
+		}
+		return nil, 0, fmt.Errorf("Claude API request failed: %w", err)
+	}
+	tokens := claudeResp.Usage.InputTokens + claudeResp.Usage.OutputTokens
+
+	for _, content := range claudeResp.Content {
+		if content.Type == "tool_use" && content.Name == taskBreakdownToolName {
+			result, err := decodeStructuredBreakdown(content.Input)
+			if err != nil {
+				return nil, tokens, fmt.Errorf("failed to parse Claude tool input: %w", &BreakdownParseError{Output: string(content.Input), Err: err})
+			}
+			return result, tokens, nil
+		}
+	}
+
+	// Without a tool call the breakdown can still be in a text block
+	for _, content := range claudeResp.Content {
+		if content.Type == "text" && strings.TrimSpace(content.Text) != "" {
+			result, err := c.parseTaskBreakdown(content.Text)
+			if err != nil {
+				return nil, tokens, fmt.Errorf("failed to parse Claude response: %w", &BreakdownParseError{Output: content.Text, Err: err})
+			}
+			return result, tokens, nil
+		}
+	}
+	return nil, tokens, fmt.Errorf("empty response from Claude")
+}
+
+// sendRequest sends a plain text request to Claude API
+func (c *ClaudeService) sendRequest(ctx context.Context, model, prompt string) (string, int, error) {
+	claudeResp, err := c.post(ctx, ClaudeRequest{
+		Model:     model,
 		MaxTokens: 4000,
 		Messages: []ClaudeMessage{
 			{
@@ -163,16 +257,29 @@ func (c *ClaudeService) sendRequest(ctx context.Context, prompt string) (string,
 				Content: prompt,
 			},
 		},
+	}, "")
+	if err != nil {
+		return "", 0, err
 	}
 
+	if len(claudeResp.Content) == 0 {
+		return "", 0, fmt.Errorf("empty response from Claude")
+	}
+
+	return claudeResp.Content[0].Text, claudeResp.Usage.InputTokens + claudeResp.Usage.OutputTokens, nil
+}
+
+// post sends a request to Claude API. A 400 response mentioning structuredFeature is
+// reported as errStructuredUnsupported.
+func (c *ClaudeService) post(ctx context.Context, reqData ClaudeRequest, structuredFeature string) (*ClaudeResponse, error) {
 	jsonData, err := json.Marshal(reqData)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -181,29 +288,28 @@ func (c *ClaudeService) sendRequest(ctx context.Context, prompt string) (string,
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		if structuredFeature != "" && structuredRejected(resp.StatusCode, body, structuredFeature) {
+			return nil, fmt.Errorf("%w: API error %d: %s", errStructuredUnsupported, resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
 	}
 
 	var claudeResp ClaudeResponse
 	if err := json.Unmarshal(body, &claudeResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	if len(claudeResp.Content) == 0 {
-		return "", fmt.Errorf("empty response from Claude")
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return claudeResp.Content[0].Text, nil
+	return &claudeResp, nil
 }
 
 // parseTaskBreakdown parses Claude's JSON response into task breakdown
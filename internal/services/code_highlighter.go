@@ -0,0 +1,385 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Limits and layout of highlighted code images. Text is drawn with the bitmap font at
+// codeScale, so a character cell is 12x22 pixels.
+const (
+	MaxHighlightLines   = 150
+	maxHighlightColumns = 120
+	codeScale           = 2
+	codePadding         = 20
+	codeCellWidth       = (glyphWidth + 1) * codeScale
+	codeLineHeight      = (glyphHeight + 3) * codeScale
+	// codeMaxAspect keeps one-line snippets within Telegram's photo aspect ratio limit
+	codeMaxAspect = 10
+)
+
+// Code image colors, a dark theme that stays readable on mobile clients
+var (
+	codeBackground = color.RGBA{0x0d, 0x11, 0x17, 0xff}
+	codeGutter     = color.RGBA{0x6e, 0x76, 0x81, 0xff}
+	codePlain      = color.RGBA{0xe6, 0xed, 0xf3, 0xff}
+	codeKeyword    = color.RGBA{0xff, 0x7b, 0x72, 0xff}
+	codeString     = color.RGBA{0xa5, 0xd6, 0xff, 0xff}
+	codeComment    = color.RGBA{0x8b, 0x94, 0x9e, 0xff}
+	codeNumber     = color.RGBA{0x79, 0xc0, 0xff, 0xff}
+)
+
+// CodeTokenKind classifies a piece of highlighted code
+type CodeTokenKind int
+
+// Token kinds of the highlighter
+const (
+	TokenPlain CodeTokenKind = iota
+	TokenKeyword
+	TokenString
+	TokenComment
+	TokenNumber
+)
+
+// CodeToken is a run of source text of one kind
+type CodeToken struct {
+	Kind CodeTokenKind
+	Text string
+}
+
+// codeLanguage describes what the highlighter needs to know about a language
+type codeLanguage struct {
+	label        string
+	keywords     map[string]bool
+	lineComment  string
+	blockComment [2]string
+	quotes       string
+	// rawQuote strings may span lines and have no escapes, e.g. Go's backticks
+	rawQuote rune
+	// ignoreCase matches keywords in any case, as SQL does
+	ignoreCase bool
+}
+
+// words builds a keyword set
+func words(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(list) {
+		set[word] = true
+	}
+	return set
+}
+
+// codeLanguages are the languages /highlight understands; unknown ones are drawn uncolored
+var codeLanguages = map[string]*codeLanguage{
+	"go": {
+		label: "Go",
+		keywords: words("break case chan const continue default defer else fallthrough for func go goto if import " +
+			"interface map package range return select struct switch type var true false nil iota"),
+		lineComment: "//", blockComment: [2]string{"/*", "*/"}, quotes: "\"'`", rawQuote: '`',
+	},
+	"python": {
+		label: "Python",
+		keywords: words("and as assert async await break class continue def del elif else except finally for from " +
+			"global if import in is lambda None nonlocal not or pass raise return True False try while with yield self"),
+		lineComment: "#", quotes: "\"'",
+	},
+	"javascript": {
+		label: "JavaScript",
+		keywords: words("async await break case catch class const continue debugger default delete do else export " +
+			"extends finally for function if import in instanceof let new of return super switch this throw try " +
+			"typeof var void while yield null undefined true false"),
+		lineComment: "//", blockComment: [2]string{"/*", "*/"}, quotes: "\"'`", rawQuote: '`',
+	},
+	"typescript": {
+		label: "TypeScript",
+		keywords: words("async await break case catch class const continue default delete do else enum export " +
+			"extends finally for function if implements import in instanceof interface let new of private protected " +
+			"public readonly return super switch this throw try type typeof var void while null undefined true false " +
+			"any boolean number string unknown never as"),
+		lineComment: "//", blockComment: [2]string{"/*", "*/"}, quotes: "\"'`", rawQuote: '`',
+	},
+	"java": {
+		label: "Java",
+		keywords: words("abstract boolean break byte case catch char class continue default do double else enum " +
+			"extends final finally float for if implements import instanceof int interface long new package private " +
+			"protected public return short static super switch this throw throws try void volatile while null true false"),
+		lineComment: "//", blockComment: [2]string{"/*", "*/"}, quotes: "\"'",
+	},
+	"sql": {
+		label: "SQL",
+		keywords: words("select from where and or not insert into values update set delete create table index drop " +
+			"alter add column join left right inner outer on group by order having limit offset as distinct null is " +
+			"in like between primary key foreign references default unique case when then else end begin commit " +
+			"rollback union all exists count sum avg min max asc desc"),
+		lineComment: "--", blockComment: [2]string{"/*", "*/"}, quotes: "'\"", ignoreCase: true,
+	},
+	"bash": {
+		label:       "Bash",
+		keywords:    words("if then else elif fi for while until do done case esac function in return export local echo exit"),
+		lineComment: "#", quotes: "\"'",
+	},
+	"json": {
+		label:    "JSON",
+		keywords: words("true false null"),
+		quotes:   "\"",
+	},
+	"text": {
+		label: "Text",
+	},
+}
+
+// codeLanguageAliases maps common names and file extensions to a language
+var codeLanguageAliases = map[string]string{
+	"golang": "go", "py": "python", "js": "javascript", "node": "javascript", "ts": "typescript",
+	"sh": "bash", "shell": "bash", "zsh": "bash", "postgres": "sql", "postgresql": "sql", "sqlite": "sql",
+	"mysql": "sql", "txt": "text", "plain": "text",
+}
+
+// CodeLanguage resolves a language name or alias, reporting whether it is known
+func CodeLanguage(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := codeLanguageAliases[name]; ok {
+		name = alias
+	}
+	_, ok := codeLanguages[name]
+	return name, ok
+}
+
+// CodeLanguageNames returns the known languages in alphabetical order
+func CodeLanguageNames() []string {
+	names := make([]string, 0, len(codeLanguages))
+	for name := range codeLanguages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CodeLanguageLabel returns a readable language name, e.g. "JavaScript"
+func CodeLanguageLabel(name string) string {
+	if language, ok := codeLanguages[name]; ok {
+		return language.label
+	}
+	return codeLanguages["text"].label
+}
+
+// Hints DetectCodeLanguage looks for, in order
+var codeLanguageHints = []struct {
+	language string
+	pattern  *regexp.Regexp
+}{
+	{"go", regexp.MustCompile(`(?m)^package \w+|\bfunc\b.*\{|:= `)},
+	{"sql", regexp.MustCompile(`(?i)^\s*(select|insert into|update|delete from|create table|alter table)\b`)},
+	{"python", regexp.MustCompile(`(?m)^\s*(def \w+\(.*\):|class \w+.*:$|from [\w.]+ import |import \w+$)`)},
+	{"bash", regexp.MustCompile(`^#!/bin/|(?m)^\s*(echo |export \w+=|sudo |apt(-get)? )`)},
+	{"java", regexp.MustCompile(`\bpublic (static )?(class|void)\b|System\.out\.`)},
+	{"typescript", regexp.MustCompile(`\binterface \w+ \{|: (string|number|boolean)\b`)},
+	{"javascript", regexp.MustCompile(`\bfunction\b|=>|\bconsole\.log\(|\b(const|let) \w+ =`)},
+}
+
+// DetectCodeLanguage guesses the language of a snippet, "text" when nothing matches
+func DetectCodeLanguage(code string) string {
+	trimmed := strings.TrimSpace(code)
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		return "json"
+	}
+	for _, hint := range codeLanguageHints {
+		if hint.pattern.MatchString(code) {
+			return hint.language
+		}
+	}
+	return "text"
+}
+
+// TokenizeCode splits source code into tokens of the language; joined, the tokens give the
+// code back unchanged
+func TokenizeCode(code, languageName string) []CodeToken {
+	language, ok := codeLanguages[languageName]
+	if !ok {
+		language = codeLanguages["text"]
+	}
+
+	var tokens []CodeToken
+	emit := func(kind CodeTokenKind, text string) {
+		if text == "" {
+			return
+		}
+		if last := len(tokens) - 1; last >= 0 && tokens[last].Kind == kind {
+			tokens[last].Text += text
+			return
+		}
+		tokens = append(tokens, CodeToken{Kind: kind, Text: text})
+	}
+
+	runes := []rune(code)
+	for i := 0; i < len(runes); {
+		rest := string(runes[i:])
+		switch {
+		case language.lineComment != "" && strings.HasPrefix(rest, language.lineComment):
+			end := strings.IndexRune(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			emit(TokenComment, rest[:end])
+			i += len([]rune(rest[:end]))
+		case language.blockComment[0] != "" && strings.HasPrefix(rest, language.blockComment[0]):
+			end := strings.Index(rest[len(language.blockComment[0]):], language.blockComment[1])
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += len(language.blockComment[0]) + len(language.blockComment[1])
+			}
+			emit(TokenComment, rest[:end])
+			i += len([]rune(rest[:end]))
+		case strings.ContainsRune(language.quotes, runes[i]):
+			end := stringEnd(runes, i, runes[i] == language.rawQuote)
+			emit(TokenString, string(runes[i:end]))
+			i = end
+		case unicode.IsDigit(runes[i]):
+			end := i
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || unicode.IsLetter(runes[end]) || runes[end] == '.' || runes[end] == '_') {
+				end++
+			}
+			emit(TokenNumber, string(runes[i:end]))
+			i = end
+		case unicode.IsLetter(runes[i]) || runes[i] == '_':
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+				end++
+			}
+			word := string(runes[i:end])
+			key := word
+			if language.ignoreCase {
+				key = strings.ToLower(word)
+			}
+			if language.keywords[key] {
+				emit(TokenKeyword, word)
+			} else {
+				emit(TokenPlain, word)
+			}
+			i = end
+		default:
+			emit(TokenPlain, string(runes[i]))
+			i++
+		}
+	}
+	return tokens
+}
+
+// stringEnd returns the index after the string literal starting at start. Escaped quotes
+// are skipped; strings other than raw ones end at the line break when left open.
+func stringEnd(runes []rune, start int, raw bool) int {
+	quote := runes[start]
+	for i := start + 1; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && !raw:
+			i++
+		case runes[i] == quote:
+			return i + 1
+		case runes[i] == '\n' && !raw:
+			return i
+		}
+	}
+	return len(runes)
+}
+
+// tokenColor returns the color a token is drawn in
+func tokenColor(kind CodeTokenKind) color.Color {
+	switch kind {
+	case TokenKeyword:
+		return codeKeyword
+	case TokenString:
+		return codeString
+	case TokenComment:
+		return codeComment
+	case TokenNumber:
+		return codeNumber
+	default:
+		return codePlain
+	}
+}
+
+// RenderCodeImage draws highlighted code with line numbers as a PNG. Tabs become four
+// spaces, lines are cut at maxHighlightColumns and characters outside ASCII show as '?'.
+func RenderCodeImage(code, languageName string) ([]byte, error) {
+	code = strings.TrimRight(strings.ReplaceAll(strings.ReplaceAll(code, "\r\n", "\n"), "\t", "    "), "\n ")
+	if strings.TrimSpace(code) == "" {
+		return nil, fmt.Errorf("no code to render")
+	}
+	if lineCount := strings.Count(code, "\n") + 1; lineCount > MaxHighlightLines {
+		return nil, fmt.Errorf("code has %d lines, the limit is %d", lineCount, MaxHighlightLines)
+	}
+
+	// Split the tokens into lines so each line can be cut at the column limit
+	lines := [][]CodeToken{nil}
+	for _, token := range TokenizeCode(code, languageName) {
+		for i, part := range strings.Split(token.Text, "\n") {
+			if i > 0 {
+				lines = append(lines, nil)
+			}
+			if part != "" {
+				lines[len(lines)-1] = append(lines[len(lines)-1], CodeToken{Kind: token.Kind, Text: part})
+			}
+		}
+	}
+
+	columns := 0
+	for _, line := range lines {
+		width := 0
+		for _, token := range line {
+			width += len([]rune(token.Text))
+		}
+		if width > columns {
+			columns = width
+		}
+	}
+	if columns > maxHighlightColumns {
+		columns = maxHighlightColumns
+	}
+
+	gutter := len(fmt.Sprint(len(lines))) + 2
+	width := 2*codePadding + (gutter+columns)*codeCellWidth
+	height := 2*codePadding + len(lines)*codeLineHeight
+	if height < width/codeMaxAspect {
+		height = width / codeMaxAspect
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{codeBackground}, image.Point{}, draw.Src)
+
+	for number, line := range lines {
+		y := codePadding + number*codeLineHeight
+		label := fmt.Sprintf("%*d", gutter-2, number+1)
+		for i, char := range label {
+			drawGlyph(img, codePadding+i*codeCellWidth, y, codeScale, char, codeGutter)
+		}
+
+		column := 0
+		for _, token := range line {
+			for _, char := range token.Text {
+				if column >= maxHighlightColumns {
+					break
+				}
+				if char != ' ' {
+					drawGlyph(img, codePadding+(gutter+column)*codeCellWidth, y, codeScale, char, tokenColor(token.Kind))
+				}
+				column++
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
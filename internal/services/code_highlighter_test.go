@@ -0,0 +1,84 @@
+package services
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestTokenizeCode(t *testing.T) {
+	code := "func main() {\n\t// say hi\n\tfmt.Println(\"hi \\\"there\\\"\", 42)\n}"
+	tokens := TokenizeCode(code, "go")
+
+	var joined strings.Builder
+	kinds := make(map[string]CodeTokenKind)
+	for _, token := range tokens {
+		joined.WriteString(token.Text)
+		kinds[strings.TrimSpace(token.Text)] = token.Kind
+	}
+	if joined.String() != code {
+		t.Fatalf("tokens do not join back to the code: %q", joined.String())
+	}
+	for text, want := range map[string]CodeTokenKind{
+		"func":           TokenKeyword,
+		"// say hi":      TokenComment,
+		`"hi \"there\""`: TokenString,
+		"42":             TokenNumber,
+	} {
+		if kind, ok := kinds[text]; !ok || kind != want {
+			t.Errorf("%s: kind %v (found %v), want %v", text, kind, ok, want)
+		}
+	}
+
+	// SQL keywords match in any case
+	if tokens := TokenizeCode("SELECT id", "sql"); tokens[0].Kind != TokenKeyword {
+		t.Errorf("expected SELECT to be a keyword, got %+v", tokens[0])
+	}
+}
+
+func TestDetectCodeLanguage(t *testing.T) {
+	for code, want := range map[string]string{
+		"package main\n\nfunc main() {}":   "go",
+		"def add(a, b):\n    return a + b": "python",
+		"select * from users where id = 1": "sql",
+		"const sum = (a, b) => a + b":      "javascript",
+		`{"name": "bot", "tags": ["go"]}`:  "json",
+		"#!/bin/bash\necho done":           "bash",
+		"just some words":                  "text",
+	} {
+		if got := DetectCodeLanguage(code); got != want {
+			t.Errorf("DetectCodeLanguage(%q) = %s, want %s", code, got, want)
+		}
+	}
+	if name, ok := CodeLanguage("Golang"); !ok || name != "go" {
+		t.Errorf("expected the golang alias to resolve to go, got %s, %v", name, ok)
+	}
+}
+
+func TestRenderCodeImage(t *testing.T) {
+	data, err := RenderCodeImage("func main() {\n\treturn\n}", "go")
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+
+	// Three lines of at most 13 columns after a gutter of one digit and two spaces
+	wantWidth := 2*codePadding + (3+13)*codeCellWidth
+	if bounds := img.Bounds(); bounds.Dx() != wantWidth || bounds.Dy() != 2*codePadding+3*codeLineHeight {
+		t.Errorf("unexpected size %v", bounds)
+	}
+
+	// The keyword "func" starts with the top bar of the F in the keyword color
+	x, y := codePadding+3*codeCellWidth+codeCellWidth/2, codePadding
+	if r, g, b, _ := img.At(x, y).RGBA(); r>>8 != 0xff || g>>8 != 0x7b || b>>8 != 0x72 {
+		t.Errorf("expected the keyword color at the start of func, got %02x%02x%02x", r>>8, g>>8, b>>8)
+	}
+
+	if _, err := RenderCodeImage(strings.Repeat("x\n", MaxHighlightLines+1), "text"); err == nil {
+		t.Error("expected an error for code over the line limit")
+	}
+}
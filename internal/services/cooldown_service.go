@@ -0,0 +1,165 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// cooldownKeyPrefix starts the chat setting keys that keep when a command last ran
+const cooldownKeyPrefix = "cooldown:"
+
+// CooldownService limits how often a chat may run a command, whoever in the chat runs it,
+// e.g. emailing the status report once per hour. Unlike the per-user rate limit it only
+// covers the commands configured in config.json. The last run is kept in the chat settings,
+// so a restart does not reset a cooldown.
+type CooldownService struct {
+	// rules maps a command, optionally followed by its first argument, to its cooldown
+	rules  map[string]time.Duration
+	store  ChatSettingsStore
+	mutex  sync.Mutex
+	logger domain.Logger
+	now    func() time.Time
+}
+
+// NewCooldownService creates a cooldown service from rules such as
+// {"/status_report email": "1h"}. Rules with an invalid duration are skipped.
+func NewCooldownService(rules map[string]string, store ChatSettingsStore, logger domain.Logger) *CooldownService {
+	parsed := make(map[string]time.Duration, len(rules))
+	for command, value := range rules {
+		every, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || every <= 0 {
+			logger.Warn("Ignoring invalid command cooldown", "command", command, "cooldown", value)
+			continue
+		}
+		parsed[strings.Join(strings.Fields(strings.ToLower(command)), " ")] = every
+	}
+	return &CooldownService{
+		rules:  parsed,
+		store:  store,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Rules returns the configured cooldowns by command
+func (s *CooldownService) Rules() map[string]time.Duration {
+	if s == nil {
+		return nil
+	}
+	return s.rules
+}
+
+// Rule returns the cooldown that applies to a command text, preferring a rule for the
+// command with its first argument over one for the command alone; "" when none applies
+func (s *CooldownService) Rule(text string) (string, time.Duration) {
+	if s == nil || len(s.rules) == 0 {
+		return "", 0
+	}
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 {
+		return "", 0
+	}
+	command, _, _ := strings.Cut(fields[0], "@")
+
+	if len(fields) > 1 {
+		if every, ok := s.rules[command+" "+fields[1]]; ok {
+			return command + " " + fields[1], every
+		}
+	}
+	if every, ok := s.rules[command]; ok {
+		return command, every
+	}
+	return "", 0
+}
+
+// Begin starts a run of a command in a chat. While the command's cooldown lasts it
+// returns the rule and the time left. Otherwise it records the run and returns a release
+// function that forgets it again, for runs that fail; commands without a cooldown get a
+// no-op release.
+func (s *CooldownService) Begin(chatID int64, text string) (string, time.Duration, func()) {
+	rule, every := s.Rule(text)
+	if rule == "" {
+		return "", 0, func() {}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := cooldownKeyPrefix + rule
+	settings, err := s.store.GetChatSettings(chatID)
+	if err != nil {
+		// A cooldown is not worth failing the command for
+		s.logger.Error("Failed to load command cooldown", "chat_id", chatID, "command", rule, "error", err)
+		return "", 0, func() {}
+	}
+
+	now := s.now()
+	previous := settings[key]
+	if previous != "" {
+		if unix, err := strconv.ParseInt(previous, 10, 64); err == nil {
+			if wait := time.Unix(unix, 0).Add(every).Sub(now); wait > 0 {
+				return rule, wait, nil
+			}
+		}
+	}
+
+	if err := s.store.SetChatSetting(chatID, key, strconv.FormatInt(now.Unix(), 10)); err != nil {
+		s.logger.Error("Failed to save command cooldown", "chat_id", chatID, "command", rule, "error", err)
+		return "", 0, func() {}
+	}
+
+	release := func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		var err error
+		if previous == "" {
+			err = s.store.DeleteChatSetting(chatID, key)
+		} else {
+			err = s.store.SetChatSetting(chatID, key, previous)
+		}
+		if err != nil {
+			s.logger.Error("Failed to release command cooldown", "chat_id", chatID, "command", rule, "error", err)
+		}
+	}
+	return "", 0, release
+}
+
+// Remaining returns the cooldowns still running in a chat by command
+func (s *CooldownService) Remaining(chatID int64) map[string]time.Duration {
+	if s == nil || len(s.rules) == 0 {
+		return nil
+	}
+	settings, err := s.store.GetChatSettings(chatID)
+	if err != nil {
+		s.logger.Error("Failed to load command cooldowns", "chat_id", chatID, "error", err)
+		return nil
+	}
+
+	now := s.now()
+	remaining := make(map[string]time.Duration)
+	for rule, every := range s.rules {
+		unix, err := strconv.ParseInt(settings[cooldownKeyPrefix+rule], 10, 64)
+		if err != nil {
+			continue
+		}
+		if wait := time.Unix(unix, 0).Add(every).Sub(now); wait > 0 {
+			remaining[rule] = wait
+		}
+	}
+	return remaining
+}
+
+// Commands returns the commands with a cooldown in alphabetical order
+func (s *CooldownService) Commands() []string {
+	commands := make([]string, 0, len(s.Rules()))
+	for command := range s.Rules() {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCooldownService(t *testing.T) {
+	settings := memorySettings{}
+	rules := map[string]string{
+		"/export_json":          "1h",
+		"/Status_Report  Email": "30m",
+		"/broken":               "soon",
+	}
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	newService := func() *CooldownService {
+		service := NewCooldownService(rules, settings, silentLogger{})
+		service.now = func() time.Time { return now }
+		return service
+	}
+	cooldowns := newService()
+
+	if _, ok := cooldowns.Rules()["/broken"]; ok {
+		t.Error("expected the rule with an invalid duration to be skipped")
+	}
+	if rule, every := cooldowns.Rule("/status_report@yordamchi_bot email team"); rule != "/status_report email" || every != 30*time.Minute {
+		t.Errorf("Rule() = %q, %v, want the rule with the argument", rule, every)
+	}
+	if rule, _ := cooldowns.Rule("/status_report"); rule != "" {
+		t.Errorf("Rule(/status_report) = %q, want none", rule)
+	}
+
+	if rule, wait, release := cooldowns.Begin(1, "/export_json"); rule != "" || wait != 0 || release == nil {
+		t.Fatalf("first Begin() = %q, %v, want the run admitted", rule, wait)
+	}
+	now = now.Add(20 * time.Minute)
+	if rule, wait, _ := cooldowns.Begin(1, "/export_json"); rule != "/export_json" || wait != 40*time.Minute {
+		t.Errorf("Begin() within the cooldown = %q, %v, want 40m left", rule, wait)
+	}
+	if _, wait, _ := cooldowns.Begin(2, "/export_json"); wait != 0 {
+		t.Error("expected the cooldown to apply to one chat only")
+	}
+
+	// A restart keeps the cooldown
+	restarted := newService()
+	if _, wait, _ := restarted.Begin(1, "/export_json"); wait != 40*time.Minute {
+		t.Errorf("Begin() after a restart waits %v, want 40m", wait)
+	}
+	if remaining := restarted.Remaining(1); len(remaining) != 1 || remaining["/export_json"] != 40*time.Minute {
+		t.Errorf("Remaining() = %v, want /export_json for 40m", remaining)
+	}
+
+	// A released run does not count
+	_, _, release := restarted.Begin(1, "/status_report email")
+	release()
+	if _, wait, _ := restarted.Begin(1, "/status_report email"); wait != 0 {
+		t.Errorf("Begin() after a release waits %v, want none", wait)
+	}
+
+	now = now.Add(time.Hour)
+	if _, wait, _ := restarted.Begin(1, "/export_json"); wait != 0 {
+		t.Errorf("Begin() after the cooldown waits %v, want none", wait)
+	}
+}
@@ -0,0 +1,399 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Dependency check limits. Only direct requirements are looked up on the module proxy;
+// every requirement is checked for vulnerabilities.
+const (
+	maxGoModBytes         = 1 << 20
+	maxDependencyLookups  = 80
+	dependencyLookupLimit = 8
+)
+
+// GoModule is a requirement of a go.mod file
+type GoModule struct {
+	Path     string
+	Version  string
+	Indirect bool
+}
+
+// ModuleUpdate is a requirement with a newer version on the module proxy
+type ModuleUpdate struct {
+	Path      string
+	Current   string
+	Latest    string
+	Changelog string
+}
+
+// ModuleVulnerability lists the known vulnerabilities of a required module version
+type ModuleVulnerability struct {
+	Path    string
+	Version string
+	IDs     []string
+}
+
+// DependencyReport is the outcome of checking a repository's Go modules
+type DependencyReport struct {
+	Repository      string
+	Module          string
+	Requirements    int
+	Direct          int
+	Updates         []ModuleUpdate
+	Vulnerabilities []ModuleVulnerability
+}
+
+// DependencyChecker reads a repository's go.mod through the GitHub API and compares its
+// requirements with the Go module proxy and the OSV vulnerability database
+type DependencyChecker struct {
+	http   *HTTPClient
+	logger Logger
+	// token raises the GitHub rate limit and gives access to private repositories
+	token string
+	// githubAPI, proxyURL and osvURL are the service endpoints, replaced in tests
+	githubAPI string
+	proxyURL  string
+	osvURL    string
+}
+
+// NewDependencyChecker creates a dependency checker, using GITHUB_TOKEN when set
+func NewDependencyChecker(logger Logger) *DependencyChecker {
+	return &DependencyChecker{
+		http:      NewHTTPClient(20*time.Second, logger),
+		logger:    logger,
+		token:     os.Getenv("GITHUB_TOKEN"),
+		githubAPI: "https://api.github.com",
+		proxyURL:  "https://proxy.golang.org",
+		osvURL:    "https://api.osv.dev",
+	}
+}
+
+// Check reads the go.mod at the root of the "owner/name" repository's default branch and
+// reports the requirements with newer versions or known vulnerabilities
+func (c *DependencyChecker) Check(ctx context.Context, repository string) (*DependencyReport, error) {
+	goMod, err := c.fetchGoMod(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	modulePath, requirements := ParseGoMod(goMod)
+	report := &DependencyReport{
+		Repository:   repository,
+		Module:       modulePath,
+		Requirements: len(requirements),
+	}
+
+	var direct []GoModule
+	for _, module := range requirements {
+		if !module.Indirect {
+			direct = append(direct, module)
+		}
+	}
+	report.Direct = len(direct)
+	if len(direct) > maxDependencyLookups {
+		direct = direct[:maxDependencyLookups]
+	}
+
+	report.Updates = c.findUpdates(ctx, direct)
+	report.Vulnerabilities, err = c.findVulnerabilities(ctx, requirements)
+	if err != nil {
+		// Updates are still worth reporting when OSV is unavailable
+		c.logger.Printf("⚠️ OSV check failed for %s: %v", repository, err)
+	}
+
+	return report, nil
+}
+
+// fetchGoMod downloads the raw go.mod of a repository
+func (c *DependencyChecker) fetchGoMod(ctx context.Context, repository string) ([]byte, error) {
+	headers := map[string]string{"Accept": "application/vnd.github.raw"}
+	if c.token != "" {
+		headers["Authorization"] = "Bearer " + c.token
+	}
+
+	resp, err := c.http.GetWithLimit(ctx, fmt.Sprintf("%s/repos/%s/contents/go.mod", c.githubAPI, repository), headers, maxGoModBytes)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("no go.mod found at the root of %s, or the repository is private", repository)
+	default:
+		return nil, fmt.Errorf("GitHub returned HTTP %d for %s", resp.StatusCode, repository)
+	}
+}
+
+// findUpdates looks up the latest version of each module on the proxy, a few at a time
+func (c *DependencyChecker) findUpdates(ctx context.Context, modules []GoModule) []ModuleUpdate {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		updates []ModuleUpdate
+	)
+	slots := make(chan struct{}, dependencyLookupLimit)
+	for _, module := range modules {
+		wg.Add(1)
+		go func(module GoModule) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			latest, err := c.latestVersion(ctx, module.Path)
+			if err != nil {
+				c.logger.Printf("⚠️ Latest version of %s unavailable: %v", module.Path, err)
+				return
+			}
+			if CompareModuleVersions(latest, module.Version) <= 0 {
+				return
+			}
+
+			mu.Lock()
+			updates = append(updates, ModuleUpdate{
+				Path:      module.Path,
+				Current:   module.Version,
+				Latest:    latest,
+				Changelog: ModuleChangelogURL(module.Path, latest),
+			})
+			mu.Unlock()
+		}(module)
+	}
+	wg.Wait()
+
+	sort.Slice(updates, func(i, j int) bool { return updates[i].Path < updates[j].Path })
+	return updates
+}
+
+// latestVersion asks the module proxy for the latest version of a module
+func (c *DependencyChecker) latestVersion(ctx context.Context, modulePath string) (string, error) {
+	var info struct {
+		Version string `json:"Version"`
+	}
+	if err := c.http.GetJSON(ctx, fmt.Sprintf("%s/%s/@latest", c.proxyURL, EscapeModulePath(modulePath)), nil, &info); err != nil {
+		return "", err
+	}
+	if info.Version == "" {
+		return "", fmt.Errorf("the proxy returned no version")
+	}
+	return info.Version, nil
+}
+
+// osvQuery is a package version looked up in the OSV batch API
+type osvQuery struct {
+	Package struct {
+		Name      string `json:"name"`
+		Ecosystem string `json:"ecosystem"`
+	} `json:"package"`
+	Version string `json:"version"`
+}
+
+// findVulnerabilities queries OSV for the known vulnerabilities of every requirement
+func (c *DependencyChecker) findVulnerabilities(ctx context.Context, modules []GoModule) ([]ModuleVulnerability, error) {
+	if len(modules) == 0 {
+		return nil, nil
+	}
+
+	queries := make([]osvQuery, len(modules))
+	for i, module := range modules {
+		queries[i].Package.Name = module.Path
+		queries[i].Package.Ecosystem = "Go"
+		// OSV stores Go versions without the "v" prefix
+		queries[i].Version = strings.TrimPrefix(module.Version, "v")
+	}
+
+	var response struct {
+		Results []struct {
+			Vulns []struct {
+				ID string `json:"id"`
+			} `json:"vulns"`
+		} `json:"results"`
+	}
+	if err := c.http.PostJSON(ctx, c.osvURL+"/v1/querybatch", nil, map[string]interface{}{"queries": queries}, &response); err != nil {
+		return nil, err
+	}
+
+	var vulnerabilities []ModuleVulnerability
+	for i, result := range response.Results {
+		if i >= len(modules) || len(result.Vulns) == 0 {
+			continue
+		}
+		vulnerability := ModuleVulnerability{Path: modules[i].Path, Version: modules[i].Version}
+		for _, vuln := range result.Vulns {
+			vulnerability.IDs = append(vulnerability.IDs, vuln.ID)
+		}
+		vulnerabilities = append(vulnerabilities, vulnerability)
+	}
+	return vulnerabilities, nil
+}
+
+// ParseGoMod returns the module path and the requirements of a go.mod file
+func ParseGoMod(data []byte) (string, []GoModule) {
+	modulePath := ""
+	var modules []GoModule
+	inRequire := false
+	for _, line := range strings.Split(string(data), "\n") {
+		comment := ""
+		if i := strings.Index(line, "//"); i >= 0 {
+			line, comment = line[:i], line[i+2:]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case inRequire && fields[0] == ")":
+			inRequire = false
+			continue
+		case inRequire:
+		case fields[0] == "module" && len(fields) == 2:
+			modulePath = strings.Trim(fields[1], `"`)
+			continue
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inRequire = true
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		default:
+			continue
+		}
+
+		if len(fields) != 2 {
+			continue
+		}
+		modules = append(modules, GoModule{
+			Path:     strings.Trim(fields[0], `"`),
+			Version:  fields[1],
+			Indirect: strings.TrimSpace(comment) == "indirect" || strings.HasPrefix(strings.TrimSpace(comment), "indirect;"),
+		})
+	}
+	return modulePath, modules
+}
+
+// EscapeModulePath escapes a module path for the module proxy, which writes capital letters
+// as "!" followed by the lower-case letter
+func EscapeModulePath(path string) string {
+	var escaped strings.Builder
+	for _, r := range path {
+		if r >= 'A' && r <= 'Z' {
+			escaped.WriteByte('!')
+			r += 'a' - 'A'
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
+
+// ModuleChangelogURL links to the release notes of a module version: the GitHub release for
+// modules hosted on GitHub, the pkg.go.dev page otherwise
+func ModuleChangelogURL(modulePath, version string) string {
+	parts := strings.Split(modulePath, "/")
+	if len(parts) < 3 || parts[0] != "github.com" {
+		return fmt.Sprintf("https://pkg.go.dev/%s@%s", modulePath, version)
+	}
+
+	// Modules in a subdirectory are tagged "dir/vX.Y.Z"; a /vN suffix is not part of the tag
+	subdirectory := parts[3:]
+	if n := len(subdirectory); n > 0 && isMajorVersionSuffix(subdirectory[n-1]) {
+		subdirectory = subdirectory[:n-1]
+	}
+	tag := strings.TrimSuffix(version, "+incompatible")
+	if len(subdirectory) > 0 {
+		tag = strings.Join(subdirectory, "/") + "/" + tag
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/releases/tag/%s", parts[1], parts[2], tag)
+}
+
+// isMajorVersionSuffix reports whether a path element is a major version suffix such as v2
+func isMajorVersionSuffix(element string) bool {
+	if len(element) < 2 || element[0] != 'v' {
+		return false
+	}
+	major, err := strconv.Atoi(element[1:])
+	return err == nil && major >= 2
+}
+
+// CompareModuleVersions compares two semantic versions such as v1.2.3 or
+// v0.0.0-20240101000000-abcdef, returning -1, 0 or 1. Build metadata is ignored and a
+// pre-release sorts before its release.
+func CompareModuleVersions(a, b string) int {
+	coreA, preA := splitModuleVersion(a)
+	coreB, preB := splitModuleVersion(b)
+	for i := 0; i < 3; i++ {
+		if coreA[i] != coreB[i] {
+			if coreA[i] < coreB[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+
+	partsA, partsB := strings.Split(preA, "."), strings.Split(preB, ".")
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		if result := comparePrereleasePart(partsA[i], partsB[i]); result != 0 {
+			return result
+		}
+	}
+	switch {
+	case len(partsA) < len(partsB):
+		return -1
+	case len(partsA) > len(partsB):
+		return 1
+	}
+	return 0
+}
+
+// splitModuleVersion returns the major, minor and patch numbers and the pre-release of a version
+func splitModuleVersion(version string) ([3]int, string) {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.Index(version, "+"); i >= 0 {
+		version = version[:i]
+	}
+	core, prerelease, _ := strings.Cut(version, "-")
+
+	var numbers [3]int
+	for i, part := range strings.SplitN(core, ".", 3) {
+		numbers[i], _ = strconv.Atoi(part)
+	}
+	return numbers, prerelease
+}
+
+// comparePrereleasePart compares pre-release identifiers: numbers numerically and below words
+func comparePrereleasePart(a, b string) int {
+	numberA, errA := strconv.Atoi(a)
+	numberB, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		switch {
+		case numberA < numberB:
+			return -1
+		case numberA > numberB:
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
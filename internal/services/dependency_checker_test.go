@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const sampleGoMod = `module github.com/acme/api
+
+go 1.22
+
+require github.com/lib/pq v1.10.0
+
+require (
+	github.com/BurntSushi/toml v1.3.0
+	golang.org/x/net v0.20.0 // indirect
+	"github.com/acme/tools/cli/v2" v2.1.0
+)
+
+replace github.com/lib/pq => ../pq
+`
+
+func TestParseGoMod(t *testing.T) {
+	module, requirements := ParseGoMod([]byte(sampleGoMod))
+	if module != "github.com/acme/api" {
+		t.Errorf("module = %q", module)
+	}
+	want := []GoModule{
+		{Path: "github.com/lib/pq", Version: "v1.10.0"},
+		{Path: "github.com/BurntSushi/toml", Version: "v1.3.0"},
+		{Path: "golang.org/x/net", Version: "v0.20.0", Indirect: true},
+		{Path: "github.com/acme/tools/cli/v2", Version: "v2.1.0"},
+	}
+	if len(requirements) != len(want) {
+		t.Fatalf("requirements = %+v", requirements)
+	}
+	for i := range want {
+		if requirements[i] != want[i] {
+			t.Errorf("requirement %d = %+v, want %+v", i, requirements[i], want[i])
+		}
+	}
+}
+
+func TestCompareModuleVersions(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want int
+	}{
+		{"v1.10.0", "v1.9.3", 1},
+		{"v1.2.3", "v1.2.3", 0},
+		{"v1.2.3-rc.1", "v1.2.3", -1},
+		{"v1.2.3-rc.2", "v1.2.3-rc.10", -1},
+		{"v0.0.0-20240101000000-abcdef123456", "v0.1.0", -1},
+		{"v2.0.0+incompatible", "v1.9.0", 1},
+	} {
+		if got := CompareModuleVersions(test.a, test.b); got != test.want {
+			t.Errorf("CompareModuleVersions(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestModuleChangelogURL(t *testing.T) {
+	for path, want := range map[string]string{
+		"github.com/lib/pq":            "https://github.com/lib/pq/releases/tag/v1.10.9",
+		"github.com/acme/tools/cli/v2": "https://github.com/acme/tools/releases/tag/cli/v1.10.9",
+		"golang.org/x/net":             "https://pkg.go.dev/golang.org/x/net@v1.10.9",
+	} {
+		if got := ModuleChangelogURL(path, "v1.10.9"); got != want {
+			t.Errorf("ModuleChangelogURL(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestDependencyCheckerCheck(t *testing.T) {
+	latest := map[string]string{
+		"/github.com/lib/pq/@latest":            "v1.10.9",
+		"/github.com/!burnt!sushi/toml/@latest": "v1.3.0",
+		"/github.com/acme/tools/cli/v2/@latest": "v2.2.0",
+	}
+	var osvQueries int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/acme/api/contents/go.mod":
+			w.Write([]byte(sampleGoMod))
+		case strings.HasSuffix(r.URL.Path, "/@latest"):
+			version, ok := latest[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"Version": version})
+		case r.URL.Path == "/v1/querybatch":
+			var request struct {
+				Queries []osvQuery `json:"queries"`
+			}
+			json.NewDecoder(r.Body).Decode(&request)
+			osvQueries = len(request.Queries)
+			results := make([]map[string]interface{}, len(request.Queries))
+			for i, query := range request.Queries {
+				results[i] = map[string]interface{}{}
+				if query.Package.Name == "golang.org/x/net" && query.Version == "0.20.0" {
+					results[i]["vulns"] = []map[string]string{{"id": "GO-2024-2687"}}
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	checker := NewDependencyChecker(silentLogger{})
+	checker.githubAPI, checker.proxyURL, checker.osvURL = server.URL, server.URL, server.URL
+
+	report, err := checker.Check(context.Background(), "acme/api")
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if report.Requirements != 4 || report.Direct != 3 || osvQueries != 4 {
+		t.Errorf("report counts = %d/%d, OSV queries = %d", report.Requirements, report.Direct, osvQueries)
+	}
+	if len(report.Updates) != 2 || report.Updates[0].Path != "github.com/acme/tools/cli/v2" || report.Updates[1].Latest != "v1.10.9" {
+		t.Errorf("updates = %+v", report.Updates)
+	}
+	if len(report.Vulnerabilities) != 1 || report.Vulnerabilities[0].IDs[0] != "GO-2024-2687" {
+		t.Errorf("vulnerabilities = %+v", report.Vulnerabilities)
+	}
+
+	if _, err := checker.Check(context.Background(), "acme/missing"); err == nil {
+		t.Error("expected an error for a repository without go.mod")
+	}
+}
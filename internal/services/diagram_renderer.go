@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// defaultKrokiURL is the public Kroki instance used unless DIAGRAM_RENDER_URL is set
+const defaultKrokiURL = "https://kroki.io"
+
+// maxDiagramImageSize limits the size of a rendered diagram in bytes
+const maxDiagramImageSize = 5 * 1024 * 1024
+
+// DiagramRenderer renders diagram source, e.g. Mermaid or PlantUML, to a PNG image
+type DiagramRenderer interface {
+	Render(ctx context.Context, kind, source string) ([]byte, error)
+	IsConfigured() bool
+}
+
+// KrokiRenderer renders diagrams with a Kroki server (https://kroki.io or self-hosted)
+type KrokiRenderer struct {
+	baseURL    string
+	httpClient *http.Client
+	logger     domain.Logger
+}
+
+// NewKrokiRenderer creates a renderer from DIAGRAM_RENDER_URL. It defaults to the public
+// kroki.io instance; "off" disables rendering so commands fall back to diagram source.
+func NewKrokiRenderer(logger domain.Logger) *KrokiRenderer {
+	baseURL := strings.TrimSpace(os.Getenv("DIAGRAM_RENDER_URL"))
+	switch {
+	case baseURL == "":
+		baseURL = defaultKrokiURL
+	case strings.EqualFold(baseURL, "off"):
+		baseURL = ""
+	}
+
+	return &KrokiRenderer{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 20 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// IsConfigured returns true if diagrams can be rendered
+func (k *KrokiRenderer) IsConfigured() bool {
+	return k.baseURL != ""
+}
+
+// Render converts diagram source of the given kind to a PNG image
+func (k *KrokiRenderer) Render(ctx context.Context, kind, source string) ([]byte, error) {
+	if !k.IsConfigured() {
+		return nil, fmt.Errorf("diagram rendering is disabled")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s/png", k.baseURL, kind), strings.NewReader(source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Accept", "image/png")
+
+	resp, err := k.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	image, err := io.ReadAll(io.LimitReader(resp.Body, maxDiagramImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("renderer error %d: %s", resp.StatusCode, strings.TrimSpace(string(image)))
+	}
+	if len(image) > maxDiagramImageSize {
+		return nil, fmt.Errorf("rendered diagram is larger than %d bytes", maxDiagramImageSize)
+	}
+
+	k.logger.Debug("Diagram rendered", "kind", kind, "bytes", len(image))
+	return image, nil
+}
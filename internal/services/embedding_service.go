@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// embeddingBatchSize limits how many texts are embedded per API request
+const embeddingBatchSize = 64
+
+// maxEmbeddingText limits the text embedded per document, in runes; longer texts are cut
+const maxEmbeddingText = 6000
+
+// Embedder is an AI provider that turns texts into embedding vectors
+type Embedder interface {
+	IsConfigured() bool
+	EmbeddingModel() string
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+type namedEmbedder struct {
+	name     string
+	embedder Embedder
+}
+
+// EmbeddingService embeds texts for semantic search with the first configured provider, or
+// the one named by EMBEDDING_PROVIDER. Vectors of different models are not comparable, so
+// they are stored with Model and re-indexed when it changes.
+type EmbeddingService struct {
+	providers []namedEmbedder
+	logger    domain.Logger
+}
+
+// NewEmbeddingService creates an embedding service with all supported providers
+func NewEmbeddingService(logger domain.Logger) *EmbeddingService {
+	return &EmbeddingService{
+		providers: []namedEmbedder{
+			{name: "openai", embedder: NewOpenAIService(logger)},
+			{name: "gemini", embedder: NewGeminiService(logger)},
+		},
+		logger: logger,
+	}
+}
+
+// provider returns the provider in use, or nil when none is configured
+func (s *EmbeddingService) provider() *namedEmbedder {
+	preferred := strings.ToLower(os.Getenv("EMBEDDING_PROVIDER"))
+	for i := range s.providers {
+		if s.providers[i].embedder.IsConfigured() && (preferred == "" || s.providers[i].name == preferred) {
+			return &s.providers[i]
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether a provider is configured for embeddings
+func (s *EmbeddingService) Enabled() bool {
+	return s.provider() != nil
+}
+
+// Model identifies the provider and model vectors are made with, e.g.
+// "openai/text-embedding-3-small", or "" when no provider is configured
+func (s *EmbeddingService) Model() string {
+	provider := s.provider()
+	if provider == nil {
+		return ""
+	}
+	return provider.name + "/" + provider.embedder.EmbeddingModel()
+}
+
+// Embed returns a vector for each text, in order, sending them in batches
+func (s *EmbeddingService) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	provider := s.provider()
+	if provider == nil {
+		return nil, fmt.Errorf("no embedding provider configured")
+	}
+
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += embeddingBatchSize {
+		end := start + embeddingBatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		batch := make([]string, 0, end-start)
+		for _, text := range texts[start:end] {
+			batch = append(batch, truncateRunes(text, maxEmbeddingText))
+		}
+		embedded, err := provider.embedder.Embed(ctx, batch)
+		if err != nil {
+			return nil, fmt.Errorf("%s embeddings failed: %w", provider.name, err)
+		}
+		vectors = append(vectors, embedded...)
+	}
+
+	s.logger.Debug("Texts embedded", "provider", provider.name, "count", len(texts))
+	return vectors, nil
+}
+
+// EmbeddingContentHash identifies the text a vector was made from, so unchanged documents
+// are not embedded again
+func EmbeddingContentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:16])
+}
+
+// CosineSimilarity returns the cosine of the angle between two vectors, from -1 to 1, or 0
+// when they differ in length or one of them is zero
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// truncateRunes cuts text to at most limit runes
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit])
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+type fakeEmbedder struct {
+	configured bool
+	batches    []int
+}
+
+func (f *fakeEmbedder) IsConfigured() bool     { return f.configured }
+func (f *fakeEmbedder) EmbeddingModel() string { return "fake-small" }
+
+func (f *fakeEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	f.batches = append(f.batches, len(texts))
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float32{float32(len([]rune(text)))}
+	}
+	return vectors, nil
+}
+
+func TestEmbeddingServiceBatchesAndKeepsOrder(t *testing.T) {
+	t.Setenv("EMBEDDING_PROVIDER", "")
+	embedder := &fakeEmbedder{configured: true}
+	service := &EmbeddingService{
+		providers: []namedEmbedder{{name: "off", embedder: &fakeEmbedder{}}, {name: "fake", embedder: embedder}},
+		logger:    silentLogger{},
+	}
+
+	if model := service.Model(); model != "fake/fake-small" {
+		t.Fatalf("Model() = %q, want the first configured provider", model)
+	}
+
+	texts := make([]string, embeddingBatchSize+3)
+	for i := range texts {
+		texts[i] = string(make([]rune, i%5))
+	}
+	texts[1] = string(make([]rune, maxEmbeddingText+10))
+
+	vectors, err := service.Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(embedder.batches) != 2 || embedder.batches[0] != embeddingBatchSize || embedder.batches[1] != 3 {
+		t.Errorf("batches = %v, want [%d 3]", embedder.batches, embeddingBatchSize)
+	}
+	if len(vectors) != len(texts) || vectors[4][0] != 4 || vectors[embeddingBatchSize+2][0] != float32((embeddingBatchSize+2)%5) {
+		t.Errorf("vectors out of order: %v", vectors)
+	}
+	if vectors[1][0] != maxEmbeddingText {
+		t.Errorf("long text embedded with %v runes, want it cut to %d", vectors[1][0], maxEmbeddingText)
+	}
+}
+
+func TestEmbeddingServiceProviderPreference(t *testing.T) {
+	service := &EmbeddingService{
+		providers: []namedEmbedder{
+			{name: "openai", embedder: &fakeEmbedder{configured: true}},
+			{name: "gemini", embedder: &fakeEmbedder{configured: true}},
+		},
+		logger: silentLogger{},
+	}
+
+	t.Setenv("EMBEDDING_PROVIDER", "gemini")
+	if model := service.Model(); model != "gemini/fake-small" {
+		t.Errorf("Model() = %q, want the preferred provider", model)
+	}
+
+	t.Setenv("EMBEDDING_PROVIDER", "missing")
+	if service.Enabled() {
+		t.Error("enabled with an unknown preferred provider")
+	}
+	if _, err := service.Embed(context.Background(), []string{"x"}); err == nil {
+		t.Error("expected an error without a provider")
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	cases := []struct {
+		a, b []float32
+		want float64
+	}{
+		{[]float32{1, 0}, []float32{2, 0}, 1},
+		{[]float32{1, 0}, []float32{0, 3}, 0},
+		{[]float32{1, 1}, []float32{-1, -1}, -1},
+		{[]float32{1, 2}, []float32{1, 2, 3}, 0},
+		{[]float32{0, 0}, []float32{1, 1}, 0},
+	}
+	for _, c := range cases {
+		if got := CosineSimilarity(c.a, c.b); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("CosineSimilarity(%v, %v) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Team setting keys used by the escalation service
+const (
+	settingEscalationEnabled  = "escalation_enabled"
+	settingEscalationRemind   = "escalation_remind_after"
+	settingEscalationEscalate = "escalation_escalate_after"
+	settingEscalationPriority = "escalation_max_priority"
+	settingEscalationLead     = "escalation_lead"
+)
+
+// MaxEscalationInterval bounds the configurable reminder and escalation intervals
+const MaxEscalationInterval = 30 * 24 * time.Hour
+
+// EscalationService manages per-team escalation rules for stale tasks
+type EscalationService struct {
+	store  TeamSettingsStore
+	logger domain.Logger
+}
+
+// NewEscalationService creates a new escalation service
+func NewEscalationService(store TeamSettingsStore, logger domain.Logger) *EscalationService {
+	return &EscalationService{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// GetRule returns the team's escalation rule, falling back to defaults on errors
+func (s *EscalationService) GetRule(teamID string) domain.EscalationRule {
+	rule := domain.DefaultEscalationRule()
+
+	values, err := s.store.GetTeamSettings(teamID)
+	if err != nil {
+		s.logger.Warn("Failed to load escalation settings, using defaults", "team_id", teamID, "error", err)
+		return rule
+	}
+
+	rule.Enabled = values[settingEscalationEnabled] == "true"
+	if interval, err := time.ParseDuration(values[settingEscalationRemind]); err == nil && interval > 0 {
+		rule.RemindAfter = interval
+	}
+	if interval, err := time.ParseDuration(values[settingEscalationEscalate]); err == nil && interval > 0 {
+		rule.EscalateAfter = interval
+	}
+	if priority, err := strconv.Atoi(values[settingEscalationPriority]); err == nil && priority >= 1 && priority <= 3 {
+		rule.MaxPriority = priority
+	}
+	rule.Lead = values[settingEscalationLead]
+
+	return rule
+}
+
+// SetEnabled turns escalation on or off for a team
+func (s *EscalationService) SetEnabled(teamID string, enabled bool) error {
+	return s.store.SetTeamSetting(teamID, settingEscalationEnabled, strconv.FormatBool(enabled))
+}
+
+// SetRemindAfter sets how long a task may stay in todo before the assignee is reminded
+func (s *EscalationService) SetRemindAfter(teamID string, interval time.Duration) error {
+	if err := validateEscalationInterval(interval); err != nil {
+		return err
+	}
+	return s.store.SetTeamSetting(teamID, settingEscalationRemind, interval.String())
+}
+
+// SetEscalateAfter sets how long after the reminder the team lead is notified
+func (s *EscalationService) SetEscalateAfter(teamID string, interval time.Duration) error {
+	if err := validateEscalationInterval(interval); err != nil {
+		return err
+	}
+	return s.store.SetTeamSetting(teamID, settingEscalationEscalate, interval.String())
+}
+
+// SetMaxPriority sets the lowest priority covered (1 = high only, 3 = all tasks)
+func (s *EscalationService) SetMaxPriority(teamID string, priority int) error {
+	if priority < 1 || priority > 3 {
+		return fmt.Errorf("priority must be 1 (high), 2 (medium) or 3 (all)")
+	}
+	return s.store.SetTeamSetting(teamID, settingEscalationPriority, strconv.Itoa(priority))
+}
+
+// SetLead sets the username notified on escalation
+func (s *EscalationService) SetLead(teamID, username string) error {
+	return s.store.SetTeamSetting(teamID, settingEscalationLead, strings.TrimPrefix(username, "@"))
+}
+
+// validateEscalationInterval checks that an interval is within the allowed range
+func validateEscalationInterval(interval time.Duration) error {
+	if interval < time.Hour || interval > MaxEscalationInterval {
+		return fmt.Errorf("interval must be between 1h and %dd", int(MaxEscalationInterval.Hours()/24))
+	}
+	return nil
+}
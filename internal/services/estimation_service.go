@@ -0,0 +1,113 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Team setting keys used by the estimation service
+const (
+	settingEstimateUnit = "estimate_unit"
+	settingPointScale   = "point_scale"
+	settingSprintLength = "sprint_length_days"
+	settingSprintStart  = "sprint_start"
+)
+
+// defaultSprintStart anchors sprint numbering for teams without a configured start date
+var defaultSprintStart = time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+// SprintConfig describes how a team's sprints are laid out
+type SprintConfig struct {
+	Start      time.Time
+	LengthDays int
+}
+
+// EstimationService manages per-team estimate units, point scales and sprint settings
+type EstimationService struct {
+	store  TeamSettingsStore
+	logger domain.Logger
+}
+
+// NewEstimationService creates a new estimation service
+func NewEstimationService(store TeamSettingsStore, logger domain.Logger) *EstimationService {
+	return &EstimationService{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// GetSettings returns the team's estimate settings, falling back to hours on errors
+func (s *EstimationService) GetSettings(teamID string) domain.EstimateSettings {
+	settings := domain.DefaultEstimateSettings()
+
+	values, err := s.store.GetTeamSettings(teamID)
+	if err != nil {
+		s.logger.Warn("Failed to load estimate settings, using defaults", "team_id", teamID, "error", err)
+		return settings
+	}
+
+	if values[settingEstimateUnit] == domain.EstimateUnitPoints {
+		settings.Unit = domain.EstimateUnitPoints
+	}
+	if value := values[settingPointScale]; value != "" {
+		if scale, err := domain.ParsePointScale(value); err == nil {
+			settings.Scale = scale
+		}
+	}
+
+	return settings
+}
+
+// SetUnit switches the team between hour and point estimates
+func (s *EstimationService) SetUnit(teamID, unit string) error {
+	if unit != domain.EstimateUnitHours && unit != domain.EstimateUnitPoints {
+		return fmt.Errorf("unit must be hours or points")
+	}
+	return s.store.SetTeamSetting(teamID, settingEstimateUnit, unit)
+}
+
+// SetScale stores a custom point scale such as "1=2,2=4,3=8"
+func (s *EstimationService) SetScale(teamID, value string) (domain.PointScale, error) {
+	scale, err := domain.ParsePointScale(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.SetTeamSetting(teamID, settingPointScale, scale.String()); err != nil {
+		return nil, err
+	}
+	return scale, nil
+}
+
+// GetSprintConfig returns the team's sprint layout
+func (s *EstimationService) GetSprintConfig(teamID string) SprintConfig {
+	config := SprintConfig{Start: defaultSprintStart, LengthDays: domain.DefaultSprintLengthDays}
+
+	values, err := s.store.GetTeamSettings(teamID)
+	if err != nil {
+		s.logger.Warn("Failed to load sprint settings, using defaults", "team_id", teamID, "error", err)
+		return config
+	}
+
+	if days, err := strconv.Atoi(values[settingSprintLength]); err == nil && days > 0 {
+		config.LengthDays = days
+	}
+	if start, err := time.Parse(holidayDateLayout, values[settingSprintStart]); err == nil {
+		config.Start = start
+	}
+
+	return config
+}
+
+// SetSprintConfig stores the sprint length and the start date of any sprint
+func (s *EstimationService) SetSprintConfig(teamID string, lengthDays int, start time.Time) error {
+	if lengthDays < 1 || lengthDays > 42 {
+		return fmt.Errorf("sprint length must be between 1 and 42 days")
+	}
+	if err := s.store.SetTeamSetting(teamID, settingSprintLength, strconv.Itoa(lengthDays)); err != nil {
+		return err
+	}
+	return s.store.SetTeamSetting(teamID, settingSprintStart, start.Format(holidayDateLayout))
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+// maxFeedBytes limits the size of a downloaded feed
+const maxFeedBytes = 4 << 20
+
+// Feed is a parsed RSS or Atom feed with its entries in document order, newest first in
+// almost every feed
+type Feed struct {
+	Title   string
+	Entries []FeedEntry
+}
+
+// FeedEntry is an item of a feed. ID is the entry's guid or id, falling back to its link
+// and title, and stays the same when the entry is edited.
+type FeedEntry struct {
+	ID        string
+	Title     string
+	Link      string
+	Published time.Time
+}
+
+// FeedResult is the outcome of a conditional feed download. When NotModified is set the
+// server reported no changes and Feed is nil.
+type FeedResult struct {
+	Feed         *Feed
+	NotModified  bool
+	ETag         string
+	LastModified string
+}
+
+// FeedReader downloads RSS and Atom feeds, sending the validators of the previous response
+// so unchanged feeds cost a 304
+type FeedReader struct {
+	http *HTTPClient
+}
+
+// NewFeedReader creates a feed reader using the given HTTP client
+func NewFeedReader(client *HTTPClient) *FeedReader {
+	return &FeedReader{http: client}
+}
+
+// Fetch downloads and parses a feed. etag and lastModified come from the previous result
+// and may be empty.
+func (r *FeedReader) Fetch(ctx context.Context, feedURL, etag, lastModified string) (*FeedResult, error) {
+	headers := map[string]string{"Accept": "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8"}
+	if etag != "" {
+		headers["If-None-Match"] = etag
+	}
+	if lastModified != "" {
+		headers["If-Modified-Since"] = lastModified
+	}
+
+	resp, err := r.http.GetWithLimit(ctx, feedURL, headers, maxFeedBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FeedResult{
+		ETag:         resp.Headers.Get("ETag"),
+		LastModified: resp.Headers.Get("Last-Modified"),
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		result.NotModified = true
+		// A 304 may leave the validators out; the previous ones stay valid
+		if result.ETag == "" {
+			result.ETag = etag
+		}
+		if result.LastModified == "" {
+			result.LastModified = lastModified
+		}
+		return result, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the feed returned HTTP %d", resp.StatusCode)
+	}
+
+	feed, err := ParseFeed(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	result.Feed = feed
+	return result, nil
+}
+
+// NormalizeFeedURL checks that a feed address is an absolute http or https URL and drops
+// its fragment
+func NormalizeFeedURL(raw string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("not an http or https URL")
+	}
+	parsed.Fragment = ""
+	return parsed.String(), nil
+}
+
+// feedDocument holds the elements of RSS 2.0, RSS 1.0 and Atom documents; tags without a
+// namespace match any namespace
+type feedDocument struct {
+	XMLName xml.Name
+	// RSS 2.0 and RSS 1.0 channel
+	Channel struct {
+		Title string     `xml:"title"`
+		Items []feedItem `xml:"item"`
+	} `xml:"channel"`
+	// RSS 1.0 puts its items next to the channel
+	Items []feedItem `xml:"item"`
+	// Atom
+	Title   string      `xml:"title"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type feedItem struct {
+	Title   string `xml:"title"`
+	Link    string `xml:"link"`
+	GUID    string `xml:"guid"`
+	PubDate string `xml:"pubDate"`
+	Date    string `xml:"date"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+// ParseFeed parses an RSS 2.0, RSS 1.0 or Atom document
+func ParseFeed(data []byte) (*Feed, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+
+	var document feedDocument
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("not a valid RSS or Atom feed: %w", err)
+	}
+
+	feed := &Feed{}
+	switch strings.ToLower(document.XMLName.Local) {
+	case "rss", "rdf":
+		feed.Title = cleanFeedText(document.Channel.Title)
+		for _, item := range append(document.Channel.Items, document.Items...) {
+			published := item.PubDate
+			if published == "" {
+				published = item.Date
+			}
+			feed.Entries = append(feed.Entries, newFeedEntry(item.GUID, item.Title, item.Link, published))
+		}
+	case "feed":
+		feed.Title = cleanFeedText(document.Title)
+		for _, entry := range document.Entries {
+			published := entry.Published
+			if published == "" {
+				published = entry.Updated
+			}
+			feed.Entries = append(feed.Entries, newFeedEntry(entry.ID, entry.Title, atomEntryLink(entry.Links), published))
+		}
+	default:
+		return nil, fmt.Errorf("not a valid RSS or Atom feed: unexpected <%s> root", document.XMLName.Local)
+	}
+
+	return feed, nil
+}
+
+// newFeedEntry builds an entry, identifying it by its id, link or title, whichever is set
+func newFeedEntry(id, title, link, published string) FeedEntry {
+	entry := FeedEntry{
+		ID:        strings.TrimSpace(id),
+		Title:     cleanFeedText(title),
+		Link:      strings.TrimSpace(link),
+		Published: parseFeedTime(published),
+	}
+	if entry.ID == "" {
+		entry.ID = entry.Link
+	}
+	if entry.ID == "" {
+		entry.ID = entry.Title
+	}
+	if entry.Title == "" {
+		entry.Title = entry.Link
+	}
+	return entry
+}
+
+// atomEntryLink returns the alternate link of an Atom entry, or its first link
+func atomEntryLink(links []atomLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	if len(links) > 0 {
+		return strings.TrimSpace(links[0].Href)
+	}
+	return ""
+}
+
+// feedTimeLayouts are the date formats found in RSS (RFC 822 and variants) and Atom (RFC 3339)
+var feedTimeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseFeedTime parses an entry date, returning the zero time when the format is unknown
+func parseFeedTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// cleanFeedText collapses the whitespace of a title
+func cleanFeedText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const rssFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom"><channel>
+<title>Release   notes</title>
+<atom:link href="https://example.com/feed.xml" rel="self"/>
+<item><title>v1.2.0</title><link>https://example.com/v1.2.0</link><guid>release-120</guid>
+<pubDate>Tue, 12 Aug 2025 10:00:00 +0000</pubDate></item>
+<item><title>v1.1.0</title><link>https://example.com/v1.1.0</link></item>
+</channel></rss>`
+
+const atomFeed = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>The Go Blog</title>
+<entry><id>tag:blog.golang.org,2013:blog.golang.org/go1.25</id><title>Go 1.25 is released</title>
+<link rel="alternate" href="https://go.dev/blog/go1.25"/><updated>2025-08-12T00:00:00+00:00</updated></entry>
+</feed>`
+
+func TestParseFeedRSS(t *testing.T) {
+	feed, err := ParseFeed([]byte(rssFeed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if feed.Title != "Release notes" || len(feed.Entries) != 2 {
+		t.Fatalf("unexpected feed: %+v", feed)
+	}
+
+	first := feed.Entries[0]
+	if first.ID != "release-120" || first.Title != "v1.2.0" || first.Link != "https://example.com/v1.2.0" {
+		t.Errorf("unexpected first entry: %+v", first)
+	}
+	if !first.Published.Equal(time.Date(2025, 8, 12, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected publish time: %v", first.Published)
+	}
+	if feed.Entries[1].ID != "https://example.com/v1.1.0" {
+		t.Errorf("an entry without a guid should be identified by its link, got %q", feed.Entries[1].ID)
+	}
+}
+
+func TestParseFeedAtom(t *testing.T) {
+	feed, err := ParseFeed([]byte(atomFeed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if feed.Title != "The Go Blog" || len(feed.Entries) != 1 {
+		t.Fatalf("unexpected feed: %+v", feed)
+	}
+	entry := feed.Entries[0]
+	if entry.Link != "https://go.dev/blog/go1.25" || entry.Published.IsZero() {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestParseFeedRejectsOtherDocuments(t *testing.T) {
+	if _, err := ParseFeed([]byte("<html><body>Not a feed</body></html>")); err == nil {
+		t.Error("expected an error for an HTML page")
+	}
+}
+
+func TestFeedReaderConditionalFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(atomFeed))
+	}))
+	defer server.Close()
+
+	reader := NewFeedReader(NewHTTPClient(5*time.Second, silentLogger{}))
+	result, err := reader.Fetch(context.Background(), server.URL, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.NotModified || result.Feed == nil || result.ETag != `"v1"` {
+		t.Fatalf("unexpected first result: %+v", result)
+	}
+
+	result, err = reader.Fetch(context.Background(), server.URL, result.ETag, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.NotModified || result.Feed != nil || result.ETag != `"v1"` {
+		t.Errorf("expected a not modified result keeping the ETag, got %+v", result)
+	}
+}
+
+func TestNormalizeFeedURL(t *testing.T) {
+	if got, err := NormalizeFeedURL(" https://go.dev/blog/feed.atom#top "); err != nil || got != "https://go.dev/blog/feed.atom" {
+		t.Errorf("unexpected result %q, %v", got, err)
+	}
+	for _, raw := range []string{"go.dev/blog", "ftp://example.com/feed", "file:///etc/passwd"} {
+		if _, err := NormalizeFeedURL(raw); err == nil {
+			t.Errorf("expected %q to be rejected", raw)
+		}
+	}
+}
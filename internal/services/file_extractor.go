@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,24 +14,82 @@ import (
 	"yordamchi-dev-bot/internal/domain"
 )
 
+// defaultMaxFileSizeMB is the largest upload accepted; the Telegram Bot API does not
+// serve larger files to bots unless a local Bot API server is used
+const defaultMaxFileSizeMB = 20
+
+// extractableFormats are the extensions ExtractContent can read; images also need OCR
+var extractableFormats = []string{".txt", ".md", ".pdf", ".docx", ".xlsx", ".xls"}
+
+// knownFormats are the document and image extensions
+var knownFormats = append(append([]string{}, extractableFormats...), imageFormats...)
+
 // FileExtractor handles content extraction from various file types
 type FileExtractor struct {
-	logger domain.Logger
+	logger        domain.Logger
+	maxFileSizeMB int
+	formats       []string
+	// images reads photos and scanned PDFs when an OCR engine is configured
+	images *ImageExtractor
 }
 
-// NewFileExtractor creates a new file extraction service
+// NewFileExtractor creates a new file extraction service with the FILE_MAX_SIZE_MB limit
+// and the FILE_FORMATS allowed extensions. Images are only accepted when OCR is set up.
 func NewFileExtractor(logger domain.Logger) *FileExtractor {
+	formats := ParseFileFormats(os.Getenv("FILE_FORMATS"))
+	if len(formats) == 0 {
+		formats = knownFormats
+	}
 	return &FileExtractor{
-		logger: logger,
+		logger:        logger,
+		maxFileSizeMB: envInt("FILE_MAX_SIZE_MB", defaultMaxFileSizeMB),
+		formats:       formats,
+		images:        NewImageExtractor(logger),
 	}
 }
 
+// ParseFileFormats parses a comma-separated extension list such as "pdf, .docx,TXT",
+// keeping only formats ExtractContent can read
+func ParseFileFormats(value string) []string {
+	var formats []string
+	for _, field := range strings.Split(value, ",") {
+		ext := strings.ToLower(strings.TrimSpace(field))
+		if ext == "" {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		for _, known := range knownFormats {
+			if ext == known {
+				formats = append(formats, ext)
+				break
+			}
+		}
+	}
+	return formats
+}
+
+// MaxFileSizeMB returns the largest accepted upload in megabytes
+func (e *FileExtractor) MaxFileSizeMB() int {
+	return e.maxFileSizeMB
+}
+
 // ExtractContent extracts text content from files based on their type
 func (e *FileExtractor) ExtractContent(filePath, fileName string) (string, error) {
 	ext := strings.ToLower(filepath.Ext(fileName))
 	
 	e.logger.Info("Extracting content from file", "file", fileName, "type", ext)
-	
+
+	if !e.IsSupported(fileName) {
+		return "", fmt.Errorf("unsupported file type: %s. Supported formats: %s", ext, strings.Join(e.GetSupportedFormats(), ", "))
+	}
+
+	// Check the content matches the extension before a parser touches it
+	if err := e.SniffFile(filePath, fileName); err != nil {
+		return "", err
+	}
+
 	switch ext {
 	case ".txt", ".md":
 		return e.extractTextFile(filePath)
@@ -40,8 +99,10 @@ func (e *FileExtractor) ExtractContent(filePath, fileName string) (string, error
 		return e.extractWordContent(filePath)
 	case ".xlsx", ".xls":
 		return e.extractExcelContent(filePath)
+	case ".jpg", ".jpeg", ".png", ".webp":
+		return e.images.ExtractImage(context.Background(), filePath)
 	default:
-		return "", fmt.Errorf("unsupported file type: %s. Supported formats: TXT, MD, PDF, DOCX, XLSX", ext)
+		return "", fmt.Errorf("unsupported file type: %s. Supported formats: %s", ext, strings.Join(e.GetSupportedFormats(), ", "))
 	}
 }
 
@@ -90,6 +151,12 @@ func (e *FileExtractor) extractPDFContent(filePath string) (string, error) {
 	
 	result := content.String()
 	e.logger.Info("PDF extracted", "pages", totalPages, "length", len(result))
+
+	// A scanned PDF holds images of its pages and no text
+	if strings.TrimSpace(result) == "" && e.images.Enabled() {
+		e.logger.Info("PDF has no text, reading it with OCR", "pages", totalPages)
+		return e.images.ExtractPDF(context.Background(), filePath)
+	}
 	
 	if result == "" {
 		return "", fmt.Errorf("no text content found in PDF")
@@ -183,35 +250,48 @@ func (e *FileExtractor) extractExcelContent(filePath string) (string, error) {
 
 // GetSupportedFormats returns list of supported file formats
 func (e *FileExtractor) GetSupportedFormats() []string {
-	return []string{"TXT", "MD", "PDF", "DOCX", "XLSX"}
+	var formats []string
+	for _, ext := range e.formats {
+		if isImageFormat(ext) && !e.images.Enabled() {
+			continue
+		}
+		formats = append(formats, strings.ToUpper(strings.TrimPrefix(ext, ".")))
+	}
+	return formats
 }
 
 // IsSupported checks if a file format is supported
 func (e *FileExtractor) IsSupported(fileName string) bool {
 	ext := strings.ToLower(filepath.Ext(fileName))
-	supportedExts := map[string]bool{
-		".txt":  true,
-		".md":   true,
-		".pdf":  true,
-		".docx": true,
-		".xlsx": true,
-		".xls":  true,
-	}
-	return supportedExts[ext]
+	if isImageFormat(ext) && !e.images.Enabled() {
+		return false
+	}
+	for _, format := range e.formats {
+		if ext == format {
+			return true
+		}
+	}
+	return false
 }
 
 // ValidateFile performs basic validation on uploaded files
 func (e *FileExtractor) ValidateFile(document *domain.TelegramDocument) error {
-	// Check file size (limit to 20MB)
-	const maxSize = 20 * 1024 * 1024 // 20MB
+	// Check file size
+	maxSize := e.maxFileSizeMB * 1024 * 1024
 	if document.FileSize > maxSize {
-		return fmt.Errorf("file too large (%.1fMB). Maximum size: 20MB", float64(document.FileSize)/(1024*1024))
+		return fmt.Errorf("file too large (%.1fMB). Maximum size: %dMB", float64(document.FileSize)/(1024*1024), e.maxFileSizeMB)
 	}
 	
 	// Check if file type is supported
 	if !e.IsSupported(document.FileName) {
 		return fmt.Errorf("unsupported file type. Supported formats: %s", strings.Join(e.GetSupportedFormats(), ", "))
 	}
+
+	// The client-reported MIME type catches renamed programs before they are downloaded;
+	// SniffFile checks the content itself
+	if dangerousMimeTypes[strings.ToLower(document.MimeType)] {
+		return fmt.Errorf("%s files are not accepted, whatever their extension", document.MimeType)
+	}
 	
 	return nil
 }
\ No newline at end of file
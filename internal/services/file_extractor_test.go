@@ -0,0 +1,45 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+func TestParseFileFormats(t *testing.T) {
+	got := ParseFileFormats(" PDF, .docx,txt,exe,, ")
+	want := []string{".pdf", ".docx", ".txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseFileFormats = %v, want %v", got, want)
+	}
+}
+
+func TestValidateFileUsesConfiguredLimits(t *testing.T) {
+	t.Setenv("FILE_MAX_SIZE_MB", "2")
+	t.Setenv("FILE_FORMATS", "pdf,md")
+	extractor := NewFileExtractor(silentLogger{})
+
+	if got := extractor.GetSupportedFormats(); !reflect.DeepEqual(got, []string{"PDF", "MD"}) {
+		t.Fatalf("GetSupportedFormats = %v", got)
+	}
+
+	tests := []struct {
+		name     string
+		document domain.TelegramDocument
+		valid    bool
+	}{
+		{"allowed format", domain.TelegramDocument{FileName: "spec.pdf", FileSize: 1024}, true},
+		{"disabled format", domain.TelegramDocument{FileName: "spec.docx", FileSize: 1024}, false},
+		{"too large", domain.TelegramDocument{FileName: "spec.md", FileSize: 3 * 1024 * 1024}, false},
+		{"executable MIME type", domain.TelegramDocument{FileName: "spec.pdf", MimeType: "application/x-msdownload"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := extractor.ValidateFile(&tt.document)
+			if (err == nil) != tt.valid {
+				t.Fatalf("ValidateFile error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
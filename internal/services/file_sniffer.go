@@ -0,0 +1,172 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// sniffSampleSize is how much of a file is read to detect its content type
+const sniffSampleSize = 8192
+
+// executableSignatures are magic bytes of programs, rejected whatever the extension says
+var executableSignatures = []struct {
+	magic []byte
+	name  string
+}{
+	{[]byte("\x7fELF"), "Linux executable"},
+	{[]byte{0xfe, 0xed, 0xfa, 0xce}, "macOS executable"},
+	{[]byte{0xfe, 0xed, 0xfa, 0xcf}, "macOS executable"},
+	{[]byte{0xce, 0xfa, 0xed, 0xfe}, "macOS executable"},
+	{[]byte{0xcf, 0xfa, 0xed, 0xfe}, "macOS executable"},
+	{[]byte{0xca, 0xfe, 0xba, 0xbe}, "macOS or Java executable"},
+}
+
+// dangerousMimeTypes are MIME types of programs and scripts
+var dangerousMimeTypes = map[string]bool{
+	"application/x-msdownload":                      true,
+	"application/x-msdos-program":                   true,
+	"application/x-dosexec":                         true,
+	"application/x-executable":                      true,
+	"application/x-elf":                             true,
+	"application/x-mach-binary":                     true,
+	"application/x-sh":                              true,
+	"application/x-msi":                             true,
+	"application/vnd.microsoft.portable-executable": true,
+	"application/java-archive":                      true,
+}
+
+// oleSignature starts legacy Office files such as .xls
+var oleSignature = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
+
+// FileRejectedError is returned when a file's content does not match its extension or
+// is not safe to process
+type FileRejectedError struct {
+	FileName string
+	Reason   string
+}
+
+// Error implements error
+func (e *FileRejectedError) Error() string {
+	return fmt.Sprintf("%s was rejected: %s", e.FileName, e.Reason)
+}
+
+// SniffFile checks a downloaded file's magic bytes against its extension before any
+// parser reads it, so a renamed program or archive is never processed
+func (e *FileExtractor) SniffFile(filePath, fileName string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	sample := make([]byte, sniffSampleSize)
+	n, err := io.ReadFull(file, sample)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	sample = sample[:n]
+
+	reject := func(reason string, args ...interface{}) error {
+		e.logger.Warn("File rejected by content check", "file", fileName, "reason", fmt.Sprintf(reason, args...))
+		return &FileRejectedError{FileName: fileName, Reason: fmt.Sprintf(reason, args...)}
+	}
+
+	if isWindowsExecutable(sample) {
+		return reject("it is a Windows executable, not a document")
+	}
+	for _, signature := range executableSignatures {
+		if bytes.HasPrefix(sample, signature.magic) {
+			return reject("it is a %s, not a document", signature.name)
+		}
+	}
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+	detected := http.DetectContentType(sample)
+
+	switch ext {
+	case ".txt", ".md":
+		if !strings.HasPrefix(detected, "text/") || bytes.IndexByte(sample, 0) >= 0 {
+			return reject("the content is %s, not text", detected)
+		}
+		if !utf8.Valid(trimPartialRune(sample)) {
+			return reject("the text is not UTF-8, save it as UTF-8 and upload again")
+		}
+
+	case ".jpg", ".jpeg", ".png", ".webp":
+		if !strings.HasPrefix(detected, "image/") {
+			return reject("the content is %s, not an image", detected)
+		}
+
+	case ".pdf":
+		if !bytes.HasPrefix(bytes.TrimLeft(sample, " \t\r\n"), []byte("%PDF-")) {
+			return reject("the content is %s, not a PDF", detected)
+		}
+
+	case ".docx":
+		if err := requireZipEntry(filePath, "word/document.xml"); err != nil {
+			return reject("it is not a Word document (%s)", err)
+		}
+
+	case ".xlsx":
+		if err := requireZipEntry(filePath, "xl/workbook.xml"); err != nil {
+			return reject("it is not an Excel workbook (%s)", err)
+		}
+
+	case ".xls":
+		if !bytes.HasPrefix(sample, oleSignature) {
+			if err := requireZipEntry(filePath, "xl/workbook.xml"); err != nil {
+				return reject("it is not an Excel workbook (%s)", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isWindowsExecutable checks for the MZ header pointing to a PE header, so a text that
+// merely starts with "MZ" is not mistaken for a program
+func isWindowsExecutable(sample []byte) bool {
+	if len(sample) < 0x40 || !bytes.HasPrefix(sample, []byte("MZ")) {
+		return false
+	}
+	offset := int(binary.LittleEndian.Uint32(sample[0x3c:]))
+	return offset+4 <= len(sample) && bytes.Equal(sample[offset:offset+4], []byte("PE\x00\x00"))
+}
+
+// requireZipEntry checks that a file is a ZIP archive containing the named entry, as
+// Office Open XML documents do
+func requireZipEntry(filePath, entry string) error {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return fmt.Errorf("not an Office Open XML archive")
+	}
+	defer archive.Close()
+
+	for _, file := range archive.File {
+		if file.Name == entry {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is missing", entry)
+}
+
+// trimPartialRune drops an incomplete UTF-8 sequence cut off at the end of a sample
+func trimPartialRune(sample []byte) []byte {
+	for i := 1; i <= utf8.UTFMax && i <= len(sample); i++ {
+		if utf8.RuneStart(sample[len(sample)-i]) {
+			if !utf8.FullRune(sample[len(sample)-i:]) {
+				return sample[:len(sample)-i]
+			}
+			break
+		}
+	}
+	return sample
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSample(t *testing.T, name string, content []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func zipWith(t *testing.T, entry string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	file, err := archive.Create(entry)
+	if err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+	file.Write([]byte("<xml/>"))
+	if err := archive.Close(); err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestSniffFile(t *testing.T) {
+	extractor := NewFileExtractor(silentLogger{})
+
+	windowsProgram := make([]byte, 0x100)
+	copy(windowsProgram, "MZ")
+	binary.LittleEndian.PutUint32(windowsProgram[0x3c:], 0x80)
+	copy(windowsProgram[0x80:], "PE\x00\x00")
+
+	tests := []struct {
+		name     string
+		fileName string
+		content  []byte
+		rejected bool
+	}{
+		{"plain text", "notes.txt", []byte("Login sahifasi kerak\nParolni tiklash"), false},
+		{"text starting with MZ", "notes.txt", []byte("MZ team requirements: build a login page for the dashboard and admin panel"), false},
+		{"renamed Windows program", "notes.txt", windowsProgram, true},
+		{"renamed Linux program", "spec.md", append([]byte("\x7fELF"), make([]byte, 64)...), true},
+		{"invalid UTF-8", "notes.txt", []byte("caf\xe9 menu"), true},
+		{"PDF", "spec.pdf", []byte("%PDF-1.7\n1 0 obj"), false},
+		{"text named PDF", "spec.pdf", []byte("not really a pdf"), true},
+		{"Word document", "spec.docx", zipWith(t, "word/document.xml"), false},
+		{"plain zip named docx", "spec.docx", zipWith(t, "readme.txt"), true},
+		{"Excel workbook", "plan.xlsx", zipWith(t, "xl/workbook.xml"), false},
+		{"legacy Excel", "plan.xls", append(append([]byte{}, oleSignature...), make([]byte, 64)...), false},
+		{"text named xls", "plan.xls", []byte("a,b,c"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := extractor.SniffFile(writeSample(t, tt.fileName, tt.content), tt.fileName)
+			var rejected *FileRejectedError
+			if got := errors.As(err, &rejected); got != tt.rejected {
+				t.Fatalf("rejected = %v, want %v (err: %v)", got, tt.rejected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Default monthly limits of files processed per team
+const (
+	defaultMonthlyFiles   = 200
+	defaultMonthlyFilesMB = 1000
+)
+
+// FileUsageStore persists monthly processed-file counters per team
+type FileUsageStore interface {
+	GetFileUsage(teamID, period string) (int, int64, error)
+	AddFileUsage(teamID, period string, files int, bytes int64) error
+}
+
+// FileUsage is a team's processed-file usage for the current month
+type FileUsage struct {
+	Files      int
+	FilesLimit int
+	Bytes      int64
+	BytesLimit int64
+	ResetAt    time.Time
+}
+
+// RemainingFiles returns how many more files the team may upload this month
+func (u FileUsage) RemainingFiles() int {
+	if u.Files >= u.FilesLimit {
+		return 0
+	}
+	return u.FilesLimit - u.Files
+}
+
+// RemainingBytes returns how many more bytes the team may upload this month
+func (u FileUsage) RemainingBytes() int64 {
+	if u.Bytes >= u.BytesLimit {
+		return 0
+	}
+	return u.BytesLimit - u.Bytes
+}
+
+// FileQuotaError is returned when an upload would exceed a team's monthly file quota
+type FileQuotaError struct {
+	Usage FileUsage
+	// Files and Bytes are the size of the rejected upload
+	Files int
+	Bytes int64
+}
+
+// Error implements the error interface
+func (e *FileQuotaError) Error() string {
+	if e.Files > e.Usage.RemainingFiles() {
+		return fmt.Sprintf("monthly quota of %d files reached (%d left), resets %s",
+			e.Usage.FilesLimit, e.Usage.RemainingFiles(), e.Usage.ResetAt.Format("Jan 2"))
+	}
+	return fmt.Sprintf("monthly upload quota of %s reached (%s left), resets %s",
+		FormatFileSize(e.Usage.BytesLimit), FormatFileSize(e.Usage.RemainingBytes()), e.Usage.ResetAt.Format("Jan 2"))
+}
+
+// FileUsageService tracks monthly processed-file quotas per team
+type FileUsageService struct {
+	store      FileUsageStore
+	filesLimit int
+	bytesLimit int64
+	location   *time.Location
+	logger     domain.Logger
+}
+
+// NewFileUsageService creates a usage service with the FILE_MONTHLY_FILES and
+// FILE_MONTHLY_MB quotas
+func NewFileUsageService(store FileUsageStore, location *time.Location, logger domain.Logger) *FileUsageService {
+	return &FileUsageService{
+		store:      store,
+		filesLimit: envInt("FILE_MONTHLY_FILES", defaultMonthlyFiles),
+		bytesLimit: int64(envInt("FILE_MONTHLY_MB", defaultMonthlyFilesMB)) * 1024 * 1024,
+		location:   location,
+		logger:     logger,
+	}
+}
+
+// Usage returns the team's file usage for the current month
+func (s *FileUsageService) Usage(teamID string) FileUsage {
+	period, resetAt := monthPeriod(time.Now(), s.location)
+
+	files, bytes, err := s.store.GetFileUsage(teamID, period)
+	if err != nil {
+		s.logger.Warn("Failed to load file usage", "team_id", teamID, "error", err)
+	}
+
+	return FileUsage{
+		Files:      files,
+		FilesLimit: s.filesLimit,
+		Bytes:      bytes,
+		BytesLimit: s.bytesLimit,
+		ResetAt:    resetAt,
+	}
+}
+
+// CheckQuota returns a FileQuotaError when the team cannot have the given files
+// processed this month
+func (s *FileUsageService) CheckQuota(teamID string, files int, bytes int64) error {
+	usage := s.Usage(teamID)
+	if files > usage.RemainingFiles() || bytes > usage.RemainingBytes() {
+		return &FileQuotaError{Usage: usage, Files: files, Bytes: bytes}
+	}
+	return nil
+}
+
+// RecordFiles counts processed files against the team's monthly quota
+func (s *FileUsageService) RecordFiles(teamID string, files int, bytes int64) {
+	period, _ := monthPeriod(time.Now(), s.location)
+	if err := s.store.AddFileUsage(teamID, period, files, bytes); err != nil {
+		s.logger.Error("Failed to record file usage", "team_id", teamID, "error", err)
+	}
+}
+
+// FormatFileSize formats a byte count as megabytes, e.g. "12.5MB"
+func FormatFileSize(bytes int64) string {
+	return fmt.Sprintf("%.1fMB", float64(bytes)/(1024*1024))
+}
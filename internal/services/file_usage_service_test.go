@@ -0,0 +1,48 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type memoryFileUsage map[string][2]int64
+
+func (m memoryFileUsage) GetFileUsage(teamID, period string) (int, int64, error) {
+	usage := m[teamID+"/"+period]
+	return int(usage[0]), usage[1], nil
+}
+
+func (m memoryFileUsage) AddFileUsage(teamID, period string, files int, bytes int64) error {
+	usage := m[teamID+"/"+period]
+	m[teamID+"/"+period] = [2]int64{usage[0] + int64(files), usage[1] + bytes}
+	return nil
+}
+
+func TestFileUsageQuota(t *testing.T) {
+	t.Setenv("FILE_MONTHLY_FILES", "3")
+	t.Setenv("FILE_MONTHLY_MB", "5")
+	service := NewFileUsageService(memoryFileUsage{}, time.UTC, silentLogger{})
+	const mb = 1024 * 1024
+
+	if err := service.CheckQuota("team_1", 3, 5*mb); err != nil {
+		t.Fatalf("upload within quota rejected: %v", err)
+	}
+	service.RecordFiles("team_1", 2, 4*mb)
+
+	var quotaErr *FileQuotaError
+	if err := service.CheckQuota("team_1", 2, mb); !errors.As(err, &quotaErr) {
+		t.Fatalf("too many files accepted: %v", err)
+	}
+	if err := service.CheckQuota("team_1", 1, 2*mb); !errors.As(err, &quotaErr) {
+		t.Fatalf("too many bytes accepted: %v", err)
+	}
+	if err := service.CheckQuota("team_2", 3, mb); err != nil {
+		t.Fatalf("other team affected by quota: %v", err)
+	}
+
+	usage := service.Usage("team_1")
+	if usage.RemainingFiles() != 1 || usage.RemainingBytes() != mb {
+		t.Fatalf("remaining = %d files, %d bytes", usage.RemainingFiles(), usage.RemainingBytes())
+	}
+}
@@ -17,7 +17,7 @@ import (
 // GeminiService handles integration with Google Gemini API
 type GeminiService struct {
 	apiKey     string
-	model      string
+	models     *ModelSelector
 	httpClient *http.Client
 	baseURL    string
 	logger     domain.Logger
@@ -40,7 +40,15 @@ type GeminiPart struct {
 
 // GeminiResponse represents response from Gemini API
 type GeminiResponse struct {
-	Candidates []GeminiCandidate `json:"candidates"`
+	Candidates    []GeminiCandidate   `json:"candidates"`
+	UsageMetadata GeminiUsageMetadata `json:"usageMetadata"`
+}
+
+// GeminiUsageMetadata represents token usage in Gemini response
+type GeminiUsageMetadata struct {
+	PromptTokenCount     int `json:"promptTokenCount"`
+	CandidatesTokenCount int `json:"candidatesTokenCount"`
+	TotalTokenCount      int `json:"totalTokenCount"`
 }
 
 // GeminiCandidate represents a candidate response
@@ -50,19 +58,10 @@ type GeminiCandidate struct {
 
 // NewGeminiService creates a new Gemini service
 func NewGeminiService(logger domain.Logger) *GeminiService {
-	// Default to Gemini Pro if no model specified
-	model := os.Getenv("GEMINI_MODEL")
-	if model == "" {
-		model = "gemini-pro"
-	}
-	
-	// Build the full URL with model
-	baseURL := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent", model)
-	
 	return &GeminiService{
 		apiKey:  os.Getenv("GEMINI_API_KEY"),
-		model:   model,
-		baseURL: baseURL,
+		models:  NewModelSelector("GEMINI", "gemini-pro", "gemini-1.5-pro"),
+		baseURL: "https://generativelanguage.googleapis.com/v1beta/models",
 		httpClient: &http.Client{
 			Timeout: 60 * time.Second,
 		},
@@ -75,6 +74,26 @@ func (g *GeminiService) IsConfigured() bool {
 	return g.apiKey != ""
 }
 
+// Model returns the model used for regular-sized prompts
+func (g *GeminiService) Model() string {
+	return g.models.Model()
+}
+
+// Complete sends a free-form prompt to Gemini and returns the text response
+func (g *GeminiService) Complete(ctx context.Context, prompt string) (string, error) {
+	if !g.IsConfigured() {
+		return "", fmt.Errorf("Gemini API key not configured")
+	}
+
+	model, _ := g.models.Select(prompt)
+	response, _, err := g.sendRequest(ctx, model, prompt)
+	if err != nil {
+		return "", fmt.Errorf("Gemini API request failed: %w", err)
+	}
+
+	return strings.TrimSpace(response), nil
+}
+
 // AnalyzeRequirement sends requirement to Gemini for task breakdown
 func (g *GeminiService) AnalyzeRequirement(ctx context.Context, req domain.TaskBreakdownRequest) (*domain.TaskBreakdownResponse, error) {
 	if !g.IsConfigured() {
@@ -83,17 +102,23 @@ func (g *GeminiService) AnalyzeRequirement(ctx context.Context, req domain.TaskB
 
 	prompt := g.buildAnalysisPrompt(req)
 	
-	response, err := g.sendRequest(ctx, prompt)
+	model, estimated := g.models.Select(prompt)
+	response, tokens, err := g.sendRequest(ctx, model, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("Gemini API request failed: %w", err)
 	}
 
 	result, err := g.parseTaskBreakdown(response)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse Gemini response: %w", err)
+		return nil, fmt.Errorf("failed to parse Gemini response: %w", &BreakdownParseError{Output: response, Err: err})
 	}
+	result.TokensUsed = tokens
+	result.Model = model
 
-	g.logger.Info("Gemini analysis completed", 
+	g.logger.Info("Gemini analysis completed",
+		"model", model,
+		"estimated_tokens", estimated,
+		"tokens_used", tokens,
 		"tasks_count", len(result.Tasks),
 		"confidence", result.Confidence,
 		"total_estimate", result.TotalEstimate)
@@ -112,7 +137,7 @@ Break down this development requirement into actionable tasks:
 **Requirement:** %s
 **Project Type:** %s
 **Team Skills:** %s
-
+%s
 Please provide a detailed task breakdown in the following JSON format:
 
 {
@@ -123,6 +148,8 @@ Please provide a detailed task breakdown in the following JSON format:
       "description": "Detailed description",
       "category": "backend|frontend|qa|devops",
       "estimate_hours": 4.5,
+      "optimistic_hours": 3,
+      "pessimistic_hours": 8,
       "priority": 1,
       "dependencies": []
     }
@@ -137,17 +164,18 @@ Please provide a detailed task breakdown in the following JSON format:
 Guidelines:
 - Break down into 3-15 specific, actionable tasks
 - Estimate hours realistically (consider complexity)
+- estimate_hours is the most likely effort; optimistic_hours and pessimistic_hours bound it
 - Use priority 1 (high), 2 (medium), 3 (low)
 - Categories: backend, frontend, qa, devops
 - Include dependencies between tasks
 - Confidence: 0.6-1.0 based on requirement clarity
 - Consider the team's available skills
 
-Respond only with valid JSON.`, req.Requirement, req.ProjectType, skillsStr)
+Respond only with valid JSON.`, req.Requirement, req.ProjectType, skillsStr, buildTeamContext(req)+buildDetailContext(req))
 }
 
 // sendRequest sends request to Gemini API
-func (g *GeminiService) sendRequest(ctx context.Context, prompt string) (string, error) {
+func (g *GeminiService) sendRequest(ctx context.Context, model, prompt string) (string, int, error) {
 	reqData := GeminiRequest{
 		Contents: []GeminiContent{
 			{
@@ -162,42 +190,42 @@ func (g *GeminiService) sendRequest(ctx context.Context, prompt string) (string,
 
 	jsonData, err := json.Marshal(reqData)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", 0, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	url := fmt.Sprintf("%s?key=%s", g.baseURL, g.apiKey)
+	url := fmt.Sprintf("%s/%s:generateContent?key=%s", g.baseURL, model, g.apiKey)
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := g.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+		return "", 0, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", 0, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return "", 0, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
 	}
 
 	var geminiResp GeminiResponse
 	if err := json.Unmarshal(body, &geminiResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return "", 0, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if len(geminiResp.Candidates) == 0 || len(geminiResp.Candidates[0].Content.Parts) == 0 {
-		return "", fmt.Errorf("empty response from Gemini")
+		return "", 0, fmt.Errorf("empty response from Gemini")
 	}
 
-	return geminiResp.Candidates[0].Content.Parts[0].Text, nil
+	return geminiResp.Candidates[0].Content.Parts[0].Text, geminiResp.UsageMetadata.TotalTokenCount, nil
 }
 
 // parseTaskBreakdown parses Gemini's JSON response into task breakdown
@@ -228,4 +256,85 @@ func (g *GeminiService) parseTaskBreakdown(response string) (*domain.TaskBreakdo
 	}
 
 	return &result, nil
-}
\ No newline at end of file
+}
+// GeminiEmbedRequest represents a batch request to the Gemini embeddings API
+type GeminiEmbedRequest struct {
+	Requests []GeminiEmbedContentRequest `json:"requests"`
+}
+
+// GeminiEmbedContentRequest asks for the embedding of one text
+type GeminiEmbedContentRequest struct {
+	Model   string        `json:"model"`
+	Content GeminiContent `json:"content"`
+}
+
+// GeminiEmbedResponse represents a batch response of the Gemini embeddings API
+type GeminiEmbedResponse struct {
+	Embeddings []struct {
+		Values []float32 `json:"values"`
+	} `json:"embeddings"`
+}
+
+// EmbeddingModel returns the embeddings model, GEMINI_EMBEDDING_MODEL or text-embedding-004
+func (g *GeminiService) EmbeddingModel() string {
+	if model := os.Getenv("GEMINI_EMBEDDING_MODEL"); model != "" {
+		return model
+	}
+	return "text-embedding-004"
+}
+
+// Embed returns an embedding vector for each text, in order
+func (g *GeminiService) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if !g.IsConfigured() {
+		return nil, fmt.Errorf("Gemini API key not configured")
+	}
+
+	model := g.EmbeddingModel()
+	reqData := GeminiEmbedRequest{Requests: make([]GeminiEmbedContentRequest, len(texts))}
+	for i, text := range texts {
+		reqData.Requests[i] = GeminiEmbedContentRequest{
+			Model:   "models/" + model,
+			Content: GeminiContent{Parts: []GeminiPart{{Text: text}}},
+		}
+	}
+
+	jsonData, err := json.Marshal(reqData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/%s:batchEmbedContents?key=%s", g.baseURL, model, g.apiKey)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var embedResp GeminiEmbedResponse
+	if err := json.Unmarshal(body, &embedResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(embedResp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("Gemini returned %d embeddings for %d texts", len(embedResp.Embeddings), len(texts))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for i, embedding := range embedResp.Embeddings {
+		vectors[i] = embedding.Values
+	}
+	return vectors, nil
+}
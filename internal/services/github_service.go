@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 )
 
@@ -10,6 +11,8 @@ import (
 type GitHubService struct {
 	httpClient *HTTPClient
 	logger     Logger
+	// token authenticates writes such as filing issues, read from GITHUB_TOKEN
+	token string
 }
 
 // GitHubRepository represents a GitHub repository
@@ -54,9 +57,44 @@ func NewGitHubService(logger Logger) *GitHubService {
 	return &GitHubService{
 		httpClient: httpClient,
 		logger:     logger,
+		token:      os.Getenv("GITHUB_TOKEN"),
 	}
 }
 
+// GitHubIssue is an issue filed on GitHub
+type GitHubIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"html_url"`
+}
+
+// CanCreateIssues reports whether a GitHub token is configured for filing issues
+func (g *GitHubService) CanCreateIssues() bool {
+	return g.token != ""
+}
+
+// CreateIssue files an issue in the "owner/name" repository
+func (g *GitHubService) CreateIssue(ctx context.Context, repository, title, body string, labels []string) (*GitHubIssue, error) {
+	if g.token == "" {
+		return nil, fmt.Errorf("GITHUB_TOKEN sozlanmagan")
+	}
+
+	url := fmt.Sprintf("https://api.github.com/repos/%s/issues", repository)
+	headers := map[string]string{
+		"Authorization": "Bearer " + g.token,
+		"Accept":        "application/vnd.github+json",
+	}
+	payload := map[string]interface{}{"title": title, "body": body, "labels": labels}
+
+	var issue GitHubIssue
+	if err := g.httpClient.PostJSON(ctx, url, headers, payload, &issue); err != nil {
+		return nil, fmt.Errorf("GitHub issue yaratishda xatolik: %w", err)
+	}
+
+	g.logger.Printf("🐞 GitHub issue created: %s#%d", repository, issue.Number)
+	return &issue, nil
+}
+
 // GetRepository fetches repository information from GitHub
 func (g *GitHubService) GetRepository(ctx context.Context, owner, repo string) (*GitHubRepository, error) {
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// GitHubPush is the part of a GitHub push event used to link commits to tasks
+type GitHubPush struct {
+	Ref        string `json:"ref"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Commits []GitHubCommit `json:"commits"`
+}
+
+// GitHubCommit is a commit of a push event
+type GitHubCommit struct {
+	ID        string    `json:"id"`
+	Message   string    `json:"message"`
+	URL       string    `json:"url"`
+	Timestamp time.Time `json:"timestamp"`
+	Author    struct {
+		Name     string `json:"name"`
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+// AuthorName returns the commit author's GitHub username, or their git name without one
+func (c GitHubCommit) AuthorName() string {
+	if c.Author.Username != "" {
+		return c.Author.Username
+	}
+	return c.Author.Name
+}
+
+// Title returns the first line of the commit message
+func (c GitHubCommit) Title() string {
+	title, _, _ := strings.Cut(c.Message, "\n")
+	return strings.TrimSpace(title)
+}
+
+// VerifyGitHubSignature checks the X-Hub-Signature-256 header of a webhook delivery
+// against the HMAC-SHA256 of its body with the webhook secret
+func VerifyGitHubSignature(secret string, body []byte, signature string) bool {
+	if secret == "" || !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// ParseGitHubPush decodes a push event payload
+func ParseGitHubPush(body []byte) (*GitHubPush, error) {
+	var push GitHubPush
+	if err := json.Unmarshal(body, &push); err != nil {
+		return nil, err
+	}
+	return &push, nil
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestVerifyGitHubSignature(t *testing.T) {
+	body := []byte(`{"ref":"refs/heads/main"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !VerifyGitHubSignature("secret", body, signature) {
+		t.Error("expected a valid signature to verify")
+	}
+	if VerifyGitHubSignature("other", body, signature) {
+		t.Error("expected a signature with another secret to fail")
+	}
+	if VerifyGitHubSignature("", body, signature) || VerifyGitHubSignature("secret", body, "sha1=abc") {
+		t.Error("expected a missing secret or another algorithm to fail")
+	}
+}
+
+func TestParseGitHubPush(t *testing.T) {
+	push, err := ParseGitHubPush([]byte(`{
+		"repository": {"full_name": "acme/api"},
+		"commits": [{"id": "0123456789abcdef", "message": "PROJ-3 Add login\n\nDetails", "url": "https://github.com/acme/api/commit/0123456",
+			"timestamp": "2026-10-16T10:00:00+05:00", "author": {"name": "Ali", "username": ""}}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if push.Repository.FullName != "acme/api" || len(push.Commits) != 1 {
+		t.Fatalf("unexpected push %+v", push)
+	}
+	commit := push.Commits[0]
+	if commit.Title() != "PROJ-3 Add login" || commit.AuthorName() != "Ali" {
+		t.Errorf("unexpected title %q or author %q", commit.Title(), commit.AuthorName())
+	}
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Lint limits. go vet type-checks the code, so runs are short, one at a time, and the
+// code may only import the standard library.
+const (
+	MaxLintBytes           = 32 * 1024
+	maxLintOutputBytes     = 8 * 1024
+	lintTimeout            = 20 * time.Second
+	defaultLintHourlyLimit = 20
+)
+
+// Resource limits of every go vet process, set with the shell's ulimit. Compiling the
+// standard library into an empty cache fits in them.
+const (
+	lintCPUSeconds = 30
+	lintMemoryKB   = 1024 * 1024
+	lintFileBlocks = 64 * 1024 // ulimit -f counts 512 or 1024 byte blocks depending on the shell
+	lintOpenFiles  = 256
+	lintWaitDelay  = time.Second
+)
+
+// packageClausePattern finds the package clause of a Go file
+var packageClausePattern = regexp.MustCompile(`(?m)^\s*package\s+\w+`)
+
+// LintReport is the outcome of linting a Go snippet
+type LintReport struct {
+	// Diff is the gofmt -d output, empty when the code is formatted
+	Diff string
+	// FormatError is the syntax error that kept gofmt from formatting the code
+	FormatError string
+	// Findings are the go vet messages, compile errors included
+	Findings []string
+	// VetSkipped explains why go vet did not run, e.g. a fragment without a package clause
+	VetSkipped string
+}
+
+// GoLinter runs gofmt and go vet on snippets in a throwaway module with no network
+// access, CPU, memory, file size and time limits, and a per-user hourly limit
+type GoLinter struct {
+	limiter *SlidingWindowLimiter
+	// slot allows one go vet run at a time
+	slot   chan struct{}
+	logger domain.Logger
+	// gofmt, goTool and shell are the binaries, empty when they are not installed
+	gofmt  string
+	goTool string
+	shell  string
+}
+
+// NewGoLinter creates a linter using the gofmt and go binaries on the PATH
+func NewGoLinter(logger domain.Logger) *GoLinter {
+	gofmt, _ := exec.LookPath("gofmt")
+	goTool, _ := exec.LookPath("go")
+	shell, _ := exec.LookPath("sh")
+	return &GoLinter{
+		limiter: NewSlidingWindowLimiter(envInt("LINT_HOURLY_LIMIT", defaultLintHourlyLimit), time.Hour),
+		slot:    make(chan struct{}, 1),
+		logger:  logger,
+		gofmt:   gofmt,
+		goTool:  goTool,
+		shell:   shell,
+	}
+}
+
+// Limiter returns the per-user lint limiter so it can be cleaned up periodically
+func (l *GoLinter) Limiter() *SlidingWindowLimiter {
+	return l.limiter
+}
+
+// Lint checks the formatting of code and vets it when it is a complete file
+func (l *GoLinter) Lint(ctx context.Context, userID int64, code string) (*LintReport, error) {
+	if len(code) > MaxLintBytes {
+		return nil, fmt.Errorf("the code is larger than %d KB", MaxLintBytes/1024)
+	}
+	if allowed, retry := l.limiter.Allow(userID); !allowed {
+		return nil, &ChatLimitError{Reason: fmt.Sprintf("hourly limit of %d lint runs reached", l.limiter.Limit()), RetryAfter: retry}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, lintTimeout)
+	defer cancel()
+
+	report := &LintReport{}
+	report.Diff, report.FormatError = l.formatDiff(ctx, code)
+
+	switch {
+	case l.goTool == "":
+		report.VetSkipped = "go vet is not installed on the bot's server"
+	case l.shell == "":
+		report.VetSkipped = "the bot's server has no shell to limit go vet's resources"
+	case !packageClausePattern.MatchString(code):
+		report.VetSkipped = "go vet needs a complete file starting with a package clause"
+	default:
+		findings, err := l.vet(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		report.Findings = findings
+	}
+	return report, nil
+}
+
+// formatDiff returns the gofmt -d output, or the syntax error gofmt reported. Without a
+// gofmt binary the code is checked with go/format and no diff is shown.
+func (l *GoLinter) formatDiff(ctx context.Context, code string) (string, string) {
+	if l.gofmt == "" {
+		formatted, err := FormatGo(code)
+		if err != nil {
+			return "", strings.TrimPrefix(err.Error(), "gofmt: ")
+		}
+		if formatted != code {
+			return "(gofmt would reformat the code; install gofmt on the server to see the diff)", ""
+		}
+		return "", ""
+	}
+
+	// Code on stdin may be a fragment of declarations or statements
+	cmd := exec.CommandContext(ctx, l.gofmt, "-d")
+	cmd.Stdin = strings.NewReader(code)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil && stderr.Len() > 0 {
+		return "", cleanLintOutput(stderr.String(), "<standard input>")
+	}
+
+	diff := stdout.String()
+	diff = strings.ReplaceAll(diff, "<standard input>.orig", "a/snippet.go")
+	diff = strings.ReplaceAll(diff, "<standard input>", "b/snippet.go")
+	return truncateLintOutput(diff), ""
+}
+
+// vet runs go vet on the code as the only file of a fresh module under limitedCommand.
+// Only the standard library is available: the module proxy is switched off, as is cgo.
+func (l *GoLinter) vet(ctx context.Context, code string) ([]string, error) {
+	select {
+	case l.slot <- struct{}{}:
+		defer func() { <-l.slot }()
+	case <-ctx.Done():
+		return nil, errors.New("the linter is busy, try again in a moment")
+	}
+
+	dir, err := os.MkdirTemp("", "yordamchi-lint-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the lint directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"go.mod":     "module snippet\n\ngo 1.21\n",
+		"snippet.go": code,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	cmd := l.limitedCommand(ctx, l.goTool, "vet", ".")
+	cmd.Dir = dir
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + dir,
+		"GOPATH=" + filepath.Join(dir, "gopath"),
+		"GOCACHE=" + lintCacheDir(),
+		"GOPROXY=off",
+		"GOFLAGS=-mod=mod",
+		"GOTOOLCHAIN=local",
+		"GOWORK=off",
+		"CGO_ENABLED=0",
+	}
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	started := time.Now()
+	err = cmd.Run()
+	l.logger.Debug("go vet finished", "duration", time.Since(started), "error", err)
+	if ctx.Err() != nil {
+		return nil, errors.New("go vet took too long")
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("failed to run go vet: %w", err)
+	}
+	// A process over its CPU time is killed by a signal, one over its memory runs out of it
+	if exitErr != nil && (exitErr.ExitCode() == -1 || strings.Contains(output.String(), "out of memory")) {
+		return nil, errors.New("go vet ran out of its CPU or memory limit")
+	}
+
+	var findings []string
+	for _, line := range strings.Split(cleanLintOutput(output.String(), "./snippet.go"), "\n") {
+		line = strings.TrimSpace(line)
+		// "# snippet" headers name the package, which is always the same
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		findings = append(findings, line)
+	}
+	return findings, nil
+}
+
+// limitedCommand runs a program through the shell, which sets the lint resource limits
+// on itself and then becomes the program; the compiler and vet processes go vet starts
+// inherit them. WaitDelay keeps a killed run from waiting on those children's output.
+func (l *GoLinter) limitedCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	script := fmt.Sprintf(`ulimit -t %d && ulimit -v %d && ulimit -f %d && ulimit -n %d && exec "$0" "$@"`,
+		lintCPUSeconds, lintMemoryKB, lintFileBlocks, lintOpenFiles)
+	cmd := exec.CommandContext(ctx, l.shell, append([]string{"-c", script, name}, args...)...)
+	cmd.WaitDelay = lintWaitDelay
+	return cmd
+}
+
+// lintCacheDir returns the Go build cache vet runs share, the server's own when it has one,
+// so the standard library is not compiled again for every snippet
+func lintCacheDir() string {
+	if cache := os.Getenv("GOCACHE"); cache != "" {
+		return cache
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "go-build")
+	}
+	return filepath.Join(os.TempDir(), "yordamchi-lint-cache")
+}
+
+// cleanLintOutput shortens the file paths in tool output to "line:column: message"
+// positions and limits its size
+func cleanLintOutput(output, path string) string {
+	output = strings.ReplaceAll(output, path+":", "")
+	output = strings.ReplaceAll(output, "snippet.go:", "")
+	return truncateLintOutput(strings.TrimSpace(output))
+}
+
+// truncateLintOutput cuts output longer than maxLintOutputBytes at a line break
+func truncateLintOutput(output string) string {
+	if len(output) <= maxLintOutputBytes {
+		return output
+	}
+	cut := strings.LastIndex(output[:maxLintOutputBytes], "\n")
+	if cut < 0 {
+		cut = maxLintOutputBytes
+	}
+	return output[:cut] + "\n… (truncated)"
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGoLinterReportsFormattingAndVetFindings(t *testing.T) {
+	linter := NewGoLinter(silentLogger{})
+	if linter.gofmt == "" || linter.goTool == "" {
+		t.Skip("gofmt and go are not installed")
+	}
+
+	code := "package main\n\nimport \"fmt\"\n\nfunc main() {\nfmt.Printf(\"%d\\n\", \"text\")\n}\n"
+	report, err := linter.Lint(context.Background(), 1, code)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if !strings.Contains(report.Diff, "+\tfmt.Printf") || !strings.Contains(report.Diff, "b/snippet.go") {
+		t.Errorf("expected a gofmt diff, got %q", report.Diff)
+	}
+	if len(report.Findings) != 1 || !strings.HasPrefix(report.Findings[0], "6:13: fmt.Printf format %d") {
+		t.Errorf("expected one printf finding, got %q", report.Findings)
+	}
+}
+
+func TestGoLinterFragmentsAndLimits(t *testing.T) {
+	linter := NewGoLinter(silentLogger{})
+	linter.goTool = ""
+	linter.gofmt = ""
+
+	report, err := linter.Lint(context.Background(), 1, "x := 1\n")
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if report.Diff != "" || report.FormatError != "" || report.VetSkipped == "" {
+		t.Errorf("expected a formatted fragment without vet, got %+v", report)
+	}
+
+	report, err = linter.Lint(context.Background(), 1, "func main() {")
+	if err != nil || report.FormatError == "" {
+		t.Errorf("expected a syntax error, got %+v, %v", report, err)
+	}
+
+	if _, err := linter.Lint(context.Background(), 1, strings.Repeat("x", MaxLintBytes+1)); err == nil {
+		t.Error("expected oversized code to be refused")
+	}
+}
+
+func TestGoLinterLimitsVetResources(t *testing.T) {
+	linter := NewGoLinter(silentLogger{})
+	if linter.shell == "" {
+		t.Skip("sh is not installed")
+	}
+
+	// The limited shell runs a second one that prints the limits it inherited
+	output, err := linter.limitedCommand(context.Background(), linter.shell, "-c", "ulimit -t; ulimit -v; ulimit -n").Output()
+	if err != nil {
+		t.Fatalf("limited command error = %v", err)
+	}
+	want := fmt.Sprintf("%d\n%d\n%d\n", lintCPUSeconds, lintMemoryKB, lintOpenFiles)
+	if string(output) != want {
+		t.Errorf("limits = %q, want %q", output, want)
+	}
+}
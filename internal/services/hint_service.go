@@ -0,0 +1,162 @@
+package services
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// minHintInterval is the least time between two hints to the same user
+const minHintInterval = 24 * time.Hour
+
+// HintStore keeps how often each user used each command and the hints they were shown
+type HintStore interface {
+	RecordFeatureUse(telegramID int64, command string) (int, error)
+	GetFeatureUsage(telegramID int64) (map[string]int, error)
+	GetHintState(telegramID int64) (bool, time.Time, []string, error)
+	SaveHintShown(telegramID int64, command string) error
+	SetHintsDisabled(telegramID int64, disabled bool) error
+}
+
+// Hint suggests a command a user has never used once they used a related one often enough
+type Hint struct {
+	// After is the related command, and Uses how often it must have been used
+	After string
+	Uses  int
+	// Suggest is the suggested command, and Text what it does
+	Suggest string
+	Text    string
+}
+
+// Hints are the suggestions, at most one of which is shown after a command
+var Hints = []Hint{
+	{After: "/add_member", Uses: 3, Suggest: "/workload", Text: "shows how busy each team member is"},
+	{After: "/add_member", Uses: 5, Suggest: "/utilization", Text: "charts the team's capacity against assigned work"},
+	{After: "/create_project", Uses: 1, Suggest: "/analyze", Text: "breaks a requirement down into estimated tasks"},
+	{After: "/analyze", Uses: 2, Suggest: "/similar", Text: "finds earlier tasks like a new one"},
+	{After: "/analyze", Uses: 3, Suggest: "/stack", Text: "tells the analyses which technologies the team uses"},
+	{After: "/list_projects", Uses: 3, Suggest: "/health", Text: "rates a project's schedule and risks"},
+	{After: "/my_tasks", Uses: 3, Suggest: "/calendar", Text: "exports task due dates to your calendar"},
+	{After: "/task", Uses: 5, Suggest: "/velocity", Text: "shows how many tasks the team finishes per week"},
+	{After: "/health", Uses: 2, Suggest: "/status_report", Text: "writes a status report for stakeholders"},
+	{After: "/chat", Uses: 3, Suggest: "/summarize", Text: "summarizes the recent messages of the chat"},
+}
+
+// FeatureUsage is how often a user used a command
+type FeatureUsage struct {
+	Command string
+	Uses    int
+}
+
+// HintProfile is a user's usage profile: the commands they use, most used first, and the
+// suggested commands they never tried
+type HintProfile struct {
+	Enabled bool
+	Used    []FeatureUsage
+	Untried []Hint
+}
+
+// HintService tracks the commands each user uses and occasionally suggests related commands
+// they never touched, at most one per minHintInterval and each only once
+type HintService struct {
+	store  HintStore
+	now    func() time.Time
+	logger domain.Logger
+}
+
+// NewHintService creates a new hint service
+func NewHintService(store HintStore, logger domain.Logger) *HintService {
+	return &HintService{
+		store:  store,
+		now:    time.Now,
+		logger: logger,
+	}
+}
+
+// Observe records a use of the command and returns a hint to show after it, or nil
+func (s *HintService) Observe(telegramID int64, command string) *Hint {
+	if s == nil {
+		return nil
+	}
+
+	uses, err := s.store.RecordFeatureUse(telegramID, command)
+	if err != nil {
+		s.logger.Warn("Failed to record feature use", "telegram_id", telegramID, "command", command, "error", err)
+		return nil
+	}
+
+	var candidates []Hint
+	for _, hint := range Hints {
+		if hint.After == command && uses >= hint.Uses {
+			candidates = append(candidates, hint)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	disabled, lastHintAt, shown, err := s.store.GetHintState(telegramID)
+	if err != nil {
+		s.logger.Warn("Failed to load hint state", "telegram_id", telegramID, "error", err)
+		return nil
+	}
+	if disabled || s.now().Sub(lastHintAt) < minHintInterval {
+		return nil
+	}
+	usage, err := s.store.GetFeatureUsage(telegramID)
+	if err != nil {
+		s.logger.Warn("Failed to load feature usage", "telegram_id", telegramID, "error", err)
+		return nil
+	}
+
+	for _, hint := range candidates {
+		if usage[hint.Suggest] > 0 || slices.Contains(shown, hint.Suggest) {
+			continue
+		}
+		if err := s.store.SaveHintShown(telegramID, hint.Suggest); err != nil {
+			s.logger.Warn("Failed to save shown hint", "telegram_id", telegramID, "error", err)
+			return nil
+		}
+		return &hint
+	}
+	return nil
+}
+
+// SetEnabled turns a user's hints on or off
+func (s *HintService) SetEnabled(telegramID int64, enabled bool) error {
+	return s.store.SetHintsDisabled(telegramID, !enabled)
+}
+
+// Profile returns a user's usage profile
+func (s *HintService) Profile(telegramID int64) (HintProfile, error) {
+	usage, err := s.store.GetFeatureUsage(telegramID)
+	if err != nil {
+		return HintProfile{}, err
+	}
+	disabled, _, _, err := s.store.GetHintState(telegramID)
+	if err != nil {
+		return HintProfile{}, err
+	}
+
+	profile := HintProfile{Enabled: !disabled}
+	for command, uses := range usage {
+		profile.Used = append(profile.Used, FeatureUsage{Command: command, Uses: uses})
+	}
+	sort.Slice(profile.Used, func(i, j int) bool {
+		if profile.Used[i].Uses != profile.Used[j].Uses {
+			return profile.Used[i].Uses > profile.Used[j].Uses
+		}
+		return profile.Used[i].Command < profile.Used[j].Command
+	})
+
+	suggested := make(map[string]bool)
+	for _, hint := range Hints {
+		if usage[hint.Suggest] == 0 && !suggested[hint.Suggest] {
+			suggested[hint.Suggest] = true
+			profile.Untried = append(profile.Untried, hint)
+		}
+	}
+	return profile, nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeHintStore struct {
+	usage      map[string]int
+	disabled   bool
+	lastHintAt time.Time
+	shown      []string
+}
+
+func (s *fakeHintStore) RecordFeatureUse(telegramID int64, command string) (int, error) {
+	s.usage[command]++
+	return s.usage[command], nil
+}
+
+func (s *fakeHintStore) GetFeatureUsage(telegramID int64) (map[string]int, error) {
+	return s.usage, nil
+}
+
+func (s *fakeHintStore) GetHintState(telegramID int64) (bool, time.Time, []string, error) {
+	return s.disabled, s.lastHintAt, s.shown, nil
+}
+
+func (s *fakeHintStore) SaveHintShown(telegramID int64, command string) error {
+	s.lastHintAt = time.Now()
+	s.shown = append(s.shown, command)
+	return nil
+}
+
+func (s *fakeHintStore) SetHintsDisabled(telegramID int64, disabled bool) error {
+	s.disabled = disabled
+	return nil
+}
+
+func TestHintServiceSuggestsUntriedCommand(t *testing.T) {
+	store := &fakeHintStore{usage: make(map[string]int)}
+	service := NewHintService(store, silentLogger{})
+
+	for i := 1; i <= 2; i++ {
+		if hint := service.Observe(1, "/add_member"); hint != nil {
+			t.Fatalf("expected no hint on use %d, got %+v", i, hint)
+		}
+	}
+	hint := service.Observe(1, "/add_member")
+	if hint == nil || hint.Suggest != "/workload" {
+		t.Fatalf("expected /workload to be suggested on the third /add_member, got %+v", hint)
+	}
+
+	// Rate limited, and each hint is shown once
+	if hint := service.Observe(1, "/add_member"); hint != nil {
+		t.Errorf("expected no second hint within the interval, got %+v", hint)
+	}
+	service.now = func() time.Time { return time.Now().Add(minHintInterval + time.Minute) }
+	if hint := service.Observe(1, "/add_member"); hint == nil || hint.Suggest != "/utilization" {
+		t.Errorf("expected the next untried suggestion after the interval, got %+v", hint)
+	}
+}
+
+func TestHintServiceSkipsUsedCommandsAndOptOut(t *testing.T) {
+	store := &fakeHintStore{usage: map[string]int{"/analyze": 4}}
+	service := NewHintService(store, silentLogger{})
+
+	if hint := service.Observe(1, "/create_project"); hint != nil {
+		t.Errorf("expected no hint for a command already used, got %+v", hint)
+	}
+
+	if err := service.SetEnabled(1, false); err != nil {
+		t.Fatal(err)
+	}
+	if hint := service.Observe(1, "/analyze"); hint != nil {
+		t.Errorf("expected no hints after opting out, got %+v", hint)
+	}
+
+	profile, err := service.Profile(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Enabled || len(profile.Used) != 2 || profile.Used[0].Command != "/analyze" {
+		t.Errorf("unexpected profile %+v", profile)
+	}
+	for _, hint := range profile.Untried {
+		if hint.Suggest == "/analyze" {
+			t.Errorf("expected a used command not to be listed as untried")
+		}
+	}
+}
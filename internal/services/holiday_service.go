@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PublicHoliday represents a single public day off
+type PublicHoliday struct {
+	Date    time.Time `json:"date"`
+	Name    string    `json:"name"`
+	Country string    `json:"country"`
+}
+
+// holidayAPIRetryAfter is how long the embedded dataset stands in after a failed API call
+// before the API is asked again
+const holidayAPIRetryAfter = 15 * time.Minute
+
+// HolidayProvider returns public holidays for a country and year
+type HolidayProvider interface {
+	GetHolidays(ctx context.Context, country string, year int) ([]PublicHoliday, error)
+}
+
+// HolidayService resolves public holidays with an optional remote API and an embedded fallback
+type HolidayService struct {
+	remote   HolidayProvider
+	embedded *EmbeddedHolidayProvider
+	cache    map[string][]PublicHoliday
+	// failedAt is when the API last failed for a country and year
+	failedAt map[string]time.Time
+	mutex    sync.RWMutex
+	logger   Logger
+	now      func() time.Time
+}
+
+// NewHolidayService creates a holiday service. The remote Nager.Date API is used
+// only when HOLIDAYS_API=nager, otherwise the embedded dataset is used.
+func NewHolidayService(logger Logger) *HolidayService {
+	service := &HolidayService{
+		embedded: &EmbeddedHolidayProvider{},
+		cache:    make(map[string][]PublicHoliday),
+		failedAt: make(map[string]time.Time),
+		logger:   logger,
+		now:      time.Now,
+	}
+
+	if strings.EqualFold(os.Getenv("HOLIDAYS_API"), "nager") {
+		service.remote = NewNagerHolidayProvider(NewHTTPClient(15*time.Second, logger))
+	}
+
+	return service
+}
+
+// SupportedCountries returns the country codes available in the embedded dataset
+func (s *HolidayService) SupportedCountries() []string {
+	return []string{"UZ", "US", "EU"}
+}
+
+// GetHolidays returns holidays for the country and year, using cached results when available.
+// Only API answers are cached: after a failed call the embedded dataset is returned until
+// the API is tried again holidayAPIRetryAfter later.
+func (s *HolidayService) GetHolidays(ctx context.Context, country string, year int) []PublicHoliday {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "" {
+		return nil
+	}
+
+	cacheKey := fmt.Sprintf("%s:%d", country, year)
+	s.mutex.RLock()
+	cached, found := s.cache[cacheKey]
+	failedAt, failed := s.failedAt[cacheKey]
+	s.mutex.RUnlock()
+	if found {
+		return cached
+	}
+
+	if s.remote != nil && (!failed || s.now().Sub(failedAt) >= holidayAPIRetryAfter) {
+		holidays, err := s.remote.GetHolidays(ctx, country, year)
+		if err == nil {
+			s.mutex.Lock()
+			s.cache[cacheKey] = holidays
+			delete(s.failedAt, cacheKey)
+			s.mutex.Unlock()
+			return holidays
+		}
+		s.logger.Printf("⚠️ Holiday API failed for %s, using embedded data: %v", cacheKey, err)
+		s.mutex.Lock()
+		s.failedAt[cacheKey] = s.now()
+		s.mutex.Unlock()
+	}
+
+	holidays, _ := s.embedded.GetHolidays(ctx, country, year)
+	return holidays
+}
+
+// ValidateCountry checks that public holidays are known for a country code, in the
+// embedded dataset or, when enabled, from the API
+func (s *HolidayService) ValidateCountry(ctx context.Context, country string) error {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if _, ok := fixedHolidays[country]; ok {
+		return nil
+	}
+	if s.remote == nil {
+		return fmt.Errorf("no public holidays known for %s, built-in countries are %s", country, strings.Join(s.SupportedCountries(), ", "))
+	}
+
+	year := s.now().Year()
+	holidays, err := s.remote.GetHolidays(ctx, country, year)
+	if err != nil || len(holidays) == 0 {
+		s.logger.Printf("⚠️ Holiday API has no holidays for %s: %v", country, err)
+		return fmt.Errorf("no public holidays found for %s, use a two-letter country code like UZ or DE", country)
+	}
+
+	s.mutex.Lock()
+	s.cache[fmt.Sprintf("%s:%d", country, year)] = holidays
+	s.mutex.Unlock()
+	return nil
+}
+
+// UpcomingHolidays returns holidays from the given date within the next number of days
+func (s *HolidayService) UpcomingHolidays(ctx context.Context, country string, from time.Time, days int) []PublicHoliday {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, days)
+
+	var upcoming []PublicHoliday
+	for year := start.Year(); year <= end.Year(); year++ {
+		for _, holiday := range s.GetHolidays(ctx, country, year) {
+			if !holiday.Date.Before(start) && holiday.Date.Before(end) {
+				upcoming = append(upcoming, holiday)
+			}
+		}
+	}
+
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].Date.Before(upcoming[j].Date)
+	})
+
+	return upcoming
+}
+
+// NagerHolidayProvider fetches holidays from the public Nager.Date API
+type NagerHolidayProvider struct {
+	httpClient *HTTPClient
+	baseURL    string
+}
+
+// NewNagerHolidayProvider creates a new Nager.Date holiday provider
+func NewNagerHolidayProvider(httpClient *HTTPClient) *NagerHolidayProvider {
+	return &NagerHolidayProvider{
+		httpClient: httpClient,
+		baseURL:    "https://date.nager.at/api/v3/PublicHolidays",
+	}
+}
+
+// GetHolidays fetches public holidays for a country and year
+func (p *NagerHolidayProvider) GetHolidays(ctx context.Context, country string, year int) ([]PublicHoliday, error) {
+	var response []struct {
+		Date      string `json:"date"`
+		LocalName string `json:"localName"`
+		Name      string `json:"name"`
+	}
+
+	url := fmt.Sprintf("%s/%d/%s", p.baseURL, year, country)
+	if err := p.httpClient.GetJSON(ctx, url, nil, &response); err != nil {
+		return nil, err
+	}
+
+	holidays := make([]PublicHoliday, 0, len(response))
+	for _, item := range response {
+		date, err := time.Parse("2006-01-02", item.Date)
+		if err != nil {
+			continue
+		}
+		holidays = append(holidays, PublicHoliday{Date: date, Name: item.Name, Country: country})
+	}
+
+	return holidays, nil
+}
+
+// EmbeddedHolidayProvider serves holidays from a built-in dataset for UZ, US and EU
+type EmbeddedHolidayProvider struct{}
+
+// fixedHolidays lists holidays that fall on the same date every year
+var fixedHolidays = map[string][]struct {
+	month time.Month
+	day   int
+	name  string
+}{
+	"UZ": {
+		{time.January, 1, "Yangi yil"},
+		{time.January, 14, "Vatan himoyachilari kuni"},
+		{time.March, 8, "Xalqaro xotin-qizlar kuni"},
+		{time.March, 21, "Navro'z bayrami"},
+		{time.May, 9, "Xotira va qadrlash kuni"},
+		{time.September, 1, "Mustaqillik kuni"},
+		{time.October, 1, "O'qituvchi va murabbiylar kuni"},
+		{time.December, 8, "Konstitutsiya kuni"},
+	},
+	"US": {
+		{time.January, 1, "New Year's Day"},
+		{time.June, 19, "Juneteenth"},
+		{time.July, 4, "Independence Day"},
+		{time.November, 11, "Veterans Day"},
+		{time.December, 25, "Christmas Day"},
+	},
+	"EU": {
+		{time.January, 1, "New Year's Day"},
+		{time.May, 1, "Labour Day"},
+		{time.May, 9, "Europe Day"},
+		{time.December, 25, "Christmas Day"},
+		{time.December, 26, "St. Stephen's Day"},
+	},
+}
+
+// GetHolidays returns embedded holidays for a country and year
+func (p *EmbeddedHolidayProvider) GetHolidays(ctx context.Context, country string, year int) ([]PublicHoliday, error) {
+	fixed, ok := fixedHolidays[country]
+	if !ok {
+		return nil, fmt.Errorf("no embedded holidays for country %s", country)
+	}
+
+	holidays := make([]PublicHoliday, 0, len(fixed)+6)
+	for _, h := range fixed {
+		holidays = append(holidays, PublicHoliday{
+			Date:    time.Date(year, h.month, h.day, 0, 0, 0, 0, time.UTC),
+			Name:    h.name,
+			Country: country,
+		})
+	}
+
+	// Holidays defined by weekday rules
+	switch country {
+	case "US":
+		holidays = append(holidays,
+			PublicHoliday{nthWeekday(year, time.January, time.Monday, 3), "Martin Luther King Jr. Day", country},
+			PublicHoliday{nthWeekday(year, time.February, time.Monday, 3), "Presidents' Day", country},
+			PublicHoliday{lastWeekday(year, time.May, time.Monday), "Memorial Day", country},
+			PublicHoliday{nthWeekday(year, time.September, time.Monday, 1), "Labor Day", country},
+			PublicHoliday{nthWeekday(year, time.October, time.Monday, 2), "Columbus Day", country},
+			PublicHoliday{nthWeekday(year, time.November, time.Thursday, 4), "Thanksgiving Day", country},
+		)
+	case "EU":
+		easter := easterSunday(year)
+		holidays = append(holidays,
+			PublicHoliday{easter.AddDate(0, 0, -2), "Good Friday", country},
+			PublicHoliday{easter.AddDate(0, 0, 1), "Easter Monday", country},
+		)
+	}
+
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+
+	return holidays, nil
+}
+
+// nthWeekday returns the n-th occurrence of a weekday in a month
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+(n-1)*7)
+}
+
+// lastWeekday returns the last occurrence of a weekday in a month
+func lastWeekday(year int, month time.Month, weekday time.Weekday) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.AddDate(0, 0, -offset)
+}
+
+// easterSunday calculates Western Easter using the anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeHolidayAPI answers like the holiday API, failing while down
+type fakeHolidayAPI struct {
+	down  bool
+	calls int
+}
+
+func (f *fakeHolidayAPI) GetHolidays(ctx context.Context, country string, year int) ([]PublicHoliday, error) {
+	f.calls++
+	if f.down || country == "XX" {
+		return nil, errors.New("unavailable")
+	}
+	return []PublicHoliday{{Date: time.Date(year, time.April, 27, 0, 0, 0, 0, time.UTC), Name: "King's Day", Country: country}}, nil
+}
+
+func TestHolidayServiceRetriesTheAPIAfterAFailure(t *testing.T) {
+	api := &fakeHolidayAPI{down: true}
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	service := NewHolidayService(silentLogger{})
+	service.remote = api
+	service.now = func() time.Time { return now }
+
+	// The embedded data stands in while the API is down, without being cached
+	if holidays := service.GetHolidays(context.Background(), "US", 2026); len(holidays) == 0 || holidays[0].Name != "New Year's Day" {
+		t.Fatalf("GetHolidays() with the API down = %v, want the embedded US holidays", holidays)
+	}
+	service.GetHolidays(context.Background(), "US", 2026)
+	if api.calls != 1 {
+		t.Errorf("API called %d times right after a failure, want 1", api.calls)
+	}
+
+	api.down = false
+	now = now.Add(holidayAPIRetryAfter)
+	if holidays := service.GetHolidays(context.Background(), "US", 2026); len(holidays) != 1 || holidays[0].Name != "King's Day" {
+		t.Fatalf("GetHolidays() after the retry wait = %v, want the API answer", holidays)
+	}
+	service.GetHolidays(context.Background(), "US", 2026)
+	if api.calls != 2 {
+		t.Errorf("API called %d times, want the successful answer cached", api.calls)
+	}
+}
+
+func TestHolidayServiceValidateCountry(t *testing.T) {
+	service := NewHolidayService(silentLogger{})
+	if err := service.ValidateCountry(context.Background(), "uz"); err != nil {
+		t.Errorf("ValidateCountry(uz) error = %v", err)
+	}
+	if err := service.ValidateCountry(context.Background(), "NL"); err == nil {
+		t.Error("expected a country outside the embedded data to be rejected without the API")
+	}
+
+	service.remote = &fakeHolidayAPI{}
+	if err := service.ValidateCountry(context.Background(), "NL"); err != nil {
+		t.Errorf("ValidateCountry(NL) with the API error = %v", err)
+	}
+	if err := service.ValidateCountry(context.Background(), "XX"); err == nil {
+		t.Error("expected a country the API does not know to be rejected")
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -41,6 +42,12 @@ func NewHTTPClient(timeout time.Duration, logger Logger) *HTTPClient {
 
 // Get performs a GET request to the specified URL
 func (h *HTTPClient) Get(ctx context.Context, url string, headers map[string]string) (*HTTPResponse, error) {
+	return h.GetWithLimit(ctx, url, headers, 0)
+}
+
+// GetWithLimit performs a GET request like Get, failing when the response body is larger
+// than maxBytes; 0 reads bodies of any size
+func (h *HTTPClient) GetWithLimit(ctx context.Context, url string, headers map[string]string, maxBytes int64) (*HTTPResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("so'rov yaratishda xatolik: %w", err)
@@ -60,10 +67,17 @@ func (h *HTTPClient) Get(ctx context.Context, url string, headers map[string]str
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	reader := io.Reader(resp.Body)
+	if maxBytes > 0 {
+		reader = io.LimitReader(resp.Body, maxBytes+1)
+	}
+	body, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, fmt.Errorf("javobni o'qishda xatolik: %w", err)
 	}
+	if maxBytes > 0 && int64(len(body)) > maxBytes {
+		return nil, fmt.Errorf("javob hajmi %d baytdan katta", maxBytes)
+	}
 
 	h.logger.Printf("🌐 HTTP GET %s - Status: %d, Size: %d bytes", 
 		url, resp.StatusCode, len(body))
@@ -91,4 +105,81 @@ func (h *HTTPClient) GetJSON(ctx context.Context, url string, headers map[string
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+// Post sends body as a POST request with the given content type and returns the response
+// whatever its status
+func (h *HTTPClient) Post(ctx context.Context, url string, headers map[string]string, contentType string, body []byte) (*HTTPResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("so'rov yaratishda xatolik: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "YordamchiDevBot/1.0")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("so'rov yuborishda xatolik: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("javobni o'qishda xatolik: %w", err)
+	}
+
+	h.logger.Printf("🌐 HTTP POST %s - Status: %d, Size: %d bytes",
+		url, resp.StatusCode, len(respBody))
+
+	return &HTTPResponse{
+		StatusCode: resp.StatusCode,
+		Body:       respBody,
+		Headers:    resp.Header,
+	}, nil
+}
+
+// PostJSON sends payload as a JSON POST request and unmarshals a successful JSON response
+// into target when it is not nil
+func (h *HTTPClient) PostJSON(ctx context.Context, url string, headers map[string]string, payload, target interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("so'rovni tayyorlashda xatolik: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("so'rov yaratishda xatolik: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "YordamchiDevBot/1.0")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("so'rov yuborishda xatolik: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("javobni o'qishda xatolik: %w", err)
+	}
+
+	h.logger.Printf("🌐 HTTP POST %s - Status: %d, Size: %d bytes",
+		url, resp.StatusCode, len(respBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP xatolik: %d", resp.StatusCode)
+	}
+	if target == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, target); err != nil {
+		return fmt.Errorf("JSON ni parsing qilishda xatolik: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// OCR limits. Scanned PDFs are read page by page, so only their first pages are read.
+const (
+	ocrTimeout          = 2 * time.Minute
+	defaultOCRMaxPages  = 5
+	defaultOCRLanguages = "eng"
+)
+
+// imageFormats are the image extensions ExtractContent reads when OCR is set up
+var imageFormats = []string{".jpg", ".jpeg", ".png", ".webp"}
+
+// OCREngine reads the text in images and scanned PDFs
+type OCREngine interface {
+	IsConfigured() bool
+	// ReadImage returns the text in an image file
+	ReadImage(ctx context.Context, filePath string) (string, error)
+	// ReadPDF returns the text on the first maxPages pages of a PDF
+	ReadPDF(ctx context.Context, filePath string, maxPages int) (string, error)
+}
+
+type namedOCREngine struct {
+	name   string
+	engine OCREngine
+}
+
+// ImageExtractor reads text from photos, screenshots and scanned PDFs with the first
+// configured OCR engine, or the one named by OCR_PROVIDER
+type ImageExtractor struct {
+	engines  []namedOCREngine
+	maxPages int
+	logger   domain.Logger
+}
+
+// NewImageExtractor creates an image extractor with all supported OCR engines
+func NewImageExtractor(logger domain.Logger) *ImageExtractor {
+	return &ImageExtractor{
+		engines: []namedOCREngine{
+			{name: "tesseract", engine: NewTesseractEngine(envString("OCR_LANGUAGES", defaultOCRLanguages))},
+			{name: "google", engine: NewVisionEngine(os.Getenv("GOOGLE_VISION_API_KEY"))},
+		},
+		maxPages: envInt("OCR_MAX_PAGES", defaultOCRMaxPages),
+		logger:   logger,
+	}
+}
+
+// engine returns the engine in use, or nil when none is configured
+func (e *ImageExtractor) engine() *namedOCREngine {
+	preferred := strings.ToLower(os.Getenv("OCR_PROVIDER"))
+	for i := range e.engines {
+		if e.engines[i].engine.IsConfigured() && (preferred == "" || e.engines[i].name == preferred) {
+			return &e.engines[i]
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether an OCR engine is configured
+func (e *ImageExtractor) Enabled() bool {
+	return e != nil && e.engine() != nil
+}
+
+// ExtractImage returns the text in an image
+func (e *ImageExtractor) ExtractImage(ctx context.Context, filePath string) (string, error) {
+	return e.read(ctx, "image", func(ctx context.Context, engine OCREngine) (string, error) {
+		return engine.ReadImage(ctx, filePath)
+	})
+}
+
+// ExtractPDF returns the text on the first pages of a scanned PDF
+func (e *ImageExtractor) ExtractPDF(ctx context.Context, filePath string) (string, error) {
+	return e.read(ctx, "pdf", func(ctx context.Context, engine OCREngine) (string, error) {
+		return engine.ReadPDF(ctx, filePath, e.maxPages)
+	})
+}
+
+// read runs an OCR call on the engine in use within ocrTimeout
+func (e *ImageExtractor) read(ctx context.Context, kind string, call func(context.Context, OCREngine) (string, error)) (string, error) {
+	engine := e.engine()
+	if engine == nil {
+		return "", fmt.Errorf("no OCR engine configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ocrTimeout)
+	defer cancel()
+
+	started := time.Now()
+	text, err := call(ctx, engine.engine)
+	if err != nil {
+		return "", fmt.Errorf("%s OCR failed: %w", engine.name, err)
+	}
+	text = strings.TrimSpace(text)
+	e.logger.Info("Text recognized", "engine", engine.name, "kind", kind, "chars", len(text), "duration", time.Since(started))
+	if text == "" {
+		return "", fmt.Errorf("no text recognized")
+	}
+	return text, nil
+}
+
+// isImageFormat reports whether ext is an image extension read with OCR
+func isImageFormat(ext string) bool {
+	for _, format := range imageFormats {
+		if ext == format {
+			return true
+		}
+	}
+	return false
+}
+
+// TesseractEngine runs the tesseract binary, and pdftoppm to turn PDF pages into images
+type TesseractEngine struct {
+	// tesseract and pdftoppm are the binaries, empty when they are not installed
+	tesseract string
+	pdftoppm  string
+	// languages are tesseract language codes such as "eng+rus"
+	languages string
+}
+
+// NewTesseractEngine creates a tesseract engine using the binaries on the PATH
+func NewTesseractEngine(languages string) *TesseractEngine {
+	tesseract, _ := exec.LookPath("tesseract")
+	pdftoppm, _ := exec.LookPath("pdftoppm")
+	return &TesseractEngine{
+		tesseract: tesseract,
+		pdftoppm:  pdftoppm,
+		languages: languages,
+	}
+}
+
+// IsConfigured returns true if tesseract is installed
+func (t *TesseractEngine) IsConfigured() bool {
+	return t.tesseract != ""
+}
+
+// ReadImage prints the recognized text of the image to stdout
+func (t *TesseractEngine) ReadImage(ctx context.Context, filePath string) (string, error) {
+	cmd := exec.CommandContext(ctx, t.tesseract, filePath, "stdout", "-l", t.languages)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", errors.New("tesseract took too long")
+		}
+		return "", fmt.Errorf("tesseract failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// ReadPDF renders the first pages at 300 DPI, the resolution tesseract works best at,
+// and reads them one by one
+func (t *TesseractEngine) ReadPDF(ctx context.Context, filePath string, maxPages int) (string, error) {
+	if t.pdftoppm == "" {
+		return "", errors.New("pdftoppm (poppler-utils) is needed to read scanned PDFs")
+	}
+
+	dir, err := os.MkdirTemp("", "yordamchi-ocr-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create the OCR directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cmd := exec.CommandContext(ctx, t.pdftoppm, "-r", "300", "-gray", "-png",
+		"-f", "1", "-l", fmt.Sprint(maxPages), filePath, filepath.Join(dir, "page"))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("pdftoppm failed: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	// pdftoppm pads page numbers to the same width, so the names sort in page order
+	pages, err := filepath.Glob(filepath.Join(dir, "page*.png"))
+	if err != nil {
+		return "", fmt.Errorf("failed to list the rendered pages: %w", err)
+	}
+	sort.Strings(pages)
+
+	var content strings.Builder
+	for _, page := range pages {
+		text, err := t.ReadImage(ctx, page)
+		if err != nil {
+			return "", err
+		}
+		content.WriteString(text)
+		content.WriteString("\n\n")
+	}
+	return content.String(), nil
+}
+
+// VisionEngine calls the Google Cloud Vision API, which reads PDFs without rendering them
+type VisionEngine struct {
+	apiKey     string
+	imageURL   string
+	fileURL    string
+	httpClient *http.Client
+}
+
+// NewVisionEngine creates a Cloud Vision client; it is unconfigured without a key
+func NewVisionEngine(apiKey string) *VisionEngine {
+	return &VisionEngine{
+		apiKey:     apiKey,
+		imageURL:   "https://vision.googleapis.com/v1/images:annotate",
+		fileURL:    "https://vision.googleapis.com/v1/files:annotate",
+		httpClient: &http.Client{Timeout: ocrTimeout},
+	}
+}
+
+// IsConfigured returns true if an API key is set
+func (v *VisionEngine) IsConfigured() bool {
+	return v.apiKey != ""
+}
+
+// visionAnnotation is the part of a Vision response that holds the recognized text
+type visionAnnotation struct {
+	FullTextAnnotation *struct {
+		Text string `json:"text"`
+	} `json:"fullTextAnnotation"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ReadImage sends the image for DOCUMENT_TEXT_DETECTION, which suits dense text
+func (v *VisionEngine) ReadImage(ctx context.Context, filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image: %w", err)
+	}
+	request := map[string]interface{}{
+		"requests": []map[string]interface{}{{
+			"image":    map[string]string{"content": base64.StdEncoding.EncodeToString(content)},
+			"features": []map[string]string{{"type": "DOCUMENT_TEXT_DETECTION"}},
+		}},
+	}
+
+	var result struct {
+		Responses []visionAnnotation `json:"responses"`
+	}
+	if err := v.post(ctx, v.imageURL, request, &result); err != nil {
+		return "", err
+	}
+	return visionText(result.Responses)
+}
+
+// ReadPDF sends the PDF inline; the API reads up to five of its pages per request
+func (v *VisionEngine) ReadPDF(ctx context.Context, filePath string, maxPages int) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read PDF: %w", err)
+	}
+	if maxPages > 5 {
+		maxPages = 5
+	}
+	pages := make([]int, maxPages)
+	for i := range pages {
+		pages[i] = i + 1
+	}
+	request := map[string]interface{}{
+		"requests": []map[string]interface{}{{
+			"inputConfig": map[string]string{
+				"content":  base64.StdEncoding.EncodeToString(content),
+				"mimeType": "application/pdf",
+			},
+			"features": []map[string]string{{"type": "DOCUMENT_TEXT_DETECTION"}},
+			"pages":    pages,
+		}},
+	}
+
+	var result struct {
+		Responses []struct {
+			Responses []visionAnnotation `json:"responses"`
+		} `json:"responses"`
+	}
+	if err := v.post(ctx, v.fileURL, request, &result); err != nil {
+		return "", err
+	}
+	if len(result.Responses) == 0 {
+		return "", errors.New("empty response")
+	}
+	return visionText(result.Responses[0].Responses)
+}
+
+// post sends a Vision API request and decodes its response
+func (v *VisionEngine) post(ctx context.Context, url string, request, result interface{}) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Goog-Api-Key", v.apiKey)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// visionText joins the text of the annotated images or pages
+func visionText(annotations []visionAnnotation) (string, error) {
+	var content strings.Builder
+	for _, annotation := range annotations {
+		if annotation.Error != nil && annotation.Error.Message != "" {
+			return "", fmt.Errorf("API error: %s", annotation.Error.Message)
+		}
+		if annotation.FullTextAnnotation != nil {
+			content.WriteString(annotation.FullTextAnnotation.Text)
+			content.WriteString("\n\n")
+		}
+	}
+	return content.String(), nil
+}
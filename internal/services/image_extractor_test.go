@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeOCR is an OCR engine returning fixed text
+type fakeOCR struct {
+	configured bool
+	text       string
+}
+
+func (f fakeOCR) IsConfigured() bool { return f.configured }
+
+func (f fakeOCR) ReadImage(ctx context.Context, filePath string) (string, error) {
+	return f.text, nil
+}
+
+func (f fakeOCR) ReadPDF(ctx context.Context, filePath string, maxPages int) (string, error) {
+	return f.text, nil
+}
+
+// pngHeader is enough of a PNG for the content check
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x02\x00\x00\x00")
+
+func TestImageExtractorPicksEngine(t *testing.T) {
+	extractor := &ImageExtractor{
+		engines: []namedOCREngine{
+			{name: "tesseract", engine: fakeOCR{}},
+			{name: "google", engine: fakeOCR{configured: true, text: "  Login page\n"}},
+		},
+		logger: silentLogger{},
+	}
+
+	text, err := extractor.ExtractImage(context.Background(), "scan.png")
+	if err != nil || text != "Login page" {
+		t.Fatalf("ExtractImage() = %q, %v", text, err)
+	}
+
+	t.Setenv("OCR_PROVIDER", "tesseract")
+	if extractor.Enabled() {
+		t.Error("expected no engine when the preferred one is not configured")
+	}
+	if _, err := extractor.ExtractImage(context.Background(), "scan.png"); err == nil {
+		t.Error("expected an error without an engine")
+	}
+}
+
+func TestFileExtractorReadsImagesWithOCR(t *testing.T) {
+	t.Setenv("FILE_FORMATS", "")
+	extractor := NewFileExtractor(silentLogger{})
+	extractor.images = &ImageExtractor{logger: silentLogger{}}
+
+	if extractor.IsSupported("screenshot.png") {
+		t.Error("expected images to be refused without OCR")
+	}
+	if got := extractor.GetSupportedFormats(); !reflect.DeepEqual(got, []string{"TXT", "MD", "PDF", "DOCX", "XLSX", "XLS"}) {
+		t.Errorf("GetSupportedFormats = %v", got)
+	}
+
+	extractor.images.engines = []namedOCREngine{{name: "fake", engine: fakeOCR{configured: true, text: "User can reset the password"}}}
+	if !extractor.IsSupported("photo.jpg") {
+		t.Error("expected images to be accepted with OCR")
+	}
+	text, err := extractor.ExtractContent(writeSample(t, "screenshot.png", pngHeader), "screenshot.png")
+	if err != nil || text != "User can reset the password" {
+		t.Fatalf("ExtractContent() = %q, %v", text, err)
+	}
+
+	if _, err := extractor.ExtractContent(writeSample(t, "photo.jpg", []byte("plain text")), "photo.jpg"); err == nil {
+		t.Error("expected text named .jpg to be rejected")
+	}
+}
+
+func TestVisionEngineReadsImagesAndPDFs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Goog-Api-Key") != "key" {
+			t.Errorf("missing API key header")
+		}
+		var body struct {
+			Requests []struct {
+				Features []struct {
+					Type string `json:"type"`
+				} `json:"features"`
+				Pages []int `json:"pages"`
+			} `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Requests) != 1 {
+			t.Errorf("unexpected request: %v", err)
+			return
+		}
+		if body.Requests[0].Features[0].Type != "DOCUMENT_TEXT_DETECTION" {
+			t.Errorf("unexpected feature %+v", body.Requests[0].Features)
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/files") {
+			if len(body.Requests[0].Pages) != 5 {
+				t.Errorf("expected pages capped at 5, got %v", body.Requests[0].Pages)
+			}
+			w.Write([]byte(`{"responses":[{"responses":[{"fullTextAnnotation":{"text":"Page one"}},{"fullTextAnnotation":{"text":"Page two"}}]}]}`))
+			return
+		}
+		w.Write([]byte(`{"responses":[{"fullTextAnnotation":{"text":"Add a login form"}}]}`))
+	}))
+	defer server.Close()
+
+	engine := NewVisionEngine("key")
+	engine.imageURL = server.URL + "/images"
+	engine.fileURL = server.URL + "/files"
+
+	text, err := engine.ReadImage(context.Background(), writeSample(t, "scan.png", pngHeader))
+	if err != nil || strings.TrimSpace(text) != "Add a login form" {
+		t.Errorf("ReadImage() = %q, %v", text, err)
+	}
+
+	text, err = engine.ReadPDF(context.Background(), writeSample(t, "scan.pdf", []byte("%PDF-1.7")), 10)
+	if err != nil || !strings.Contains(text, "Page one") || !strings.Contains(text, "Page two") {
+		t.Errorf("ReadPDF() = %q, %v", text, err)
+	}
+}
+
+func TestVisionEngineReportsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"responses":[{"error":{"message":"Bad image data."}}]}`))
+	}))
+	defer server.Close()
+
+	engine := NewVisionEngine("key")
+	engine.imageURL = server.URL
+	if _, err := engine.ReadImage(context.Background(), writeSample(t, "scan.png", pngHeader)); err == nil || !strings.Contains(err.Error(), "Bad image data") {
+		t.Errorf("expected the API error, got %v", err)
+	}
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Interview practice defaults; INTERVIEW_HOURLY_LIMIT overrides the AI calls per user and hour
+const (
+	defaultInterviewHourlyLimit = 30
+	interviewSessionTTL         = 2 * time.Hour
+	interviewHistorySize        = 10
+	maxInterviewAnswerChars     = 3000
+)
+
+// InterviewQuestions is the number of questions of a practice interview
+const InterviewQuestions = 5
+
+// DefaultInterviewTopic is practiced when /interview names no topic
+const DefaultInterviewTopic = "go"
+
+// InterviewTopics are the practice topics and how the interviewer understands them
+var InterviewTopics = map[string]string{
+	"go":            "the Go language: types, interfaces, slices and maps, error handling, generics and the standard library",
+	"concurrency":   "concurrency in Go: goroutines, channels, select, sync primitives, contexts and the race detector",
+	"system_design": "system design: scalability, caching, queues, databases, consistency and trade-offs of a backend service",
+	"databases":     "databases: SQL, indexes, transactions and isolation levels, schema design and query tuning",
+	"testing":       "testing Go code: table-driven tests, mocks and fakes, benchmarks, fuzzing and integration tests",
+}
+
+// InterviewTopicNames returns the topic keys in alphabetical order
+func InterviewTopicNames() []string {
+	names := make([]string, 0, len(InterviewTopics))
+	for name := range InterviewTopics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// InterviewTurn is an answered interview question
+type InterviewTurn struct {
+	Question string
+	Answer   string
+	// Score is the interviewer's rating from 1 to 10
+	Score    int
+	Feedback string
+}
+
+// InterviewSession is a user's practice interview. It moves from asking a question to
+// waiting for the answer, evaluating it and asking the next one, until InterviewQuestions
+// are answered or the user stops.
+type InterviewSession struct {
+	Topic string
+	// Question is the question waiting for an answer
+	Question  string
+	Turns     []InterviewTurn
+	StartedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AverageScore returns the mean score of the answered questions, 0 without answers
+func (s InterviewSession) AverageScore() float64 {
+	if len(s.Turns) == 0 {
+		return 0
+	}
+	total := 0
+	for _, turn := range s.Turns {
+		total += turn.Score
+	}
+	return float64(total) / float64(len(s.Turns))
+}
+
+// Finished reports whether every question of the session is answered
+func (s InterviewSession) Finished() bool {
+	return len(s.Turns) >= InterviewQuestions
+}
+
+// InterviewEvaluation is the interviewer's verdict on an answer and the next question
+type InterviewEvaluation struct {
+	Score        int    `json:"score"`
+	Feedback     string `json:"feedback"`
+	NextQuestion string `json:"next_question"`
+}
+
+// InterviewService runs practice interviews with the AI chain as interviewer. Sessions and
+// the history of finished ones are kept in memory per user.
+type InterviewService struct {
+	ai       TextCompleter
+	sessions map[int64]*InterviewSession
+	history  map[int64][]InterviewSession
+	limiter  *SlidingWindowLimiter
+	mutex    sync.Mutex
+	now      func() time.Time
+	logger   domain.Logger
+}
+
+// NewInterviewService creates a new interview practice service
+func NewInterviewService(ai TextCompleter, logger domain.Logger) *InterviewService {
+	return &InterviewService{
+		ai:       ai,
+		sessions: make(map[int64]*InterviewSession),
+		history:  make(map[int64][]InterviewSession),
+		limiter:  NewSlidingWindowLimiter(envInt("INTERVIEW_HOURLY_LIMIT", defaultInterviewHourlyLimit), time.Hour),
+		now:      time.Now,
+		logger:   logger,
+	}
+}
+
+// IsConfigured returns true if an AI provider is available
+func (s *InterviewService) IsConfigured() bool {
+	return s.ai.IsConfigured()
+}
+
+// Active reports whether the user has a session waiting for an answer
+func (s *InterviewService) Active(userID int64) bool {
+	return s.Session(userID) != nil
+}
+
+// Session returns a copy of the user's open session, or nil when there is none
+func (s *InterviewService) Session(userID int64) *InterviewSession {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	session := s.openSession(userID)
+	if session == nil {
+		return nil
+	}
+	snapshot := *session
+	snapshot.Turns = append([]InterviewTurn(nil), session.Turns...)
+	return &snapshot
+}
+
+// Start opens a session on the topic, replacing an open one, and returns the first question
+func (s *InterviewService) Start(ctx context.Context, userID int64, topic string) (string, error) {
+	if _, ok := InterviewTopics[topic]; !ok {
+		return "", fmt.Errorf("unknown interview topic %q", topic)
+	}
+	if err := s.allow(userID); err != nil {
+		return "", err
+	}
+
+	prompt := fmt.Sprintf("You are a senior engineer interviewing a candidate about %s.\n"+
+		"Ask one interview question of medium difficulty that can be answered in a few sentences. %s\n"+
+		"Reply with the question only.", InterviewTopics[topic], s.askedBefore(userID, topic))
+	question, err := s.ai.Complete(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	question = strings.TrimSpace(question)
+
+	now := s.now()
+	s.mutex.Lock()
+	s.sessions[userID] = &InterviewSession{Topic: topic, Question: question, StartedAt: now, UpdatedAt: now}
+	s.mutex.Unlock()
+
+	s.logger.Info("Interview started", "user_id", userID, "topic", topic)
+	return question, nil
+}
+
+// Answer evaluates the answer to the open question. It returns the evaluation and the
+// session afterwards; a finished session is moved to the history.
+func (s *InterviewService) Answer(ctx context.Context, userID int64, answer string) (*InterviewEvaluation, *InterviewSession, error) {
+	session := s.Session(userID)
+	if session == nil {
+		return nil, nil, fmt.Errorf("no open interview")
+	}
+	if err := s.allow(userID); err != nil {
+		return nil, nil, err
+	}
+	if len(answer) > maxInterviewAnswerChars {
+		answer = answer[:maxInterviewAnswerChars]
+	}
+
+	last := len(session.Turns)+1 >= InterviewQuestions
+	next := "Then ask the next question on the topic, different from the previous ones, in \"next_question\"."
+	if last {
+		next = "This was the last question, leave \"next_question\" empty."
+	}
+	var previous strings.Builder
+	for _, turn := range session.Turns {
+		previous.WriteString("- " + turn.Question + "\n")
+	}
+	prompt := fmt.Sprintf(`You are a senior engineer interviewing a candidate about %s.
+
+Previous questions:
+%s
+Question: %s
+Candidate's answer: %s
+
+Rate the answer from 1 (wrong or empty) to 10 (complete and precise) and give short, concrete feedback (under 120 words): what was right, what was missing, and the key point of a strong answer. %s
+
+Respond only with valid JSON: {"score": 7, "feedback": "...", "next_question": "..."}`,
+		InterviewTopics[session.Topic], previous.String(), session.Question, answer, next)
+
+	response, err := s.ai.Complete(ctx, prompt)
+	if err != nil {
+		return nil, nil, err
+	}
+	evaluation, err := ParseInterviewEvaluation(response)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !last && evaluation.NextQuestion == "" {
+		return nil, nil, fmt.Errorf("interviewer gave no next question")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	open := s.openSession(userID)
+	if open == nil {
+		return nil, nil, fmt.Errorf("no open interview")
+	}
+	open.Turns = append(open.Turns, InterviewTurn{Question: open.Question, Answer: answer, Score: evaluation.Score, Feedback: evaluation.Feedback})
+	open.Question = evaluation.NextQuestion
+	open.UpdatedAt = s.now()
+
+	result := *open
+	result.Turns = append([]InterviewTurn(nil), open.Turns...)
+	if open.Finished() {
+		s.finish(userID)
+	}
+	s.logger.Info("Interview answer evaluated", "user_id", userID, "topic", open.Topic, "score", evaluation.Score, "turn", len(open.Turns))
+	return evaluation, &result, nil
+}
+
+// Stop ends the user's open session, keeping its answered questions in the history
+func (s *InterviewService) Stop(userID int64) *InterviewSession {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	session := s.openSession(userID)
+	if session == nil {
+		return nil
+	}
+	result := *session
+	s.finish(userID)
+	return &result
+}
+
+// History returns the user's finished sessions, newest first
+func (s *InterviewService) History(userID int64) []InterviewSession {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	history := s.history[userID]
+	result := make([]InterviewSession, len(history))
+	for i, session := range history {
+		result[len(history)-1-i] = session
+	}
+	return result
+}
+
+// Limiter returns the per-user hourly limiter
+func (s *InterviewService) Limiter() *SlidingWindowLimiter {
+	return s.limiter
+}
+
+// allow counts an AI call against the user's hourly limit
+func (s *InterviewService) allow(userID int64) error {
+	if allowed, retry := s.limiter.Allow(userID); !allowed {
+		return &ChatLimitError{Reason: fmt.Sprintf("hourly limit of %d interview steps reached", s.limiter.Limit()), RetryAfter: retry}
+	}
+	return nil
+}
+
+// askedBefore lists the questions of the user's earlier sessions on the topic, so they are not repeated
+func (s *InterviewService) askedBefore(userID int64, topic string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var asked []string
+	for _, session := range s.history[userID] {
+		if session.Topic != topic {
+			continue
+		}
+		for _, turn := range session.Turns {
+			asked = append(asked, "- "+turn.Question)
+		}
+	}
+	if len(asked) == 0 {
+		return ""
+	}
+	return "Do not repeat these earlier questions:\n" + strings.Join(asked, "\n")
+}
+
+// openSession returns the user's session unless it expired; callers hold the mutex
+func (s *InterviewService) openSession(userID int64) *InterviewSession {
+	session := s.sessions[userID]
+	if session == nil {
+		return nil
+	}
+	if s.now().Sub(session.UpdatedAt) > interviewSessionTTL {
+		s.finish(userID)
+		return nil
+	}
+	return session
+}
+
+// finish moves the user's session to the history if it has answers; callers hold the mutex
+func (s *InterviewService) finish(userID int64) {
+	session := s.sessions[userID]
+	delete(s.sessions, userID)
+	if session == nil || len(session.Turns) == 0 {
+		return
+	}
+
+	finished := *session
+	finished.Question = ""
+	history := append(s.history[userID], finished)
+	if len(history) > interviewHistorySize {
+		history = history[len(history)-interviewHistorySize:]
+	}
+	s.history[userID] = history
+}
+
+// ParseInterviewEvaluation reads the interviewer's JSON verdict, tolerating code fences and
+// text around the JSON object
+func ParseInterviewEvaluation(response string) (*InterviewEvaluation, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON in interviewer response")
+	}
+
+	var evaluation InterviewEvaluation
+	if err := json.Unmarshal([]byte(response[start:end+1]), &evaluation); err != nil {
+		return nil, fmt.Errorf("failed to parse interviewer response: %w", err)
+	}
+	if evaluation.Score < 1 || evaluation.Score > 10 || strings.TrimSpace(evaluation.Feedback) == "" {
+		return nil, fmt.Errorf("interviewer response has no valid score or feedback")
+	}
+	evaluation.Feedback = strings.TrimSpace(evaluation.Feedback)
+	evaluation.NextQuestion = strings.TrimSpace(evaluation.NextQuestion)
+	return &evaluation, nil
+}
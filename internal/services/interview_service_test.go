@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// scriptedCompleter answers prompts with canned responses, in order
+type scriptedCompleter struct {
+	responses []string
+	prompts   []string
+}
+
+func (c *scriptedCompleter) IsConfigured() bool { return true }
+func (c *scriptedCompleter) Complete(ctx context.Context, prompt string) (string, error) {
+	c.prompts = append(c.prompts, prompt)
+	response := c.responses[0]
+	c.responses = c.responses[1:]
+	return response, nil
+}
+
+func TestParseInterviewEvaluation(t *testing.T) {
+	evaluation, err := ParseInterviewEvaluation("```json\n{\"score\": 7, \"feedback\": \" Good. \", \"next_question\": \"Why?\"}\n```")
+	if err != nil || evaluation.Score != 7 || evaluation.Feedback != "Good." || evaluation.NextQuestion != "Why?" {
+		t.Errorf("unexpected evaluation %+v, %v", evaluation, err)
+	}
+	for _, response := range []string{"Great answer!", `{"score": 11, "feedback": "x"}`, `{"score": 5, "feedback": ""}`} {
+		if _, err := ParseInterviewEvaluation(response); err == nil {
+			t.Errorf("expected %q to be rejected", response)
+		}
+	}
+}
+
+func TestInterviewSessionFlow(t *testing.T) {
+	ai := &scriptedCompleter{responses: []string{"What is a goroutine?"}}
+	for i := 1; i <= InterviewQuestions; i++ {
+		next := "Question " + string(rune('A'+i))
+		if i == InterviewQuestions {
+			next = ""
+		}
+		ai.responses = append(ai.responses, `{"score": 8, "feedback": "Solid.", "next_question": "`+next+`"}`)
+	}
+	service := NewInterviewService(ai, silentLogger{})
+
+	if _, err := service.Start(context.Background(), 1, "kotlin"); err == nil {
+		t.Fatal("expected an unknown topic to be rejected")
+	}
+	question, err := service.Start(context.Background(), 1, "concurrency")
+	if err != nil || question != "What is a goroutine?" || !service.Active(1) {
+		t.Fatalf("expected an open session, got %q, %v", question, err)
+	}
+
+	var session *InterviewSession
+	for i := 0; i < InterviewQuestions; i++ {
+		_, session, err = service.Answer(context.Background(), 1, "A lightweight thread")
+		if err != nil {
+			t.Fatalf("answer %d: %v", i+1, err)
+		}
+	}
+	if !session.Finished() || session.AverageScore() != 8 || service.Active(1) {
+		t.Errorf("expected a finished session, got %+v", session)
+	}
+	if !strings.Contains(ai.prompts[2], "What is a goroutine?") {
+		t.Errorf("expected later prompts to list the previous questions")
+	}
+
+	history := service.History(1)
+	if len(history) != 1 || history[0].Topic != "concurrency" || len(history[0].Turns) != InterviewQuestions {
+		t.Errorf("expected the session in the history, got %+v", history)
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Job queue defaults, overridable with JOB_WORKERS and JOB_QUEUE_SIZE
+const (
+	defaultJobWorkers   = 2
+	defaultJobQueueSize = 20
+)
+
+// Enqueue errors
+var (
+	ErrJobQueueFull   = errors.New("the job queue is full")
+	ErrJobQueueClosed = errors.New("the bot is shutting down")
+)
+
+// Job is a unit of background work; it reports its own progress and results
+type Job func(ctx context.Context)
+
+type queuedJob struct {
+	name string
+	run  Job
+}
+
+// JobQueue runs slow work, such as batch analyses, on a fixed number of background
+// workers, so a command can answer at once and a burst of requests does not start
+// unbounded AI calls
+type JobQueue struct {
+	jobs chan queuedJob
+	// pending counts the queued and running jobs
+	pending atomic.Int64
+	// closed refuses new jobs once the bot shuts down
+	closed atomic.Bool
+	logger domain.Logger
+}
+
+// NewJobQueue creates a queue and starts its JOB_WORKERS workers
+func NewJobQueue(logger domain.Logger) *JobQueue {
+	queue := &JobQueue{
+		jobs:   make(chan queuedJob, envInt("JOB_QUEUE_SIZE", defaultJobQueueSize)),
+		logger: logger,
+	}
+	for i := 0; i < envInt("JOB_WORKERS", defaultJobWorkers); i++ {
+		go queue.work()
+	}
+	return queue
+}
+
+// Enqueue adds a job and returns how many jobs are ahead of it
+func (q *JobQueue) Enqueue(name string, run Job) (int, error) {
+	if q.closed.Load() {
+		return 0, ErrJobQueueClosed
+	}
+	ahead := int(q.pending.Add(1)) - 1
+	select {
+	case q.jobs <- queuedJob{name: name, run: run}:
+		q.logger.Info("Job queued", "job", name, "ahead", ahead)
+		return ahead, nil
+	default:
+		q.pending.Add(-1)
+		return 0, ErrJobQueueFull
+	}
+}
+
+// Pending returns the number of queued and running jobs
+func (q *JobQueue) Pending() int {
+	return int(q.pending.Load())
+}
+
+// Drain refuses new jobs and waits until the queued and running ones finish, or the
+// context ends
+func (q *JobQueue) Drain(ctx context.Context) error {
+	q.closed.Store(true)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for q.Pending() > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d jobs unfinished: %w", q.Pending(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// work runs jobs as they are queued
+func (q *JobQueue) work() {
+	for job := range q.jobs {
+		q.run(job)
+	}
+}
+
+// run executes one job, keeping a panicking job from taking its worker down
+func (q *JobQueue) run(job queuedJob) {
+	started := time.Now()
+	defer func() {
+		q.pending.Add(-1)
+		if recovered := recover(); recovered != nil {
+			q.logger.Error("Job panicked", "job", job.name, "panic", recovered)
+			return
+		}
+		q.logger.Info("Job finished", "job", job.name, "duration", time.Since(started))
+	}()
+	job.run(context.Background())
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJobQueueRunsJobsAndRefusesOverflow(t *testing.T) {
+	t.Setenv("JOB_WORKERS", "1")
+	t.Setenv("JOB_QUEUE_SIZE", "1")
+	queue := NewJobQueue(silentLogger{})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	if _, err := queue.Enqueue("blocking", func(ctx context.Context) {
+		close(started)
+		<-release
+	}); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	<-started
+
+	finished := make(chan struct{})
+	ahead, err := queue.Enqueue("waiting", func(ctx context.Context) {
+		panic("a failing job must not stop the worker")
+	})
+	if err != nil || ahead != 1 {
+		t.Fatalf("Enqueue() = %d, %v, want one job ahead", ahead, err)
+	}
+	if _, err := queue.Enqueue("overflow", func(ctx context.Context) {}); !errors.Is(err, ErrJobQueueFull) {
+		t.Fatalf("expected ErrJobQueueFull, got %v", err)
+	}
+
+	close(release)
+	if _, err := enqueueEventually(queue, func(ctx context.Context) { close(finished) }); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the worker stopped after a panicking job")
+	}
+}
+
+// enqueueEventually retries while the queue drains
+func enqueueEventually(queue *JobQueue, run Job) (int, error) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		ahead, err := queue.Enqueue("last", run)
+		if !errors.Is(err, ErrJobQueueFull) || time.Now().After(deadline) {
+			return ahead, err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestJobQueueDrainWaitsAndRefusesNewJobs(t *testing.T) {
+	t.Setenv("JOB_WORKERS", "1")
+	queue := NewJobQueue(silentLogger{})
+
+	finished := make(chan struct{})
+	if _, err := queue.Enqueue("slow", func(ctx context.Context) {
+		time.Sleep(20 * time.Millisecond)
+		close(finished)
+	}); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := queue.Drain(ctx); err != nil {
+		t.Fatalf("Drain() error = %v", err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Error("Drain() returned before the job finished")
+	}
+	if _, err := queue.Enqueue("late", func(ctx context.Context) {}); !errors.Is(err, ErrJobQueueClosed) {
+		t.Errorf("expected ErrJobQueueClosed after Drain, got %v", err)
+	}
+}
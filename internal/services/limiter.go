@@ -0,0 +1,93 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// SlidingWindowLimiter allows up to max events per key within a sliding window
+type SlidingWindowLimiter struct {
+	events map[int64][]time.Time
+	max    int
+	window time.Duration
+	mutex  sync.Mutex
+}
+
+// NewSlidingWindowLimiter creates a limiter allowing max events per window
+func NewSlidingWindowLimiter(max int, window time.Duration) *SlidingWindowLimiter {
+	return &SlidingWindowLimiter{
+		events: make(map[int64][]time.Time),
+		max:    max,
+		window: window,
+	}
+}
+
+// Allow records an event for the key if the limit allows it. When the limit is
+// reached it returns false and the time until the next event is allowed.
+func (l *SlidingWindowLimiter) Allow(key int64) (bool, time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	events := l.prune(key, now)
+	if len(events) >= l.max {
+		return false, events[0].Add(l.window).Sub(now)
+	}
+
+	l.events[key] = append(events, now)
+	return true, 0
+}
+
+// Remaining returns how many events are left for the key and when the oldest one expires
+func (l *SlidingWindowLimiter) Remaining(key int64) (int, time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	events := l.prune(key, now)
+	if len(events) == 0 {
+		return l.max, now
+	}
+	return l.max - len(events), events[0].Add(l.window)
+}
+
+// Limit returns the maximum number of events per window
+func (l *SlidingWindowLimiter) Limit() int {
+	return l.max
+}
+
+// Window returns the limiter window
+func (l *SlidingWindowLimiter) Window() time.Duration {
+	return l.window
+}
+
+// Cleanup removes keys without events in the current window
+func (l *SlidingWindowLimiter) Cleanup() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	for key := range l.events {
+		l.prune(key, now)
+	}
+}
+
+// prune drops expired events of a key; the caller must hold the mutex
+func (l *SlidingWindowLimiter) prune(key int64, now time.Time) []time.Time {
+	cutoff := now.Add(-l.window)
+	events := l.events[key]
+
+	valid := events[:0]
+	for _, event := range events {
+		if event.After(cutoff) {
+			valid = append(valid, event)
+		}
+	}
+
+	if len(valid) == 0 {
+		delete(l.events, key)
+		return nil
+	}
+	l.events[key] = valid
+	return valid
+}
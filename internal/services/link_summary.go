@@ -0,0 +1,499 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Link summary defaults; LINK_SUMMARY_HOURLY_LIMIT overrides the summaries per chat and hour
+const (
+	defaultLinkSummaryHourlyLimit = 20
+	maxLinkPageBytes              = 2 << 20
+	maxLinkTextChars              = 12000
+	linkWordsPerMinute            = 200
+	// minLinkBlockWords drops menus, buttons and other short page chrome
+	minLinkBlockWords = 4
+)
+
+// Team settings of link summaries. LinkAutoKey is "on" when shared links are summarized
+// without a command; the lists hold comma-separated domains.
+const (
+	LinkAutoKey  = "link_auto"
+	LinkAllowKey = "link_allow"
+	LinkDenyKey  = "link_deny"
+)
+
+// linkPattern finds http and https URLs in a message
+var linkPattern = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// LinkSettingsStore reads and writes the team settings holding the link options
+type LinkSettingsStore interface {
+	GetTeamSettings(teamID string) (map[string]string, error)
+	SetTeamSetting(teamID, key, value string) error
+}
+
+// LinkSettings are a chat's link summary options. With an allow list only its domains are
+// summarized; the deny list always wins. Subdomains match their domain.
+type LinkSettings struct {
+	Auto  bool
+	Allow []string
+	Deny  []string
+}
+
+// LinkSummary is the summary of a shared page
+type LinkSummary struct {
+	URL            string
+	Title          string
+	Bullets        []string
+	Words          int
+	ReadingMinutes int
+}
+
+// LinkSummaryService fetches shared articles, extracts their readable text and summarizes
+// it in three bullets with the AI chain
+type LinkSummaryService struct {
+	http    *HTTPClient
+	ai      TextCompleter
+	store   LinkSettingsStore
+	limiter *SlidingWindowLimiter
+	logger  domain.Logger
+}
+
+// NewLinkSummaryService creates a new link summary service. Pages are fetched with the given
+// client, which should be a NewPublicHTTPClient so links cannot reach internal hosts.
+func NewLinkSummaryService(httpClient *HTTPClient, ai TextCompleter, store LinkSettingsStore, logger domain.Logger) *LinkSummaryService {
+	return &LinkSummaryService{
+		http:    httpClient,
+		ai:      ai,
+		store:   store,
+		limiter: NewSlidingWindowLimiter(envInt("LINK_SUMMARY_HOURLY_LIMIT", defaultLinkSummaryHourlyLimit), time.Hour),
+		logger:  logger,
+	}
+}
+
+// IsConfigured returns true if an AI provider is available
+func (s *LinkSummaryService) IsConfigured() bool {
+	return s.ai.IsConfigured()
+}
+
+// Limiter returns the per-chat hourly limiter
+func (s *LinkSummaryService) Limiter() *SlidingWindowLimiter {
+	return s.limiter
+}
+
+// Settings returns the chat's link summary options
+func (s *LinkSummaryService) Settings(chatID int64) (LinkSettings, error) {
+	settings, err := s.store.GetTeamSettings(linkTeamID(chatID))
+	if err != nil {
+		return LinkSettings{}, err
+	}
+	return LinkSettings{
+		Auto:  settings[LinkAutoKey] == "on",
+		Allow: splitDomains(settings[LinkAllowKey]),
+		Deny:  splitDomains(settings[LinkDenyKey]),
+	}, nil
+}
+
+// SetAuto turns automatic summaries of shared links on or off
+func (s *LinkSummaryService) SetAuto(chatID int64, enabled bool) error {
+	value := ""
+	if enabled {
+		value = "on"
+	}
+	return s.store.SetTeamSetting(linkTeamID(chatID), LinkAutoKey, value)
+}
+
+// AddDomain puts a domain on the allow or deny list, taking it off the other one
+func (s *LinkSummaryService) AddDomain(chatID int64, domainName string, allow bool) error {
+	domainName = normalizeDomain(domainName)
+	if !strings.Contains(domainName, ".") {
+		return fmt.Errorf("invalid domain %q", domainName)
+	}
+	settings, err := s.Settings(chatID)
+	if err != nil {
+		return err
+	}
+
+	add, remove := &settings.Allow, &settings.Deny
+	if !allow {
+		add, remove = remove, add
+	}
+	*remove = withoutDomain(*remove, domainName)
+	*add = append(withoutDomain(*add, domainName), domainName)
+	return s.saveLists(chatID, settings)
+}
+
+// RemoveDomain takes a domain off both lists
+func (s *LinkSummaryService) RemoveDomain(chatID int64, domainName string) error {
+	settings, err := s.Settings(chatID)
+	if err != nil {
+		return err
+	}
+	domainName = normalizeDomain(domainName)
+	settings.Allow = withoutDomain(settings.Allow, domainName)
+	settings.Deny = withoutDomain(settings.Deny, domainName)
+	return s.saveLists(chatID, settings)
+}
+
+// saveLists stores both domain lists of the chat
+func (s *LinkSummaryService) saveLists(chatID int64, settings LinkSettings) error {
+	teamID := linkTeamID(chatID)
+	if err := s.store.SetTeamSetting(teamID, LinkAllowKey, strings.Join(settings.Allow, ",")); err != nil {
+		return err
+	}
+	return s.store.SetTeamSetting(teamID, LinkDenyKey, strings.Join(settings.Deny, ","))
+}
+
+// AutoLink returns the first link of a plain message that should be summarized without a
+// command, or "" when the chat has not opted in or the link's domain is not allowed
+func (s *LinkSummaryService) AutoLink(chatID int64, text string) string {
+	link := FindLink(text)
+	if link == "" {
+		return ""
+	}
+	settings, err := s.Settings(chatID)
+	if err != nil {
+		s.logger.Warn("Failed to load link settings", "chat_id", chatID, "error", err)
+		return ""
+	}
+	if !settings.Auto {
+		return ""
+	}
+	if _, err := CheckLink(link, settings); err != nil {
+		return ""
+	}
+	return link
+}
+
+// Summarize fetches the page behind a link and summarizes it for the chat
+func (s *LinkSummaryService) Summarize(ctx context.Context, chatID int64, link string) (*LinkSummary, error) {
+	settings, err := s.Settings(chatID)
+	if err != nil {
+		return nil, err
+	}
+	pageURL, err := CheckLink(link, settings)
+	if err != nil {
+		return nil, err
+	}
+	if allowed, retry := s.limiter.Allow(chatID); !allowed {
+		return nil, &ChatLimitError{Reason: fmt.Sprintf("hourly limit of %d link summaries reached", s.limiter.Limit()), RetryAfter: retry}
+	}
+
+	title, text, err := s.fetchReadable(ctx, pageURL.String())
+	if err != nil {
+		return nil, err
+	}
+	words := len(strings.Fields(text))
+	if words < minLinkBlockWords*5 {
+		return nil, fmt.Errorf("the page has no readable article text")
+	}
+	if len(text) > maxLinkTextChars {
+		text = strings.ToValidUTF8(text[:maxLinkTextChars], "")
+	}
+
+	prompt := fmt.Sprintf(`Summarize this article for a software development team in exactly 3 bullet points.
+Reply in the language the article is written in. Each bullet is one sentence under 30 words with the key facts, not an introduction.
+Respond with the 3 bullets only, each on its own line starting with "- ".
+
+Title: %s
+
+Article:
+%s`, title, text)
+
+	response, err := s.ai.Complete(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	bullets := ParseSummaryBullets(response)
+	if len(bullets) == 0 {
+		return nil, fmt.Errorf("AI response has no bullet points")
+	}
+
+	s.logger.Info("Link summarized", "chat_id", chatID, "host", pageURL.Host, "words", words)
+	return &LinkSummary{
+		URL:            pageURL.String(),
+		Title:          title,
+		Bullets:        bullets,
+		Words:          words,
+		ReadingMinutes: ReadingMinutes(words),
+	}, nil
+}
+
+// fetchReadable downloads a page and returns its title and readable text
+func (s *LinkSummaryService) fetchReadable(ctx context.Context, pageURL string) (string, string, error) {
+	resp, err := s.http.GetWithLimit(ctx, pageURL, map[string]string{"Accept": "text/html,text/plain"}, maxLinkPageBytes)
+	if err != nil {
+		return "", "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("the page returned HTTP %d", resp.StatusCode)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Headers.Get("Content-Type"))
+	switch mediaType {
+	case "text/html", "application/xhtml+xml", "":
+		title, text := ExtractReadableText(string(resp.Body))
+		return title, text, nil
+	case "text/plain", "text/markdown":
+		return "", string(resp.Body), nil
+	default:
+		return "", "", fmt.Errorf("unsupported content type %s", mediaType)
+	}
+}
+
+// FindLink returns the first http or https link of a text, without trailing punctuation
+func FindLink(text string) string {
+	return strings.TrimRight(linkPattern.FindString(text), ".,;:!?)]}'")
+}
+
+// CheckLink parses a link and applies the chat's domain lists
+func CheckLink(link string, settings LinkSettings) (*url.URL, error) {
+	pageURL, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || (pageURL.Scheme != "http" && pageURL.Scheme != "https") || pageURL.Hostname() == "" {
+		return nil, fmt.Errorf("not a valid http or https link")
+	}
+	host := normalizeDomain(pageURL.Hostname())
+	if matchesDomain(host, settings.Deny) {
+		return nil, fmt.Errorf("%s is on this chat's deny list", host)
+	}
+	if len(settings.Allow) > 0 && !matchesDomain(host, settings.Allow) {
+		return nil, fmt.Errorf("%s is not on this chat's allow list", host)
+	}
+	return pageURL, nil
+}
+
+// ReadingMinutes estimates the reading time of a text, at least one minute
+func ReadingMinutes(words int) int {
+	if words <= linkWordsPerMinute {
+		return 1
+	}
+	return (words + linkWordsPerMinute - 1) / linkWordsPerMinute
+}
+
+// bulletPattern matches a "-", "*", "•" or numbered list item
+var bulletPattern = regexp.MustCompile(`^(?:[-*•]|\d+[.)])\s+(.+)$`)
+
+// ParseSummaryBullets returns up to three bullet points of an AI response
+func ParseSummaryBullets(response string) []string {
+	var bullets []string
+	for _, line := range strings.Split(response, "\n") {
+		match := bulletPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		bullets = append(bullets, strings.TrimSpace(match[1]))
+		if len(bullets) == 3 {
+			break
+		}
+	}
+	return bullets
+}
+
+// skippedElements hold page chrome and code rather than article text
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "nav": true, "header": true, "footer": true,
+	"aside": true, "form": true, "svg": true, "iframe": true, "button": true, "template": true,
+}
+
+// blockElements end a block of text
+var blockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "main": true, "li": true, "pre": true,
+	"blockquote": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"br": true, "tr": true, "td": true, "th": true, "dd": true, "dt": true, "figcaption": true,
+}
+
+// ExtractReadableText returns the title of an HTML page and the text of its article: the
+// <article> or <main> element when there is one, otherwise the body without navigation,
+// scripts and other chrome. Blocks are separated by blank lines.
+func ExtractReadableText(page string) (string, string) {
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		return "", ""
+	}
+
+	var title, ogTitle string
+	var article, mainElement, body *html.Node
+	var find func(*html.Node)
+	find = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "title":
+				if title == "" && n.FirstChild != nil {
+					title = strings.TrimSpace(n.FirstChild.Data)
+				}
+			case "meta":
+				if attribute(n, "property") == "og:title" {
+					ogTitle = strings.TrimSpace(attribute(n, "content"))
+				}
+			case "article":
+				if article == nil {
+					article = n
+				}
+			case "main":
+				if mainElement == nil {
+					mainElement = n
+				}
+			case "body":
+				body = n
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			find(child)
+		}
+	}
+	find(doc)
+	if ogTitle != "" {
+		title = ogTitle
+	}
+
+	root := body
+	if mainElement != nil {
+		root = mainElement
+	}
+	if article != nil {
+		root = article
+	}
+	if root == nil {
+		return title, ""
+	}
+
+	var blocks []string
+	var current strings.Builder
+	endBlock := func(heading bool) {
+		block := strings.Join(strings.Fields(current.String()), " ")
+		current.Reset()
+		if block != "" && (heading || len(strings.Fields(block)) >= minLinkBlockWords) {
+			blocks = append(blocks, block)
+		}
+	}
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			current.WriteString(n.Data + " ")
+			return
+		case html.ElementNode:
+			if skippedElements[n.Data] {
+				return
+			}
+		}
+		block := n.Type == html.ElementNode && blockElements[n.Data]
+		if block {
+			endBlock(false)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if block {
+			endBlock(len(n.Data) == 2 && n.Data[0] == 'h' && n.Data[1] >= '1' && n.Data[1] <= '6')
+		}
+	}
+	walk(root)
+	endBlock(false)
+
+	return html.UnescapeString(title), strings.Join(blocks, "\n\n")
+}
+
+// attribute returns the value of an HTML attribute, "" when missing
+func attribute(n *html.Node, name string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// NewPublicHTTPClient creates an HTTP client that only connects to public addresses, for
+// fetching URLs chat members share: loopback, private and link-local hosts are refused,
+// redirects included, so a link cannot reach the bot's own network
+func NewPublicHTTPClient(timeout time.Duration, logger Logger) *HTTPClient {
+	client := NewHTTPClient(timeout, logger)
+	client.client.Transport = newPublicTransport()
+	return client
+}
+
+// newPublicTransport returns a transport whose dialer refuses non-public addresses. The
+// check runs on the resolved address of every connection, so hostnames pointing inside
+// and redirects to internal hosts are caught as well.
+func newPublicTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+				ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+				return fmt.Errorf("refusing to connect to non-public address %s", host)
+			}
+			return nil
+		},
+	}
+	return &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+}
+
+// linkTeamID returns the team whose settings hold a chat's link options
+func linkTeamID(chatID int64) string {
+	return fmt.Sprintf("team_%d", chatID)
+}
+
+// normalizeDomain lowercases a domain and drops a scheme, "www." and a trailing dot or path
+func normalizeDomain(domainName string) string {
+	domainName = strings.ToLower(strings.TrimSpace(domainName))
+	domainName = strings.TrimPrefix(strings.TrimPrefix(domainName, "https://"), "http://")
+	if slash := strings.IndexByte(domainName, '/'); slash >= 0 {
+		domainName = domainName[:slash]
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(domainName, "www."), ".")
+}
+
+// splitDomains parses a comma-separated domain list
+func splitDomains(value string) []string {
+	var domains []string
+	for _, domainName := range strings.Split(value, ",") {
+		if domainName = normalizeDomain(domainName); domainName != "" {
+			domains = append(domains, domainName)
+		}
+	}
+	return domains
+}
+
+// withoutDomain returns the list without the domain
+func withoutDomain(domains []string, domainName string) []string {
+	var result []string
+	for _, existing := range domains {
+		if existing != domainName {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
+
+// matchesDomain reports whether a host is one of the domains or a subdomain of one
+func matchesDomain(host string, domains []string) bool {
+	for _, domainName := range domains {
+		if host == domainName || strings.HasSuffix(host, "."+domainName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func (silentLogger) Printf(string, ...interface{}) {}
+func (silentLogger) Println(...interface{})        {}
+
+type memorySettingsStore map[string]map[string]string
+
+func (m memorySettingsStore) GetTeamSettings(teamID string) (map[string]string, error) {
+	return m[teamID], nil
+}
+
+func (m memorySettingsStore) SetTeamSetting(teamID, key, value string) error {
+	if m[teamID] == nil {
+		m[teamID] = make(map[string]string)
+	}
+	m[teamID][key] = value
+	return nil
+}
+
+const articlePage = `<html><head><title>Old title</title><meta property="og:title" content="Go 1.25 &amp; you"></head>
+<body><nav>Home About Blog Careers Contact</nav>
+<article><h1>Release</h1><p>Go 1.25 brings a container-aware GOMAXPROCS that respects cgroup limits.</p>
+<script>track()</script><p>Share</p>
+<p>The new experimental garbage collector reduces pause times for programs with many small objects.</p></article>
+<footer>Copyright notice for the whole site</footer></body></html>`
+
+func TestExtractReadableText(t *testing.T) {
+	title, text := ExtractReadableText(articlePage)
+	if title != "Go 1.25 & you" {
+		t.Errorf("expected the og:title, got %q", title)
+	}
+	want := "Release\n\nGo 1.25 brings a container-aware GOMAXPROCS that respects cgroup limits.\n\n" +
+		"The new experimental garbage collector reduces pause times for programs with many small objects."
+	if text != want {
+		t.Errorf("unexpected article text:\n%s", text)
+	}
+}
+
+func TestCheckLink(t *testing.T) {
+	settings := LinkSettings{Allow: []string{"go.dev", "github.com"}, Deny: []string{"gist.github.com"}}
+	for link, ok := range map[string]bool{
+		"https://go.dev/blog/go1.25":          true,
+		"https://www.go.dev/doc":              true,
+		"https://github.com/golang/go":        true,
+		"https://gist.github.com/someone/123": false,
+		"https://example.com/post":            false,
+		"ftp://go.dev/file":                   false,
+	} {
+		if _, err := CheckLink(link, settings); (err == nil) != ok {
+			t.Errorf("CheckLink(%s) error = %v, want allowed %v", link, err, ok)
+		}
+	}
+	if _, err := CheckLink("https://example.com", LinkSettings{}); err != nil {
+		t.Errorf("expected every domain allowed without lists, got %v", err)
+	}
+}
+
+func TestParseSummaryBullets(t *testing.T) {
+	bullets := ParseSummaryBullets("Here you go:\n- 3 new features ship\n* GC is faster\n1. Upgrade soon\n- ignored fourth")
+	if len(bullets) != 3 || bullets[0] != "3 new features ship" || bullets[2] != "Upgrade soon" {
+		t.Errorf("unexpected bullets %q", bullets)
+	}
+	if ReadingMinutes(0) != 1 || ReadingMinutes(401) != 3 {
+		t.Errorf("unexpected reading times %d, %d", ReadingMinutes(0), ReadingMinutes(401))
+	}
+}
+
+func TestLinkSummaryService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(articlePage))
+	}))
+	defer server.Close()
+
+	store := memorySettingsStore{}
+	ai := &fakeCompleter{configured: true, response: "- GOMAXPROCS respects cgroups\n- GC pauses shrink\n- Upgrade soon"}
+	links := NewLinkSummaryService(NewHTTPClient(5*time.Second, silentLogger{}), ai, store, silentLogger{})
+
+	summary, err := links.Summarize(context.Background(), 1, server.URL+"/blog")
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	if summary.Title != "Go 1.25 & you" || len(summary.Bullets) != 3 || summary.ReadingMinutes != 1 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	// Automatic summaries need the chat's opt-in and respect the deny list
+	message := "Worth a read: " + server.URL + "/blog."
+	if link := links.AutoLink(1, message); link != "" {
+		t.Errorf("expected no automatic summary before opting in, got %s", link)
+	}
+	links.SetAuto(1, true)
+	if link := links.AutoLink(1, message); link != server.URL+"/blog" {
+		t.Errorf("expected the shared link without the trailing dot, got %q", link)
+	}
+	if err := links.AddDomain(1, "127.0.0.1", false); err != nil {
+		t.Fatalf("AddDomain failed: %v", err)
+	}
+	if link := links.AutoLink(1, message); link != "" {
+		t.Errorf("expected a denied domain to be skipped, got %s", link)
+	}
+	if _, err := links.Summarize(context.Background(), 1, server.URL); err == nil || !strings.Contains(err.Error(), "deny list") {
+		t.Errorf("expected the deny list to apply, got %v", err)
+	}
+}
+
+func TestPublicHTTPClientRefusesLocalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := NewPublicHTTPClient(5*time.Second, silentLogger{})
+	if _, err := client.Get(context.Background(), server.URL, nil); err == nil || !strings.Contains(err.Error(), "non-public") {
+		t.Errorf("expected a loopback address to be refused, got %v", err)
+	}
+}
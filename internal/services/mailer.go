@@ -0,0 +1,119 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Email is an HTML message sent by the mailer
+type Email struct {
+	To      string
+	Subject string
+	HTML    string
+	// UnsubscribeURL is advertised in the List-Unsubscribe headers when set
+	UnsubscribeURL string
+}
+
+// Mailer sends emails over SMTP. It is disabled when SMTP_HOST is not configured.
+type Mailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+	logger   domain.Logger
+}
+
+// NewMailer creates a mailer from the SMTP_* environment variables
+func NewMailer(logger domain.Logger) *Mailer {
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+
+	return &Mailer{
+		host:     os.Getenv("SMTP_HOST"),
+		port:     port,
+		username: os.Getenv("SMTP_USERNAME"),
+		password: os.Getenv("SMTP_PASSWORD"),
+		from:     os.Getenv("SMTP_FROM"),
+		logger:   logger,
+	}
+}
+
+// Enabled reports whether SMTP is configured
+func (m *Mailer) Enabled() bool {
+	return m.host != "" && m.from != ""
+}
+
+// Send delivers an email
+func (m *Mailer) Send(email Email) error {
+	if !m.Enabled() {
+		return fmt.Errorf("email is not configured")
+	}
+	if err := ValidateEmail(email.To); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	// SMTP_FROM may include a display name, the envelope needs the bare address
+	envelopeFrom := m.from
+	if parsed, err := mail.ParseAddress(m.from); err == nil {
+		envelopeFrom = parsed.Address
+	}
+
+	if err := smtp.SendMail(net.JoinHostPort(m.host, m.port), auth, envelopeFrom, []string{email.To}, m.buildMessage(email)); err != nil {
+		return fmt.Errorf("email yuborishda xatolik: %w", err)
+	}
+
+	m.logger.Info("Email sent", "subject", email.Subject)
+	return nil
+}
+
+// buildMessage renders the MIME message with headers
+func (m *Mailer) buildMessage(email Email) []byte {
+	var msg bytes.Buffer
+	headers := [][2]string{
+		{"From", m.from},
+		{"To", email.To},
+		{"Subject", mime.QEncoding.Encode("utf-8", email.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/html; charset=utf-8"},
+		{"Content-Transfer-Encoding", "8bit"},
+	}
+	if email.UnsubscribeURL != "" {
+		headers = append(headers,
+			[2]string{"List-Unsubscribe", "<" + email.UnsubscribeURL + ">"},
+			[2]string{"List-Unsubscribe-Post", "List-Unsubscribe=One-Click"})
+	}
+
+	for _, header := range headers {
+		msg.WriteString(header[0] + ": " + header[1] + "\r\n")
+	}
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(email.HTML, "\n", "\r\n"))
+	return msg.Bytes()
+}
+
+// ValidateEmail checks that an address is a plain, single email address
+func ValidateEmail(address string) error {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Address != address || strings.ContainsAny(address, "\r\n") {
+		return fmt.Errorf("invalid email address")
+	}
+	return nil
+}
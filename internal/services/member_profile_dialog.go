@@ -0,0 +1,118 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memberProfileTTL is how long an imported member can answer the profile question
+const memberProfileTTL = 7 * 24 * time.Hour
+
+// maxMemberCapacity is the most weekly hours accepted as a member's capacity
+const maxMemberCapacity = 80
+
+// MemberProfileRequest asks a member added to a team to fill in their skills and capacity
+type MemberProfileRequest struct {
+	TeamID    string
+	MemberID  string
+	TeamName  string
+	CreatedAt time.Time
+}
+
+// MemberProfileService keeps the profile questions sent to imported members, answered in
+// the private chat with the bot. Requests are held in memory, oldest first per user.
+type MemberProfileService struct {
+	requests map[int64][]MemberProfileRequest
+	mutex    sync.Mutex
+	now      func() time.Time
+}
+
+// NewMemberProfileService creates a new member profile service
+func NewMemberProfileService() *MemberProfileService {
+	return &MemberProfileService{
+		requests: make(map[int64][]MemberProfileRequest),
+		now:      time.Now,
+	}
+}
+
+// Request queues a profile question for the user
+func (s *MemberProfileService) Request(userID int64, request MemberProfileRequest) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	request.CreatedAt = s.now()
+	s.requests[userID] = append(s.pruned(userID), request)
+}
+
+// Pending returns the user's oldest unanswered profile question, or nil when there is none
+func (s *MemberProfileService) Pending(userID int64) *MemberProfileRequest {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	requests := s.pruned(userID)
+	if len(requests) == 0 {
+		return nil
+	}
+	request := requests[0]
+	return &request
+}
+
+// Finish removes the user's oldest profile question and returns how many are left
+func (s *MemberProfileService) Finish(userID int64) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	requests := s.pruned(userID)
+	if len(requests) > 0 {
+		requests = requests[1:]
+	}
+	if len(requests) == 0 {
+		delete(s.requests, userID)
+	} else {
+		s.requests[userID] = requests
+	}
+	return len(requests)
+}
+
+// pruned drops the user's expired requests; callers hold the mutex
+func (s *MemberProfileService) pruned(userID int64) []MemberProfileRequest {
+	requests := s.requests[userID][:0:0]
+	for _, request := range s.requests[userID] {
+		if s.now().Sub(request.CreatedAt) <= memberProfileTTL {
+			requests = append(requests, request)
+		}
+	}
+	if len(requests) == 0 {
+		delete(s.requests, userID)
+		return nil
+	}
+	s.requests[userID] = requests
+	return requests
+}
+
+// ParseMemberProfile reads skills and an optional weekly capacity from an answer such as
+// "go, react, docker 30h". Capacity is 0 when the answer has none.
+func ParseMemberProfile(text string) ([]string, float64, error) {
+	var skills []string
+	var capacity float64
+	for _, field := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	}) {
+		hours := strings.TrimSuffix(strings.TrimSuffix(field, "h/week"), "h")
+		if value, err := strconv.ParseFloat(hours, 64); err == nil {
+			if value <= 0 || value > maxMemberCapacity {
+				return nil, 0, fmt.Errorf("capacity must be between 1 and %d hours a week", maxMemberCapacity)
+			}
+			capacity = value
+			continue
+		}
+		skills = append(skills, field)
+	}
+	if len(skills) == 0 {
+		return nil, 0, fmt.Errorf("no skills given")
+	}
+	return skills, capacity, nil
+}
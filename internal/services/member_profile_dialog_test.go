@@ -0,0 +1,48 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMemberProfile(t *testing.T) {
+	skills, capacity, err := ParseMemberProfile("Go, React docker 30h")
+	if err != nil || strings.Join(skills, ",") != "go,react,docker" || capacity != 30 {
+		t.Errorf("unexpected profile %v, %v, %v", skills, capacity, err)
+	}
+
+	if _, capacity, err := ParseMemberProfile("python"); err != nil || capacity != 0 {
+		t.Errorf("expected no capacity without hours, got %v, %v", capacity, err)
+	}
+	if _, _, err := ParseMemberProfile("30h"); err == nil {
+		t.Error("expected an error without skills")
+	}
+	if _, _, err := ParseMemberProfile("go 200h"); err == nil {
+		t.Error("expected an error for an impossible capacity")
+	}
+}
+
+func TestMemberProfileServiceQueue(t *testing.T) {
+	service := NewMemberProfileService()
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	service.Request(7, MemberProfileRequest{TeamID: "team_1", MemberID: "m1"})
+	service.Request(7, MemberProfileRequest{TeamID: "team_2", MemberID: "m2"})
+
+	if request := service.Pending(7); request == nil || request.MemberID != "m1" {
+		t.Fatalf("expected the oldest request first, got %+v", request)
+	}
+	if remaining := service.Finish(7); remaining != 1 {
+		t.Errorf("expected one request left, got %d", remaining)
+	}
+	if request := service.Pending(7); request == nil || request.MemberID != "m2" {
+		t.Errorf("expected the next request, got %+v", request)
+	}
+
+	now = now.Add(memberProfileTTL + time.Minute)
+	if request := service.Pending(7); request != nil {
+		t.Errorf("expected expired requests to be dropped, got %+v", request)
+	}
+}
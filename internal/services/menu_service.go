@@ -0,0 +1,187 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Chat setting keys of the quick action menu
+const (
+	menuEnabledKey = "menu"
+	menuButtonsKey = "menu_buttons"
+)
+
+// maxMenuButtons limits the size of the quick action keyboard
+const maxMenuButtons = 8
+
+// menuButtonsPerRow is the number of buttons on each keyboard row
+const menuButtonsPerRow = 2
+
+// DefaultMenuCommands are the quick actions shown when a chat has not picked its own
+var DefaultMenuCommands = []string{"/analyze", "/my_tasks", "/workload", "/health"}
+
+// menuLabels are the button labels of well-known commands; other commands are shown as-is
+var menuLabels = map[string]string{
+	"/analyze":       "🧠 Analyze",
+	"/my_tasks":      "🗂️ My Tasks",
+	"/workload":      "👥 Workload",
+	"/health":        "🚦 Health",
+	"/list_projects": "📁 Projects",
+	"/list_team":     "👤 Team",
+	"/velocity":      "🚀 Velocity",
+	"/calendar":      "📅 Calendar",
+	"/summarize":     "📝 Summary",
+	"/help":          "❓ Help",
+}
+
+// MenuConfig is the quick action menu of a chat
+type MenuConfig struct {
+	Enabled  bool
+	Commands []string
+}
+
+// MenuService manages the per-chat reply keyboard with quick actions
+type MenuService struct {
+	store  ChatSettingsStore
+	cache  map[int64]MenuConfig
+	mutex  sync.RWMutex
+	logger domain.Logger
+}
+
+// NewMenuService creates a new menu service
+func NewMenuService(store ChatSettingsStore, logger domain.Logger) *MenuService {
+	return &MenuService{
+		store:  store,
+		cache:  make(map[int64]MenuConfig),
+		logger: logger,
+	}
+}
+
+// MenuLabel returns the button label of a command
+func MenuLabel(command string) string {
+	if label, ok := menuLabels[command]; ok {
+		return label
+	}
+	return command
+}
+
+// GetConfig returns the chat's menu configuration, loading it from the store on first use
+func (s *MenuService) GetConfig(chatID int64) MenuConfig {
+	s.mutex.RLock()
+	cached, found := s.cache[chatID]
+	s.mutex.RUnlock()
+	if found {
+		return cached
+	}
+
+	config := MenuConfig{Commands: DefaultMenuCommands}
+	settings, err := s.store.GetChatSettings(chatID)
+	if err != nil {
+		s.logger.Warn("Failed to load chat menu", "chat_id", chatID, "error", err)
+		return config
+	}
+
+	config.Enabled = settings[menuEnabledKey] == "on"
+	if buttons := settings[menuButtonsKey]; buttons != "" {
+		config.Commands = strings.Split(buttons, ",")
+	}
+
+	s.mutex.Lock()
+	s.cache[chatID] = config
+	s.mutex.Unlock()
+
+	return config
+}
+
+// SetEnabled turns the chat's menu on or off
+func (s *MenuService) SetEnabled(chatID int64, enabled bool) error {
+	var err error
+	if enabled {
+		err = s.store.SetChatSetting(chatID, menuEnabledKey, "on")
+	} else {
+		err = s.store.DeleteChatSetting(chatID, menuEnabledKey)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.invalidate(chatID)
+	return nil
+}
+
+// SetCommands replaces the chat's quick actions; an empty list restores the defaults.
+// Checking that the commands exist is done by the caller, which knows the registered handlers.
+func (s *MenuService) SetCommands(chatID int64, commands []string) error {
+	if len(commands) == 0 {
+		if err := s.store.DeleteChatSetting(chatID, menuButtonsKey); err != nil {
+			return err
+		}
+		s.invalidate(chatID)
+		return nil
+	}
+
+	if len(commands) > maxMenuButtons {
+		return fmt.Errorf("a menu can have at most %d buttons", maxMenuButtons)
+	}
+	for _, command := range commands {
+		if !aliasPattern.MatchString(command) {
+			return fmt.Errorf("%s is not a command, e.g. `/analyze`", command)
+		}
+	}
+
+	if err := s.store.SetChatSetting(chatID, menuButtonsKey, strings.Join(commands, ",")); err != nil {
+		return err
+	}
+
+	s.invalidate(chatID)
+	return nil
+}
+
+// Keyboard builds the reply keyboard for a set of quick actions
+func (s *MenuService) Keyboard(commands []string) *domain.ReplyKeyboardMarkup {
+	keyboard := &domain.ReplyKeyboardMarkup{ResizeKeyboard: true, IsPersistent: true}
+	for i := 0; i < len(commands); i += menuButtonsPerRow {
+		end := i + menuButtonsPerRow
+		if end > len(commands) {
+			end = len(commands)
+		}
+
+		row := make([]domain.KeyboardButton, 0, menuButtonsPerRow)
+		for _, command := range commands[i:end] {
+			row = append(row, domain.KeyboardButton{Text: MenuLabel(command)})
+		}
+		keyboard.Keyboard = append(keyboard.Keyboard, row)
+	}
+	return keyboard
+}
+
+// ResolveButton maps the text of a pressed menu button back to its command.
+// Only chats with the menu turned on are matched, so ordinary messages stay untouched.
+func (s *MenuService) ResolveButton(chatID int64, text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.HasPrefix(text, "/") {
+		return "", false
+	}
+
+	config := s.GetConfig(chatID)
+	if !config.Enabled {
+		return "", false
+	}
+
+	for _, command := range config.Commands {
+		if MenuLabel(command) == text {
+			return command, true
+		}
+	}
+	return "", false
+}
+
+// invalidate drops the cached menu of a chat
+func (s *MenuService) invalidate(chatID int64) {
+	s.mutex.Lock()
+	delete(s.cache, chatID)
+	s.mutex.Unlock()
+}
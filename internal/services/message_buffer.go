@@ -0,0 +1,301 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Chat setting keys used by the chat history service
+const (
+	settingHistoryEnabled   = "history_enabled"
+	settingHistorySize      = "history_size"
+	settingHistoryRetention = "history_retention_hours"
+)
+
+// Message history limits
+const (
+	DefaultHistoryCapacity  = 200
+	MinHistorySize          = 10
+	MaxHistorySize          = 500
+	DefaultHistoryRetention = 24 * time.Hour
+	MaxHistoryRetention     = 7 * 24 * time.Hour
+)
+
+// BufferedMessage is a chat message kept in the rolling history buffer
+type BufferedMessage struct {
+	ChatID    int64
+	UserID    int64
+	Author    string
+	Text      string
+	Timestamp time.Time
+}
+
+// HistorySettings describes the message history configuration of a chat
+type HistorySettings struct {
+	Enabled   bool
+	Size      int
+	Retention time.Duration
+}
+
+// DefaultHistorySettings returns the settings used for chats that never configured history
+func DefaultHistorySettings() HistorySettings {
+	return HistorySettings{
+		Enabled:   false,
+		Size:      DefaultHistoryCapacity,
+		Retention: DefaultHistoryRetention,
+	}
+}
+
+// MessageBuffer keeps recent messages per chat in memory
+type MessageBuffer struct {
+	chats map[int64][]BufferedMessage
+	mutex sync.RWMutex
+}
+
+// NewMessageBuffer creates an empty message buffer
+func NewMessageBuffer() *MessageBuffer {
+	return &MessageBuffer{
+		chats: make(map[int64][]BufferedMessage),
+	}
+}
+
+// Add appends a message, dropping the oldest ones beyond size
+func (b *MessageBuffer) Add(message BufferedMessage, size int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	messages := append(b.chats[message.ChatID], message)
+	if size > 0 && len(messages) > size {
+		messages = messages[len(messages)-size:]
+	}
+	b.chats[message.ChatID] = messages
+}
+
+// Recent returns up to n most recent messages newer than since, in chronological order
+func (b *MessageBuffer) Recent(chatID int64, n int, since time.Time) []BufferedMessage {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	var result []BufferedMessage
+	for _, message := range b.chats[chatID] {
+		if message.Timestamp.After(since) {
+			result = append(result, message)
+		}
+	}
+	if n > 0 && len(result) > n {
+		result = result[len(result)-n:]
+	}
+	return result
+}
+
+// Count returns the number of buffered messages of a chat
+func (b *MessageBuffer) Count(chatID int64) int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return len(b.chats[chatID])
+}
+
+// Prune removes messages of a chat older than before and trims it to size
+func (b *MessageBuffer) Prune(chatID int64, before time.Time, size int) int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	messages := b.chats[chatID]
+	kept := messages[:0]
+	for _, message := range messages {
+		if message.Timestamp.After(before) {
+			kept = append(kept, message)
+		}
+	}
+	if size > 0 && len(kept) > size {
+		kept = kept[len(kept)-size:]
+	}
+
+	removed := len(messages) - len(kept)
+	if len(kept) == 0 {
+		delete(b.chats, chatID)
+	} else {
+		b.chats[chatID] = kept
+	}
+	return removed
+}
+
+// Clear removes all buffered messages of a chat and returns how many were removed
+func (b *MessageBuffer) Clear(chatID int64) int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	removed := len(b.chats[chatID])
+	delete(b.chats, chatID)
+	return removed
+}
+
+// ChatIDs returns the chats that currently have buffered messages
+func (b *MessageBuffer) ChatIDs() []int64 {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	ids := make([]int64, 0, len(b.chats))
+	for chatID := range b.chats {
+		ids = append(ids, chatID)
+	}
+	return ids
+}
+
+// ChatHistoryService records chat messages for chats that opted in, enforcing
+// each chat's size and retention limits
+type ChatHistoryService struct {
+	buffer   *MessageBuffer
+	store    ChatSettingsStore
+	settings map[int64]HistorySettings
+	mutex    sync.RWMutex
+	logger   domain.Logger
+}
+
+// NewChatHistoryService creates a new chat history service
+func NewChatHistoryService(buffer *MessageBuffer, store ChatSettingsStore, logger domain.Logger) *ChatHistoryService {
+	return &ChatHistoryService{
+		buffer:   buffer,
+		store:    store,
+		settings: make(map[int64]HistorySettings),
+		logger:   logger,
+	}
+}
+
+// GetSettings returns the history settings of a chat, falling back to defaults
+func (s *ChatHistoryService) GetSettings(chatID int64) HistorySettings {
+	s.mutex.RLock()
+	cached, found := s.settings[chatID]
+	s.mutex.RUnlock()
+	if found {
+		return cached
+	}
+
+	settings := DefaultHistorySettings()
+	values, err := s.store.GetChatSettings(chatID)
+	if err != nil {
+		s.logger.Warn("Failed to load chat history settings", "chat_id", chatID, "error", err)
+		return settings
+	}
+
+	settings.Enabled = values[settingHistoryEnabled] == "true"
+	if size, err := strconv.Atoi(values[settingHistorySize]); err == nil {
+		settings.Size = size
+	}
+	if hours, err := strconv.Atoi(values[settingHistoryRetention]); err == nil {
+		settings.Retention = time.Duration(hours) * time.Hour
+	}
+
+	s.mutex.Lock()
+	s.settings[chatID] = settings
+	s.mutex.Unlock()
+
+	return settings
+}
+
+// IsEnabled reports whether the chat opted into message history
+func (s *ChatHistoryService) IsEnabled(chatID int64) bool {
+	return s.GetSettings(chatID).Enabled
+}
+
+// SetEnabled turns message history on or off; turning it off purges the buffer
+func (s *ChatHistoryService) SetEnabled(chatID int64, enabled bool) error {
+	if err := s.store.SetChatSetting(chatID, settingHistoryEnabled, strconv.FormatBool(enabled)); err != nil {
+		return err
+	}
+	s.invalidate(chatID)
+
+	if !enabled {
+		s.buffer.Clear(chatID)
+	}
+	s.logger.Info("Chat history setting changed", "chat_id", chatID, "enabled", enabled)
+	return nil
+}
+
+// SetSize changes how many recent messages are kept for the chat
+func (s *ChatHistoryService) SetSize(chatID int64, size int) error {
+	if size < MinHistorySize || size > MaxHistorySize {
+		return fmt.Errorf("history size must be between %d and %d messages", MinHistorySize, MaxHistorySize)
+	}
+	if err := s.store.SetChatSetting(chatID, settingHistorySize, strconv.Itoa(size)); err != nil {
+		return err
+	}
+	s.invalidate(chatID)
+
+	settings := s.GetSettings(chatID)
+	s.buffer.Prune(chatID, time.Now().Add(-settings.Retention), settings.Size)
+	return nil
+}
+
+// SetRetention changes how long messages are kept for the chat
+func (s *ChatHistoryService) SetRetention(chatID int64, retention time.Duration) error {
+	if retention < time.Hour || retention > MaxHistoryRetention {
+		return fmt.Errorf("retention must be between 1h and %dh", int(MaxHistoryRetention.Hours()))
+	}
+	hours := int(retention.Hours())
+	if err := s.store.SetChatSetting(chatID, settingHistoryRetention, strconv.Itoa(hours)); err != nil {
+		return err
+	}
+	s.invalidate(chatID)
+
+	settings := s.GetSettings(chatID)
+	s.buffer.Prune(chatID, time.Now().Add(-settings.Retention), settings.Size)
+	return nil
+}
+
+// Record stores a message if the chat opted into history
+func (s *ChatHistoryService) Record(message BufferedMessage) {
+	if message.Text == "" {
+		return
+	}
+	settings := s.GetSettings(message.ChatID)
+	if !settings.Enabled {
+		return
+	}
+	s.buffer.Add(message, settings.Size)
+}
+
+// Recent returns up to n recent messages of a chat within its retention window
+func (s *ChatHistoryService) Recent(chatID int64, n int) []BufferedMessage {
+	settings := s.GetSettings(chatID)
+	return s.buffer.Recent(chatID, n, time.Now().Add(-settings.Retention))
+}
+
+// Count returns the number of messages currently kept for a chat
+func (s *ChatHistoryService) Count(chatID int64) int {
+	return s.buffer.Count(chatID)
+}
+
+// Purge removes all stored messages of a chat and returns how many were removed
+func (s *ChatHistoryService) Purge(chatID int64) int {
+	removed := s.buffer.Clear(chatID)
+	s.logger.Info("Chat history purged", "chat_id", chatID, "removed", removed)
+	return removed
+}
+
+// PruneExpired drops messages past each chat's retention window
+func (s *ChatHistoryService) PruneExpired() int {
+	removed := 0
+	now := time.Now()
+	for _, chatID := range s.buffer.ChatIDs() {
+		settings := s.GetSettings(chatID)
+		if !settings.Enabled {
+			removed += s.buffer.Clear(chatID)
+			continue
+		}
+		removed += s.buffer.Prune(chatID, now.Add(-settings.Retention), settings.Size)
+	}
+	return removed
+}
+
+// invalidate drops the cached settings of a chat
+func (s *ChatHistoryService) invalidate(chatID int64) {
+	s.mutex.Lock()
+	delete(s.settings, chatID)
+	s.mutex.Unlock()
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// defaultLargeModelThreshold is the estimated prompt size in tokens from which the large model is used
+const defaultLargeModelThreshold = 3000
+
+// ModelSelector picks a provider model per request from the prompt size: small requirements
+// use the cheap default model, large extracted documents a larger-context model.
+//
+// The models come from <PROVIDER>_MODEL and <PROVIDER>_LARGE_MODEL, the threshold from
+// AI_LARGE_MODEL_THRESHOLD (estimated tokens, 0 always uses the default model).
+type ModelSelector struct {
+	model      string
+	largeModel string
+	threshold  int
+}
+
+// NewModelSelector creates a selector for a provider, e.g. "CLAUDE", falling back to the given defaults
+func NewModelSelector(provider, defaultModel, defaultLargeModel string) *ModelSelector {
+	model := os.Getenv(provider + "_MODEL")
+	if model == "" {
+		model = defaultModel
+	}
+	largeModel := os.Getenv(provider + "_LARGE_MODEL")
+	if largeModel == "" {
+		largeModel = defaultLargeModel
+	}
+
+	threshold := defaultLargeModelThreshold
+	if value := strings.TrimSpace(os.Getenv("AI_LARGE_MODEL_THRESHOLD")); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+			threshold = parsed
+		}
+	}
+
+	return &ModelSelector{
+		model:      model,
+		largeModel: largeModel,
+		threshold:  threshold,
+	}
+}
+
+// Select returns the model for a prompt and its estimated token count
+func (s *ModelSelector) Select(prompt string) (string, int) {
+	tokens := EstimateTokens(prompt)
+	if s.threshold > 0 && tokens >= s.threshold && s.largeModel != "" {
+		return s.largeModel, tokens
+	}
+	return s.model, tokens
+}
+
+// Model returns the default model used for regular-sized prompts
+func (s *ModelSelector) Model() string {
+	return s.model
+}
+
+// EstimateTokens approximates the token count of a text at about four characters per token
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	return (len([]rune(text)) + 3) / 4
+}
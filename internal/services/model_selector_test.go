@@ -0,0 +1,32 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModelSelectorUsesLargeModelForLongPrompts(t *testing.T) {
+	t.Setenv("TEST_MODEL", "")
+	t.Setenv("TEST_LARGE_MODEL", "")
+	t.Setenv("AI_LARGE_MODEL_THRESHOLD", "100")
+	selector := NewModelSelector("TEST", "small", "large")
+
+	if model, tokens := selector.Select("Add login with GitHub"); model != "small" || tokens == 0 {
+		t.Errorf("expected small model for a short prompt, got %s (%d tokens)", model, tokens)
+	}
+	if model, tokens := selector.Select(strings.Repeat("word ", 100)); model != "large" || tokens < 100 {
+		t.Errorf("expected large model for a long prompt, got %s (%d tokens)", model, tokens)
+	}
+}
+
+func TestModelSelectorEnvironmentOverrides(t *testing.T) {
+	t.Setenv("TEST_MODEL", "custom-small")
+	t.Setenv("TEST_LARGE_MODEL", "custom-large")
+	t.Setenv("AI_LARGE_MODEL_THRESHOLD", "0")
+	selector := NewModelSelector("TEST", "small", "large")
+
+	// A zero threshold turns automatic selection off
+	if model, _ := selector.Select(strings.Repeat("word ", 10000)); model != "custom-small" {
+		t.Errorf("expected the configured default model, got %s", model)
+	}
+}
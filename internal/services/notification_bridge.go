@@ -0,0 +1,258 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Team setting keys used by the notification bridge
+const (
+	settingBridgeSlackURL   = "bridge_slack_url"
+	settingBridgeDiscordURL = "bridge_discord_url"
+	settingBridgeEvents     = "bridge_events"
+	settingBridgeLastSprint = "bridge_last_sprint"
+)
+
+// BridgeSettingPrefix is the common prefix of the bridge's team settings
+const BridgeSettingPrefix = "bridge_"
+
+// Events that can be mirrored to Slack or Discord
+const (
+	BridgeEventProjectCreated = "project_created"
+	BridgeEventSprintSummary  = "sprint_summary"
+	BridgeEventIncident       = "incident"
+	BridgeEventStatusReport   = "status_report"
+)
+
+// Bridge targets
+const (
+	BridgeSlack   = "slack"
+	BridgeDiscord = "discord"
+)
+
+// BridgeEvents lists all events the bridge can mirror
+var BridgeEvents = []string{BridgeEventProjectCreated, BridgeEventSprintSummary, BridgeEventIncident, BridgeEventStatusReport}
+
+// bridgeHosts restricts webhook URLs to the official Slack and Discord endpoints
+var bridgeHosts = map[string][]string{
+	BridgeSlack:   {"hooks.slack.com"},
+	BridgeDiscord: {"discord.com", "discordapp.com"},
+}
+
+// BridgeConfig is a team's outbound notification configuration
+type BridgeConfig struct {
+	SlackURL   string
+	DiscordURL string
+	Events     []string
+}
+
+// Enabled reports whether the bridge has a target and mirrors the event
+func (c BridgeConfig) Enabled(event string) bool {
+	if c.SlackURL == "" && c.DiscordURL == "" {
+		return false
+	}
+	for _, enabled := range c.Events {
+		if enabled == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationBridge mirrors selected team events to Slack and Discord incoming webhooks
+type NotificationBridge struct {
+	store  TeamSettingsStore
+	client *http.Client
+	logger domain.Logger
+}
+
+// NewNotificationBridge creates a new notification bridge
+func NewNotificationBridge(store TeamSettingsStore, logger domain.Logger) *NotificationBridge {
+	return &NotificationBridge{
+		store:  store,
+		client: &http.Client{Timeout: 10 * time.Second},
+		logger: logger,
+	}
+}
+
+// GetConfig returns the team's bridge configuration. All events are mirrored unless narrowed down.
+func (b *NotificationBridge) GetConfig(teamID string) BridgeConfig {
+	config := BridgeConfig{Events: BridgeEvents}
+
+	values, err := b.store.GetTeamSettings(teamID)
+	if err != nil {
+		b.logger.Warn("Failed to load bridge settings", "team_id", teamID, "error", err)
+		return BridgeConfig{}
+	}
+
+	config.SlackURL = values[settingBridgeSlackURL]
+	config.DiscordURL = values[settingBridgeDiscordURL]
+	if events, ok := values[settingBridgeEvents]; ok {
+		config.Events = nil
+		for _, event := range strings.Split(events, ",") {
+			if event != "" {
+				config.Events = append(config.Events, event)
+			}
+		}
+	}
+
+	return config
+}
+
+// SetWebhook stores or clears (with an empty URL) the webhook of a target
+func (b *NotificationBridge) SetWebhook(teamID, target, webhookURL string) error {
+	key := settingBridgeSlackURL
+	if target == BridgeDiscord {
+		key = settingBridgeDiscordURL
+	}
+
+	if webhookURL != "" {
+		if err := ValidateBridgeURL(target, webhookURL); err != nil {
+			return err
+		}
+	}
+	return b.store.SetTeamSetting(teamID, key, webhookURL)
+}
+
+// SetEvents stores which events are mirrored
+func (b *NotificationBridge) SetEvents(teamID string, events []string) error {
+	for _, event := range events {
+		if !isBridgeEvent(event) {
+			return fmt.Errorf("unknown event %q, use %s", event, strings.Join(BridgeEvents, ", "))
+		}
+	}
+	return b.store.SetTeamSetting(teamID, settingBridgeEvents, strings.Join(events, ","))
+}
+
+// LastSprintSummary returns the number of the last sprint whose summary was mirrored
+func (b *NotificationBridge) LastSprintSummary(teamID string) int {
+	values, err := b.store.GetTeamSettings(teamID)
+	if err != nil {
+		b.logger.Warn("Failed to load bridge settings", "team_id", teamID, "error", err)
+		return 0
+	}
+	number, _ := strconv.Atoi(values[settingBridgeLastSprint])
+	return number
+}
+
+// SetLastSprintSummary records that a sprint's summary was mirrored
+func (b *NotificationBridge) SetLastSprintSummary(teamID string, number int) error {
+	return b.store.SetTeamSetting(teamID, settingBridgeLastSprint, strconv.Itoa(number))
+}
+
+// Notify mirrors an event to the team's configured targets. Text uses the bot's Markdown
+// and is converted per target. Delivery failures are logged and never reach the caller.
+func (b *NotificationBridge) Notify(ctx context.Context, teamID, event, text string) {
+	config := b.GetConfig(teamID)
+	if !config.Enabled(event) {
+		return
+	}
+
+	if err := b.deliver(ctx, config, text); err != nil {
+		b.logger.Error("Failed to mirror event", "team_id", teamID, "event", event, "error", err)
+		return
+	}
+	b.logger.Info("Event mirrored", "team_id", teamID, "event", event)
+}
+
+// Test sends a test message to all configured targets and reports delivery failures
+func (b *NotificationBridge) Test(ctx context.Context, teamID, text string) error {
+	return b.Send(ctx, teamID, text)
+}
+
+// Send delivers a message to all configured targets regardless of the enabled events
+func (b *NotificationBridge) Send(ctx context.Context, teamID, text string) error {
+	config := b.GetConfig(teamID)
+	if config.SlackURL == "" && config.DiscordURL == "" {
+		return fmt.Errorf("no Slack or Discord webhook configured")
+	}
+
+	return b.deliver(ctx, config, text)
+}
+
+// deliver sends a message to every configured target, attempting all of them
+func (b *NotificationBridge) deliver(ctx context.Context, config BridgeConfig, text string) error {
+	var errs []error
+	if config.SlackURL != "" {
+		if err := b.post(ctx, config.SlackURL, map[string]string{"text": toSlackMarkdown(text)}); err != nil {
+			errs = append(errs, fmt.Errorf("slack: %w", err))
+		}
+	}
+	if config.DiscordURL != "" {
+		if err := b.post(ctx, config.DiscordURL, map[string]string{"content": text}); err != nil {
+			errs = append(errs, fmt.Errorf("discord: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// post sends a JSON payload to a webhook
+func (b *NotificationBridge) post(ctx context.Context, webhookURL string, payload map[string]string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// ValidateBridgeURL checks that a webhook URL points at the target's official endpoint
+func ValidateBridgeURL(target, webhookURL string) error {
+	hosts, ok := bridgeHosts[target]
+	if !ok {
+		return fmt.Errorf("unknown target %q, use slack or discord", target)
+	}
+
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || parsed.Scheme != "https" {
+		return fmt.Errorf("webhook must be an https URL")
+	}
+	for _, host := range hosts {
+		if parsed.Hostname() == host {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s webhooks must be on %s", target, strings.Join(hosts, " or "))
+}
+
+// isBridgeEvent checks whether an event can be mirrored
+func isBridgeEvent(event string) bool {
+	for _, known := range BridgeEvents {
+		if known == event {
+			return true
+		}
+	}
+	return false
+}
+
+// toSlackMarkdown converts the bot's **bold** Markdown to Slack's *bold*
+func toSlackMarkdown(text string) string {
+	return strings.ReplaceAll(text, "**", "*")
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,7 +18,7 @@ import (
 // OpenAIService handles integration with OpenAI ChatGPT API
 type OpenAIService struct {
 	apiKey     string
-	model      string
+	models     *ModelSelector
 	httpClient *http.Client
 	baseURL    string
 	logger     domain.Logger
@@ -29,6 +30,20 @@ type OpenAIRequest struct {
 	Messages    []OpenAIMessage  `json:"messages"`
 	MaxTokens   int              `json:"max_tokens"`
 	Temperature float64          `json:"temperature"`
+	// ResponseFormat requests JSON output, optionally following a schema
+	ResponseFormat *OpenAIResponseFormat `json:"response_format,omitempty"`
+}
+
+// OpenAIResponseFormat is "json_schema" on models with structured outputs, else "json_object"
+type OpenAIResponseFormat struct {
+	Type       string            `json:"type"`
+	JSONSchema *OpenAIJSONSchema `json:"json_schema,omitempty"`
+}
+
+// OpenAIJSONSchema names the schema the response must follow
+type OpenAIJSONSchema struct {
+	Name   string                 `json:"name"`
+	Schema map[string]interface{} `json:"schema"`
 }
 
 // OpenAIMessage represents a message in OpenAI API format
@@ -63,15 +78,9 @@ type OpenAIUsage struct {
 
 // NewOpenAIService creates a new OpenAI service
 func NewOpenAIService(logger domain.Logger) *OpenAIService {
-	// Default to GPT-3.5-turbo if no model specified
-	model := os.Getenv("OPENAI_MODEL")
-	if model == "" {
-		model = "gpt-3.5-turbo"
-	}
-	
 	return &OpenAIService{
 		apiKey:  os.Getenv("OPENAI_API_KEY"),
-		model:   model,
+		models:  NewModelSelector("OPENAI", "gpt-3.5-turbo", "gpt-4o"),
 		baseURL: "https://api.openai.com/v1/chat/completions",
 		httpClient: &http.Client{
 			Timeout: 60 * time.Second,
@@ -85,6 +94,26 @@ func (o *OpenAIService) IsConfigured() bool {
 	return o.apiKey != ""
 }
 
+// Model returns the model used for regular-sized prompts
+func (o *OpenAIService) Model() string {
+	return o.models.Model()
+}
+
+// Complete sends a free-form prompt to OpenAI and returns the text response
+func (o *OpenAIService) Complete(ctx context.Context, prompt string) (string, error) {
+	if !o.IsConfigured() {
+		return "", fmt.Errorf("OpenAI API key not configured")
+	}
+
+	model, _ := o.models.Select(prompt)
+	response, _, err := o.sendRequest(ctx, model, prompt, nil)
+	if err != nil {
+		return "", fmt.Errorf("OpenAI API request failed: %w", err)
+	}
+
+	return strings.TrimSpace(response), nil
+}
+
 // AnalyzeRequirement sends requirement to OpenAI for task breakdown
 func (o *OpenAIService) AnalyzeRequirement(ctx context.Context, req domain.TaskBreakdownRequest) (*domain.TaskBreakdownResponse, error) {
 	if !o.IsConfigured() {
@@ -93,17 +122,35 @@ func (o *OpenAIService) AnalyzeRequirement(ctx context.Context, req domain.TaskB
 
 	prompt := o.buildAnalysisPrompt(req)
 	
-	response, err := o.sendRequest(ctx, prompt)
+	model, estimated := o.models.Select(prompt)
+	format := openAIResponseFormat(model)
+	response, tokens, err := o.sendRequest(ctx, model, prompt, format)
+	if errors.Is(err, errStructuredUnsupported) {
+		o.logger.Warn("OpenAI response format unavailable, parsing JSON from text", "model", model, "error", err)
+		format = nil
+		response, tokens, err = o.sendRequest(ctx, model, prompt, nil)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("OpenAI API request failed: %w", err)
 	}
 
-	result, err := o.parseTaskBreakdown(response)
+	// JSON mode guarantees valid JSON but not the schema, so it keeps the lenient parser
+	var result *domain.TaskBreakdownResponse
+	if format != nil && format.JSONSchema != nil {
+		result, err = decodeStructuredBreakdown([]byte(response))
+	} else {
+		result, err = o.parseTaskBreakdown(response)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse OpenAI response: %w", err)
+		return nil, fmt.Errorf("failed to parse OpenAI response: %w", &BreakdownParseError{Output: response, Err: err})
 	}
+	result.TokensUsed = tokens
+	result.Model = model
 
-	o.logger.Info("OpenAI analysis completed", 
+	o.logger.Info("OpenAI analysis completed",
+		"model", model,
+		"estimated_tokens", estimated,
+		"tokens_used", tokens,
 		"tasks_count", len(result.Tasks),
 		"confidence", result.Confidence,
 		"total_estimate", result.TotalEstimate)
@@ -122,7 +169,7 @@ Break down this development requirement into actionable tasks:
 **Requirement:** %s
 **Project Type:** %s
 **Team Skills:** %s
-
+%s
 Please provide a detailed task breakdown in the following JSON format:
 
 {
@@ -133,6 +180,8 @@ Please provide a detailed task breakdown in the following JSON format:
       "description": "Detailed description of what needs to be done",
       "category": "backend|frontend|qa|devops",
       "estimate_hours": 4.5,
+      "optimistic_hours": 3,
+      "pessimistic_hours": 8,
       "priority": 1,
       "dependencies": []
     }
@@ -147,6 +196,7 @@ Please provide a detailed task breakdown in the following JSON format:
 Guidelines:
 - Break down into 3-15 specific, actionable tasks
 - Estimate hours realistically considering complexity and potential blockers
+- estimate_hours is the most likely effort; optimistic_hours and pessimistic_hours bound it
 - Use priority: 1 (high/critical), 2 (medium), 3 (low)
 - Categories: backend, frontend, qa, devops
 - Include task dependencies where one task blocks another
@@ -154,13 +204,28 @@ Guidelines:
 - Consider the team's available skills when making recommendations
 - Think about integration points, testing requirements, and deployment considerations
 
-Respond ONLY with valid JSON, no additional text or formatting.`, req.Requirement, req.ProjectType, skillsStr)
+Respond ONLY with valid JSON, no additional text or formatting.`, req.Requirement, req.ProjectType, skillsStr, buildTeamContext(req)+buildDetailContext(req))
+}
+
+// openAIResponseFormat picks the strictest JSON output the model supports. Structured
+// outputs with a schema need gpt-4o or newer; older chat models get JSON mode.
+func openAIResponseFormat(model string) *OpenAIResponseFormat {
+	for _, prefix := range []string{"gpt-4o", "gpt-4.1", "gpt-5", "o3", "o4"} {
+		if strings.HasPrefix(model, prefix) {
+			return &OpenAIResponseFormat{
+				Type:       "json_schema",
+				JSONSchema: &OpenAIJSONSchema{Name: "task_breakdown", Schema: taskBreakdownSchema},
+			}
+		}
+	}
+	return &OpenAIResponseFormat{Type: "json_object"}
 }
 
-// sendRequest sends request to OpenAI API
-func (o *OpenAIService) sendRequest(ctx context.Context, prompt string) (string, error) {
+// sendRequest sends request to OpenAI API. A rejected responseFormat is reported as
+// errStructuredUnsupported.
+func (o *OpenAIService) sendRequest(ctx context.Context, model, prompt string, responseFormat *OpenAIResponseFormat) (string, int, error) {
 	reqData := OpenAIRequest{
-		Model: o.model,
+		Model: model,
 		Messages: []OpenAIMessage{
 			{
 				Role:    "system",
@@ -171,18 +236,19 @@ func (o *OpenAIService) sendRequest(ctx context.Context, prompt string) (string,
 				Content: prompt,
 			},
 		},
-		MaxTokens:   4000,
-		Temperature: 0.3, // Lower temperature for more consistent, focused responses
+		MaxTokens:      4000,
+		Temperature:    0.3, // Lower temperature for more consistent, focused responses
+		ResponseFormat: responseFormat,
 	}
 
 	jsonData, err := json.Marshal(reqData)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", 0, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", o.baseURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -190,29 +256,32 @@ func (o *OpenAIService) sendRequest(ctx context.Context, prompt string) (string,
 
 	resp, err := o.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+		return "", 0, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", 0, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("OpenAI API error %d: %s", resp.StatusCode, string(body))
+		if responseFormat != nil && structuredRejected(resp.StatusCode, body, "response_format") {
+			return "", 0, fmt.Errorf("%w: OpenAI API error %d: %s", errStructuredUnsupported, resp.StatusCode, string(body))
+		}
+		return "", 0, fmt.Errorf("OpenAI API error %d: %s", resp.StatusCode, string(body))
 	}
 
 	var openaiResp OpenAIResponse
 	if err := json.Unmarshal(body, &openaiResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return "", 0, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if len(openaiResp.Choices) == 0 {
-		return "", fmt.Errorf("empty response from OpenAI")
+		return "", 0, fmt.Errorf("empty response from OpenAI")
 	}
 
-	return openaiResp.Choices[0].Message.Content, nil
+	return openaiResp.Choices[0].Message.Content, openaiResp.Usage.TotalTokens, nil
 }
 
 // parseTaskBreakdown parses OpenAI's JSON response into task breakdown
@@ -269,4 +338,78 @@ func (o *OpenAIService) parseTaskBreakdown(response string) (*domain.TaskBreakdo
 	}
 
 	return &result, nil
-}
\ No newline at end of file
+}
+// openAIEmbeddingsURL is the OpenAI endpoint that turns texts into embedding vectors
+const openAIEmbeddingsURL = "https://api.openai.com/v1/embeddings"
+
+// OpenAIEmbeddingRequest represents a request to the OpenAI embeddings API
+type OpenAIEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// OpenAIEmbeddingResponse represents a response of the OpenAI embeddings API
+type OpenAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// EmbeddingModel returns the embeddings model, OPENAI_EMBEDDING_MODEL or text-embedding-3-small
+func (o *OpenAIService) EmbeddingModel() string {
+	if model := os.Getenv("OPENAI_EMBEDDING_MODEL"); model != "" {
+		return model
+	}
+	return "text-embedding-3-small"
+}
+
+// Embed returns an embedding vector for each text, in order
+func (o *OpenAIService) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if !o.IsConfigured() {
+		return nil, fmt.Errorf("OpenAI API key not configured")
+	}
+
+	jsonData, err := json.Marshal(OpenAIEmbeddingRequest{Model: o.EmbeddingModel(), Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", openAIEmbeddingsURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+o.apiKey)
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenAI API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var embeddingResp OpenAIEmbeddingResponse
+	if err := json.Unmarshal(body, &embeddingResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(embeddingResp.Data) != len(texts) {
+		return nil, fmt.Errorf("OpenAI returned %d embeddings for %d texts", len(embeddingResp.Data), len(texts))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, item := range embeddingResp.Data {
+		if item.Index < 0 || item.Index >= len(texts) {
+			return nil, fmt.Errorf("OpenAI returned an embedding for unknown input %d", item.Index)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	return vectors, nil
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxPlaygroundBytes is the largest snippet shared on the Go Playground, which refuses
+// bigger programs
+const MaxPlaygroundBytes = 64 * 1024
+
+// playgroundIDPattern matches the snippet IDs the share endpoint returns
+var playgroundIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// PlaygroundService formats Go code and shares it on the Go Playground
+type PlaygroundService struct {
+	http   *HTTPClient
+	logger Logger
+	// shareURL and linkURL are the Playground endpoints, replaced in tests
+	shareURL string
+	linkURL  string
+}
+
+// NewPlaygroundService creates a Go Playground client
+func NewPlaygroundService(logger Logger) *PlaygroundService {
+	return &PlaygroundService{
+		http:     NewHTTPClient(15*time.Second, logger),
+		logger:   logger,
+		shareURL: "https://play.golang.org/share",
+		linkURL:  "https://go.dev/play/p/",
+	}
+}
+
+// FormatGo runs gofmt on a Go file or a fragment of declarations or statements. The
+// error describes the first syntax error when the code does not parse.
+func FormatGo(code string) (string, error) {
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		return "", fmt.Errorf("gofmt: %w", err)
+	}
+	return string(formatted), nil
+}
+
+// Share uploads code to the Go Playground and returns the snippet's short link
+func (s *PlaygroundService) Share(ctx context.Context, code string) (string, error) {
+	if len(code) > MaxPlaygroundBytes {
+		return "", fmt.Errorf("the code is larger than %d KB", MaxPlaygroundBytes/1024)
+	}
+
+	resp, err := s.http.Post(ctx, s.shareURL, nil, "text/plain; charset=utf-8", []byte(code))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("the Playground returned HTTP %d", resp.StatusCode)
+	}
+
+	id := strings.TrimSpace(string(resp.Body))
+	if !playgroundIDPattern.MatchString(id) {
+		return "", fmt.Errorf("the Playground returned no snippet ID")
+	}
+	return s.linkURL + id, nil
+}
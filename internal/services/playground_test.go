@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFormatGo(t *testing.T) {
+	formatted, err := FormatGo("package main\nfunc main(){\nx:=1\n_ = x}\n")
+	if err != nil {
+		t.Fatalf("FormatGo() error = %v", err)
+	}
+	if want := "package main\n\nfunc main() {\n\tx := 1\n\t_ = x\n}\n"; formatted != want {
+		t.Errorf("FormatGo() = %q, want %q", formatted, want)
+	}
+
+	// Statements without a package clause are formatted as a fragment
+	if fragment, err := FormatGo("for i:=0;i<3;i++{fmt.Println(i)}"); err != nil || fragment != "for i := 0; i < 3; i++ {\n\tfmt.Println(i)\n}" {
+		t.Errorf("FormatGo(fragment) = %q, %v", fragment, err)
+	}
+
+	if _, err := FormatGo("func main() {"); err == nil {
+		t.Error("expected a syntax error")
+	}
+}
+
+func TestPlaygroundShare(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.Write([]byte("aBc_12-x\n"))
+	}))
+	defer server.Close()
+
+	service := NewPlaygroundService(silentLogger{})
+	service.shareURL = server.URL
+
+	link, err := service.Share(context.Background(), "package main\n")
+	if err != nil {
+		t.Fatalf("Share() error = %v", err)
+	}
+	if link != "https://go.dev/play/p/aBc_12-x" {
+		t.Errorf("link = %q", link)
+	}
+	if received != "package main\n" {
+		t.Errorf("uploaded %q", received)
+	}
+
+	if _, err := service.Share(context.Background(), strings.Repeat("x", MaxPlaygroundBytes+1)); err == nil {
+		t.Error("expected oversized code to be refused")
+	}
+}
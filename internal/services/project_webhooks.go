@@ -0,0 +1,394 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Events a project webhook can receive
+const (
+	ProjectEventTaskCreated   = "task.created"
+	ProjectEventTaskCompleted = "task.completed"
+	ProjectEventStatusChanged = "project.status_changed"
+	// ProjectEventPing is only sent by /project_webhook test
+	ProjectEventPing = "ping"
+)
+
+// ProjectEvents lists the events a project webhook can subscribe to
+var ProjectEvents = []string{ProjectEventTaskCreated, ProjectEventTaskCompleted, ProjectEventStatusChanged}
+
+// Webhook request headers; the signature is "sha256=" and the hex HMAC-SHA256 of the body
+// with the project's secret
+const (
+	WebhookEventHeader     = "X-Yordamchi-Event"
+	WebhookDeliveryHeader  = "X-Yordamchi-Delivery"
+	WebhookSignatureHeader = "X-Yordamchi-Signature"
+)
+
+const (
+	// projectWebhookAttempts is how often a delivery is tried before it is given up
+	projectWebhookAttempts = 5
+	// projectWebhookBackoff is the wait after the first failed attempt; it doubles after each
+	projectWebhookBackoff = 2 * time.Second
+)
+
+// ProjectWebhookStore persists the outbound webhook of each project
+type ProjectWebhookStore interface {
+	GetProjectWebhook(projectID string) (string, string, string, error)
+	SetProjectWebhook(projectID, url, secret, events string) error
+	DeleteProjectWebhook(projectID string) error
+}
+
+// ProjectWebhookConfig is a project's outbound webhook
+type ProjectWebhookConfig struct {
+	URL    string
+	Secret string
+	Events []string
+}
+
+// Enabled reports whether the webhook is set and subscribed to the event
+func (c ProjectWebhookConfig) Enabled(event string) bool {
+	if c.URL == "" {
+		return false
+	}
+	for _, enabled := range c.Events {
+		if enabled == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectEvent is the JSON body posted to a project webhook
+type ProjectEvent struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	ProjectID string      `json:"project_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// ProjectEventTask is the task in task.created and task.completed events
+type ProjectEventTask struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Category      string     `json:"category"`
+	Status        string     `json:"status"`
+	Priority      int        `json:"priority"`
+	EstimateHours float64    `json:"estimate_hours"`
+	ActualHours   float64    `json:"actual_hours"`
+	AssignedTo    string     `json:"assigned_to,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+// ProjectStatusChange is the data of project.status_changed: the project's health level
+// moved between green, yellow and red
+type ProjectStatusChange struct {
+	Name           string   `json:"name"`
+	Status         string   `json:"status"`
+	PreviousStatus string   `json:"previous_status"`
+	Score          int      `json:"score"`
+	Reasons        []string `json:"reasons,omitempty"`
+}
+
+// ProjectWebhooks posts project events to the URL a team configured for the project, so
+// their own tooling can follow tasks and health. Deliveries run in the background and
+// are retried with a growing backoff while the receiver fails.
+type ProjectWebhooks struct {
+	store    ProjectWebhookStore
+	client   *http.Client
+	logger   domain.Logger
+	backoff  time.Duration
+	inFlight sync.WaitGroup
+}
+
+// NewProjectWebhooks creates the project webhook service. Deliveries only connect to
+// public addresses and do not follow redirects, so a webhook URL cannot reach the bot's
+// own network.
+func NewProjectWebhooks(store ProjectWebhookStore, logger domain.Logger) *ProjectWebhooks {
+	return &ProjectWebhooks{
+		store: store,
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: newPublicTransport(),
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger:  logger,
+		backoff: projectWebhookBackoff,
+	}
+}
+
+// GetConfig returns a project's webhook; every event is sent unless narrowed down
+func (w *ProjectWebhooks) GetConfig(projectID string) (ProjectWebhookConfig, error) {
+	webhookURL, secret, events, err := w.store.GetProjectWebhook(projectID)
+	if err != nil {
+		return ProjectWebhookConfig{}, err
+	}
+
+	config := ProjectWebhookConfig{URL: webhookURL, Secret: secret, Events: ProjectEvents}
+	if events != "" {
+		config.Events = strings.Split(events, ",")
+	}
+	return config, nil
+}
+
+// Configure points a project's webhook at a URL with a new signing secret, which is
+// returned to be shown once. The subscribed events are kept.
+func (w *ProjectWebhooks) Configure(projectID, webhookURL string) (string, error) {
+	if err := ValidateWebhookURL(webhookURL); err != nil {
+		return "", err
+	}
+	_, _, events, err := w.store.GetProjectWebhook(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	encoded := hex.EncodeToString(secret)
+	if err := w.store.SetProjectWebhook(projectID, webhookURL, encoded, events); err != nil {
+		return "", err
+	}
+	return encoded, nil
+}
+
+// Restore puts back a configuration read with GetConfig, e.g. when a new secret could
+// not be handed over
+func (w *ProjectWebhooks) Restore(projectID string, config ProjectWebhookConfig) error {
+	if config.URL == "" {
+		return w.store.DeleteProjectWebhook(projectID)
+	}
+	return w.store.SetProjectWebhook(projectID, config.URL, config.Secret, strings.Join(config.Events, ","))
+}
+
+// Remove turns a project's webhook off
+func (w *ProjectWebhooks) Remove(projectID string) error {
+	return w.store.DeleteProjectWebhook(projectID)
+}
+
+// SetEvents narrows down the events sent to a project's webhook
+func (w *ProjectWebhooks) SetEvents(projectID string, events []string) error {
+	for _, event := range events {
+		if !isProjectEvent(event) {
+			return fmt.Errorf("unknown event %q, use %s", event, strings.Join(ProjectEvents, ", "))
+		}
+	}
+	config, err := w.GetConfig(projectID)
+	if err != nil {
+		return err
+	}
+	if config.URL == "" {
+		return fmt.Errorf("no webhook configured for the project")
+	}
+	return w.store.SetProjectWebhook(projectID, config.URL, config.Secret, strings.Join(events, ","))
+}
+
+// TaskCreated sends task.created for a new task
+func (w *ProjectWebhooks) TaskCreated(task domain.Task) {
+	w.Emit(task.ProjectID, ProjectEventTaskCreated, newProjectEventTask(task))
+}
+
+// TaskCompleted sends task.completed for a finished task
+func (w *ProjectWebhooks) TaskCompleted(task domain.Task) {
+	if task.CompletedAt == nil {
+		now := time.Now().UTC()
+		task.CompletedAt = &now
+	}
+	w.Emit(task.ProjectID, ProjectEventTaskCompleted, newProjectEventTask(task))
+}
+
+// StatusChanged sends project.status_changed
+func (w *ProjectWebhooks) StatusChanged(projectID string, change ProjectStatusChange) {
+	w.Emit(projectID, ProjectEventStatusChanged, change)
+}
+
+// Emit delivers an event to the project's webhook in the background when it subscribed to
+// it. Failures are logged and never reach the caller; a nil service sends nothing.
+func (w *ProjectWebhooks) Emit(projectID, event string, data interface{}) {
+	if w == nil {
+		return
+	}
+	config, err := w.GetConfig(projectID)
+	if err != nil {
+		w.logger.Warn("Failed to load project webhook", "project_id", projectID, "error", err)
+		return
+	}
+	if !config.Enabled(event) {
+		return
+	}
+
+	payload := newProjectEvent(projectID, event, data)
+	w.inFlight.Add(1)
+	go func() {
+		defer w.inFlight.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		if err := w.deliver(ctx, config, payload); err != nil {
+			w.logger.Error("Failed to deliver project webhook", "project_id", projectID, "event", event, "delivery", payload.ID, "error", err)
+			return
+		}
+		w.logger.Info("Project webhook delivered", "project_id", projectID, "event", event, "delivery", payload.ID)
+	}()
+}
+
+// Test posts a ping event once and reports the result
+func (w *ProjectWebhooks) Test(ctx context.Context, projectID string) error {
+	config, err := w.GetConfig(projectID)
+	if err != nil {
+		return err
+	}
+	if config.URL == "" {
+		return fmt.Errorf("no webhook configured for the project")
+	}
+	_, err = w.post(ctx, config, newProjectEvent(projectID, ProjectEventPing, map[string]string{"message": "Webhook configured"}))
+	return err
+}
+
+// Flush waits for the deliveries in progress, e.g. when the bot shuts down
+func (w *ProjectWebhooks) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		w.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// deliver posts an event, retrying network errors, 429s and 5xx answers
+func (w *ProjectWebhooks) deliver(ctx context.Context, config ProjectWebhookConfig, event ProjectEvent) error {
+	wait := w.backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := w.post(ctx, config, event)
+		if err == nil || !retry {
+			return err
+		}
+		if attempt == projectWebhookAttempts {
+			return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+		}
+		w.logger.Warn("Project webhook failed, retrying", "project_id", event.ProjectID, "event", event.Event, "attempt", attempt, "wait", wait, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// post sends one signed delivery and reports whether a failure is worth retrying
+func (w *ProjectWebhooks) post(ctx context.Context, config ProjectWebhookConfig, event ProjectEvent) (bool, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "yordamchi-dev-bot")
+	req.Header.Set(WebhookEventHeader, event.Event)
+	req.Header.Set(WebhookDeliveryHeader, event.ID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(config.Secret, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The answer's body is left out: /project_webhook test shows this error in the chat
+	if resp.StatusCode >= 300 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+// SignWebhook returns the signature header of a body, which receivers compare against
+// their own HMAC-SHA256 of the raw body
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateWebhookURL accepts https URLs that do not point at the bot's own host or a
+// private network address
+func ValidateWebhookURL(webhookURL string) error {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" {
+		return fmt.Errorf("webhook must be an https URL")
+	}
+	host := parsed.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return fmt.Errorf("webhook must not point at localhost")
+	}
+	if ip := net.ParseIP(host); ip != nil && (ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified()) {
+		return fmt.Errorf("webhook must not point at a private address")
+	}
+	return nil
+}
+
+// newProjectEvent wraps event data with a delivery ID
+func newProjectEvent(projectID, event string, data interface{}) ProjectEvent {
+	id := make([]byte, 8)
+	rand.Read(id)
+	return ProjectEvent{
+		ID:        hex.EncodeToString(id),
+		Event:     event,
+		ProjectID: projectID,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Data:      data,
+	}
+}
+
+// newProjectEventTask converts a task to its webhook form
+func newProjectEventTask(task domain.Task) ProjectEventTask {
+	return ProjectEventTask{
+		ID:            task.ID,
+		Title:         task.Title,
+		Category:      task.Category,
+		Status:        task.Status,
+		Priority:      task.Priority,
+		EstimateHours: task.EstimateHours,
+		ActualHours:   task.ActualHours,
+		AssignedTo:    task.AssignedTo,
+		CompletedAt:   task.CompletedAt,
+	}
+}
+
+// isProjectEvent checks whether a webhook can subscribe to an event
+func isProjectEvent(event string) bool {
+	for _, known := range ProjectEvents {
+		if known == event {
+			return true
+		}
+	}
+	return false
+}
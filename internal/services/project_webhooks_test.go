@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// memoryWebhookStore keeps project webhooks in memory
+type memoryWebhookStore struct {
+	webhooks map[string][3]string
+}
+
+func (s *memoryWebhookStore) GetProjectWebhook(projectID string) (string, string, string, error) {
+	webhook := s.webhooks[projectID]
+	return webhook[0], webhook[1], webhook[2], nil
+}
+
+func (s *memoryWebhookStore) SetProjectWebhook(projectID, url, secret, events string) error {
+	s.webhooks[projectID] = [3]string{url, secret, events}
+	return nil
+}
+
+func (s *memoryWebhookStore) DeleteProjectWebhook(projectID string) error {
+	delete(s.webhooks, projectID)
+	return nil
+}
+
+func TestProjectWebhooksSignAndRetry(t *testing.T) {
+	var mutex sync.Mutex
+	var received []ProjectEvent
+	failures := 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(WebhookSignatureHeader) != SignWebhook("secret", body) {
+			t.Errorf("signature %q does not match the body", r.Header.Get(WebhookSignatureHeader))
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var event ProjectEvent
+		json.Unmarshal(body, &event)
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	store := &memoryWebhookStore{webhooks: map[string][3]string{
+		"proj_1": {server.URL, "secret", ProjectEventTaskCompleted},
+	}}
+	webhooks := NewProjectWebhooks(store, silentLogger{})
+	webhooks.backoff = time.Millisecond
+	// httptest listens on loopback, which the public transport refuses
+	webhooks.client.Transport = http.DefaultTransport
+
+	// Not subscribed, so only the completion is delivered
+	webhooks.TaskCreated(domain.Task{ID: "task_1", ProjectID: "proj_1", Title: "Login page"})
+	webhooks.TaskCompleted(domain.Task{ID: "task_1", ProjectID: "proj_1", Title: "Login page", Status: domain.TaskStatusCompleted})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := webhooks.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(received) != 1 || received[0].Event != ProjectEventTaskCompleted || received[0].ProjectID != "proj_1" {
+		t.Fatalf("received %+v, want one task.completed after the retries", received)
+	}
+	task, _ := received[0].Data.(map[string]interface{})
+	if task["id"] != "task_1" || task["completed_at"] == nil {
+		t.Errorf("data = %v, want the completed task", received[0].Data)
+	}
+}
+
+func TestProjectWebhooksStayOffTheInternalNetwork(t *testing.T) {
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("internal admin page"))
+	}))
+	defer internal.Close()
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	store := &memoryWebhookStore{webhooks: map[string][3]string{
+		"proj_1": {internal.URL, "secret", ""},
+		"proj_2": {redirect.URL, "secret", ""},
+	}}
+	webhooks := NewProjectWebhooks(store, silentLogger{})
+
+	// A host resolving to a loopback address is refused when dialing
+	if err := webhooks.Test(context.Background(), "proj_1"); err == nil || !strings.Contains(err.Error(), "non-public") {
+		t.Errorf("Test() to loopback error = %v, want the connection refused", err)
+	}
+
+	// Redirects are not followed, and no answer body reaches the error
+	webhooks.client.Transport = http.DefaultTransport
+	if err := webhooks.Test(context.Background(), "proj_2"); err == nil || err.Error() != "webhook returned 302" {
+		t.Errorf("Test() through a redirect error = %v, want the 302 without following it", err)
+	}
+	if err := webhooks.Test(context.Background(), "proj_1"); err == nil || strings.Contains(err.Error(), "admin page") {
+		t.Errorf("Test() error = %v, want the status without the body", err)
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	for webhookURL, valid := range map[string]bool{
+		"https://tools.example.com/hooks/yordamchi": true,
+		"http://tools.example.com/hooks":            false,
+		"https://localhost:8080/hook":               false,
+		"https://127.0.0.1/hook":                    false,
+		"https://10.0.0.5/hook":                     false,
+		"ftp://example.com":                         false,
+	} {
+		if err := ValidateWebhookURL(webhookURL); (err == nil) != valid {
+			t.Errorf("ValidateWebhookURL(%q) error = %v, want valid %v", webhookURL, err, valid)
+		}
+	}
+}
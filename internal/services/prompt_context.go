@@ -0,0 +1,66 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// buildTeamContext describes the team's members and open tasks for the analysis prompts.
+// It returns an empty string when the request carries no stored team data.
+func buildTeamContext(req domain.TaskBreakdownRequest) string {
+	if len(req.TeamMembers) == 0 && len(req.ExistingTasks) == 0 {
+		return ""
+	}
+
+	var context strings.Builder
+	usernames := make(map[string]string, len(req.TeamMembers))
+
+	if len(req.TeamMembers) > 0 {
+		context.WriteString("\n**Team Members (skills, assigned open hours / weekly capacity):**\n")
+		for _, member := range req.TeamMembers {
+			usernames[member.ID] = member.Username
+			context.WriteString(fmt.Sprintf("- %s (%s): %s - %.0fh / %.0fh\n",
+				member.Username, member.Role, strings.Join(member.Skills, ", "), member.Current, member.Capacity))
+		}
+	}
+
+	if len(req.ExistingTasks) > 0 {
+		context.WriteString("\n**Existing Open Tasks:**\n")
+		for _, task := range req.ExistingTasks {
+			owner := "unassigned"
+			if username, ok := usernames[task.AssignedTo]; ok {
+				owner = username
+			}
+			context.WriteString(fmt.Sprintf("- [%s] %s (%.1fh, %s, %s)\n",
+				task.Category, task.Title, task.EstimateHours, task.Status, owner))
+		}
+	}
+
+	context.WriteString("\nTeam guidelines:\n")
+	if len(req.ExistingTasks) > 0 {
+		context.WriteString("- Do not repeat work covered by the existing open tasks; only add what is missing\n")
+	}
+	if len(req.TeamMembers) > 0 {
+		context.WriteString(`- Add "assigned_to" to each task with the username of the best-fit member above, preferring members with spare capacity` + "\n")
+	}
+
+	return context.String()
+}
+
+// buildDetailContext adjusts the analysis prompts to the requested breakdown depth.
+// It returns an empty string for the default depth.
+func buildDetailContext(req domain.TaskBreakdownRequest) string {
+	switch req.Detail {
+	case domain.AnalysisDetailBrief:
+		return "\nDetail level: brief. Instead of 3-15 tasks, return only 3-5 epics: large, outcome-level " +
+			"work items that each cover a whole feature area, with their total estimates.\n"
+	case domain.AnalysisDetailDetailed:
+		return "\nDetail level: detailed. Add to every task:\n" +
+			`- "subtasks": 3-6 concrete implementation steps as strings` + "\n" +
+			`- "acceptance_criteria": 2-4 testable criteria as strings in Given/When/Then form, ` +
+			`e.g. "Given a registered user, when they log in with a wrong password, then an error is shown"` + "\n"
+	}
+	return ""
+}
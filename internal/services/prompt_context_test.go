@@ -0,0 +1,53 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+func TestBuildTeamContextEmptyWithoutTeamData(t *testing.T) {
+	req := domain.TaskBreakdownRequest{Requirement: "Build login", TeamSkills: []string{"go"}}
+	if context := buildTeamContext(req); context != "" {
+		t.Errorf("expected no context without team data, got %q", context)
+	}
+}
+
+func TestBuildTeamContextListsMembersAndTasks(t *testing.T) {
+	req := domain.TaskBreakdownRequest{
+		TeamMembers: []domain.TeamMember{
+			{ID: "m1", Username: "alice", Role: "lead", Skills: []string{"go", "postgresql"}, Capacity: 40, Current: 12},
+		},
+		ExistingTasks: []domain.Task{
+			{Title: "Cart API", Category: "backend", EstimateHours: 8, Status: domain.TaskStatusInProgress, AssignedTo: "m1"},
+			{Title: "Cart page", Category: "frontend", EstimateHours: 6, Status: domain.TaskStatusTodo},
+		},
+	}
+
+	context := buildTeamContext(req)
+	for _, want := range []string{
+		"alice (lead): go, postgresql - 12h / 40h",
+		"[backend] Cart API (8.0h, in_progress, alice)",
+		"[frontend] Cart page (6.0h, todo, unassigned)",
+		"Do not repeat work",
+		`"assigned_to"`,
+	} {
+		if !strings.Contains(context, want) {
+			t.Errorf("context missing %q:\n%s", want, context)
+		}
+	}
+}
+
+func TestBuildDetailContext(t *testing.T) {
+	if context := buildDetailContext(domain.TaskBreakdownRequest{}); context != "" {
+		t.Errorf("expected no detail context by default, got %q", context)
+	}
+	if context := buildDetailContext(domain.TaskBreakdownRequest{Detail: domain.AnalysisDetailBrief}); !strings.Contains(context, "3-5 epics") {
+		t.Errorf("expected brief context to ask for epics, got %q", context)
+	}
+	context := buildDetailContext(domain.TaskBreakdownRequest{Detail: domain.AnalysisDetailDetailed})
+	if !strings.Contains(context, `"subtasks"`) || !strings.Contains(context, `"acceptance_criteria"`) {
+		t.Errorf("expected detailed context to ask for subtasks and criteria, got %q", context)
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// defaultReportLinkHours is how long a report link works
+const defaultReportLinkHours = 24
+
+// Report link errors; the HTTP handler tells an expired link from a forged or mistyped one
+var (
+	ErrReportLinkInvalid = errors.New("invalid report link")
+	ErrReportLinkExpired = errors.New("report link expired")
+)
+
+// StoredReport is a report served through a link
+type StoredReport struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"-"`
+}
+
+// ReportLinkService stores generated reports and exports and hands out short HTTPS links
+// to them, so they can be shared outside Telegram. A link carries its expiry and an HMAC
+// signature, so it cannot be guessed or extended and needs no database row.
+type ReportLinkService struct {
+	storage ReportStorage
+	secret  []byte
+	baseURL string
+	ttl     time.Duration
+	logger  domain.Logger
+}
+
+// NewReportLinkService creates the service with links under baseURL, usually PUBLIC_URL, that
+// expire after REPORT_LINK_HOURS; links are off without a base URL or with 0 hours
+func NewReportLinkService(storage ReportStorage, secret, baseURL string, logger domain.Logger) *ReportLinkService {
+	hours := defaultReportLinkHours
+	if value, err := strconv.Atoi(strings.TrimSpace(os.Getenv("REPORT_LINK_HOURS"))); err == nil && value >= 0 {
+		hours = value
+	}
+	return &ReportLinkService{
+		storage: storage,
+		secret:  []byte(secret),
+		baseURL: strings.TrimRight(baseURL, "/"),
+		ttl:     time.Duration(hours) * time.Hour,
+		logger:  logger,
+	}
+}
+
+// Enabled reports whether reports get links
+func (s *ReportLinkService) Enabled() bool {
+	return s != nil && s.baseURL != "" && s.ttl > 0 && len(s.secret) > 0
+}
+
+// Store saves a report and returns its link and when the link expires
+func (s *ReportLinkService) Store(ctx context.Context, report *StoredReport, now time.Time) (string, time.Time, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate report id: %w", err)
+	}
+	key := hex.EncodeToString(id)
+
+	meta, err := json.Marshal(report)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to marshal report metadata: %w", err)
+	}
+	if err := s.storage.Put(ctx, key+".data", report.Content); err != nil {
+		return "", time.Time{}, err
+	}
+	if err := s.storage.Put(ctx, key+".json", meta); err != nil {
+		return "", time.Time{}, err
+	}
+
+	expires := now.Add(s.ttl).Truncate(time.Second)
+	token := key + "." + strconv.FormatInt(expires.Unix(), 36)
+	token += "." + s.sign(token)
+	s.logger.Info("Report stored for sharing", "file", report.FileName, "bytes", len(report.Content), "expires", expires)
+	return s.baseURL + "/reports/" + token, expires, nil
+}
+
+// Open checks a link token and returns its report
+func (s *ReportLinkService) Open(ctx context.Context, token string, now time.Time) (*StoredReport, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrReportLinkInvalid
+	}
+	signed := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(signed))) {
+		return nil, ErrReportLinkInvalid
+	}
+	expires, err := strconv.ParseInt(parts[1], 36, 64)
+	if err != nil {
+		return nil, ErrReportLinkInvalid
+	}
+	if !now.Before(time.Unix(expires, 0)) {
+		return nil, ErrReportLinkExpired
+	}
+
+	meta, err := s.storage.Get(ctx, parts[0]+".json")
+	if err != nil {
+		return nil, err
+	}
+	var report StoredReport
+	if err := json.Unmarshal(meta, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse report metadata: %w", err)
+	}
+	if report.Content, err = s.storage.Get(ctx, parts[0]+".data"); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Prune deletes the reports whose links have expired
+func (s *ReportLinkService) Prune(ctx context.Context, now time.Time) (int, error) {
+	return s.storage.DeleteBefore(ctx, now.Add(-s.ttl))
+}
+
+// sign returns the link signature, shortened to 128 bits to keep links short
+func (s *ReportLinkService) sign(value string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// ReportLinkSecret returns REPORT_LINK_SECRET, or a key derived from the bot token so links
+// survive restarts without extra configuration
+func ReportLinkSecret(secret, botToken string) string {
+	if secret != "" {
+		return secret
+	}
+	if botToken == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(botToken))
+	mac.Write([]byte("report-links"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportLinkServiceStoresAndOpensReports(t *testing.T) {
+	links := NewReportLinkService(NewLocalReportStorage(t.TempDir()), "secret", "https://bot.example.com/", silentLogger{})
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	link, expires, err := links.Store(context.Background(), &StoredReport{FileName: "testplan_proj_1.md", ContentType: "text/markdown", Content: []byte("# Test plan")}, now)
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if !strings.HasPrefix(link, "https://bot.example.com/reports/") || !expires.Equal(now.Add(24*time.Hour)) {
+		t.Fatalf("Store() = %q, %v", link, expires)
+	}
+	token := strings.TrimPrefix(link, "https://bot.example.com/reports/")
+
+	report, err := links.Open(context.Background(), token, now.Add(time.Hour))
+	if err != nil || report.FileName != "testplan_proj_1.md" || report.ContentType != "text/markdown" || string(report.Content) != "# Test plan" {
+		t.Fatalf("Open() = %+v, %v", report, err)
+	}
+
+	if _, err := links.Open(context.Background(), token, now.Add(25*time.Hour)); !errors.Is(err, ErrReportLinkExpired) {
+		t.Errorf("expected the link to expire, got %v", err)
+	}
+
+	// Moving the expiry forward breaks the signature
+	parts := strings.Split(token, ".")
+	extended := parts[0] + ".zzzzzz." + parts[2]
+	if _, err := links.Open(context.Background(), extended, now); !errors.Is(err, ErrReportLinkInvalid) {
+		t.Errorf("expected a tampered link to be refused, got %v", err)
+	}
+
+	other := NewReportLinkService(NewLocalReportStorage(t.TempDir()), "other secret", "https://bot.example.com", silentLogger{})
+	if _, err := other.Open(context.Background(), token, now); !errors.Is(err, ErrReportLinkInvalid) {
+		t.Errorf("expected a link signed with another secret to be refused, got %v", err)
+	}
+}
+
+func TestReportLinkServicePrunesExpiredReports(t *testing.T) {
+	links := NewReportLinkService(NewLocalReportStorage(t.TempDir()), "secret", "https://bot.example.com", silentLogger{})
+	if _, _, err := links.Store(context.Background(), &StoredReport{FileName: "a.md", Content: []byte("a")}, time.Now()); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	if removed, err := links.Prune(context.Background(), time.Now()); err != nil || removed != 0 {
+		t.Errorf("Prune() = %d, %v, want nothing removed before expiry", removed, err)
+	}
+	if removed, err := links.Prune(context.Background(), time.Now().Add(25*time.Hour)); err != nil || removed != 2 {
+		t.Errorf("Prune() = %d, %v, want the report and its metadata removed", removed, err)
+	}
+}
+
+func TestReportLinkServiceEnabled(t *testing.T) {
+	storage := NewLocalReportStorage(t.TempDir())
+	if NewReportLinkService(storage, "secret", "", silentLogger{}).Enabled() {
+		t.Error("expected links off without a public URL")
+	}
+	t.Setenv("REPORT_LINK_HOURS", "0")
+	if NewReportLinkService(storage, "secret", "https://bot.example.com", silentLogger{}).Enabled() {
+		t.Error("expected REPORT_LINK_HOURS=0 to turn links off")
+	}
+
+	if ReportLinkSecret("", "123:token") == "" || ReportLinkSecret("", "123:token") == "123:token" {
+		t.Error("expected a secret derived from the bot token")
+	}
+}
+
+func TestLocalReportStorageRefusesPathKeys(t *testing.T) {
+	storage := NewLocalReportStorage(t.TempDir())
+	if err := storage.Put(context.Background(), "../escape", []byte("x")); err == nil {
+		t.Error("expected a key with a path to be refused")
+	}
+	if _, err := storage.Get(context.Background(), "missing.data"); !errors.Is(err, ErrReportNotFound) {
+		t.Errorf("expected ErrReportNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// ErrReportNotFound is returned for a key that is not stored
+var ErrReportNotFound = errors.New("report not found")
+
+// storageKeyPattern limits keys to names that are safe as file names
+var storageKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// ReportStorage keeps generated reports outside Telegram, so a link can serve them. A bucket
+// or object store can stand in for the local disk by implementing it.
+type ReportStorage interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	// DeleteBefore removes the objects stored before a time and returns how many it removed
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// LocalReportStorage stores reports as files in a directory
+type LocalReportStorage struct {
+	dir string
+}
+
+// NewLocalReportStorage creates a storage in dir, or in the temp directory when dir is
+// empty; the directory is created on first use
+func NewLocalReportStorage(dir string) *LocalReportStorage {
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "yordamchi-reports")
+	}
+	return &LocalReportStorage{dir: dir}
+}
+
+// path returns the file of a key, refusing keys that could leave the directory
+func (s *LocalReportStorage) path(key string) (string, error) {
+	if !storageKeyPattern.MatchString(key) || key == "." || key == ".." {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.dir, key), nil
+}
+
+// Put writes the object, replacing one with the same key
+func (s *LocalReportStorage) Put(ctx context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create the report directory: %w", err)
+	}
+
+	// Write to a temporary file first so a reader never sees half a report
+	temp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store report: %w", err)
+	}
+	return nil
+}
+
+// Get reads the object, or returns ErrReportNotFound
+func (s *LocalReportStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrReportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	return data, nil
+}
+
+// DeleteBefore removes the files last written before a time
+func (s *LocalReportStorage) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() || !info.ModTime().Before(before) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, fmt.Errorf("failed to delete report: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"math"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// An estimate counts as changed when it moves by at least this share of the saved one, and
+// by at least an hour
+const (
+	estimateChangeShare = 0.2
+	minEstimateChange   = 1.0
+)
+
+// TaskChange is a saved task whose estimate the new breakdown changes
+type TaskChange struct {
+	Existing domain.Task
+	Proposed domain.Task
+}
+
+// TaskDiff compares a new breakdown of a requirement with the tasks a project already has
+type TaskDiff struct {
+	// Added are the proposed tasks without a saved counterpart
+	Added []domain.Task
+	// Changed are saved tasks that are still open and get a different estimate
+	Changed []TaskChange
+	// Removed are saved tasks not yet started that the requirements no longer ask for
+	Removed []domain.Task
+	// Kept are started or finished tasks missing from the breakdown; work on them is
+	// not undone, so they stay
+	Kept []domain.Task
+	// Unchanged counts the proposed tasks that match a saved task as it is
+	Unchanged int
+	// Matches maps the IDs of proposed tasks to the saved tasks they match
+	Matches map[string]string
+}
+
+// Empty reports whether applying the diff would change nothing
+func (d *TaskDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// HoursDelta is how much the project's estimate grows, or shrinks when negative, once the
+// diff is applied
+func (d *TaskDiff) HoursDelta() float64 {
+	delta := 0.0
+	for _, task := range d.Added {
+		delta += task.EstimateHours
+	}
+	for _, change := range d.Changed {
+		delta += change.Proposed.EstimateHours - change.Existing.EstimateHours
+	}
+	for _, task := range d.Removed {
+		delta -= task.EstimateHours
+	}
+	return delta
+}
+
+// DiffTasks matches the proposed tasks of a re-analysis to a project's saved tasks by
+// title, the same way BuildBatchPlan finds shared work, and sorts them into new tasks,
+// changed estimates and dropped scope
+func DiffTasks(existing, proposed []domain.Task) *TaskDiff {
+	diff := &TaskDiff{Matches: make(map[string]string)}
+
+	existingWords := make([]map[string]bool, len(existing))
+	for i, task := range existing {
+		existingWords[i] = titleWords(task.Title)
+	}
+	matched := make([]bool, len(existing))
+
+	for _, task := range proposed {
+		words := titleWords(task.Title)
+		best, bestScore := -1, 0.0
+		for i, saved := range existing {
+			if matched[i] || !sameCategory(saved, task) {
+				continue
+			}
+			score := wordSimilarity(words, existingWords[i])
+			if strings.EqualFold(strings.TrimSpace(saved.Title), strings.TrimSpace(task.Title)) {
+				score = 1
+			}
+			if score >= sharedTaskSimilarity && score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best < 0 {
+			diff.Added = append(diff.Added, task)
+			continue
+		}
+
+		matched[best] = true
+		saved := existing[best]
+		diff.Matches[task.ID] = saved.ID
+		if saved.Status != domain.TaskStatusCompleted && estimateChanged(saved.EstimateHours, task.EstimateHours) {
+			diff.Changed = append(diff.Changed, TaskChange{Existing: saved, Proposed: task})
+			continue
+		}
+		diff.Unchanged++
+	}
+
+	for i, saved := range existing {
+		if matched[i] {
+			continue
+		}
+		if saved.Status == domain.TaskStatusTodo {
+			diff.Removed = append(diff.Removed, saved)
+		} else {
+			diff.Kept = append(diff.Kept, saved)
+		}
+	}
+	return diff
+}
+
+// estimateChanged reports whether a new estimate differs enough from the saved one to
+// be worth updating
+func estimateChanged(saved, proposed float64) bool {
+	difference := math.Abs(proposed - saved)
+	return difference >= minEstimateChange && difference >= saved*estimateChangeShare
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+func TestDiffTasks(t *testing.T) {
+	existing := []domain.Task{
+		{ID: "task_1", Title: "User registration API", Category: "backend", EstimateHours: 8, Status: domain.TaskStatusCompleted},
+		{ID: "task_2", Title: "Login page", Category: "frontend", EstimateHours: 6, Status: domain.TaskStatusTodo},
+		{ID: "task_3", Title: "Password reset emails", Category: "backend", EstimateHours: 5, Status: domain.TaskStatusTodo},
+		{ID: "task_4", Title: "Admin dashboard charts", Category: "frontend", EstimateHours: 10, Status: domain.TaskStatusInProgress},
+		{ID: "task_5", Title: "Deployment pipeline", Category: "devops", EstimateHours: 4, Status: domain.TaskStatusTodo},
+	}
+	proposed := []domain.Task{
+		// Finished work keeps its estimate even when the new one differs
+		{ID: "1", Title: "Implement user registration API", Category: "backend", EstimateHours: 12},
+		// Same title, estimate grows
+		{ID: "2", Title: "login page", Category: "frontend", EstimateHours: 10},
+		// A small change is not worth an update
+		{ID: "3", Title: "Deployment pipeline", Category: "devops", EstimateHours: 4.5},
+		{ID: "4", Title: "Two-factor authentication", Category: "backend", EstimateHours: 7, Dependencies: []string{"1"}},
+	}
+
+	diff := DiffTasks(existing, proposed)
+
+	if len(diff.Added) != 1 || diff.Added[0].ID != "4" {
+		t.Errorf("Added = %+v, want the two-factor task", diff.Added)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Existing.ID != "task_2" || diff.Changed[0].Proposed.EstimateHours != 10 {
+		t.Errorf("Changed = %+v, want the login page estimate", diff.Changed)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != "task_3" {
+		t.Errorf("Removed = %+v, want the password reset task that was not started", diff.Removed)
+	}
+	if len(diff.Kept) != 1 || diff.Kept[0].ID != "task_4" {
+		t.Errorf("Kept = %+v, want the started dashboard task", diff.Kept)
+	}
+	if diff.Unchanged != 2 {
+		t.Errorf("Unchanged = %d, want 2", diff.Unchanged)
+	}
+	if diff.Matches["1"] != "task_1" || diff.Matches["2"] != "task_2" || diff.Matches["3"] != "task_5" {
+		t.Errorf("Matches = %v", diff.Matches)
+	}
+	// +7 new, +4 changed, -5 removed
+	if delta := diff.HoursDelta(); delta != 6 {
+		t.Errorf("HoursDelta() = %v, want 6", delta)
+	}
+}
+
+func TestDiffTasksWithoutChanges(t *testing.T) {
+	tasks := []domain.Task{{ID: "task_1", Title: "Login page", Category: "frontend", EstimateHours: 6, Status: domain.TaskStatusTodo}}
+	diff := DiffTasks(tasks, []domain.Task{{ID: "1", Title: "Login page", Category: "frontend", EstimateHours: 6}})
+	if !diff.Empty() || diff.Unchanged != 1 {
+		t.Errorf("expected an empty diff, got %+v", diff)
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Default retention periods in days
+const (
+	defaultActivityRetentionDays  = 90
+	defaultAnalysesRetentionDays  = 90
+	defaultTemporaryRetentionDays = 30
+)
+
+// Data categories reported by the retention metrics
+const (
+	RetentionUserActivity  = "user_activity"
+	RetentionAnalyses      = "analysis_history"
+	RetentionTemporary     = "temporary_data"
+	RetentionMessageBuffer = "message_buffer"
+)
+
+// RetentionStore deletes stored data older than a cutoff and reports how many rows it removed
+type RetentionStore interface {
+	PurgeUserActivity(before time.Time) (int64, error)
+	PurgeAnalysisRuns(before time.Time) (int64, error)
+	PurgeTemporaryData(before time.Time, beforeWeek string) (int64, error)
+}
+
+// RetentionPolicy is how long each kind of data is kept
+type RetentionPolicy struct {
+	UserActivity time.Duration
+	Analyses     time.Duration
+	Temporary    time.Duration
+}
+
+// RetentionStats are the purged row counts of the last run and since startup
+type RetentionStats struct {
+	LastRun    time.Time
+	LastPurged map[string]int64
+	Total      map[string]int64
+}
+
+// RetentionService enforces the data retention policy. Chat message buffers have their own,
+// shorter per-chat retention and are pruned by the history job, which reports its counts here.
+type RetentionService struct {
+	store  RetentionStore
+	policy RetentionPolicy
+	logger domain.Logger
+	stats  RetentionStats
+	mutex  sync.Mutex
+}
+
+// NewRetentionService creates a retention service with the RETENTION_ACTIVITY_DAYS,
+// RETENTION_ANALYSES_DAYS and RETENTION_TEMP_DAYS policy
+func NewRetentionService(store RetentionStore, logger domain.Logger) *RetentionService {
+	policy := ParseRetentionPolicy(
+		envInt("RETENTION_ACTIVITY_DAYS", defaultActivityRetentionDays),
+		envInt("RETENTION_ANALYSES_DAYS", defaultAnalysesRetentionDays),
+		envInt("RETENTION_TEMP_DAYS", defaultTemporaryRetentionDays),
+	)
+	return &RetentionService{
+		store:  store,
+		policy: policy,
+		logger: logger,
+		stats: RetentionStats{
+			LastPurged: make(map[string]int64),
+			Total:      make(map[string]int64),
+		},
+	}
+}
+
+// ParseRetentionPolicy builds a policy from periods in days. Temporary data is kept for at
+// least the utilization trend period, which reads the daily snapshots.
+func ParseRetentionPolicy(activityDays, analysesDays, temporaryDays int) RetentionPolicy {
+	if temporaryDays < domain.UtilizationTrendDays {
+		temporaryDays = domain.UtilizationTrendDays
+	}
+	return RetentionPolicy{
+		UserActivity: time.Duration(activityDays) * 24 * time.Hour,
+		Analyses:     time.Duration(analysesDays) * 24 * time.Hour,
+		Temporary:    time.Duration(temporaryDays) * 24 * time.Hour,
+	}
+}
+
+// Policy returns the retention periods
+func (s *RetentionService) Policy() RetentionPolicy {
+	return s.policy
+}
+
+// Purge deletes the data past its retention period. A failing category is logged and
+// the others are still purged; the first error is returned.
+func (s *RetentionService) Purge(now time.Time) (map[string]int64, error) {
+	purged := make(map[string]int64)
+	var firstErr error
+	run := func(category string, purge func() (int64, error)) {
+		removed, err := purge()
+		if err != nil {
+			s.logger.Error("Retention purge failed", "category", category, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		purged[category] = removed
+	}
+
+	run(RetentionUserActivity, func() (int64, error) {
+		return s.store.PurgeUserActivity(now.Add(-s.policy.UserActivity))
+	})
+	run(RetentionAnalyses, func() (int64, error) {
+		return s.store.PurgeAnalysisRuns(now.Add(-s.policy.Analyses))
+	})
+	run(RetentionTemporary, func() (int64, error) {
+		before := now.Add(-s.policy.Temporary)
+		// Answers can be given until the pulse of their week is posted the week after
+		return s.store.PurgeTemporaryData(before, domain.MoodWeekKey(now.AddDate(0, 0, -14)))
+	})
+
+	s.mutex.Lock()
+	s.stats.LastRun = now
+	s.stats.LastPurged = purged
+	for category, removed := range purged {
+		s.stats.Total[category] += removed
+	}
+	s.mutex.Unlock()
+
+	return purged, firstErr
+}
+
+// RecordPurged adds rows removed outside Purge, e.g. pruned message buffers, to the totals
+func (s *RetentionService) RecordPurged(category string, removed int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stats.Total[category] += removed
+}
+
+// Stats returns a copy of the purge counts
+func (s *RetentionService) Stats() RetentionStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats := RetentionStats{
+		LastRun:    s.stats.LastRun,
+		LastPurged: make(map[string]int64, len(s.stats.LastPurged)),
+		Total:      make(map[string]int64, len(s.stats.Total)),
+	}
+	for category, removed := range s.stats.LastPurged {
+		stats.LastPurged[category] = removed
+	}
+	for category, removed := range s.stats.Total {
+		stats.Total[category] = removed
+	}
+	return stats
+}
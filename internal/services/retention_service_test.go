@@ -0,0 +1,67 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeRetentionStore struct {
+	activityBefore time.Time
+	analysesBefore time.Time
+	tempBefore     time.Time
+	beforeWeek     string
+	analysesErr    error
+}
+
+func (s *fakeRetentionStore) PurgeUserActivity(before time.Time) (int64, error) {
+	s.activityBefore = before
+	return 5, nil
+}
+
+func (s *fakeRetentionStore) PurgeAnalysisRuns(before time.Time) (int64, error) {
+	s.analysesBefore = before
+	return 0, s.analysesErr
+}
+
+func (s *fakeRetentionStore) PurgeTemporaryData(before time.Time, beforeWeek string) (int64, error) {
+	s.tempBefore = before
+	s.beforeWeek = beforeWeek
+	return 3, nil
+}
+
+func TestRetentionPurge(t *testing.T) {
+	store := &fakeRetentionStore{analysesErr: errors.New("locked")}
+	service := &RetentionService{
+		store:  store,
+		policy: ParseRetentionPolicy(90, 30, 7),
+		logger: silentLogger{},
+		stats:  RetentionStats{LastPurged: map[string]int64{}, Total: map[string]int64{}},
+	}
+	now := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+
+	purged, err := service.Purge(now)
+	if err == nil {
+		t.Error("expected the failed category to be reported")
+	}
+	if purged[RetentionUserActivity] != 5 || purged[RetentionTemporary] != 3 {
+		t.Errorf("expected other categories to be purged, got %v", purged)
+	}
+	if !store.activityBefore.Equal(now.AddDate(0, 0, -90)) || !store.analysesBefore.Equal(now.AddDate(0, 0, -30)) {
+		t.Errorf("unexpected cutoffs %v, %v", store.activityBefore, store.analysesBefore)
+	}
+	// Temporary data is kept at least for the utilization trend
+	if !store.tempBefore.Equal(now.AddDate(0, 0, -28)) {
+		t.Errorf("expected temporary data kept 28 days, got cutoff %v", store.tempBefore)
+	}
+	if store.beforeWeek != "2026-W40" {
+		t.Errorf("expected respondents before 2026-W40 purged, got %s", store.beforeWeek)
+	}
+
+	service.RecordPurged(RetentionMessageBuffer, 4)
+	service.Purge(now)
+	stats := service.Stats()
+	if stats.Total[RetentionUserActivity] != 10 || stats.Total[RetentionMessageBuffer] != 4 || stats.LastPurged[RetentionUserActivity] != 5 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// defaultBotTimezone is used when BOT_TIMEZONE is not set or invalid
+const defaultBotTimezone = "Asia/Tashkent"
+
+// JobFunc is a periodic background job that may send messages to chats
+type JobFunc func(ctx context.Context, sender domain.MessageSender) error
+
+// scheduledJob is a job registered with the scheduler
+type scheduledJob struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+}
+
+// Scheduler runs periodic background jobs such as scheduled message delivery
+type Scheduler struct {
+	jobs    []scheduledJob
+	mutex   sync.Mutex
+	started bool
+	logger  domain.Logger
+}
+
+// NewScheduler creates a new job scheduler
+func NewScheduler(logger domain.Logger) *Scheduler {
+	return &Scheduler{
+		jobs:   make([]scheduledJob, 0),
+		logger: logger,
+	}
+}
+
+// AddJob registers a job to run at the given interval. Jobs must be added before Start.
+func (s *Scheduler) AddJob(name string, interval time.Duration, run JobFunc) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.jobs = append(s.jobs, scheduledJob{name: name, interval: interval, run: run})
+	s.logger.Info("Scheduler job registered", "job", name, "interval", interval.String())
+}
+
+// Start launches all registered jobs until the context is cancelled
+func (s *Scheduler) Start(ctx context.Context, sender domain.MessageSender) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	for _, job := range s.jobs {
+		go s.runJob(ctx, job, sender)
+	}
+	s.logger.Info("Scheduler started", "jobs", len(s.jobs))
+}
+
+// runJob executes a job on every tick of its interval
+func (s *Scheduler) runJob(ctx context.Context, job scheduledJob, sender domain.MessageSender) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job.run(ctx, sender); err != nil {
+				s.logger.Error("Scheduler job failed", "job", job.name, "error", err)
+			}
+		}
+	}
+}
+
+// BotLocation returns the bot timezone from BOT_TIMEZONE, defaulting to Asia/Tashkent
+func BotLocation() *time.Location {
+	name := os.Getenv("BOT_TIMEZONE")
+	if name == "" {
+		name = defaultBotTimezone
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		// Asia/Tashkent is UTC+5 without daylight saving
+		return time.FixedZone(defaultBotTimezone, 5*60*60)
+	}
+	return location
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxSimulatedLatency caps the delay /simulate latency can add to a command
+const MaxSimulatedLatency = time.Minute
+
+// simulatedRetryAfter is the retry_after of simulated Telegram 429 answers, short so a
+// staging check does not hold up the chat for long
+const simulatedRetryAfter = time.Second
+
+// SimulationState is the set of faults currently injected
+type SimulationState struct {
+	Latency     time.Duration
+	AIFailure   bool
+	Telegram429 int // outgoing messages still to rate limit
+}
+
+// Active reports whether any fault is injected
+func (s SimulationState) Active() bool {
+	return s.Latency > 0 || s.AIFailure || s.Telegram429 > 0
+}
+
+// Simulator injects faults for staging so operators can check fallbacks, retries and
+// error messages before real outages. It does nothing unless SIMULATE_ENABLED=true.
+type Simulator struct {
+	enabled bool
+	state   SimulationState
+	mutex   sync.RWMutex
+}
+
+// NewSimulator creates a simulator from the SIMULATE_ENABLED environment variable
+func NewSimulator() *Simulator {
+	return &Simulator{enabled: strings.EqualFold(os.Getenv("SIMULATE_ENABLED"), "true")}
+}
+
+// Enabled reports whether fault injection is allowed on this deployment
+func (s *Simulator) Enabled() bool {
+	return s != nil && s.enabled
+}
+
+// State returns the injected faults
+func (s *Simulator) State() SimulationState {
+	if !s.Enabled() {
+		return SimulationState{}
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.state
+}
+
+// SetLatency delays every command by the given duration; 0 turns the delay off
+func (s *Simulator) SetLatency(latency time.Duration) error {
+	if latency < 0 || latency > MaxSimulatedLatency {
+		return fmt.Errorf("latency must be between 0 and %s", MaxSimulatedLatency)
+	}
+	s.mutex.Lock()
+	s.state.Latency = latency
+	s.mutex.Unlock()
+	return nil
+}
+
+// SetAIFailure makes every AI provider fail while on
+func (s *Simulator) SetAIFailure(on bool) {
+	s.mutex.Lock()
+	s.state.AIFailure = on
+	s.mutex.Unlock()
+}
+
+// SetTelegram429 makes Telegram rate limit the next count messages the bot sends
+func (s *Simulator) SetTelegram429(count int) {
+	s.mutex.Lock()
+	s.state.Telegram429 = count
+	s.mutex.Unlock()
+}
+
+// Reset removes all injected faults
+func (s *Simulator) Reset() {
+	s.mutex.Lock()
+	s.state = SimulationState{}
+	s.mutex.Unlock()
+}
+
+// Latency returns the delay to add to a command
+func (s *Simulator) Latency() time.Duration {
+	return s.State().Latency
+}
+
+// AIError returns the error AI providers fail with, or nil when AI failures are off
+func (s *Simulator) AIError(provider string) error {
+	if !s.State().AIFailure {
+		return nil
+	}
+	return fmt.Errorf("%s unavailable (simulated AI failure)", provider)
+}
+
+// TelegramError returns a simulated 429 answer to a sendMessage call, consuming one armed
+// failure, or nil when no failure is armed. It is a TelegramAPIError like a real one, so
+// the message is queued and retried after RetryAfter.
+func (s *Simulator) TelegramError() error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.state.Telegram429 <= 0 {
+		return nil
+	}
+	s.state.Telegram429--
+	return &TelegramAPIError{
+		Method:      "sendMessage",
+		StatusCode:  http.StatusTooManyRequests,
+		Description: fmt.Sprintf("Too Many Requests: retry after %d (simulated)", int(simulatedRetryAfter.Seconds())),
+		RetryAfter:  simulatedRetryAfter,
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// maxStatusReportItems limits how many tasks of each kind are listed in the prompt and fallback report
+const maxStatusReportItems = 8
+
+// StatusFacts are the project metrics a status report is written from
+type StatusFacts struct {
+	ProjectName    string
+	Since          time.Time
+	Health         domain.ProjectHealth
+	Deadline       time.Time
+	TotalTasks     int
+	DoneTasks      int
+	RemainingHours float64
+	Completed      []string // finished since Since
+	InProgress     []string
+	Upcoming       []string // open todo tasks, highest priority first
+	Blocked        []string
+}
+
+// StatusReport is a stakeholder-facing project status narrative
+type StatusReport struct {
+	Text     string
+	Language string
+	// Generated is true when the AI wrote the narrative, false for the rule-based fallback
+	Generated bool
+}
+
+// statusReportHeadings are the localized section headings of the rule-based report
+var statusReportHeadings = map[string][4]string{
+	"en": {"Accomplishments", "Upcoming work", "Risks", "Asks"},
+	"uz": {"Erishilgan natijalar", "Keyingi ishlar", "Xavflar", "So'rovlar"},
+	"ru": {"Достижения", "Предстоящая работа", "Риски", "Просьбы"},
+}
+
+// statusReportUnblockAsk is the localized ask to help with blocked tasks
+var statusReportUnblockAsk = map[string]string{
+	"en": "Help needed to unblock: %s",
+	"uz": "Bloklangan vazifalar uchun yordam kerak: %s",
+	"ru": "Нужна помощь с заблокированными задачами: %s",
+}
+
+// StatusReportService writes weekly project status updates with the AI provider chain
+type StatusReportService struct {
+	ai     *AIChain
+	logger domain.Logger
+}
+
+// NewStatusReportService creates a new status report service
+func NewStatusReportService(ai *AIChain, logger domain.Logger) *StatusReportService {
+	return &StatusReportService{
+		ai:     ai,
+		logger: logger,
+	}
+}
+
+// Compose writes a status report in the language (uz, ru or en). Without an AI provider,
+// or when the AI fails, the report lists the facts under localized headings.
+func (s *StatusReportService) Compose(ctx context.Context, facts StatusFacts, language string) (*StatusReport, error) {
+	if _, ok := LanguageNames[language]; !ok {
+		return nil, fmt.Errorf("unsupported language %q, use uz, ru or en", language)
+	}
+
+	if s.ai.IsConfigured() {
+		text, err := s.ai.Complete(ctx, statusReportPrompt(facts, language))
+		if err == nil && strings.TrimSpace(text) != "" {
+			s.logger.Info("Status report generated", "project", facts.ProjectName, "language", language)
+			return &StatusReport{Text: strings.TrimSpace(text), Language: language, Generated: true}, nil
+		}
+		s.logger.Warn("AI status report failed, using project metrics", "project", facts.ProjectName, "error", err)
+	}
+
+	return &StatusReport{Text: ruleBasedStatusReport(facts, language), Language: language}, nil
+}
+
+// statusReportPrompt asks for a short narrative with the four report sections
+func statusReportPrompt(facts StatusFacts, language string) string {
+	return fmt.Sprintf(`You are a project manager writing the weekly status update of the software project "%s" for non-technical stakeholders.
+Write it in %s. Be factual and concise (under 250 words), use only the facts below and do not invent work.
+Use exactly these four sections as bold headings, translated into the report language, with short bullet points ("• ") under each:
+Accomplishments, Upcoming work, Risks, Asks (decisions or help needed from stakeholders; "• none" if there are none).
+Use Telegram Markdown: **bold** headings only, no tables and no # headings.
+
+**Facts:**
+%s`, facts.ProjectName, LanguageNames[language], formatStatusFacts(facts))
+}
+
+// formatStatusFacts lists the facts in plain English for the prompt
+func formatStatusFacts(facts StatusFacts) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("- Period: since %s\n", facts.Since.Format("Mon, Jan 2")))
+	text.WriteString(fmt.Sprintf("- Progress: %d of %d tasks done (%.0f%%), about %sh of work remaining\n",
+		facts.DoneTasks, facts.TotalTasks, facts.Health.Progress*100, domain.FormatHours(facts.RemainingHours)))
+	text.WriteString(fmt.Sprintf("- Health: %s, score %d/100\n", facts.Health.Level, facts.Health.Score))
+	for _, reason := range facts.Health.Reasons {
+		text.WriteString(fmt.Sprintf("  - %s\n", reason))
+	}
+	if !facts.Deadline.IsZero() {
+		text.WriteString(fmt.Sprintf("- Deadline: %s\n", facts.Deadline.Format("Jan 2 2006")))
+	}
+	writeFactList(&text, "Completed this period", facts.Completed)
+	writeFactList(&text, "In progress", facts.InProgress)
+	writeFactList(&text, "Next up", facts.Upcoming)
+	writeFactList(&text, "Blocked", facts.Blocked)
+	return text.String()
+}
+
+// writeFactList writes a titled list of task titles, or "none"
+func writeFactList(text *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		text.WriteString(fmt.Sprintf("- %s: none\n", title))
+		return
+	}
+	text.WriteString(fmt.Sprintf("- %s:\n", title))
+	for _, item := range limitItems(items) {
+		text.WriteString(fmt.Sprintf("  - %s\n", item))
+	}
+}
+
+// ruleBasedStatusReport lists the facts under localized headings
+func ruleBasedStatusReport(facts StatusFacts, language string) string {
+	headings := statusReportHeadings[language]
+
+	var report strings.Builder
+	report.WriteString(fmt.Sprintf("%s %d/%d (%.0f%%) · %sh\n\n", domain.HealthIcon(facts.Health.Level),
+		facts.DoneTasks, facts.TotalTasks, facts.Health.Progress*100, domain.FormatHours(facts.RemainingHours)))
+
+	writeReportSection(&report, headings[0], facts.Completed)
+	writeReportSection(&report, headings[1], append(append([]string(nil), facts.InProgress...), facts.Upcoming...))
+
+	risks := append([]string(nil), facts.Health.Reasons...)
+	for _, blocked := range facts.Blocked {
+		risks = append(risks, "⛔ "+blocked)
+	}
+	writeReportSection(&report, headings[2], risks)
+
+	var asks []string
+	if len(facts.Blocked) > 0 {
+		asks = append(asks, fmt.Sprintf(statusReportUnblockAsk[language], strings.Join(limitItems(facts.Blocked), ", ")))
+	}
+	writeReportSection(&report, headings[3], asks)
+
+	return strings.TrimSpace(report.String())
+}
+
+// writeReportSection writes a bold heading with bullet points, or "—" when empty
+func writeReportSection(report *strings.Builder, heading string, items []string) {
+	report.WriteString(fmt.Sprintf("**%s**\n", heading))
+	if len(items) == 0 {
+		report.WriteString("• —\n\n")
+		return
+	}
+	for _, item := range limitItems(items) {
+		report.WriteString(fmt.Sprintf("• %s\n", item))
+	}
+	report.WriteString("\n")
+}
+
+// limitItems keeps the first maxStatusReportItems items
+func limitItems(items []string) []string {
+	if len(items) > maxStatusReportItems {
+		return items[:maxStatusReportItems]
+	}
+	return items
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+func TestRuleBasedStatusReportIsLocalized(t *testing.T) {
+	facts := StatusFacts{
+		ProjectName:    "Shop",
+		Since:          time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+		Health:         domain.ProjectHealth{Score: 55, Level: domain.HealthYellow, Progress: 0.4, Reasons: []string{"2 tasks overdue"}},
+		TotalTasks:     5,
+		DoneTasks:      2,
+		RemainingHours: 18,
+		Completed:      []string{"Catalog API"},
+		Upcoming:       []string{"Checkout page"},
+		Blocked:        []string{"Payments"},
+	}
+
+	report := ruleBasedStatusReport(facts, "ru")
+	for _, want := range []string{"🟡 2/5 (40%) · 18h", "**Достижения**\n• Catalog API", "• 2 tasks overdue\n• ⛔ Payments", "Нужна помощь с заблокированными задачами: Payments"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+
+	prompt := statusReportPrompt(facts, "uz")
+	for _, want := range []string{"Write it in O'zbek", "- In progress: none", "  - Checkout page"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected prompt to contain %q, got:\n%s", want, prompt)
+		}
+	}
+}
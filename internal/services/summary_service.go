@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// maxSummaryInputChars limits how much chat text is sent to the AI provider
+const maxSummaryInputChars = 12000
+
+// ChatSummary is an AI generated summary of a chat discussion
+type ChatSummary struct {
+	Text        string
+	ActionItems []string
+}
+
+// SummaryService summarizes chat discussions using the AI provider chain
+type SummaryService struct {
+	ai     *AIChain
+	logger domain.Logger
+}
+
+// NewSummaryService creates a new summary service
+func NewSummaryService(ai *AIChain, logger domain.Logger) *SummaryService {
+	return &SummaryService{
+		ai:     ai,
+		logger: logger,
+	}
+}
+
+// IsConfigured returns true if an AI provider is available
+func (s *SummaryService) IsConfigured() bool {
+	return s.ai.IsConfigured()
+}
+
+// Summarize produces a summary with decisions and action items for the messages
+func (s *SummaryService) Summarize(ctx context.Context, messages []BufferedMessage) (*ChatSummary, error) {
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("no messages to summarize")
+	}
+
+	// Keep the most recent messages when the transcript is too long
+	var lines []string
+	total := 0
+	for i := len(messages) - 1; i >= 0; i-- {
+		line := fmt.Sprintf("[%s] %s: %s", messages[i].Timestamp.Format("15:04"), messages[i].Author, messages[i].Text)
+		if total+len(line) > maxSummaryInputChars {
+			break
+		}
+		total += len(line)
+		lines = append([]string{line}, lines...)
+	}
+
+	prompt := fmt.Sprintf(`Summarize this team chat discussion for a software development team.
+Reply in the same language the discussion is mostly written in.
+Use exactly these three sections with these headings:
+
+SUMMARY:
+2-4 short sentences.
+
+DECISIONS:
+- one decision per line (or "- none")
+
+ACTION ITEMS:
+- one action item per line, starting with the owner if known (or "- none")
+
+Chat transcript:
+%s`, strings.Join(lines, "\n"))
+
+	response, err := s.ai.Complete(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Chat summary generated", "messages", len(lines))
+
+	return &ChatSummary{
+		Text:        response,
+		ActionItems: extractActionItems(response),
+	}, nil
+}
+
+// extractActionItems returns the bullet lines listed under the ACTION ITEMS heading
+func extractActionItems(summary string) []string {
+	var items []string
+	inSection := false
+
+	for _, line := range strings.Split(summary, "\n") {
+		trimmed := strings.TrimSpace(line)
+		heading := strings.ToUpper(strings.Trim(trimmed, "*#: "))
+
+		switch {
+		case heading == "ACTION ITEMS":
+			inSection = true
+		case heading == "SUMMARY" || heading == "DECISIONS":
+			inSection = false
+		case inSection && (strings.HasPrefix(trimmed, "-") || strings.HasPrefix(trimmed, "•") || strings.HasPrefix(trimmed, "*")):
+			item := strings.TrimSpace(strings.TrimLeft(trimmed, "-•* "))
+			item = strings.TrimSpace(strings.TrimPrefix(item, "[ ]"))
+			if item != "" && !strings.EqualFold(item, "none") {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"yordamchi-dev-bot/internal/domain"
@@ -13,7 +15,21 @@ type TaskAnalyzer struct {
 	claudeService *ClaudeService
 	openaiService *OpenAIService
 	geminiService *GeminiService
+	telemetry     *Telemetry
+	simulator     *Simulator
+	stats         *AIStats
 	logger        domain.Logger
+	// repairs counts JSON repair round-trips per provider
+	repairs     map[string]*RepairStats
+	repairMutex sync.Mutex
+}
+
+// breakdownProvider is an AI provider that can break requirements down into tasks
+type breakdownProvider interface {
+	TextCompleter
+	modelReporter
+	AnalyzeRequirement(ctx context.Context, req domain.TaskBreakdownRequest) (*domain.TaskBreakdownResponse, error)
+	parseTaskBreakdown(response string) (*domain.TaskBreakdownResponse, error)
 }
 
 func NewTaskAnalyzer(logger domain.Logger) *TaskAnalyzer {
@@ -22,11 +38,40 @@ func NewTaskAnalyzer(logger domain.Logger) *TaskAnalyzer {
 		openaiService: NewOpenAIService(logger),
 		geminiService: NewGeminiService(logger),
 		logger:        logger,
+		repairs:       make(map[string]*RepairStats),
 	}
 }
 
+// SetTelemetry reports which provider answered each analysis in the usage statistics
+func (ta *TaskAnalyzer) SetTelemetry(telemetry *Telemetry) {
+	ta.telemetry = telemetry
+}
+
+// SetSimulator lets /simulate ai_failure make the AI providers fail
+func (ta *TaskAnalyzer) SetSimulator(simulator *Simulator) {
+	ta.simulator = simulator
+}
+
+// SetAIStats counts each provider call in the AI statistics of /metrics
+func (ta *TaskAnalyzer) SetAIStats(stats *AIStats) {
+	ta.stats = stats
+}
+
 // AnalyzeRequirement breaks down a development requirement into tasks
 func (ta *TaskAnalyzer) AnalyzeRequirement(req domain.TaskBreakdownRequest) (*domain.TaskBreakdownResponse, error) {
+	result, err := ta.analyze(req)
+	if err == nil {
+		ta.telemetry.RecordAIProvider(result.Provider)
+		result.ValidationNotes = domain.ValidateBreakdown(result, req.Detail)
+		if len(result.ValidationNotes) > 0 {
+			ta.logger.Warn("AI breakdown adjusted by validation", "provider", result.Provider, "notes", len(result.ValidationNotes))
+		}
+	}
+	return result, err
+}
+
+// analyze runs the provider fallback chain
+func (ta *TaskAnalyzer) analyze(req domain.TaskBreakdownRequest) (*domain.TaskBreakdownResponse, error) {
 	ctx := context.Background()
 	
 	// Intelligent AI fallback chain: Claude → OpenAI → Gemini → Rule-based
@@ -34,8 +79,9 @@ func (ta *TaskAnalyzer) AnalyzeRequirement(req domain.TaskBreakdownRequest) (*do
 	// 1. Try Claude first (most accurate for code analysis and complex reasoning)
 	if ta.claudeService.IsConfigured() {
 		ta.logger.Info("Using Claude AI for task analysis")
-		result, err := ta.claudeService.AnalyzeRequirement(ctx, req)
+		result, err := ta.callProvider(ctx, "claude", ta.claudeService, req)
 		if err == nil {
+			result.Provider = "claude"
 			return result, nil
 		}
 		ta.logger.Error("Claude analysis failed, trying OpenAI", "error", err)
@@ -44,8 +90,9 @@ func (ta *TaskAnalyzer) AnalyzeRequirement(req domain.TaskBreakdownRequest) (*do
 	// 2. Try OpenAI ChatGPT as primary fallback (most widely available and reliable)
 	if ta.openaiService.IsConfigured() {
 		ta.logger.Info("Using OpenAI ChatGPT for task analysis")
-		result, err := ta.openaiService.AnalyzeRequirement(ctx, req)
+		result, err := ta.callProvider(ctx, "openai", ta.openaiService, req)
 		if err == nil {
+			result.Provider = "openai"
 			return result, nil
 		}
 		ta.logger.Error("OpenAI analysis failed, trying Gemini", "error", err)
@@ -54,8 +101,9 @@ func (ta *TaskAnalyzer) AnalyzeRequirement(req domain.TaskBreakdownRequest) (*do
 	// 3. Try Gemini as secondary fallback
 	if ta.geminiService.IsConfigured() {
 		ta.logger.Info("Using Gemini AI for task analysis")
-		result, err := ta.geminiService.AnalyzeRequirement(ctx, req)
+		result, err := ta.callProvider(ctx, "gemini", ta.geminiService, req)
 		if err == nil {
+			result.Provider = "gemini"
 			return result, nil
 		}
 		ta.logger.Error("Gemini analysis failed, using rule-based fallback", "error", err)
@@ -66,6 +114,27 @@ func (ta *TaskAnalyzer) AnalyzeRequirement(req domain.TaskBreakdownRequest) (*do
 	return ta.ruleBasedAnalysis(req)
 }
 
+// callProvider runs an AI analysis unless a simulated AI failure is injected. An answer
+// that cannot be parsed gets one repair round-trip before the next provider is tried.
+func (ta *TaskAnalyzer) callProvider(ctx context.Context, name string, provider breakdownProvider, req domain.TaskBreakdownRequest) (*domain.TaskBreakdownResponse, error) {
+	if err := ta.simulator.AIError(name); err != nil {
+		ta.stats.Record(name, 0, err)
+		return nil, err
+	}
+
+	result, err := provider.AnalyzeRequirement(ctx, req)
+	var parseErr *BreakdownParseError
+	if errors.As(err, &parseErr) {
+		result, err = ta.repairBreakdown(ctx, name, provider, parseErr)
+	}
+	tokens := 0
+	if result != nil {
+		tokens = result.TokensUsed
+	}
+	ta.stats.Record(name, tokens, err)
+	return result, err
+}
+
 // ruleBasedAnalysis provides fallback analysis when AI services are unavailable
 func (ta *TaskAnalyzer) ruleBasedAnalysis(req domain.TaskBreakdownRequest) (*domain.TaskBreakdownResponse, error) {
 	tasks := ta.generateTasks(req.Requirement, req.ProjectType)
@@ -76,6 +145,9 @@ func (ta *TaskAnalyzer) ruleBasedAnalysis(req domain.TaskBreakdownRequest) (*dom
 		tasks[i].EstimateHours = ta.estimateTaskTime(tasks[i])
 		totalEstimate += tasks[i].EstimateHours
 	}
+	if req.Detail == domain.AnalysisDetailBrief {
+		tasks = collapseToEpics(tasks)
+	}
 
 	// Recommend team members based on skills
 	recommendedTeam := ta.recommendTeam(tasks, req.TeamSkills)
@@ -87,6 +159,7 @@ func (ta *TaskAnalyzer) ruleBasedAnalysis(req domain.TaskBreakdownRequest) (*dom
 		CriticalPath:    ta.identifyCriticalPath(tasks),
 		RiskFactors:     ta.identifyRiskFactors(req.Requirement),
 		Confidence:      0.75, // Rule-based confidence level
+		Provider:        "rules",
 	}, nil
 }
 
@@ -319,6 +392,47 @@ func (ta *TaskAnalyzer) identifyRiskFactors(requirement string) []string {
 	return risks
 }
 
+// collapseToEpics merges rule-based tasks into one epic per category for brief analyses.
+// Each epic lists its merged tasks as subtasks.
+func collapseToEpics(tasks []domain.Task) []domain.Task {
+	epicNames := map[string]string{
+		"backend":  "Backend",
+		"frontend": "Frontend",
+		"qa":       "Quality Assurance",
+		"devops":   "Infrastructure & Delivery",
+	}
+
+	var epics []domain.Task
+	index := make(map[string]int)
+	for _, task := range tasks {
+		i, ok := index[task.Category]
+		if !ok {
+			name := epicNames[task.Category]
+			if name == "" {
+				name = strings.Title(task.Category)
+			}
+			index[task.Category] = len(epics)
+			epics = append(epics, domain.Task{
+				ID:          fmt.Sprintf("epic_%s_%d", task.Category, time.Now().UnixNano()),
+				Title:       name + " Epic",
+				Description: "Epic covering all " + task.Category + " work",
+				Category:    task.Category,
+				Priority:    task.Priority,
+			})
+			i = len(epics) - 1
+		}
+		r := domain.TaskEstimateRange(task)
+		epics[i].OptimisticHours += r.Optimistic
+		epics[i].EstimateHours += r.Likely
+		epics[i].PessimisticHours += r.Pessimistic
+		epics[i].Subtasks = append(epics[i].Subtasks, task.Title)
+		if task.Priority < epics[i].Priority {
+			epics[i].Priority = task.Priority
+		}
+	}
+	return epics
+}
+
 func generateID() string {
 	return fmt.Sprintf("task_%d", time.Now().UnixNano())
 }
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// ChatAdministrator is an administrator of a group chat
+type ChatAdministrator struct {
+	UserID    int64
+	Username  string
+	FirstName string
+	IsBot     bool
+	// Status is "creator" or "administrator"
+	Status string
+}
+
+// DisplayName returns the administrator's @username, or their first name without one
+func (a ChatAdministrator) DisplayName() string {
+	if a.Username != "" {
+		return "@" + a.Username
+	}
+	return a.FirstName
+}
+
+// TelegramChatService calls Bot API methods on chats for commands, such as listing a group's
+// administrators or messaging a user directly
+type TelegramChatService struct {
+	telegram *TelegramClient
+	logger   domain.Logger
+}
+
+// NewTelegramChatService creates a new Telegram chat service
+func NewTelegramChatService(telegram *TelegramClient, logger domain.Logger) *TelegramChatService {
+	return &TelegramChatService{
+		telegram: telegram,
+		logger:   logger,
+	}
+}
+
+// GetChatAdministrators returns the administrators of a group, bots included
+func (s *TelegramChatService) GetChatAdministrators(ctx context.Context, chatID int64) ([]ChatAdministrator, error) {
+	var members []struct {
+		Status string `json:"status"`
+		User   struct {
+			ID        int64  `json:"id"`
+			IsBot     bool   `json:"is_bot"`
+			FirstName string `json:"first_name"`
+			Username  string `json:"username"`
+		} `json:"user"`
+	}
+	if err := s.telegram.Call(ctx, "getChatAdministrators", map[string]interface{}{"chat_id": chatID}, &members); err != nil {
+		return nil, err
+	}
+
+	admins := make([]ChatAdministrator, 0, len(members))
+	for _, member := range members {
+		admins = append(admins, ChatAdministrator{
+			UserID:    member.User.ID,
+			Username:  member.User.Username,
+			FirstName: member.User.FirstName,
+			IsBot:     member.User.IsBot,
+			Status:    member.Status,
+		})
+	}
+	return admins, nil
+}
+
+// SendMessage sends a message, e.g. to a user's private chat. It fails when the user never
+// started the bot.
+func (s *TelegramChatService) SendMessage(ctx context.Context, chatID int64, text, parseMode string) error {
+	payload := map[string]interface{}{
+		"chat_id": chatID,
+		"text":    text,
+	}
+	if parseMode != "" {
+		payload["parse_mode"] = parseMode
+	}
+	return s.telegram.Call(ctx, "sendMessage", payload, nil)
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+const (
+	// telegramAPIURL is the Bot API server; endpoints add /bot<token> or /file/bot<token>
+	telegramAPIURL = "https://api.telegram.org"
+	// defaultTelegramTimeout bounds a Bot API call whose context has no deadline
+	defaultTelegramTimeout = 30 * time.Second
+	// maxTelegramResponse is the largest Bot API answer read; file downloads are not limited
+	maxTelegramResponse = 8 << 20
+)
+
+// TelegramAPIError is a Bot API call Telegram refused, with the code, description and
+// wait it answered with
+type TelegramAPIError struct {
+	Method      string
+	StatusCode  int
+	Description string
+	// RetryAfter is how long Telegram asks to wait after a 429 answer
+	RetryAfter time.Duration
+}
+
+func (e *TelegramAPIError) Error() string {
+	return fmt.Sprintf("telegram API error: %d, response: %s", e.StatusCode, e.Description)
+}
+
+// RateLimited reports whether Telegram refused the call for sending too many requests
+func (e *TelegramAPIError) RateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// TelegramClient calls the Bot API for the bot and the services that talk to Telegram.
+// All calls share one pooled HTTP client, whose transport logs and measures them, and
+// are bounded by their context or by defaultTelegramTimeout.
+type TelegramClient struct {
+	endpoint     string
+	fileEndpoint string
+	client       *http.Client
+}
+
+// NewTelegramClient creates a client for the bot with token. transport is usually a
+// TelegramTransport; a nil transport calls Telegram through the pool directly.
+func NewTelegramClient(token string, transport http.RoundTripper) *TelegramClient {
+	if transport == nil {
+		transport = newTelegramPool()
+	}
+	return &TelegramClient{
+		endpoint:     fmt.Sprintf("%s/bot%s", telegramAPIURL, token),
+		fileEndpoint: fmt.Sprintf("%s/file/bot%s", telegramAPIURL, token),
+		client:       &http.Client{Transport: transport},
+	}
+}
+
+// WithEndpoint returns a client for another Bot API server, such as a local one, that
+// shares the connection pool. endpoint replaces https://api.telegram.org/bot<token>.
+func (c *TelegramClient) WithEndpoint(endpoint string) *TelegramClient {
+	endpoint = strings.TrimRight(endpoint, "/")
+	return &TelegramClient{
+		endpoint:     endpoint,
+		fileEndpoint: endpoint + "/file",
+		client:       c.client,
+	}
+}
+
+// newTelegramPool returns the connection pool of Bot API calls. Every call goes to the same
+// host, so it keeps more idle connections per host than the default transport's two.
+func newTelegramPool() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 32
+	transport.IdleConnTimeout = 90 * time.Second
+	return transport
+}
+
+// Call calls a Bot API method with a JSON payload and decodes its result into result,
+// which may be nil
+func (c *TelegramClient) Call(ctx context.Context, method string, payload interface{}, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return c.post(ctx, method, "application/json", bytes.NewReader(body), result)
+}
+
+// CallForm calls a Bot API method with form parameters
+func (c *TelegramClient) CallForm(ctx context.Context, method string, params url.Values, result interface{}) error {
+	return c.post(ctx, method, "application/x-www-form-urlencoded", strings.NewReader(params.Encode()), result)
+}
+
+// Upload calls a Bot API method with a multipart body, e.g. sendDocument
+func (c *TelegramClient) Upload(ctx context.Context, method, contentType string, body io.Reader, result interface{}) error {
+	return c.post(ctx, method, contentType, body, result)
+}
+
+// GetFile returns the path a file can be downloaded from
+func (c *TelegramClient) GetFile(ctx context.Context, fileID string) (*domain.TelegramFile, error) {
+	var file domain.TelegramFile
+	if err := c.Call(ctx, "getFile", map[string]string{"file_id": fileID}, &file); err != nil {
+		return nil, err
+	}
+	if file.FilePath == "" {
+		return nil, fmt.Errorf("file path not available from Telegram")
+	}
+	return &file, nil
+}
+
+// DownloadFile opens a file by the path GetFile returned. The caller closes the body;
+// cancelling ctx stops the download.
+func (c *TelegramClient) DownloadFile(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.fileEndpoint+"/"+filePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", RedactTelegramError(err))
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &TelegramAPIError{Method: "downloadFile", StatusCode: resp.StatusCode, Description: http.StatusText(resp.StatusCode)}
+	}
+	return resp.Body, nil
+}
+
+// post sends a Bot API request and decodes the answer's result
+func (c *TelegramClient) post(ctx context.Context, method, contentType string, body io.Reader, result interface{}) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTelegramTimeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/"+method, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send HTTP request: %w", RedactTelegramError(err))
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxTelegramResponse))
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", method, err)
+	}
+	return decodeTelegramResponse(method, resp.StatusCode, data, result)
+}
+
+// decodeTelegramResponse unpacks the {"ok", "result"} envelope of a Bot API answer
+func decodeTelegramResponse(method string, status int, data []byte, result interface{}) error {
+	var response struct {
+		OK          bool            `json:"ok"`
+		Result      json.RawMessage `json:"result"`
+		Description string          `json:"description"`
+		Parameters  struct {
+			RetryAfter int `json:"retry_after"`
+		} `json:"parameters"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		if status != http.StatusOK {
+			apiErr := &TelegramAPIError{Method: method, StatusCode: status, Description: strings.TrimSpace(string(data))}
+			if apiErr.RateLimited() {
+				// Without parameters Telegram still wants a pause
+				apiErr.RetryAfter = time.Second
+			}
+			return apiErr
+		}
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+
+	if !response.OK || status != http.StatusOK {
+		apiErr := &TelegramAPIError{Method: method, StatusCode: status, Description: response.Description}
+		if apiErr.RateLimited() {
+			apiErr.RetryAfter = time.Duration(response.Parameters.RetryAfter) * time.Second
+		}
+		return apiErr
+	}
+
+	if result != nil && len(response.Result) > 0 {
+		if err := json.Unmarshal(response.Result, result); err != nil {
+			return fmt.Errorf("failed to decode %s result: %w", method, err)
+		}
+	}
+	return nil
+}
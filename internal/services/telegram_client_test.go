@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTelegramClient(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bot/sendMessage":
+			w.Write([]byte(`{"ok":true,"result":{"message_id":7}}`))
+		case "/bot/editMessageText":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: can't parse entities"}`))
+		case "/bot/sendChatAction":
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"ok":false,"error_code":429,"parameters":{"retry_after":17}}`))
+		case "/bot/getFile":
+			w.Write([]byte(`{"ok":true,"result":{"file_id":"f1","file_path":"documents/spec.txt"}}`))
+		case "/bot/file/documents/spec.txt":
+			w.Write([]byte("requirements"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	var connections atomic.Int32
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := NewTelegramClient("token", nil).WithEndpoint(server.URL + "/bot/")
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		var message struct {
+			MessageID int `json:"message_id"`
+		}
+		if err := client.Call(ctx, "sendMessage", map[string]interface{}{"chat_id": 1, "text": "hi"}, &message); err != nil || message.MessageID != 7 {
+			t.Fatalf("Call(sendMessage) = %+v, %v, want message 7", message, err)
+		}
+	}
+	if count := connections.Load(); count != 1 {
+		t.Errorf("opened %d connections for sequential calls, want one reused", count)
+	}
+
+	err := client.Call(ctx, "editMessageText", map[string]interface{}{}, nil)
+	var apiErr *TelegramAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.RateLimited() || !strings.Contains(err.Error(), "can't parse entities") {
+		t.Errorf("Call(editMessageText) error = %v, want a 400 with the description", err)
+	}
+	err = client.Call(ctx, "sendChatAction", map[string]interface{}{}, nil)
+	if !errors.As(err, &apiErr) || !apiErr.RateLimited() || apiErr.RetryAfter != 17*time.Second {
+		t.Errorf("Call(sendChatAction) error = %v, want a rate limit of 17s", err)
+	}
+
+	file, err := client.GetFile(ctx, "f1")
+	if err != nil {
+		t.Fatalf("GetFile() error = %v", err)
+	}
+	body, err := client.DownloadFile(ctx, file.FilePath)
+	if err != nil {
+		t.Fatalf("DownloadFile() error = %v", err)
+	}
+	defer body.Close()
+	if content, _ := io.ReadAll(body); string(content) != "requirements" {
+		t.Errorf("downloaded %q, want the file content", content)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := client.Call(cancelled, "sendMessage", map[string]interface{}{}, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Call() with a cancelled context error = %v, want context.Canceled", err)
+	}
+}
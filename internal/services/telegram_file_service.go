@@ -1,10 +1,9 @@
 package services
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"time"
@@ -12,51 +11,44 @@ import (
 	"yordamchi-dev-bot/internal/domain"
 )
 
+// fileDownloadTimeout bounds getting and downloading one file
+const fileDownloadTimeout = 2 * time.Minute
+
 // TelegramFileService handles file downloads from Telegram
 type TelegramFileService struct {
-	botToken string
+	telegram *TelegramClient
 	logger   domain.Logger
-	client   *http.Client
 }
 
 // NewTelegramFileService creates a new Telegram file service
-func NewTelegramFileService(botToken string, logger domain.Logger) *TelegramFileService {
+func NewTelegramFileService(telegram *TelegramClient, logger domain.Logger) *TelegramFileService {
 	return &TelegramFileService{
-		botToken: botToken,
+		telegram: telegram,
 		logger:   logger,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
 	}
 }
 
 // DownloadFile downloads a file from Telegram servers to a temporary location
-func (s *TelegramFileService) DownloadFile(document *domain.TelegramDocument) (string, error) {
+func (s *TelegramFileService) DownloadFile(ctx context.Context, document *domain.TelegramDocument) (string, error) {
 	s.logger.Info("Starting file download", "file_id", document.FileID, "filename", document.FileName)
 	
+	// Downloads of large files take longer than a Bot API call, but not forever
+	ctx, cancel := context.WithTimeout(ctx, fileDownloadTimeout)
+	defer cancel()
+	
 	// 1. Get file info from Telegram
-	fileInfo, err := s.getFileInfo(document.FileID)
+	fileInfo, err := s.telegram.GetFile(ctx, document.FileID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get file info: %v", err)
-	}
-	
-	if fileInfo.FilePath == "" {
-		return "", fmt.Errorf("file path not available from Telegram")
+		return "", fmt.Errorf("failed to get file info: %w", err)
 	}
 	
 	// 2. Download file from Telegram servers
-	downloadURL := fmt.Sprintf("https://api.telegram.org/file/bot%s/%s", s.botToken, fileInfo.FilePath)
-	
-	resp, err := s.client.Get(downloadURL)
+	body, err := s.telegram.DownloadFile(ctx, fileInfo.FilePath)
 	if err != nil {
-		s.logger.Error("Failed to download file from Telegram", "error", err, "url", downloadURL)
-		return "", fmt.Errorf("failed to download file: %v", err)
-	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to download file: HTTP %d", resp.StatusCode)
+		s.logger.Error("Failed to download file from Telegram", "error", err, "file_id", document.FileID)
+		return "", err
 	}
+	defer body.Close()
 	
 	// 3. Create temporary file
 	tempDir := os.TempDir()
@@ -69,7 +61,7 @@ func (s *TelegramFileService) DownloadFile(document *domain.TelegramDocument) (s
 	defer file.Close()
 	
 	// 4. Copy file content
-	_, err = io.Copy(file, resp.Body)
+	_, err = io.Copy(file, body)
 	if err != nil {
 		os.Remove(tempFile) // Clean up on error
 		return "", fmt.Errorf("failed to save file: %v", err)
@@ -83,37 +75,6 @@ func (s *TelegramFileService) DownloadFile(document *domain.TelegramDocument) (s
 	return tempFile, nil
 }
 
-// getFileInfo gets file information from Telegram Bot API
-func (s *TelegramFileService) getFileInfo(fileID string) (*domain.TelegramFile, error) {
-	url := fmt.Sprintf("https://api.telegram.org/bot%s/getFile?file_id=%s", s.botToken, fileID)
-	
-	resp, err := s.client.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Telegram API returned status %d", resp.StatusCode)
-	}
-	
-	var result struct {
-		OK     bool                `json:"ok"`
-		Result *domain.TelegramFile `json:"result"`
-		Description string           `json:"description,omitempty"`
-	}
-	
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %v", err)
-	}
-	
-	if !result.OK {
-		return nil, fmt.Errorf("Telegram API error: %s", result.Description)
-	}
-	
-	return result.Result, nil
-}
-
 // CleanupFile removes a temporary file
 func (s *TelegramFileService) CleanupFile(filePath string) error {
 	if filePath == "" {
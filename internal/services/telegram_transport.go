@@ -0,0 +1,207 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Chat redaction levels of TELEGRAM_LOG_REDACT. Bot tokens are always removed.
+const (
+	// RedactChatsNone logs chat IDs as they are
+	RedactChatsNone = "none"
+	// RedactChatsUsers replaces the IDs of private chats, which are user IDs, with a pseudonym
+	// and keeps group and channel IDs
+	RedactChatsUsers = "users"
+	// RedactChatsAll replaces every chat ID with a pseudonym
+	RedactChatsAll = "all"
+)
+
+// maxChatLookupBytes is how much of a request body is searched for its chat_id
+const maxChatLookupBytes = 64 << 10
+
+// telegramTokenPattern matches a bot token, e.g. in a request URL quoted by an error
+var telegramTokenPattern = regexp.MustCompile(`\d{5,}:[A-Za-z0-9_-]{30,}`)
+
+// RedactTelegramToken replaces bot tokens in a text
+func RedactTelegramToken(text string) string {
+	return telegramTokenPattern.ReplaceAllString(text, "<token>")
+}
+
+// RedactTelegramError removes the bot token from the URL an HTTP client error quotes,
+// keeping the error chain intact
+func RedactTelegramError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = RedactTelegramToken(urlErr.URL)
+	}
+	return err
+}
+
+// TelegramCallRecorder receives the outcome of every Bot API call
+type TelegramCallRecorder interface {
+	RecordTelegramCall(method string, failed bool, duration time.Duration)
+}
+
+// TelegramRedaction decides how chats appear in Telegram API logs. Pseudonyms are keyed
+// with a secret, so the same chat keeps its pseudonym across log lines without the ID
+// being recoverable from it.
+type TelegramRedaction struct {
+	Chats string
+	key   []byte
+}
+
+// TelegramRedactionFromEnv reads TELEGRAM_LOG_REDACT (none, users or all; users when unset)
+// and keys pseudonyms with the bot token, so they stay stable across restarts
+func TelegramRedactionFromEnv(token string) TelegramRedaction {
+	chats := strings.ToLower(strings.TrimSpace(os.Getenv("TELEGRAM_LOG_REDACT")))
+	switch chats {
+	case RedactChatsNone, RedactChatsAll:
+	default:
+		chats = RedactChatsUsers
+	}
+
+	key := []byte(token)
+	if token == "" {
+		key = make([]byte, 32)
+		rand.Read(key)
+	}
+	return TelegramRedaction{Chats: chats, key: key}
+}
+
+// Chat returns how a chat ID is logged
+func (r TelegramRedaction) Chat(chatID string) string {
+	private := !strings.HasPrefix(chatID, "-") && !strings.HasPrefix(chatID, "@")
+	if r.Chats == RedactChatsNone || (r.Chats == RedactChatsUsers && !private) {
+		return chatID
+	}
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(chatID))
+	prefix := "chat:"
+	if private {
+		prefix = "user:"
+	}
+	return prefix + hex.EncodeToString(mac.Sum(nil))[:10]
+}
+
+// TelegramTransport is the HTTP transport of Bot API calls. It logs the method, chat,
+// latency and status of every call, with the token removed and chats redacted by the
+// policy, and reports each call to the metrics.
+type TelegramTransport struct {
+	base      http.RoundTripper
+	logger    domain.Logger
+	recorder  TelegramCallRecorder
+	redaction TelegramRedaction
+}
+
+// NewTelegramTransport wraps base, the shared Bot API connection pool when nil; recorder may be nil
+func NewTelegramTransport(base http.RoundTripper, redaction TelegramRedaction, logger domain.Logger, recorder TelegramCallRecorder) *TelegramTransport {
+	if base == nil {
+		base = newTelegramPool()
+	}
+	return &TelegramTransport{
+		base:      base,
+		logger:    logger,
+		recorder:  recorder,
+		redaction: redaction,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *TelegramTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method := telegramMethod(req.URL.Path)
+	fields := []interface{}{"method", method}
+	if chatID := requestChatID(req); chatID != "" {
+		fields = append(fields, "chat", t.redaction.Chat(chatID))
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := time.Since(start)
+	fields = append(fields, "latency_ms", duration.Milliseconds())
+
+	failed := err != nil || resp.StatusCode >= 400
+	if t.recorder != nil {
+		t.recorder.RecordTelegramCall(method, failed, duration)
+	}
+
+	switch {
+	case err != nil:
+		t.logger.Warn("Telegram API call failed", append(fields, "error", RedactTelegramToken(err.Error()))...)
+	case resp.StatusCode >= 400:
+		t.logger.Warn("Telegram API call rejected", append(fields, "status", resp.StatusCode)...)
+	default:
+		t.logger.Debug("Telegram API call", append(fields, "status", resp.StatusCode)...)
+	}
+	return resp, err
+}
+
+// telegramMethod returns the Bot API method of a request path such as /bot<token>/sendMessage;
+// file downloads are reported as downloadFile
+func telegramMethod(requestPath string) string {
+	if strings.HasPrefix(requestPath, "/file/") {
+		return "downloadFile"
+	}
+	return path.Base(requestPath)
+}
+
+// requestChatID returns the chat_id parameter of a request from its query or a copy of its
+// JSON, form or multipart body, or "" when it has none
+func requestChatID(req *http.Request) string {
+	if chatID := req.URL.Query().Get("chat_id"); chatID != "" {
+		return chatID
+	}
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	limited := io.LimitReader(body, maxChatLookupBytes)
+
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		var payload struct {
+			ChatID json.RawMessage `json:"chat_id"`
+		}
+		if json.NewDecoder(limited).Decode(&payload) != nil {
+			return ""
+		}
+		return strings.Trim(string(payload.ChatID), `"`)
+	case "application/x-www-form-urlencoded":
+		data, _ := io.ReadAll(limited)
+		values, _ := url.ParseQuery(string(data))
+		return values.Get("chat_id")
+	case "multipart/form-data":
+		reader := multipart.NewReader(limited, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				return ""
+			}
+			if part.FormName() == "chat_id" {
+				value, _ := io.ReadAll(io.LimitReader(part, 64))
+				return string(value)
+			}
+		}
+	}
+	return ""
+}
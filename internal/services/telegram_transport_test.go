@@ -0,0 +1,103 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordedCall is a Bot API call reported to a callRecorder
+type recordedCall struct {
+	method string
+	failed bool
+}
+
+// callRecorder keeps the reported Bot API calls
+type callRecorder struct {
+	calls []recordedCall
+}
+
+func (r *callRecorder) RecordTelegramCall(method string, failed bool, duration time.Duration) {
+	r.calls = append(r.calls, recordedCall{method: method, failed: failed})
+}
+
+func TestTelegramTransportRecordsCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/sendMessage") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	recorder := &callRecorder{}
+	redaction := TelegramRedaction{Chats: RedactChatsUsers, key: []byte("key")}
+	client := &http.Client{Transport: NewTelegramTransport(nil, redaction, silentLogger{}, recorder)}
+	token := "123456789:AAHdqTcvCH1vGWJxfSeofSAs0K5PALDsaw1"
+
+	resp, err := client.Post(server.URL+"/bot"+token+"/sendMessage", "application/json", strings.NewReader(`{"chat_id":42,"text":"hi"}`))
+	if err != nil {
+		t.Fatalf("sendMessage error = %v", err)
+	}
+	resp.Body.Close()
+	resp, err = client.PostForm(server.URL+"/bot"+token+"/getUpdates", url.Values{"timeout": {"30"}})
+	if err != nil {
+		t.Fatalf("getUpdates error = %v", err)
+	}
+	resp.Body.Close()
+
+	want := []recordedCall{{method: "sendMessage", failed: true}, {method: "getUpdates", failed: false}}
+	if len(recorder.calls) != len(want) {
+		t.Fatalf("recorded %+v, want %+v", recorder.calls, want)
+	}
+	for i := range want {
+		if recorder.calls[i] != want[i] {
+			t.Errorf("call %d = %+v, want %+v", i, recorder.calls[i], want[i])
+		}
+	}
+}
+
+func TestTelegramRedaction(t *testing.T) {
+	err := &url.Error{Op: "Post", URL: "https://api.telegram.org/bot123456789:AAHdqTcvCH1vGWJxfSeofSAs0K5PALDsaw1/sendMessage", Err: http.ErrHandlerTimeout}
+	if text := RedactTelegramError(err).Error(); strings.Contains(text, "AAHdqTcv") || !strings.Contains(text, "/bot<token>/sendMessage") {
+		t.Errorf("RedactTelegramError() = %q, want the token removed", text)
+	}
+
+	users := TelegramRedaction{Chats: RedactChatsUsers, key: []byte("key")}
+	if chat := users.Chat("-100123"); chat != "-100123" {
+		t.Errorf("users policy logged group as %q, want its ID", chat)
+	}
+	pseudonym := users.Chat("42")
+	if !strings.HasPrefix(pseudonym, "user:") || strings.Contains(pseudonym, "42") || users.Chat("42") != pseudonym {
+		t.Errorf("users policy logged private chat as %q, want a stable pseudonym", pseudonym)
+	}
+	if chat := (TelegramRedaction{Chats: RedactChatsAll, key: []byte("key")}).Chat("-100123"); !strings.HasPrefix(chat, "chat:") {
+		t.Errorf("all policy logged group as %q, want a pseudonym", chat)
+	}
+	if chat := (TelegramRedaction{Chats: RedactChatsNone}).Chat("42"); chat != "42" {
+		t.Errorf("none policy logged private chat as %q, want its ID", chat)
+	}
+}
+
+func TestRequestChatID(t *testing.T) {
+	jsonRequest, _ := http.NewRequest(http.MethodPost, "https://api.telegram.org/botX/sendMessage", strings.NewReader(`{"chat_id":"-100123","text":"hi"}`))
+	jsonRequest.Header.Set("Content-Type", "application/json")
+	formRequest, _ := http.NewRequest(http.MethodPost, "https://api.telegram.org/botX/sendChatAction", strings.NewReader("chat_id=42&action=typing"))
+	formRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	queryRequest, _ := http.NewRequest(http.MethodGet, "https://api.telegram.org/botX/getChat?chat_id=7", nil)
+	noChatRequest, _ := http.NewRequest(http.MethodPost, "https://api.telegram.org/botX/getMe", nil)
+
+	for request, want := range map[*http.Request]string{
+		jsonRequest:   "-100123",
+		formRequest:   "42",
+		queryRequest:  "7",
+		noChatRequest: "",
+	} {
+		if got := requestChatID(request); got != want {
+			t.Errorf("requestChatID(%s) = %q, want %q", request.URL.Path, got, want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// telemetryInstallIDKey stores the random install ID under chat 0, which no Telegram chat uses
+const telemetryInstallIDKey = "telemetry_install_id"
+
+// TelemetryReport is the anonymized usage summary sent to the telemetry endpoint.
+// It carries aggregate counts only: no chat, user or message data.
+type TelemetryReport struct {
+	InstallID     string           `json:"install_id"`
+	PeriodStart   time.Time        `json:"period_start"`
+	PeriodEnd     time.Time        `json:"period_end"`
+	Requests      int64            `json:"requests"`
+	Errors        int64            `json:"errors"`
+	Commands      map[string]int64 `json:"commands"`
+	CommandErrors map[string]int64 `json:"command_errors"`
+	AIProviders   map[string]int64 `json:"ai_providers"`
+}
+
+// Telemetry collects anonymized usage statistics and reports them to a configurable
+// endpoint. It is opt-in per deployment: nothing is collected unless TELEMETRY_ENDPOINT
+// is set, and TELEMETRY_OPT_OUT=true turns it off even when an endpoint is configured.
+type Telemetry struct {
+	endpoint      string
+	store         ChatSettingsStore
+	client        *http.Client
+	logger        domain.Logger
+	mutex         sync.Mutex
+	periodStart   time.Time
+	requests      int64
+	errors        int64
+	commands      map[string]int64
+	commandErrors map[string]int64
+	providers     map[string]int64
+}
+
+// NewTelemetry creates telemetry from the TELEMETRY_* environment variables
+func NewTelemetry(store ChatSettingsStore, logger domain.Logger) *Telemetry {
+	endpoint := strings.TrimSpace(os.Getenv("TELEMETRY_ENDPOINT"))
+	if strings.EqualFold(os.Getenv("TELEMETRY_OPT_OUT"), "true") {
+		endpoint = ""
+	}
+	return newTelemetry(endpoint, store, logger)
+}
+
+func newTelemetry(endpoint string, store ChatSettingsStore, logger domain.Logger) *Telemetry {
+	return &Telemetry{
+		endpoint:      endpoint,
+		store:         store,
+		client:        &http.Client{Timeout: 10 * time.Second},
+		logger:        logger,
+		periodStart:   time.Now(),
+		commands:      make(map[string]int64),
+		commandErrors: make(map[string]int64),
+		providers:     make(map[string]int64),
+	}
+}
+
+// Enabled reports whether usage statistics are collected and reported
+func (t *Telemetry) Enabled() bool {
+	return t != nil && t.endpoint != ""
+}
+
+// RecordCommand counts a handled command. command is the registered command name only,
+// e.g. "/analyze", never the message text; anything else, such as the data of a button
+// press, is ignored.
+func (t *Telemetry) RecordCommand(command string, failed bool) {
+	if !t.Enabled() || !strings.HasPrefix(command, "/") || strings.ContainsAny(command, " @") {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.requests++
+	t.commands[command]++
+	if failed {
+		t.errors++
+		t.commandErrors[command]++
+	}
+}
+
+// RecordAIProvider counts an AI request answered by the given provider
+func (t *Telemetry) RecordAIProvider(provider string) {
+	if !t.Enabled() || provider == "" {
+		return
+	}
+
+	t.mutex.Lock()
+	t.providers[provider]++
+	t.mutex.Unlock()
+}
+
+// Flush sends the statistics collected since the last successful report. Counts are
+// kept for the next attempt when the endpoint cannot be reached.
+func (t *Telemetry) Flush(ctx context.Context) error {
+	if !t.Enabled() {
+		return nil
+	}
+
+	installID, err := t.installID()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	report := t.snapshot(installID, now)
+	if report.Requests == 0 && len(report.AIProviders) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal telemetry report: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create telemetry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("telemetry request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint returned %d", resp.StatusCode)
+	}
+
+	t.subtract(report, now)
+	t.logger.Info("Telemetry report sent", "requests", report.Requests, "commands", len(report.Commands))
+	return nil
+}
+
+// snapshot copies the current counters into a report
+func (t *Telemetry) snapshot(installID string, now time.Time) TelemetryReport {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return TelemetryReport{
+		InstallID:     installID,
+		PeriodStart:   t.periodStart.UTC().Truncate(time.Second),
+		PeriodEnd:     now.UTC().Truncate(time.Second),
+		Requests:      t.requests,
+		Errors:        t.errors,
+		Commands:      copyCounts(t.commands),
+		CommandErrors: copyCounts(t.commandErrors),
+		AIProviders:   copyCounts(t.providers),
+	}
+}
+
+// subtract removes reported counts, keeping what was recorded while the report was sent
+func (t *Telemetry) subtract(report TelemetryReport, periodEnd time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.periodStart = periodEnd
+	t.requests -= report.Requests
+	t.errors -= report.Errors
+	subtractCounts(t.commands, report.Commands)
+	subtractCounts(t.commandErrors, report.CommandErrors)
+	subtractCounts(t.providers, report.AIProviders)
+}
+
+// installID returns the random ID of this deployment, creating it on first use
+func (t *Telemetry) installID() (string, error) {
+	settings, err := t.store.GetChatSettings(0)
+	if err != nil {
+		return "", fmt.Errorf("failed to load telemetry install ID: %w", err)
+	}
+	if id := settings[telemetryInstallIDKey]; id != "" {
+		return id, nil
+	}
+
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate telemetry install ID: %w", err)
+	}
+	id := hex.EncodeToString(raw)
+	if err := t.store.SetChatSetting(0, telemetryInstallIDKey, id); err != nil {
+		return "", fmt.Errorf("failed to save telemetry install ID: %w", err)
+	}
+	return id, nil
+}
+
+func copyCounts(counts map[string]int64) map[string]int64 {
+	result := make(map[string]int64, len(counts))
+	for key, count := range counts {
+		result[key] = count
+	}
+	return result
+}
+
+func subtractCounts(counts, reported map[string]int64) {
+	for key, count := range reported {
+		counts[key] -= count
+		if counts[key] <= 0 {
+			delete(counts, key)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+type memorySettings map[int64]map[string]string
+
+func (m memorySettings) GetChatSettings(chatID int64) (map[string]string, error) {
+	return m[chatID], nil
+}
+
+func (m memorySettings) SetChatSetting(chatID int64, key, value string) error {
+	if m[chatID] == nil {
+		m[chatID] = make(map[string]string)
+	}
+	m[chatID][key] = value
+	return nil
+}
+
+func (m memorySettings) DeleteChatSetting(chatID int64, key string) error {
+	delete(m[chatID], key)
+	return nil
+}
+
+type silentLogger struct{}
+
+func (silentLogger) Debug(string, ...interface{})        {}
+func (silentLogger) Info(string, ...interface{})         {}
+func (silentLogger) Warn(string, ...interface{})         {}
+func (silentLogger) Error(string, ...interface{})        {}
+func (l silentLogger) With(...interface{}) domain.Logger { return l }
+
+func TestTelemetryDisabledWithoutEndpoint(t *testing.T) {
+	telemetry := newTelemetry("", memorySettings{}, silentLogger{})
+	telemetry.RecordCommand("/analyze", false)
+
+	if telemetry.Enabled() || telemetry.requests != 0 {
+		t.Errorf("expected disabled telemetry to record nothing, got %d requests", telemetry.requests)
+	}
+	if err := telemetry.Flush(context.Background()); err != nil {
+		t.Errorf("expected no-op flush, got %v", err)
+	}
+}
+
+func TestTelemetryRecordsCommandNamesOnly(t *testing.T) {
+	telemetry := newTelemetry("https://telemetry.example.com", memorySettings{}, silentLogger{})
+	telemetry.RecordCommand("/analyze", false)
+	telemetry.RecordCommand("projects:open:proj_1a2b3c", false)
+	telemetry.RecordCommand("/analyze@other_bot", false)
+
+	if len(telemetry.commands) != 1 || telemetry.commands["/analyze"] != 1 || telemetry.requests != 1 {
+		t.Errorf("commands = %v, want only /analyze", telemetry.commands)
+	}
+}
+
+func TestTelemetryFlushReportsAndResets(t *testing.T) {
+	var reports []TelemetryReport
+	status := http.StatusInternalServerError
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report TelemetryReport
+		if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+			t.Fatalf("invalid report: %v", err)
+		}
+		reports = append(reports, report)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	settings := memorySettings{}
+	telemetry := newTelemetry(server.URL, settings, silentLogger{})
+	telemetry.RecordCommand("/analyze", false)
+	telemetry.RecordCommand("/analyze", true)
+	telemetry.RecordCommand("/help", false)
+	telemetry.RecordAIProvider("claude")
+
+	// A failed report keeps the counts for the next attempt
+	if err := telemetry.Flush(context.Background()); err == nil {
+		t.Fatal("expected an error from the failing endpoint")
+	}
+	status = http.StatusOK
+	if err := telemetry.Flush(context.Background()); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	report := reports[1]
+	if report.InstallID == "" || report.InstallID != reports[0].InstallID || settings[0][telemetryInstallIDKey] != report.InstallID {
+		t.Errorf("expected a stable stored install ID, got %q and %q", reports[0].InstallID, report.InstallID)
+	}
+	if report.Requests != 3 || report.Errors != 1 || report.Commands["/analyze"] != 2 ||
+		report.CommandErrors["/analyze"] != 1 || report.AIProviders["claude"] != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	// Reported counts are cleared, so an idle period sends nothing
+	if err := telemetry.Flush(context.Background()); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	if len(reports) != 2 {
+		t.Errorf("expected no report without new usage, got %d reports", len(reports))
+	}
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// maxTestPlanTasks limits how many tasks are sent to the AI provider for one test plan
+const maxTestPlanTasks = 60
+
+// defaultScenarioHours is the effort of a rule-based test scenario
+const defaultScenarioHours = 0.5
+
+// TestScenario is one QA scenario of a test plan
+type TestScenario struct {
+	Title         string   `json:"title"`
+	Steps         []string `json:"steps"`
+	Expected      string   `json:"expected"`
+	Priority      int      `json:"priority"` // 1 high, 2 medium, 3 low
+	EstimateHours float64  `json:"estimate_hours"`
+	TaskID        string   `json:"task_id"` // task the scenario verifies
+}
+
+// TestFeature groups the scenarios that cover one feature
+type TestFeature struct {
+	Name      string         `json:"name"`
+	Scenarios []TestScenario `json:"scenarios"`
+}
+
+// TestPlan is a structured QA test plan generated from a project's tasks
+type TestPlan struct {
+	Features []TestFeature `json:"features"`
+	// Generated is false when the plan was derived from acceptance criteria without AI
+	Generated bool `json:"-"`
+}
+
+// ScenarioCount returns the number of scenarios in the plan
+func (p *TestPlan) ScenarioCount() int {
+	count := 0
+	for _, feature := range p.Features {
+		count += len(feature.Scenarios)
+	}
+	return count
+}
+
+// TotalHours returns the estimated effort of all scenarios
+func (p *TestPlan) TotalHours() float64 {
+	total := 0.0
+	for _, feature := range p.Features {
+		for _, scenario := range feature.Scenarios {
+			total += scenario.EstimateHours
+		}
+	}
+	return total
+}
+
+// Markdown renders the plan as a Markdown document
+func (p *TestPlan) Markdown(projectName string) string {
+	var doc strings.Builder
+	doc.WriteString(fmt.Sprintf("# Test Plan: %s\n\n", projectName))
+	doc.WriteString(fmt.Sprintf("%d scenarios in %d features, estimated %.1f hours.\n", p.ScenarioCount(), len(p.Features), p.TotalHours()))
+
+	for _, feature := range p.Features {
+		doc.WriteString(fmt.Sprintf("\n## %s\n", feature.Name))
+		for i, scenario := range feature.Scenarios {
+			doc.WriteString(fmt.Sprintf("\n### %d. %s\n\n", i+1, scenario.Title))
+			doc.WriteString(fmt.Sprintf("- **Priority:** %s\n", scenarioPriorityName(scenario.Priority)))
+			doc.WriteString(fmt.Sprintf("- **Effort:** %.1fh\n", scenario.EstimateHours))
+			if scenario.TaskID != "" {
+				doc.WriteString(fmt.Sprintf("- **Task:** `%s`\n", scenario.TaskID))
+			}
+			if len(scenario.Steps) > 0 {
+				doc.WriteString("\n**Steps:**\n\n")
+				for j, step := range scenario.Steps {
+					doc.WriteString(fmt.Sprintf("%d. %s\n", j+1, step))
+				}
+			}
+			if scenario.Expected != "" {
+				doc.WriteString(fmt.Sprintf("\n**Expected:** %s\n", scenario.Expected))
+			}
+		}
+	}
+
+	return doc.String()
+}
+
+// scenarioPriorityName names a scenario priority
+func scenarioPriorityName(priority int) string {
+	switch priority {
+	case 1:
+		return "high"
+	case 2:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// TestPlanService generates QA test plans from project tasks and their acceptance criteria
+type TestPlanService struct {
+	ai     *AIChain
+	logger domain.Logger
+}
+
+// NewTestPlanService creates a new test plan service
+func NewTestPlanService(ai *AIChain, logger domain.Logger) *TestPlanService {
+	return &TestPlanService{
+		ai:     ai,
+		logger: logger,
+	}
+}
+
+// Generate builds a test plan for the tasks. Without an AI provider, or when the AI
+// response can't be used, the plan is derived from the acceptance criteria.
+func (s *TestPlanService) Generate(ctx context.Context, projectName string, tasks []domain.Task) (*TestPlan, error) {
+	var planned []domain.Task
+	for _, task := range tasks {
+		if task.Category != "qa" {
+			planned = append(planned, task)
+		}
+	}
+	if len(planned) == 0 {
+		return nil, fmt.Errorf("no tasks to test")
+	}
+	if len(planned) > maxTestPlanTasks {
+		planned = planned[:maxTestPlanTasks]
+	}
+
+	if s.ai.IsConfigured() {
+		plan, err := s.generateWithAI(ctx, projectName, planned)
+		if err == nil {
+			return plan, nil
+		}
+		s.logger.Warn("AI test plan failed, using acceptance criteria", "error", err)
+	}
+
+	return ruleBasedTestPlan(planned), nil
+}
+
+// generateWithAI asks the AI chain for a JSON test plan
+func (s *TestPlanService) generateWithAI(ctx context.Context, projectName string, tasks []domain.Task) (*TestPlan, error) {
+	var taskList strings.Builder
+	for _, task := range tasks {
+		taskList.WriteString(fmt.Sprintf("- [%s] %s (id %s, %s, priority %d)", task.Category, task.Title, task.ID, task.Status, task.Priority))
+		if task.Description != "" {
+			taskList.WriteString(": " + task.Description)
+		}
+		taskList.WriteString("\n")
+		for _, criterion := range task.AcceptanceCriteria {
+			taskList.WriteString(fmt.Sprintf("  - Acceptance: %s\n", criterion))
+		}
+	}
+
+	prompt := fmt.Sprintf(`You are a senior QA engineer. Write a test plan for the project "%s".
+
+**Tasks:**
+%s
+Provide the test plan in the following JSON format:
+
+{
+  "features": [
+    {
+      "name": "Feature name",
+      "scenarios": [
+        {
+          "title": "Scenario title",
+          "steps": ["Step 1", "Step 2"],
+          "expected": "Expected result",
+          "priority": 1,
+          "estimate_hours": 0.5,
+          "task_id": "id of the task it verifies"
+        }
+      ]
+    }
+  ]
+}
+
+Guidelines:
+- Group scenarios by user-facing feature, not by task
+- Cover every acceptance criterion with at least one scenario
+- Include negative and edge cases for high-priority tasks
+- Use priority 1 (high), 2 (medium), 3 (low)
+- Estimate the effort to prepare and run each scenario in hours
+
+Respond only with valid JSON.`, projectName, taskList.String())
+
+	response, err := s.ai.Complete(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+
+	var plan TestPlan
+	if err := json.Unmarshal([]byte(strings.TrimSpace(response)), &plan); err != nil {
+		return nil, fmt.Errorf("invalid test plan JSON: %w", err)
+	}
+	if plan.ScenarioCount() == 0 {
+		return nil, fmt.Errorf("test plan has no scenarios")
+	}
+
+	for i := range plan.Features {
+		for j := range plan.Features[i].Scenarios {
+			scenario := &plan.Features[i].Scenarios[j]
+			if scenario.Priority < 1 || scenario.Priority > 3 {
+				scenario.Priority = 2
+			}
+			if scenario.EstimateHours <= 0 {
+				scenario.EstimateHours = defaultScenarioHours
+			}
+		}
+	}
+	plan.Generated = true
+
+	s.logger.Info("Test plan generated", "project", projectName, "features", len(plan.Features), "scenarios", plan.ScenarioCount())
+	return &plan, nil
+}
+
+// ruleBasedTestPlan turns every acceptance criterion into a scenario, grouped by task
+// category. Tasks without criteria get one scenario that checks the task as described.
+func ruleBasedTestPlan(tasks []domain.Task) *TestPlan {
+	plan := &TestPlan{}
+	index := make(map[string]int)
+
+	for _, task := range tasks {
+		category := task.Category
+		if category == "" {
+			category = "general"
+		}
+		i, ok := index[category]
+		if !ok {
+			i = len(plan.Features)
+			index[category] = i
+			plan.Features = append(plan.Features, TestFeature{Name: strings.Title(category)})
+		}
+
+		priority := task.Priority
+		if priority < 1 || priority > 3 {
+			priority = 3
+		}
+
+		if len(task.AcceptanceCriteria) == 0 {
+			plan.Features[i].Scenarios = append(plan.Features[i].Scenarios, TestScenario{
+				Title:         "Verify " + task.Title,
+				Expected:      "Works as described in the task",
+				Priority:      priority,
+				EstimateHours: defaultScenarioHours,
+				TaskID:        task.ID,
+			})
+			continue
+		}
+
+		for _, criterion := range task.AcceptanceCriteria {
+			plan.Features[i].Scenarios = append(plan.Features[i].Scenarios, TestScenario{
+				Title:         fmt.Sprintf("%s: %s", task.Title, criterion),
+				Expected:      criterion,
+				Priority:      priority,
+				EstimateHours: defaultScenarioHours,
+				TaskID:        task.ID,
+			})
+		}
+	}
+
+	return plan
+}
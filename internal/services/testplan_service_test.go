@@ -0,0 +1,35 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+func TestRuleBasedTestPlanUsesAcceptanceCriteria(t *testing.T) {
+	plan := ruleBasedTestPlan([]domain.Task{
+		{ID: "t1", Title: "Login", Category: "backend", Priority: 1, AcceptanceCriteria: []string{
+			"Given a user, when they log in, then they see the dashboard",
+			"Given a wrong password, when they log in, then an error is shown",
+		}},
+		{ID: "t2", Title: "Cart page", Category: "frontend", Priority: 2},
+	})
+
+	if len(plan.Features) != 2 || plan.ScenarioCount() != 3 {
+		t.Fatalf("expected 3 scenarios in 2 features, got %+v", plan.Features)
+	}
+	if scenario := plan.Features[0].Scenarios[1]; scenario.TaskID != "t1" || scenario.Priority != 1 {
+		t.Errorf("expected criteria scenarios to keep the task and priority, got %+v", scenario)
+	}
+	if plan.TotalHours() != 3*defaultScenarioHours {
+		t.Errorf("expected %.1f hours, got %.1f", 3*defaultScenarioHours, plan.TotalHours())
+	}
+
+	markdown := plan.Markdown("Shop")
+	for _, want := range []string{"# Test Plan: Shop", "## Backend", "### 1. Verify Cart page", "**Priority:** medium"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", want, markdown)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Voice message limits. Telegram serves bots files up to 20MB, below Whisper's 25MB.
+const (
+	defaultMaxVoiceSeconds = 300
+	MaxVoiceBytes          = 20 * 1024 * 1024
+)
+
+// Transcriber is a speech-to-text provider
+type Transcriber interface {
+	IsConfigured() bool
+	Transcribe(ctx context.Context, filePath, fileName string) (string, error)
+}
+
+type namedTranscriber struct {
+	name        string
+	transcriber Transcriber
+}
+
+// TranscriptionService turns voice messages into text with the first configured provider,
+// or the one named by TRANSCRIPTION_PROVIDER
+type TranscriptionService struct {
+	providers  []namedTranscriber
+	maxSeconds int
+	logger     domain.Logger
+}
+
+// NewTranscriptionService creates a transcription service with all supported providers
+func NewTranscriptionService(logger domain.Logger) *TranscriptionService {
+	return &TranscriptionService{
+		providers: []namedTranscriber{
+			{name: "openai", transcriber: NewWhisperTranscriber(os.Getenv("OPENAI_API_KEY"),
+				"https://api.openai.com/v1/audio/transcriptions", envString("OPENAI_TRANSCRIPTION_MODEL", "whisper-1"))},
+			{name: "groq", transcriber: NewWhisperTranscriber(os.Getenv("GROQ_API_KEY"),
+				"https://api.groq.com/openai/v1/audio/transcriptions", envString("GROQ_TRANSCRIPTION_MODEL", "whisper-large-v3"))},
+		},
+		maxSeconds: envInt("TRANSCRIPTION_MAX_SECONDS", defaultMaxVoiceSeconds),
+		logger:     logger,
+	}
+}
+
+// provider returns the provider in use, or nil when none is configured
+func (s *TranscriptionService) provider() *namedTranscriber {
+	preferred := strings.ToLower(os.Getenv("TRANSCRIPTION_PROVIDER"))
+	for i := range s.providers {
+		if s.providers[i].transcriber.IsConfigured() && (preferred == "" || s.providers[i].name == preferred) {
+			return &s.providers[i]
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether a provider is configured for transcription
+func (s *TranscriptionService) Enabled() bool {
+	return s.provider() != nil
+}
+
+// MaxSeconds returns the longest voice message that is transcribed
+func (s *TranscriptionService) MaxSeconds() int {
+	return s.maxSeconds
+}
+
+// Transcribe returns the text spoken in an audio file
+func (s *TranscriptionService) Transcribe(ctx context.Context, filePath, fileName string) (string, error) {
+	provider := s.provider()
+	if provider == nil {
+		return "", fmt.Errorf("no transcription provider configured")
+	}
+
+	started := time.Now()
+	text, err := provider.transcriber.Transcribe(ctx, filePath, fileName)
+	if err != nil {
+		return "", fmt.Errorf("%s transcription failed: %w", provider.name, err)
+	}
+	s.logger.Info("Voice transcribed", "provider", provider.name, "chars", len(text), "duration", time.Since(started))
+	return strings.TrimSpace(text), nil
+}
+
+// WhisperTranscriber calls an OpenAI-compatible /audio/transcriptions endpoint
+type WhisperTranscriber struct {
+	apiKey     string
+	url        string
+	model      string
+	httpClient *http.Client
+}
+
+// NewWhisperTranscriber creates a Whisper API client; it is unconfigured without a key
+func NewWhisperTranscriber(apiKey, url, model string) *WhisperTranscriber {
+	return &WhisperTranscriber{
+		apiKey:     apiKey,
+		url:        url,
+		model:      model,
+		httpClient: &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+// IsConfigured returns true if an API key is set
+func (w *WhisperTranscriber) IsConfigured() bool {
+	return w.apiKey != ""
+}
+
+// Transcribe uploads the audio file and returns its text
+func (w *WhisperTranscriber) Transcribe(ctx context.Context, filePath, fileName string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open audio: %w", err)
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("model", w.model)
+	writer.WriteField("response_format", "json")
+	if language := os.Getenv("TRANSCRIPTION_LANGUAGE"); language != "" {
+		writer.WriteField("language", language)
+	}
+	part, err := writer.CreateFormFile("file", filepath.Base(fileName))
+	if err != nil {
+		return "", fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return "", fmt.Errorf("failed to read audio: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to finish form: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", w.url, &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+w.apiKey)
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result.Text, nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWhisperTranscriberUploadsAudio(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("expected an audio file: %v", err)
+			return
+		}
+		file.Close()
+		if header.Filename != "voice.ogg" || r.FormValue("model") != "whisper-1" {
+			t.Errorf("unexpected upload %q with model %q", header.Filename, r.FormValue("model"))
+		}
+		w.Write([]byte(`{"text":" Build a login page with OAuth "}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "download.tmp")
+	if err := os.WriteFile(path, []byte("OggS"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	service := &TranscriptionService{
+		providers: []namedTranscriber{
+			{name: "groq", transcriber: NewWhisperTranscriber("", server.URL, "whisper-large-v3")},
+			{name: "openai", transcriber: NewWhisperTranscriber("key", server.URL, "whisper-1")},
+		},
+		logger: silentLogger{},
+	}
+	if !service.Enabled() {
+		t.Fatal("expected the configured provider to be used")
+	}
+
+	text, err := service.Transcribe(context.Background(), path, "voice.ogg")
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if text != "Build a login page with OAuth" {
+		t.Errorf("Transcribe() = %q", text)
+	}
+}
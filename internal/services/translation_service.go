@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// LanguageNames maps supported language codes to display names
+var LanguageNames = map[string]string{
+	"uz": "O'zbek",
+	"ru": "Русский",
+	"en": "English",
+}
+
+// Translation is the result of translating a piece of text
+type Translation struct {
+	Text   string
+	Source string
+	Target string
+}
+
+// Translator translates text into a target language
+type Translator interface {
+	Translate(ctx context.Context, text, source, target string) (string, error)
+	IsConfigured() bool
+}
+
+// AITranslator translates text using the AI provider chain
+type AITranslator struct {
+	ai *AIChain
+}
+
+// NewAITranslator creates a translator backed by the AI chain
+func NewAITranslator(ai *AIChain) *AITranslator {
+	return &AITranslator{ai: ai}
+}
+
+// IsConfigured returns true if an AI provider is available
+func (t *AITranslator) IsConfigured() bool {
+	return t.ai.IsConfigured()
+}
+
+// Translate asks the AI provider to translate the text
+func (t *AITranslator) Translate(ctx context.Context, text, source, target string) (string, error) {
+	prompt := fmt.Sprintf(`Translate the following text from %s to %s.
+Keep technical terms, code, command names and Markdown formatting unchanged.
+Respond with the translation only, without quotes or explanations.
+
+Text:
+%s`, LanguageNames[source], LanguageNames[target], text)
+
+	return t.ai.Complete(ctx, prompt)
+}
+
+// TranslationService detects languages and translates text between uz, ru and en
+type TranslationService struct {
+	translator Translator
+	logger     domain.Logger
+}
+
+// NewTranslationService creates a new translation service
+func NewTranslationService(translator Translator, logger domain.Logger) *TranslationService {
+	return &TranslationService{
+		translator: translator,
+		logger:     logger,
+	}
+}
+
+// IsConfigured returns true if the underlying translator can be used
+func (s *TranslationService) IsConfigured() bool {
+	return s.translator.IsConfigured()
+}
+
+// Translate translates text into the target language, picking a default target when empty
+func (s *TranslationService) Translate(ctx context.Context, text, target string) (*Translation, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("nothing to translate")
+	}
+
+	source := DetectLanguage(text)
+	if target == "" {
+		target = DefaultTargetLanguage(source)
+	}
+	if _, ok := LanguageNames[target]; !ok {
+		return nil, fmt.Errorf("unsupported language %q, use uz, ru or en", target)
+	}
+
+	if source == target {
+		return &Translation{Text: text, Source: source, Target: target}, nil
+	}
+
+	translated, err := s.translator.Translate(ctx, text, source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Text translated", "source", source, "target", target, "length", len(text))
+	return &Translation{Text: translated, Source: source, Target: target}, nil
+}
+
+// DefaultTargetLanguage returns English for non-English text and Uzbek otherwise
+func DefaultTargetLanguage(source string) string {
+	if source == "en" {
+		return "uz"
+	}
+	return "en"
+}
+
+// uzbekLatinMarkers are letter combinations typical for Uzbek Latin script
+var uzbekLatinMarkers = []string{"o'", "g'", "o‘", "g‘", "oʻ", "gʻ"}
+
+// uzbekCommonWords are frequent Uzbek words used to tell Uzbek from English
+var uzbekCommonWords = map[string]bool{
+	"va": true, "bu": true, "uchun": true, "bilan": true, "kerak": true,
+	"qilish": true, "emas": true, "ham": true, "yoki": true, "bor": true,
+	"yo'q": true, "men": true, "biz": true, "siz": true, "loyiha": true,
+}
+
+// DetectLanguage guesses whether text is Uzbek, Russian or English
+func DetectLanguage(text string) string {
+	var cyrillic, latin int
+	uzbekCyrillic := false
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			if strings.ContainsRune("ўқғҳЎҚҒҲ", r) {
+				uzbekCyrillic = true
+			}
+		case unicode.IsLetter(r):
+			latin++
+		}
+	}
+
+	if cyrillic > latin {
+		if uzbekCyrillic {
+			return "uz"
+		}
+		return "ru"
+	}
+
+	lower := strings.ToLower(text)
+	score := 0
+	for _, word := range strings.FieldsFunc(lower, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	}) {
+		if uzbekCommonWords[word] {
+			score += 2
+		}
+	}
+	for _, marker := range uzbekLatinMarkers {
+		score += strings.Count(lower, marker) * 2
+	}
+	// "q" is rare in English outside of "qu"
+	score += strings.Count(lower, "q") - strings.Count(lower, "qu")
+
+	if score >= 2 {
+		return "uz"
+	}
+	return "en"
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+// Layout of the utilization chart in pixels. The y axis runs from 0 to chartMaxUtilization.
+const (
+	chartWidth          = 560
+	chartHeight         = 240
+	chartPadding        = 16
+	chartMaxUtilization = 1.5
+)
+
+// Chart colors, matching the utilization emojis of /workload
+var (
+	chartBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	chartGrid       = color.RGBA{0xdd, 0xdd, 0xdd, 0xff}
+	chartLimit      = color.RGBA{0xcc, 0x33, 0x33, 0xff}
+	chartWeek       = color.RGBA{0xee, 0xee, 0xee, 0xff}
+	chartUnder      = color.RGBA{0x4a, 0x90, 0xd9, 0xff}
+	chartOptimal    = color.RGBA{0x2e, 0x9e, 0x2e, 0xff}
+	chartHigh       = color.RGBA{0xe0, 0xa8, 0x00, 0xff}
+	chartOverloaded = color.RGBA{0xcc, 0x33, 0x33, 0xff}
+)
+
+// RenderUtilizationChart draws one bar per day of the trend period as a PNG. Days without
+// a snapshot stay empty; the red line marks full capacity and the grey line 50%.
+func RenderUtilizationChart(points []domain.UtilizationPoint, start time.Time) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{chartBackground}, image.Point{}, draw.Src)
+
+	plot := image.Rect(chartPadding, chartPadding, chartWidth-chartPadding, chartHeight-chartPadding)
+	slot := plot.Dx() / domain.UtilizationTrendDays
+	yFor := func(utilization float64) int {
+		if utilization > chartMaxUtilization {
+			utilization = chartMaxUtilization
+		}
+		return plot.Max.Y - int(utilization/chartMaxUtilization*float64(plot.Dy()))
+	}
+
+	// Shade every other week so weeks are easy to tell apart
+	for week := 1; week < domain.UtilizationTrendDays/7; week += 2 {
+		x := plot.Min.X + week*7*slot
+		fillRect(img, image.Rect(x, plot.Min.Y, x+7*slot, plot.Max.Y), chartWeek)
+	}
+	fillRect(img, image.Rect(plot.Min.X, yFor(0.5), plot.Max.X, yFor(0.5)+1), chartGrid)
+
+	for _, point := range points {
+		day := time.Date(point.Day.Year(), point.Day.Month(), point.Day.Day(), 0, 0, 0, 0, start.Location())
+		offset := int(day.Sub(start).Hours()/24 + 0.5)
+		if offset < 0 || offset >= domain.UtilizationTrendDays {
+			continue
+		}
+		utilization := point.Utilization()
+		x := plot.Min.X + offset*slot
+		top := yFor(utilization)
+		if top == plot.Max.Y && utilization > 0 {
+			top--
+		}
+		fillRect(img, image.Rect(x+2, top, x+slot-2, plot.Max.Y), utilizationColor(utilization))
+	}
+
+	fillRect(img, image.Rect(plot.Min.X, yFor(1)-1, plot.Max.X, yFor(1)+1), chartLimit)
+	fillRect(img, image.Rect(plot.Min.X, plot.Max.Y, plot.Max.X, plot.Max.Y+1), chartGrid)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// utilizationColor picks a bar color with the thresholds of the /workload emojis
+func utilizationColor(utilization float64) color.Color {
+	switch {
+	case utilization > domain.OverloadThreshold:
+		return chartOverloaded
+	case utilization > 0.75:
+		return chartHigh
+	case utilization > 0.6:
+		return chartOptimal
+	default:
+		return chartUnder
+	}
+}
+
+func fillRect(img draw.Image, rect image.Rectangle, c color.Color) {
+	draw.Draw(img, rect, &image.Uniform{c}, image.Point{}, draw.Src)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"yordamchi-dev-bot/internal/domain"
+)
+
+func TestRenderUtilizationChart(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	points := []domain.UtilizationPoint{
+		{Day: start, Capacity: 40, Allocated: 20},
+		{Day: start.AddDate(0, 0, 10), Capacity: 40, Allocated: 100},
+		{Day: start.AddDate(0, 0, 40), Capacity: 40, Allocated: 40},
+	}
+
+	data, err := RenderUtilizationChart(points, start)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() != chartWidth || bounds.Dy() != chartHeight {
+		t.Errorf("unexpected size %v", bounds)
+	}
+
+	// The bar of day 10 is red; its top is clamped to the chart
+	slot := (chartWidth - 2*chartPadding) / domain.UtilizationTrendDays
+	x := chartPadding + 10*slot + slot/2
+	if r, g, b, _ := img.At(x, chartPadding+1).RGBA(); r>>8 != 0xcc || g>>8 != 0x33 || b>>8 != 0x33 {
+		t.Errorf("expected an overloaded bar at day 10, got %02x%02x%02x", r>>8, g>>8, b>>8)
+	}
+}
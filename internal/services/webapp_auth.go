@@ -0,0 +1,75 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WebAppUser is the Telegram user who opened the Mini App
+type WebAppUser struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Username  string `json:"username"`
+}
+
+// ValidateWebAppInitData checks the signature of the initData a Telegram Mini App receives and
+// returns its user. Data older than maxAge is rejected to limit replay.
+// See https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app
+func ValidateWebAppInitData(initData, botToken string, maxAge time.Duration, now time.Time) (*WebAppUser, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid init data: %w", err)
+	}
+
+	hash := values.Get("hash")
+	if hash == "" {
+		return nil, fmt.Errorf("init data is not signed")
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if key != "hash" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = key + "=" + values.Get(key)
+	}
+
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(botToken))
+	signature := hmac.New(sha256.New, secret.Sum(nil))
+	signature.Write([]byte(strings.Join(lines, "\n")))
+
+	expected, err := hex.DecodeString(hash)
+	if err != nil || !hmac.Equal(signature.Sum(nil), expected) {
+		return nil, fmt.Errorf("init data signature mismatch")
+	}
+
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("init data has no auth date")
+	}
+	if now.Sub(time.Unix(authDate, 0)) > maxAge {
+		return nil, fmt.Errorf("init data expired")
+	}
+
+	var user WebAppUser
+	if err := json.Unmarshal([]byte(values.Get("user")), &user); err != nil || user.ID == 0 {
+		return nil, fmt.Errorf("init data has no user")
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// signInitData builds initData signed the way Telegram does
+func signInitData(values url.Values, botToken string) string {
+	keys := []string{"auth_date", "query_id", "user"}
+	check := ""
+	for i, key := range keys {
+		if i > 0 {
+			check += "\n"
+		}
+		check += key + "=" + values.Get(key)
+	}
+
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(botToken))
+	signature := hmac.New(sha256.New, secret.Sum(nil))
+	signature.Write([]byte(check))
+
+	values.Set("hash", hex.EncodeToString(signature.Sum(nil)))
+	return values.Encode()
+}
+
+func TestValidateWebAppInitData(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	values := url.Values{}
+	values.Set("auth_date", strconv.FormatInt(now.Add(-time.Minute).Unix(), 10))
+	values.Set("query_id", "AAH")
+	values.Set("user", `{"id":42,"first_name":"Ali","username":"ali"}`)
+	initData := signInitData(values, "123:token")
+
+	user, err := ValidateWebAppInitData(initData, "123:token", time.Hour, now)
+	if err != nil {
+		t.Fatalf("Expected valid init data, got %v", err)
+	}
+	if user.ID != 42 || user.Username != "ali" {
+		t.Errorf("Unexpected user %+v", user)
+	}
+
+	if _, err := ValidateWebAppInitData(initData, "other:token", time.Hour, now); err == nil {
+		t.Error("Expected a signature mismatch for another bot token")
+	}
+
+	tampered, _ := url.ParseQuery(initData)
+	tampered.Set("user", `{"id":43,"first_name":"Eve"}`)
+	if _, err := ValidateWebAppInitData(tampered.Encode(), "123:token", time.Hour, now); err == nil {
+		t.Error("Expected tampered init data to be rejected")
+	}
+
+	if _, err := ValidateWebAppInitData(initData, "123:token", time.Hour, now.Add(2*time.Hour)); err == nil {
+		t.Error("Expected expired init data to be rejected")
+	}
+}
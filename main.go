@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/joho/godotenv"
 	
@@ -69,7 +72,33 @@ func main() {
 	log.Printf("🤖 %s (v%s) starting with clean architecture on port %s", 
 		config.Bot.Name, config.Bot.Version, appPort)
 	
-	if err := bot.Start(appPort); err != nil {
-		log.Fatalf("Failed to start bot server: %v", err)
+	// Stop on SIGTERM (docker stop, systemd) or Ctrl+C after finishing the work in progress
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() { serverErr <- bot.Start(appPort) }()
+
+	select {
+	case err := <-serverErr:
+		if err != nil {
+			// log.Fatalf would skip closing the database
+			log.Printf("Failed to start bot server: %v", err)
+			db.Close()
+			os.Exit(1)
+		}
+	case <-ctx.Done():
+		stop()
+		log.Println("🛑 Shutdown signal received, finishing work in progress")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), app.ShutdownTimeout())
+		defer cancel()
+		if err := bot.Shutdown(shutdownCtx); err != nil {
+			log.Printf("⚠️ Shutdown incomplete: %v", err)
+		}
+		if err := <-serverErr; err != nil {
+			log.Printf("Bot server error: %v", err)
+		}
 	}
+	log.Println("👋 Bot stopped")
 }
\ No newline at end of file